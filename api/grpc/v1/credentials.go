@@ -0,0 +1,34 @@
+package grpcv1
+
+import (
+	"context"
+
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	// AuthorizationMetadataKey is the key of the metadata that contains the token of a call
+	AuthorizationMetadataKey = "authorization"
+	// BearerPrefix precedes the token in the authorization metadata
+	BearerPrefix = "Bearer "
+)
+
+type tokenCredentials struct {
+	token    string
+	insecure bool
+}
+
+// TokenCredentials returns credentials that add the given token to the metadata of every call. They can be used with
+// grpc.WithPerRPCCredentials. The token is only sent over connections with transport security, unless insecure is
+// true, e.g. for a server that is only reachable via a port-forward.
+func TokenCredentials(token string, insecure bool) credentials.PerRPCCredentials {
+	return &tokenCredentials{token: token, insecure: insecure}
+}
+
+func (c *tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{AuthorizationMetadataKey: BearerPrefix + c.token}, nil
+}
+
+func (c *tokenCredentials) RequireTransportSecurity() bool {
+	return !c.insecure
+}
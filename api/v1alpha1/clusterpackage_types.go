@@ -18,6 +18,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -55,6 +57,22 @@ func (in *ClusterPackage) SetAutoUpdatesEnabled(enabled bool) {
 	setAutoUpdatesEnabled(&in.ObjectMeta, enabled)
 }
 
+func (pkg *ClusterPackage) VersionConstraint() string {
+	return versionConstraint(pkg.ObjectMeta)
+}
+
+func (pkg *ClusterPackage) SetVersionConstraint(constraint string) {
+	setVersionConstraint(&pkg.ObjectMeta, constraint)
+}
+
+func (pkg *ClusterPackage) UpdateNotifiedVersion() string {
+	return updateNotifiedVersion(pkg.ObjectMeta)
+}
+
+func (pkg *ClusterPackage) SetUpdateNotifiedVersion(version string) {
+	setUpdateNotifiedVersion(&pkg.ObjectMeta, version)
+}
+
 func (pkg *ClusterPackage) InstalledAsDependency() bool {
 	return installedAsDependency(pkg.ObjectMeta)
 }
@@ -63,6 +81,43 @@ func (pkg *ClusterPackage) SetInstalledAsDependency(value bool) {
 	setInstalledAsDependency(&pkg.ObjectMeta, value)
 }
 
+func (pkg *ClusterPackage) IsPaused() bool {
+	return paused(pkg.ObjectMeta)
+}
+
+func (pkg *ClusterPackage) SetPaused(value bool) {
+	setPaused(&pkg.ObjectMeta, value)
+}
+
+func (pkg *ClusterPackage) RetryRequestedAt() (time.Time, bool) {
+	return retryRequestedAt(pkg.ObjectMeta)
+}
+
+func (pkg *ClusterPackage) RequestRetry(now time.Time) {
+	requestRetry(&pkg.ObjectMeta, now)
+}
+
+// IsRetryRequested returns true if a retry of the failed resources was requested after the last retry
+func (pkg *ClusterPackage) IsRetryRequested() bool {
+	requestedAt, ok := pkg.RetryRequestedAt()
+	return ok && (len(pkg.Status.FailedResources) > 0 || pkg.Status.FailedHook() != nil) &&
+		(pkg.Status.LastRetryTime == nil || requestedAt.After(pkg.Status.LastRetryTime.Time))
+}
+
+func (pkg *ClusterPackage) RecreateRequestedAt() (time.Time, bool) {
+	return recreateRequestedAt(pkg.ObjectMeta)
+}
+
+func (pkg *ClusterPackage) RequestRecreate(now time.Time) {
+	requestRecreate(&pkg.ObjectMeta, now)
+}
+
+// IsRecreateRequested returns true if a recreation of the resources with immutable changes was requested after the
+// last retry
+func (pkg *ClusterPackage) IsRecreateRequested() bool {
+	return isRecreateRequested(pkg.ObjectMeta, pkg.Status.LastRetryTime)
+}
+
 func (pkg *ClusterPackage) IsNamespaceScoped() bool {
 	return false
 }
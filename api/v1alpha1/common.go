@@ -6,7 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -32,6 +34,24 @@ func setAutoUpdatesEnabled(obj *metav1.ObjectMeta, enabled bool) {
 	}
 }
 
+func versionConstraint(obj metav1.ObjectMeta) string {
+	if obj.Annotations == nil {
+		return ""
+	}
+	return obj.Annotations[AnnotationVersionConstraint]
+}
+
+func setVersionConstraint(obj *metav1.ObjectMeta, constraint string) {
+	if obj.Annotations == nil {
+		obj.Annotations = make(map[string]string)
+	}
+	if constraint != "" {
+		obj.Annotations[AnnotationVersionConstraint] = constraint
+	} else {
+		delete(obj.Annotations, AnnotationVersionConstraint)
+	}
+}
+
 func installedAsDependency(obj metav1.ObjectMeta) bool {
 	if obj.Annotations == nil {
 		return false
@@ -54,6 +74,82 @@ func setInstalledAsDependency(obj *metav1.ObjectMeta, value bool) {
 	}
 }
 
+func paused(obj metav1.ObjectMeta) bool {
+	if obj.Annotations == nil {
+		return false
+	}
+	value, _ := strconv.ParseBool(obj.Annotations[AnnotationPause])
+	return value
+}
+
+func setPaused(obj *metav1.ObjectMeta, value bool) {
+	if obj.Annotations == nil {
+		obj.Annotations = make(map[string]string)
+	}
+	if value {
+		obj.Annotations[AnnotationPause] = strconv.FormatBool(true)
+	} else {
+		delete(obj.Annotations, AnnotationPause)
+	}
+}
+
+func retryRequestedAt(obj metav1.ObjectMeta) (time.Time, bool) {
+	if value, ok := obj.Annotations[AnnotationRetryRequested]; ok {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func requestRetry(obj *metav1.ObjectMeta, now time.Time) {
+	if obj.Annotations == nil {
+		obj.Annotations = make(map[string]string)
+	}
+	obj.Annotations[AnnotationRetryRequested] = now.UTC().Format(time.RFC3339)
+}
+
+func recreateRequestedAt(obj metav1.ObjectMeta) (time.Time, bool) {
+	if value, ok := obj.Annotations[AnnotationRecreateRequested]; ok {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func requestRecreate(obj *metav1.ObjectMeta, now time.Time) {
+	if obj.Annotations == nil {
+		obj.Annotations = make(map[string]string)
+	}
+	obj.Annotations[AnnotationRecreateRequested] = now.UTC().Format(time.RFC3339)
+}
+
+// isRecreateRequested returns true if a recreation was requested after the last retry. Unlike a retry, it does not
+// require failed resources, so that it can be requested together with the change that makes the recreation necessary.
+func isRecreateRequested(obj metav1.ObjectMeta, lastRetryTime *metav1.Time) bool {
+	requestedAt, ok := recreateRequestedAt(obj)
+	return ok && (lastRetryTime == nil || requestedAt.After(lastRetryTime.Time))
+}
+
+func updateNotifiedVersion(obj metav1.ObjectMeta) string {
+	if obj.Annotations == nil {
+		return ""
+	}
+	return obj.Annotations[AnnotationUpdateNotifiedVersion]
+}
+
+func setUpdateNotifiedVersion(obj *metav1.ObjectMeta, version string) {
+	if obj.Annotations == nil {
+		obj.Annotations = make(map[string]string)
+	}
+	if version != "" {
+		obj.Annotations[AnnotationUpdateNotifiedVersion] = version
+	} else {
+		delete(obj.Annotations, AnnotationUpdateNotifiedVersion)
+	}
+}
+
 type PackageInfoTemplate struct {
 	// Name of the package to install
 	Name string `json:"name"`
@@ -61,6 +157,19 @@ type PackageInfoTemplate struct {
 	Version string `json:"version"`
 	// RepositoryName is the name of the repository to pull the package from (optional)
 	RepositoryName string `json:"repositoryName,omitempty"`
+	// Digest of the package manifest, e.g. "sha256:…" (optional). If set, the package is only installed if the
+	// manifest fetched from the repository has this digest.
+	// +kubebuilder:validation:Pattern=`^sha256:[a-f0-9]{64}$`
+	Digest string `json:"digest,omitempty"`
+}
+
+// SetVersion sets the version of the package to install. A pinned digest is removed if the version changes, because it
+// can only match the manifest of the previous version.
+func (t *PackageInfoTemplate) SetVersion(version string) {
+	if t.Version != version {
+		t.Digest = ""
+	}
+	t.Version = version
 }
 
 type ObjectKeyValueSource struct {
@@ -91,6 +200,38 @@ type InlineValueConfiguration struct {
 type ValueConfiguration struct {
 	InlineValueConfiguration `json:",inline"`
 	ValueFrom                *ValueReference `json:"valueFrom,omitempty"`
+	// Template is a Go template that is evaluated whenever the package is rendered. Only a restricted set of
+	// functions is available, e.g. to read cluster facts or values of other packages.
+	Template *string `json:"template,omitempty"`
+}
+
+// ImageRegistryMirror replaces the registry of container images with a mirror
+type ImageRegistryMirror struct {
+	// Registry is the registry of the images that should be replaced, optionally followed by a path prefix,
+	// e.g. "docker.io" or "ghcr.io/glasskube". Images without a registry are treated as "docker.io" images.
+	Registry string `json:"registry"`
+	// Mirror replaces Registry in the references of all matching images, e.g. "myregistry.internal/dockerhub"
+	Mirror string `json:"mirror"`
+}
+
+// SchedulingOverrides are merged into the pod templates of all workloads of a package, e.g. to run them on GPU nodes.
+// They are merged with the semantics of a strategic merge patch of the pod spec.
+type SchedulingOverrides struct {
+	// NodeSelector is merged into the node selector of every pod template
+	//
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations replace the tolerations of every pod template
+	//
+	// +kubebuilder:validation:Optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// Affinity is merged into the affinity of every pod template
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
 }
 
 // PackageSpec defines the desired state
@@ -98,6 +239,30 @@ type PackageSpec struct {
 	PackageInfo PackageInfoTemplate           `json:"packageInfo"`
 	Values      map[string]ValueConfiguration `json:"values,omitempty"`
 
+	// Overlays contain values for single environments, keyed by the name of the environment. The overlay of the
+	// environment of the cluster, which is set in the cluster info ConfigMap, takes precedence over Values. This way,
+	// the same base configuration can be used for several clusters, e.g. for dev, staging and prod.
+	//
+	// +kubebuilder:validation:Optional
+	Overlays map[string]map[string]ValueConfiguration `json:"overlays,omitempty"`
+
+	// ImageRegistryMirrors are applied to the images of all workloads of this package, in addition to the mirrors
+	// that are configured for the whole cluster. If both match an image, the mirror configured here is used.
+	//
+	// +kubebuilder:validation:Optional
+	ImageRegistryMirrors []ImageRegistryMirror `json:"imageRegistryMirrors,omitempty"`
+
+	// OptionalDependencies are the names of the optional dependencies of the package manifest that should be
+	// installed. Optional dependencies that are not listed here are not installed.
+	//
+	// +kubebuilder:validation:Optional
+	OptionalDependencies []string `json:"optionalDependencies,omitempty"`
+
+	// Scheduling overrides the node selector, tolerations and affinity of all workloads of this package
+	//
+	// +kubebuilder:validation:Optional
+	Scheduling *SchedulingOverrides `json:"scheduling,omitempty"`
+
 	// Suspend indicates that reconciliation of this resource should be suspended.
 	//
 	// +kubebuilder:validation:Optional
@@ -120,4 +285,87 @@ type PackageStatus struct {
 	OwnedResources    []OwnedResourceRef `json:"ownedResources,omitempty"`
 	OwnedPackageInfos []OwnedResourceRef `json:"ownedPackageInfos,omitempty"`
 	OwnedPackages     []OwnedResourceRef `json:"ownedPackages,omitempty"`
+	// Revisions contains the most recent successfully installed versions and configurations of the package,
+	// ordered from oldest to newest.
+	Revisions []PackageRevision `json:"revisions,omitempty"`
+	// Digest of the manifest of the installed version
+	Digest string `json:"digest,omitempty"`
+	// FailedResources are the resources that could not be applied in the last reconciliation. All other resources
+	// of the package are applied nonetheless.
+	FailedResources []FailedResourceRef `json:"failedResources,omitempty"`
+	// LastRetryTime is the time at which the operator handled the last retry that was requested for the failed
+	// resources
+	LastRetryTime *metav1.Time `json:"lastRetryTime,omitempty"`
+	// Hooks are the hooks that have been run for the current version
+	Hooks []HookStatus `json:"hooks,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=PreInstall;PostInstall
+type HookPhase string
+
+const (
+	HookPhasePreInstall  HookPhase = "PreInstall"
+	HookPhasePostInstall HookPhase = "PostInstall"
+)
+
+// +kubebuilder:validation:Enum=Running;Succeeded;Failed
+type HookState string
+
+const (
+	HookRunning   HookState = "Running"
+	HookSucceeded HookState = "Succeeded"
+	HookFailed    HookState = "Failed"
+)
+
+// HookStatus is the status of the Job of a hook (see PackageHooks). The Job is kept until the hooks of the next
+// version are run, so that its logs can be inspected.
+type HookStatus struct {
+	Name  string    `json:"name"`
+	Phase HookPhase `json:"phase"`
+	// Version is the version of the package the hook has been run for
+	Version      string    `json:"version"`
+	JobName      string    `json:"jobName"`
+	JobNamespace string    `json:"jobNamespace"`
+	State        HookState `json:"state"`
+	// Message is the reason why the Job failed
+	Message string `json:"message,omitempty"`
+	// Attempt counts how often the hook has been retried after it failed
+	Attempt int32 `json:"attempt,omitempty"`
+}
+
+// FailedHook returns the status of the hook that failed, or nil if no hook failed
+func (status *PackageStatus) FailedHook() *HookStatus {
+	for i := range status.Hooks {
+		if status.Hooks[i].State == HookFailed {
+			return &status.Hooks[i]
+		}
+	}
+	return nil
+}
+
+// FailedResourceRef is a resource of a package that could not be applied, e.g. because it was rejected by an
+// admission webhook
+type FailedResourceRef struct {
+	metav1.GroupVersionKind `json:",inline"`
+	Name                    string `json:"name"`
+	Namespace               string `json:"namespace,omitempty"`
+	// Message is the error that was returned when the resource was applied
+	Message string `json:"message"`
+	// Immutable is true if the resource was rejected because an immutable field changed. It can only be applied by
+	// recreating it, see AnnotationRecreateRequested.
+	Immutable bool `json:"immutable,omitempty"`
+}
+
+func (ref FailedResourceRef) String() string {
+	return ref.GroupVersionKind.String() + " Namespace=" + ref.Namespace + " Name=" + ref.Name
+}
+
+// PackageRevision is a version and configuration of a package that was successfully installed
+type PackageRevision struct {
+	// Revision is incremented for every new revision of a package
+	Revision int64                         `json:"revision"`
+	Version  string                        `json:"version"`
+	Values   map[string]ValueConfiguration `json:"values,omitempty"`
+	// InstalledAt is the time at which this revision was first reconciled successfully
+	InstalledAt metav1.Time `json:"installedAt"`
 }
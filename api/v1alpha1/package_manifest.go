@@ -69,6 +69,26 @@ type PlainManifest struct {
 	DefaultNamespace string `json:"defaultNamespace,omitempty"`
 }
 
+// PackageHooks are Jobs that are run when a version of a package is installed, similar to Helm hooks. Every hook is
+// run once per version.
+type PackageHooks struct {
+	// PreInstall hooks are run one after another before the manifests are applied, e.g. to migrate a database. The
+	// manifests are only applied after all of them have completed successfully.
+	PreInstall []PackageHook `json:"preInstall,omitempty"`
+	// PostInstall hooks are run one after another after all resources of the package are ready, e.g. to verify the
+	// installation. The package only becomes ready after all of them have completed successfully.
+	PostInstall []PackageHook `json:"postInstall,omitempty"`
+}
+
+type PackageHook struct {
+	// Name identifies the hook. It must be unique among the hooks of the same phase.
+	Name string `json:"name" jsonschema:"required"`
+	// Url is the location of a manifest that contains a single Job. Like the Url of a PlainManifest, it may be a path
+	// relative to the packages "package.yaml" file. Values, image registry mirrors and scheduling overrides are
+	// applied to the Job like to any other resource of the package.
+	Url string `json:"url" jsonschema:"required"`
+}
+
 type PackageReference struct {
 	Label string `json:"label" jsonschema:"required"`
 	Url   string `json:"url" jsonschema:"required"`
@@ -77,6 +97,9 @@ type PackageReference struct {
 type Dependency struct {
 	Name    string `json:"name" jsonschema:"required"`
 	Version string `json:"version,omitempty"`
+	// Optional dependencies are only installed if they are enabled in the spec of the package
+	// (see PackageSpec.OptionalDependencies). Otherwise, they are ignored.
+	Optional bool `json:"optional,omitempty"`
 }
 
 type Component struct {
@@ -140,12 +163,21 @@ type PackageManifest struct {
 	LongDescription  string             `json:"longDescription,omitempty"`
 	References       []PackageReference `json:"references,omitempty"`
 	IconUrl          string             `json:"iconUrl,omitempty" jsonschema:"format=uri"`
+	// Keywords are used to find this package when searching for packages.
+	Keywords []string `json:"keywords,omitempty"`
+	// Categories are used to group and filter packages.
+	Categories []string `json:"categories,omitempty"`
 	// Helm instructs the controller to create a helm release when installing this package.
 	Helm *HelmManifest `json:"helm,omitempty"`
 	// Kustomize instructs the controller to apply a kustomization when installing this package [PLACEHOLDER].
-	Kustomize           *KustomizeManifest                 `json:"kustomize,omitempty"`
-	Manifests           []PlainManifest                    `json:"manifests,omitempty"`
-	ValueDefinitions    map[string]ValueDefinition         `json:"valueDefinitions,omitempty"`
+	Kustomize *KustomizeManifest `json:"kustomize,omitempty"`
+	Manifests []PlainManifest    `json:"manifests,omitempty"`
+	// Hooks are Jobs that are run before and after the manifests of a version are applied.
+	Hooks            *PackageHooks              `json:"hooks,omitempty"`
+	ValueDefinitions map[string]ValueDefinition `json:"valueDefinitions,omitempty"`
+	// ValueGroups are the sections of the configuration form, in the order in which they are shown. Values are assigned
+	// to a group with metadata.group.
+	ValueGroups         []ValueGroup                       `json:"valueGroups,omitempty"`
 	Transformations     []TransformationDefinition         `json:"transformations,omitempty"`
 	TransitiveResources []corev1.TypedLocalObjectReference `json:"transitiveResources,omitempty"`
 	// DefaultNamespace to install the package. May be overridden.
@@ -153,4 +185,12 @@ type PackageManifest struct {
 	Entrypoints      []PackageEntrypoint `json:"entrypoints,omitempty"`
 	Dependencies     []Dependency        `json:"dependencies,omitempty"`
 	Components       []Component         `json:"components,omitempty"`
+	// ReleaseNotes describe the changes of this version of the package, formatted as markdown.
+	ReleaseNotes string `json:"releaseNotes,omitempty"`
+	// ReleaseNotesUrl links to the release notes of this version, e.g. if they are not embedded in the manifest.
+	ReleaseNotesUrl string `json:"releaseNotesUrl,omitempty" jsonschema:"format=uri"`
+	// PostInstallNotes are shown to users after the package has been installed, formatted as markdown. They are a
+	// template with the same functions as value templates and have access to the package, its values and the
+	// cluster info, e.g. {{ .Package.Namespace }} or {{ .Values.host }}.
+	PostInstallNotes string `json:"postInstallNotes,omitempty"`
 }
@@ -48,10 +48,42 @@ func (ref *ValueType) UnmarshalJson(data []byte) error {
 	return ref.parseString(s)
 }
 
+type ValueFormat string
+
+const (
+	ValueFormatEmail    ValueFormat = "email"
+	ValueFormatURI      ValueFormat = "uri"
+	ValueFormatHostname ValueFormat = "hostname"
+	ValueFormatIPv4     ValueFormat = "ipv4"
+	ValueFormatIPv6     ValueFormat = "ipv6"
+)
+
+func (ValueFormat) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type: "string",
+		Enum: []any{ValueFormatEmail, ValueFormatURI, ValueFormatHostname, ValueFormatIPv4, ValueFormatIPv6},
+	}
+}
+
 type ValueDefinitionMetadata struct {
 	Label       string   `json:"label,omitempty"`
 	Description string   `json:"description,omitempty"`
 	Hints       []string `json:"hints,omitempty"`
+	// Group is the name of the value group (see PackageManifest.ValueGroups) that the value is shown in. Required
+	// values are always shown in the essential group instead.
+	Group string `json:"group,omitempty"`
+	// Order determines the position of the value within its group. Values with a lower order come first, values with
+	// the same order are sorted by name.
+	Order int `json:"order,omitempty"`
+}
+
+// ValueGroup is a section of the configuration form that contains related values
+type ValueGroup struct {
+	Name        string `json:"name" jsonschema:"required"`
+	Label       string `json:"label,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Collapsed groups are only expanded on demand, e.g. for advanced values that rarely need to be changed.
+	Collapsed bool `json:"collapsed,omitempty"`
 }
 
 type ValueDefinitionConstraints struct {
@@ -61,6 +93,10 @@ type ValueDefinitionConstraints struct {
 	MinLength *int    `json:"minLength,omitempty"`
 	MaxLength *int    `json:"maxLength,omitempty"`
 	Pattern   *string `json:"pattern,omitempty"`
+	// Format restricts text values to a well-known format, like the "format" keyword of JSON schema. It also determines
+	// the type of the input in the UI.
+	// +kubebuilder:validation:Enum=email;uri;hostname;ipv4;ipv6
+	Format *ValueFormat `json:"format,omitempty"`
 }
 
 type PartialJsonPatch struct {
@@ -77,10 +113,13 @@ type ValueDefinitionTarget struct {
 }
 
 type ValueDefinition struct {
-	Type         ValueType                  `json:"type" jsonschema:"required"`
-	Metadata     ValueDefinitionMetadata    `json:"metadata,omitempty"`
-	DefaultValue string                     `json:"defaultValue,omitempty"`
-	Options      []string                   `json:"options,omitempty"`
-	Constraints  ValueDefinitionConstraints `json:"constraints,omitempty"`
-	Targets      []ValueDefinitionTarget    `json:"targets" jsonschema:"required"`
+	Type         ValueType               `json:"type" jsonschema:"required"`
+	Metadata     ValueDefinitionMetadata `json:"metadata,omitempty"`
+	DefaultValue string                  `json:"defaultValue,omitempty"`
+	// ClusterDefault is the key of a cluster-wide default in the cluster info ConfigMap. If it is set in the cluster,
+	// it is used instead of DefaultValue.
+	ClusterDefault string                     `json:"clusterDefault,omitempty"`
+	Options        []string                   `json:"options,omitempty"`
+	Constraints    ValueDefinitionConstraints `json:"constraints,omitempty"`
+	Targets        []ValueDefinitionTarget    `json:"targets" jsonschema:"required"`
 }
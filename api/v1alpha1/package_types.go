@@ -18,6 +18,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -55,6 +57,22 @@ func (pkg *Package) SetAutoUpdatesEnabled(enabled bool) {
 	setAutoUpdatesEnabled(&pkg.ObjectMeta, enabled)
 }
 
+func (pkg *Package) VersionConstraint() string {
+	return versionConstraint(pkg.ObjectMeta)
+}
+
+func (pkg *Package) SetVersionConstraint(constraint string) {
+	setVersionConstraint(&pkg.ObjectMeta, constraint)
+}
+
+func (pkg *Package) UpdateNotifiedVersion() string {
+	return updateNotifiedVersion(pkg.ObjectMeta)
+}
+
+func (pkg *Package) SetUpdateNotifiedVersion(version string) {
+	setUpdateNotifiedVersion(&pkg.ObjectMeta, version)
+}
+
 func (pkg *Package) InstalledAsDependency() bool {
 	return installedAsDependency(pkg.ObjectMeta)
 }
@@ -63,6 +81,43 @@ func (pkg *Package) SetInstalledAsDependency(value bool) {
 	setInstalledAsDependency(&pkg.ObjectMeta, value)
 }
 
+func (pkg *Package) IsPaused() bool {
+	return paused(pkg.ObjectMeta)
+}
+
+func (pkg *Package) SetPaused(value bool) {
+	setPaused(&pkg.ObjectMeta, value)
+}
+
+func (pkg *Package) RetryRequestedAt() (time.Time, bool) {
+	return retryRequestedAt(pkg.ObjectMeta)
+}
+
+func (pkg *Package) RequestRetry(now time.Time) {
+	requestRetry(&pkg.ObjectMeta, now)
+}
+
+// IsRetryRequested returns true if a retry of the failed resources was requested after the last retry
+func (pkg *Package) IsRetryRequested() bool {
+	requestedAt, ok := pkg.RetryRequestedAt()
+	return ok && (len(pkg.Status.FailedResources) > 0 || pkg.Status.FailedHook() != nil) &&
+		(pkg.Status.LastRetryTime == nil || requestedAt.After(pkg.Status.LastRetryTime.Time))
+}
+
+func (pkg *Package) RecreateRequestedAt() (time.Time, bool) {
+	return recreateRequestedAt(pkg.ObjectMeta)
+}
+
+func (pkg *Package) RequestRecreate(now time.Time) {
+	requestRecreate(&pkg.ObjectMeta, now)
+}
+
+// IsRecreateRequested returns true if a recreation of the resources with immutable changes was requested after the
+// last retry
+func (pkg *Package) IsRecreateRequested() bool {
+	return isRecreateRequested(pkg.ObjectMeta, pkg.Status.LastRetryTime)
+}
+
 func (pkg *Package) IsNamespaceScoped() bool {
 	return true
 }
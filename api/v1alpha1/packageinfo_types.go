@@ -29,6 +29,7 @@ type PackageInfoSpec struct {
 	Name           string `json:"name"`
 	Version        string `json:"version,omitempty"`
 	RepositoryName string `json:"repositoryUrl,omitempty"`
+	Digest         string `json:"digest,omitempty"`
 }
 
 // PackageInfoStatus defines the observed state of PackageInfo
@@ -38,6 +39,8 @@ type PackageInfoStatus struct {
 	Conditions          []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 	LastUpdateTimestamp *metav1.Time       `json:"lastUpdateTimestamp,omitempty"`
 	Version             string             `json:"version,omitempty"`
+	// Digest of the fetched manifest
+	Digest string `json:"digest,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,65 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PackageProfileSpec defines a reusable configuration of a package
+type PackageProfileSpec struct {
+	// PackageName is the name of the package (as in the package repository) this profile configures.
+	PackageName string `json:"packageName"`
+	// PackageVersion is the version of the package the profile was created for. Values of the profile might not apply
+	// to other versions of the package.
+	PackageVersion string `json:"packageVersion"`
+	// RepositoryName is the repository of the package the profile was created for.
+	RepositoryName string                        `json:"repositoryName,omitempty"`
+	Values         map[string]ValueConfiguration `json:"values,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster,shortName=pkgprof
+//+kubebuilder:printcolumn:name="Package",type=string,JSONPath=".spec.packageName"
+//+kubebuilder:printcolumn:name="Version",type=string,JSONPath=".spec.packageVersion"
+
+// PackageProfile is the Schema for the packageprofiles API. A profile is a named set of values for a package, that can
+// be reused when installing the package.
+type PackageProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PackageProfileSpec `json:"spec,omitempty"`
+}
+
+// IsForVersion returns true if the profile was created for the given version of the package
+func (profile PackageProfile) IsForVersion(version string) bool {
+	return profile.Spec.PackageVersion == version
+}
+
+//+kubebuilder:object:root=true
+
+// PackageProfileList contains a list of PackageProfile
+type PackageProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PackageProfile `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PackageProfile{}, &PackageProfileList{})
+}
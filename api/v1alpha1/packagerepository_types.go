@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"strings"
+	"time"
 
 	"github.com/glasskube/glasskube/internal/constants"
 	corev1 "k8s.io/api/core/v1"
@@ -44,6 +45,82 @@ type PackageRepositoryAuthSpec struct {
 	Bearer *PackageRepositoryBearerAuthSpec `json:"bearer,omitempty"`
 }
 
+// PackageRepositoryGitSpec configures a package repository that is read from a git repository instead of being
+// served over HTTP. The repository is cloned from the Url of the PackageRepositorySpec.
+type PackageRepositoryGitSpec struct {
+	// Ref is the branch, tag or commit SHA to check out. If it is empty, the default branch of the remote is used.
+	Ref string `json:"ref,omitempty"`
+	// Path is the directory within the git repository that contains the index.yaml of the package repository.
+	Path string `json:"path,omitempty"`
+}
+
+// PackageRepositoryHelmSpec configures a repository that is a Helm chart repository instead of a glasskube package
+// repository. Every chart of the repository is presented as a package.
+type PackageRepositoryHelmSpec struct {
+	// Charts restricts the packages of the repository to the charts with the given names. If it is empty, all charts
+	// of the repository are available.
+	Charts []string `json:"charts,omitempty"`
+}
+
+// PackageRepositoryTLSSpec configures the TLS connection to a package repository that is served over HTTPS. All
+// referenced Secrets must be in the glasskube-system namespace.
+type PackageRepositoryTLSSpec struct {
+	// CABundle contains PEM encoded CA certificates that are trusted in addition to the system trust store.
+	CABundle string `json:"caBundle,omitempty"`
+	// CABundleSecretRef references a key of a Secret that contains PEM encoded CA certificates that are trusted in
+	// addition to the system trust store.
+	CABundleSecretRef *corev1.SecretKeySelector `json:"caBundleSecretRef,omitempty"`
+	// ClientCertificateSecretRef references a Secret of type kubernetes.io/tls. Its certificate and key are presented
+	// to the repository for mutual TLS authentication.
+	ClientCertificateSecretRef *corev1.LocalObjectReference `json:"clientCertificateSecretRef,omitempty"`
+}
+
+// PackageRepositoryProxySpec configures the proxy through which a package repository is accessed. It takes precedence
+// over the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables of the operator. Referenced Secrets must be in
+// the glasskube-system namespace.
+type PackageRepositoryProxySpec struct {
+	// Url of an HTTP, HTTPS or SOCKS5 proxy, e.g. http://proxy.example.com:3128 or socks5://proxy.example.com:1080.
+	// Credentials for the proxy can be included in the Url. If neither Url nor UrlSecretRef is set, the repository is
+	// accessed without a proxy, regardless of the environment.
+	Url string `json:"url,omitempty"`
+	// UrlSecretRef references a key of a Secret that contains the Url of the proxy, e.g. because it contains
+	// credentials. It takes precedence over Url.
+	UrlSecretRef *corev1.SecretKeySelector `json:"urlSecretRef,omitempty"`
+}
+
+// PackageRepositorySignatureSpec configures how the signatures of the package manifests in a repository are verified.
+// Signatures are created with "cosign sign-blob" and stored next to the manifest, see the documentation for details.
+type PackageRepositorySignatureSpec struct {
+	// Required rejects package manifests that are not signed or whose signature is invalid. If it is false, such
+	// packages can be installed, but are shown with a warning.
+	Required bool `json:"required,omitempty"`
+	// PublicKeys are PEM encoded public keys (ECDSA, Ed25519 or RSA). Signatures made with any of them are trusted.
+	PublicKeys []string `json:"publicKeys,omitempty"`
+	// Keyless trusts signatures made with a short-lived certificate that was issued to one of the given identities.
+	Keyless *PackageRepositoryKeylessSpec `json:"keyless,omitempty"`
+}
+
+// PackageRepositoryKeylessSpec is a policy for signatures that were created with "keyless" signing, where the signer
+// gets a short-lived certificate for their identity from a certificate authority (like Sigstore Fulcio) and the
+// signature is recorded in a transparency log (like Sigstore Rekor).
+type PackageRepositoryKeylessSpec struct {
+	// Identities are the signers that are trusted. A signature must match at least one of them.
+	Identities []PackageRepositoryKeylessIdentity `json:"identities"`
+	// RootCertificates are the PEM encoded certificates of the certificate authority that issues signing certificates.
+	RootCertificates string `json:"rootCertificates"`
+	// TransparencyLogPublicKey is the PEM encoded public key of the transparency log. It is needed to prove that the
+	// signature was created while the short-lived certificate was valid.
+	TransparencyLogPublicKey string `json:"transparencyLogPublicKey"`
+}
+
+// PackageRepositoryKeylessIdentity identifies the signer of a keyless signature.
+type PackageRepositoryKeylessIdentity struct {
+	// Issuer is the OIDC issuer that authenticated the signer, e.g. https://token.actions.githubusercontent.com.
+	Issuer string `json:"issuer"`
+	// Subject is the email address or URI of the signer, as contained in the certificate.
+	Subject string `json:"subject"`
+}
+
 // PackageRepositorySpec defines the desired state of PackageRepository
 type PackageRepositorySpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
@@ -51,6 +128,22 @@ type PackageRepositorySpec struct {
 
 	Url  string                     `json:"url"`
 	Auth *PackageRepositoryAuthSpec `json:"auth,omitempty"`
+	// Git must be set if Url points to a git repository rather than an HTTP server or OCI registry.
+	Git *PackageRepositoryGitSpec `json:"git,omitempty"`
+	// Helm must be set if Url points to a Helm chart repository rather than a package repository.
+	Helm *PackageRepositoryHelmSpec `json:"helm,omitempty"`
+	// SyncInterval is the time between two syncs of the repository. If it is not set, DefaultSyncInterval is used.
+	SyncInterval *metav1.Duration `json:"syncInterval,omitempty"`
+	// Signature enables the verification of package manifest signatures for this repository.
+	Signature *PackageRepositorySignatureSpec `json:"signature,omitempty"`
+	// TLS configures custom CA certificates and client certificates for repositories that are served over HTTPS.
+	TLS *PackageRepositoryTLSSpec `json:"tls,omitempty"`
+	// Proxy configures the proxy through which the repository is accessed. If it is not set, the proxy environment
+	// variables of the operator are used.
+	Proxy *PackageRepositoryProxySpec `json:"proxy,omitempty"`
+	// Priority decides which repository a package is installed from, if it is available in multiple
+	// repositories and no repository is given explicitly. The repository with the highest priority is used.
+	Priority int `json:"priority,omitempty"`
 }
 
 // PackageRepositoryStatus defines the observed state of PackageRepository
@@ -58,6 +151,13 @@ type PackageRepositoryStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+	// Commit is the SHA of the commit that was synced last, if this is a git repository.
+	Commit string `json:"commit,omitempty"`
+	// LastSyncTime is the time at which the repository was synced last, regardless of whether the sync succeeded.
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// LastSyncDuration is the time that the last sync took. Syncs that time out take as long as the sync timeout of
+	// the operator.
+	LastSyncDuration *metav1.Duration `json:"lastSyncDuration,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -75,6 +175,13 @@ type PackageRepository struct {
 
 const (
 	defaultRepositoryAnnotation = "packages.glasskube.dev/default-repository"
+	// syncRequestedAnnotation contains the time at which an immediate sync of the repository was requested
+	syncRequestedAnnotation = "packages.glasskube.dev/sync-requested"
+
+	// DefaultSyncInterval is used for repositories that do not specify a SyncInterval
+	DefaultSyncInterval = 60 * time.Second
+	// MinSyncInterval is the smallest allowed SyncInterval
+	MinSyncInterval = 10 * time.Second
 )
 
 func (repo PackageRepository) IsDefaultRepository() bool {
@@ -96,6 +203,62 @@ func (repo *PackageRepository) SetDefaultRepositoryBool(value bool) {
 	}
 }
 
+// GetSyncInterval returns the configured SyncInterval, or DefaultSyncInterval if it is not set
+func (repo PackageRepository) GetSyncInterval() time.Duration {
+	if repo.Spec.SyncInterval == nil || repo.Spec.SyncInterval.Duration <= 0 {
+		return DefaultSyncInterval
+	} else if repo.Spec.SyncInterval.Duration < MinSyncInterval {
+		return MinSyncInterval
+	}
+	return repo.Spec.SyncInterval.Duration
+}
+
+// NextSyncTime returns the time at which the next scheduled sync happens, or nil if the repository was never synced
+func (repo PackageRepository) NextSyncTime() *metav1.Time {
+	if repo.Status.LastSyncTime == nil {
+		return nil
+	}
+	next := metav1.NewTime(repo.Status.LastSyncTime.Add(repo.GetSyncInterval()))
+	return &next
+}
+
+// RequestSync marks the repository for an immediate sync, which is picked up by the operator
+func (repo *PackageRepository) RequestSync(now time.Time) {
+	if repo.Annotations == nil {
+		repo.SetAnnotations(map[string]string{})
+	}
+	repo.Annotations[syncRequestedAnnotation] = now.UTC().Format(time.RFC3339)
+}
+
+// SyncRequestedAt returns the time at which the last immediate sync was requested
+func (repo PackageRepository) SyncRequestedAt() (time.Time, bool) {
+	if value, ok := repo.Annotations[syncRequestedAnnotation]; ok {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// IsSyncRequested returns true if an immediate sync was requested after the last sync
+func (repo PackageRepository) IsSyncRequested() bool {
+	requestedAt, ok := repo.SyncRequestedAt()
+	return ok && (repo.Status.LastSyncTime == nil || requestedAt.After(repo.Status.LastSyncTime.Time))
+}
+
+// RequiresSignatures returns true if package manifests of this repository must have a valid signature
+func (repo PackageRepository) RequiresSignatures() bool {
+	return repo.Spec.Signature != nil && repo.Spec.Signature.Required
+}
+
+func (repo PackageRepository) IsGitRepository() bool {
+	return repo.Spec.Git != nil
+}
+
+func (repo PackageRepository) IsHelmRepository() bool {
+	return repo.Spec.Helm != nil
+}
+
 func (repo *PackageRepository) IsGlasskubeRepo() bool {
 	return strings.HasPrefix(repo.Spec.Url, constants.DefaultRepoUrl)
 }
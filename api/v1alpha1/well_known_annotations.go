@@ -1,7 +1,25 @@
 package v1alpha1
 
 const (
-	AnnotationAutoUpdate        = "packages.glasskube.dev/auto-update"
-	AnnotationInstalledAsDep    = "packages.glasskube.dev/installed-as-dependency"
-	AnnotationPackageSpecHashed = "packages.glasskube.dev/package-spec-hashed"
+	AnnotationAutoUpdate            = "packages.glasskube.dev/auto-update"
+	AnnotationInstalledAsDep        = "packages.glasskube.dev/installed-as-dependency"
+	AnnotationPackageSpecHashed     = "packages.glasskube.dev/package-spec-hashed"
+	AnnotationVersionConstraint     = "packages.glasskube.dev/version-constraint"
+	AnnotationUpdateNotifiedVersion = "packages.glasskube.dev/update-notified-version"
+	// AnnotationRetainedFrom is added to resources that were kept when their package was uninstalled. Its value is the
+	// name of the package.
+	AnnotationRetainedFrom = "packages.glasskube.dev/retained-from"
+	// AnnotationTraceParent contains the W3C trace context of the operation that last changed a package, so that its
+	// reconciliation is part of the same trace
+	AnnotationTraceParent = "packages.glasskube.dev/traceparent"
+	// AnnotationPause stops the reconciliation of a package while it is set to "true", e.g. to patch its resources
+	// manually for debugging. Unlike spec.suspend, it does not change the spec and does not prevent the deletion.
+	AnnotationPause = "packages.glasskube.dev/pause"
+	// AnnotationRetryRequested contains the time at which a user requested to apply the failed resources of a package
+	// again. Only the failed resources are applied in the next reconciliation.
+	AnnotationRetryRequested = "packages.glasskube.dev/retry-requested"
+	// AnnotationRecreateRequested contains the time at which a user requested to recreate the resources of a package
+	// that can not be applied because an immutable field changed. In the next reconciliation, these resources are
+	// deleted and applied again.
+	AnnotationRecreateRequested = "packages.glasskube.dev/recreate-requested"
 )
@@ -143,6 +143,22 @@ func (in *Dependency) DeepCopy() *Dependency {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedResourceRef) DeepCopyInto(out *FailedResourceRef) {
+	*out = *in
+	out.GroupVersionKind = in.GroupVersionKind
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedResourceRef.
+func (in *FailedResourceRef) DeepCopy() *FailedResourceRef {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedResourceRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HelmManifest) DeepCopyInto(out *HelmManifest) {
 	*out = *in
@@ -163,6 +179,36 @@ func (in *HelmManifest) DeepCopy() *HelmManifest {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HookStatus) DeepCopyInto(out *HookStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookStatus.
+func (in *HookStatus) DeepCopy() *HookStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HookStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageRegistryMirror) DeepCopyInto(out *ImageRegistryMirror) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageRegistryMirror.
+func (in *ImageRegistryMirror) DeepCopy() *ImageRegistryMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageRegistryMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InlineValueConfiguration) DeepCopyInto(out *InlineValueConfiguration) {
 	*out = *in
@@ -291,6 +337,46 @@ func (in *PackageEntrypoint) DeepCopy() *PackageEntrypoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageHook) DeepCopyInto(out *PackageHook) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageHook.
+func (in *PackageHook) DeepCopy() *PackageHook {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageHooks) DeepCopyInto(out *PackageHooks) {
+	*out = *in
+	if in.PreInstall != nil {
+		in, out := &in.PreInstall, &out.PreInstall
+		*out = make([]PackageHook, len(*in))
+		copy(*out, *in)
+	}
+	if in.PostInstall != nil {
+		in, out := &in.PostInstall, &out.PostInstall
+		*out = make([]PackageHook, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageHooks.
+func (in *PackageHooks) DeepCopy() *PackageHooks {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageHooks)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PackageInfo) DeepCopyInto(out *PackageInfo) {
 	*out = *in
@@ -456,6 +542,16 @@ func (in *PackageManifest) DeepCopyInto(out *PackageManifest) {
 		*out = make([]PackageReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.Keywords != nil {
+		in, out := &in.Keywords, &out.Keywords
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Categories != nil {
+		in, out := &in.Categories, &out.Categories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Helm != nil {
 		in, out := &in.Helm, &out.Helm
 		*out = new(HelmManifest)
@@ -471,6 +567,11 @@ func (in *PackageManifest) DeepCopyInto(out *PackageManifest) {
 		*out = make([]PlainManifest, len(*in))
 		copy(*out, *in)
 	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = new(PackageHooks)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ValueDefinitions != nil {
 		in, out := &in.ValueDefinitions, &out.ValueDefinitions
 		*out = make(map[string]ValueDefinition, len(*in))
@@ -478,6 +579,11 @@ func (in *PackageManifest) DeepCopyInto(out *PackageManifest) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.ValueGroups != nil {
+		in, out := &in.ValueGroups, &out.ValueGroups
+		*out = make([]ValueGroup, len(*in))
+		copy(*out, *in)
+	}
 	if in.Transformations != nil {
 		in, out := &in.Transformations, &out.Transformations
 		*out = make([]TransformationDefinition, len(*in))
@@ -521,6 +627,86 @@ func (in *PackageManifest) DeepCopy() *PackageManifest {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageProfile) DeepCopyInto(out *PackageProfile) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageProfile.
+func (in *PackageProfile) DeepCopy() *PackageProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PackageProfile) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageProfileList) DeepCopyInto(out *PackageProfileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PackageProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageProfileList.
+func (in *PackageProfileList) DeepCopy() *PackageProfileList {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PackageProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageProfileSpec) DeepCopyInto(out *PackageProfileSpec) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]ValueConfiguration, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageProfileSpec.
+func (in *PackageProfileSpec) DeepCopy() *PackageProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PackageReference) DeepCopyInto(out *PackageReference) {
 	*out = *in
@@ -648,6 +834,76 @@ func (in *PackageRepositoryBearerAuthSpec) DeepCopy() *PackageRepositoryBearerAu
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRepositoryGitSpec) DeepCopyInto(out *PackageRepositoryGitSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRepositoryGitSpec.
+func (in *PackageRepositoryGitSpec) DeepCopy() *PackageRepositoryGitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRepositoryGitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRepositoryHelmSpec) DeepCopyInto(out *PackageRepositoryHelmSpec) {
+	*out = *in
+	if in.Charts != nil {
+		in, out := &in.Charts, &out.Charts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRepositoryHelmSpec.
+func (in *PackageRepositoryHelmSpec) DeepCopy() *PackageRepositoryHelmSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRepositoryHelmSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRepositoryKeylessIdentity) DeepCopyInto(out *PackageRepositoryKeylessIdentity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRepositoryKeylessIdentity.
+func (in *PackageRepositoryKeylessIdentity) DeepCopy() *PackageRepositoryKeylessIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRepositoryKeylessIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRepositoryKeylessSpec) DeepCopyInto(out *PackageRepositoryKeylessSpec) {
+	*out = *in
+	if in.Identities != nil {
+		in, out := &in.Identities, &out.Identities
+		*out = make([]PackageRepositoryKeylessIdentity, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRepositoryKeylessSpec.
+func (in *PackageRepositoryKeylessSpec) DeepCopy() *PackageRepositoryKeylessSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRepositoryKeylessSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PackageRepositoryList) DeepCopyInto(out *PackageRepositoryList) {
 	*out = *in
@@ -680,6 +936,51 @@ func (in *PackageRepositoryList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRepositoryProxySpec) DeepCopyInto(out *PackageRepositoryProxySpec) {
+	*out = *in
+	if in.UrlSecretRef != nil {
+		in, out := &in.UrlSecretRef, &out.UrlSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRepositoryProxySpec.
+func (in *PackageRepositoryProxySpec) DeepCopy() *PackageRepositoryProxySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRepositoryProxySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRepositorySignatureSpec) DeepCopyInto(out *PackageRepositorySignatureSpec) {
+	*out = *in
+	if in.PublicKeys != nil {
+		in, out := &in.PublicKeys, &out.PublicKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Keyless != nil {
+		in, out := &in.Keyless, &out.Keyless
+		*out = new(PackageRepositoryKeylessSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRepositorySignatureSpec.
+func (in *PackageRepositorySignatureSpec) DeepCopy() *PackageRepositorySignatureSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRepositorySignatureSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PackageRepositorySpec) DeepCopyInto(out *PackageRepositorySpec) {
 	*out = *in
@@ -688,6 +989,36 @@ func (in *PackageRepositorySpec) DeepCopyInto(out *PackageRepositorySpec) {
 		*out = new(PackageRepositoryAuthSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(PackageRepositoryGitSpec)
+		**out = **in
+	}
+	if in.Helm != nil {
+		in, out := &in.Helm, &out.Helm
+		*out = new(PackageRepositoryHelmSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SyncInterval != nil {
+		in, out := &in.SyncInterval, &out.SyncInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Signature != nil {
+		in, out := &in.Signature, &out.Signature
+		*out = new(PackageRepositorySignatureSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(PackageRepositoryTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(PackageRepositoryProxySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRepositorySpec.
@@ -700,6 +1031,31 @@ func (in *PackageRepositorySpec) DeepCopy() *PackageRepositorySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRepositoryTLSSpec) DeepCopyInto(out *PackageRepositoryTLSSpec) {
+	*out = *in
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientCertificateSecretRef != nil {
+		in, out := &in.ClientCertificateSecretRef, &out.ClientCertificateSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRepositoryTLSSpec.
+func (in *PackageRepositoryTLSSpec) DeepCopy() *PackageRepositoryTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRepositoryTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PackageRepositoryStatus) DeepCopyInto(out *PackageRepositoryStatus) {
 	*out = *in
@@ -710,6 +1066,15 @@ func (in *PackageRepositoryStatus) DeepCopyInto(out *PackageRepositoryStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSyncDuration != nil {
+		in, out := &in.LastSyncDuration, &out.LastSyncDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRepositoryStatus.
@@ -722,6 +1087,29 @@ func (in *PackageRepositoryStatus) DeepCopy() *PackageRepositoryStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRevision) DeepCopyInto(out *PackageRevision) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]ValueConfiguration, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	in.InstalledAt.DeepCopyInto(&out.InstalledAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRevision.
+func (in *PackageRevision) DeepCopy() *PackageRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PackageSpec) DeepCopyInto(out *PackageSpec) {
 	*out = *in
@@ -733,6 +1121,39 @@ func (in *PackageSpec) DeepCopyInto(out *PackageSpec) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.Overlays != nil {
+		in, out := &in.Overlays, &out.Overlays
+		*out = make(map[string]map[string]ValueConfiguration, len(*in))
+		for key, val := range *in {
+			var outVal map[string]ValueConfiguration
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(map[string]ValueConfiguration, len(*in))
+				for key, val := range *in {
+					(*out)[key] = *val.DeepCopy()
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.ImageRegistryMirrors != nil {
+		in, out := &in.ImageRegistryMirrors, &out.ImageRegistryMirrors
+		*out = make([]ImageRegistryMirror, len(*in))
+		copy(*out, *in)
+	}
+	if in.OptionalDependencies != nil {
+		in, out := &in.OptionalDependencies, &out.OptionalDependencies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Scheduling != nil {
+		in, out := &in.Scheduling, &out.Scheduling
+		*out = new(SchedulingOverrides)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageSpec.
@@ -770,6 +1191,27 @@ func (in *PackageStatus) DeepCopyInto(out *PackageStatus) {
 		*out = make([]OwnedResourceRef, len(*in))
 		copy(*out, *in)
 	}
+	if in.Revisions != nil {
+		in, out := &in.Revisions, &out.Revisions
+		*out = make([]PackageRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FailedResources != nil {
+		in, out := &in.FailedResources, &out.FailedResources
+		*out = make([]FailedResourceRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastRetryTime != nil {
+		in, out := &in.LastRetryTime, &out.LastRetryTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = make([]HookStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageStatus.
@@ -827,6 +1269,40 @@ func (in *PlainManifest) DeepCopy() *PlainManifest {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulingOverrides) DeepCopyInto(out *SchedulingOverrides) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingOverrides.
+func (in *SchedulingOverrides) DeepCopy() *SchedulingOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulingOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransformationDefinition) DeepCopyInto(out *TransformationDefinition) {
 	*out = *in
@@ -879,6 +1355,11 @@ func (in *ValueConfiguration) DeepCopyInto(out *ValueConfiguration) {
 		*out = new(ValueReference)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValueConfiguration.
@@ -948,6 +1429,11 @@ func (in *ValueDefinitionConstraints) DeepCopyInto(out *ValueDefinitionConstrain
 		*out = new(string)
 		**out = **in
 	}
+	if in.Format != nil {
+		in, out := &in.Format, &out.Format
+		*out = new(ValueFormat)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValueDefinitionConstraints.
@@ -1006,6 +1492,21 @@ func (in *ValueDefinitionTarget) DeepCopy() *ValueDefinitionTarget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValueGroup) DeepCopyInto(out *ValueGroup) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValueGroup.
+func (in *ValueGroup) DeepCopy() *ValueGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ValueGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValueReference) DeepCopyInto(out *ValueReference) {
 	*out = *in
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/glasskube/glasskube/internal/audit"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+)
+
+// recordAuditEntry writes an audit log entry for an operation that has been performed successfully.
+// Failing to write the entry does not fail the command.
+func recordAuditEntry(
+	ctx context.Context,
+	operation audit.Operation,
+	pkg ctrlpkg.Package,
+	versionBefore, versionAfter string,
+) {
+	if err := audit.Record(ctx, audit.SourceCLI, operation, pkg, versionBefore, versionAfter); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+	}
+}
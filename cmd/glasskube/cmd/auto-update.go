@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/glasskube/glasskube/api/v1alpha1"
+	clientadapter "github.com/glasskube/glasskube/internal/adapter/goclient"
+	"github.com/glasskube/glasskube/internal/autoupdate"
 	"github.com/glasskube/glasskube/internal/cliutils"
 	"github.com/glasskube/glasskube/internal/config"
 	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
@@ -13,6 +16,7 @@ import (
 	"github.com/glasskube/glasskube/pkg/update"
 	"github.com/spf13/cobra"
 	"go.uber.org/multierr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -156,6 +160,33 @@ var autoUpdateCmd = &cobra.Command{
 func runAutoUpdate(cmd *cobra.Command, args []string) {
 	ctx := cmd.Context()
 	client := cliutils.PackageClient(ctx)
+
+	k8sClient := clientadapter.NewKubernetesClientAdapter(cliutils.KubernetesClient(ctx))
+
+	// the global freeze takes precedence over the auto-update setting of every single package
+	if freeze, err := autoupdate.LoadFreeze(ctx, k8sClient); apierrors.IsForbidden(err) {
+		// older installations of the auto-updater are not allowed to read the freeze
+		fmt.Fprintf(os.Stderr, "Could not check whether automatic updates are suspended: %v\n", err)
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "Error checking whether automatic updates are suspended: %v\n", err)
+		cliutils.ExitWithError()
+	} else if freeze.IsActive() {
+		fmt.Fprintf(os.Stderr, "Automatic updates are suspended globally since %v", freeze.Since.Local())
+		if freeze.Reason != "" {
+			fmt.Fprintf(os.Stderr, ": %v", freeze.Reason)
+		}
+		fmt.Fprintln(os.Stderr)
+		cliutils.ExitSuccess()
+	}
+
+	window, err := autoupdate.LoadMaintenanceWindow(ctx, k8sClient)
+	if apierrors.IsForbidden(err) {
+		fmt.Fprintf(os.Stderr, "Could not check the maintenance window: %v\n", err)
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "Error checking the maintenance window: %v\n", err)
+		cliutils.ExitWithError()
+	}
+
 	updater := update.NewUpdater(ctx).
 		WithStatusWriter(statuswriter.Stderr())
 
@@ -195,6 +226,16 @@ func runAutoUpdate(cmd *cobra.Command, args []string) {
 	}
 	printTransaction(*tx)
 
+	// outside the maintenance window, available updates are only reported. They are applied by the first run inside
+	// the window.
+	if now := time.Now(); !window.Contains(now) {
+		if !tx.IsEmpty() {
+			fmt.Fprintf(os.Stderr, "Updates are pending until the next maintenance window (%v) starts at %v\n",
+				window, window.Next(now).Format(time.RFC1123))
+		}
+		cliutils.ExitSuccess()
+	}
+
 	if updated, err := updater.Apply(ctx, tx, update.ApplyUpdateOptions{Blocking: true, DryRun: false}); err != nil {
 		fmt.Fprintf(os.Stderr, "Error applying update: %v\n", err)
 		cliutils.ExitWithError()
@@ -29,6 +29,8 @@ type bootstrapOptions struct {
 	force                   bool
 	createDefaultRepository bool
 	yes                     bool
+	check                   bool
+	repair                  bool
 	DryRunOptions
 	OutputOptions
 }
@@ -50,6 +52,11 @@ var bootstrapCmd = &cobra.Command{
 		client := bootstrap.NewBootstrapClient(cfg)
 		ctx := cmd.Context()
 
+		if bootstrapCmdOptions.check || bootstrapCmdOptions.repair {
+			runBootstrapCheck(ctx, client)
+			return
+		}
+
 		var installedVersion, targetVersion *semver.Version
 		if installedVersionRaw, err := clientutils.GetPackageOperatorVersion(ctx); err != nil {
 			if !apierrors.IsNotFound(err) {
@@ -113,6 +120,83 @@ func (o bootstrapOptions) asBootstrapOptions() bootstrap.BootstrapOptions {
 	}
 }
 
+// runBootstrapCheck reports the differences between the cluster and the manifests of the release and, if --repair is
+// given, applies the manifests of all repairable resources again. It exits with an error if problems remain.
+func runBootstrapCheck(ctx context.Context, client *bootstrap.BootstrapClient) {
+	result, err := client.Check(ctx, bootstrapCmdOptions.asBootstrapOptions())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\nAn error occurred during the check:\n%v\n", err)
+		cliutils.ExitWithError()
+	}
+	if bootstrapCmdOptions.check && bootstrapCmdOptions.Output != "" {
+		printBootstrapCheckResult(result, bootstrapCmdOptions.Output)
+	}
+
+	currentContext := color.New(color.Bold).Sprint(clicontext.RawConfigFromContext(ctx).CurrentContext)
+	if len(result.Problems) == 0 {
+		fmt.Fprintf(os.Stderr, "✅ Glasskube is installed correctly in context %v.\n", currentContext)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Compared context %v with %v:\n", currentContext, result.Url)
+	for _, problem := range result.Problems {
+		if problem.Warning {
+			fmt.Fprintf(os.Stderr, " ⚠️  %v\n", problem)
+		} else {
+			fmt.Fprintf(os.Stderr, " ❌ %v\n", problem)
+		}
+	}
+
+	if bootstrapCmdOptions.check {
+		if !result.IsHealthy() {
+			if result.IsRepairable() {
+				fmt.Fprintln(os.Stderr, "\nRun \"glasskube bootstrap --repair\" to apply the missing or modified "+
+					"resources again.")
+			}
+			cliutils.ExitWithError()
+		}
+		return
+	}
+
+	if !result.IsRepairable() {
+		fmt.Fprintln(os.Stderr, "\nNone of these problems can be repaired by applying the manifests again.")
+		cliutils.ExitWithError()
+	}
+	if bootstrapCmdOptions.DryRun {
+		fmt.Fprintln(os.Stderr, "🔎 Dry-run mode is enabled. Nothing will be changed in your cluster.")
+	} else if !bootstrapCmdOptions.yes &&
+		!cliutils.YesNoPrompt("Do you want to apply the affected resources again?", true) {
+		cancel()
+	}
+	manifests, err := client.Repair(ctx, result, bootstrapCmdOptions.asBootstrapOptions())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\nAn error occurred during the repair:\n%v\n", err)
+		cliutils.ExitWithError()
+	}
+	if err := printBootstrap(manifests, bootstrapCmdOptions.Output); err != nil {
+		fmt.Fprintf(os.Stderr, "\nAn error occurred in printing : %v\n", err)
+		cliutils.ExitWithError()
+	}
+}
+
+func printBootstrapCheckResult(result *bootstrap.CheckResult, output outputFormat) {
+	switch output {
+	case outputFormatJSON:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "    ")
+		if err := enc.Encode(result); err != nil {
+			fmt.Fprintf(os.Stderr, "error marshaling data to JSON: %v\n", err)
+			cliutils.ExitWithError()
+		}
+	case outputFormatYAML:
+		if data, err := yaml.Marshal(result); err != nil {
+			fmt.Fprintf(os.Stderr, "error marshaling data to YAML: %v\n", err)
+			cliutils.ExitWithError()
+		} else {
+			fmt.Print(string(data))
+		}
+	}
+}
+
 func printBootstrap(manifests []unstructured.Unstructured, output outputFormat) error {
 	if output != "" {
 		if err := convertAndPrintManifests(manifests, output); err != nil {
@@ -230,12 +314,19 @@ func init() {
 		bootstrapCmdOptions.createDefaultRepository,
 		"Toggle creation of the default glasskube package repository")
 	bootstrapCmd.Flags().BoolVar(&bootstrapCmdOptions.yes, "yes", false, "Skip confirmation prompt")
+	bootstrapCmd.Flags().BoolVar(&bootstrapCmdOptions.check, "check", false,
+		"Verify that the controller, CRDs and default repository match the manifests of this version "+
+			"without changing anything")
+	bootstrapCmd.Flags().BoolVar(&bootstrapCmdOptions.repair, "repair", false,
+		"Apply all missing or modified resources found by --check again")
 
 	bootstrapCmdOptions.OutputOptions.AddFlagsToCommand(bootstrapCmd)
 	bootstrapCmdOptions.DryRunOptions.AddFlagsToCommand(bootstrapCmd)
 
 	bootstrapCmd.MarkFlagsMutuallyExclusive("url", "type")
 	bootstrapCmd.MarkFlagsMutuallyExclusive("url", "latest")
+	bootstrapCmd.MarkFlagsMutuallyExclusive("check", "repair")
+	bootstrapCmd.MarkFlagsMutuallyExclusive("check", "dry-run")
 
 	bootstrapCmd.AddCommand(bootstrapGitCmd)
 }
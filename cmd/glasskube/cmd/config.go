@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Get and set the configuration values of an installed package",
+}
+
+func init() {
+	configCmd.AddCommand(configGetCmd, configSetCmd)
+	RootCmd.AddCommand(configCmd)
+}
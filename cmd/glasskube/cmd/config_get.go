@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/cliutils"
+	"github.com/glasskube/glasskube/internal/manifestvalues/cli"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+var configGetCmdOptions = struct {
+	OutputOptions
+	NamespaceOptions
+	KindOptions
+}{
+	KindOptions: DefaultKindOptions(),
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <package-name> [key]",
+	Short: "Print the configuration values of a package",
+	Long: "Print the configuration values of a package.\n" +
+		"Values that reference other resources are printed in the same syntax that is accepted by " +
+		"\"glasskube config set\", the referenced data is not resolved.",
+	Args:   cobra.RangeArgs(1, 2),
+	PreRun: cliutils.SetupClientContext(true, &rootCmdOptions.SkipUpdateCheck),
+	Run:    runConfigGet,
+	ValidArgsFunction: installedPackagesCompletionFunc(
+		&configGetCmdOptions.NamespaceOptions,
+		&configGetCmdOptions.KindOptions,
+	),
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+	pkg, err :=
+		getPackageOrClusterPackage(ctx, args[0], configGetCmdOptions.KindOptions, configGetCmdOptions.NamespaceOptions)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ could not get resource: %v\n", err)
+		cliutils.ExitWithError()
+	}
+
+	values := pkg.GetSpec().Values
+	if len(args) > 1 {
+		key := args[1]
+		value, ok := values[key]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "❌ value %v is not configured for %v\n", key, args[0])
+			cliutils.ExitWithError()
+		}
+		values = map[string]v1alpha1.ValueConfiguration{key: value}
+	}
+
+	switch configGetCmdOptions.Output {
+	case outputFormatJSON:
+		if out, err := json.MarshalIndent(values, "", "  "); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ could not marshal JSON output: %v\n", err)
+			cliutils.ExitWithError()
+		} else {
+			fmt.Println(string(out))
+		}
+	case outputFormatYAML:
+		if out, err := yaml.Marshal(values); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ could not marshal YAML output: %v\n", err)
+			cliutils.ExitWithError()
+		} else {
+			fmt.Print(string(out))
+		}
+	default:
+		for _, line := range cli.FormatValues(values) {
+			if len(args) > 1 {
+				// only print the value if a single key was requested, so that the output can be used in scripts
+				_, line, _ = strings.Cut(line, "=")
+			}
+			fmt.Println(line)
+		}
+	}
+}
+
+func init() {
+	configGetCmdOptions.OutputOptions.AddFlagsToCommand(configGetCmd)
+	configGetCmdOptions.NamespaceOptions.AddFlagsToCommand(configGetCmd)
+	configGetCmdOptions.KindOptions.AddFlagsToCommand(configGetCmd)
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/glasskube/glasskube/internal/audit"
+	"github.com/glasskube/glasskube/internal/cliutils"
+	"github.com/glasskube/glasskube/internal/manifestvalues"
+	"github.com/glasskube/glasskube/internal/manifestvalues/cli"
+	"github.com/glasskube/glasskube/pkg/manifest"
+	"github.com/spf13/cobra"
+	"go.uber.org/multierr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var configSetCmdOptions = struct {
+	NamespaceOptions
+	KindOptions
+	DryRunOptions
+}{
+	KindOptions: DefaultKindOptions(),
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <package-name> <key>=<value>...",
+	Short: "Change configuration values of a package",
+	Long: "Change configuration values of a package. Values that are not given keep their current value.\n" +
+		"You can create values referencing data in other resources using the following syntax: " +
+		"$<ReferenceKind>$[<specifier>[,<specifier>...]].\n" +
+		"For example:\n" +
+		" * Reference a ConfigMap key: \"name=$ConfigMapRef$namespace,name,key\"\n" +
+		" * Reference a Secret key: \"name=$SecretRef$namespace,name,key\"\n" +
+		" * Reference another Package value: \"name=$PackageRef$name,value\"\n" +
+		" * Compute the value from a template: \"name=$Template${{ .Cluster.domain }}\"\n" +
+		"The new values are validated against the value definitions of the package before they are applied.",
+	Args:   cobra.MinimumNArgs(2),
+	PreRun: cliutils.SetupClientContext(true, &rootCmdOptions.SkipUpdateCheck),
+	Run:    runConfigSet,
+	ValidArgsFunction: installedPackagesCompletionFunc(
+		&configSetCmdOptions.NamespaceOptions,
+		&configSetCmdOptions.KindOptions,
+	),
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) {
+	bold := color.New(color.Bold).SprintFunc()
+	ctx := cmd.Context()
+	name := args[0]
+
+	opts := metav1.UpdateOptions{}
+	if configSetCmdOptions.DryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+		fmt.Fprintln(os.Stderr,
+			"🔎 Dry-run mode is enabled. Nothing will be changed.")
+	}
+
+	pkg, err :=
+		getPackageOrClusterPackage(ctx, name, configSetCmdOptions.KindOptions, configSetCmdOptions.NamespaceOptions)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ could not get resource: %v\n", err)
+		cliutils.ExitWithError()
+	}
+
+	pkgManifest, err := manifest.GetInstalledManifestForPackage(ctx, pkg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ error getting installed manifest: %v\n", err)
+		cliutils.ExitWithError()
+	}
+
+	valuesOptions := cli.ValuesOptions{Values: args[1:], KeepOldValues: true}
+	values, err := valuesOptions.ParseValues(pkgManifest, pkg.GetSpec().Values)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ invalid values: %v\n", err)
+		cliutils.ExitWithError()
+	}
+	if err := manifestvalues.ValidateValueConfigurations(*pkgManifest, values); err != nil {
+		fmt.Fprintln(os.Stderr, "❌ the configuration is not valid:")
+		for _, err := range multierr.Errors(err) {
+			fmt.Fprintf(os.Stderr, " * %v\n", err)
+		}
+		cliutils.ExitWithError()
+	}
+	pkg.GetSpec().Values = values
+
+	fmt.Fprintln(os.Stderr, bold("Configuration:"))
+	printValueConfigurations(os.Stderr, values)
+	if _, err := cliutils.ValueResolver(ctx).Resolve(ctx, values); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Some values can not be resolved: %v\n", err)
+	}
+
+	if err := updatePackageValues(ctx, pkg, opts); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		cliutils.ExitWithError()
+	}
+
+	if configSetCmdOptions.DryRun {
+		fmt.Fprintln(os.Stderr, "✅ valid configuration but nothing has been changed")
+	} else {
+		version := pkg.GetSpec().PackageInfo.Version
+		recordAuditEntry(ctx, audit.OperationConfigure, pkg, version, version)
+		fmt.Fprintln(os.Stderr, "✅ configuration changed")
+	}
+}
+
+func init() {
+	configSetCmdOptions.NamespaceOptions.AddFlagsToCommand(configSetCmd)
+	configSetCmdOptions.KindOptions.AddFlagsToCommand(configSetCmd)
+	configSetCmdOptions.DryRunOptions.AddFlagsToCommand(configSetCmd)
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"maps"
 	"os"
@@ -9,7 +11,9 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/audit"
 	"github.com/glasskube/glasskube/internal/cliutils"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
 	"github.com/glasskube/glasskube/internal/manifestvalues/cli"
 	"github.com/glasskube/glasskube/pkg/manifest"
 	"github.com/spf13/cobra"
@@ -42,7 +46,6 @@ var configureCmd = &cobra.Command{
 func runConfigure(cmd *cobra.Command, args []string) {
 	bold := color.New(color.Bold).SprintFunc()
 	ctx := cmd.Context()
-	pkgClient := cliutils.PackageClient(ctx)
 	valueResolver := cliutils.ValueResolver(ctx)
 	name := args[0]
 
@@ -80,6 +83,7 @@ func runConfigure(cmd *cobra.Command, args []string) {
 		} else if values, err := cli.Configure(*pkgManifest,
 			cli.WithOldValues(pkg.GetSpec().Values),
 			cli.WithUseDefaults(configureCmdOptions.UseDefault),
+			clusterDefaultsOption(ctx),
 		); err != nil {
 			fmt.Fprintf(os.Stderr, "❌ error during configure: %v\n", err)
 			cliutils.ExitWithError()
@@ -100,39 +104,16 @@ func runConfigure(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	switch pkg := pkg.(type) {
-	case *v1alpha1.ClusterPackage:
-		values := maps.Clone(pkg.Spec.Values)
-		if err := pkgClient.ClusterPackages().Get(ctx, pkg.Name, pkg); err != nil {
-			// Don't exit, we can still try to call update ...
-			fmt.Fprintf(os.Stderr, "⚠️  error fetching package: %v\n", err)
-		}
-		pkg.Spec.Values = values
-
-		if err := pkgClient.ClusterPackages().Update(ctx, pkg, opts); err != nil {
-			fmt.Fprintf(os.Stderr, "❌ error updating package: %v\n", err)
-			cliutils.ExitWithError()
-		}
-	case *v1alpha1.Package:
-		values := maps.Clone(pkg.Spec.Values)
-		if err := pkgClient.Packages(pkg.Namespace).Get(ctx, pkg.Name, pkg); err != nil {
-			// Don't exit, we can still try to call update ...
-			fmt.Fprintf(os.Stderr, "⚠️  error fetching package: %v\n", err)
-		}
-		pkg.Spec.Values = values
-
-		if err := pkgClient.Packages(pkg.Namespace).Update(ctx, pkg, opts); err != nil {
-			fmt.Fprintf(os.Stderr, "❌ error updating package: %v\n", err)
-			cliutils.ExitWithError()
-		}
-	default:
-		fmt.Fprintln(os.Stderr, "❌ invalid state: pkg must be either Package or ClusterPackage")
+	if err := updatePackageValues(ctx, pkg, opts); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
 		cliutils.ExitWithError()
 	}
 
 	if configureCmdOptions.DryRun {
 		fmt.Fprintln(os.Stderr, "✅ valid configuration but nothing has been changed")
 	} else {
+		version := pkg.GetSpec().PackageInfo.Version
+		recordAuditEntry(ctx, audit.OperationConfigure, pkg, version, version)
 		fmt.Fprintln(os.Stderr, "✅ configuration changed")
 	}
 
@@ -155,3 +136,46 @@ func init() {
 	configureCmdOptions.DryRunOptions.AddFlagsToCommand(configureCmd)
 	RootCmd.AddCommand(configureCmd)
 }
+
+// updatePackageValues stores the values of pkg in the cluster. The package is fetched again before it is updated, so
+// that only the values are changed, even if the package has been modified in the meantime.
+func updatePackageValues(ctx context.Context, pkg ctrlpkg.Package, opts metav1.UpdateOptions) error {
+	pkgClient := cliutils.PackageClient(ctx)
+	switch pkg := pkg.(type) {
+	case *v1alpha1.ClusterPackage:
+		values := maps.Clone(pkg.Spec.Values)
+		if err := pkgClient.ClusterPackages().Get(ctx, pkg.Name, pkg); err != nil {
+			// Don't exit, we can still try to call update ...
+			fmt.Fprintf(os.Stderr, "⚠️  error fetching package: %v\n", err)
+		}
+		pkg.Spec.Values = values
+
+		if err := pkgClient.ClusterPackages().Update(ctx, pkg, opts); err != nil {
+			return fmt.Errorf("error updating package: %w", err)
+		}
+	case *v1alpha1.Package:
+		values := maps.Clone(pkg.Spec.Values)
+		if err := pkgClient.Packages(pkg.Namespace).Get(ctx, pkg.Name, pkg); err != nil {
+			// Don't exit, we can still try to call update ...
+			fmt.Fprintf(os.Stderr, "⚠️  error fetching package: %v\n", err)
+		}
+		pkg.Spec.Values = values
+
+		if err := pkgClient.Packages(pkg.Namespace).Update(ctx, pkg, opts); err != nil {
+			return fmt.Errorf("error updating package: %w", err)
+		}
+	default:
+		return errors.New("invalid state: pkg must be either Package or ClusterPackage")
+	}
+	return nil
+}
+
+// clusterDefaultsOption suggests the cluster defaults for values that refer to them. If the cluster defaults can not
+// be read, the default values of the manifest are suggested instead.
+func clusterDefaultsOption(ctx context.Context) cli.ConfigureOption {
+	clusterDefaults, err := cliutils.ValueResolver(ctx).ClusterDefaults(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Could not read the cluster defaults: %v\n", err)
+	}
+	return cli.WithClusterDefaults(clusterDefaults)
+}
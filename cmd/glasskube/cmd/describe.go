@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 
 	repoerror "github.com/glasskube/glasskube/internal/repo/error"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/glasskube/glasskube/internal/cliutils"
 	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
 	"github.com/glasskube/glasskube/internal/manifestvalues"
+	"github.com/glasskube/glasskube/internal/maputils"
 	repoclient "github.com/glasskube/glasskube/internal/repo/client"
 	"github.com/glasskube/glasskube/internal/semver"
 	"github.com/glasskube/glasskube/internal/util"
@@ -28,10 +30,16 @@ import (
 	"github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/renderer"
 	goldmarkutil "github.com/yuin/goldmark/util"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
 	"sigs.k8s.io/yaml"
 )
 
+// describeEventLimit is the maximum number of recent events that are shown
+const describeEventLimit = 10
+
 var describeCmdOptions = struct {
 	repository string
 	OutputOptions
@@ -42,9 +50,11 @@ var describeCmdOptions = struct {
 }
 
 var describeCmd = &cobra.Command{
-	Use:               "describe <package-name>",
-	Short:             "Describe a package",
-	Long:              "Shows additional information about the given package.",
+	Use:   "describe <package-name>",
+	Short: "Describe a package",
+	Long: "Shows additional information about the given package.\n" +
+		"For installed packages, this includes the status of their dependencies, conditions, owned resources and " +
+		"recent events.",
 	Args:              cobra.ExactArgs(1),
 	PreRun:            cliutils.SetupClientContext(true, &rootCmdOptions.SkipUpdateCheck),
 	ValidArgsFunction: completeAvailablePackageNames,
@@ -129,12 +139,14 @@ var describeCmd = &cobra.Command{
 			fmt.Fprintf(os.Stderr, "❌ Could not get repos for %v: %v\n", pkgName, err)
 		}
 
+		details := getDescribeDetails(ctx, pkg, manifest)
+
 		bold := color.New(color.Bold).SprintFunc()
 
 		if describeCmdOptions.Output == outputFormatJSON {
-			printJSON(ctx, pkg, pkgs, manifest, latestVersion, repos)
+			printJSON(ctx, pkg, pkgs, manifest, latestVersion, repos, details)
 		} else if describeCmdOptions.Output == outputFormatYAML {
-			printYAML(ctx, pkg, pkgs, manifest, latestVersion, repos)
+			printYAML(ctx, pkg, pkgs, manifest, latestVersion, repos, details)
 		} else {
 			fmt.Println(bold("Package:"), nameAndDescription(manifest))
 
@@ -142,10 +154,14 @@ var describeCmd = &cobra.Command{
 				pkgStatus := client.GetStatusOrPending(pkg)
 
 				fmt.Println(bold("Version:    "), version(pkg, latestVersion))
+				if digest := pkg.GetStatus().Digest; digest != "" {
+					fmt.Println(bold("Digest:     "), digest)
+				}
 				fmt.Println(bold("Status:     "), status(pkgStatus))
 				fmt.Println(bold("Message:    "), message(pkgStatus))
 				fmt.Println(bold("Auto-Update:"), clientutils.AutoUpdateString(pkg, "Disabled"))
 				fmt.Println(bold("Suspended:  "), boolYesNo(pkg.GetSpec().Suspend))
+				fmt.Println(bold("Paused:     "), boolYesNo(pkg.IsPaused()))
 			} else if len(pkgs) > 0 {
 				fmt.Println()
 				fmt.Println(bold("Instances:"))
@@ -155,10 +171,14 @@ var describeCmd = &cobra.Command{
 					fmt.Println(fmt.Sprintf(" %v.", i+1), bold("Name:       "), pkg.Name)
 					fmt.Println(bold("    Namespace:  "), pkg.Namespace)
 					fmt.Println(bold("    Version:    "), version(&pkg, latestVersion))
+					if digest := pkg.Status.Digest; digest != "" {
+						fmt.Println(bold("    Digest:     "), digest)
+					}
 					fmt.Println(bold("    Status:     "), status(pkgStatus))
 					fmt.Println(bold("    Message:    "), message(pkgStatus))
 					fmt.Println(bold("    Auto-Update:"), clientutils.AutoUpdateString(&pkg, "Disabled"))
 					fmt.Println(bold("    Suspended:  "), boolYesNo(pkg.Spec.Suspend))
+					fmt.Println(bold("    Paused:     "), boolYesNo(pkg.IsPaused()))
 				}
 			}
 
@@ -171,7 +191,19 @@ var describeCmd = &cobra.Command{
 			if len(manifest.Dependencies) > 0 {
 				fmt.Println()
 				fmt.Println(bold("Dependencies:"))
-				printDependencies(manifest)
+				printDependencies(details.dependencies)
+			}
+
+			if !pkg.IsNil() && len(pkg.GetStatus().Conditions) > 0 {
+				fmt.Println()
+				fmt.Println(bold("Conditions:"))
+				printConditions(pkg.GetStatus().Conditions)
+			}
+
+			if len(details.resources) > 0 {
+				fmt.Println()
+				fmt.Println(bold("Resources:"))
+				printResources(details.resources)
 			}
 
 			if len(manifest.Components) > 0 {
@@ -200,10 +232,66 @@ var describeCmd = &cobra.Command{
 				fmt.Println(bold("Configuration:"))
 				printValueConfigurations(os.Stdout, pkg.GetSpec().Values)
 			}
+
+			if !pkg.IsNil() {
+				for _, environment := range maputils.KeysSorted(pkg.GetSpec().Overlays) {
+					fmt.Println()
+					if environment == details.environment {
+						fmt.Println(bold(fmt.Sprintf("Configuration Overlay %v (active):", environment)))
+					} else {
+						fmt.Println(bold(fmt.Sprintf("Configuration Overlay %v:", environment)))
+					}
+					printValueConfigurations(os.Stdout, pkg.GetSpec().Overlays[environment])
+				}
+			}
+
+			if !pkg.IsNil() && manifest.PostInstallNotes != "" {
+				fmt.Println()
+				fmt.Println(bold("Notes:"))
+				printPostInstallNotes(ctx, os.Stdout, pkg, manifest)
+			}
+
+			if !pkg.IsNil() {
+				fmt.Println()
+				fmt.Println(bold("Events:"))
+				printEvents(details.events)
+			}
 		}
 	},
 }
 
+// describeDetails is the state of a package and its dependencies in the cluster, which is looked up in addition to
+// the manifest. Parts that can not be looked up are empty.
+type describeDetails struct {
+	dependencies []describe.DependencyStatus
+	resources    []describe.ResourceStatus
+	events       []describe.Event
+	environment  string
+}
+
+func getDescribeDetails(ctx context.Context, pkg ctrlpkg.Package, manifest *v1alpha1.PackageManifest) describeDetails {
+	var details describeDetails
+	var err error
+	if details.dependencies, err = describe.DescribeDependencies(ctx, pkg, manifest); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Could not get dependencies: %v\n", err)
+	}
+	if pkg.IsNil() {
+		return details
+	}
+	if details.resources, err = describe.DescribeResources(ctx, pkg); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Could not get resources: %v\n", err)
+	}
+	if details.events, err = describe.DescribeEvents(ctx, pkg, details.resources, describeEventLimit); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Could not get events: %v\n", err)
+	}
+	if len(pkg.GetSpec().Overlays) > 0 {
+		if details.environment, err = cliutils.ValueResolver(ctx).Environment(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Could not get environment: %v\n", err)
+		}
+	}
+	return details
+}
+
 func printEntrypoints(manifest *v1alpha1.PackageManifest) {
 	for _, i := range manifest.Entrypoints {
 		var messageParts []string
@@ -231,12 +319,78 @@ func printEntrypoints(manifest *v1alpha1.PackageManifest) {
 	}
 }
 
-func printDependencies(manifest *v1alpha1.PackageManifest) {
-	for _, dep := range manifest.Dependencies {
+func printDependencies(dependencies []describe.DependencyStatus) {
+	for _, dep := range dependencies {
 		fmt.Printf(" * %v", dep.Name)
 		if len(dep.Version) > 0 {
 			fmt.Printf(" (%v)", dep.Version)
 		}
+		if dep.Optional {
+			if dep.Enabled {
+				fmt.Print(" [optional, enabled]")
+			} else {
+				fmt.Print(" [optional]")
+			}
+		}
+		if dep.Status != "" {
+			fmt.Printf(": %v %v", dep.InstalledVersion, status(&client.PackageStatus{Status: dep.Status}))
+		} else if dep.Enabled {
+			fmt.Printf(": %v", color.New(color.Faint).Sprint("Not installed"))
+		}
+		fmt.Println()
+	}
+}
+
+func printConditions(conditions []metav1.Condition) {
+	for _, cond := range conditions {
+		fmt.Printf(" * %v=%v (%v, %v ago)", cond.Type, cond.Status, cond.Reason,
+			duration.HumanDuration(time.Since(cond.LastTransitionTime.Time)))
+		if cond.Message != "" {
+			fmt.Printf(": %v", cond.Message)
+		}
+		fmt.Println()
+	}
+}
+
+func printResources(resources []describe.ResourceStatus) {
+	for _, resource := range resources {
+		name := resource.Name
+		if resource.Namespace != "" {
+			name = resource.Namespace + "/" + resource.Name
+		}
+		fmt.Printf(" * %v %v: %v", resource.Kind, name, health(resource.Health))
+		if resource.Message != "" {
+			fmt.Printf(" (%v)", resource.Message)
+		}
+		fmt.Println()
+	}
+}
+
+func health(value string) string {
+	switch value {
+	case describe.HealthHealthy:
+		return color.GreenString(value)
+	case describe.HealthUnhealthy, describe.HealthFailed, describe.HealthMissing:
+		return color.RedString(value)
+	default:
+		return value
+	}
+}
+
+func printEvents(events []describe.Event) {
+	if len(events) == 0 {
+		fmt.Println(color.New(color.Faint).Sprint(" No recent events"))
+	}
+	for _, event := range events {
+		eventType := event.Type
+		if eventType == corev1.EventTypeWarning {
+			eventType = color.YellowString(eventType)
+		}
+		fmt.Printf(" * %v ago %v %v %v: %v", duration.HumanDuration(time.Since(event.LastSeen)), eventType,
+			event.Reason, event.Object, event.Message)
+		if event.Count > 1 {
+			fmt.Printf(" (x%v)", event.Count)
+		}
 		fmt.Println()
 	}
 }
@@ -326,6 +480,16 @@ func printValueConfigurations(w io.Writer, values map[string]v1alpha1.ValueConfi
 	}
 }
 
+// printPostInstallNotes renders the post-install notes of the manifest for the given package. If they can not be
+// rendered, the error is printed instead.
+func printPostInstallNotes(ctx context.Context, w io.Writer, pkg ctrlpkg.Package, manifest *v1alpha1.PackageManifest) {
+	if notes, err := cliutils.ValueResolver(ctx).RenderNotes(ctx, pkg, manifest.PostInstallNotes); err != nil {
+		util.Must(fmt.Fprintf(w, "⚠️  %v\n", err))
+	} else {
+		printMarkdown(w, strings.TrimSpace(notes))
+	}
+}
+
 func printMarkdown(w io.Writer, text string) {
 	md := goldmark.New(
 		goldmark.WithExtensions(
@@ -413,6 +577,7 @@ func createOutputStructure(
 	manifest *v1alpha1.PackageManifest,
 	latestVersion string,
 	repos []v1alpha1.PackageRepository,
+	details describeDetails,
 ) map[string]interface{} {
 	data := map[string]interface{}{
 		"packageName":      manifest.Name,
@@ -420,7 +585,7 @@ func createOutputStructure(
 		"latestVersion":    latestVersion,
 		"status":           "Not Installed",
 		"entrypoints":      manifest.Entrypoints,
-		"dependencies":     manifest.Dependencies,
+		"dependencies":     details.dependencies,
 		"components":       manifest.Components,
 		"longDescription":  strings.TrimSpace(manifest.LongDescription),
 		"repositories":     repositoriesAsMap(pkg, repos),
@@ -434,6 +599,14 @@ func createOutputStructure(
 		data["isUpgradable"] = semver.IsUpgradable(pkg.GetSpec().PackageInfo.Version, latestVersion)
 		data["status"] = client.GetStatusOrPending(pkg).Status
 		data["suspend"] = pkg.GetSpec().Suspend
+		data["paused"] = pkg.IsPaused()
+		data["conditions"] = pkg.GetStatus().Conditions
+		data["resources"] = details.resources
+		data["events"] = details.events
+		if len(pkg.GetSpec().Overlays) > 0 {
+			data["overlays"] = pkg.GetSpec().Overlays
+			data["environment"] = details.environment
+		}
 	}
 	if len(instances) > 0 {
 		data["instances"] = instances
@@ -446,8 +619,9 @@ func printJSON(ctx context.Context,
 	pkgs []v1alpha1.Package,
 	manifest *v1alpha1.PackageManifest,
 	latestVersion string,
-	repos []v1alpha1.PackageRepository) {
-	output := createOutputStructure(ctx, pkg, pkgs, manifest, latestVersion, repos)
+	repos []v1alpha1.PackageRepository,
+	details describeDetails) {
+	output := createOutputStructure(ctx, pkg, pkgs, manifest, latestVersion, repos, details)
 	jsonOutput, err := json.MarshalIndent(output, "", "  ")
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "❌ Could not marshal JSON output: %v\n", err)
@@ -461,8 +635,9 @@ func printYAML(ctx context.Context,
 	pkgs []v1alpha1.Package,
 	manifest *v1alpha1.PackageManifest,
 	latestVersion string,
-	repos []v1alpha1.PackageRepository) {
-	output := createOutputStructure(ctx, pkg, pkgs, manifest, latestVersion, repos)
+	repos []v1alpha1.PackageRepository,
+	details describeDetails) {
+	output := createOutputStructure(ctx, pkg, pkgs, manifest, latestVersion, repos, details)
 	yamlOutput, err := yaml.Marshal(output)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "❌ Could not marshal YAML output: %v\n", err)
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/clicontext"
+	"github.com/glasskube/glasskube/internal/cliutils"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/manifest/render"
+	"github.com/glasskube/glasskube/internal/names"
+	"github.com/glasskube/glasskube/pkg/update"
+	"github.com/spf13/cobra"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var diffCmdOptions = struct {
+	Version string
+	KindOptions
+	NamespaceOptions
+}{
+	KindOptions: DefaultKindOptions(),
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <package-name>",
+	Short: "Show the changes an update of a package would make",
+	Long: "Show the changes an update of a package would make.\n" +
+		"The resources of the installed version and of the new version are rendered with the current configuration " +
+		"and compared per resource. For helm packages, the HelmRelease is compared.",
+	PreRun: cliutils.SetupClientContext(true, &rootCmdOptions.SkipUpdateCheck),
+	Run:    func(cmd *cobra.Command, args []string) { runDiff(cmd.Context(), args[0]) },
+	Args:   cobra.ExactArgs(1),
+	ValidArgsFunction: installedPackagesCompletionFunc(
+		&diffCmdOptions.NamespaceOptions,
+		&diffCmdOptions.KindOptions,
+	),
+}
+
+func runDiff(ctx context.Context, name string) {
+	pkg, err := getPackageOrClusterPackage(ctx, name, diffCmdOptions.KindOptions, diffCmdOptions.NamespaceOptions)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		cliutils.ExitWithError()
+	}
+
+	version := getDiffVersion(ctx, pkg)
+	if version == "" {
+		fmt.Fprintf(os.Stderr, "☑️  %v is up-to-date\n", pkg.GetName())
+		cliutils.ExitSuccess()
+	}
+
+	repoClient := cliutils.RepositoryClientset(ctx).ForPackage(pkg)
+	client, err := ctrlclient.New(clicontext.ConfigFromContext(ctx), ctrlclient.Options{})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		cliutils.ExitWithError()
+	}
+	renderer, err := render.NewRenderer(client, cliutils.RepositoryClientset(ctx), cliutils.ValueResolver(ctx))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		cliutils.ExitWithError()
+	}
+
+	var packageInfo v1alpha1.PackageInfo
+	if err := cliutils.PackageClient(ctx).PackageInfos().
+		Get(ctx, names.PackageInfoName(pkg), &packageInfo); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ could not get installed manifest of %v: %v\n", pkg.GetName(), err)
+		cliutils.ExitWithError()
+	} else if packageInfo.Status.Manifest == nil {
+		fmt.Fprintf(os.Stderr, "❌ %v has no installed manifest yet\n", pkg.GetName())
+		cliutils.ExitWithError()
+	}
+	current, err := renderer.Render(ctx, pkg, packageInfo.Status.Manifest, packageInfo.Status.ResolvedUrl)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ could not render installed version: %v\n", err)
+		cliutils.ExitWithError()
+	}
+
+	packageName := pkg.GetSpec().PackageInfo.Name
+	var manifest v1alpha1.PackageManifest
+	manifestURL, err := repoClient.GetPackageManifestURL(packageName, version)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		cliutils.ExitWithError()
+	} else if err := repoClient.FetchPackageManifest(packageName, version, &manifest); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ could not fetch manifest of %v in version %v: %v\n", packageName, version, err)
+		cliutils.ExitWithError()
+	}
+	desired, err := renderer.Render(ctx, pkg, &manifest, manifestURL)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ could not render version %v: %v\n", version, err)
+		cliutils.ExitWithError()
+	}
+
+	diffs, err := render.Diff(current, desired)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		cliutils.ExitWithError()
+	}
+
+	fmt.Fprintf(os.Stderr, "Changes for %v: %v -> %v", pkg.GetName(), pkg.GetSpec().PackageInfo.Version, version)
+	if repositoryName := pkg.GetSpec().PackageInfo.RepositoryName; repositoryName != "" {
+		// the repository is fixed once a package is installed, regardless of the priority of other repositories
+		fmt.Fprintf(os.Stderr, " (from repository %v)", repositoryName)
+	}
+	fmt.Fprint(os.Stderr, "\n\n")
+	if len(diffs) == 0 {
+		fmt.Fprintf(os.Stderr, "☑️  no resources are changed\n")
+		cliutils.ExitSuccess()
+	}
+	for _, diff := range diffs {
+		printResourceDiff(diff)
+	}
+	cliutils.ExitSuccess()
+}
+
+// getDiffVersion returns the version that an update of pkg would install, using the same resolution as the update
+// command. If pkg is up-to-date, the empty string is returned.
+func getDiffVersion(ctx context.Context, pkg ctrlpkg.Package) string {
+	updater := update.NewUpdater(ctx)
+	var tx *update.UpdateTransaction
+	var err error
+	if diffCmdOptions.Version != "" {
+		if !strings.HasPrefix(diffCmdOptions.Version, "v") {
+			diffCmdOptions.Version = "v" + diffCmdOptions.Version
+		}
+		tx, err = updater.PrepareForVersion(ctx, pkg, diffCmdOptions.Version)
+	} else {
+		tx, err = updater.Prepare(ctx, update.GetExact([]ctrlpkg.Package{pkg}))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ update preparation failed: %v\n", err)
+		cliutils.ExitWithError()
+	}
+
+	for _, conflictItem := range tx.ConflictItems {
+		for _, conflict := range conflictItem.Conflicts {
+			fmt.Fprintf(os.Stderr, "⚠️  Updating %s would cause a dependency conflict: %s (required: %s, actual: %s)\n",
+				conflictItem.Package.GetName(), conflict.Actual.Name, conflict.Required.Version, conflict.Actual.Version)
+		}
+		if conflictItem.Package.GetName() == pkg.GetName() &&
+			conflictItem.Package.GetNamespace() == pkg.GetNamespace() {
+			return conflictItem.Version
+		}
+	}
+	for _, item := range tx.Items {
+		if item.Package.GetName() == pkg.GetName() && item.Package.GetNamespace() == pkg.GetNamespace() &&
+			item.UpdateRequired() {
+			return item.Version
+		}
+	}
+	return ""
+}
+
+func printResourceDiff(diff render.ResourceDiff) {
+	header := color.New(color.Bold)
+	switch diff.Change {
+	case render.ChangeAdded:
+		header.Add(color.FgGreen)
+		header.Printf("+ %v (added)\n", diff)
+	case render.ChangeRemoved:
+		header.Add(color.FgRed)
+		header.Printf("- %v (removed)\n", diff)
+	default:
+		header.Add(color.FgYellow)
+		header.Printf("~ %v\n", diff)
+	}
+	for _, highlight := range diff.Highlights {
+		color.New(color.Bold).Printf("  %v\n", highlight)
+	}
+	for _, line := range strings.SplitAfter(diff.Diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			fmt.Print(line)
+		case strings.HasPrefix(line, "+"):
+			color.New(color.FgGreen).Print(line)
+		case strings.HasPrefix(line, "-"):
+			color.New(color.FgRed).Print(line)
+		case strings.HasPrefix(line, "@@"):
+			color.New(color.FgCyan).Print(line)
+		default:
+			fmt.Print(line)
+		}
+	}
+	fmt.Println()
+}
+
+func init() {
+	diffCmd.Flags().StringVarP(&diffCmdOptions.Version, "version", "v", "",
+		"Show the changes of an update to a specific version")
+	_ = diffCmd.RegisterFlagCompletionFunc("version", completeUpgradablePackageVersions)
+	diffCmdOptions.KindOptions.AddFlagsToCommand(diffCmd)
+	diffCmdOptions.NamespaceOptions.AddFlagsToCommand(diffCmd)
+	RootCmd.AddCommand(diffCmd)
+}
@@ -1,13 +1,65 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 )
 
+type dryRunStrategy string
+
+const (
+	dryRunNone   dryRunStrategy = "none"
+	dryRunClient dryRunStrategy = "client"
+	dryRunServer dryRunStrategy = "server"
+)
+
 type DryRunOptions struct {
-	DryRun bool
+	DryRun   bool
+	Strategy dryRunStrategy
 }
 
 func (opt *DryRunOptions) AddFlagsToCommand(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&opt.DryRun, "dry-run", false, "Simulate the execution of the command without making any changes")
 }
+
+// AddStrategyFlagsToCommand adds a --dry-run flag that additionally accepts a strategy.
+// Using --dry-run without a value is the same as --dry-run=server.
+func (opt *DryRunOptions) AddStrategyFlagsToCommand(cmd *cobra.Command) {
+	flag := cmd.Flags().VarPF((*dryRunStrategyValue)(opt), "dry-run", "",
+		"Simulate the execution of the command without making any changes.\n"+
+			"With \"client\", the resulting resources are only printed and nothing is sent to the cluster. "+
+			"With \"server\", the resources are submitted to the cluster in dry-run mode.")
+	flag.NoOptDefVal = string(dryRunServer)
+}
+
+func (opt *DryRunOptions) IsClientDryRun() bool {
+	return opt.DryRun && opt.Strategy == dryRunClient
+}
+
+type dryRunStrategyValue DryRunOptions
+
+func (v *dryRunStrategyValue) String() string {
+	if !v.DryRun {
+		return string(dryRunNone)
+	}
+	return string(v.Strategy)
+}
+
+func (v *dryRunStrategyValue) Set(value string) error {
+	switch value {
+	case string(dryRunNone), "false":
+		v.DryRun, v.Strategy = false, dryRunNone
+	case string(dryRunClient):
+		v.DryRun, v.Strategy = true, dryRunClient
+	case string(dryRunServer), "true":
+		v.DryRun, v.Strategy = true, dryRunServer
+	default:
+		return fmt.Errorf("invalid dry-run strategy %q (allowed: none, client, server)", value)
+	}
+	return nil
+}
+
+func (v *dryRunStrategyValue) Type() string {
+	return "strategy"
+}
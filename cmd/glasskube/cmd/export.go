@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/clientutils"
+	"github.com/glasskube/glasskube/internal/cliutils"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/manifestvalues"
+	"github.com/glasskube/glasskube/internal/maputils"
+	repoclient "github.com/glasskube/glasskube/internal/repo/client"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+)
+
+var exportCmdOptions = struct {
+	InlineSecrets bool
+	Pin           bool
+	OverlaysDir   string
+}{}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export installed packages as a YAML bundle",
+	Long: "Export all installed packages and clusterpackages, including their configuration, as a YAML bundle.\n" +
+		"Values referencing a ConfigMap or another package are resolved, values referencing a Secret are exported " +
+		"as references, unless --inline-secrets is given.\n" +
+		"With --pin, every package is pinned to the digest of its installed manifest, so that installing the bundle " +
+		"fails instead of silently deploying a manifest that has changed in the repository.\n" +
+		"With --overlays-dir, the overlays of the packages are not part of the bundle. Instead, the overlay of every " +
+		"environment is written to <dir>/<environment>.yaml as a bundle of patches that only contain the values of " +
+		"the overlay, e.g. to be applied with kustomize.\n" +
+		"The bundle can be installed using \"glasskube install -f <file>\".",
+	Args:   cobra.NoArgs,
+	PreRun: cliutils.SetupClientContext(true, &rootCmdOptions.SkipUpdateCheck),
+	Run:    runExport,
+}
+
+func runExport(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+	pkgClient := cliutils.PackageClient(ctx)
+	valueResolver := cliutils.ValueResolver(ctx)
+	repoClientset := cliutils.RepositoryClientset(ctx)
+
+	var pkgs []ctrlpkg.Package
+	var clpkgList v1alpha1.ClusterPackageList
+	if err := pkgClient.ClusterPackages().GetAll(ctx, &clpkgList); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ could not list clusterpackages: %v\n", err)
+		cliutils.ExitWithError()
+	}
+	for i := range clpkgList.Items {
+		pkgs = append(pkgs, &clpkgList.Items[i])
+	}
+	var pkgList v1alpha1.PackageList
+	if err := pkgClient.Packages("").GetAll(ctx, &pkgList); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ could not list packages: %v\n", err)
+		cliutils.ExitWithError()
+	}
+	for i := range pkgList.Items {
+		pkgs = append(pkgs, &pkgList.Items[i])
+	}
+
+	if len(pkgs) == 0 {
+		fmt.Fprintln(os.Stderr, "No packages installed")
+		cliutils.ExitSuccess()
+	}
+
+	for _, pkg := range pkgs {
+		pkg.GetSpec().Values = exportValues(ctx, valueResolver, pkg, pkg.GetSpec().Values, exportCmdOptions.InlineSecrets)
+		for environment, overlay := range pkg.GetSpec().Overlays {
+			pkg.GetSpec().Overlays[environment] =
+				exportValues(ctx, valueResolver, pkg, overlay, exportCmdOptions.InlineSecrets)
+		}
+		if exportCmdOptions.Pin {
+			pkg.GetSpec().PackageInfo.Digest = exportDigest(repoClientset, pkg)
+		}
+		// the hash is specific to the current cluster state and must not be carried over
+		annotations := pkg.GetAnnotations()
+		delete(annotations, v1alpha1.AnnotationPackageSpecHashed)
+		pkg.SetAnnotations(annotations)
+	}
+
+	var overlays map[string][]ctrlpkg.Package
+	if exportCmdOptions.OverlaysDir != "" {
+		overlays = splitOverlays(pkgs)
+	}
+
+	if output, err := clientutils.Format(clientutils.OutputFormatYAML, false, pkgs...); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ could not export packages: %v\n", err)
+		cliutils.ExitWithError()
+	} else {
+		fmt.Print(output)
+	}
+
+	for _, environment := range maputils.KeysSorted(overlays) {
+		file, err := writeOverlay(exportCmdOptions.OverlaysDir, environment, overlays[environment])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ could not export overlay %v: %v\n", environment, err)
+			cliutils.ExitWithError()
+		}
+		fmt.Fprintf(os.Stderr, "✅ Overlay %v exported to %v\n", environment, file)
+	}
+}
+
+// splitOverlays removes the overlays from the given packages. It returns copies of the packages that only contain the
+// values of the overlay, grouped by environment.
+func splitOverlays(pkgs []ctrlpkg.Package) map[string][]ctrlpkg.Package {
+	result := make(map[string][]ctrlpkg.Package)
+	for _, pkg := range pkgs {
+		for _, environment := range maputils.KeysSorted(pkg.GetSpec().Overlays) {
+			patch := pkg.DeepCopyObject().(ctrlpkg.Package)
+			*patch.GetSpec() = v1alpha1.PackageSpec{Values: pkg.GetSpec().Overlays[environment]}
+			result[environment] = append(result[environment], patch)
+		}
+		pkg.GetSpec().Overlays = nil
+	}
+	return result
+}
+
+// writeOverlay writes the patches of one environment to <dir>/<environment>.yaml. Only the identifying fields and
+// the values of the patches are written, so that they can be merged into the base bundle.
+func writeOverlay(dir, environment string, patches []ctrlpkg.Package) (string, error) {
+	if environment == "" || filepath.Base(environment) != environment {
+		return "", fmt.Errorf("invalid environment name %q", environment)
+	}
+	var buffer bytes.Buffer
+	for _, patch := range patches {
+		gvk := patch.GetObjectKind().GroupVersionKind()
+		if gvks, _, err := scheme.Scheme.ObjectKinds(patch); err == nil && len(gvks) == 1 {
+			gvk = gvks[0]
+		}
+		metadata := map[string]any{"name": patch.GetName()}
+		if patch.GetNamespace() != "" {
+			metadata["namespace"] = patch.GetNamespace()
+		}
+		data, err := yaml.Marshal(map[string]any{
+			"apiVersion": gvk.GroupVersion().String(),
+			"kind":       gvk.Kind,
+			"metadata":   metadata,
+			"spec":       map[string]any{"values": patch.GetSpec().Values},
+		})
+		if err != nil {
+			return "", err
+		}
+		buffer.WriteString("---\n")
+		buffer.Write(data)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	file := filepath.Join(dir, environment+".yaml")
+	return file, os.WriteFile(file, buffer.Bytes(), 0o644)
+}
+
+// exportValues resolves all value references of the given values of pkg, except for secret references (unless
+// inlineSecrets is set). References that can not be resolved are exported as they are.
+func exportValues(
+	ctx context.Context,
+	valueResolver *manifestvalues.Resolver,
+	pkg ctrlpkg.Package,
+	values map[string]v1alpha1.ValueConfiguration,
+	inlineSecrets bool,
+) map[string]v1alpha1.ValueConfiguration {
+	result := make(map[string]v1alpha1.ValueConfiguration, len(values))
+	for name, value := range values {
+		if value.ValueFrom == nil || (value.ValueFrom.SecretRef != nil && !inlineSecrets) {
+			result[name] = value
+		} else if resolved, err := valueResolver.ResolveValue(ctx, value); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  value %v of %v can not be resolved and is exported as reference: %v\n",
+				name, pkg.GetName(), err)
+			result[name] = value
+		} else {
+			result[name] = v1alpha1.ValueConfiguration{
+				InlineValueConfiguration: v1alpha1.InlineValueConfiguration{Value: &resolved},
+			}
+		}
+	}
+	return result
+}
+
+// exportDigest returns the digest of the installed manifest of the given package. Packages that were installed before
+// digests were recorded in the status do not have one, so the manifest is fetched from the repository instead.
+func exportDigest(repoClientset repoclient.RepoClientset, pkg ctrlpkg.Package) string {
+	if digest := pkg.GetStatus().Digest; digest != "" {
+		return digest
+	}
+	info := pkg.GetSpec().PackageInfo
+	var manifest v1alpha1.PackageManifest
+	digest, err := repoclient.FetchPackageManifestWithDigest(
+		repoClientset.ForPackage(pkg), info.Name, info.Version, "", &manifest)
+	if err == nil && digest == "" {
+		err = errors.New("the repository does not support digests")
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  %v can not be pinned and is exported without digest: %v\n", pkg.GetName(), err)
+		return ""
+	}
+	return digest
+}
+
+func init() {
+	exportCmd.Flags().BoolVar(&exportCmdOptions.InlineSecrets, "inline-secrets", false,
+		"Resolve values referencing a Secret and include them in the bundle in plain text")
+	exportCmd.Flags().BoolVar(&exportCmdOptions.Pin, "pin", false,
+		"Pin every package to the digest of its installed manifest")
+	exportCmd.Flags().StringVar(&exportCmdOptions.OverlaysDir, "overlays-dir", "",
+		"Export the overlays of all packages to one file per environment in this directory instead of the bundle")
+	RootCmd.AddCommand(exportCmd)
+}
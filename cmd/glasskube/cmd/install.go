@@ -1,42 +1,57 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	v1 "k8s.io/api/core/v1"
 
 	"github.com/glasskube/glasskube/internal/clientutils"
+	"github.com/glasskube/glasskube/internal/completion"
 	"github.com/glasskube/glasskube/internal/namespaces"
 
 	"github.com/fatih/color"
 	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/audit"
 	"github.com/glasskube/glasskube/internal/clicontext"
 	"github.com/glasskube/glasskube/internal/cliutils"
 	"github.com/glasskube/glasskube/internal/config"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
 	"github.com/glasskube/glasskube/internal/dependency"
+	deputil "github.com/glasskube/glasskube/internal/dependency/util"
+	"github.com/glasskube/glasskube/internal/lockfile"
+	"github.com/glasskube/glasskube/internal/manifest/render"
 	"github.com/glasskube/glasskube/internal/manifestvalues/cli"
-	"github.com/glasskube/glasskube/internal/maputils"
 	"github.com/glasskube/glasskube/internal/repo"
 	repoclient "github.com/glasskube/glasskube/internal/repo/client"
-	repotypes "github.com/glasskube/glasskube/internal/repo/types"
-	"github.com/glasskube/glasskube/internal/util"
 	"github.com/glasskube/glasskube/pkg/client"
 	"github.com/glasskube/glasskube/pkg/condition"
 	"github.com/glasskube/glasskube/pkg/install"
 	"github.com/glasskube/glasskube/pkg/statuswriter"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var installCmdOptions = struct {
 	cli.ValuesOptions
-	Version           string
-	Repository        string
-	EnableAutoUpdates bool
-	NoWait            bool
-	Yes               bool
+	Version              string
+	Digest               string
+	Repository           string
+	File                 string
+	EnableAutoUpdates    bool
+	NoWait               bool
+	Yes                  bool
+	CreateNamespace      bool
+	WriteLockfile        bool
+	Frozen               bool
+	Lockfile             string
+	Atomic               bool
+	OptionalDependencies []string
+	StrictQuota          bool
 	OutputOptions
 	NamespaceOptions
 	DryRunOptions
@@ -45,14 +60,40 @@ var installCmdOptions = struct {
 }
 
 var installCmd = &cobra.Command{
-	Use:               "install <package-name> [<name>]",
-	Short:             "Install a package",
-	Long:              `Install a package.`,
-	Args:              cobra.RangeArgs(1, 2),
+	Use:   "install <package-name> [<name>]",
+	Short: "Install a package",
+	Long: `Install a package.
+Use --file to install all packages from a bundle created with "glasskube export".
+Use --write-lockfile to record the resolved versions of the package and all its dependencies in a lockfile and
+--frozen to install exactly these versions later.
+Use --atomic to install the latest version of multiple packages together (e.g. "glasskube install --atomic a b c"):
+their dependencies are resolved together and if any package does not become ready, all of them are uninstalled again.
+Without --atomic, at most one package and the name of its installation can be given.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if installCmdOptions.File != "" {
+			return cobra.NoArgs(cmd, args)
+		} else if installCmdOptions.Atomic {
+			if err := validateTransactionFlags(); err != nil {
+				return err
+			}
+			return cobra.MinimumNArgs(1)(cmd, args)
+		} else if len(args) > 2 {
+			return fmt.Errorf("accepts at most 2 arg(s), received %d (use --atomic to install multiple packages)",
+				len(args))
+		}
+		return cobra.RangeArgs(1, 2)(cmd, args)
+	},
 	PreRun:            cliutils.SetupClientContext(true, &rootCmdOptions.SkipUpdateCheck),
-	ValidArgsFunction: completeAvailablePackageNames,
+	ValidArgsFunction: completeInstallArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
+		if installCmdOptions.File != "" {
+			runInstallFromFile(ctx, installCmdOptions.File)
+			return
+		} else if installCmdOptions.Atomic {
+			runInstallTransaction(ctx, args)
+			return
+		}
 		config := clicontext.RawConfigFromContext(ctx)
 		pkgClient := clicontext.PackageClientFromContext(ctx)
 		dm := cliutils.DependencyManager(ctx)
@@ -62,54 +103,54 @@ var installCmd = &cobra.Command{
 		cs := clicontext.KubernetesClientFromContext(ctx)
 
 		opts := metav1.CreateOptions{}
-		if installCmdOptions.DryRun {
+		if installCmdOptions.IsClientDryRun() {
+			fmt.Fprintln(os.Stderr,
+				"🔎 Client-side dry-run mode is enabled. Nothing will be sent to the cluster.")
+		} else if installCmdOptions.DryRun {
 			opts.DryRun = []string{metav1.DryRunAll}
 			fmt.Fprintln(os.Stderr,
 				"🔎 Dry-run mode is enabled. Nothing will be changed.")
 		}
 
 		if !rootCmdOptions.NoProgress {
-			installer.WithStatusWriter(statuswriter.Spinner())
+			installer.WithStatusWriter(statuswriter.Progress())
 		}
 
 		bold := color.New(color.Bold).SprintFunc()
 		packageName := args[0]
 		pkgBuilder := client.PackageBuilder(packageName)
 		var repoClient repoclient.RepoClient
+		var repoResolution *repoclient.RepositoryResolution
+
+		var lock *lockfile.Lockfile
+		if installCmdOptions.Frozen {
+			if l, err := lockfile.Read(installCmdOptions.Lockfile); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ Could not read lockfile: %v\n", err)
+				cliutils.ExitWithError()
+			} else if l.Package.Name != packageName {
+				fmt.Fprintf(os.Stderr, "❌ Lockfile %v was written for %v, not for %v\n",
+					installCmdOptions.Lockfile, l.Package.Name, packageName)
+				cliutils.ExitWithError()
+			} else {
+				lock = l
+			}
+			installCmdOptions.Repository = lock.Package.Repository
+			installCmdOptions.Version = lock.Package.Version
+			installCmdOptions.Digest = lock.Package.Digest
+		}
 
 		if len(installCmdOptions.Repository) > 0 {
 			repoClient = repoClientset.ForRepoWithName(installCmdOptions.Repository)
 			pkgBuilder.WithRepositoryName(installCmdOptions.Repository)
 		} else {
-			repos, err := repoClientset.Meta().GetReposForPackage(packageName)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "❗ Error: could not collect repository list: %v\n", err)
-			}
-			switch len(repos) {
-			case 0:
-				fmt.Fprintf(os.Stderr, "❗ Error: %v is not available\n", packageName)
-				cliutils.ExitWithError()
-			case 1:
-				repoClient = repoClientset.ForRepo(repos[0])
-				pkgBuilder.WithRepositoryName(repos[0].Name)
-			default:
-				names := make([]string, len(repos))
-				for i := range repos {
-					names[i] = repos[i].Name
-				}
-				for {
-					fmt.Fprintf(os.Stderr,
-						"%v is available from %v repositories. Please select the one to install from.\n",
-						packageName, len(names))
-					if repoName, err := cliutils.GetOption("", names); err != nil {
-						fmt.Fprintf(os.Stderr, "invalid input: %v\n", err)
-					} else {
-						repoClient = repoClientset.ForRepoWithName(repoName)
-						pkgBuilder.WithRepositoryName(repoName)
-						break
-					}
-				}
-			}
+			var repoName string
+			repoClient, repoName, repoResolution = selectRepository(repoClientset, packageName)
+			pkgBuilder.WithRepositoryName(repoName)
+		}
+
+		if installCmdOptions.Digest != "" && installCmdOptions.Version == "" {
+			fmt.Fprintln(os.Stderr, "❌ --digest can only be used together with --version")
+			cliutils.ExitWithError()
 		}
 
 		if installCmdOptions.Version == "" {
@@ -125,10 +166,14 @@ var installCmd = &cobra.Command{
 			installCmdOptions.Version = "v" + installCmdOptions.Version
 		}
 
-		pkgBuilder.WithVersion(installCmdOptions.Version)
+		pkgBuilder.WithVersion(installCmdOptions.Version).WithDigest(installCmdOptions.Digest)
 
 		var manifest v1alpha1.PackageManifest
-		if err := repoClient.FetchPackageManifest(packageName, installCmdOptions.Version, &manifest); err != nil {
+		// if a digest is given, fail early instead of letting the operator reject the manifest after the package has
+		// been created
+		manifestDigest, err := repoclient.FetchPackageManifestWithDigest(repoClient, packageName,
+			installCmdOptions.Version, installCmdOptions.Digest, &manifest)
+		if err != nil {
 			fmt.Fprintf(os.Stderr, "❗ Error: Could not fetch package manifest: %v\n", err)
 			cliutils.ExitWithError()
 		}
@@ -181,14 +226,17 @@ var installCmd = &cobra.Command{
 				pkgBuilder.WithValues(values)
 			}
 		} else {
-			if values, err := cli.Configure(manifest, cli.WithUseDefaults(installCmdOptions.UseDefault)); err != nil {
+			if values, err := cli.Configure(manifest,
+				cli.WithUseDefaults(installCmdOptions.UseDefault),
+				clusterDefaultsOption(ctx),
+			); err != nil {
 				cancel()
 			} else {
 				pkgBuilder.WithValues(values)
 			}
 		}
 
-		if !installCmdOptions.EnableAutoUpdates && !installCmdOptions.Yes {
+		if !installCmdOptions.EnableAutoUpdates && !installCmdOptions.Yes && !installCmdOptions.Frozen {
 			if cliutils.YesNoPrompt("Would you like to enable automatic updates?", false) {
 				installCmdOptions.EnableAutoUpdates = true
 			}
@@ -204,11 +252,15 @@ var installCmd = &cobra.Command{
 		}
 
 		pkgBuilder.WithAutoUpdates(installCmdOptions.EnableAutoUpdates)
+		pkgBuilder.WithOptionalDependencies(selectOptionalDependencies(&manifest))
 
 		pkg := pkgBuilder.Build(manifest.Scope)
 
-		if validationResult, err :=
-			dm.Validate(ctx, pkg.GetName(), pkg.GetNamespace(), &manifest, installCmdOptions.Version); err != nil {
+		var installationOrder []dependency.Requirement
+		enabledManifest := deputil.WithEnabledDependencies(manifest, pkg.GetSpec().OptionalDependencies)
+		validationResult, err :=
+			dm.Validate(ctx, pkg.GetName(), pkg.GetNamespace(), &enabledManifest, installCmdOptions.Version)
+		if err != nil {
 			fmt.Fprintf(os.Stderr, "❗ Error: Could not validate dependencies: %v\n", err)
 			cliutils.ExitWithError()
 		} else if len(validationResult.Conflicts) > 0 {
@@ -217,6 +269,27 @@ var installCmd = &cobra.Command{
 			cliutils.ExitWithError()
 		} else if len(validationResult.Requirements) > 0 {
 			installationPlan = append(installationPlan, validationResult.Requirements...)
+			installationOrder = validationResult.InstallationOrder
+		}
+
+		var resolvedDependencies []lockfile.LockedPackage
+		if installCmdOptions.Frozen || installCmdOptions.WriteLockfile {
+			resolvedDependencies, err = lockfile.Dependencies(validationResult.Graph, pkg.GetName(), pkg.GetNamespace())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "❗ Error: Could not resolve dependencies: %v\n", err)
+				cliutils.ExitWithError()
+			}
+		}
+		if lock != nil {
+			if err := lock.Verify(resolvedDependencies); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ The resolved dependencies of %v do not match lockfile %v:\n%v\n",
+					packageName, installCmdOptions.Lockfile, err)
+				cliutils.ExitWithError()
+			} else if err := lock.VerifyDigests(repoClientset); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ The dependencies of %v do not match lockfile %v:\n%v\n",
+					packageName, installCmdOptions.Lockfile, err)
+				cliutils.ExitWithError()
+			}
 		}
 
 		fmt.Fprintln(os.Stderr, bold("Summary:"))
@@ -224,6 +297,10 @@ var installCmd = &cobra.Command{
 		for i, p := range installationPlan {
 			fmt.Fprintf(os.Stderr, "    %v. %v (version %v)\n", i+1, p.Name, p.Version)
 		}
+		if repoResolution != nil {
+			fmt.Fprintf(os.Stderr, " * %v will be installed from repository %v\n", packageName, repoResolution)
+		}
+		printDependencyRepositories(repoClientset, installationPlan[1:])
 		if installCmdOptions.EnableAutoUpdates {
 			fmt.Fprintln(os.Stderr, " * Automatic updates will be", bold("enabled"))
 		} else {
@@ -231,14 +308,17 @@ var installCmd = &cobra.Command{
 		}
 
 		createNamespace := false
-		if installCmdOptions.NamespaceOptions.Namespace != "" {
-			if ok, err := namespaces.Exists(ctx, cs, installCmdOptions.NamespaceOptions.Namespace); !ok {
-				fmt.Fprintf(os.Stderr, " * Namespace %v does not exist and will be created\n",
-					installCmdOptions.NamespaceOptions.Namespace)
-				createNamespace = true
-			} else if err != nil {
+		if pkg.IsNamespaceScoped() {
+			if ok, err := namespaces.Exists(ctx, cs, pkg.GetNamespace()); err != nil {
 				fmt.Fprintf(os.Stderr, "An error occurred in the Namespace check:\n\n%v\n", err)
 				cliutils.ExitWithError()
+			} else if !ok && !installCmdOptions.CreateNamespace {
+				fmt.Fprintf(os.Stderr, "❌ Namespace %v does not exist. Use --create-namespace to create it.\n",
+					pkg.GetNamespace())
+				cliutils.ExitWithError()
+			} else if !ok {
+				fmt.Fprintf(os.Stderr, " * Namespace %v does not exist and will be created\n", pkg.GetNamespace())
+				createNamespace = true
 			}
 		}
 
@@ -250,41 +330,68 @@ var installCmd = &cobra.Command{
 			}
 		}
 
+		checkResourceQuotas(ctx, repoClient, pkg, &manifest)
+
+		if installCmdOptions.IsClientDryRun() {
+			if installCmdOptions.WriteLockfile {
+				writeLockfile(repoClientset, pkg, manifestDigest, resolvedDependencies)
+			}
+			printClientDryRun(pkg, &manifest, installationOrder)
+			return
+		}
+
 		if !installCmdOptions.Yes && !cliutils.YesNoPrompt("Continue?", true) {
 			cancel()
 		}
 
+		if installCmdOptions.WriteLockfile {
+			writeLockfile(repoClientset, pkg, manifestDigest, resolvedDependencies)
+		}
+
 		if createNamespace {
 			ns := &v1.Namespace{
 				ObjectMeta: metav1.ObjectMeta{
-					Name: installCmdOptions.NamespaceOptions.Namespace,
+					Name: pkg.GetNamespace(),
 				},
 			}
-			_, err := cs.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
+			_, err := cs.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{DryRun: opts.DryRun})
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "An error occurred in creating the Namespace:\n\n%v\n", err)
 				cliutils.ExitWithError()
 			}
 		}
+		if lock != nil {
+			installLockedDependencies(ctx, pkgClient, lock, installationOrder, opts)
+		}
 		if installCmdOptions.NoWait {
 			if err := installer.Install(ctx, pkg, opts); err != nil {
 				fmt.Fprintf(os.Stderr, "An error occurred during installation:\n\n%v\n", err)
 				cliutils.ExitWithError()
 			}
+			if !installCmdOptions.DryRun {
+				recordAuditEntry(ctx, audit.OperationInstall, pkg, "", pkg.GetSpec().PackageInfo.Version)
+			}
 			fmt.Fprintf(os.Stderr,
 				"☑️  %v is being installed in the background.\n"+
 					"💡 Run \"glasskube describe %v\" to get the current status\n",
 				packageName, packageName)
 		} else {
-			status, err := installer.InstallBlocking(ctx, pkg, opts)
+			status, err := installer.WithComponents(&manifest).InstallBlocking(ctx, pkg, opts)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "An error occurred during installation:\n\n%v\n", err)
 				cliutils.ExitWithError()
 			}
+			if !installCmdOptions.DryRun {
+				recordAuditEntry(ctx, audit.OperationInstall, pkg, "", pkg.GetSpec().PackageInfo.Version)
+			}
 			if status != nil {
 				switch status.Status {
 				case string(condition.Ready):
 					fmt.Fprintf(os.Stderr, "✅ %v is now installed in %v.\n", packageName, config.CurrentContext)
+					if manifest.PostInstallNotes != "" {
+						fmt.Fprintln(os.Stderr)
+						printPostInstallNotes(ctx, os.Stderr, pkg, &manifest)
+					}
 				default:
 					fmt.Fprintf(os.Stderr, "❌ %v installation has status %v, reason: %v\nMessage: %v\n",
 						packageName, status.Status, status.Reason, status.Message)
@@ -305,6 +412,122 @@ var installCmd = &cobra.Command{
 	},
 }
 
+// selectOptionalDependencies returns the optional dependencies of the manifest that should be installed. These are the
+// ones given via flag or, if none are given, the ones the user selects interactively.
+func selectOptionalDependencies(manifest *v1alpha1.PackageManifest) []string {
+	optional := deputil.OptionalDependencies(manifest)
+	for _, name := range installCmdOptions.OptionalDependencies {
+		if !slices.Contains(optional, name) {
+			fmt.Fprintf(os.Stderr, "❌ %v is not an optional dependency of %v\n", name, manifest.Name)
+			cliutils.ExitWithError()
+		}
+	}
+	if len(installCmdOptions.OptionalDependencies) > 0 || installCmdOptions.Yes || installCmdOptions.Frozen {
+		return installCmdOptions.OptionalDependencies
+	}
+	var selected []string
+	for _, name := range optional {
+		if cliutils.YesNoPrompt(fmt.Sprintf("Would you like to install the optional dependency %v?", name), false) {
+			selected = append(selected, name)
+		}
+	}
+	return selected
+}
+
+// selectRepository returns the repository to install the given package from. If the package is available from
+// multiple repositories and none of them can be chosen automatically, the user is asked to select one.
+func selectRepository(repoClientset repoclient.RepoClientset, packageName string) (
+	repoclient.RepoClient, string, *repoclient.RepositoryResolution) {
+	repos, err := repoClientset.Meta().GetReposForPackage(packageName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❗ Error: could not collect repository list: %v\n", err)
+	}
+	switch len(repos) {
+	case 0:
+		fmt.Fprintf(os.Stderr, "❗ Error: %v is not available\n", packageName)
+		cliutils.ExitWithError()
+		return nil, "", nil
+	case 1:
+		return repoClientset.ForRepo(repos[0]), repos[0].Name, nil
+	}
+	if resolution, err := repoclient.ResolveRepository(packageName, repos); err == nil {
+		return repoClientset.ForRepo(resolution.Repository), resolution.Repository.Name, resolution
+	}
+	names := make([]string, len(repos))
+	for i := range repos {
+		names[i] = repos[i].Name
+	}
+	for {
+		fmt.Fprintf(os.Stderr,
+			"%v is available from %v repositories. Please select the one to install from.\n",
+			packageName, len(names))
+		if repoName, err := cliutils.GetOption("", names); err != nil {
+			fmt.Fprintf(os.Stderr, "invalid input: %v\n", err)
+		} else {
+			return repoClientset.ForRepoWithName(repoName), repoName, nil
+		}
+	}
+}
+
+// checkResourceQuotas warns if the resource requests of pkg would exceed a ResourceQuota of the namespaces its
+// resources are created in. With --strict-quota, the installation is aborted instead.
+func checkResourceQuotas(
+	ctx context.Context,
+	repoClient repoclient.RepoClient,
+	pkg ctrlpkg.Package,
+	manifest *v1alpha1.PackageManifest,
+) {
+	usages, err := projectQuotaUsage(ctx, repoClient, pkg, manifest)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  The resource quotas can not be checked: %v\n", err)
+		return
+	}
+	exceeded := render.Exceeded(usages)
+	if len(exceeded) == 0 {
+		return
+	}
+	fmt.Fprintln(os.Stderr, "⚠️  The resource requests of the package exceed the available quota:")
+	for _, usage := range exceeded {
+		projected := usage.Projected()
+		fmt.Fprintf(os.Stderr, " * %v of %v/%v: %v of %v (%v%%)\n", usage.Resource, usage.Namespace, usage.Quota,
+			projected.String(), usage.Hard.String(), usage.Utilization())
+	}
+	if installCmdOptions.StrictQuota {
+		fmt.Fprintf(os.Stderr, "❌ %v can not be installed, because it would exceed a resource quota\n",
+			pkg.GetName())
+		cliutils.ExitWithError()
+	}
+	fmt.Fprintln(os.Stderr, "   Pods and volume claims that do not fit into the quota will not be created.")
+}
+
+// projectQuotaUsage renders the resources of pkg like the operator would and returns the usage of all ResourceQuotas
+// that limit them after they have been created
+func projectQuotaUsage(
+	ctx context.Context,
+	repoClient repoclient.RepoClient,
+	pkg ctrlpkg.Package,
+	manifest *v1alpha1.PackageManifest,
+) ([]render.QuotaUsage, error) {
+	client, err := ctrlclient.New(clicontext.ConfigFromContext(ctx), ctrlclient.Options{})
+	if err != nil {
+		return nil, err
+	}
+	renderer, err := render.NewRenderer(client, cliutils.RepositoryClientset(ctx), cliutils.ValueResolver(ctx))
+	if err != nil {
+		return nil, err
+	}
+	info := pkg.GetSpec().PackageInfo
+	manifestURL, err := repoClient.GetPackageManifestURL(info.Name, info.Version)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := renderer.Render(ctx, pkg, manifest, manifestURL)
+	if err != nil {
+		return nil, err
+	}
+	return renderer.ProjectQuotaUsage(ctx, objects)
+}
+
 func cancel() {
 	fmt.Fprintf(os.Stderr, "❌ Operation cancelled.")
 	cliutils.ExitWithError()
@@ -318,22 +541,31 @@ func completeAvailablePackageNames(
 	if len(args) > 0 {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
-	cfg, rawCfg := cliutils.RequireConfig(config.Kubeconfig)
-	ctx := util.Must(clicontext.SetupContext(cmd.Context(), cfg, rawCfg))
-	repoClient := cliutils.RepositoryClientset(ctx)
-	var index repotypes.MetaIndex
-	err := repoClient.Meta().FetchMetaIndex(&index)
+	contextName, clientset := completionRepoClientset(cmd)
+	names, err := completion.DefaultCache().PackageNames(contextName, clientset)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error fetching package repository index: %v\n", err)
 		return nil, cobra.ShellCompDirectiveError
 	}
-	names := make([]string, 0, len(index.Packages))
-	for _, pkg := range index.Packages {
-		if toComplete == "" || strings.HasPrefix(pkg.Name, toComplete) {
-			names = append(names, pkg.Name)
-		}
+	return completion.Filter(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeInstallArgs completes one package name or, with --atomic, any number of distinct package names
+func completeInstallArgs(
+	cmd *cobra.Command,
+	args []string,
+	toComplete string,
+) ([]string, cobra.ShellCompDirective) {
+	if !installCmdOptions.Atomic {
+		return completeAvailablePackageNames(cmd, args, toComplete)
 	}
-	return names, cobra.ShellCompDirectiveNoFileComp
+	contextName, clientset := completionRepoClientset(cmd)
+	names, err := completion.DefaultCache().PackageNames(contextName, clientset)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching package repository index: %v\n", err)
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return completion.Filter(names, toComplete, args...), cobra.ShellCompDirectiveNoFileComp
 }
 
 func completeAvailablePackageVersions(
@@ -344,44 +576,145 @@ func completeAvailablePackageVersions(
 	if len(args) == 0 {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
-	packageName := args[0]
-	cfg, rawCfg := cliutils.RequireConfig(config.Kubeconfig)
-	ctx := util.Must(clicontext.SetupContext(cmd.Context(), cfg, rawCfg))
-	repoClient := cliutils.RepositoryClientset(ctx)
-	repos, err := repoClient.Meta().GetReposForPackage(packageName)
+	contextName, clientset := completionRepoClientset(cmd)
+	versions, err := completion.DefaultCache().PackageVersions(contextName, args[0], clientset)
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveError
 	}
-	versionsMap := make(map[string]struct{})
-	for _, r := range repos {
-		var packageIndex repo.PackageIndex
-		if err := repoClient.ForRepo(r).FetchPackageIndex(packageName, &packageIndex); err != nil {
+	return completion.Filter(versions, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionRepoClientset returns the name of the current kubeconfig context, which the completion cache is keyed
+// by, and a function that sets up the repository clientset only if it is actually needed
+func completionRepoClientset(cmd *cobra.Command) (string, completion.ClientsetFunc) {
+	cfg, rawCfg := cliutils.RequireConfig(config.Kubeconfig)
+	return rawCfg.CurrentContext, func() (repoclient.RepoClientset, error) {
+		ctx, err := clicontext.SetupContext(cmd.Context(), cfg, rawCfg)
+		if err != nil {
+			return nil, err
+		}
+		return cliutils.RepositoryClientset(ctx), nil
+	}
+}
+
+// printDependencyRepositories prints which repository the operator will install each of the given requirements from,
+// if it is available from multiple repositories
+func printDependencyRepositories(repoClientset repoclient.RepoClientset, requirements []dependency.Requirement) {
+	for _, req := range requirements {
+		repos, _ := repoClientset.Meta().GetReposForPackage(req.Name)
+		if len(repos) < 2 {
 			continue
 		}
-		for _, version := range packageIndex.Versions {
-			if toComplete == "" || strings.HasPrefix(version.Version, toComplete) {
-				versionsMap[version.Version] = struct{}{}
-			}
+		if resolution, err := repoclient.ResolveRepository(req.Name, repos); err != nil {
+			fmt.Fprintf(os.Stderr, " * ⚠️  %v\n", err)
+		} else {
+			fmt.Fprintf(os.Stderr, " * %v will be installed from repository %v\n", req.Name, resolution)
+		}
+	}
+}
+
+// writeLockfile pins the given package and its resolved dependencies to their repository and manifest digest and writes
+// the result to the path given by --lockfile
+func writeLockfile(
+	repoClientset repoclient.RepoClientset,
+	pkg ctrlpkg.Package,
+	digest string,
+	dependencies []lockfile.LockedPackage,
+) {
+	info := pkg.GetSpec().PackageInfo
+	lock, err := lockfile.New(repoClientset,
+		lockfile.LockedPackage{Name: info.Name, Version: info.Version, Repository: info.RepositoryName, Digest: digest},
+		dependencies)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Could not create lockfile: %v\n", err)
+		cliutils.ExitWithError()
+	}
+	if err := lock.Write(installCmdOptions.Lockfile); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Could not write lockfile: %v\n", err)
+		cliutils.ExitWithError()
+	}
+	fmt.Fprintf(os.Stderr, "🔒 Resolved versions have been written to %v\n", installCmdOptions.Lockfile)
+}
+
+// installLockedDependencies installs all required ClusterPackages with the repository, version and digest of the
+// lockfile before the package itself is installed, so that the operator finds them instead of resolving them again.
+// Components are still installed by the operator, their versions have already been verified against the lockfile.
+func installLockedDependencies(
+	ctx context.Context,
+	pkgClient client.PackageV1Alpha1Client,
+	lock *lockfile.Lockfile,
+	installationOrder []dependency.Requirement,
+	opts metav1.CreateOptions,
+) {
+	for _, req := range installationOrder {
+		if req.ComponentMetadata != nil {
+			continue
+		}
+		locked := lock.Dependency(req.Name)
+		if locked == nil {
+			fmt.Fprintf(os.Stderr, "❌ %v is required, but not locked\n", req.Name)
+			cliutils.ExitWithError()
+		}
+		dep := client.PackageBuilder(locked.Name).
+			WithRepositoryName(locked.Repository).
+			WithVersion(locked.Version).
+			WithDigest(locked.Digest).
+			BuildClusterPackage()
+		dep.SetInstalledAsDependency(true)
+		if err := pkgClient.ClusterPackages().Create(ctx, dep, opts); err != nil {
+			fmt.Fprintf(os.Stderr, "An error occurred during installation of %v:\n\n%v\n", locked.Name, err)
+			cliutils.ExitWithError()
 		}
 	}
-	return maputils.KeysSorted(versionsMap), cobra.ShellCompDirectiveNoFileComp
 }
 
 func init() {
 	installCmd.PersistentFlags().StringVarP(&installCmdOptions.Version, "version", "v", "",
 		"Install a specific version")
 	_ = installCmd.RegisterFlagCompletionFunc("version", completeAvailablePackageVersions)
+	installCmd.PersistentFlags().StringVar(&installCmdOptions.Digest, "digest", "",
+		"Only install the package if the manifest of the given version has this digest (e.g. sha256:…)")
 	installCmd.PersistentFlags().BoolVar(&installCmdOptions.EnableAutoUpdates, "enable-auto-updates", false,
 		"Enable automatic updates for this package")
 	installCmd.PersistentFlags().StringVar(&installCmdOptions.Repository, "repository", installCmdOptions.Repository,
 		"Specify the name of the package repository to install this package from")
+	installCmd.PersistentFlags().StringVarP(&installCmdOptions.File, "file", "f", "",
+		"Install all packages from a bundle file created with \"glasskube export\" (use - for stdin)")
 	installCmd.PersistentFlags().BoolVar(&installCmdOptions.NoWait, "no-wait", false, "Perform non-blocking install")
 	installCmd.PersistentFlags().BoolVarP(&installCmdOptions.Yes, "yes", "y", false, "Do not ask for any confirmation")
+	installCmd.PersistentFlags().BoolVar(&installCmdOptions.CreateNamespace, "create-namespace", false,
+		"Create the namespace of the package if it does not exist")
+	installCmd.PersistentFlags().BoolVar(&installCmdOptions.WriteLockfile, "write-lockfile", false,
+		"Write the resolved versions of the package and all its dependencies to the lockfile")
+	installCmd.PersistentFlags().BoolVar(&installCmdOptions.Frozen, "frozen", false,
+		"Install exactly the versions recorded in the lockfile and fail if that is not possible")
+	installCmd.PersistentFlags().StringVar(&installCmdOptions.Lockfile, "lockfile", lockfile.DefaultPath,
+		"Path of the lockfile used by --write-lockfile and --frozen")
+	installCmd.PersistentFlags().BoolVar(&installCmdOptions.Atomic, "atomic", false,
+		"Install all given packages together and uninstall them again if any of them does not become ready")
+	installCmd.PersistentFlags().StringArrayVar(&installCmdOptions.OptionalDependencies, "optional-dependency", nil,
+		"Install the given optional dependency of the package (can be used multiple times)")
+	installCmd.PersistentFlags().BoolVar(&installCmdOptions.StrictQuota, "strict-quota", false,
+		"Abort the installation if the resource requests of the package exceed a resource quota of its namespace")
 	installCmdOptions.ValuesOptions.AddFlagsToCommand(installCmd)
 	installCmdOptions.OutputOptions.AddFlagsToCommand(installCmd)
 	installCmdOptions.NamespaceOptions.AddFlagsToCommand(installCmd)
-	installCmdOptions.DryRunOptions.AddFlagsToCommand(installCmd)
+	installCmdOptions.DryRunOptions.AddStrategyFlagsToCommand(installCmd)
 	installCmd.MarkFlagsMutuallyExclusive("version", "enable-auto-updates")
 	installCmd.MarkFlagsMutuallyExclusive("no-wait", "dry-run")
+	installCmd.MarkFlagsMutuallyExclusive("file", "version")
+	installCmd.MarkFlagsMutuallyExclusive("file", "digest")
+	installCmd.MarkFlagsMutuallyExclusive("file", "repository")
+	installCmd.MarkFlagsMutuallyExclusive("file", "enable-auto-updates")
+	installCmd.MarkFlagsMutuallyExclusive("file", "write-lockfile")
+	installCmd.MarkFlagsMutuallyExclusive("file", "frozen")
+	installCmd.MarkFlagsMutuallyExclusive("file", "atomic")
+	installCmd.MarkFlagsMutuallyExclusive("file", "optional-dependency")
+	installCmd.MarkFlagsMutuallyExclusive("atomic", "optional-dependency")
+	installCmd.MarkFlagsMutuallyExclusive("frozen", "write-lockfile")
+	installCmd.MarkFlagsMutuallyExclusive("frozen", "version")
+	installCmd.MarkFlagsMutuallyExclusive("frozen", "digest")
+	installCmd.MarkFlagsMutuallyExclusive("frozen", "repository")
+	installCmd.MarkFlagsMutuallyExclusive("frozen", "enable-auto-updates")
 	RootCmd.AddCommand(installCmd)
 }
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/clientutils"
+	"github.com/glasskube/glasskube/internal/cliutils"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/dependency"
+	"github.com/glasskube/glasskube/internal/maputils"
+	"github.com/glasskube/glasskube/pkg/client"
+)
+
+// printClientDryRun prints everything that would be created by installing pkg without sending anything to the cluster.
+// The packages are printed in the order they are expected to become ready, i.e. every package after its dependencies.
+func printClientDryRun(
+	pkg ctrlpkg.Package,
+	manifest *v1alpha1.PackageManifest,
+	installationOrder []dependency.Requirement,
+) {
+	bold := color.New(color.Bold).SprintFunc()
+
+	pkgs := make([]ctrlpkg.Package, 0, len(installationOrder)+1)
+	fmt.Fprintln(os.Stderr, bold("Installation order:"))
+	for i, req := range installationOrder {
+		var kind string
+		if req.ComponentMetadata != nil {
+			kind = fmt.Sprintf("component %v in namespace %v",
+				req.ComponentMetadata.Name, req.ComponentMetadata.Namespace)
+		} else {
+			kind = "dependency"
+		}
+		if req.Transitive {
+			kind = "transitive " + kind
+		}
+		fmt.Fprintf(os.Stderr, "    %v. %v (%v, version %v)\n", i+1, req.Name, kind, req.Version)
+		pkgs = append(pkgs, requirementAsPackage(req, manifest))
+	}
+	fmt.Fprintf(os.Stderr, "    %v. %v (version %v)\n",
+		len(installationOrder)+1, pkg.GetName(), pkg.GetSpec().PackageInfo.Version)
+	pkgs = append(pkgs, pkg)
+
+	if defaulted := defaultedValues(pkg, manifest); len(defaulted) > 0 {
+		fmt.Fprintln(os.Stderr, bold("Defaulted values:"))
+		for _, name := range maputils.KeysSorted(defaulted) {
+			fmt.Fprintf(os.Stderr, " * %v: %v\n", name, defaulted[name])
+		}
+	}
+
+	if installCmdOptions.Output != "" {
+		if output, err := clientutils.Format(installCmdOptions.Output.OutputFormat(), false, pkgs...); err != nil {
+			fmt.Fprintf(os.Stderr, "❗ Error: %v\n", err)
+			cliutils.ExitWithError()
+		} else {
+			fmt.Println(output)
+		}
+	}
+}
+
+// requirementAsPackage creates the package that the operator would create for the given requirement
+func requirementAsPackage(req dependency.Requirement, manifest *v1alpha1.PackageManifest) ctrlpkg.Package {
+	builder := client.PackageBuilder(req.Name).WithVersion(req.Version)
+	var pkg ctrlpkg.Package
+	if req.ComponentMetadata != nil {
+		if !req.Transitive {
+			for _, cmp := range manifest.Components {
+				if cmp.Name == req.Name {
+					builder.WithValues(cmp.Values.AsPackageValues())
+				}
+			}
+		}
+		pkg = builder.WithName(req.ComponentMetadata.Name).WithNamespace(req.ComponentMetadata.Namespace).BuildPackage()
+	} else {
+		pkg = builder.BuildClusterPackage()
+	}
+	pkg.SetInstalledAsDependency(true)
+	return pkg
+}
+
+// defaultedValues returns the default value of every value definition that is not configured in pkg
+func defaultedValues(pkg ctrlpkg.Package, manifest *v1alpha1.PackageManifest) map[string]string {
+	result := make(map[string]string)
+	for name, def := range manifest.ValueDefinitions {
+		if _, ok := pkg.GetSpec().Values[name]; !ok && def.DefaultValue != "" {
+			result[name] = def.DefaultValue
+		}
+	}
+	return result
+}
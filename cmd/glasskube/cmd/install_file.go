@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/clicontext"
+	"github.com/glasskube/glasskube/internal/clientutils"
+	"github.com/glasskube/glasskube/internal/cliutils"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/maputils"
+	"github.com/glasskube/glasskube/internal/namespaces"
+	"github.com/glasskube/glasskube/pkg/condition"
+	"github.com/glasskube/glasskube/pkg/install"
+	"github.com/glasskube/glasskube/pkg/statuswriter"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// runInstallFromFile installs all packages contained in a YAML bundle, e.g. created by "glasskube export".
+func runInstallFromFile(ctx context.Context, fileName string) {
+	config := clicontext.RawConfigFromContext(ctx)
+	pkgClient := clicontext.PackageClientFromContext(ctx)
+	cs := clicontext.KubernetesClientFromContext(ctx)
+	installer := install.NewInstaller(pkgClient)
+	bold := color.New(color.Bold).SprintFunc()
+
+	pkgs, err := readPackageBundle(fileName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ could not read %v: %v\n", fileName, err)
+		cliutils.ExitWithError()
+	} else if len(pkgs) == 0 {
+		fmt.Fprintf(os.Stderr, "%v does not contain any packages\n", fileName)
+		cliutils.ExitSuccess()
+	}
+
+	opts := metav1.CreateOptions{}
+	if installCmdOptions.IsClientDryRun() {
+		fmt.Fprintln(os.Stderr,
+			"🔎 Client-side dry-run mode is enabled. Nothing will be sent to the cluster.")
+	} else if installCmdOptions.DryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+		fmt.Fprintln(os.Stderr,
+			"🔎 Dry-run mode is enabled. Nothing will be changed.")
+	}
+	if !rootCmdOptions.NoProgress {
+		installer.WithStatusWriter(statuswriter.Progress())
+	}
+
+	missingNamespaces := make(map[string]struct{})
+	fmt.Fprintln(os.Stderr, bold("Summary:"))
+	fmt.Fprintf(os.Stderr, " * The following packages will be installed in your cluster (%v):\n", config.CurrentContext)
+	for i, pkg := range pkgs {
+		if pkg.IsNamespaceScoped() {
+			fmt.Fprintf(os.Stderr, "    %v. %v of type %v in namespace %v (version %v)\n", i+1,
+				pkg.GetName(), pkg.GetSpec().PackageInfo.Name, pkg.GetNamespace(), pkg.GetSpec().PackageInfo.Version)
+			if _, ok := missingNamespaces[pkg.GetNamespace()]; !ok {
+				if exists, err := namespaces.Exists(ctx, cs, pkg.GetNamespace()); err != nil {
+					fmt.Fprintf(os.Stderr, "An error occurred in the Namespace check:\n\n%v\n", err)
+					cliutils.ExitWithError()
+				} else if !exists {
+					missingNamespaces[pkg.GetNamespace()] = struct{}{}
+				}
+			}
+		} else {
+			fmt.Fprintf(os.Stderr, "    %v. %v (version %v)\n", i+1,
+				pkg.GetName(), pkg.GetSpec().PackageInfo.Version)
+		}
+	}
+	if len(missingNamespaces) > 0 && !installCmdOptions.CreateNamespace {
+		fmt.Fprintf(os.Stderr, "❌ Namespaces %v do not exist. Use --create-namespace to create them.\n",
+			strings.Join(maputils.KeysSorted(missingNamespaces), ", "))
+		cliutils.ExitWithError()
+	}
+	for ns := range missingNamespaces {
+		fmt.Fprintf(os.Stderr, " * Namespace %v does not exist and will be created\n", ns)
+	}
+
+	if installCmdOptions.IsClientDryRun() {
+		if installCmdOptions.Output != "" {
+			if output, err := clientutils.Format(installCmdOptions.Output.OutputFormat(), false, pkgs...); err != nil {
+				fmt.Fprintf(os.Stderr, "❗ Error: %v\n", err)
+				cliutils.ExitWithError()
+			} else {
+				fmt.Println(output)
+			}
+		}
+		return
+	}
+
+	if !installCmdOptions.Yes && !cliutils.YesNoPrompt("Continue?", true) {
+		cancel()
+	}
+
+	for ns := range missingNamespaces {
+		namespace := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}}
+		if _, err := cs.CoreV1().Namespaces().Create(ctx, &namespace, opts); err != nil {
+			fmt.Fprintf(os.Stderr, "An error occurred in creating the Namespace:\n\n%v\n", err)
+			cliutils.ExitWithError()
+		}
+	}
+
+	var failed bool
+	for _, pkg := range pkgs {
+		if installCmdOptions.NoWait {
+			if err := installer.Install(ctx, pkg, opts); apierrors.IsAlreadyExists(err) {
+				fmt.Fprintf(os.Stderr, "⏭️  %v is already installed\n", pkg.GetName())
+			} else if err != nil {
+				fmt.Fprintf(os.Stderr, "❌ An error occurred during installation of %v: %v\n", pkg.GetName(), err)
+				failed = true
+			} else {
+				fmt.Fprintf(os.Stderr, "☑️  %v is being installed in the background.\n", pkg.GetName())
+			}
+		} else if status, err := installer.InstallBlocking(ctx, pkg, opts); apierrors.IsAlreadyExists(err) {
+			fmt.Fprintf(os.Stderr, "⏭️  %v is already installed\n", pkg.GetName())
+		} else if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ An error occurred during installation of %v: %v\n", pkg.GetName(), err)
+			failed = true
+		} else if status != nil && status.Status == string(condition.Ready) {
+			fmt.Fprintf(os.Stderr, "✅ %v is now installed in %v.\n", pkg.GetName(), config.CurrentContext)
+		} else if status != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v installation has status %v, reason: %v\nMessage: %v\n",
+				pkg.GetName(), status.Status, status.Reason, status.Message)
+			failed = true
+		}
+	}
+	if failed {
+		cliutils.ExitWithError()
+	}
+}
+
+// readPackageBundle reads all packages and clusterpackages from a (multi-document) YAML or JSON file.
+// If fileName is "-", the bundle is read from stdin.
+func readPackageBundle(fileName string) ([]ctrlpkg.Package, error) {
+	var reader io.Reader
+	if fileName == "-" {
+		reader = os.Stdin
+	} else if file, err := os.Open(fileName); err != nil {
+		return nil, err
+	} else {
+		defer func() { _ = file.Close() }()
+		reader = file
+	}
+
+	var pkgs []ctrlpkg.Package
+	decoder := yaml.NewYAMLOrJSONDecoder(reader, 4096)
+	for {
+		var obj map[string]any
+		if err := decoder.Decode(&obj); errors.Is(err, io.EOF) {
+			return pkgs, nil
+		} else if err != nil {
+			return nil, err
+		} else if obj == nil {
+			// empty document
+			continue
+		}
+		var pkg ctrlpkg.Package
+		switch kind := obj["kind"]; kind {
+		case "ClusterPackage":
+			pkg = &v1alpha1.ClusterPackage{}
+		case "Package":
+			pkg = &v1alpha1.Package{}
+		default:
+			return nil, fmt.Errorf("unsupported kind: %v", kind)
+		}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, pkg); err != nil {
+			return nil, err
+		}
+		pkgs = append(pkgs, pkg)
+	}
+}
@@ -0,0 +1,285 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/audit"
+	"github.com/glasskube/glasskube/internal/clicontext"
+	"github.com/glasskube/glasskube/internal/clientutils"
+	"github.com/glasskube/glasskube/internal/cliutils"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/dependency"
+	deputil "github.com/glasskube/glasskube/internal/dependency/util"
+	"github.com/glasskube/glasskube/internal/manifestvalues/cli"
+	"github.com/glasskube/glasskube/internal/namespaces"
+	"github.com/glasskube/glasskube/internal/repo"
+	repoclient "github.com/glasskube/glasskube/internal/repo/client"
+	"github.com/glasskube/glasskube/pkg/client"
+	"github.com/glasskube/glasskube/pkg/condition"
+	"github.com/glasskube/glasskube/pkg/install"
+	"github.com/glasskube/glasskube/pkg/statuswriter"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// runInstallTransaction installs the latest version of all given packages together. The dependencies of all packages
+// are resolved at once and if any package does not become ready, all of them are uninstalled again.
+func runInstallTransaction(ctx context.Context, packageNames []string) {
+	config := clicontext.RawConfigFromContext(ctx)
+	pkgClient := clicontext.PackageClientFromContext(ctx)
+	cs := clicontext.KubernetesClientFromContext(ctx)
+	dm := cliutils.DependencyManager(ctx)
+	repoClientset := cliutils.RepositoryClientset(ctx)
+	installer := install.NewInstaller(pkgClient)
+	bold := color.New(color.Bold).SprintFunc()
+
+	opts := metav1.CreateOptions{}
+	if installCmdOptions.IsClientDryRun() {
+		fmt.Fprintln(os.Stderr,
+			"🔎 Client-side dry-run mode is enabled. Nothing will be sent to the cluster.")
+	} else if installCmdOptions.DryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+		fmt.Fprintln(os.Stderr,
+			"🔎 Dry-run mode is enabled. Nothing will be changed.")
+	}
+	if !rootCmdOptions.NoProgress {
+		installer.WithStatusWriter(statuswriter.Progress())
+	}
+
+	if !installCmdOptions.EnableAutoUpdates && !installCmdOptions.Yes {
+		if cliutils.YesNoPrompt("Would you like to enable automatic updates for all packages?", false) {
+			installCmdOptions.EnableAutoUpdates = true
+		}
+	}
+
+	namespace := installCmdOptions.GetActualNamespace(ctx)
+	clusterDefaults, err := cliutils.ValueResolver(ctx).ClusterDefaults(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Could not read the cluster defaults: %v\n", err)
+	}
+	planned := make([]dependency.PlannedPackage, 0, len(packageNames))
+	pkgs := make(map[string]ctrlpkg.Package, len(packageNames))
+	for _, packageName := range packageNames {
+		if _, ok := pkgs[packageName]; ok {
+			fmt.Fprintf(os.Stderr, "❌ %v is given more than once\n", packageName)
+			cliutils.ExitWithError()
+		}
+		pkg, manifest := buildTransactionPackage(repoClientset, packageName, namespace, clusterDefaults)
+		pkgs[packageName] = pkg
+		planned = append(planned, dependency.PlannedPackage{
+			Name:      pkg.GetName(),
+			Namespace: pkg.GetNamespace(),
+			Manifest:  deputil.WithEnabledDependencies(*manifest, nil),
+			Version:   pkg.GetSpec().PackageInfo.Version,
+		})
+	}
+
+	validationResult, err := dm.ValidateAll(ctx, planned)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❗ Error: Could not validate dependencies: %v\n", err)
+		cliutils.ExitWithError()
+	} else if len(validationResult.Conflicts) > 0 {
+		fmt.Fprintf(os.Stderr, "❗ Error: The packages cannot be installed together due to conflicts: %v\n",
+			validationResult.Conflicts)
+		cliutils.ExitWithError()
+	}
+
+	// packages are installed after the other given packages they depend on, so the operator does not create them as
+	// dependencies in the meantime
+	ordered := make([]ctrlpkg.Package, len(validationResult.Packages))
+	for i, p := range validationResult.Packages {
+		ordered[i] = pkgs[p.Manifest.Name]
+	}
+
+	fmt.Fprintln(os.Stderr, bold("Summary:"))
+	fmt.Fprintf(os.Stderr, " * The following packages will be installed in your cluster (%v):\n", config.CurrentContext)
+	for i, pkg := range ordered {
+		if pkg.IsNamespaceScoped() {
+			fmt.Fprintf(os.Stderr, "    %v. %v in namespace %v (version %v)\n", i+1,
+				pkg.GetName(), pkg.GetNamespace(), pkg.GetSpec().PackageInfo.Version)
+		} else {
+			fmt.Fprintf(os.Stderr, "    %v. %v (version %v)\n", i+1, pkg.GetName(), pkg.GetSpec().PackageInfo.Version)
+		}
+	}
+	if len(validationResult.InstallationOrder) > 0 {
+		fmt.Fprintln(os.Stderr, " * The following dependencies will be installed as well:")
+		for _, req := range validationResult.InstallationOrder {
+			fmt.Fprintf(os.Stderr, "    - %v (version %v)\n", req.Name, req.Version)
+		}
+		printDependencyRepositories(repoClientset, validationResult.InstallationOrder)
+	}
+	if installCmdOptions.EnableAutoUpdates {
+		fmt.Fprintln(os.Stderr, " * Automatic updates will be", bold("enabled"))
+	} else {
+		fmt.Fprintln(os.Stderr, " * Automatic updates will be", bold("not enabled"))
+	}
+	fmt.Fprintln(os.Stderr, " * If any package does not become ready, all packages will be", bold("uninstalled again"))
+
+	createNamespace := false
+	if anyNamespaced(ordered) {
+		if ok, err := namespaces.Exists(ctx, cs, namespace); err != nil {
+			fmt.Fprintf(os.Stderr, "An error occurred in the Namespace check:\n\n%v\n", err)
+			cliutils.ExitWithError()
+		} else if !ok && !installCmdOptions.CreateNamespace {
+			fmt.Fprintf(os.Stderr, "❌ Namespace %v does not exist. Use --create-namespace to create it.\n", namespace)
+			cliutils.ExitWithError()
+		} else if !ok {
+			fmt.Fprintf(os.Stderr, " * Namespace %v does not exist and will be created\n", namespace)
+			createNamespace = true
+		}
+	}
+
+	if installCmdOptions.IsClientDryRun() {
+		printTransactionOutput(ordered)
+		return
+	}
+
+	if !installCmdOptions.Yes && !cliutils.YesNoPrompt("Continue?", true) {
+		cancel()
+	}
+
+	if createNamespace {
+		ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+		if _, err := cs.CoreV1().Namespaces().Create(ctx, &ns, metav1.CreateOptions{DryRun: opts.DryRun}); err != nil {
+			fmt.Fprintf(os.Stderr, "An error occurred in creating the Namespace:\n\n%v\n", err)
+			cliutils.ExitWithError()
+		}
+	}
+
+	result, err := installer.InstallAllBlocking(ctx, ordered, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "An error occurred during installation:\n\n%v\n", err)
+		cliutils.ExitWithError()
+	}
+	for _, p := range result.Packages {
+		name := p.Package.GetName()
+		switch {
+		case p.Err != nil:
+			fmt.Fprintf(os.Stderr, "❌ %v could not be installed: %v\n", name, p.Err)
+		case p.Status == nil:
+			fmt.Fprintf(os.Stderr, "⏹️  %v did not finish installing\n", name)
+		case p.Status.Status == string(condition.Ready):
+			fmt.Fprintf(os.Stderr, "✅ %v is ready\n", name)
+		default:
+			fmt.Fprintf(os.Stderr, "❌ %v installation has status %v, reason: %v\nMessage: %v\n",
+				name, p.Status.Status, p.Status.Reason, p.Status.Message)
+		}
+	}
+	if !result.Succeeded() {
+		if result.RolledBack {
+			fmt.Fprintln(os.Stderr, "↩️  The installation has been rolled back.")
+		}
+		if result.RollbackErr != nil {
+			fmt.Fprintf(os.Stderr, "❗ Some packages could not be uninstalled and must be removed manually:\n%v\n",
+				result.RollbackErr)
+		}
+		cliutils.ExitWithError()
+	}
+	if !installCmdOptions.DryRun {
+		for _, pkg := range ordered {
+			recordAuditEntry(ctx, audit.OperationInstall, pkg, "", pkg.GetSpec().PackageInfo.Version)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "✅ All %v packages are now installed in %v.\n", len(ordered), config.CurrentContext)
+	printTransactionOutput(ordered)
+}
+
+// buildTransactionPackage creates the package to install the latest version of packageName. Namespaced packages are
+// named like the package and installed in the given namespace.
+// Optional dependencies are not enabled, use a separate installation for packages that need them.
+func buildTransactionPackage(
+	repoClientset repoclient.RepoClientset, packageName, namespace string, clusterDefaults map[string]string,
+) (ctrlpkg.Package, *v1alpha1.PackageManifest) {
+	pkgBuilder := client.PackageBuilder(packageName).WithAutoUpdates(installCmdOptions.EnableAutoUpdates)
+	var repoClient repoclient.RepoClient
+	if installCmdOptions.Repository != "" {
+		repoClient = repoClientset.ForRepoWithName(installCmdOptions.Repository)
+		pkgBuilder.WithRepositoryName(installCmdOptions.Repository)
+	} else {
+		var repoName string
+		repoClient, repoName, _ = selectRepository(repoClientset, packageName)
+		pkgBuilder.WithRepositoryName(repoName)
+	}
+
+	var packageIndex repo.PackageIndex
+	if err := repoClient.FetchPackageIndex(packageName, &packageIndex); err != nil {
+		fmt.Fprintf(os.Stderr, "❗ Error: Could not fetch package metadata of %v: %v\n", packageName, err)
+		cliutils.ExitWithError()
+	}
+	var manifest v1alpha1.PackageManifest
+	if err := repoClient.FetchPackageManifest(packageName, packageIndex.LatestVersion, &manifest); err != nil {
+		fmt.Fprintf(os.Stderr, "❗ Error: Could not fetch package manifest of %v: %v\n", packageName, err)
+		cliutils.ExitWithError()
+	}
+	pkgBuilder.WithVersion(packageIndex.LatestVersion)
+	if !manifest.Scope.IsCluster() {
+		pkgBuilder.WithName(packageName).WithNamespace(namespace)
+	}
+
+	if len(manifest.ValueDefinitions) > 0 {
+		fmt.Fprintf(os.Stderr, "Configuring %v:\n", packageName)
+	}
+	if values, err := cli.Configure(manifest,
+		cli.WithUseDefaults(installCmdOptions.UseDefault),
+		cli.WithClusterDefaults(clusterDefaults),
+	); err != nil {
+		cancel()
+	} else {
+		pkgBuilder.WithValues(values)
+	}
+	return pkgBuilder.Build(manifest.Scope), &manifest
+}
+
+func anyNamespaced(pkgs []ctrlpkg.Package) bool {
+	for _, pkg := range pkgs {
+		if pkg.IsNamespaceScoped() {
+			return true
+		}
+	}
+	return false
+}
+
+func printTransactionOutput(pkgs []ctrlpkg.Package) {
+	if installCmdOptions.Output == "" {
+		return
+	}
+	if output, err := clientutils.Format(installCmdOptions.Output.OutputFormat(), installCmdOptions.ShowAll,
+		pkgs...); err != nil {
+		fmt.Fprintf(os.Stderr, "❗ Error: %v\n", err)
+		cliutils.ExitWithError()
+	} else {
+		fmt.Println(output)
+	}
+}
+
+// validateTransactionFlags rejects the flags of the install command that can only be used for a single package
+func validateTransactionFlags() error {
+	var invalid []string
+	if installCmdOptions.Version != "" {
+		invalid = append(invalid, "--version")
+	}
+	if installCmdOptions.Digest != "" {
+		invalid = append(invalid, "--digest")
+	}
+	if installCmdOptions.WriteLockfile {
+		invalid = append(invalid, "--write-lockfile")
+	}
+	if installCmdOptions.Frozen {
+		invalid = append(invalid, "--frozen")
+	}
+	if installCmdOptions.NoWait {
+		invalid = append(invalid, "--no-wait")
+	}
+	if installCmdOptions.IsValuesSet() {
+		invalid = append(invalid, "--value")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("%v can not be used when installing multiple packages", strings.Join(invalid, ", "))
+	}
+	return nil
+}
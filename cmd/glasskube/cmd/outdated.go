@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/glasskube/glasskube/internal/cliutils"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/repo/types"
+	"github.com/glasskube/glasskube/internal/semver"
+	"github.com/glasskube/glasskube/internal/util"
+	"github.com/glasskube/glasskube/pkg/update"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+var outdatedCmdOptions = struct {
+	OutputOptions
+	KindOptions
+	NamespaceOptions
+}{}
+
+// outdatedPackage is a package with a newer version available in its repository. It is the item type of the JSON and
+// YAML output of the outdated command.
+type outdatedPackage struct {
+	Kind              string `json:"kind"`
+	Name              string `json:"name"`
+	Namespace         string `json:"namespace,omitempty"`
+	PackageName       string `json:"packageName"`
+	Repository        string `json:"repository,omitempty"`
+	CurrentVersion    string `json:"currentVersion"`
+	LatestVersion     string `json:"latestVersion"`
+	VersionConstraint string `json:"versionConstraint,omitempty"`
+	// WithinConstraint is true if the latest version satisfies the version constraint of the package (or if the package
+	// has no constraint), i.e. if the latest version would be installed by "glasskube update"
+	WithinConstraint bool `json:"withinConstraint"`
+	// LatestAllowedVersion is the highest version that satisfies the version constraint. It is only set if the package
+	// has a constraint and the update to this version is possible.
+	LatestAllowedVersion string `json:"latestAllowedVersion,omitempty"`
+}
+
+var outdatedCmd = &cobra.Command{
+	Use:   "outdated",
+	Short: "List installed packages for which a newer version is available",
+	Long: "List installed packages for which a newer version is available.\n" +
+		"The command exits with a non-zero exit code if at least one package is outdated, " +
+		"so that it can be used in CI pipelines.",
+	PreRun: cliutils.SetupClientContext(true, &rootCmdOptions.SkipUpdateCheck),
+	Args:   cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		pkgs, err := getOutdatedCandidates(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ error listing packages: %v\n", err)
+			cliutils.ExitWithError()
+		}
+
+		outdated := make([]outdatedPackage, 0, len(pkgs))
+		for _, pkg := range pkgs {
+			if item, err := getOutdatedPackage(ctx, pkg); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ error checking %v for updates: %v\n", pkg.GetName(), err)
+				cliutils.ExitWithError()
+			} else if item != nil {
+				outdated = append(outdated, *item)
+			}
+		}
+
+		switch outdatedCmdOptions.Output {
+		case outputFormatJSON:
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "    ")
+			if err := enc.Encode(outdated); err != nil {
+				fmt.Fprintf(os.Stderr, "error marshaling data to JSON: %v\n", err)
+				cliutils.ExitWithError()
+			}
+		case outputFormatYAML:
+			if data, err := yaml.Marshal(outdated); err != nil {
+				fmt.Fprintf(os.Stderr, "error marshaling data to YAML: %v\n", err)
+				cliutils.ExitWithError()
+			} else {
+				fmt.Print(string(data))
+			}
+		default:
+			if len(outdated) == 0 {
+				fmt.Fprintln(os.Stderr, "All packages are up-to-date.")
+			} else {
+				printOutdatedTable(outdated)
+			}
+		}
+
+		if len(outdated) > 0 {
+			cliutils.ExitWithError()
+		}
+		cliutils.ExitSuccess()
+	},
+}
+
+func getOutdatedCandidates(ctx context.Context) ([]ctrlpkg.Package, error) {
+	var getters []update.PackagesGetter
+	if outdatedCmdOptions.Namespace != "" {
+		getters = append(getters, update.GetAllPackages(outdatedCmdOptions.Namespace))
+	} else {
+		switch outdatedCmdOptions.Kind {
+		case KindClusterPackage:
+			getters = append(getters, update.GetAllClusterPackages())
+		case KindPackage:
+			getters = append(getters, update.GetAllPackages(""))
+		default:
+			getters = append(getters, update.GetAllClusterPackages(), update.GetAllPackages(""))
+		}
+	}
+	var result []ctrlpkg.Package
+	for _, getter := range getters {
+		if pkgs, err := getter.Get(ctx); err != nil {
+			return nil, err
+		} else {
+			result = append(result, pkgs...)
+		}
+	}
+	return result, nil
+}
+
+// getOutdatedPackage returns nil if the given package is up-to-date
+func getOutdatedPackage(ctx context.Context, pkg ctrlpkg.Package) (*outdatedPackage, error) {
+	spec := pkg.GetSpec()
+	currentVersion := spec.PackageInfo.Version
+	if currentVersion == "" {
+		return nil, nil
+	}
+
+	var idx types.PackageIndex
+	repoClient := cliutils.RepositoryClientset(ctx).ForPackage(pkg)
+	if err := repoClient.FetchPackageIndex(spec.PackageInfo.Name, &idx); err != nil {
+		return nil, err
+	}
+	if idx.LatestVersion == "" || !semver.IsUpgradable(currentVersion, idx.LatestVersion) {
+		return nil, nil
+	}
+
+	kind := "ClusterPackage"
+	if pkg.IsNamespaceScoped() {
+		kind = "Package"
+	}
+	result := outdatedPackage{
+		Kind:              kind,
+		Name:              pkg.GetName(),
+		Namespace:         pkg.GetNamespace(),
+		PackageName:       spec.PackageInfo.Name,
+		Repository:        spec.PackageInfo.RepositoryName,
+		CurrentVersion:    currentVersion,
+		LatestVersion:     idx.LatestVersion,
+		VersionConstraint: pkg.VersionConstraint(),
+		WithinConstraint:  semver.IsUpgradableWithConstraint(currentVersion, idx.LatestVersion, pkg.VersionConstraint()),
+	}
+	if result.VersionConstraint != "" {
+		versions := make([]string, len(idx.Versions))
+		for i, item := range idx.Versions {
+			versions[i] = item.Version
+		}
+		latestAllowed, err := semver.LatestVersionWithConstraint(versions, result.VersionConstraint)
+		if err != nil {
+			return nil, err
+		}
+		if latestAllowed != "" && semver.IsUpgradable(currentVersion, latestAllowed) {
+			result.LatestAllowedVersion = latestAllowed
+		}
+	}
+
+	return &result, nil
+}
+
+func printOutdatedTable(outdated []outdatedPackage) {
+	util.SortBy(outdated, func(item outdatedPackage) string { return item.Namespace + "/" + item.Name })
+	header := []string{"NAME", "PACKAGE", "CURRENT", "LATEST", "CONSTRAINT", "WITHIN CONSTRAINT"}
+	if outdatedCmdOptions.Kind != KindClusterPackage {
+		header = append([]string{"NAMESPACE"}, header...)
+	}
+	err := cliutils.PrintTable(os.Stdout, outdated, header, func(item outdatedPackage) []string {
+		row := []string{
+			item.Name,
+			item.PackageName,
+			item.CurrentVersion,
+			item.LatestVersion,
+			item.VersionConstraint,
+			strconv.FormatBool(item.WithinConstraint),
+		}
+		if outdatedCmdOptions.Kind != KindClusterPackage {
+			row = append([]string{item.Namespace}, row...)
+		}
+		return row
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "There was an error displaying the package table:\n%v\n(This is a bug)\n", err)
+		cliutils.ExitWithError()
+	}
+}
+
+func init() {
+	outdatedCmdOptions.OutputOptions.AddFlagsToCommand(outdatedCmd)
+	outdatedCmdOptions.KindOptions.AddFlagsToCommand(outdatedCmd)
+	outdatedCmdOptions.NamespaceOptions.AddFlagsToCommand(outdatedCmd)
+	RootCmd.AddCommand(outdatedCmd)
+}
@@ -38,11 +38,32 @@ var repoAddCmd = &cobra.Command{
 				Name: repoName,
 			},
 			Spec: v1alpha1.PackageRepositorySpec{
-				Url: repoAddCmdOptions.Url,
+				Url:      repoAddCmdOptions.Url,
+				Priority: repoAddCmdOptions.Priority,
 			},
 		}
 
 		repo.Spec.Auth = repoAddCmdOptions.SetAuth()
+		repo.Spec.Git = repoAddCmdOptions.SetGit(nil)
+		repo.Spec.Helm = repoAddCmdOptions.SetHelm(nil)
+		if signature, err := repoAddCmdOptions.SetSignature(nil); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			cliutils.ExitWithError()
+		} else {
+			repo.Spec.Signature = signature
+		}
+		if tls, err := repoAddCmdOptions.SetTLS(nil); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			cliutils.ExitWithError()
+		} else {
+			repo.Spec.TLS = tls
+		}
+		if proxy, err := repoAddCmdOptions.SetProxy(nil); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			cliutils.ExitWithError()
+		} else {
+			repo.Spec.Proxy = proxy
+		}
 
 		if repoAddCmdOptions.Default {
 			defaultRepo, err = cliutils.GetDefaultRepo(ctx)
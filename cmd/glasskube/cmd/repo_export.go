@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/cliutils"
+	"github.com/glasskube/glasskube/internal/repo/transfer"
+	"github.com/spf13/cobra"
+)
+
+var repoExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the package repositories of the current cluster as YAML",
+	Long: "Export the package repositories of the current cluster as YAML, so that they can be imported into " +
+		"another cluster with \"glasskube repo import\".\n" +
+		"Credentials are only exported as references to Secrets. Inline passwords and tokens are removed, so the " +
+		"referenced Secrets have to be created in the target cluster before importing.",
+	Args:   cobra.NoArgs,
+	PreRun: cliutils.SetupClientContext(true, &rootCmdOptions.SkipUpdateCheck),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		client := cliutils.PackageClient(ctx)
+
+		var repos v1alpha1.PackageRepositoryList
+		if err := client.PackageRepositories().GetAll(ctx, &repos); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ error listing package repositories: %v\n", err)
+			cliutils.ExitWithError()
+		}
+
+		data, removed, err := transfer.Export(repos.Items)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			cliutils.ExitWithError()
+		}
+		for _, name := range slices.Sorted(maps.Keys(removed)) {
+			fmt.Fprintf(os.Stderr, "⚠️  inline credentials of %v are not exported: %v\n",
+				name, strings.Join(removed[name], ", "))
+		}
+		fmt.Print(string(data))
+		cliutils.ExitSuccess()
+	},
+}
+
+func init() {
+	repoCmd.AddCommand(repoExportCmd)
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/glasskube/glasskube/internal/cliutils"
+	"github.com/glasskube/glasskube/internal/repo/transfer"
+	"github.com/spf13/cobra"
+)
+
+var repoImportCmdOptions = struct {
+	Timeout time.Duration
+}{}
+
+var repoImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import package repositories from a YAML file",
+	Long: "Import package repositories from a YAML file created by \"glasskube repo export\". Use - to read from " +
+		"stdin.\n" +
+		"Repositories that do not exist yet are created, existing repositories with the same name are updated. " +
+		"Every imported repository is synced, and its readiness is reported once the sync has finished.",
+	Args:   cobra.ExactArgs(1),
+	PreRun: cliutils.SetupClientContext(true, &rootCmdOptions.SkipUpdateCheck),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		client := cliutils.PackageClient(ctx)
+
+		var reader io.Reader
+		if args[0] == "-" {
+			reader = os.Stdin
+		} else if file, err := os.Open(args[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			cliutils.ExitWithError()
+		} else {
+			defer func() { _ = file.Close() }()
+			reader = file
+		}
+
+		repos, err := transfer.Parse(reader)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ invalid repository file: %v\n", err)
+			cliutils.ExitWithError()
+		} else if len(repos) == 0 {
+			fmt.Fprintln(os.Stderr, "No repositories to import")
+			cliutils.ExitSuccess()
+		}
+
+		fmt.Fprintf(os.Stderr, "⏳ importing %v package repositories\n", len(repos))
+		results, err := transfer.Import(ctx, client, repos, repoImportCmdOptions.Timeout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		}
+
+		failed := err != nil
+		for _, result := range results {
+			action := "updated"
+			if result.Created {
+				action = "created"
+			}
+			switch {
+			case result.Err != nil:
+				failed = true
+				fmt.Fprintf(os.Stderr, "❌ %v\n", result.Err)
+			case result.Ready:
+				fmt.Fprintf(os.Stderr, "✅ package repository %v %v and ready\n", result.Name, action)
+			default:
+				failed = true
+				fmt.Fprintf(os.Stderr, "⚠️  package repository %v %v, but not ready: %v\n",
+					result.Name, action, result.Message)
+			}
+		}
+		if failed {
+			cliutils.ExitWithError()
+		}
+		cliutils.ExitSuccess()
+	},
+}
+
+func init() {
+	repoImportCmd.Flags().DurationVar(&repoImportCmdOptions.Timeout, "timeout", transfer.DefaultTimeout,
+		"Time to wait for the imported repositories to be synced")
+	repoCmd.AddCommand(repoImportCmd)
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/glasskube/glasskube/api/v1alpha1"
 	"github.com/glasskube/glasskube/internal/cliutils"
@@ -32,7 +33,7 @@ var repoListCmd = &cobra.Command{
 		util.SortBy(repos.Items, func(repo v1alpha1.PackageRepository) string { return repo.Name })
 
 		_ = cliutils.PrintTable(os.Stdout, repos.Items,
-			[]string{"NAME", "URL", "DEFAULT", "AUTHENTICATION", "STATUS", "MESSAGE"},
+			[]string{"NAME", "URL", "DEFAULT", "PRIORITY", "AUTHENTICATION", "STATUS", "SYNC DURATION", "MESSAGE"},
 			func(repo v1alpha1.PackageRepository) []string {
 				condition := meta.FindStatusCondition(repo.Status.Conditions, string(condition.Ready))
 				authType := "None"
@@ -54,6 +55,11 @@ var repoListCmd = &cobra.Command{
 					message = condition.Message
 				}
 
+				syncDuration := "-"
+				if repo.Status.LastSyncDuration != nil {
+					syncDuration = repo.Status.LastSyncDuration.Duration.String()
+				}
+
 				isDefRepo := "No"
 				if repo.IsDefaultRepository() {
 					isDefRepo = "Yes"
@@ -63,8 +69,10 @@ var repoListCmd = &cobra.Command{
 					repo.Name,
 					repo.Spec.Url,
 					isDefRepo,
+					strconv.Itoa(repo.Spec.Priority),
 					authType,
 					status,
+					syncDuration,
 					message,
 				}
 			})
@@ -8,7 +8,9 @@ import (
 
 	"github.com/glasskube/glasskube/api/v1alpha1"
 	"github.com/glasskube/glasskube/internal/cliutils"
+	repoclient "github.com/glasskube/glasskube/internal/repo/client"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 )
 
 type repoAuthType string
@@ -39,33 +41,90 @@ const (
 
 type repoOptions struct {
 	Default  bool
+	Priority int
 	Auth     repoAuthType
 	Username string
 	Password string
 	Token    string
 	Url      string
+	Git      bool
+	GitRef   string
+	GitPath  string
+
+	Helm       bool
+	HelmCharts []string
+
+	SignatureKeys     []string
+	RequireSignatures bool
+
+	CAFile                  string
+	CASecret                string
+	ClientCertificateSecret string
+
+	Proxy       string
+	ProxySecret string
+	NoProxy     bool
 }
 
 func (opts *repoOptions) BindToCmdFlags(cmd *cobra.Command, update bool) {
 	cmd.Flags().BoolVar(&opts.Default, "default", opts.Default, "Use this repository as default")
 	cmd.Flags().Var(&opts.Auth, "auth", "Type of authentication")
+	cmd.Flags().IntVar(&opts.Priority, "priority", opts.Priority,
+		"Packages that are available from multiple repositories are installed from the one with the highest priority")
 	if update {
 		cmd.Flags().StringVar(&opts.Url, "url", opts.Url, "New url for the repository")
 	}
 	cmd.Flags().StringVar(&opts.Username, "username", opts.Username, "Username for basic authentication")
 	cmd.Flags().StringVar(&opts.Password, "password", opts.Password, "Password for basic authentication")
 	cmd.Flags().StringVar(&opts.Token, "token", opts.Token, "Token for bearer authentication")
+	cmd.Flags().BoolVar(&opts.Git, "git", opts.Git, "Read the repository from a git repository at the given url")
+	cmd.Flags().StringVar(&opts.GitRef, "git-ref", opts.GitRef,
+		"Branch, tag or commit of the git repository to use (implies --git)")
+	cmd.Flags().StringVar(&opts.GitPath, "git-path", opts.GitPath,
+		"Directory in the git repository that contains the index.yaml (implies --git)")
+	cmd.Flags().BoolVar(&opts.Helm, "helm", opts.Helm,
+		"Present the charts of the Helm chart repository at the given url as packages")
+	cmd.Flags().StringArrayVar(&opts.HelmCharts, "helm-chart", opts.HelmCharts,
+		"Only present this chart of the Helm chart repository as package (can be repeated, implies --helm)")
+	cmd.Flags().StringArrayVar(&opts.SignatureKeys, "signature-key", opts.SignatureKeys,
+		"File containing a PEM encoded public key that is trusted to sign package manifests (can be repeated)")
+	cmd.Flags().BoolVar(&opts.RequireSignatures, "require-signatures", opts.RequireSignatures,
+		"Reject packages from this repository that do not have a valid signature")
+	cmd.Flags().StringVar(&opts.CAFile, "ca-file", opts.CAFile,
+		"File containing PEM encoded CA certificates that are trusted in addition to the system trust store")
+	cmd.Flags().StringVar(&opts.CASecret, "ca-secret", opts.CASecret,
+		"Secret in the glasskube-system namespace whose \"ca.crt\" key contains trusted CA certificates")
+	cmd.Flags().StringVar(&opts.ClientCertificateSecret, "client-cert-secret", opts.ClientCertificateSecret,
+		"TLS Secret in the glasskube-system namespace with a client certificate for mutual TLS")
+	cmd.Flags().StringVar(&opts.Proxy, "proxy", opts.Proxy,
+		"URL of an HTTP or SOCKS5 proxy for this repository, which overrides the proxy environment of the operator")
+	cmd.Flags().StringVar(&opts.ProxySecret, "proxy-secret", opts.ProxySecret,
+		"Secret in the glasskube-system namespace whose \"url\" key contains the proxy URL, e.g. with credentials")
+	cmd.Flags().BoolVar(&opts.NoProxy, "no-proxy", opts.NoProxy,
+		"Access this repository without proxy, even if the operator has a proxy environment")
+	cmd.MarkFlagsMutuallyExclusive("git", "helm")
 	cmd.MarkFlagsMutuallyExclusive("username", "token")
+	cmd.MarkFlagsMutuallyExclusive("proxy", "proxy-secret", "no-proxy")
 	cmd.MarkFlagsMutuallyExclusive("password", "token")
 }
 
 func (opts *repoOptions) Normalize() error {
 	if len(opts.Url) > 0 {
-		if _, err := url.ParseRequestURI(opts.Url); err != nil {
-			return fmt.Errorf("use a valid URL for the package repository (got %v)", opts.Url)
+		if err := repoclient.ValidateURL(opts.Url); err != nil {
+			return fmt.Errorf("use a valid URL for the package repository (got %v): %w", opts.Url, err)
 		}
 	}
 
+	if len(opts.GitRef) > 0 || len(opts.GitPath) > 0 {
+		opts.Git = true
+	}
+	if len(opts.HelmCharts) > 0 {
+		opts.Helm = true
+	}
+	if opts.Git && opts.Helm {
+		return errors.New("a repository can not be a git and a Helm repository at once")
+	}
+
 	if len(opts.Username) > 0 || len(opts.Password) > 0 {
 		if opts.Auth == repoNoAuth || opts.Auth == repoBearerAuth {
 			return fmt.Errorf("username/password only applies to basic authentication (got %v)", opts.Auth)
@@ -82,6 +141,125 @@ func (opts *repoOptions) Normalize() error {
 	return nil
 }
 
+// SetGit returns the git configuration of a repository, starting from the given existing configuration. Only the
+// ref and path that were passed as flags are changed. If no git flag was passed, the existing configuration is kept.
+func (opts *repoOptions) SetGit(existing *v1alpha1.PackageRepositoryGitSpec) *v1alpha1.PackageRepositoryGitSpec {
+	if !opts.Git {
+		return existing
+	}
+	var spec v1alpha1.PackageRepositoryGitSpec
+	if existing != nil {
+		spec = *existing
+	}
+	if len(opts.GitRef) > 0 {
+		spec.Ref = opts.GitRef
+	}
+	if len(opts.GitPath) > 0 {
+		spec.Path = opts.GitPath
+	}
+	return &spec
+}
+
+// SetHelm returns the Helm configuration of a repository, starting from the given existing configuration. Charts
+// that were passed as flags replace the existing ones. If no Helm flag was passed, the existing configuration is kept.
+func (opts *repoOptions) SetHelm(existing *v1alpha1.PackageRepositoryHelmSpec) *v1alpha1.PackageRepositoryHelmSpec {
+	if !opts.Helm {
+		return existing
+	}
+	var spec v1alpha1.PackageRepositoryHelmSpec
+	if existing != nil {
+		spec = *existing
+	}
+	if len(opts.HelmCharts) > 0 {
+		spec.Charts = opts.HelmCharts
+	}
+	return &spec
+}
+
+// SetSignature returns the signature configuration of a repository, starting from the given existing configuration.
+// Public keys that were passed as flags replace the existing ones. If no signature flag was passed, the existing
+// configuration is kept.
+func (opts *repoOptions) SetSignature(existing *v1alpha1.PackageRepositorySignatureSpec) (
+	*v1alpha1.PackageRepositorySignatureSpec, error,
+) {
+	if len(opts.SignatureKeys) == 0 && !opts.RequireSignatures {
+		return existing, nil
+	}
+	var spec v1alpha1.PackageRepositorySignatureSpec
+	if existing != nil {
+		spec = *existing
+	}
+	if len(opts.SignatureKeys) > 0 {
+		spec.PublicKeys = nil
+		for _, file := range opts.SignatureKeys {
+			if key, err := os.ReadFile(file); err != nil {
+				return nil, fmt.Errorf("could not read signature key: %w", err)
+			} else {
+				spec.PublicKeys = append(spec.PublicKeys, string(key))
+			}
+		}
+	}
+	if opts.RequireSignatures {
+		spec.Required = true
+	}
+	if len(spec.PublicKeys) == 0 && spec.Keyless == nil {
+		return nil, errors.New("--require-signatures needs at least one --signature-key")
+	}
+	return &spec, nil
+}
+
+// SetTLS returns the TLS configuration of a repository, starting from the given existing configuration. Only the
+// settings that were passed as flags are changed. If no TLS flag was passed, the existing configuration is kept.
+func (opts *repoOptions) SetTLS(existing *v1alpha1.PackageRepositoryTLSSpec) (*v1alpha1.PackageRepositoryTLSSpec, error) {
+	if opts.CAFile == "" && opts.CASecret == "" && opts.ClientCertificateSecret == "" {
+		return existing, nil
+	}
+	var spec v1alpha1.PackageRepositoryTLSSpec
+	if existing != nil {
+		spec = *existing
+	}
+	if opts.CAFile != "" {
+		if caBundle, err := os.ReadFile(opts.CAFile); err != nil {
+			return nil, fmt.Errorf("could not read CA file: %w", err)
+		} else {
+			spec.CABundle = string(caBundle)
+		}
+	}
+	if opts.CASecret != "" {
+		spec.CABundleSecretRef = &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: opts.CASecret},
+			Key:                  "ca.crt",
+		}
+	}
+	if opts.ClientCertificateSecret != "" {
+		spec.ClientCertificateSecretRef = &corev1.LocalObjectReference{Name: opts.ClientCertificateSecret}
+	}
+	return &spec, nil
+}
+
+// SetProxy returns the proxy configuration of a repository, starting from the given existing configuration. If no
+// proxy flag was passed, the existing configuration is kept.
+func (opts *repoOptions) SetProxy(existing *v1alpha1.PackageRepositoryProxySpec) (
+	*v1alpha1.PackageRepositoryProxySpec, error,
+) {
+	switch {
+	case opts.Proxy != "":
+		if proxyUrl, err := url.Parse(opts.Proxy); err != nil || proxyUrl.Host == "" {
+			return nil, fmt.Errorf("use a valid URL for the proxy (got %v)", opts.Proxy)
+		}
+		return &v1alpha1.PackageRepositoryProxySpec{Url: opts.Proxy}, nil
+	case opts.ProxySecret != "":
+		return &v1alpha1.PackageRepositoryProxySpec{UrlSecretRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: opts.ProxySecret},
+			Key:                  "url",
+		}}, nil
+	case opts.NoProxy:
+		return &v1alpha1.PackageRepositoryProxySpec{}, nil
+	default:
+		return existing, nil
+	}
+}
+
 func (opts *repoOptions) SetAuth() *v1alpha1.PackageRepositoryAuthSpec {
 	switch opts.Auth {
 	case repoBasicAuth:
@@ -47,6 +47,29 @@ var repoUpdateCmd = &cobra.Command{
 		if repoUpdateCmdOptions.Url != "" {
 			repo.Spec.Url = repoUpdateCmdOptions.Url
 		}
+		if cmd.Flags().Changed("priority") {
+			repo.Spec.Priority = repoUpdateCmdOptions.Priority
+		}
+		repo.Spec.Git = repoUpdateCmdOptions.SetGit(repo.Spec.Git)
+		repo.Spec.Helm = repoUpdateCmdOptions.SetHelm(repo.Spec.Helm)
+		if signature, err := repoUpdateCmdOptions.SetSignature(repo.Spec.Signature); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			cliutils.ExitWithError()
+		} else {
+			repo.Spec.Signature = signature
+		}
+		if tls, err := repoUpdateCmdOptions.SetTLS(repo.Spec.TLS); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			cliutils.ExitWithError()
+		} else {
+			repo.Spec.TLS = tls
+		}
+		if proxy, err := repoUpdateCmdOptions.SetProxy(repo.Spec.Proxy); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			cliutils.ExitWithError()
+		} else {
+			repo.Spec.Proxy = proxy
+		}
 
 		if repoUpdateCmdOptions.Default {
 			defaultRepo, err = cliutils.GetDefaultRepo(ctx)
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/glasskube/glasskube/internal/audit"
+	"github.com/glasskube/glasskube/internal/cliutils"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/controller/revisions"
+	"github.com/glasskube/glasskube/internal/util"
+	"github.com/glasskube/glasskube/pkg/rollback"
+	"github.com/spf13/cobra"
+)
+
+var rollbackCmdOptions = struct {
+	Revision int64
+	History  bool
+	Yes      bool
+	DryRunOptions
+	KindOptions
+	NamespaceOptions
+}{
+	KindOptions: DefaultKindOptions(),
+}
+
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback <package-name>",
+	Short: "Roll back a package to a previously installed revision",
+	Long: "Roll back a package to a previously installed revision.\n" +
+		"The version and configuration of every successful installation are recorded in the status of the package. " +
+		"By default, the package is rolled back to the revision before the current one.",
+	PreRun: cliutils.SetupClientContext(true, &rootCmdOptions.SkipUpdateCheck),
+	Run:    func(cmd *cobra.Command, args []string) { runRollback(cmd.Context(), args[0]) },
+	Args:   cobra.ExactArgs(1),
+	ValidArgsFunction: installedPackagesCompletionFunc(
+		&rollbackCmdOptions.NamespaceOptions,
+		&rollbackCmdOptions.KindOptions,
+	),
+}
+
+func runRollback(ctx context.Context, name string) {
+	pkg, err := getPackageOrClusterPackage(ctx, name, rollbackCmdOptions.KindOptions,
+		rollbackCmdOptions.NamespaceOptions)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		cliutils.ExitWithError()
+	}
+
+	if rollbackCmdOptions.History {
+		printRevisionHistory(pkg)
+		cliutils.ExitSuccess()
+	}
+
+	rollbacker := rollback.NewRollbacker(ctx)
+	tx, err := rollbacker.Prepare(ctx, pkg, rollbackCmdOptions.Revision)
+	if errors.Is(err, rollback.ErrNoPreviousRevision) {
+		fmt.Fprintf(os.Stderr, "☑️  %v has no previous revision to roll back to\n", pkg.GetName())
+		cliutils.ExitSuccess()
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ rollback preparation failed: %v\n", err)
+		cliutils.ExitWithError()
+	} else if len(tx.Conflicts) > 0 {
+		fmt.Fprintf(os.Stderr, "❌ Cannot roll back %v to version %v due to dependency conflicts: %v\n",
+			pkg.GetName(), tx.Revision.Version, tx.Conflicts)
+		cliutils.ExitWithError()
+	}
+
+	bold := color.New(color.Bold).SprintFunc()
+	fmt.Fprintln(os.Stderr, bold("Summary:"))
+	fmt.Fprintf(os.Stderr, " * %v will be rolled back to revision %v (version %v -> %v)\n",
+		pkg.GetName(), tx.Revision.Revision, pkg.GetSpec().PackageInfo.Version, tx.Revision.Version)
+	fmt.Fprintf(os.Stderr, " * The configuration will be restored to the one from %v\n",
+		tx.Revision.InstalledAt.Format(time.RFC3339))
+	if len(tx.Requirements) > 0 {
+		fmt.Fprintln(os.Stderr, " * The following dependencies will be installed:")
+		for i, req := range tx.Requirements {
+			fmt.Fprintf(os.Stderr, "    %v. %v (version %v)\n", i+1, req.Name, req.Version)
+		}
+	}
+	if pkg.AutoUpdatesEnabled() {
+		fmt.Fprintf(os.Stderr, "⚠️  Automatic updates are enabled for %v and may update it again\n", pkg.GetName())
+	}
+	if !rollbackCmdOptions.Yes && !cliutils.YesNoPrompt("Do you want to continue?", true) {
+		fmt.Fprintf(os.Stderr, "⛔ Rollback cancelled. No changes were made.\n")
+		cliutils.ExitSuccess()
+	}
+
+	versionBefore := pkg.GetSpec().PackageInfo.Version
+	if err := rollbacker.Apply(ctx, tx,
+		rollback.ApplyRollbackOptions{DryRun: rollbackCmdOptions.DryRun}); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ rollback failed: %v\n", err)
+		cliutils.ExitWithError()
+	}
+	if !rollbackCmdOptions.DryRun {
+		recordAuditEntry(ctx, audit.OperationRollback, pkg, versionBefore, tx.Revision.Version)
+	}
+	fmt.Fprintf(os.Stderr, "✅ %v is being rolled back to revision %v\n", pkg.GetName(), tx.Revision.Revision)
+	cliutils.ExitSuccess()
+}
+
+func printRevisionHistory(pkg ctrlpkg.Package) {
+	current := revisions.Current(pkg.GetStatus())
+	if current == nil {
+		fmt.Fprintf(os.Stderr, "%v has no recorded revisions\n", pkg.GetName())
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	util.Must(fmt.Fprintln(w, "REVISION\tVERSION\tVALUES\tINSTALLED AT\t"))
+	for _, revision := range pkg.GetStatus().Revisions {
+		marker := ""
+		if revision.Revision == current.Revision {
+			marker = "(current)"
+		}
+		util.Must(fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", revision.Revision, revision.Version, len(revision.Values),
+			revision.InstalledAt.Format(time.RFC3339), marker))
+	}
+	_ = w.Flush()
+}
+
+func init() {
+	rollbackCmd.Flags().Int64Var(&rollbackCmdOptions.Revision, "revision", 0,
+		"Roll back to the given revision instead of the previous one")
+	rollbackCmd.Flags().BoolVar(&rollbackCmdOptions.History, "history", false,
+		"Show the recorded revisions of the package and exit")
+	rollbackCmd.Flags().BoolVarP(&rollbackCmdOptions.Yes, "yes", "y", false, "Do not ask for confirmation")
+	rollbackCmd.MarkFlagsMutuallyExclusive("revision", "history")
+	rollbackCmdOptions.DryRunOptions.AddFlagsToCommand(rollbackCmd)
+	rollbackCmdOptions.KindOptions.AddFlagsToCommand(rollbackCmd)
+	rollbackCmdOptions.NamespaceOptions.AddFlagsToCommand(rollbackCmd)
+	RootCmd.AddCommand(rollbackCmd)
+}
@@ -26,7 +26,7 @@ var (
 		Short:   "🧊 The next generation Package Manager for Kubernetes 📦",
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
 			telemetry.Init()
-			if !rootCmdOptions.SkipUpdateCheck {
+			if !rootCmdOptions.SkipUpdateCheck && !isCompletionRequest(cmd) {
 				cliutils.UpdateFetch()
 			}
 
@@ -58,12 +58,20 @@ func init() {
 		"Prevent progress logging to the cli")
 }
 
+// isCompletionRequest returns true for the hidden commands that shells call to complete arguments. These must be
+// fast, so the update check is skipped.
+func isCompletionRequest(cmd *cobra.Command) bool {
+	return cmd.Name() == cobra.ShellCompRequestCmd || cmd.Name() == cobra.ShellCompNoDescRequestCmd
+}
+
 func hasCustomShutdownLogic(cmd *cobra.Command) bool {
 	switch cmd {
 	case openCmd:
 		return true
 	case serveCmd:
 		return true
+	case serveGrpcCmd:
+		return true
 	}
 	return false
 }
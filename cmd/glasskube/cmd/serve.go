@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/glasskube/glasskube/internal/cliutils"
 
@@ -12,27 +13,85 @@ import (
 	"github.com/spf13/cobra"
 )
 
+type logFormat string
+
+func (lf *logFormat) String() string {
+	return string(*lf)
+}
+
+func (lf *logFormat) Set(value string) error {
+	switch value {
+	case web.LogFormatText, web.LogFormatJSON:
+		*lf = logFormat(value)
+		return nil
+	default:
+		return fmt.Errorf("invalid log format: %s", value)
+	}
+}
+
+func (lf *logFormat) Type() string {
+	return fmt.Sprintf("(%v|%v)", web.LogFormatText, web.LogFormatJSON)
+}
+
 type ServeCmdOptions struct {
-	host     string
-	port     int
-	logLevel int
-	skipOpen bool
+	host        string
+	port        int
+	logLevel    int
+	logFormat   logFormat
+	metricsPort int
+	skipOpen    bool
+	cacheSize   int
+	dev         bool
+	gracePeriod time.Duration
+	readOnly    bool
+	support     web.SupportOptions
+	rateLimit   web.RateLimitOptions
+	auth        web.AuthOptions
+	resources   web.ResourceThresholds
+	strictQuota bool
+	tls         web.TLSOptions
 }
 
 func (opts ServeCmdOptions) ServerOptions() web.ServerOptions {
+	var metricsPort string
+	if opts.metricsPort > 0 {
+		metricsPort = strconv.Itoa(opts.metricsPort)
+	}
 	return web.ServerOptions{
-		Host:               opts.host,
-		Port:               strconv.Itoa(opts.port),
-		Kubeconfig:         config.Kubeconfig,
-		LogLevel:           opts.logLevel,
-		SkipOpeningBrowser: opts.skipOpen,
+		Host:                opts.host,
+		Port:                strconv.Itoa(opts.port),
+		Kubeconfig:          config.Kubeconfig,
+		LogLevel:            opts.logLevel,
+		LogFormat:           opts.logFormat.String(),
+		MetricsPort:         metricsPort,
+		SkipOpeningBrowser:  opts.skipOpen,
+		MarkdownCacheSize:   opts.cacheSize,
+		Dev:                 opts.dev,
+		ShutdownGracePeriod: opts.gracePeriod,
+		ReadOnly:            opts.readOnly,
+		SupportOptions:      opts.support,
+		RateLimitOptions:    opts.rateLimit,
+		AuthOptions:         opts.auth,
+		ResourceThresholds:  opts.resources,
+		StrictQuota:         opts.strictQuota,
+		TLSOptions:          opts.tls,
 	}
 }
 
 var (
 	serveCmdOptions = ServeCmdOptions{
-		host: "localhost",
-		port: 8580,
+		host:        "localhost",
+		port:        8580,
+		logFormat:   web.LogFormatText,
+		cacheSize:   256,
+		dev:         config.IsDevBuild(),
+		gracePeriod: 10 * time.Second,
+		readOnly:    isReadOnlyFromEnv(),
+		support:     web.DefaultSupportOptions(),
+		rateLimit:   web.DefaultRateLimitOptions(),
+		auth:        web.DefaultAuthOptions(),
+		resources:   web.DefaultResourceThresholds(),
+		tls:         web.DefaultTLSOptions(),
 	}
 )
 
@@ -51,6 +110,13 @@ var serveCmd = &cobra.Command{
 	},
 }
 
+// isReadOnlyFromEnv returns whether GLASSKUBE_READ_ONLY is set to a true value, which is used as default for the
+// --read-only flag, so that the mode can be enabled when the command line can not be changed, e.g. in a container
+func isReadOnlyFromEnv() bool {
+	readOnly, _ := strconv.ParseBool(os.Getenv("GLASSKUBE_READ_ONLY"))
+	return readOnly
+}
+
 func init() {
 	serveCmd.Flags().StringVar(&serveCmdOptions.host, "host", serveCmdOptions.host,
 		"Hostname for the webserver")
@@ -58,7 +124,75 @@ func init() {
 		"Port for the webserver")
 	serveCmd.Flags().IntVarP(&serveCmdOptions.logLevel, "log-level", "l", serveCmdOptions.logLevel,
 		"Level for additional logging, where 0 is the least verbose")
+	serveCmd.Flags().Var(&serveCmdOptions.logFormat, "log-format",
+		"Format of the log output of the webserver")
+	serveCmd.Flags().IntVar(&serveCmdOptions.metricsPort, "metrics-port", serveCmdOptions.metricsPort,
+		"Serve the /metrics endpoint on a separate port instead of the port of the webserver")
 	serveCmd.Flags().BoolVarP(&serveCmdOptions.skipOpen, "skip-open", "s", serveCmdOptions.skipOpen,
 		"Skip opening the browser")
+	serveCmd.Flags().IntVar(&serveCmdOptions.cacheSize, "markdown-cache-size", serveCmdOptions.cacheSize,
+		"Maximum number of rendered package descriptions to keep in memory")
+	serveCmd.Flags().BoolVar(&serveCmdOptions.dev, "dev", serveCmdOptions.dev,
+		"Read templates from the working directory and parse them again when they change")
+	serveCmd.Flags().DurationVar(&serveCmdOptions.gracePeriod, "shutdown-grace-period", serveCmdOptions.gracePeriod,
+		"Time in-flight requests are given to complete when the webserver shuts down")
+	serveCmd.Flags().BoolVar(&serveCmdOptions.readOnly, "read-only", serveCmdOptions.readOnly,
+		"Show all packages and settings, but reject every action that modifies the cluster "+
+			"(can also be enabled with GLASSKUBE_READ_ONLY=true)")
+	serveCmd.Flags().StringVar(&serveCmdOptions.support.SupportURL, "support-url",
+		serveCmdOptions.support.SupportURL, "Link to the place for questions and bug reports (empty to hide)")
+	serveCmd.Flags().StringVar(&serveCmdOptions.support.ChatURL, "chat-url",
+		serveCmdOptions.support.ChatURL, "Link to the community or team chat (empty to hide)")
+	serveCmd.Flags().StringVar(&serveCmdOptions.support.DiscussionURL, "discussion-url",
+		serveCmdOptions.support.DiscussionURL, "Link to an external discussion page that replaces the built-in "+
+			"package discussions. \"{package}\" is replaced with the package name")
+	serveCmd.Flags().BoolVar(&serveCmdOptions.support.DisableDiscussions, "disable-discussions",
+		serveCmdOptions.support.DisableDiscussions, "Hide the discussion link and badge of packages")
+	serveCmd.Flags().Float64Var(&serveCmdOptions.rateLimit.RequestsPerSecond, "rate-limit",
+		serveCmdOptions.rateLimit.RequestsPerSecond, "Maximum number of requests per second per client IP (0 to disable)")
+	serveCmd.Flags().IntVar(&serveCmdOptions.rateLimit.Burst, "rate-limit-burst",
+		serveCmdOptions.rateLimit.Burst, "Number of requests a client IP may send at once")
+	serveCmd.Flags().IntVar(&serveCmdOptions.rateLimit.MaxEventConnections, "max-event-connections",
+		serveCmdOptions.rateLimit.MaxEventConnections,
+		"Maximum number of concurrent event stream and websocket connections per client IP (0 to disable)")
+	serveCmd.Flags().StringSliceVar(&serveCmdOptions.rateLimit.TrustedCIDRs, "rate-limit-trusted-cidr",
+		serveCmdOptions.rateLimit.TrustedCIDRs, "Networks (in CIDR notation) that are not rate limited")
+	serveCmd.Flags().StringVar(&serveCmdOptions.auth.UserHeader, "auth-user-header",
+		serveCmdOptions.auth.UserHeader, "Request header in which an authenticating proxy passes the user name, "+
+			"e.g. X-Forwarded-User (enables sessions with idle timeout and logout)")
+	serveCmd.Flags().DurationVar(&serveCmdOptions.auth.SessionTimeout, "session-timeout",
+		serveCmdOptions.auth.SessionTimeout, "Time after which a session without activity expires")
+	serveCmd.Flags().StringVar(&serveCmdOptions.auth.LoginURL, "login-url",
+		serveCmdOptions.auth.LoginURL, "URL the UI opens after the session has expired, which must authenticate the "+
+			"user at the proxy again and then redirect to /login (required with --auth-user-header)")
+	serveCmd.Flags().StringVar(&serveCmdOptions.auth.LogoutURL, "logout-url",
+		serveCmdOptions.auth.LogoutURL, "URL the UI opens after logging out, which must end the session of the proxy, "+
+			"e.g. its sign-out endpoint (required with --auth-user-header)")
+	serveCmd.Flags().StringVar(&serveCmdOptions.resources.CPU, "resource-warning-cpu",
+		serveCmdOptions.resources.CPU, "Warn about packages that request more CPU than this in total (empty to disable)")
+	serveCmd.Flags().StringVar(&serveCmdOptions.resources.Memory, "resource-warning-memory",
+		serveCmdOptions.resources.Memory, "Warn about packages that request more memory than this in total "+
+			"(empty to disable)")
+	serveCmd.Flags().StringVar(&serveCmdOptions.resources.Storage, "resource-warning-storage",
+		serveCmdOptions.resources.Storage, "Warn about packages whose volume claims request more storage than this "+
+			"in total (empty to disable)")
+	serveCmd.Flags().BoolVar(&serveCmdOptions.strictQuota, "strict-quota", serveCmdOptions.strictQuota,
+		"Reject installing packages whose resource requests exceed a resource quota of their namespace, "+
+			"instead of asking for confirmation")
+	serveCmd.Flags().StringVar(&serveCmdOptions.tls.CertFile, "tls-cert-file", serveCmdOptions.tls.CertFile,
+		"Serve HTTPS with the PEM encoded certificate from this file, which is reloaded when it changes")
+	serveCmd.Flags().StringVar(&serveCmdOptions.tls.KeyFile, "tls-key-file", serveCmdOptions.tls.KeyFile,
+		"File containing the PEM encoded private key of --tls-cert-file")
+	serveCmd.Flags().StringVar(&serveCmdOptions.tls.CertSecret, "tls-secret", serveCmdOptions.tls.CertSecret,
+		"Serve HTTPS with the certificate of this TLS secret (namespace/name), which is reloaded when it changes")
+	serveCmd.Flags().StringVar(&serveCmdOptions.tls.MinVersion, "tls-min-version", serveCmdOptions.tls.MinVersion,
+		"Minimum TLS version (1.2|1.3)")
+	serveCmd.Flags().StringSliceVar(&serveCmdOptions.tls.CipherSuites, "tls-cipher-suites",
+		serveCmdOptions.tls.CipherSuites, "Cipher suites allowed for TLS 1.2, e.g. "+
+			"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256 (empty for the Go defaults)")
+	serveCmd.Flags().StringVar(&serveCmdOptions.tls.RedirectPort, "http-redirect-port",
+		serveCmdOptions.tls.RedirectPort, "Redirect plain HTTP requests on this port to HTTPS (requires TLS)")
+	serveCmd.MarkFlagsRequiredTogether("tls-cert-file", "tls-key-file")
+	serveCmd.MarkFlagsMutuallyExclusive("tls-cert-file", "tls-secret")
 	RootCmd.AddCommand(serveCmd)
 }
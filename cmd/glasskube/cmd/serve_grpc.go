@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/glasskube/glasskube/internal/cliutils"
+	"github.com/glasskube/glasskube/internal/grpcserver"
+	"github.com/spf13/cobra"
+)
+
+const grpcTokenEnv = "GLASSKUBE_GRPC_TOKEN"
+
+var serveGrpcCmdOptions = struct {
+	host        string
+	port        int
+	token       string
+	tlsCertFile string
+	tlsKeyFile  string
+	gracePeriod time.Duration
+}{
+	host:        "localhost",
+	port:        8581,
+	gracePeriod: 10 * time.Second,
+}
+
+var serveGrpcCmd = &cobra.Command{
+	Use:   "serve-grpc",
+	Short: "Serve the gRPC API",
+	Long: "Serve a gRPC API to list, describe, install, update and uninstall packages.\n" +
+		"Every call must be authenticated with the token in the \"authorization\" metadata, as \"Bearer <token>\".",
+	Args:   cobra.NoArgs,
+	PreRun: cliutils.SetupClientContext(true, &rootCmdOptions.SkipUpdateCheck),
+	Run: func(cmd *cobra.Command, args []string) {
+		token := serveGrpcCmdOptions.token
+		if token == "" {
+			token = os.Getenv(grpcTokenEnv)
+		}
+		if token == "" {
+			fmt.Fprintf(os.Stderr, "❌ A token is required. Use --token or set %v.\n", grpcTokenEnv)
+			cliutils.ExitWithError()
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGTERM, syscall.SIGINT)
+		defer stop()
+		server := grpcserver.NewServer(grpcserver.Options{
+			Host:                serveGrpcCmdOptions.host,
+			Port:                strconv.Itoa(serveGrpcCmdOptions.port),
+			Token:               token,
+			TLSCertFile:         serveGrpcCmdOptions.tlsCertFile,
+			TLSKeyFile:          serveGrpcCmdOptions.tlsKeyFile,
+			ShutdownGracePeriod: serveGrpcCmdOptions.gracePeriod,
+		})
+		if err := server.Start(ctx); err != nil && ctx.Err() != context.Canceled {
+			fmt.Fprintf(os.Stderr, "An error occurred serving the gRPC API:\n\n%v\n", err)
+			cliutils.ExitWithError()
+		}
+	},
+}
+
+func init() {
+	serveGrpcCmd.Flags().StringVar(&serveGrpcCmdOptions.host, "host", serveGrpcCmdOptions.host,
+		"Hostname for the gRPC server")
+	serveGrpcCmd.Flags().IntVarP(&serveGrpcCmdOptions.port, "port", "p", serveGrpcCmdOptions.port,
+		"Port for the gRPC server")
+	serveGrpcCmd.Flags().StringVar(&serveGrpcCmdOptions.token, "token", serveGrpcCmdOptions.token,
+		fmt.Sprintf("Token that clients must send with every call (defaults to %v)", grpcTokenEnv))
+	serveGrpcCmd.Flags().StringVar(&serveGrpcCmdOptions.tlsCertFile, "tls-cert-file", serveGrpcCmdOptions.tlsCertFile,
+		"Serve TLS with the PEM encoded certificate from this file")
+	serveGrpcCmd.Flags().StringVar(&serveGrpcCmdOptions.tlsKeyFile, "tls-key-file", serveGrpcCmdOptions.tlsKeyFile,
+		"File containing the PEM encoded private key of --tls-cert-file")
+	serveGrpcCmd.Flags().DurationVar(&serveGrpcCmdOptions.gracePeriod, "shutdown-grace-period",
+		serveGrpcCmdOptions.gracePeriod, "Time running calls are given to complete when the server shuts down")
+	serveGrpcCmd.MarkFlagsRequiredTogether("tls-cert-file", "tls-key-file")
+	RootCmd.AddCommand(serveGrpcCmd)
+}
@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/clicontext"
+	"github.com/glasskube/glasskube/internal/cliutils"
+	"github.com/glasskube/glasskube/internal/manifestvalues/cli"
+	"github.com/glasskube/glasskube/internal/repo"
+	repoclient "github.com/glasskube/glasskube/internal/repo/client"
+	"github.com/glasskube/glasskube/internal/sandbox"
+	"github.com/glasskube/glasskube/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+var tryCmdOptions = struct {
+	cli.ValuesOptions
+	Version    string
+	Repository string
+	TTL        time.Duration
+	Yes        bool
+}{
+	ValuesOptions: cli.NewOptions(),
+	TTL:           sandbox.DefaultTTL,
+}
+
+var tryPromoteCmdOptions = struct {
+	Name string
+	Yes  bool
+	NamespaceOptions
+}{}
+
+var tryCmd = &cobra.Command{
+	Use:   "try <package-name>",
+	Short: "Try a package in a temporary sandbox namespace",
+	Long: `Try a package in a temporary sandbox namespace.
+The package is installed in a new namespace, which is deleted with everything in it once the sandbox has expired.
+Use "glasskube try promote" to install the package for real before that.`,
+	Args:              cobra.ExactArgs(1),
+	PreRun:            cliutils.SetupClientContext(true, &rootCmdOptions.SkipUpdateCheck),
+	ValidArgsFunction: completeAvailablePackageNames,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		pkgClient := clicontext.PackageClientFromContext(ctx)
+		repoClientset := cliutils.RepositoryClientset(ctx)
+		packageName := args[0]
+
+		if err := sandbox.ValidateTTL(tryCmdOptions.TTL); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			cliutils.ExitWithError()
+		}
+
+		repositoryName := tryCmdOptions.Repository
+		if repositoryName == "" {
+			repos, err := repoClientset.Meta().GetReposForPackage(packageName)
+			if len(repos) == 0 {
+				fmt.Fprintf(os.Stderr, "❗ Error: %v is not available: %v\n", packageName, err)
+				cliutils.ExitWithError()
+			}
+			if resolution, err := repoclient.ResolveRepository(packageName, repos); err != nil {
+				fmt.Fprintf(os.Stderr, "❗ Error: %v\nUse --repository to choose one.\n", err)
+				cliutils.ExitWithError()
+			} else {
+				repositoryName = resolution.Repository.Name
+			}
+		}
+		repoClient := repoClientset.ForRepoWithName(repositoryName)
+
+		if tryCmdOptions.Version == "" {
+			var packageIndex repo.PackageIndex
+			if err := repoClient.FetchPackageIndex(packageName, &packageIndex); err != nil {
+				fmt.Fprintf(os.Stderr, "❗ Error: Could not fetch package metadata: %v\n", err)
+				cliutils.ExitWithError()
+			}
+			tryCmdOptions.Version = packageIndex.LatestVersion
+		} else if !strings.HasPrefix(tryCmdOptions.Version, "v") {
+			tryCmdOptions.Version = "v" + tryCmdOptions.Version
+		}
+
+		var manifest v1alpha1.PackageManifest
+		if err := repoClient.FetchPackageManifest(packageName, tryCmdOptions.Version, &manifest); err != nil {
+			fmt.Fprintf(os.Stderr, "❗ Error: Could not fetch package manifest: %v\n", err)
+			cliutils.ExitWithError()
+		}
+		if manifest.Scope.IsCluster() {
+			fmt.Fprintf(os.Stderr, "❌ %v has scope Cluster and can not be installed in a sandbox namespace\n",
+				packageName)
+			cliutils.ExitWithError()
+		}
+
+		pkgBuilder := client.PackageBuilder(packageName).
+			WithName(packageName).
+			WithRepositoryName(repositoryName).
+			WithVersion(tryCmdOptions.Version)
+		if tryCmdOptions.IsValuesSet() {
+			if values, err := tryCmdOptions.ParseValues(&manifest, nil); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ invalid values in command line flags: %v\n", err)
+				cliutils.ExitWithError()
+			} else {
+				pkgBuilder.WithValues(values)
+			}
+		} else if values, err := cli.Configure(manifest,
+			cli.WithUseDefaults(tryCmdOptions.UseDefault),
+			clusterDefaultsOption(ctx),
+		); err != nil {
+			cancel()
+		} else {
+			pkgBuilder.WithValues(values)
+		}
+		pkg := pkgBuilder.BuildPackage()
+
+		fmt.Fprintf(os.Stderr, "%v (version %v) will be installed from repository %v in a new sandbox namespace, "+
+			"which is deleted after %v.\n", packageName, tryCmdOptions.Version, repositoryName, tryCmdOptions.TTL)
+		if !tryCmdOptions.Yes && !cliutils.YesNoPrompt("Continue?", true) {
+			cancel()
+		}
+
+		if err := sandbox.Create(ctx, clicontext.KubernetesClientFromContext(ctx), pkgClient, pkg,
+			tryCmdOptions.TTL); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ An error occurred during installation:\n\n%v\n", err)
+			cliutils.ExitWithError()
+		}
+		expiresAt, _ := sandbox.ExpiresAt(pkg)
+		fmt.Fprintf(os.Stderr, "✅ %v is being installed in sandbox namespace %v, which expires at %v.\n"+
+			"💡 Run \"glasskube try promote %v -n <namespace>\" to install it for real\n",
+			packageName, pkg.Namespace, expiresAt.Local().Format(time.DateTime), pkg.Namespace)
+		fmt.Println(pkg.Namespace)
+	},
+}
+
+var tryPromoteCmd = &cobra.Command{
+	Use:   "promote <sandbox-namespace>",
+	Short: "Install the package of a sandbox for real",
+	Long: `Install the package of a sandbox for real.
+The package is installed again with the same version and configuration in the given namespace, which must exist.
+Afterwards, the sandbox is deleted.`,
+	Args:              cobra.ExactArgs(1),
+	PreRun:            cliutils.SetupClientContext(true, &rootCmdOptions.SkipUpdateCheck),
+	ValidArgsFunction: completeSandboxNamespaces,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		pkgClient := clicontext.PackageClientFromContext(ctx)
+		sandboxPkg := getSandboxPackage(cmd, args[0])
+		namespace := tryPromoteCmdOptions.GetActualNamespace(ctx)
+		name := tryPromoteCmdOptions.Name
+		if name == "" {
+			name = sandboxPkg.Name
+		}
+
+		fmt.Fprintf(os.Stderr, "%v will be installed as %v/%v and sandbox %v will be deleted.\n",
+			sandboxPkg.Spec.PackageInfo.Name, namespace, name, sandboxPkg.Namespace)
+		if !tryPromoteCmdOptions.Yes && !cliutils.YesNoPrompt("Continue?", true) {
+			cancel()
+		}
+		if _, err := sandbox.Promote(ctx, clicontext.KubernetesClientFromContext(ctx), pkgClient, sandboxPkg,
+			namespace, name); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Could not promote sandbox %v: %v\n", sandboxPkg.Namespace, err)
+			cliutils.ExitWithError()
+		}
+		fmt.Fprintf(os.Stderr, "✅ %v is being installed in namespace %v.\n", name, namespace)
+	},
+}
+
+var tryDeleteCmd = &cobra.Command{
+	Use:               "delete <sandbox-namespace>",
+	Short:             "Delete a sandbox before it expires",
+	Args:              cobra.ExactArgs(1),
+	PreRun:            cliutils.SetupClientContext(true, &rootCmdOptions.SkipUpdateCheck),
+	ValidArgsFunction: completeSandboxNamespaces,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		if err := sandbox.Delete(ctx, clicontext.KubernetesClientFromContext(ctx), args[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Could not delete sandbox %v: %v\n", args[0], err)
+			cliutils.ExitWithError()
+		}
+		fmt.Fprintf(os.Stderr, "🗑️  Sandbox %v is being deleted.\n", args[0])
+	},
+}
+
+// getSandboxPackage returns the only package in the given sandbox namespace or exits with an error
+func getSandboxPackage(cmd *cobra.Command, namespace string) *v1alpha1.Package {
+	ctx := cmd.Context()
+	var list v1alpha1.PackageList
+	if err := clicontext.PackageClientFromContext(ctx).Packages(namespace).GetAll(ctx, &list); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Could not get packages in %v: %v\n", namespace, err)
+		cliutils.ExitWithError()
+	}
+	for i := range list.Items {
+		if sandbox.IsSandbox(&list.Items[i]) {
+			return &list.Items[i]
+		}
+	}
+	fmt.Fprintf(os.Stderr, "❌ %v is not a sandbox namespace\n", namespace)
+	cliutils.ExitWithError()
+	return nil
+}
+
+func completeSandboxNamespaces(
+	cmd *cobra.Command,
+	args []string,
+	toComplete string,
+) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names, dir := completeNamespaces(cmd, args, toComplete)
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		if strings.HasPrefix(name, "try-") {
+			result = append(result, name)
+		}
+	}
+	return result, dir
+}
+
+func init() {
+	tryCmd.Flags().StringVarP(&tryCmdOptions.Version, "version", "v", "",
+		"Try a specific version")
+	_ = tryCmd.RegisterFlagCompletionFunc("version", completeAvailablePackageVersions)
+	tryCmd.Flags().StringVar(&tryCmdOptions.Repository, "repository", "",
+		"Specify the name of the package repository to install this package from")
+	tryCmd.Flags().DurationVar(&tryCmdOptions.TTL, "ttl", tryCmdOptions.TTL,
+		fmt.Sprintf("Time after which the sandbox is deleted (at most %v)", sandbox.MaxTTL))
+	tryCmd.Flags().BoolVarP(&tryCmdOptions.Yes, "yes", "y", false, "Do not ask for any confirmation")
+	tryCmdOptions.ValuesOptions.AddFlagsToCommand(tryCmd)
+
+	tryPromoteCmd.Flags().StringVar(&tryPromoteCmdOptions.Name, "name", "",
+		"Name of the promoted package (defaults to the name in the sandbox)")
+	tryPromoteCmd.Flags().BoolVarP(&tryPromoteCmdOptions.Yes, "yes", "y", false, "Do not ask for any confirmation")
+	tryPromoteCmdOptions.NamespaceOptions.AddFlagsToCommand(tryPromoteCmd)
+
+	tryCmd.AddCommand(tryPromoteCmd, tryDeleteCmd)
+	RootCmd.AddCommand(tryCmd)
+}
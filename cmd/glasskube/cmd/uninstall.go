@@ -1,21 +1,27 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/fatih/color"
+	"github.com/glasskube/glasskube/internal/audit"
 	"github.com/glasskube/glasskube/internal/clicontext"
 	"github.com/glasskube/glasskube/internal/cliutils"
-	"github.com/glasskube/glasskube/internal/dependency/graph"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
 	"github.com/glasskube/glasskube/pkg/statuswriter"
 	"github.com/glasskube/glasskube/pkg/uninstall"
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/cache"
 )
 
 var uninstallCmdOptions = struct {
-	NoWait bool
-	Yes    bool
+	NoWait        bool
+	Yes           bool
+	RetainVolumes bool
+	RetainSecrets bool
+	Cascade       string
 	KindOptions
 	NamespaceOptions
 	DryRunOptions
@@ -39,7 +45,11 @@ var uninstallCmd = &cobra.Command{
 		currentContext := clicontext.RawConfigFromContext(ctx).CurrentContext
 		client := clicontext.PackageClientFromContext(ctx)
 		dm := cliutils.DependencyManager(ctx)
-		uninstaller := uninstall.NewUninstaller(client)
+		uninstaller := uninstall.NewUninstaller(client).
+			WithRetention(clicontext.KubernetesClientFromContext(ctx), uninstall.RetainOptions{
+				PersistentVolumeClaims: uninstallCmdOptions.RetainVolumes,
+				Secrets:                uninstallCmdOptions.RetainSecrets,
+			})
 		if !rootCmdOptions.NoProgress {
 			uninstaller.WithStatusWriter(statuswriter.Spinner())
 		}
@@ -55,22 +65,56 @@ var uninstallCmd = &cobra.Command{
 			cliutils.ExitWithError()
 		}
 
+		cascade, err := uninstall.ParseCascadeMode(uninstallCmdOptions.Cascade)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			cliutils.ExitWithError()
+		}
+
+		var dependents []ctrlpkg.Package
 		if g, err := dm.NewGraph(ctx); err != nil {
 			fmt.Fprintf(os.Stderr, "❌ Error validating uninstall: %v\n", err)
 			cliutils.ExitWithError()
+		} else if impact, err := uninstall.AnalyzeImpact(g, pkg.GetName(), pkg.GetNamespace(), cascade); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			if errors.Is(err, uninstall.ErrHasDependents) {
+				fmt.Fprintf(os.Stderr, "Use --cascade=%v to uninstall them as well, or --cascade=%v to keep them "+
+					"without %v.\n", uninstall.CascadeDependents, uninstall.CascadeOrphan, pkgName)
+			}
+			cliutils.ExitWithError()
 		} else {
-			g.Delete(pkg.GetName(), pkg.GetNamespace())
-			pruned := g.Prune()
-			if err := g.Validate(); err != nil {
-				fmt.Fprintf(os.Stderr, "❌ %v can not be uninstalled for the following reason: %v\n", pkgName, err)
-				cliutils.ExitWithError()
-			} else {
-				showUninstallDetails(currentContext, pkgName, pruned)
-				if !uninstallCmdOptions.Yes && !cliutils.YesNoPrompt("Do you want to continue?", false) {
-					fmt.Println("❌ Uninstallation cancelled.")
-					cliutils.ExitSuccess()
+			showUninstallDetails(currentContext, cache.MetaObjectToName(pkg).String(), impact)
+			if len(impact.Orphaned) > 0 {
+				fmt.Fprintf(os.Stderr, "⚠️  The following packages depend on %v and will not work correctly "+
+					"after it has been removed:\n", pkgName)
+				for _, ref := range impact.Orphaned {
+					fmt.Fprintf(os.Stderr, " * %v\n", ref)
 				}
 			}
+			if !uninstallCmdOptions.RetainVolumes {
+				fmt.Fprintf(os.Stderr, "⚠️  All persistent volume claims of %v will be deleted. "+
+					"Use --retain-volumes to keep them.\n", pkgName)
+			}
+			if !uninstallCmdOptions.Yes && !cliutils.YesNoPrompt("Do you want to continue?", false) {
+				fmt.Println("❌ Uninstallation cancelled.")
+				cliutils.ExitSuccess()
+			}
+			if dependents, err = uninstall.GetPackages(ctx, client, impact.Cascaded); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+				cliutils.ExitWithError()
+			}
+		}
+
+		// dependents are uninstalled first, so that no package is left without its dependencies in the meantime
+		for _, dependent := range dependents {
+			if err := uninstaller.Uninstall(ctx, dependent, uninstallCmdOptions.DryRun); err != nil {
+				fmt.Fprintf(os.Stderr, "\n❌ An error occurred during uninstallation of %v:\n\n%v\n",
+					cache.MetaObjectToName(dependent), err)
+				cliutils.ExitWithError()
+			}
+			if !uninstallCmdOptions.DryRun {
+				recordAuditEntry(ctx, audit.OperationUninstall, dependent, dependent.GetSpec().PackageInfo.Version, "")
+			}
 		}
 
 		if uninstallCmdOptions.NoWait {
@@ -86,16 +130,22 @@ var uninstallCmd = &cobra.Command{
 			}
 			fmt.Fprintf(os.Stderr, "🗑️  %v uninstalled successfully.\n", pkgName)
 		}
+		if !uninstallCmdOptions.DryRun {
+			recordAuditEntry(ctx, audit.OperationUninstall, pkg, pkg.GetSpec().PackageInfo.Version, "")
+		}
 	},
 }
 
-func showUninstallDetails(context, name string, pruned []graph.PackageRef) {
+func showUninstallDetails(context, name string, impact *uninstall.Impact) {
 	fmt.Fprintf(os.Stderr,
 		"The following packages will be %v from your cluster (%v):\n",
 		color.New(color.Bold).Sprint("removed"),
 		context)
 	fmt.Fprintf(os.Stderr, " * %v (requested by user)\n", name)
-	for _, dep := range pruned {
+	for _, dep := range impact.Cascaded {
+		fmt.Fprintf(os.Stderr, " * %+v (depends on %v)\n", dep, name)
+	}
+	for _, dep := range impact.Pruned {
 		fmt.Fprintf(os.Stderr, " * %+v (no longer needed)\n", dep)
 	}
 }
@@ -107,6 +157,15 @@ func init() {
 		"Perform non-blocking uninstall")
 	uninstallCmd.PersistentFlags().BoolVarP(&uninstallCmdOptions.Yes, "yes", "y", false,
 		"Do not ask for any confirmation")
+	uninstallCmd.PersistentFlags().BoolVar(&uninstallCmdOptions.RetainVolumes, "retain-volumes", false,
+		"Keep the persistent volume claims of the package, so that they can be adopted by a future installation")
+	uninstallCmd.PersistentFlags().StringVar(&uninstallCmdOptions.Cascade, "cascade", "",
+		fmt.Sprintf("Uninstall a package that other packages depend on. Use %q to uninstall them as well or %q to "+
+			"keep them without their dependency", uninstall.CascadeDependents, uninstall.CascadeOrphan))
+	uninstallCmd.PersistentFlags().BoolVar(&uninstallCmdOptions.RetainSecrets, "retain-secrets", false,
+		"Keep the secrets of the package, so that they can be adopted by a future installation")
+	_ = uninstallCmd.RegisterFlagCompletionFunc("cascade", cobra.FixedCompletions(
+		[]string{string(uninstall.CascadeDependents), string(uninstall.CascadeOrphan)}, cobra.ShellCompDirectiveNoFileComp))
 	RootCmd.AddCommand(uninstallCmd)
 	uninstallCmdOptions.DryRunOptions.AddFlagsToCommand(uninstallCmd)
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/glasskube/glasskube/internal/util"
 
 	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/audit"
 	"github.com/glasskube/glasskube/internal/clicontext"
 	"github.com/glasskube/glasskube/internal/cliutils"
 	"github.com/glasskube/glasskube/internal/config"
@@ -136,6 +137,11 @@ var updateCmd = &cobra.Command{
 					}
 				}
 
+				versionsBefore := make([]string, len(tx.Items))
+				for i, item := range tx.Items {
+					versionsBefore[i] = item.Package.GetSpec().PackageInfo.Version
+				}
+
 				updatedPackages, err := updater.Apply(
 					ctx,
 					tx,
@@ -147,6 +153,13 @@ var updateCmd = &cobra.Command{
 					fmt.Fprintf(os.Stderr, "❌ update failed: %v\n", err)
 					cliutils.ExitWithError()
 				}
+				if !updateCmdOptions.DryRun {
+					for i, item := range tx.Items {
+						if item.UpdateRequired() {
+							recordAuditEntry(ctx, audit.OperationUpdate, item.Package, versionsBefore[i], item.Version)
+						}
+					}
+				}
 				if updateCmdOptions.Output != "" {
 					if out, err := clientutils.Format(updateCmdOptions.Output.OutputFormat(),
 						updateCmdOptions.ShowAll, updatedPackages...); err != nil {
@@ -304,6 +317,7 @@ func updateConfigurationIfNeeded(ctx context.Context, pkg ctrlpkg.Package, newVe
 			values, err := cli.Configure(*newManifest,
 				cli.WithOldValues(pkg.GetSpec().Values),
 				cli.WithUseDefaults(updateCmdOptions.UseDefault),
+				clusterDefaultsOption(ctx),
 			)
 			if err != nil {
 				return fmt.Errorf("error during configuration: %v", err)
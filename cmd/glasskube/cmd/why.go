@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/glasskube/glasskube/internal/cliutils"
+	"github.com/glasskube/glasskube/internal/dependency/graph"
+	"github.com/spf13/cobra"
+)
+
+var whyCmdOptions = struct {
+	KindOptions
+	NamespaceOptions
+}{
+	KindOptions: DefaultKindOptions(),
+}
+
+var whyCmd = &cobra.Command{
+	Use:   "why <package-name>",
+	Short: "Explain why a package is installed",
+	Long: "Explain why a package is installed.\n" +
+		"Shows every chain of dependencies and components through which the package is required by a package " +
+		"that has been installed manually.",
+	PreRun:            cliutils.SetupClientContext(true, &rootCmdOptions.SkipUpdateCheck),
+	Run:               func(cmd *cobra.Command, args []string) { runWhy(cmd.Context(), args[0]) },
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: installedPackagesCompletionFunc(&whyCmdOptions.NamespaceOptions, &whyCmdOptions.KindOptions),
+}
+
+func runWhy(ctx context.Context, name string) {
+	pkg, err := getPackageOrClusterPackage(ctx, name, whyCmdOptions.KindOptions, whyCmdOptions.NamespaceOptions)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		cliutils.ExitWithError()
+	}
+
+	g, err := cliutils.DependencyManager(ctx).NewGraph(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Could not build dependency graph: %v\n", err)
+		cliutils.ExitWithError()
+	}
+
+	chains := g.Why(pkg.GetName(), pkg.GetNamespace())
+	if len(chains) == 0 {
+		fmt.Fprintf(os.Stderr, "🤷 %v is not required by any installed package\n", pkg.GetName())
+		cliutils.ExitSuccess()
+	}
+	for _, chain := range chains {
+		if len(chain) == 1 {
+			fmt.Fprintf(os.Stderr, "👤 %v was installed manually\n", chain[0])
+		} else {
+			fmt.Fprintf(os.Stderr, "🔗 %v\n", formatChain(chain))
+		}
+	}
+	cliutils.ExitSuccess()
+}
+
+func formatChain(chain []graph.PackageRef) string {
+	parts := make([]string, len(chain))
+	for i, ref := range chain {
+		parts[i] = ref.String()
+	}
+	return strings.Join(parts, " → ")
+}
+
+func init() {
+	whyCmdOptions.KindOptions.AddFlagsToCommand(whyCmd)
+	whyCmdOptions.NamespaceOptions.AddFlagsToCommand(whyCmd)
+	RootCmd.AddCommand(whyCmd)
+}
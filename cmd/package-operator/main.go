@@ -20,6 +20,7 @@ import (
 	"context"
 	"flag"
 	"os"
+	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/glasskube/glasskube/internal/dependency"
 	repoclient "github.com/glasskube/glasskube/internal/repo/client"
 	"github.com/glasskube/glasskube/internal/telemetry"
+	"github.com/glasskube/glasskube/internal/tracing"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -41,8 +43,13 @@ import (
 
 	packagesv1alpha1 "github.com/glasskube/glasskube/api/v1alpha1"
 	"github.com/glasskube/glasskube/internal/controller"
+	"github.com/glasskube/glasskube/internal/controller/reposync"
+	"github.com/glasskube/glasskube/internal/controller/revisions"
 	"github.com/glasskube/glasskube/internal/manifest/helm/flux"
 	"github.com/glasskube/glasskube/internal/manifest/plain"
+	"github.com/glasskube/glasskube/internal/notification"
+	"github.com/glasskube/glasskube/internal/retention"
+	"github.com/glasskube/glasskube/internal/sandbox"
 	"github.com/glasskube/glasskube/internal/webhook"
 	//+kubebuilder:scaffold:imports
 )
@@ -63,11 +70,35 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	retryBackoff := repoclient.DefaultRetryBackoff
+	var maxRetryDuration time.Duration
+	var maxConcurrentSyncs int
+	var syncTimeout time.Duration
+	var revisionHistoryLimit int
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.DurationVar(&retryBackoff.Duration, "repo-retry-initial-interval", retryBackoff.Duration,
+		"The initial wait time before retrying a failed repository request.")
+	flag.DurationVar(&retryBackoff.Cap, "repo-retry-max-interval", retryBackoff.Cap,
+		"The maximum wait time between retries of a failed repository request.")
+	flag.Float64Var(&retryBackoff.Factor, "repo-retry-factor", retryBackoff.Factor,
+		"The factor by which the wait time between retries of a failed repository request is multiplied.")
+	flag.Float64Var(&retryBackoff.Jitter, "repo-retry-jitter", retryBackoff.Jitter,
+		"The maximum fraction of random jitter added to the wait time between retries.")
+	flag.IntVar(&retryBackoff.Steps, "repo-retry-attempts", retryBackoff.Steps,
+		"The maximum number of attempts for a repository request that fails with a transient error.")
+	flag.DurationVar(&maxRetryDuration, "repo-max-retry-duration", controller.DefaultMaxRetryDuration,
+		"The time for which a repository that fails to sync with a transient error is reported as retrying, "+
+			"before it is marked as failed.")
+	flag.IntVar(&maxConcurrentSyncs, "repo-max-concurrent-syncs", controller.DefaultMaxConcurrentSyncs,
+		"The maximum number of repositories that are synced in parallel.")
+	flag.DurationVar(&syncTimeout, "repo-sync-timeout", reposync.DefaultTimeout,
+		"The time after which a repository sync that does not finish is aborted and retried later.")
+	flag.IntVar(&revisionHistoryLimit, "package-revision-history-limit", revisions.DefaultLimit,
+		"The number of previously installed revisions kept in the status of a package for rollbacks.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -99,9 +130,10 @@ func main() {
 		os.Exit(1)
 	}
 
-	repoClient := repoclient.NewClientset(
+	repoClient := repoclient.NewClientsetWithRetryBackoff(
 		ctrladapter.NewPackageClientAdapter(mgr.GetClient()),
 		ctrladapter.NewKubernetesClientAdapter(mgr.GetClient()),
+		retryBackoff,
 	)
 	dependencyManager := dependency.NewDependencyManager(
 		ctrladapter.NewPackageClientAdapter(mgr.GetClient()),
@@ -110,13 +142,15 @@ func main() {
 
 	telemetry.InitWithManager(mgr)
 	commonReconciler := controller.PackageReconcilerCommon{
-		Client:            mgr.GetClient(),
-		EventRecorder:     mgr.GetEventRecorderFor("package-controller"),
-		Scheme:            mgr.GetScheme(),
-		HelmAdapter:       flux.NewAdapter(),
-		ManifestAdapter:   plain.NewAdapter(),
-		RepoClientset:     repoClient,
-		DependencyManager: dependencyManager,
+		Client:               mgr.GetClient(),
+		EventRecorder:        mgr.GetEventRecorderFor("package-controller"),
+		Scheme:               mgr.GetScheme(),
+		HelmAdapter:          flux.NewAdapter(),
+		ManifestAdapter:      plain.NewAdapter(),
+		RepoClientset:        repoClient,
+		DependencyManager:    dependencyManager,
+		RevisionHistoryLimit: revisionHistoryLimit,
+		Notifier:             notification.NewNotifier(),
 	}
 	if err = (&controller.PackageReconciler{
 		PackageReconcilerCommon: commonReconciler,
@@ -140,13 +174,25 @@ func main() {
 		os.Exit(1)
 	}
 	if err = (&controller.PackageRepositoryReconciler{
-		Client:     mgr.GetClient(),
-		Scheme:     mgr.GetScheme(),
-		RepoClient: repoClient,
+		Client:             mgr.GetClient(),
+		Scheme:             mgr.GetScheme(),
+		RepoClient:         repoClient,
+		Notifier:           notification.NewNotifier(),
+		MaxRetryDuration:   maxRetryDuration,
+		MaxConcurrentSyncs: maxConcurrentSyncs,
+		SyncTimeout:        syncTimeout,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "PackageRepository")
 		os.Exit(1)
 	}
+	if err = mgr.Add(&sandbox.Cleaner{Client: mgr.GetClient()}); err != nil {
+		setupLog.Error(err, "unable to add sandbox cleaner")
+		os.Exit(1)
+	}
+	if err = mgr.Add(&retention.Pruner{Client: mgr.GetClient()}); err != nil {
+		setupLog.Error(err, "unable to add retention pruner")
+		os.Exit(1)
+	}
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
 		if err = (&webhook.PackageValidatingWebhook{
 			Client:             mgr.GetClient(),
@@ -179,6 +225,20 @@ func main() {
 		telemetry.ForOperator().ReportStart()
 		return nil
 	}))
+	if tracing.IsConfigured() {
+		_ = mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+			shutdown, err := tracing.Setup(ctx, "glasskube-package-operator")
+			if err != nil {
+				setupLog.Error(err, "unable to set up tracing")
+				return nil
+			}
+			<-ctx.Done()
+			// the manager context is already cancelled, so a new one is needed to flush the remaining spans
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			return shutdown(shutdownCtx)
+		}))
+	}
 
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
@@ -0,0 +1,167 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/clicontext"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// Namespace is the namespace where audit log entries are stored
+	Namespace = "glasskube-system"
+	// LabelAuditLog is set on every ConfigMap that contains an audit log entry
+	LabelAuditLog = "packages.glasskube.dev/audit-log"
+
+	entryKey = "entry"
+)
+
+type Operation string
+
+const (
+	OperationInstall   Operation = "install"
+	OperationUpdate    Operation = "update"
+	OperationConfigure Operation = "configure"
+	OperationRollback  Operation = "rollback"
+	OperationUninstall Operation = "uninstall"
+)
+
+type Source string
+
+const (
+	SourceCLI  Source = "cli"
+	SourceUI   Source = "ui"
+	SourceGRPC Source = "grpc"
+)
+
+// Entry is a single record of the audit log. Every entry is stored in an immutable ConfigMap, so it can not be
+// changed after it has been written.
+type Entry struct {
+	Timestamp     time.Time `json:"timestamp"`
+	Operation     Operation `json:"operation"`
+	Source        Source    `json:"source"`
+	User          string    `json:"user,omitempty"`
+	Name          string    `json:"name"`
+	Namespace     string    `json:"namespace,omitempty"`
+	PackageName   string    `json:"packageName"`
+	VersionBefore string    `json:"versionBefore,omitempty"`
+	VersionAfter  string    `json:"versionAfter,omitempty"`
+}
+
+// Record writes a new entry to the audit log. The clients are taken from the given context. The user is determined
+// via a SelfSubjectReview, falling back to the name of the user in the current kubeconfig context.
+func Record(
+	ctx context.Context,
+	source Source,
+	operation Operation,
+	pkg ctrlpkg.Package,
+	versionBefore, versionAfter string,
+) error {
+	client := clicontext.KubernetesClientFromContext(ctx)
+	if client == nil {
+		return errors.New("no kubernetes client in context")
+	}
+	entry := Entry{
+		Timestamp:     time.Now().UTC(),
+		Operation:     operation,
+		Source:        source,
+		User:          currentUser(ctx, client),
+		Name:          pkg.GetName(),
+		Namespace:     pkg.GetNamespace(),
+		PackageName:   pkg.GetSpec().PackageInfo.Name,
+		VersionBefore: versionBefore,
+		VersionAfter:  versionAfter,
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	immutable := true
+	cm := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "glasskube-audit-",
+			Namespace:    Namespace,
+			Labels: map[string]string{
+				LabelAuditLog:             "true",
+				v1alpha1.LabelPackageName: entry.PackageName,
+			},
+		},
+		Immutable: &immutable,
+		Data:      map[string]string{entryKey: string(data)},
+	}
+	if _, err := client.CoreV1().ConfigMaps(Namespace).Create(ctx, &cm, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to write audit log entry: %w", err)
+	}
+	return nil
+}
+
+// Filter restricts the entries returned by List. Zero values are ignored.
+type Filter struct {
+	PackageName string
+	From, To    time.Time
+}
+
+func (f Filter) Matches(entry Entry) bool {
+	return (f.PackageName == "" || entry.PackageName == f.PackageName) &&
+		(f.From.IsZero() || !entry.Timestamp.Before(f.From)) &&
+		(f.To.IsZero() || entry.Timestamp.Before(f.To))
+}
+
+// List returns all audit log entries matching the filter, newest first
+func List(ctx context.Context, client kubernetes.Interface, filter Filter) ([]Entry, error) {
+	selector := labels.Set{LabelAuditLog: "true"}
+	if filter.PackageName != "" {
+		selector[v1alpha1.LabelPackageName] = filter.PackageName
+	}
+	list, err := client.CoreV1().ConfigMaps(Namespace).
+		List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit log entries: %w", err)
+	}
+	entries := make([]Entry, 0, len(list.Items))
+	for i := range list.Items {
+		entry, err := EntryFromConfigMap(&list.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		if filter.Matches(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	slices.SortFunc(entries, func(a, b Entry) int { return b.Timestamp.Compare(a.Timestamp) })
+	return entries, nil
+}
+
+// EntryFromConfigMap reads the audit log entry stored in the given ConfigMap
+func EntryFromConfigMap(cm *corev1.ConfigMap) (Entry, error) {
+	var entry Entry
+	if err := json.Unmarshal([]byte(cm.Data[entryKey]), &entry); err != nil {
+		return entry, fmt.Errorf("invalid audit log entry %v: %w", cm.Name, err)
+	}
+	return entry, nil
+}
+
+func currentUser(ctx context.Context, client kubernetes.Interface) string {
+	review, err := client.AuthenticationV1().SelfSubjectReviews().
+		Create(ctx, &authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{})
+	if err == nil && review.Status.UserInfo.Username != "" {
+		return review.Status.UserInfo.Username
+	}
+	if rawConfig := clicontext.RawConfigFromContext(ctx); rawConfig != nil {
+		if kubeContext, ok := rawConfig.Contexts[rawConfig.CurrentContext]; ok {
+			return kubeContext.AuthInfo
+		}
+	}
+	return ""
+}
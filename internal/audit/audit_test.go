@@ -0,0 +1,26 @@
+package audit_test
+
+import (
+	"time"
+
+	"github.com/glasskube/glasskube/internal/audit"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Filter", func() {
+	day := func(d int) time.Time { return time.Date(2024, time.June, d, 0, 0, 0, 0, time.UTC) }
+	entry := audit.Entry{PackageName: "foo", Timestamp: day(10).Add(12 * time.Hour)}
+
+	DescribeTable("Matches",
+		func(filter audit.Filter, expected bool) {
+			Expect(filter.Matches(entry)).To(Equal(expected))
+		},
+		Entry("empty filter", audit.Filter{}, true),
+		Entry("same package", audit.Filter{PackageName: "foo"}, true),
+		Entry("other package", audit.Filter{PackageName: "bar"}, false),
+		Entry("within range", audit.Filter{From: day(10), To: day(11)}, true),
+		Entry("before range", audit.Filter{From: day(11)}, false),
+		Entry("after range", audit.Filter{To: day(10)}, false),
+	)
+})
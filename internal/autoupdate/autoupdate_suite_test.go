@@ -0,0 +1,13 @@
+package autoupdate
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestAutoUpdate(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "AutoUpdate Suite")
+}
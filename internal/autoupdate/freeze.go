@@ -0,0 +1,79 @@
+package autoupdate
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/glasskube/glasskube/internal/adapter"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	Namespace     = "glasskube-system"
+	ConfigMapName = "glasskube-auto-update"
+
+	keySuspended = "suspended"
+	keyReason    = "reason"
+	keySince     = "since"
+)
+
+// Freeze suspends automatic updates of all packages, regardless of whether they are enabled for a single package.
+// It is stored in a ConfigMap in the glasskube-system namespace.
+type Freeze struct {
+	Suspended bool
+	// Reason is an optional message that explains why updates are suspended, e.g. a maintenance freeze
+	Reason string
+	// Since is the time when the freeze was activated. It is zero if updates are not suspended.
+	Since time.Time
+}
+
+func (f *Freeze) IsActive() bool {
+	return f != nil && f.Suspended
+}
+
+// FreezeFromConfigMap reads the freeze from the given ConfigMap. Invalid values are treated as not suspended.
+func FreezeFromConfigMap(cm *corev1.ConfigMap) *Freeze {
+	var freeze Freeze
+	freeze.Suspended, _ = strconv.ParseBool(cm.Data[keySuspended])
+	if freeze.Suspended {
+		freeze.Reason = cm.Data[keyReason]
+		freeze.Since, _ = time.Parse(time.RFC3339, cm.Data[keySince])
+	}
+	return &freeze
+}
+
+// ConfigMap returns the ConfigMap that stores this freeze
+func (f *Freeze) ConfigMap() *corev1.ConfigMap {
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: ConfigMapName, Namespace: Namespace}}
+	f.ApplyTo(cm)
+	return cm
+}
+
+// ApplyTo stores the freeze in the given ConfigMap. Other keys of the ConfigMap, e.g. the maintenance window, are kept.
+func (f *Freeze) ApplyTo(cm *corev1.ConfigMap) {
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	cm.Data[keySuspended] = strconv.FormatBool(f.Suspended)
+	if f.Suspended {
+		cm.Data[keyReason] = f.Reason
+		cm.Data[keySince] = f.Since.UTC().Format(time.RFC3339)
+	} else {
+		delete(cm.Data, keyReason)
+		delete(cm.Data, keySince)
+	}
+}
+
+// LoadFreeze loads the freeze from the cluster. If it does not exist, updates are not suspended.
+func LoadFreeze(ctx context.Context, client adapter.KubernetesClientAdapter) (*Freeze, error) {
+	cm, err := client.GetConfigMap(ctx, ConfigMapName, Namespace)
+	if apierrors.IsNotFound(err) {
+		return &Freeze{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return FreezeFromConfigMap(cm), nil
+}
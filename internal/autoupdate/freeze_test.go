@@ -0,0 +1,26 @@
+package autoupdate
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Freeze", func() {
+	It("should survive a round trip through a ConfigMap", func() {
+		freeze := Freeze{Suspended: true, Reason: "maintenance", Since: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+		Expect(*FreezeFromConfigMap(freeze.ConfigMap())).To(Equal(freeze))
+	})
+
+	DescribeTable("FreezeFromConfigMap",
+		func(data map[string]string, active bool) {
+			Expect(FreezeFromConfigMap(&corev1.ConfigMap{Data: data}).IsActive()).To(Equal(active))
+		},
+		Entry("empty", map[string]string{}, false),
+		Entry("suspended", map[string]string{"suspended": "true"}, true),
+		Entry("not suspended", map[string]string{"suspended": "false", "reason": "ignored"}, false),
+		Entry("invalid", map[string]string{"suspended": "yes"}, false),
+	)
+})
@@ -0,0 +1,229 @@
+package autoupdate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/glasskube/glasskube/internal/adapter"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	keyWindowDays     = "windowDays"
+	keyWindowStart    = "windowStart"
+	keyWindowEnd      = "windowEnd"
+	keyWindowTimezone = "windowTimezone"
+
+	clockLayout = "15:04"
+)
+
+var ErrInvalidWindow = errors.New("invalid maintenance window")
+
+// Weekdays are all days of the week in the order they are shown to users
+var Weekdays = []time.Weekday{
+	time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday,
+}
+
+// MaintenanceWindow restricts automatic updates to certain times, e.g. Sundays from 02:00 to 04:00. Outside the
+// window, available updates are detected but not applied. A nil window means that updates can be applied at any time.
+//
+// Start and End are wall clock times in Location. If End is not after Start, the window ends on the next day. A window
+// always lasts as long as configured, also if it overlaps a daylight saving time transition.
+type MaintenanceWindow struct {
+	// Days are the days on which the window starts. If empty, the window starts every day.
+	Days []time.Weekday
+	// Start and End are durations since midnight
+	Start, End time.Duration
+	Location   *time.Location
+}
+
+// ParseMaintenanceWindow parses a window from its string representation, as it is stored in the ConfigMap. Days are a
+// comma separated list of weekdays (e.g. "Sat,Sun"), start and end are formatted as "15:04" and timezone is an IANA
+// time zone name. An empty timezone means UTC.
+func ParseMaintenanceWindow(days, start, end, timezone string) (*MaintenanceWindow, error) {
+	var window MaintenanceWindow
+	for _, day := range strings.Split(days, ",") {
+		if day = strings.TrimSpace(day); day == "" {
+			continue
+		} else if weekday, ok := parseWeekday(day); !ok {
+			return nil, fmt.Errorf("%w: unknown day %q", ErrInvalidWindow, day)
+		} else {
+			window.Days = append(window.Days, weekday)
+		}
+	}
+	var err error
+	if window.Start, err = parseClock(start); err != nil {
+		return nil, fmt.Errorf("%w: invalid start: %w", ErrInvalidWindow, err)
+	}
+	if window.End, err = parseClock(end); err != nil {
+		return nil, fmt.Errorf("%w: invalid end: %w", ErrInvalidWindow, err)
+	}
+	if window.Location, err = time.LoadLocation(timezone); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidWindow, err)
+	}
+	return &window, nil
+}
+
+func parseWeekday(s string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(s, day.String()) || strings.EqualFold(s, day.String()[:3]) {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse(clockLayout, strings.TrimSpace(s))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func formatClock(d time.Duration) string {
+	return time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC).Add(d).Format(clockLayout)
+}
+
+// duration returns the length of the window. A window with the same start and end lasts a full day.
+func (w *MaintenanceWindow) duration() time.Duration {
+	if d := w.End - w.Start; d > 0 {
+		return d
+	} else {
+		return d + 24*time.Hour
+	}
+}
+
+// StartsOn returns whether a window starts on the given day
+func (w *MaintenanceWindow) StartsOn(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, d := range w.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// next returns the start and end of the first window that has not ended at t
+func (w *MaintenanceWindow) next(t time.Time) (time.Time, time.Time) {
+	t = t.In(w.Location)
+	// start with the previous day, because its window may not have ended yet
+	for i := -1; i <= 7; i++ {
+		date := time.Date(t.Year(), t.Month(), t.Day()+i, 0, 0, 0, 0, w.Location)
+		if !w.StartsOn(date.Weekday()) {
+			continue
+		}
+		// times that don't exist because of a daylight saving time transition are normalized by time.Date. Times that
+		// exist twice are not guaranteed to resolve to the first occurrence, so this is done here.
+		start := time.Date(date.Year(), date.Month(), date.Day(),
+			int(w.Start/time.Hour), int(w.Start%time.Hour/time.Minute), 0, 0, w.Location)
+		if earlier := start.Add(-time.Hour); earlier.Hour() == start.Hour() && earlier.Minute() == start.Minute() {
+			start = earlier
+		}
+		if end := start.Add(w.duration()); end.After(t) {
+			return start, end
+		}
+	}
+	// unreachable, because every week contains at least one window
+	return t, t
+}
+
+// Contains returns whether updates may be applied at time t
+func (w *MaintenanceWindow) Contains(t time.Time) bool {
+	if w == nil {
+		return true
+	}
+	start, _ := w.next(t)
+	return !start.After(t)
+}
+
+// Next returns the time at which updates may be applied next. If t is inside a window, t is returned.
+func (w *MaintenanceWindow) Next(t time.Time) time.Time {
+	if w == nil {
+		return t
+	}
+	if start, _ := w.next(t); start.After(t) {
+		return start.In(w.Location)
+	}
+	return t
+}
+
+// IsOpen returns whether updates may be applied now
+func (w *MaintenanceWindow) IsOpen() bool {
+	return w.Contains(time.Now())
+}
+
+// NextOpening returns the start of the next window after now
+func (w *MaintenanceWindow) NextOpening() time.Time {
+	return w.Next(time.Now())
+}
+
+func (w *MaintenanceWindow) DaysString() string {
+	days := make([]string, len(w.Days))
+	for i, day := range w.Days {
+		days[i] = day.String()[:3]
+	}
+	return strings.Join(days, ",")
+}
+
+func (w *MaintenanceWindow) StartString() string {
+	return formatClock(w.Start)
+}
+
+func (w *MaintenanceWindow) EndString() string {
+	return formatClock(w.End)
+}
+
+func (w *MaintenanceWindow) String() string {
+	days := "Daily"
+	if len(w.Days) > 0 {
+		days = w.DaysString()
+	}
+	return fmt.Sprintf("%v %v–%v %v", days, w.StartString(), w.EndString(), w.Location)
+}
+
+// MaintenanceWindowFromConfigMap reads the window from the given ConfigMap. If no window is configured, nil is
+// returned.
+func MaintenanceWindowFromConfigMap(cm *corev1.ConfigMap) (*MaintenanceWindow, error) {
+	if cm.Data[keyWindowStart] == "" {
+		return nil, nil
+	}
+	return ParseMaintenanceWindow(
+		cm.Data[keyWindowDays], cm.Data[keyWindowStart], cm.Data[keyWindowEnd], cm.Data[keyWindowTimezone])
+}
+
+// ApplyTo stores the window in the given ConfigMap. Other keys of the ConfigMap are kept. If the window is nil, it is
+// removed from the ConfigMap.
+func (w *MaintenanceWindow) ApplyTo(cm *corev1.ConfigMap) {
+	if w == nil {
+		for _, key := range []string{keyWindowDays, keyWindowStart, keyWindowEnd, keyWindowTimezone} {
+			delete(cm.Data, key)
+		}
+		return
+	}
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	cm.Data[keyWindowDays] = w.DaysString()
+	cm.Data[keyWindowStart] = w.StartString()
+	cm.Data[keyWindowEnd] = w.EndString()
+	cm.Data[keyWindowTimezone] = w.Location.String()
+}
+
+// LoadMaintenanceWindow loads the window from the cluster. If it does not exist, nil is returned.
+func LoadMaintenanceWindow(ctx context.Context, client adapter.KubernetesClientAdapter) (*MaintenanceWindow, error) {
+	cm, err := client.GetConfigMap(ctx, ConfigMapName, Namespace)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return MaintenanceWindowFromConfigMap(cm)
+}
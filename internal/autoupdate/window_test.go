@@ -0,0 +1,76 @@
+package autoupdate
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("MaintenanceWindow", func() {
+	vienna, err := time.LoadLocation("Europe/Vienna")
+	if err != nil {
+		panic(err)
+	}
+
+	It("should allow updates at any time if not configured", func() {
+		var window *MaintenanceWindow
+		Expect(window.Contains(time.Now())).To(BeTrue())
+	})
+
+	It("should reject invalid windows", func() {
+		_, err := ParseMaintenanceWindow("Funday", "02:00", "04:00", "")
+		Expect(err).To(MatchError(ErrInvalidWindow))
+		_, err = ParseMaintenanceWindow("Sun", "2 AM", "04:00", "")
+		Expect(err).To(MatchError(ErrInvalidWindow))
+		_, err = ParseMaintenanceWindow("Sun", "02:00", "04:00", "Mars/Olympus")
+		Expect(err).To(MatchError(ErrInvalidWindow))
+	})
+
+	It("should survive a round trip through a ConfigMap", func() {
+		window, err := ParseMaintenanceWindow("sat, Sunday", "23:30", "01:00", "Europe/Vienna")
+		Expect(err).NotTo(HaveOccurred())
+		cm := (&Freeze{Suspended: true}).ConfigMap()
+		window.ApplyTo(cm)
+		Expect(MaintenanceWindowFromConfigMap(cm)).To(Equal(window))
+		Expect(FreezeFromConfigMap(cm).IsActive()).To(BeTrue())
+
+		(*MaintenanceWindow)(nil).ApplyTo(cm)
+		Expect(MaintenanceWindowFromConfigMap(cm)).To(BeNil())
+		Expect(MaintenanceWindowFromConfigMap(&corev1.ConfigMap{})).To(BeNil())
+	})
+
+	DescribeTable("Contains and Next",
+		func(days, start, end string, t time.Time, contains bool, next time.Time) {
+			window, err := ParseMaintenanceWindow(days, start, end, "Europe/Vienna")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(window.Contains(t)).To(Equal(contains))
+			Expect(window.Next(t)).To(BeTemporally("==", next))
+		},
+		Entry("inside the window",
+			"Sun", "02:00", "04:00", time.Date(2024, 6, 2, 3, 0, 0, 0, vienna),
+			true, time.Date(2024, 6, 2, 3, 0, 0, 0, vienna)),
+		Entry("before the window",
+			"Sun", "02:00", "04:00", time.Date(2024, 6, 1, 12, 0, 0, 0, vienna),
+			false, time.Date(2024, 6, 2, 2, 0, 0, 0, vienna)),
+		Entry("at the end of the window",
+			"Sun", "02:00", "04:00", time.Date(2024, 6, 2, 4, 0, 0, 0, vienna),
+			false, time.Date(2024, 6, 9, 2, 0, 0, 0, vienna)),
+		Entry("in another time zone",
+			"Sun", "02:00", "04:00", time.Date(2024, 6, 2, 1, 30, 0, 0, time.UTC),
+			true, time.Date(2024, 6, 2, 1, 30, 0, 0, time.UTC)),
+		Entry("after midnight of a window spanning midnight",
+			"Sat", "23:00", "01:00", time.Date(2024, 6, 2, 0, 30, 0, 0, vienna),
+			true, time.Date(2024, 6, 2, 0, 30, 0, 0, vienna)),
+		Entry("every day",
+			"", "02:00", "04:00", time.Date(2024, 6, 4, 5, 0, 0, 0, vienna),
+			false, time.Date(2024, 6, 5, 2, 0, 0, 0, vienna)),
+		Entry("on the day daylight saving time starts",
+			"Sun", "02:00", "04:00", time.Date(2024, 3, 31, 4, 30, 0, 0, vienna),
+			true, time.Date(2024, 3, 31, 4, 30, 0, 0, vienna)),
+		Entry("on the day daylight saving time ends",
+			"Sun", "02:00", "04:00", time.Date(2024, 10, 27, 3, 30, 0, 0, vienna),
+			false, time.Date(2024, 11, 3, 2, 0, 0, 0, vienna)),
+	)
+})
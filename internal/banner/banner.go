@@ -0,0 +1,73 @@
+package banner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	Namespace     = "glasskube-system"
+	ConfigMapName = "glasskube-banner"
+
+	keyText     = "text"
+	keySeverity = "severity"
+)
+
+// Severity determines the color of the banner. The values match the contextual classes of bootstrap alerts.
+type Severity string
+
+const (
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	SeverityDanger  Severity = "danger"
+)
+
+var Severities = []Severity{SeverityInfo, SeverityWarning, SeverityDanger}
+
+// Banner is a message that is shown to all users at the top of every page of the web UI, e.g. to announce a
+// maintenance. It is stored in a ConfigMap in the glasskube-system namespace.
+type Banner struct {
+	// Text is formatted as markdown. If it is empty, no banner is shown.
+	Text     string
+	Severity Severity
+}
+
+func (b *Banner) Enabled() bool {
+	return b != nil && strings.TrimSpace(b.Text) != ""
+}
+
+// ID identifies the content of the banner, so that a user who dismissed a banner sees it again once it is changed
+func (b *Banner) ID() string {
+	hash := sha256.Sum256([]byte(string(b.Severity) + "\n" + b.Text))
+	return hex.EncodeToString(hash[:8])
+}
+
+func (b *Banner) Validate() error {
+	if !slices.Contains(Severities, b.Severity) {
+		return fmt.Errorf("invalid severity: %v", b.Severity)
+	}
+	return nil
+}
+
+// FromConfigMap reads the banner from the given ConfigMap. An invalid severity is treated as info.
+func FromConfigMap(cm *corev1.ConfigMap) *Banner {
+	banner := Banner{Text: strings.TrimSpace(cm.Data[keyText]), Severity: Severity(cm.Data[keySeverity])}
+	if !slices.Contains(Severities, banner.Severity) {
+		banner.Severity = SeverityInfo
+	}
+	return &banner
+}
+
+// ConfigMap returns the ConfigMap that stores this banner
+func (b *Banner) ConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: ConfigMapName, Namespace: Namespace},
+		Data:       map[string]string{keyText: b.Text, keySeverity: string(b.Severity)},
+	}
+}
@@ -0,0 +1,13 @@
+package banner
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestBanner(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Banner Suite")
+}
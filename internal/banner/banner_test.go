@@ -0,0 +1,32 @@
+package banner
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Banner", func() {
+	It("should survive a round trip through a ConfigMap", func() {
+		banner := Banner{Text: "Cluster maintenance at **5pm**", Severity: SeverityWarning}
+		Expect(*FromConfigMap(banner.ConfigMap())).To(Equal(banner))
+	})
+
+	DescribeTable("FromConfigMap",
+		func(data map[string]string, enabled bool, severity Severity) {
+			banner := FromConfigMap(&corev1.ConfigMap{Data: data})
+			Expect(banner.Enabled()).To(Equal(enabled))
+			Expect(banner.Severity).To(Equal(severity))
+		},
+		Entry("empty", map[string]string{}, false, SeverityInfo),
+		Entry("blank text", map[string]string{"text": "  \n", "severity": "danger"}, false, SeverityDanger),
+		Entry("invalid severity", map[string]string{"text": "hello", "severity": "critical"}, true, SeverityInfo),
+	)
+
+	It("should change the ID when the content changes", func() {
+		banner := Banner{Text: "hello", Severity: SeverityInfo}
+		changed := Banner{Text: "hello", Severity: SeverityDanger}
+		Expect(banner.ID()).NotTo(Equal(changed.ID()))
+		Expect(banner.ID()).To(Equal((&Banner{Text: "hello", Severity: SeverityInfo}).ID()))
+	})
+})
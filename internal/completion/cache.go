@@ -0,0 +1,92 @@
+// Package completion provides the data for the dynamic shell completion of the CLI. The results are cached briefly,
+// so that completing an argument is fast and does not query the package repositories on every key stroke.
+package completion
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultTTL is the time after which cached completions are fetched again
+const DefaultTTL = 5 * time.Minute
+
+type cacheEntry struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Items     []string  `json:"items"`
+}
+
+// Cache stores completions as files in a directory. An empty directory disables caching.
+type Cache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+func NewCache(dir string, ttl time.Duration) *Cache {
+	return &Cache{dir: dir, ttl: ttl, now: time.Now}
+}
+
+// DefaultCache returns a cache in the user cache directory, e.g. ~/.cache/glasskube/completion on Linux
+func DefaultCache() *Cache {
+	if dir, err := os.UserCacheDir(); err == nil {
+		return NewCache(filepath.Join(dir, "glasskube", "completion"), DefaultTTL)
+	}
+	return NewCache("", DefaultTTL)
+}
+
+// Get returns the cached items for key, if they are younger than the TTL of the cache. Otherwise, fetch is called
+// and its result is cached. Errors are never cached and failing to write the cache is not an error.
+func (c *Cache) Get(key string, fetch func() ([]string, error)) ([]string, error) {
+	path := c.path(key)
+	if path != "" {
+		if data, err := os.ReadFile(path); err == nil {
+			var entry cacheEntry
+			if err := json.Unmarshal(data, &entry); err == nil && c.now().Sub(entry.CreatedAt) < c.ttl {
+				return entry.Items, nil
+			}
+		}
+	}
+	items, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	if path != "" {
+		if data, err := json.Marshal(cacheEntry{CreatedAt: c.now(), Items: items}); err == nil {
+			if err := os.MkdirAll(c.dir, 0700); err == nil {
+				_ = os.WriteFile(path, data, 0600)
+			}
+		}
+	}
+	return items, nil
+}
+
+func (c *Cache) path(key string) string {
+	if c.dir == "" {
+		return ""
+	}
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(hash[:16])+".json")
+}
+
+// Filter returns the items that start with prefix and are not contained in exclude
+func Filter(items []string, prefix string, exclude ...string) []string {
+	result := make([]string, 0, len(items))
+items:
+	for _, item := range items {
+		if !strings.HasPrefix(item, prefix) {
+			continue
+		}
+		for _, e := range exclude {
+			if e == item {
+				continue items
+			}
+		}
+		result = append(result, item)
+	}
+	return result
+}
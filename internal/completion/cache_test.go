@@ -0,0 +1,65 @@
+package completion
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cache", func() {
+	var cache *Cache
+	var now time.Time
+	var calls int
+	fetch := func(items ...string) func() ([]string, error) {
+		return func() ([]string, error) {
+			calls++
+			return items, nil
+		}
+	}
+
+	BeforeEach(func() {
+		now = time.Now()
+		calls = 0
+		cache = NewCache(GinkgoT().TempDir(), time.Minute)
+		cache.now = func() time.Time { return now }
+	})
+
+	It("should return cached items until they expire", func() {
+		Expect(cache.Get("key", fetch("a", "b"))).To(Equal([]string{"a", "b"}))
+		Expect(cache.Get("key", fetch("c"))).To(Equal([]string{"a", "b"}))
+		Expect(calls).To(Equal(1))
+		now = now.Add(time.Minute)
+		Expect(cache.Get("key", fetch("c"))).To(Equal([]string{"c"}))
+		Expect(calls).To(Equal(2))
+	})
+
+	It("should cache items per key", func() {
+		Expect(cache.Get("a", fetch("a"))).To(Equal([]string{"a"}))
+		Expect(cache.Get("b", fetch("b"))).To(Equal([]string{"b"}))
+		Expect(calls).To(Equal(2))
+	})
+
+	It("should not cache errors", func() {
+		_, err := cache.Get("key", func() ([]string, error) { return nil, errors.New("unavailable") })
+		Expect(err).To(HaveOccurred())
+		Expect(cache.Get("key", fetch("a"))).To(Equal([]string{"a"}))
+	})
+
+	It("should always fetch without a directory", func() {
+		cache = NewCache("", time.Minute)
+		Expect(cache.Get("key", fetch("a"))).To(Equal([]string{"a"}))
+		Expect(cache.Get("key", fetch("b"))).To(Equal([]string{"b"}))
+	})
+})
+
+var _ = Describe("Filter", func() {
+	It("should return items with the prefix", func() {
+		Expect(Filter([]string{"argo-cd", "argo-workflows", "cert-manager"}, "argo")).
+			To(Equal([]string{"argo-cd", "argo-workflows"}))
+	})
+	It("should exclude items", func() {
+		Expect(Filter([]string{"argo-cd", "argo-workflows"}, "", "argo-cd")).To(Equal([]string{"argo-workflows"}))
+	})
+})
@@ -0,0 +1,63 @@
+package completion
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/glasskube/glasskube/internal/repo"
+	repoclient "github.com/glasskube/glasskube/internal/repo/client"
+	repotypes "github.com/glasskube/glasskube/internal/repo/types"
+)
+
+// ClientsetFunc creates the repository clientset. It is only called if the completions are not cached, because
+// setting up the clients takes longer than reading the cache.
+type ClientsetFunc func() (repoclient.RepoClientset, error)
+
+// PackageNames returns the sorted names of all packages that are available in the repositories of the given
+// kubeconfig context
+func (c *Cache) PackageNames(contextName string, clientset ClientsetFunc) ([]string, error) {
+	return c.Get(fmt.Sprintf("packages/%v", contextName), func() ([]string, error) {
+		cs, err := clientset()
+		if err != nil {
+			return nil, err
+		}
+		var index repotypes.MetaIndex
+		if err := cs.Meta().FetchMetaIndex(&index); err != nil && len(index.Packages) == 0 {
+			return nil, err
+		}
+		names := make([]string, 0, len(index.Packages))
+		for _, pkg := range index.Packages {
+			names = append(names, pkg.Name)
+		}
+		slices.Sort(names)
+		return slices.Compact(names), nil
+	})
+}
+
+// PackageVersions returns the sorted versions of the given package in all repositories of the given kubeconfig
+// context
+func (c *Cache) PackageVersions(contextName, packageName string, clientset ClientsetFunc) ([]string, error) {
+	return c.Get(fmt.Sprintf("versions/%v/%v", contextName, packageName), func() ([]string, error) {
+		cs, err := clientset()
+		if err != nil {
+			return nil, err
+		}
+		repos, err := cs.Meta().GetReposForPackage(packageName)
+		if len(repos) == 0 {
+			return nil, errors.Join(fmt.Errorf("%v is not available", packageName), err)
+		}
+		var versions []string
+		for _, r := range repos {
+			var packageIndex repo.PackageIndex
+			if err := cs.ForRepo(r).FetchPackageIndex(packageName, &packageIndex); err != nil {
+				continue
+			}
+			for _, version := range packageIndex.Versions {
+				versions = append(versions, version.Version)
+			}
+		}
+		slices.Sort(versions)
+		return slices.Compact(versions), nil
+	})
+}
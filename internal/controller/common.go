@@ -6,30 +6,40 @@ import (
 	"reflect"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/glasskube/glasskube/api/v1alpha1"
 	packagesv1alpha1 "github.com/glasskube/glasskube/api/v1alpha1"
 	ctrladapter "github.com/glasskube/glasskube/internal/adapter/controllerruntime"
 	"github.com/glasskube/glasskube/internal/controller/conditions"
 	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/controller/events"
+	"github.com/glasskube/glasskube/internal/controller/hooks"
 	"github.com/glasskube/glasskube/internal/controller/labels"
 	"github.com/glasskube/glasskube/internal/controller/owners"
 	ownerutils "github.com/glasskube/glasskube/internal/controller/owners/utils"
 	"github.com/glasskube/glasskube/internal/controller/requeue"
+	"github.com/glasskube/glasskube/internal/controller/revisions"
 	"github.com/glasskube/glasskube/internal/controller/watch"
 	"github.com/glasskube/glasskube/internal/dependency"
 	deputil "github.com/glasskube/glasskube/internal/dependency/util"
 	"github.com/glasskube/glasskube/internal/manifest"
+	"github.com/glasskube/glasskube/internal/manifest/plain"
 	"github.com/glasskube/glasskube/internal/manifest/result"
 	"github.com/glasskube/glasskube/internal/manifesttransformations"
 	"github.com/glasskube/glasskube/internal/manifestvalues"
 	"github.com/glasskube/glasskube/internal/names"
+	"github.com/glasskube/glasskube/internal/notification"
 	repoclient "github.com/glasskube/glasskube/internal/repo/client"
 	"github.com/glasskube/glasskube/internal/resourcepatch"
 	"github.com/glasskube/glasskube/internal/telemetry"
+	"github.com/glasskube/glasskube/internal/tracing"
 	"github.com/glasskube/glasskube/internal/util"
 	"github.com/glasskube/glasskube/pkg/condition"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/multierr"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -38,6 +48,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -46,6 +57,7 @@ import (
 
 const (
 	packageDeletionFinalizer = "packages.glasskube.dev/packageDeletion"
+	waitingForDependencies   = "waiting for required package(s)"
 )
 
 type PackageReconcilerCommon struct {
@@ -59,6 +71,12 @@ type PackageReconcilerCommon struct {
 	HelmAdapter       manifest.ManifestAdapter
 	KustomizeAdapter  manifest.ManifestAdapter
 	DependencyManager *dependency.DependendcyManager
+	// Notifier is used to send a notification when the workloads of a package become unhealthy. It may be nil.
+	Notifier *notification.Notifier
+	// RevisionHistoryLimit is the number of revisions kept in the status of a package. If it is zero,
+	// revisions.DefaultLimit is used.
+	RevisionHistoryLimit int
+	hookRunner           *hooks.Runner
 }
 
 func (r *PackageReconcilerCommon) baseSetup(
@@ -74,6 +92,14 @@ func (r *PackageReconcilerCommon) baseSetup(
 		)
 	}
 
+	if r.hookRunner == nil {
+		renderer, err := plain.NewRenderer(r.Client, r.RepoClientset)
+		if err != nil {
+			return nil, err
+		}
+		r.hookRunner = &hooks.Runner{Client: r.Client, OwnerManager: r.OwnerManager, Renderer: renderer}
+	}
+
 	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
 		For(object).
 		Watches(&v1alpha1.PackageInfo{},
@@ -81,7 +107,13 @@ func (r *PackageReconcilerCommon) baseSetup(
 		Watches(&v1alpha1.ClusterPackage{},
 			watch.EnqueueRequestsFromOwnedResource(r.Scheme, lister, watch.OwnedPackages)).
 		Watches(&v1alpha1.Package{},
-			watch.EnqueueRequestsFromOwnedResource(r.Scheme, lister, watch.OwnedPackages))
+			watch.EnqueueRequestsFromOwnedResource(r.Scheme, lister, watch.OwnedPackages)).
+		Watches(&batchv1.Job{},
+			watch.EnqueueRequestsFromOwnedResource(r.Scheme, lister, watch.HookJobs))
+	for _, workload := range []client.Object{&appsv1.Deployment{}, &appsv1.StatefulSet{}, &appsv1.DaemonSet{}} {
+		controllerBuilder = controllerBuilder.Watches(workload,
+			watch.EnqueueRequestsForWorkload(lister), builder.WithPredicates(watch.WorkloadHealthChanged()))
+	}
 
 	if err := r.InitAdapters(controllerBuilder); err != nil {
 		return nil, err
@@ -100,8 +132,17 @@ func (r *PackageReconcilerCommon) InitAdapters(builder *builder.Builder) error {
 	return nil
 }
 
-func (r *PackageReconcilerCommon) reconcile(ctx context.Context, pkg ctrlpkg.Package) (ctrl.Result, error) {
+func (r *PackageReconcilerCommon) reconcile(ctx context.Context, pkg ctrlpkg.Package) (_ ctrl.Result, err error) {
+	if isOperationInProgress(pkg) {
+		// continue the trace of the install or update operation that caused this reconciliation
+		ctx = tracing.Extract(ctx, pkg)
+	}
+	info := pkg.GetSpec().PackageInfo
+	ctx, span := tracing.Start(ctx, "reconcile", tracing.PackageAttributes(pkg, info.Name, info.Version)...)
+	defer func() { tracing.End(span, err) }()
+
 	prc := &PackageReconcilationContext{PackageReconcilerCommon: r, pkg: pkg}
+	prc.rememberPreviousStatus()
 	log := ctrl.LoggerFrom(ctx)
 
 	if pkg.GetSpec().Suspend {
@@ -112,6 +153,10 @@ func (r *PackageReconcilerCommon) reconcile(ctx context.Context, pkg ctrlpkg.Pac
 		return prc.reconcileAfterDeletion(ctx)
 	}
 
+	if pkg.IsPaused() {
+		return prc.reconcilePaused(ctx)
+	}
+
 	telemetry.ForOperator().ReconcilePackage(prc.pkg)
 	prc.ensureFinalizer()
 
@@ -135,6 +180,13 @@ func (r *PackageReconcilerCommon) reconcile(ctx context.Context, pkg ctrlpkg.Pac
 	}
 }
 
+// isOperationInProgress returns true if the installed version of pkg differs from the desired version or if pkg is not
+// ready yet
+func isOperationInProgress(pkg ctrlpkg.Package) bool {
+	return pkg.GetStatus().Version != pkg.GetSpec().PackageInfo.Version ||
+		!meta.IsStatusConditionTrue(pkg.GetStatus().Conditions, string(condition.Ready))
+}
+
 type PackageReconcilationContext struct {
 	*PackageReconcilerCommon
 	pkg                   ctrlpkg.Package
@@ -144,6 +196,48 @@ type PackageReconcilationContext struct {
 	shouldUpdateResource  bool
 	currentOwnedResources []v1alpha1.OwnedResourceRef
 	currentOwnedPackages  []v1alpha1.OwnedResourceRef
+	previousReady         *metav1.Condition
+	previousFailed        *metav1.Condition
+	previousVersion       string
+}
+
+// rememberPreviousStatus keeps a copy of the status that is relevant for deciding which events should be recorded,
+// because the status is modified in place during the reconciliation.
+func (r *PackageReconcilationContext) rememberPreviousStatus() {
+	status := r.pkg.GetStatus()
+	if c := meta.FindStatusCondition(status.Conditions, string(condition.Ready)); c != nil {
+		r.previousReady = c.DeepCopy()
+	}
+	if c := meta.FindStatusCondition(status.Conditions, string(condition.Failed)); c != nil {
+		r.previousFailed = c.DeepCopy()
+	}
+	r.previousVersion = status.Version
+}
+
+func (r *PackageReconcilationContext) wasReady() bool {
+	return r.previousReady != nil && r.previousReady.Status == metav1.ConditionTrue
+}
+
+// isFirstAttempt returns true if the installation of the current version has not been started yet or if the previous
+// reconciliation was waiting for dependencies. It is used to record progress events only once per installation, even
+// though the package is reconciled multiple times while waiting for the applied resources to become ready.
+func (r *PackageReconcilationContext) isFirstAttempt() bool {
+	if r.wasReady() && r.previousVersion == r.pi.Status.Version {
+		return false
+	}
+	return r.previousReady == nil || r.previousReady.Reason != string(condition.Pending) ||
+		strings.HasPrefix(r.previousReady.Message, waitingForDependencies)
+}
+
+// wasWaitingForPreInstallHooks returns true if the previous reconciliation did not apply the manifests, because a
+// pre-install hook was still running
+func (r *PackageReconcilationContext) wasWaitingForPreInstallHooks() bool {
+	return r.previousReady != nil && r.previousReady.Reason == string(condition.Pending) &&
+		strings.HasPrefix(r.previousReady.Message, waitingForHook(v1alpha1.HookPhasePreInstall))
+}
+
+func waitingForHook(phase v1alpha1.HookPhase) string {
+	return fmt.Sprintf("waiting for %v hook", hooks.PhaseName(phase))
 }
 
 func (r *PackageReconcilationContext) setShouldUpdate(value bool) {
@@ -159,37 +253,36 @@ func (r *PackageReconcilationContext) reconcilePackageInfoReady(ctx context.Cont
 		return r.finalizeNoRequeue(ctx)
 	}
 
-	if !r.ensureDependencies(ctx) {
+	depCtx, depSpan := tracing.Start(ctx, "dependency resolution")
+	dependenciesReady := r.ensureDependencies(depCtx)
+	depSpan.SetAttributes(attribute.Bool("glasskube.dependencies.ready", dependenciesReady))
+	depSpan.End()
+	if !dependenciesReady {
 		return r.finalize(ctx)
+	} else if (len(piManifest.Dependencies) > 0 || len(piManifest.Components) > 0) && r.isFirstAttempt() {
+		events.Normal(r.EventRecorder, r.pkg, events.DependenciesResolved, "All required packages are ready")
 	}
 
-	var patches []resourcepatch.TargetPatch
-	if resolvedValues, err := r.ValueResolver.Resolve(ctx, r.pkg.GetSpec().Values); err != nil {
-		r.setShouldUpdate(
-			conditions.SetFailed(ctx, r.EventRecorder, r.pkg, &r.pkg.GetStatus().Conditions,
-				condition.ValueConfigurationInvalid, err.Error()))
-		return r.finalizeWithError(ctx, err)
-	} else if err := manifestvalues.ValidateResolvedValues(*piManifest, resolvedValues); err != nil {
+	renderCtx, renderSpan := tracing.Start(ctx, "manifest render")
+	patches, reason, err := r.generatePatches(renderCtx, piManifest)
+	tracing.End(renderSpan, err)
+	if err != nil {
 		r.setShouldUpdate(
-			conditions.SetFailed(ctx, r.EventRecorder, r.pkg, &r.pkg.GetStatus().Conditions,
-				condition.ValueConfigurationInvalid, err.Error()))
+			conditions.SetFailed(ctx, r.EventRecorder, r.pkg, &r.pkg.GetStatus().Conditions, reason, err.Error()))
 		return r.finalizeWithError(ctx, err)
-	} else if p, err := resourcepatch.GeneratePatches(*piManifest, resolvedValues); err != nil {
-		r.setShouldUpdate(
-			conditions.SetFailed(ctx, r.EventRecorder, r.pkg, &r.pkg.GetStatus().Conditions,
-				condition.InstallationFailed, err.Error()))
-		return r.finalizeWithError(ctx, err)
-	} else {
-		patches = p
 	}
 
-	if p, err := manifesttransformations.ResolveAndGeneratePatches(ctx, r.Client, r.pkg, piManifest); err != nil {
+	retryRequested := r.pkg.IsRetryRequested()
+	if err := hooks.Validate(piManifest); err != nil {
 		r.setShouldUpdate(
 			conditions.SetFailed(ctx, r.EventRecorder, r.pkg, &r.pkg.GetStatus().Conditions,
-				condition.InstallationFailed, err.Error()))
+				condition.UnsupportedFormat, err.Error()))
+		return r.finalizeNoRequeue(ctx)
+	}
+	if done, err := r.runHooks(ctx, v1alpha1.HookPhasePreInstall, patches, retryRequested); err != nil {
 		return r.finalizeWithError(ctx, err)
-	} else {
-		patches = append(patches, p...)
+	} else if !done {
+		return r.finalize(ctx)
 	}
 
 	// First, collect the adapters for all included manifests and ensure that they are supported.
@@ -225,26 +318,139 @@ func (r *PackageReconcilationContext) reconcilePackageInfoReady(ctx context.Cont
 	}
 
 	results := make([]result.ReconcileResult, 0, len(adaptersToRun))
+	var failedResources []v1alpha1.FailedResourceRef
 	var errs error
+	recreateRequested := r.pkg.IsRecreateRequested()
+	var recreatedResources []v1alpha1.OwnedResourceRef
+	applyCtx, applySpan := tracing.Start(ctx, "server-side apply")
+	applySpan.SetAttributes(attribute.Bool("glasskube.package.retry", retryRequested),
+		attribute.Bool("glasskube.package.recreate", recreateRequested))
 	for _, adapter := range adaptersToRun {
-		if result, err := adapter.Reconcile(ctx, r.pkg, r.pi, patches); err != nil {
+		if result, err := adapter.Reconcile(applyCtx, r.pkg, r.pi, patches); err != nil {
 			errs = multierr.Append(errs, err)
 		} else {
 			results = append(results, *result)
 			ownerutils.Add(&r.currentOwnedResources, result.OwnedResources...)
+			failedResources = append(failedResources, result.FailedResources...)
+			recreatedResources = append(recreatedResources, result.RecreatedResources...)
 		}
 	}
+	tracing.End(applySpan, errs)
+
+	if retryRequested && len(r.pkg.GetStatus().FailedResources) > 0 {
+		events.Normal(r.EventRecorder, r.pkg, events.Retried, "Applied %v failed resources again",
+			len(r.pkg.GetStatus().FailedResources))
+	}
+	for _, ref := range recreatedResources {
+		events.Normal(r.EventRecorder, r.pkg, events.Recreated, "Recreated %v %v because immutable fields changed",
+			ref.Kind, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name})
+	}
+	if retryRequested || recreateRequested {
+		// a recreation is only done once, like a retry
+		r.pkg.GetStatus().LastRetryTime = ptr.To(metav1.Now())
+		r.setShouldUpdate(true)
+	}
+	if errs == nil {
+		r.setShouldUpdate(r.setFailedResources(failedResources))
+	}
 
 	if errs != nil {
 		r.setShouldUpdate(
 			conditions.SetFailed(ctx, r.EventRecorder, r.pkg, &r.pkg.GetStatus().Conditions,
 				condition.InstallationFailed, errs.Error()))
 		return r.finalizeWithError(ctx, errs)
-	} else if !r.handleAdapterResults(ctx, results) {
-		return r.finalize(ctx)
+	}
+
+	if len(adaptersToRun) > 0 && (r.isFirstAttempt() || r.wasWaitingForPreInstallHooks()) {
+		events.Normal(r.EventRecorder, r.pkg, events.Applied, "Applied manifests of version %v", r.pi.Status.Version)
+	}
+
+	_, readinessSpan := tracing.Start(ctx, "readiness check")
+	ready := r.handleAdapterResults(ctx, results)
+	readinessSpan.SetAttributes(attribute.Bool("glasskube.package.ready", ready))
+	readinessSpan.End()
+	if ready {
+		if done, err := r.runHooks(ctx, v1alpha1.HookPhasePostInstall, patches, retryRequested); err != nil {
+			return r.finalizeWithError(ctx, err)
+		} else if done {
+			r.afterSuccess(ctx, results)
+		}
+	}
+	r.updateHealth(ctx)
+	return r.finalize(ctx)
+}
+
+// runHooks runs the hooks of the given phase and returns true if all of them have completed successfully. Otherwise,
+// the conditions of the package are set to reflect the running or failed hook.
+func (r *PackageReconcilationContext) runHooks(
+	ctx context.Context,
+	phase v1alpha1.HookPhase,
+	patches resourcepatch.TargetPatches,
+	retryRequested bool,
+) (bool, error) {
+	hooksCtx, hooksSpan := tracing.Start(ctx, hooks.PhaseName(phase)+" hooks")
+	res, err := r.hookRunner.Run(hooksCtx, r.pkg, r.pi, phase, patches, retryRequested)
+	tracing.End(hooksSpan, err)
+	if err != nil {
+		r.setShouldUpdate(
+			conditions.SetFailed(ctx, r.EventRecorder, r.pkg, &r.pkg.GetStatus().Conditions,
+				condition.InstallationFailed, err.Error()))
+		return false, err
+	}
+	r.setShouldUpdate(res.Changed)
+
+	phaseName := hooks.PhaseName(phase)
+	if res.Retried {
+		events.Normal(r.EventRecorder, r.pkg, events.Retried, "Running %v hook %v again", phaseName, res.Running.Name)
+		r.pkg.GetStatus().LastRetryTime = ptr.To(metav1.Now())
+		r.setShouldUpdate(true)
+	} else if res.Started != nil {
+		events.Normal(r.EventRecorder, r.pkg, events.HookStarted, "Started %v hook %v (Job %v)",
+			phaseName, res.Started.Name, res.Started.JobName)
+	}
+	for _, hookStatus := range res.Succeeded {
+		events.Normal(r.EventRecorder, r.pkg, events.HookSucceeded, "The %v hook %v has completed",
+			phaseName, hookStatus.Name)
+	}
+
+	if res.Failed != nil {
+		r.setShouldUpdate(
+			conditions.SetFailed(ctx, r.EventRecorder, r.pkg, &r.pkg.GetStatus().Conditions,
+				condition.HookFailed, fmt.Sprintf("%v hook %v failed: %v", phaseName, res.Failed.Name, res.Failed.Message)))
+		return false, nil
+	} else if res.Running != nil {
+		r.setShouldUpdate(
+			conditions.SetUnknown(ctx, &r.pkg.GetStatus().Conditions, condition.Pending,
+				fmt.Sprintf("%v %v to complete", waitingForHook(phase), res.Running.Name)))
+		return false, nil
+	}
+	return res.Done, nil
+}
+
+// generatePatches resolves the values of the package and generates the patches for the manifests of the package.
+// If this fails, the reason for the failed condition is returned as well.
+func (r *PackageReconcilationContext) generatePatches(
+	ctx context.Context,
+	piManifest *v1alpha1.PackageManifest,
+) ([]resourcepatch.TargetPatch, condition.Reason, error) {
+	values, err := r.ValueResolver.EffectiveValues(ctx, r.pkg)
+	if err != nil {
+		return nil, condition.ValueConfigurationInvalid, err
+	}
+	resolvedValues, err := r.ValueResolver.Resolve(ctx, values)
+	if err != nil {
+		return nil, condition.ValueConfigurationInvalid, err
+	} else if err := manifestvalues.ValidateResolvedValues(*piManifest, resolvedValues); err != nil {
+		return nil, condition.ValueConfigurationInvalid, err
+	}
+	patches, err := resourcepatch.GeneratePatches(*piManifest, resolvedValues)
+	if err != nil {
+		return nil, condition.InstallationFailed, err
+	}
+	if p, err := manifesttransformations.ResolveAndGeneratePatches(ctx, r.Client, r.pkg, piManifest); err != nil {
+		return nil, condition.InstallationFailed, err
 	} else {
-		r.afterSuccess(ctx, results)
-		return r.finalize(ctx)
+		return append(patches, p...), "", nil
 	}
 }
 
@@ -254,6 +460,13 @@ func (r *PackageReconcilationContext) reconcileSuspended(ctx context.Context) (c
 	return r.finalizeNoRequeue(ctx)
 }
 
+// reconcilePaused leaves the package and all of its resources as they are, so that manual changes are not reverted
+func (r *PackageReconcilationContext) reconcilePaused(ctx context.Context) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	log.Info("skipping reconciliation for paused package")
+	return r.finalizeNoRequeue(ctx)
+}
+
 func (r *PackageReconcilationContext) reconcileAfterDeletion(ctx context.Context) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 
@@ -275,6 +488,7 @@ func (r *PackageReconcilationContext) reconcileAfterDeletion(ctx context.Context
 		} else {
 			r.pkg.SetFinalizers(util.DeleteAll(r.pkg.GetFinalizers(), packageDeletionFinalizer))
 			r.shouldUpdateResource = true
+			events.Normal(r.EventRecorder, r.pkg, events.Uninstalled, "All resources of the package have been removed")
 		}
 
 		if err != nil {
@@ -289,6 +503,8 @@ func (r *PackageReconcilationContext) ensureFinalizer() {
 	if !slices.Contains(r.pkg.GetFinalizers(), packageDeletionFinalizer) {
 		r.pkg.SetFinalizers(append(r.pkg.GetFinalizers(), packageDeletionFinalizer))
 		r.shouldUpdateResource = true
+		events.Normal(r.EventRecorder, r.pkg, events.InstallStarted, "Installing version %v",
+			r.pkg.GetSpec().PackageInfo.Version)
 	}
 }
 
@@ -326,7 +542,8 @@ func (r *PackageReconcilationContext) ensureDependencies(ctx context.Context) bo
 	}
 
 	var failed []string
-	if result, err := r.DependencyManager.Validate(ctx, r.pkg.GetName(), r.pkg.GetNamespace(), r.pi.Status.Manifest,
+	manifest := deputil.WithEnabledDependencies(*r.pi.Status.Manifest, r.pkg.GetSpec().OptionalDependencies)
+	if result, err := r.DependencyManager.Validate(ctx, r.pkg.GetName(), r.pkg.GetNamespace(), &manifest,
 		r.pkg.GetSpec().PackageInfo.Version); err != nil {
 		r.setShouldUpdate(
 			conditions.SetFailed(ctx, r.EventRecorder, r.pkg, &r.pkg.GetStatus().Conditions,
@@ -369,19 +586,16 @@ func (r *PackageReconcilationContext) ensureDependencies(ctx context.Context) bo
 				log.Error(err, "could not get all repos for package", "required", requirement.Name)
 			}
 
-			var repositoryName string
-			switch len(repositories) {
-			case 0:
-				log.Error(err, "could not find package in any repo", "required", requirement.Name)
-				failed = append(failed, requirement.Name)
-				continue
-			case 1:
-				repositoryName = repositories[0].Name
-			default:
-				log.Error(err, "dependency in multiple repos is not supported yet", "required", requirement.Name)
+			resolution, err := repoclient.ResolveRepository(requirement.Name, repositories)
+			if err != nil {
+				log.Error(err, "could not resolve repository of required package", "required", requirement.Name)
 				failed = append(failed, requirement.Name)
 				continue
+			} else if len(resolution.Alternatives) > 0 {
+				log.Info("resolved repository of required package", "required", requirement.Name,
+					"repository", resolution.String())
 			}
+			repositoryName := resolution.Repository.Name
 
 			if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, newPkg, func() error {
 				newPkg.GetSpec().PackageInfo = packagesv1alpha1.PackageInfoTemplate{
@@ -454,7 +668,7 @@ func (r *PackageReconcilationContext) ensureDependencies(ctx context.Context) bo
 	}
 
 	// if all requirements fulfilled, status can be checked
-	for _, dep := range r.pi.Status.Manifest.Dependencies {
+	for _, dep := range manifest.Dependencies {
 		requiredPkg := packagesv1alpha1.ClusterPackage{
 			ObjectMeta: metav1.ObjectMeta{Name: dep.Name},
 		}
@@ -490,7 +704,7 @@ func (r *PackageReconcilationContext) ensureDependencies(ctx context.Context) bo
 	}
 
 	if len(waitingFor) > 0 {
-		message := fmt.Sprintf("waiting for required package(s) %v", strings.Join(waitingFor, ","))
+		message := fmt.Sprintf("%v %v", waitingForDependencies, strings.Join(waitingFor, ","))
 		r.setShouldUpdate(
 			conditions.SetUnknown(ctx, &r.pkg.GetStatus().Conditions, condition.Pending, message))
 		return false
@@ -509,11 +723,17 @@ func (r *PackageReconcilationContext) ensurePackageInfo(ctx context.Context) err
 
 	log.V(1).Info("ensuring PackageInfo")
 	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, &packageInfo, func() error {
-		packageInfo.Spec = packagesv1alpha1.PackageInfoSpec{
+		spec := packagesv1alpha1.PackageInfoSpec{
 			Name:           r.pkg.GetSpec().PackageInfo.Name,
 			Version:        r.pkg.GetSpec().PackageInfo.Version,
 			RepositoryName: r.pkg.GetSpec().PackageInfo.RepositoryName,
+			Digest:         r.pkg.GetSpec().PackageInfo.Digest,
+		}
+		if packageInfo.Spec != spec {
+			// only a changed spec causes a new fetch, so the trace is not propagated otherwise
+			tracing.Inject(ctx, &packageInfo)
 		}
+		packageInfo.Spec = spec
 		return nil
 	})
 	if err != nil {
@@ -545,6 +765,16 @@ func (r *PackageReconcilationContext) ensurePackageInfo(ctx context.Context) err
 	return nil
 }
 
+// setFailedResources replaces the failed resources in the status of the package and returns true if they changed
+func (r *PackageReconcilationContext) setFailedResources(failedResources []v1alpha1.FailedResourceRef) bool {
+	status := r.pkg.GetStatus()
+	if slices.Equal(status.FailedResources, failedResources) {
+		return false
+	}
+	status.FailedResources = failedResources
+	return true
+}
+
 func (r *PackageReconcilationContext) handleAdapterResults(ctx context.Context, results []result.ReconcileResult) bool {
 	var firstFailed *result.ReconcileResult
 	var firstWaiting *result.ReconcileResult
@@ -584,8 +814,18 @@ func (r *PackageReconcilationContext) afterSuccess(ctx context.Context, results
 
 	r.setShouldUpdate(
 		conditions.SetReady(ctx, r.EventRecorder, r.pkg, &r.pkg.GetStatus().Conditions, reason, message))
-	r.setShouldUpdate(r.pkg.GetStatus().Version != r.pi.Status.Version)
+	if !r.wasReady() {
+		events.Normal(r.EventRecorder, r.pkg, events.Ready, "Version %v is ready", r.pi.Status.Version)
+	}
+	if r.previousVersion != "" && r.previousVersion != r.pi.Status.Version {
+		events.Normal(r.EventRecorder, r.pkg, events.Updated, "Updated from version %v to %v",
+			r.previousVersion, r.pi.Status.Version)
+	}
+	r.setShouldUpdate(r.pkg.GetStatus().Version != r.pi.Status.Version || r.pkg.GetStatus().Digest != r.pi.Status.Digest)
 	r.pkg.GetStatus().Version = r.pi.Status.Version
+	r.pkg.GetStatus().Digest = r.pi.Status.Digest
+	r.setShouldUpdate(
+		revisions.Record(r.pkg.GetStatus(), r.pkg.GetSpec(), r.RevisionHistoryLimit, time.Now()))
 	r.isSuccess = true
 }
 
@@ -618,6 +858,8 @@ func (r *PackageReconcilationContext) actualFinalize(ctx context.Context) error
 		log.V(1).Info("cleanup done")
 	}
 
+	r.recordFailure()
+
 	if r.shouldUpdateStatus {
 		if err := r.Status().Update(ctx, r.pkg); err != nil {
 			log.Error(err, "package status update failed")
@@ -637,6 +879,20 @@ func (r *PackageReconcilationContext) actualFinalize(ctx context.Context) error
 	return errs
 }
 
+// recordFailure records an event if the package has failed in this reconciliation, unless it has already failed for
+// the same reason before.
+func (r *PackageReconcilationContext) recordFailure() {
+	failed := meta.FindStatusCondition(r.pkg.GetStatus().Conditions, string(condition.Failed))
+	if failed == nil || failed.Status != metav1.ConditionTrue {
+		return
+	}
+	if r.previousFailed != nil && r.previousFailed.Status == metav1.ConditionTrue &&
+		r.previousFailed.Message == failed.Message {
+		return
+	}
+	events.Warning(r.EventRecorder, r.pkg, events.Failed, "%v", failed.Message)
+}
+
 func (r *PackageReconcilationContext) cleanup(ctx context.Context) error {
 	return multierr.Combine(
 		r.pruneOwnedResources(ctx),
@@ -3,6 +3,7 @@ package conditions
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/glasskube/glasskube/internal/telemetry"
 	"github.com/glasskube/glasskube/pkg/condition"
@@ -43,12 +44,12 @@ func SetUnknownAndUpdate(ctx context.Context, client client.Client, obj client.O
 func SetReady(ctx context.Context, recorder record.EventRecorder, obj client.Object, objConditions *[]metav1.Condition, reason condition.Reason, message string) bool {
 	log := log.FromContext(ctx)
 	log.V(1).Info("set condition to ready: " + message)
-	recorder.Event(obj, "Normal", string(reason), message)
 	changed := setStatusConditions(objConditions,
 		metav1.Condition{Type: string(condition.Ready), Status: metav1.ConditionTrue, Reason: string(reason), Message: message},
 		metav1.Condition{Type: string(condition.Failed), Status: metav1.ConditionFalse, Reason: string(reason), Message: message},
 	)
 	if changed {
+		recorder.Event(obj, "Normal", string(reason), message)
 		telemetry.ForOperator().OnEvent(obj, condition.Ready, reason)
 	}
 	return changed
@@ -64,12 +65,12 @@ func SetReadyAndUpdate(ctx context.Context, client client.Client, recorder recor
 func SetFailed(ctx context.Context, recorder record.EventRecorder, obj client.Object, objConditions *[]metav1.Condition, reason condition.Reason, message string) bool {
 	log := log.FromContext(ctx)
 	log.V(1).Info("set condition to failed: " + message)
-	recorder.Event(obj, "Warning", string(reason), message)
 	changed := setStatusConditions(objConditions,
 		metav1.Condition{Type: string(condition.Ready), Status: metav1.ConditionFalse, Reason: string(reason), Message: message},
 		metav1.Condition{Type: string(condition.Failed), Status: metav1.ConditionTrue, Reason: string(reason), Message: message},
 	)
 	if changed {
+		recorder.Event(obj, "Warning", string(reason), message)
 		telemetry.ForOperator().OnEvent(obj, condition.Failed, reason)
 	}
 	return changed
@@ -83,6 +84,19 @@ func SetFailedAndUpdate(ctx context.Context, client client.Client, recorder reco
 	return nil
 }
 
+// SetHealthy sets the Healthy condition, which is independent of the Ready and Failed conditions. If problems is
+// empty, its Status is True, otherwise it is False and the message contains all problems.
+func SetHealthy(ctx context.Context, objConditions *[]metav1.Condition, problems []string) bool {
+	if len(problems) == 0 {
+		return setStatusConditions(objConditions, metav1.Condition{Type: string(condition.Healthy),
+			Status: metav1.ConditionTrue, Reason: string(condition.WorkloadsHealthy), Message: "All workloads are healthy"})
+	}
+	message := strings.Join(problems, "\n")
+	log.FromContext(ctx).V(1).Info("set condition to unhealthy: " + message)
+	return setStatusConditions(objConditions, metav1.Condition{Type: string(condition.Healthy),
+		Status: metav1.ConditionFalse, Reason: string(condition.WorkloadsUnhealthy), Message: message})
+}
+
 func updateAfterConditionsChanged(ctx context.Context, cl client.Client, obj client.Object) error {
 	log := log.FromContext(ctx)
 	log.V(1).Info("Updating status after conditions changed")
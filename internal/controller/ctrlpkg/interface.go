@@ -1,6 +1,8 @@
 package ctrlpkg
 
 import (
+	"time"
+
 	"github.com/glasskube/glasskube/api/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -14,8 +16,20 @@ type Package interface {
 	schema.ObjectKind
 	AutoUpdatesEnabled() bool
 	SetAutoUpdatesEnabled(enabled bool)
+	VersionConstraint() string
+	SetVersionConstraint(constraint string)
+	UpdateNotifiedVersion() string
+	SetUpdateNotifiedVersion(version string)
 	InstalledAsDependency() bool
 	SetInstalledAsDependency(value bool)
+	IsPaused() bool
+	SetPaused(value bool)
+	RetryRequestedAt() (time.Time, bool)
+	RequestRetry(now time.Time)
+	IsRetryRequested() bool
+	RecreateRequestedAt() (time.Time, bool)
+	RequestRecreate(now time.Time)
+	IsRecreateRequested() bool
 	GetSpec() *v1alpha1.PackageSpec
 	GetStatus() *v1alpha1.PackageStatus
 	IsNamespaceScoped() bool
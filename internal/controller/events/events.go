@@ -0,0 +1,49 @@
+// Package events defines the reasons of the Kubernetes events that are recorded for lifecycle transitions of
+// packages. These reasons are part of the public interface of the operator: They are stable and can be used by
+// other tools, for example to filter the output of "kubectl get events" or in an event exporter.
+package events
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+type Reason string
+
+const (
+	// InstallStarted is recorded when the operator picks up a package for the first time
+	InstallStarted Reason = "InstallStarted"
+	// DependenciesResolved is recorded when all required packages and components of a package are ready
+	DependenciesResolved Reason = "DependenciesResolved"
+	// Applied is recorded when the manifests of a package have been applied to the cluster
+	Applied Reason = "Applied"
+	// Ready is recorded when a package becomes ready
+	Ready Reason = "Ready"
+	// Failed is recorded when a package fails, or when the cause of the failure changes
+	Failed Reason = "Failed"
+	// Updated is recorded when a different version of a package has been installed successfully
+	Updated Reason = "Updated"
+	// Unhealthy is recorded when a workload of an installed package becomes unhealthy
+	Unhealthy Reason = "Unhealthy"
+	// Recovered is recorded when all workloads of a previously unhealthy package are healthy again
+	Recovered Reason = "Recovered"
+	// Retried is recorded when the failed resources of a package are applied again, because a user requested it
+	Retried Reason = "Retried"
+	// Recreated is recorded when resources of a package are deleted and applied again, because their immutable fields
+	// changed and a user requested to recreate them
+	Recreated Reason = "Recreated"
+	// HookStarted is recorded when the Job of a pre-install or post-install hook of a package has been created
+	HookStarted Reason = "HookStarted"
+	// HookSucceeded is recorded when the Job of a hook has completed successfully. If it fails, Failed is recorded.
+	HookSucceeded Reason = "HookSucceeded"
+	// Uninstalled is recorded when all resources of a package have been removed and the package is about to be deleted
+	Uninstalled Reason = "Uninstalled"
+)
+
+func Normal(recorder record.EventRecorder, obj runtime.Object, reason Reason, messageFmt string, args ...any) {
+	recorder.Eventf(obj, "Normal", string(reason), messageFmt, args...)
+}
+
+func Warning(recorder record.EventRecorder, obj runtime.Object, reason Reason, messageFmt string, args ...any) {
+	recorder.Eventf(obj, "Warning", string(reason), messageFmt, args...)
+}
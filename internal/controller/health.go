@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ctrladapter "github.com/glasskube/glasskube/internal/adapter/controllerruntime"
+	"github.com/glasskube/glasskube/internal/controller/conditions"
+	"github.com/glasskube/glasskube/internal/controller/events"
+	"github.com/glasskube/glasskube/internal/notification"
+	"github.com/glasskube/glasskube/internal/workloads"
+	"github.com/glasskube/glasskube/pkg/condition"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+//+kubebuilder:rbac:groups=apps,resources=deployments;statefulsets;daemonsets,verbs=get;list;watch
+
+// updateHealth sets the Healthy condition of an installed package based on the health of its workloads. This is
+// independent of the Ready condition: A package that was installed successfully can become unhealthy later on, for
+// example if its pods are crash looping. When a package becomes unhealthy, a warning event is recorded and a
+// notification is sent, if configured.
+func (r *PackageReconcilationContext) updateHealth(ctx context.Context) {
+	if r.pkg.GetStatus().Version == "" {
+		return
+	}
+	log := ctrl.LoggerFrom(ctx)
+	problems, err := r.workloadProblems(ctx)
+	if err != nil {
+		log.Error(err, "could not check health of workloads")
+		return
+	}
+
+	status := r.pkg.GetStatus()
+	wasUnhealthy := meta.IsStatusConditionFalse(status.Conditions, string(condition.Healthy))
+	if !conditions.SetHealthy(ctx, &status.Conditions, problems) {
+		return
+	}
+	r.setShouldUpdate(true)
+	if len(problems) == 0 {
+		if wasUnhealthy {
+			events.Normal(r.EventRecorder, r.pkg, events.Recovered, "All workloads are healthy again")
+		}
+		return
+	}
+
+	message := strings.Join(problems, "\n")
+	events.Warning(r.EventRecorder, r.pkg, events.Unhealthy, "Workloads are unhealthy: %v", message)
+	if !wasUnhealthy {
+		if err := r.notifyUnhealthy(ctx, message); err != nil {
+			log.Error(err, "could not send unhealthy notification")
+		}
+	}
+}
+
+// workloadProblems returns a description of every unhealthy workload that belongs to the package
+func (r *PackageReconcilationContext) workloadProblems(ctx context.Context) ([]string, error) {
+	var objs []metav1.Object
+	var deployments appsv1.DeploymentList
+	if err := r.List(ctx, &deployments); err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for i := range deployments.Items {
+		objs = append(objs, &deployments.Items[i])
+	}
+	var statefulSets appsv1.StatefulSetList
+	if err := r.List(ctx, &statefulSets); err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for i := range statefulSets.Items {
+		objs = append(objs, &statefulSets.Items[i])
+	}
+	var daemonSets appsv1.DaemonSetList
+	if err := r.List(ctx, &daemonSets); err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	for i := range daemonSets.Items {
+		objs = append(objs, &daemonSets.Items[i])
+	}
+
+	var problems []string
+	for _, obj := range objs {
+		if workloads.BelongsTo(r.pkg, workloads.Kind(obj), obj) {
+			if problem := workloads.Problem(obj); problem != "" {
+				problems = append(problems, problem)
+			}
+		}
+	}
+	return problems, nil
+}
+
+func (r *PackageReconcilationContext) notifyUnhealthy(ctx context.Context, message string) error {
+	if r.Notifier == nil {
+		return nil
+	}
+	packageName := r.pkg.GetSpec().PackageInfo.Name
+	config, err := notification.LoadConfig(ctx, ctrladapter.NewKubernetesClientAdapter(r.Client))
+	if err != nil {
+		return fmt.Errorf("failed to load notification config: %w", err)
+	} else if !config.Watches(packageName) {
+		return nil
+	}
+	return r.Notifier.NotifyPackageUnhealthy(ctx, config, notification.PackageUnhealthy{
+		Name:        r.pkg.GetName(),
+		Namespace:   r.pkg.GetNamespace(),
+		PackageName: packageName,
+		Message:     message,
+	})
+}
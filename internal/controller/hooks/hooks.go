@@ -0,0 +1,303 @@
+// Package hooks runs the pre-install and post-install hooks of packages (see v1alpha1.PackageHooks). Every hook is
+// run as a Job that is owned by the package. The hooks of a phase are run one after another, and the state of their
+// Jobs is recorded in the status of the package, so that every hook is only run once per version.
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/controller/labels"
+	"github.com/glasskube/glasskube/internal/controller/owners"
+	"github.com/glasskube/glasskube/internal/manifest/plain"
+	"github.com/glasskube/glasskube/internal/names"
+	"github.com/glasskube/glasskube/internal/resourcepatch"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+
+// Runner starts the Jobs of hooks and checks whether they have completed
+type Runner struct {
+	client.Client
+	*owners.OwnerManager
+	Renderer *plain.Adapter
+}
+
+// Result describes the progress of the hooks of one phase after Run
+type Result struct {
+	// Done is true if all hooks of the phase have completed successfully
+	Done bool
+	// Running is the hook that has not completed yet, if any
+	Running *v1alpha1.HookStatus
+	// Failed is the hook that has failed, if any. No further hooks are run until it is retried.
+	Failed *v1alpha1.HookStatus
+	// Started is the hook whose Job has been created by Run, if any
+	Started *v1alpha1.HookStatus
+	// Succeeded are the hooks that have completed since the last Run
+	Succeeded []v1alpha1.HookStatus
+	// Retried is true if the Job of a failed hook has been deleted to run it again, because a retry was requested
+	Retried bool
+	// Changed is true if the hooks in the status of the package have been changed
+	Changed bool
+}
+
+// Of returns the hooks of the given phase
+func Of(manifest *v1alpha1.PackageManifest, phase v1alpha1.HookPhase) []v1alpha1.PackageHook {
+	if manifest == nil || manifest.Hooks == nil {
+		return nil
+	}
+	switch phase {
+	case v1alpha1.HookPhasePreInstall:
+		return manifest.Hooks.PreInstall
+	case v1alpha1.HookPhasePostInstall:
+		return manifest.Hooks.PostInstall
+	default:
+		return nil
+	}
+}
+
+// PhaseName returns the name of phase as it is shown to users, e.g. "pre-install"
+func PhaseName(phase v1alpha1.HookPhase) string {
+	switch phase {
+	case v1alpha1.HookPhasePreInstall:
+		return "pre-install"
+	case v1alpha1.HookPhasePostInstall:
+		return "post-install"
+	default:
+		return string(phase)
+	}
+}
+
+// Validate returns an error if a hook of the manifest has no name, or if two hooks of the same phase have the same
+// name
+func Validate(manifest *v1alpha1.PackageManifest) error {
+	for _, phase := range []v1alpha1.HookPhase{v1alpha1.HookPhasePreInstall, v1alpha1.HookPhasePostInstall} {
+		var seen []string
+		for _, hook := range Of(manifest, phase) {
+			if hook.Name == "" {
+				return fmt.Errorf("%v hook with url %v has no name", PhaseName(phase), hook.Url)
+			} else if slices.Contains(seen, hook.Name) {
+				return fmt.Errorf("%v hook %v is declared more than once", PhaseName(phase), hook.Name)
+			}
+			seen = append(seen, hook.Name)
+		}
+	}
+	return nil
+}
+
+// Run continues running the hooks of the given phase for the version of pi. Hooks that have completed before are
+// skipped. If the Job of a hook is still running or has failed, Run returns without starting the next one. A failed
+// hook is only run again if retry is true. The status of the package is updated in place, and the Jobs and statuses
+// of hooks of other versions are deleted.
+func (r *Runner) Run(
+	ctx context.Context,
+	pkg ctrlpkg.Package,
+	pi *v1alpha1.PackageInfo,
+	phase v1alpha1.HookPhase,
+	patches resourcepatch.TargetPatches,
+	retry bool,
+) (*Result, error) {
+	var res Result
+	if changed, err := r.prune(ctx, pkg, pi.Status.Version); err != nil {
+		return nil, err
+	} else {
+		res.Changed = changed
+	}
+
+	status := pkg.GetStatus()
+	for _, hook := range Of(pi.Status.Manifest, phase) {
+		index := indexOf(status.Hooks, phase, hook.Name)
+		if index < 0 {
+			return r.startNext(ctx, pkg, pi, phase, hook, patches, 0, &res)
+		}
+
+		hookStatus := &status.Hooks[index]
+		switch hookStatus.State {
+		case v1alpha1.HookSucceeded:
+			continue
+		case v1alpha1.HookFailed:
+			if !retry {
+				res.Failed = hookStatus
+				return &res, nil
+			}
+			// the Job of the next attempt has a different name, so the failed Job does not have to be gone yet
+			if err := r.deleteJob(ctx, *hookStatus); err != nil {
+				return nil, err
+			}
+			res.Retried = true
+			attempt := hookStatus.Attempt + 1
+			status.Hooks = slices.Delete(status.Hooks, index, index+1)
+			return r.startNext(ctx, pkg, pi, phase, hook, patches, attempt, &res)
+		}
+
+		var job batchv1.Job
+		if err := r.Get(ctx, jobKey(*hookStatus), &job); apierrors.IsNotFound(err) {
+			// The Job was deleted before it completed, or it has just been created and is not in the cache yet.
+			// In both cases, it is created again, which has no effect in the latter case.
+			attempt := hookStatus.Attempt
+			status.Hooks = slices.Delete(status.Hooks, index, index+1)
+			return r.startNext(ctx, pkg, pi, phase, hook, patches, attempt, &res)
+		} else if err != nil {
+			return nil, err
+		}
+
+		if state, message := JobState(&job); hookStatus.State != state || hookStatus.Message != message {
+			hookStatus.State = state
+			hookStatus.Message = message
+			res.Changed = true
+		}
+		switch hookStatus.State {
+		case v1alpha1.HookSucceeded:
+			res.Succeeded = append(res.Succeeded, *hookStatus)
+		case v1alpha1.HookFailed:
+			res.Failed = hookStatus
+			return &res, nil
+		default:
+			res.Running = hookStatus
+			return &res, nil
+		}
+	}
+	res.Done = true
+	return &res, nil
+}
+
+// startNext starts the given hook and adds it to the status of the package as the running hook of res
+func (r *Runner) startNext(
+	ctx context.Context,
+	pkg ctrlpkg.Package,
+	pi *v1alpha1.PackageInfo,
+	phase v1alpha1.HookPhase,
+	hook v1alpha1.PackageHook,
+	patches resourcepatch.TargetPatches,
+	attempt int32,
+	res *Result,
+) (*Result, error) {
+	hookStatus, created, err := r.start(ctx, pkg, pi, phase, hook, patches, attempt)
+	if err != nil {
+		return nil, fmt.Errorf("could not start %v hook %v: %w", PhaseName(phase), hook.Name, err)
+	}
+	status := pkg.GetStatus()
+	status.Hooks = append(status.Hooks, *hookStatus)
+	if created {
+		res.Started = hookStatus
+	}
+	res.Running = &status.Hooks[len(status.Hooks)-1]
+	res.Changed = true
+	return res, nil
+}
+
+// start creates the Job of the given hook and the namespace it is created in, if necessary. It returns false if the
+// Job already existed.
+func (r *Runner) start(
+	ctx context.Context,
+	pkg ctrlpkg.Package,
+	pi *v1alpha1.PackageInfo,
+	phase v1alpha1.HookPhase,
+	hook v1alpha1.PackageHook,
+	patches resourcepatch.TargetPatches,
+	attempt int32,
+) (*v1alpha1.HookStatus, bool, error) {
+	job, namespace, err := r.Renderer.RenderHook(ctx, pkg, pi, hook, patches)
+	if err != nil {
+		return nil, false, err
+	}
+	if namespace != nil {
+		if err := r.SetOwnerIfManagedOrNotExists(r.Client, ctx, pkg, namespace); err != nil {
+			return nil, false, err
+		} else if err := r.Patch(ctx, namespace, client.Apply, plain.FieldOwner, client.ForceOwnership); err != nil {
+			return nil, false, err
+		}
+	}
+
+	job.SetName(names.HookJobName(pkg, phase, hook.Name, pi.Status.Version, attempt))
+	labels.SetManaged(job)
+	if err := r.SetOwner(pkg, job, owners.BlockOwnerDeletion); err != nil {
+		return nil, false, err
+	}
+	created := true
+	if err := r.Create(ctx, job); apierrors.IsAlreadyExists(err) {
+		created = false
+	} else if err != nil {
+		return nil, false, err
+	} else {
+		ctrl.LoggerFrom(ctx).Info("started hook", "phase", phase, "hook", hook.Name,
+			"namespace", job.GetNamespace(), "job", job.GetName())
+	}
+	return &v1alpha1.HookStatus{
+		Name:         hook.Name,
+		Phase:        phase,
+		Version:      pi.Status.Version,
+		JobName:      job.GetName(),
+		JobNamespace: job.GetNamespace(),
+		State:        v1alpha1.HookRunning,
+		Attempt:      attempt,
+	}, created, nil
+}
+
+// prune deletes the Jobs of all hooks that were run for a different version than the given one and removes them from
+// the status of the package. It returns true if the status changed.
+func (r *Runner) prune(ctx context.Context, pkg ctrlpkg.Package, version string) (bool, error) {
+	status := pkg.GetStatus()
+	length := len(status.Hooks)
+	for _, hookStatus := range status.Hooks {
+		if hookStatus.Version != version {
+			if err := r.deleteJob(ctx, hookStatus); err != nil {
+				return false, err
+			}
+		}
+	}
+	status.Hooks = slices.DeleteFunc(status.Hooks, func(hookStatus v1alpha1.HookStatus) bool {
+		return hookStatus.Version != version
+	})
+	return len(status.Hooks) != length, nil
+}
+
+func (r *Runner) deleteJob(ctx context.Context, hookStatus v1alpha1.HookStatus) error {
+	job := batchv1.Job{}
+	job.SetName(hookStatus.JobName)
+	job.SetNamespace(hookStatus.JobNamespace)
+	// the pods of the Job must be deleted as well, which does not happen with the default orphan propagation
+	err := r.Delete(ctx, &job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("could not delete Job of hook %v: %w", hookStatus.Name, err)
+	}
+	return nil
+}
+
+// JobState returns the state of a hook whose Job is job, and a message if the Job has failed
+func JobState(job *batchv1.Job) (v1alpha1.HookState, string) {
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			return v1alpha1.HookSucceeded, ""
+		case batchv1.JobFailed:
+			if c.Message != "" {
+				return v1alpha1.HookFailed, fmt.Sprintf("%v: %v", c.Reason, c.Message)
+			}
+			return v1alpha1.HookFailed, c.Reason
+		}
+	}
+	return v1alpha1.HookRunning, ""
+}
+
+func indexOf(hooks []v1alpha1.HookStatus, phase v1alpha1.HookPhase, name string) int {
+	return slices.IndexFunc(hooks, func(hookStatus v1alpha1.HookStatus) bool {
+		return hookStatus.Phase == phase && hookStatus.Name == name
+	})
+}
+
+func jobKey(hookStatus v1alpha1.HookStatus) client.ObjectKey {
+	return client.ObjectKey{Namespace: hookStatus.JobNamespace, Name: hookStatus.JobName}
+}
@@ -0,0 +1,155 @@
+package hooks
+
+import (
+	"context"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func hookStatus(name string, phase v1alpha1.HookPhase, version string, state v1alpha1.HookState) v1alpha1.HookStatus {
+	return v1alpha1.HookStatus{
+		Name:         name,
+		Phase:        phase,
+		Version:      version,
+		JobName:      name + "-" + version,
+		JobNamespace: "default",
+		State:        state,
+	}
+}
+
+func job(status v1alpha1.HookStatus, conditionType batchv1.JobConditionType) *batchv1.Job {
+	result := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: status.JobName, Namespace: status.JobNamespace}}
+	if conditionType != "" {
+		result.Status.Conditions = []batchv1.JobCondition{{
+			Type:    conditionType,
+			Status:  corev1.ConditionTrue,
+			Reason:  "BackoffLimitExceeded",
+			Message: "Job has reached the specified backoff limit",
+		}}
+	}
+	return result
+}
+
+var _ = Describe("Hooks", func() {
+	manifest := &v1alpha1.PackageManifest{Hooks: &v1alpha1.PackageHooks{
+		PreInstall:  []v1alpha1.PackageHook{{Name: "migrate", Url: "migrate.yaml"}, {Name: "seed", Url: "seed.yaml"}},
+		PostInstall: []v1alpha1.PackageHook{{Name: "verify", Url: "verify.yaml"}},
+	}}
+
+	Describe("Validate", func() {
+		It("should accept unique hook names", func() {
+			Expect(Validate(manifest)).To(Succeed())
+			Expect(Validate(&v1alpha1.PackageManifest{})).To(Succeed())
+		})
+		It("should reject hooks without a name", func() {
+			Expect(Validate(&v1alpha1.PackageManifest{Hooks: &v1alpha1.PackageHooks{
+				PostInstall: []v1alpha1.PackageHook{{Url: "verify.yaml"}},
+			}})).To(MatchError(ContainSubstring("post-install hook with url verify.yaml has no name")))
+		})
+		It("should reject duplicate hook names of the same phase", func() {
+			Expect(Validate(&v1alpha1.PackageManifest{Hooks: &v1alpha1.PackageHooks{
+				PreInstall:  []v1alpha1.PackageHook{{Name: "migrate"}, {Name: "migrate"}},
+				PostInstall: []v1alpha1.PackageHook{{Name: "migrate"}},
+			}})).To(MatchError(ContainSubstring("pre-install hook migrate is declared more than once")))
+		})
+	})
+
+	Describe("JobState", func() {
+		It("should treat a Job without conditions as running", func() {
+			Expect(JobState(&batchv1.Job{})).To(Equal(v1alpha1.HookRunning))
+		})
+		It("should report a completed Job as succeeded", func() {
+			state, message := JobState(job(v1alpha1.HookStatus{}, batchv1.JobComplete))
+			Expect(state).To(Equal(v1alpha1.HookSucceeded))
+			Expect(message).To(BeEmpty())
+		})
+		It("should report the reason of a failed Job", func() {
+			state, message := JobState(job(v1alpha1.HookStatus{}, batchv1.JobFailed))
+			Expect(state).To(Equal(v1alpha1.HookFailed))
+			Expect(message).To(Equal("BackoffLimitExceeded: Job has reached the specified backoff limit"))
+		})
+	})
+
+	Describe("Run", func() {
+		var scheme *runtime.Scheme
+		var pkg *v1alpha1.ClusterPackage
+		var pi *v1alpha1.PackageInfo
+
+		BeforeEach(func() {
+			scheme = runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+			pkg = &v1alpha1.ClusterPackage{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+			pi = &v1alpha1.PackageInfo{Status: v1alpha1.PackageInfoStatus{Version: "v2", Manifest: manifest}}
+		})
+
+		runner := func(objects ...client.Object) *Runner {
+			return &Runner{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()}
+		}
+
+		It("should wait for a running Job", func() {
+			migrate := hookStatus("migrate", v1alpha1.HookPhasePreInstall, "v2", v1alpha1.HookRunning)
+			pkg.Status.Hooks = []v1alpha1.HookStatus{migrate}
+			res, err := runner(job(migrate, "")).Run(context.Background(), pkg, pi, v1alpha1.HookPhasePreInstall, nil, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.Done).To(BeFalse())
+			Expect(res.Running).NotTo(BeNil())
+			Expect(res.Running.Name).To(Equal("migrate"))
+			Expect(res.Changed).To(BeFalse())
+		})
+
+		It("should be done when all Jobs have completed", func() {
+			migrate := hookStatus("migrate", v1alpha1.HookPhasePreInstall, "v2", v1alpha1.HookSucceeded)
+			seed := hookStatus("seed", v1alpha1.HookPhasePreInstall, "v2", v1alpha1.HookRunning)
+			pkg.Status.Hooks = []v1alpha1.HookStatus{migrate, seed}
+			res, err := runner(job(seed, batchv1.JobComplete)).
+				Run(context.Background(), pkg, pi, v1alpha1.HookPhasePreInstall, nil, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.Done).To(BeTrue())
+			Expect(res.Changed).To(BeTrue())
+			Expect(res.Succeeded).To(HaveLen(1))
+			Expect(pkg.Status.Hooks[1].State).To(Equal(v1alpha1.HookSucceeded))
+		})
+
+		It("should stop at a failed Job", func() {
+			migrate := hookStatus("migrate", v1alpha1.HookPhasePreInstall, "v2", v1alpha1.HookRunning)
+			pkg.Status.Hooks = []v1alpha1.HookStatus{migrate}
+			res, err := runner(job(migrate, batchv1.JobFailed)).
+				Run(context.Background(), pkg, pi, v1alpha1.HookPhasePreInstall, nil, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.Done).To(BeFalse())
+			Expect(res.Failed).NotTo(BeNil())
+			Expect(pkg.Status.FailedHook()).NotTo(BeNil())
+			Expect(pkg.Status.FailedHook().Message).To(ContainSubstring("BackoffLimitExceeded"))
+
+			res, err = runner().Run(context.Background(), pkg, pi, v1alpha1.HookPhasePreInstall, nil, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.Failed).NotTo(BeNil())
+			Expect(res.Changed).To(BeFalse())
+		})
+
+		It("should delete the Jobs of other versions", func() {
+			old := hookStatus("verify", v1alpha1.HookPhasePostInstall, "v1", v1alpha1.HookSucceeded)
+			current := hookStatus("verify", v1alpha1.HookPhasePostInstall, "v2", v1alpha1.HookSucceeded)
+			pkg.Status.Hooks = []v1alpha1.HookStatus{old, current}
+			r := runner(job(old, batchv1.JobComplete))
+			res, err := r.Run(context.Background(), pkg, pi, v1alpha1.HookPhasePostInstall, nil, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.Done).To(BeTrue())
+			Expect(res.Changed).To(BeTrue())
+			Expect(pkg.Status.Hooks).To(Equal([]v1alpha1.HookStatus{current}))
+			err = r.Get(context.Background(), jobKey(old), &batchv1.Job{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
@@ -25,6 +25,7 @@ import (
 	"github.com/glasskube/glasskube/internal/controller/owners"
 	"github.com/glasskube/glasskube/internal/controller/requeue"
 	repoclient "github.com/glasskube/glasskube/internal/repo/client"
+	"github.com/glasskube/glasskube/internal/tracing"
 	"github.com/glasskube/glasskube/pkg/condition"
 	"go.uber.org/multierr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -73,7 +74,7 @@ func (r *PackageInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	if shouldSyncFromRepo(packageInfo) {
 		log.Info("updating manifest")
-		if err := r.updatePackageManifest(&packageInfo); err != nil {
+		if err := r.updatePackageManifest(ctx, &packageInfo); err != nil {
 			err1 := conditions.SetFailedAndUpdate(ctx, r.Client, r.EventRecorder, &packageInfo, &packageInfo.Status.Conditions,
 				condition.SyncFailed, err.Error())
 			return requeue.Always(ctx, multierr.Append(err, err1))
@@ -113,13 +114,23 @@ func (r *PackageInfoReconciler) isLatestResourceVersion(ctx context.Context, pac
 func shouldSyncFromRepo(pi packagesv1alpha1.PackageInfo) bool {
 	return pi.Status.LastUpdateTimestamp == nil ||
 		(pi.Spec.Version != "" && pi.Spec.Version != pi.Status.Version) ||
+		(pi.Spec.Digest != "" && pi.Spec.Digest != pi.Status.Digest) ||
 		time.Since(pi.Status.LastUpdateTimestamp.Time) > repositorySyncInterval
 }
 
-func (r *PackageInfoReconciler) updatePackageManifest(pi *packagesv1alpha1.PackageInfo) error {
+func (r *PackageInfoReconciler) updatePackageManifest(ctx context.Context, pi *packagesv1alpha1.PackageInfo) (err error) {
+	if pi.Spec.Version != pi.Status.Version {
+		// the fetch is part of an install or update operation, whose trace is continued
+		ctx = tracing.Extract(ctx, pi)
+	}
+	_, span := tracing.Start(ctx, "repository fetch", tracing.PackageAttributes(pi, pi.Spec.Name, pi.Spec.Version)...)
+	defer func() { tracing.End(span, err) }()
+
 	var manifest packagesv1alpha1.PackageManifest
 	repo := r.RepoClient.ForRepoWithName(pi.Spec.RepositoryName)
-	if err := repo.FetchPackageManifest(pi.Spec.Name, pi.Spec.Version, &manifest); err != nil {
+	digest, err := repoclient.FetchPackageManifestWithDigest(repo, pi.Spec.Name, pi.Spec.Version, pi.Spec.Digest,
+		&manifest)
+	if err != nil {
 		return err
 	}
 	if url, err := repo.GetPackageManifestURL(pi.Spec.Name, pi.Spec.Version); err != nil {
@@ -129,6 +140,7 @@ func (r *PackageInfoReconciler) updatePackageManifest(pi *packagesv1alpha1.Packa
 	}
 	pi.Status.Manifest = &manifest
 	pi.Status.Version = pi.Spec.Version
+	pi.Status.Digest = digest
 	return nil
 }
 
@@ -19,19 +19,26 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
 	packagesv1alpha1 "github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/controller/reposync"
 	"github.com/glasskube/glasskube/internal/controller/requeue"
+	"github.com/glasskube/glasskube/internal/httperror"
+	"github.com/glasskube/glasskube/internal/notification"
 	repoclient "github.com/glasskube/glasskube/internal/repo/client"
-	repotypes "github.com/glasskube/glasskube/internal/repo/types"
 	"github.com/glasskube/glasskube/pkg/condition"
 	"go.uber.org/multierr"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
 // PackageRepositoryReconciler reconciles a PackageRepository object
@@ -39,8 +46,25 @@ type PackageRepositoryReconciler struct {
 	client.Client
 	Scheme     *runtime.Scheme
 	RepoClient repoclient.RepoClientset
+	Notifier   *notification.Notifier
+	// MaxRetryDuration is the time for which a repository that fails to sync with a transient error is reported as
+	// retrying, before it is marked as failed. If it is zero, DefaultMaxRetryDuration is used.
+	MaxRetryDuration time.Duration
+	// MaxConcurrentSyncs is the number of repositories that are synced in parallel. If it is zero,
+	// DefaultMaxConcurrentSyncs is used.
+	MaxConcurrentSyncs int
+	// SyncTimeout is the time after which a sync is aborted and reported as failed temporarily. If it is zero,
+	// reposync.DefaultTimeout is used.
+	SyncTimeout time.Duration
+	syncs       reposync.Runner
 }
 
+const (
+	DefaultMaxRetryDuration   = 5 * time.Minute
+	DefaultMaxConcurrentSyncs = 4
+	retryRequeueInterval      = 10 * time.Second
+)
+
 //+kubebuilder:rbac:groups=packages.glasskube.dev,resources=packagerepositories,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=packages.glasskube.dev,resources=packagerepositories/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=packages.glasskube.dev,resources=packagerepositories/finalizers,verbs=update
@@ -62,10 +86,52 @@ func (r *PackageRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	var index repotypes.PackageRepoIndex
 	var cond metav1.Condition
-	err := r.RepoClient.ForRepo(repo).FetchPackageRepoIndex(&index)
-	if err != nil {
+	repoClient := r.RepoClient.ForRepo(repo)
+	if repo.IsSyncRequested() {
+		log.FromContext(ctx).Info("immediate sync was requested")
+		if invalidator, ok := repoClient.(repoclient.CacheInvalidator); ok {
+			invalidator.InvalidateCache()
+		}
+	}
+	syncStart := time.Now()
+	result := r.syncs.Run(ctx, repo.Name, func() (result reposync.Result) {
+		if syncer, ok := repoClient.(repoclient.GitSyncer); ok {
+			result.Commit, result.Err = syncer.Sync()
+		}
+		if result.Err == nil {
+			result.Err = repoClient.FetchPackageRepoIndex(&result.Index)
+		}
+		return
+	})
+	index, commit, err := result.Index, result.Commit, result.Err
+	repo.Status.LastSyncDuration = &metav1.Duration{Duration: time.Since(syncStart).Round(time.Millisecond)}
+	if err != nil && r.isRetrying(repo, err) {
+		log.FromContext(ctx).Info("repository sync failed temporarily", "error", err)
+		cond = metav1.Condition{
+			Type:    string(condition.Ready),
+			Status:  metav1.ConditionUnknown,
+			Reason:  string(condition.SyncRetrying),
+			Message: fmt.Sprintf("sync failed temporarily and is being retried: %v", err),
+		}
+		meta.SetStatusCondition(&repo.Status.Conditions, cond)
+		repo.Status.LastSyncTime = ptr.To(metav1.Now())
+		return requeue.After(ctx, r.Status().Update(ctx, &repo), retryRequeueInterval)
+	} else if err != nil && httperror.IsTLSError(err) {
+		cond = metav1.Condition{
+			Type:    string(condition.Ready),
+			Status:  metav1.ConditionFalse,
+			Reason:  string(condition.TLSVerificationFailed),
+			Message: fmt.Sprintf("%v (check the CA certificates in spec.tls of the repository)", err),
+		}
+	} else if err != nil && httperror.IsProxyError(err) {
+		cond = metav1.Condition{
+			Type:    string(condition.Ready),
+			Status:  metav1.ConditionFalse,
+			Reason:  string(condition.ProxyConnectionFailed),
+			Message: fmt.Sprintf("%v (check spec.proxy of the repository or the proxy environment of the operator)", err),
+		}
+	} else if err != nil {
 		cond = metav1.Condition{
 			Type:    string(condition.Ready),
 			Status:  metav1.ConditionFalse,
@@ -79,18 +145,70 @@ func (r *PackageRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.Re
 			Reason:  string(condition.SyncCompleted),
 			Message: fmt.Sprintf("repo has %v packages", len(index.Packages)),
 		}
+		if commit != "" {
+			cond.Message += fmt.Sprintf(" at commit %v", shortCommit(commit))
+			if repo.Status.Commit != commit {
+				log.FromContext(ctx).Info("repository synced new commit", "previous", repo.Status.Commit, "commit", commit)
+				repo.Status.Commit = commit
+			}
+		}
+		if err := r.notifyUpdatesAvailable(ctx, repo, index); err != nil {
+			log.FromContext(ctx).Error(err, "failed to send update notification")
+		}
 	}
 
-	if meta.SetStatusCondition(&repo.Status.Conditions, cond) {
-		multierr.AppendInto(&err, r.Status().Update(ctx, &repo))
+	// the status is always updated, so that the time of the last sync is visible to users
+	meta.SetStatusCondition(&repo.Status.Conditions, cond)
+	repo.Status.LastSyncTime = ptr.To(metav1.Now())
+	multierr.AppendInto(&err, r.Status().Update(ctx, &repo))
+
+	return requeue.After(ctx, err, repo.GetSyncInterval())
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
 	}
+	return commit
+}
 
-	return requeue.Always(ctx, err)
+// isRetrying returns true if a sync error should be reported as a sync that is still in progress, rather than a
+// permanent failure. This is the case for transient errors, until the repository has been retrying for longer than
+// MaxRetryDuration. A repository that has already been marked as failed stays failed until the next successful sync.
+func (r *PackageRepositoryReconciler) isRetrying(repo packagesv1alpha1.PackageRepository, err error) bool {
+	if !httperror.IsTransient(err) {
+		return false
+	}
+	maxRetryDuration := r.MaxRetryDuration
+	if maxRetryDuration == 0 {
+		maxRetryDuration = DefaultMaxRetryDuration
+	}
+	cond := meta.FindStatusCondition(repo.Status.Conditions, string(condition.Ready))
+	switch {
+	case cond == nil || cond.Status == metav1.ConditionTrue:
+		return true
+	case cond.Reason == string(condition.SyncRetrying):
+		return time.Since(cond.LastTransitionTime.Time) < maxRetryDuration
+	default:
+		return false
+	}
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *PackageRepositoryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// The runner is shared by all workers, so it must not be changed once they have started.
+	r.syncs.Timeout = r.SyncTimeout
+	// Status updates are ignored, because every sync updates the status. Syncs that are requested by users are
+	// triggered by changing an annotation.
+	// Every repository is synced by one worker at a time, so a failing or slow repository never affects the others.
+	maxConcurrentSyncs := r.MaxConcurrentSyncs
+	if maxConcurrentSyncs == 0 {
+		maxConcurrentSyncs = DefaultMaxConcurrentSyncs
+	}
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&packagesv1alpha1.PackageRepository{}).
+		For(&packagesv1alpha1.PackageRepository{}, builder.WithPredicates(
+			predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}),
+		)).
+		WithOptions(ctrlcontroller.Options{MaxConcurrentReconciles: maxConcurrentSyncs}).
 		Complete(r)
 }
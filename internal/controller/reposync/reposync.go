@@ -0,0 +1,79 @@
+package reposync
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	repotypes "github.com/glasskube/glasskube/internal/repo/types"
+)
+
+// DefaultTimeout is the time after which a sync is reported as failed, if Runner.Timeout is zero
+const DefaultTimeout = 2 * time.Minute
+
+// Result is the outcome of a sync of a repository
+type Result struct {
+	Index repotypes.PackageRepoIndex
+	// Commit is the synced commit of a git repository
+	Commit string
+	Err    error
+}
+
+// Runner runs the syncs of repositories in the background, so that a slow or hanging repository times out instead of
+// blocking the worker that reconciles it. The requests of repository clients can not be cancelled, so a sync that
+// timed out keeps running. Until it finishes, no other sync of the same repository is started. Instead, later syncs
+// wait for the running one.
+type Runner struct {
+	Timeout time.Duration
+	mutex   sync.Mutex
+	running map[string]*run
+}
+
+type run struct {
+	done   chan struct{}
+	result Result
+}
+
+// Run executes fn for the repository with the given name, unless a sync of this repository is already running. It
+// returns the result of the sync, or an error that wraps context.DeadlineExceeded if it does not finish in time.
+func (r *Runner) Run(ctx context.Context, name string, fn func() Result) Result {
+	current := r.start(name, fn)
+	timeout := r.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-current.done:
+		return current.result
+	case <-timer.C:
+		return Result{Err: fmt.Errorf("sync did not finish within %v: %w", timeout, context.DeadlineExceeded)}
+	case <-ctx.Done():
+		return Result{Err: ctx.Err()}
+	}
+}
+
+func (r *Runner) start(name string, fn func() Result) *run {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if current, ok := r.running[name]; ok {
+		return current
+	}
+	if r.running == nil {
+		r.running = make(map[string]*run)
+	}
+	current := &run{done: make(chan struct{})}
+	r.running[name] = current
+	go func() {
+		defer func() {
+			r.mutex.Lock()
+			delete(r.running, name)
+			r.mutex.Unlock()
+			close(current.done)
+		}()
+		current.result = fn()
+	}()
+	return current
+}
@@ -0,0 +1,13 @@
+package reposync
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestReposync(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Reposync Suite")
+}
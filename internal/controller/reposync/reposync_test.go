@@ -0,0 +1,64 @@
+package reposync
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/glasskube/glasskube/internal/httperror"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Runner", func() {
+	It("should return the result of the sync", func(ctx context.Context) {
+		runner := Runner{}
+		result := runner.Run(ctx, "a", func() Result { return Result{Commit: "abc"} })
+		Expect(result.Err).NotTo(HaveOccurred())
+		Expect(result.Commit).To(Equal("abc"))
+	})
+
+	It("should time out without waiting for a hanging sync", func(ctx context.Context) {
+		runner := Runner{Timeout: 10 * time.Millisecond}
+		release := make(chan struct{})
+		defer close(release)
+		result := runner.Run(ctx, "a", func() Result {
+			<-release
+			return Result{}
+		})
+		Expect(result.Err).To(MatchError(context.DeadlineExceeded))
+		Expect(httperror.IsTransient(result.Err)).To(BeTrue())
+	})
+
+	It("should not start a second sync of a repository while one is running", func(ctx context.Context) {
+		runner := Runner{Timeout: 10 * time.Millisecond}
+		release := make(chan struct{})
+		var calls atomic.Int32
+		fn := func() Result {
+			calls.Add(1)
+			<-release
+			return Result{Commit: "abc"}
+		}
+		Expect(runner.Run(ctx, "a", fn).Err).To(HaveOccurred())
+		runner.Timeout = time.Second
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			close(release)
+		}()
+		Expect(runner.Run(ctx, "a", fn).Commit).To(Equal("abc"))
+		Expect(calls.Load()).To(Equal(int32(1)))
+	})
+
+	It("should not block other repositories", func(ctx context.Context) {
+		runner := Runner{Timeout: time.Second}
+		release := make(chan struct{})
+		defer close(release)
+		go runner.Run(ctx, "slow", func() Result {
+			<-release
+			return Result{}
+		})
+		result := runner.Run(ctx, "fast", func() Result { return Result{Err: errors.New("failed")} })
+		Expect(result.Err).To(MatchError("failed"))
+	})
+})
@@ -23,6 +23,17 @@ func Always(ctx context.Context, err error) (ctrl.Result, error) {
 	return requeueAfter(RequeueDuration), nil
 }
 
+// After requeues after the given duration, unless an error occurred
+func After(ctx context.Context, err error, duration time.Duration) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	if err != nil {
+		log.Error(err, "error during reconciliation")
+		return ctrl.Result{}, err
+	}
+	log.V(1).Info("reconciliation finished")
+	return requeueAfter(duration), nil
+}
+
 func OnError(ctx context.Context, err error) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 	if err != nil {
@@ -0,0 +1,75 @@
+package revisions
+
+import (
+	"time"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultLimit is the number of revisions kept in the status of a package if no other limit is configured.
+const DefaultLimit = 5
+
+// Record adds a new revision with the version and values from spec to the revision history of status, unless they
+// are equal to the most recent revision. Afterwards, only the newest limit revisions are kept. If limit is not
+// positive, DefaultLimit is used.
+// The return value indicates whether status was changed.
+func Record(status *v1alpha1.PackageStatus, spec *v1alpha1.PackageSpec, limit int, now time.Time) bool {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	changed := false
+	current := Current(status)
+	if current == nil ||
+		current.Version != spec.PackageInfo.Version ||
+		!equality.Semantic.DeepEqual(current.Values, spec.Values) {
+		revision := v1alpha1.PackageRevision{
+			Revision:    1,
+			Version:     spec.PackageInfo.Version,
+			InstalledAt: metav1.NewTime(now),
+		}
+		if current != nil {
+			revision.Revision = current.Revision + 1
+		}
+		if spec.Values != nil {
+			revision.Values = make(map[string]v1alpha1.ValueConfiguration, len(spec.Values))
+			for name, value := range spec.Values {
+				revision.Values[name] = *value.DeepCopy()
+			}
+		}
+		status.Revisions = append(status.Revisions, revision)
+		changed = true
+	}
+	if len(status.Revisions) > limit {
+		status.Revisions = status.Revisions[len(status.Revisions)-limit:]
+		changed = true
+	}
+	return changed
+}
+
+// Current returns the most recent revision or nil if status has no revisions.
+func Current(status *v1alpha1.PackageStatus) *v1alpha1.PackageRevision {
+	if len(status.Revisions) == 0 {
+		return nil
+	}
+	return &status.Revisions[len(status.Revisions)-1]
+}
+
+// Previous returns the revision that was installed before the most recent one or nil if there is none.
+func Previous(status *v1alpha1.PackageStatus) *v1alpha1.PackageRevision {
+	if len(status.Revisions) < 2 {
+		return nil
+	}
+	return &status.Revisions[len(status.Revisions)-2]
+}
+
+// Find returns the revision with the given number or nil if it is not (or no longer) part of the history.
+func Find(status *v1alpha1.PackageStatus, revision int64) *v1alpha1.PackageRevision {
+	for i := range status.Revisions {
+		if status.Revisions[i].Revision == revision {
+			return &status.Revisions[i]
+		}
+	}
+	return nil
+}
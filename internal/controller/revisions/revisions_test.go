@@ -0,0 +1,81 @@
+package revisions
+
+import (
+	"time"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func spec(version string, values map[string]string) *v1alpha1.PackageSpec {
+	s := v1alpha1.PackageSpec{PackageInfo: v1alpha1.PackageInfoTemplate{Version: version}}
+	if values != nil {
+		s.Values = make(map[string]v1alpha1.ValueConfiguration, len(values))
+		for name, value := range values {
+			s.Values[name] = v1alpha1.ValueConfiguration{
+				InlineValueConfiguration: v1alpha1.InlineValueConfiguration{Value: &value},
+			}
+		}
+	}
+	return &s
+}
+
+var _ = Describe("Record", func() {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	It("should add the first revision", func() {
+		var status v1alpha1.PackageStatus
+		Expect(Record(&status, spec("v1", nil), 0, now)).To(BeTrue())
+		Expect(status.Revisions).To(HaveLen(1))
+		Expect(status.Revisions[0].Revision).To(Equal(int64(1)))
+		Expect(status.Revisions[0].Version).To(Equal("v1"))
+		Expect(status.Revisions[0].InstalledAt.Time).To(Equal(now))
+		Expect(Previous(&status)).To(BeNil())
+	})
+
+	It("should not add a revision if nothing changed", func() {
+		var status v1alpha1.PackageStatus
+		Record(&status, spec("v1", map[string]string{"a": "1"}), 0, now)
+		Expect(Record(&status, spec("v1", map[string]string{"a": "1"}), 0, now.Add(time.Hour))).To(BeFalse())
+		Expect(status.Revisions).To(HaveLen(1))
+		Expect(status.Revisions[0].InstalledAt.Time).To(Equal(now))
+	})
+
+	It("should treat nil and empty values as equal", func() {
+		var status v1alpha1.PackageStatus
+		Record(&status, spec("v1", nil), 0, now)
+		Expect(Record(&status, spec("v1", map[string]string{}), 0, now)).To(BeFalse())
+	})
+
+	It("should add a revision if the version or values changed", func() {
+		var status v1alpha1.PackageStatus
+		Record(&status, spec("v1", nil), 0, now)
+		Expect(Record(&status, spec("v2", nil), 0, now)).To(BeTrue())
+		Expect(Record(&status, spec("v2", map[string]string{"a": "1"}), 0, now)).To(BeTrue())
+		Expect(status.Revisions).To(HaveLen(3))
+		Expect(Current(&status).Revision).To(Equal(int64(3)))
+		Expect(Previous(&status).Version).To(Equal("v2"))
+		Expect(Previous(&status).Values).To(BeEmpty())
+	})
+
+	It("should not share values with the spec", func() {
+		var status v1alpha1.PackageStatus
+		s := spec("v1", map[string]string{"a": "1"})
+		Record(&status, s, 0, now)
+		*s.Values["a"].Value = "2"
+		Expect(*Current(&status).Values["a"].Value).To(Equal("1"))
+	})
+
+	It("should keep only the newest revisions", func() {
+		var status v1alpha1.PackageStatus
+		for _, version := range []string{"v1", "v2", "v3", "v4"} {
+			Record(&status, spec(version, nil), 2, now)
+		}
+		Expect(status.Revisions).To(HaveLen(2))
+		Expect(status.Revisions[0].Revision).To(Equal(int64(3)))
+		Expect(status.Revisions[1].Revision).To(Equal(int64(4)))
+		Expect(Find(&status, 3)).NotTo(BeNil())
+		Expect(Find(&status, 1)).To(BeNil())
+	})
+})
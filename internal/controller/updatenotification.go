@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	packagesv1alpha1 "github.com/glasskube/glasskube/api/v1alpha1"
+	ctrladapter "github.com/glasskube/glasskube/internal/adapter/controllerruntime"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/notification"
+	repotypes "github.com/glasskube/glasskube/internal/repo/types"
+	"github.com/glasskube/glasskube/internal/semver"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// notifyUpdatesAvailable sends a notification for every installed package from the given repository that has an
+// update available. The notified version is stored in an annotation on the package, so that subsequent syncs of the
+// same repository do not send duplicate notifications for the same version.
+func (r *PackageRepositoryReconciler) notifyUpdatesAvailable(
+	ctx context.Context,
+	repo packagesv1alpha1.PackageRepository,
+	index repotypes.PackageRepoIndex,
+) error {
+	if r.Notifier == nil {
+		return nil
+	}
+	config, err := notification.LoadConfig(ctx, ctrladapter.NewKubernetesClientAdapter(r.Client))
+	if err != nil {
+		return fmt.Errorf("failed to load notification config: %w", err)
+	} else if !config.Enabled() {
+		return nil
+	}
+
+	pkgs, err := r.listPackagesOfRepo(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	latestVersions := make(map[string]string, len(index.Packages))
+	for _, item := range index.Packages {
+		latestVersions[item.Name] = item.LatestVersion
+	}
+
+	var updates []notification.UpdateAvailable
+	var notifiedPkgs []ctrlpkg.Package
+	for _, pkg := range pkgs {
+		packageName := pkg.GetSpec().PackageInfo.Name
+		installedVersion := pkg.GetSpec().PackageInfo.Version
+		latestVersion, ok := latestVersions[packageName]
+		if !ok || !config.Watches(packageName) || !pkg.GetDeletionTimestamp().IsZero() ||
+			pkg.UpdateNotifiedVersion() == latestVersion || !semver.IsUpgradable(installedVersion, latestVersion) {
+			continue
+		}
+		updates = append(updates, notification.UpdateAvailable{
+			Name:             pkg.GetName(),
+			Namespace:        pkg.GetNamespace(),
+			PackageName:      packageName,
+			Repository:       repo.Name,
+			InstalledVersion: installedVersion,
+			LatestVersion:    latestVersion,
+		})
+		notifiedPkgs = append(notifiedPkgs, pkg)
+	}
+
+	if len(updates) == 0 {
+		return nil
+	} else if err := r.Notifier.NotifyUpdatesAvailable(ctx, config, updates); err != nil {
+		return err
+	}
+	log.FromContext(ctx).Info("sent update notification", "packages", len(updates))
+
+	for i, pkg := range notifiedPkgs {
+		patch := client.MergeFrom(pkg.DeepCopyObject().(client.Object))
+		pkg.SetUpdateNotifiedVersion(updates[i].LatestVersion)
+		if err := r.Patch(ctx, pkg, patch); err != nil {
+			return fmt.Errorf("failed to update annotations of %v: %w", pkg.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// listPackagesOfRepo returns all Packages and ClusterPackages that are installed from the given repository
+func (r *PackageRepositoryReconciler) listPackagesOfRepo(
+	ctx context.Context,
+	repo packagesv1alpha1.PackageRepository,
+) ([]ctrlpkg.Package, error) {
+	var clusterPackages packagesv1alpha1.ClusterPackageList
+	if err := r.List(ctx, &clusterPackages); err != nil {
+		return nil, fmt.Errorf("failed to list clusterpackages: %w", err)
+	}
+	var packages packagesv1alpha1.PackageList
+	if err := r.List(ctx, &packages); err != nil {
+		return nil, fmt.Errorf("failed to list packages: %w", err)
+	}
+
+	isFromRepo := func(pkg ctrlpkg.Package) bool {
+		repoName := pkg.GetSpec().PackageInfo.RepositoryName
+		return repoName == repo.Name || (repoName == "" && repo.IsDefaultRepository())
+	}
+	var result []ctrlpkg.Package
+	for i := range clusterPackages.Items {
+		if isFromRepo(&clusterPackages.Items[i]) {
+			result = append(result, &clusterPackages.Items[i])
+		}
+	}
+	for i := range packages.Items {
+		if isFromRepo(&packages.Items[i]) {
+			result = append(result, &packages.Items[i])
+		}
+	}
+	return result, nil
+}
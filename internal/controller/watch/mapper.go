@@ -3,12 +3,15 @@ package watch
 import (
 	"github.com/glasskube/glasskube/api/v1alpha1"
 	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type ownedMapperFunc func(pkg ctrlpkg.Package) []v1alpha1.OwnedResourceRef
 
 var _ ownedMapperFunc = OwnedPackageInfos
 var _ ownedMapperFunc = OwnedPackages
+var _ ownedMapperFunc = HookJobs
 
 func OwnedPackageInfos(pkg ctrlpkg.Package) []v1alpha1.OwnedResourceRef {
 	return pkg.GetStatus().OwnedPackageInfos
@@ -17,3 +20,21 @@ func OwnedPackageInfos(pkg ctrlpkg.Package) []v1alpha1.OwnedResourceRef {
 func OwnedPackages(pkg ctrlpkg.Package) []v1alpha1.OwnedResourceRef {
 	return pkg.GetStatus().OwnedPackages
 }
+
+// HookJobs returns references to the Jobs of all hooks that have been run for pkg
+func HookJobs(pkg ctrlpkg.Package) []v1alpha1.OwnedResourceRef {
+	hooks := pkg.GetStatus().Hooks
+	refs := make([]v1alpha1.OwnedResourceRef, len(hooks))
+	for i, hook := range hooks {
+		refs[i] = v1alpha1.OwnedResourceRef{
+			GroupVersionKind: metav1.GroupVersionKind{
+				Group:   batchv1.SchemeGroupVersion.Group,
+				Version: batchv1.SchemeGroupVersion.Version,
+				Kind:    "Job",
+			},
+			Namespace: hook.JobNamespace,
+			Name:      hook.JobName,
+		}
+	}
+	return refs
+}
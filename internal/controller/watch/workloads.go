@@ -0,0 +1,48 @@
+package watch
+
+import (
+	"context"
+
+	"github.com/glasskube/glasskube/internal/workloads"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// EnqueueRequestsForWorkload enqueues all packages that a workload belongs to (see workloads.BelongsTo)
+func EnqueueRequestsForWorkload(targetLister PackageLister) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		kind := workloads.Kind(obj)
+		if kind == "" {
+			return nil
+		}
+		if pkgs, err := targetLister.ListPackages(ctx); err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "could not list packages event handler")
+			return nil
+		} else {
+			var res []reconcile.Request
+			for _, pkg := range pkgs {
+				if workloads.BelongsTo(pkg, kind, obj) {
+					res = append(res, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(pkg)})
+				}
+			}
+			return res
+		}
+	})
+}
+
+// WorkloadHealthChanged only accepts updates of a workload that change its health (see workloads.Problem), and
+// deletions. Creations are ignored, because a package is reconciled anyway after it created a workload.
+func WorkloadHealthChanged() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return workloads.Problem(e.ObjectOld) != workloads.Problem(e.ObjectNew)
+		},
+		DeleteFunc:  func(event.DeleteEvent) bool { return true },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
@@ -43,15 +43,14 @@ func (a *defaultRepoAdapter) GetManifest(name string, version string) (*v1alpha1
 
 func (a *defaultRepoAdapter) getRepoForPackage(name string) (*v1alpha1.PackageRepository, error) {
 	repos, err := a.client.Meta().GetReposForPackage(name)
-	switch len(repos) {
-	case 0:
-		return nil, multierr.Append(fmt.Errorf("\"%v\" is not available in any repository", name), err)
-	case 1:
-		return &repos[0], err
-	default:
-		return nil, multierr.Append(err,
-			fmt.Errorf("%v is available from %v repositories (currently unsupported)", name, len(repos)),
-		)
+	if resolution, resolveErr := repoclient.ResolveRepository(name, repos); resolveErr != nil {
+		if err != nil {
+			// err may be partial, but the resolution failed completely
+			return nil, fmt.Errorf("%w (%v)", resolveErr, err)
+		}
+		return nil, resolveErr
+	} else {
+		return &resolution.Repository, err
 	}
 }
 
@@ -1,7 +1,9 @@
 package graph
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
 
 	"k8s.io/client-go/tools/cache"
 
@@ -40,10 +42,11 @@ func (m vertexMap) createVertex(key vertexRef, packageName string) *vertex {
 	return n
 }
 
-func (m *vertexMap) edge(from *vertex, to vertexRef, constraint *semver.Constraints) {
+func (m *vertexMap) edge(from *vertex, to vertexRef, constraint *semver.Constraints, optional bool) {
 	from.edges[to] = &edge{
 		vertex:     m.vertex(to, ""),
 		constraint: constraint,
+		optional:   optional,
 	}
 }
 
@@ -55,7 +58,7 @@ func (oldMap vertexMap) deepCopy() vertexMap {
 		newVertex.manual = vertex.manual
 		for edgeRef, edge := range vertex.edges {
 			newMap.vertex(edgeRef, edge.vertex.packageName)
-			newMap.edge(newVertex, edgeRef, edge.constraint)
+			newMap.edge(newVertex, edgeRef, edge.constraint, edge.optional)
 		}
 	}
 	return newMap
@@ -75,6 +78,8 @@ type vertex struct {
 type edge struct {
 	constraint *semver.Constraints
 	vertex     *vertex
+	// optional is true if the edge is an optional dependency that has been enabled
+	optional bool
 }
 
 func NewGraph() *DependencyGraph {
@@ -144,6 +149,31 @@ func (g *DependencyGraph) Dependants(of, namespace string) []PackageRef {
 	return dependants
 }
 
+// DependantsTransitive returns all installed packages that depend on this package, either directly or via other
+// packages. Direct dependants come first, followed by their dependants and so on.
+func (g *DependencyGraph) DependantsTransitive(of, namespace string) []PackageRef {
+	var result []PackageRef
+	visited := map[vertexRef]struct{}{{name: of, namespace: namespace}: {}}
+	queue := []vertexRef{{name: of, namespace: namespace}}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		dependants := g.Dependants(current.name, current.namespace)
+		slices.SortFunc(dependants, func(a, b PackageRef) int {
+			return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+		})
+		for _, dependant := range dependants {
+			ref := vertexRef{name: dependant.Name, namespace: dependant.Namespace}
+			if _, ok := visited[ref]; !ok {
+				visited[ref] = struct{}{}
+				queue = append(queue, ref)
+				result = append(result, dependant)
+			}
+		}
+	}
+	return result
+}
+
 // Constraints returns all constraints of dependants of this package
 func (g *DependencyGraph) Constraints(of, namespace string) []*semver.Constraints {
 	var constraints []*semver.Constraints
@@ -221,6 +251,19 @@ func (g *DependencyGraph) DeleteAndPrune(name, namespace string) []PackageRef {
 	return nil
 }
 
+// DeleteCascading simulates uninstalling a package together with all packages that depend on it. The dependants are
+// returned in the order in which they should be uninstalled, so that no package is uninstalled before its dependants.
+// Dependencies that are no longer needed afterwards are not removed, use Prune for that.
+func (g *DependencyGraph) DeleteCascading(name, namespace string) []PackageRef {
+	dependants := g.DependantsTransitive(name, namespace)
+	slices.Reverse(dependants)
+	for _, dependant := range dependants {
+		g.Delete(dependant.Name, dependant.Namespace)
+	}
+	g.Delete(name, namespace)
+	return dependants
+}
+
 func (g *DependencyGraph) ValidateDelete(name, namespace string) ([]PackageRef, error) {
 	gc := g.DeepCopy()
 	return gc.DeleteAndPrune(name, namespace), gc.Validate()
@@ -282,7 +325,7 @@ func (g *DependencyGraph) add(
 				constraint = c
 			}
 		}
-		g.vertices.edge(vertex, depRef, constraint)
+		g.vertices.edge(vertex, depRef, constraint, dep.Optional)
 	}
 
 	for _, cmp := range manifest.Components {
@@ -303,7 +346,7 @@ func (g *DependencyGraph) add(
 				constraint = c
 			}
 		}
-		g.vertices.edge(vertex, cmpRef, constraint)
+		g.vertices.edge(vertex, cmpRef, constraint, false)
 	}
 
 	return nil
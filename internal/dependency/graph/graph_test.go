@@ -226,6 +226,41 @@ var _ = Describe("DependencyGraph", func() {
 		})
 	})
 
+	Describe("DependantsTransitive", func() {
+		It("should return direct dependants first", func() {
+			fooManifest := v1alpha1.PackageManifest{Name: foo, Dependencies: []v1alpha1.Dependency{{Name: bar}}}
+			barManifest := v1alpha1.PackageManifest{Name: bar, Dependencies: []v1alpha1.Dependency{{Name: baz}}}
+			Expect(graph.AddCluster(fooManifest, "v1.0.0", true)).NotTo(HaveOccurred())
+			Expect(graph.AddCluster(barManifest, "v1.0.0", true)).NotTo(HaveOccurred())
+			Expect(graph.AddCluster(v1alpha1.PackageManifest{Name: baz}, "v1.0.0", true)).NotTo(HaveOccurred())
+			Expect(graph.DependantsTransitive(baz, "")).To(Equal([]PackageRef{{bar, "", bar}, {foo, "", foo}}))
+			Expect(graph.DependantsTransitive(foo, "")).To(BeEmpty())
+		})
+
+		It("should terminate for cycles", func() {
+			fooManifest := v1alpha1.PackageManifest{Name: foo, Dependencies: []v1alpha1.Dependency{{Name: bar}}}
+			barManifest := v1alpha1.PackageManifest{Name: bar, Dependencies: []v1alpha1.Dependency{{Name: foo}}}
+			Expect(graph.AddCluster(fooManifest, "v1.0.0", true)).NotTo(HaveOccurred())
+			Expect(graph.AddCluster(barManifest, "v1.0.0", true)).NotTo(HaveOccurred())
+			Expect(graph.DependantsTransitive(foo, "")).To(Equal([]PackageRef{{bar, "", bar}}))
+		})
+	})
+
+	Describe("DeleteCascading", func() {
+		It("should delete all dependants, most dependent first", func() {
+			fooManifest := v1alpha1.PackageManifest{Name: foo, Dependencies: []v1alpha1.Dependency{{Name: bar}}}
+			barManifest := v1alpha1.PackageManifest{Name: bar, Dependencies: []v1alpha1.Dependency{{Name: baz}}}
+			Expect(graph.AddCluster(fooManifest, "v1.0.0", true)).NotTo(HaveOccurred())
+			Expect(graph.AddCluster(barManifest, "v1.0.0", true)).NotTo(HaveOccurred())
+			Expect(graph.AddCluster(v1alpha1.PackageManifest{Name: baz}, "v1.0.0", true)).NotTo(HaveOccurred())
+			Expect(graph.DeleteCascading(baz, "")).To(Equal([]PackageRef{{foo, "", foo}, {bar, "", bar}}))
+			Expect(graph.Version(foo, "")).To(BeNil())
+			Expect(graph.Version(bar, "")).To(BeNil())
+			Expect(graph.Version(baz, "")).To(BeNil())
+			Expect(graph.Validate()).NotTo(HaveOccurred())
+		})
+	})
+
 	Describe("Constraints", func() {
 		It("should return constraints of dependants", func() {
 			fooManifest1 := v1alpha1.PackageManifest{Name: foo, Dependencies: []v1alpha1.Dependency{{Name: bar, Version: "1.2.x"}}}
@@ -252,6 +287,97 @@ var _ = Describe("DependencyGraph", func() {
 		})
 	})
 
+	Describe("Tree", func() {
+		It("should return transitive dependencies", func() {
+			fooManifest := v1alpha1.PackageManifest{Name: foo, Dependencies: []v1alpha1.Dependency{{Name: bar, Version: "1.x.x"}}}
+			barManifest := v1alpha1.PackageManifest{Name: bar, Dependencies: []v1alpha1.Dependency{{Name: baz}}}
+			Expect(graph.AddCluster(fooManifest, "v1.0.0", true)).NotTo(HaveOccurred())
+			Expect(graph.AddCluster(barManifest, "v1.0.0", false)).NotTo(HaveOccurred())
+			tree := graph.Tree(foo, "")
+			Expect(tree.Version).To(Equal("v1.0.0"))
+			Expect(tree.Dependencies).To(HaveLen(1))
+			Expect(tree.Dependencies[0].Name).To(Equal(bar))
+			Expect(tree.Dependencies[0].Constraint).To(Equal("1.x.x"))
+			Expect(tree.Dependencies[0].Dependencies).To(HaveLen(1))
+			Expect(tree.Dependencies[0].Dependencies[0].Missing).To(BeTrue())
+			Expect(tree.HasProblems()).To(BeTrue())
+		})
+
+		It("should flag violated constraints", func() {
+			fooManifest := v1alpha1.PackageManifest{Name: foo, Dependencies: []v1alpha1.Dependency{{Name: bar, Version: "1.1.x"}}}
+			Expect(graph.AddCluster(fooManifest, "v1.0.0", true)).NotTo(HaveOccurred())
+			Expect(graph.AddCluster(v1alpha1.PackageManifest{Name: bar}, "v1.0.0", false)).NotTo(HaveOccurred())
+			tree := graph.Tree(foo, "")
+			Expect(tree.Dependencies[0].ConstraintViolated).To(BeTrue())
+		})
+
+		It("should not expand cycles", func() {
+			fooManifest := v1alpha1.PackageManifest{Name: foo, Dependencies: []v1alpha1.Dependency{{Name: bar}}}
+			barManifest := v1alpha1.PackageManifest{Name: bar, Dependencies: []v1alpha1.Dependency{{Name: foo}}}
+			Expect(graph.AddCluster(fooManifest, "v1.0.0", true)).NotTo(HaveOccurred())
+			Expect(graph.AddCluster(barManifest, "v1.0.0", false)).NotTo(HaveOccurred())
+			tree := graph.Tree(foo, "")
+			Expect(tree.HasProblems()).To(BeFalse())
+			cyclic := tree.Dependencies[0].Dependencies[0]
+			Expect(cyclic.Name).To(Equal(foo))
+			Expect(cyclic.Cyclic).To(BeTrue())
+			Expect(cyclic.Dependencies).To(BeEmpty())
+		})
+
+		It("should mark missing package", func() {
+			Expect(graph.Tree(foo, "").Missing).To(BeTrue())
+		})
+
+		It("should mark optional dependencies", func() {
+			fooManifest := v1alpha1.PackageManifest{Name: foo, Dependencies: []v1alpha1.Dependency{
+				{Name: bar}, {Name: baz, Optional: true},
+			}}
+			Expect(graph.AddCluster(fooManifest, "v1.0.0", true)).NotTo(HaveOccurred())
+			tree := graph.Tree(foo, "")
+			Expect(tree.Dependencies).To(HaveLen(2))
+			Expect(tree.Dependencies[0].Name).To(Equal(bar))
+			Expect(tree.Dependencies[0].Optional).To(BeFalse())
+			Expect(tree.Dependencies[1].Name).To(Equal(baz))
+			Expect(tree.Dependencies[1].Optional).To(BeTrue())
+		})
+	})
+
+	Describe("Why", func() {
+		It("should return all chains from manually installed packages", func() {
+			fooManifest := v1alpha1.PackageManifest{Name: foo, DefaultNamespace: defaultNs,
+				Dependencies: []v1alpha1.Dependency{{Name: baz}},
+				Components:   []v1alpha1.Component{{Name: "qux"}}}
+			barManifest := v1alpha1.PackageManifest{Name: bar, Dependencies: []v1alpha1.Dependency{{Name: foo}}}
+			Expect(graph.AddCluster(fooManifest, "v1.0.0", false)).To(Succeed())
+			Expect(graph.AddCluster(barManifest, "v1.0.0", true)).To(Succeed())
+			Expect(graph.AddCluster(v1alpha1.PackageManifest{Name: baz}, "v1.0.0", true)).To(Succeed())
+			Expect(graph.AddNamespaced("foo-qux", defaultNs, v1alpha1.PackageManifest{Name: "qux"}, "v1.0.0", false)).
+				To(Succeed())
+
+			Expect(graph.Why("foo-qux", defaultNs)).To(Equal([][]PackageRef{{
+				{Name: bar, PackageName: bar},
+				{Name: foo, PackageName: foo},
+				{Name: "foo-qux", Namespace: defaultNs, PackageName: "qux"},
+			}}))
+			Expect(graph.Why(baz, "")).To(ConsistOf(
+				[]PackageRef{{Name: bar, PackageName: bar}, {Name: foo, PackageName: foo}, {Name: baz, PackageName: baz}},
+				[]PackageRef{{Name: baz, PackageName: baz}},
+			))
+		})
+
+		It("should terminate for cycles", func() {
+			Expect(graph.AddCluster(v1alpha1.PackageManifest{Name: foo, Dependencies: []v1alpha1.Dependency{{Name: bar}}},
+				"v1.0.0", false)).To(Succeed())
+			Expect(graph.AddCluster(v1alpha1.PackageManifest{Name: bar, Dependencies: []v1alpha1.Dependency{{Name: foo}}},
+				"v1.0.0", false)).To(Succeed())
+			Expect(graph.Why(foo, "")).To(BeEmpty())
+		})
+
+		It("should return nil for missing package", func() {
+			Expect(graph.Why(foo, "")).To(BeNil())
+		})
+	})
+
 	Describe("Version", func() {
 		It("should return nil for missing package", func() {
 			Expect(graph.Version("foo", "")).To(BeNil())
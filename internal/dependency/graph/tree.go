@@ -0,0 +1,85 @@
+package graph
+
+import (
+	"slices"
+	"strings"
+
+	isemver "github.com/glasskube/glasskube/internal/semver"
+)
+
+// TreeNode represents a package and its (transitive) dependencies, as they are present in the graph.
+type TreeNode struct {
+	PackageRef
+	// Version is the version of this package in the graph. It is empty if the package is missing.
+	Version string
+	// Constraint is the version constraint of the dependant on this package (if any)
+	Constraint string
+	// Optional is true if this package is an optional dependency of its dependant, that has been enabled
+	Optional bool
+	// Missing is true if this package is neither installed nor can be installed
+	Missing bool
+	// ConstraintViolated is true if Version does not satisfy Constraint
+	ConstraintViolated bool
+	// Cyclic is true if this package is also an ancestor of this node. Dependencies of a cyclic node are omitted.
+	Cyclic       bool
+	Dependencies []TreeNode
+}
+
+// HasProblems returns true if this node or any of its descendants is missing or has a violated constraint
+func (node TreeNode) HasProblems() bool {
+	if node.Missing || node.ConstraintViolated {
+		return true
+	}
+	for _, dep := range node.Dependencies {
+		if dep.HasProblems() {
+			return true
+		}
+	}
+	return false
+}
+
+// Tree returns the dependency tree of the package with the given name and namespace. Dependencies of every node are
+// sorted by name. Cycles are detected and the repeated package is marked as Cyclic, instead of being expanded again.
+func (g *DependencyGraph) Tree(name, namespace string) TreeNode {
+	ref := vertexRef{name: name, namespace: namespace}
+	root := TreeNode{PackageRef: PackageRef{Name: name, Namespace: namespace}}
+	if v, ok := g.vertices[ref]; ok {
+		root.PackageName = v.packageName
+		g.fillTreeNode(&root, v, []vertexRef{ref})
+	} else {
+		root.Missing = true
+	}
+	return root
+}
+
+func (g *DependencyGraph) fillTreeNode(node *TreeNode, v *vertex, path []vertexRef) {
+	if v.version == nil {
+		node.Missing = true
+		return
+	}
+	node.Version = v.version.Original()
+	for ref, e := range v.edges {
+		child := TreeNode{
+			PackageRef: PackageRef{Name: ref.name, Namespace: ref.namespace, PackageName: e.vertex.packageName},
+			Optional:   e.optional,
+		}
+		if e.constraint != nil {
+			child.Constraint = e.constraint.String()
+			if e.vertex.version != nil {
+				child.ConstraintViolated = isemver.ValidateVersionConstraint(e.vertex.version, e.constraint) != nil
+			}
+		}
+		if slices.Contains(path, ref) {
+			child.Cyclic = true
+			if e.vertex.version != nil {
+				child.Version = e.vertex.version.Original()
+			}
+		} else {
+			g.fillTreeNode(&child, e.vertex, append(slices.Clone(path), ref))
+		}
+		node.Dependencies = append(node.Dependencies, child)
+	}
+	slices.SortFunc(node.Dependencies, func(a, b TreeNode) int {
+		return strings.Compare(a.String(), b.String())
+	})
+}
@@ -0,0 +1,45 @@
+package graph
+
+import (
+	"slices"
+	"strings"
+)
+
+// Why returns every chain of dependencies through which the package with the given name and namespace is required.
+// Every chain starts with a package that has been installed manually, or that is not required by any other package,
+// and ends with the given package. Chains are sorted, so that the result is stable.
+// If the given package has been installed manually, the result also contains a chain with only this package. If the
+// package is not installed, nil is returned.
+func (g *DependencyGraph) Why(name, namespace string) [][]PackageRef {
+	target := vertexRef{name: name, namespace: namespace}
+	v, ok := g.vertices[target]
+	if !ok || v.version == nil {
+		return nil
+	}
+	var chains [][]PackageRef
+	var walk func(path []PackageRef)
+	walk = func(path []PackageRef) {
+		current := path[len(path)-1]
+		dependants := g.Dependants(current.Name, current.Namespace)
+		if g.Manual(current.Name, current.Namespace) || len(dependants) == 0 {
+			chain := slices.Clone(path)
+			slices.Reverse(chain)
+			chains = append(chains, chain)
+			if len(path) > 1 || len(dependants) == 0 {
+				return
+			}
+		}
+		for _, dependant := range dependants {
+			if !slices.ContainsFunc(path, func(ref PackageRef) bool {
+				return ref.Name == dependant.Name && ref.Namespace == dependant.Namespace
+			}) {
+				walk(append(slices.Clone(path), dependant))
+			}
+		}
+	}
+	walk([]PackageRef{{Name: name, Namespace: namespace, PackageName: v.packageName}})
+	slices.SortFunc(chains, func(a, b []PackageRef) int {
+		return slices.CompareFunc(a, b, func(x, y PackageRef) int { return strings.Compare(x.String(), y.String()) })
+	})
+	return chains
+}
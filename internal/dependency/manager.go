@@ -13,10 +13,13 @@ import (
 
 	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
 	"github.com/glasskube/glasskube/internal/names"
+	"github.com/glasskube/glasskube/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/multierr"
 
 	"github.com/glasskube/glasskube/internal/adapter"
 	"github.com/glasskube/glasskube/internal/dependency/graph"
+	deputil "github.com/glasskube/glasskube/internal/dependency/util"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/glasskube/glasskube/api/v1alpha1"
@@ -35,16 +38,56 @@ func NewDependencyManager(pkgClient adapter.PackageClientAdapter, repoClient rep
 	}
 }
 
+// Validate checks whether the package can be installed or updated to the given version. All dependencies contained in
+// the manifest are considered, so optional dependencies that are not enabled must be removed beforehand (see
+// util.WithEnabledDependencies).
 func (dm *DependendcyManager) Validate(
 	ctx context.Context,
 	name, namespace string,
 	manifest *v1alpha1.PackageManifest,
 	version string,
+) (*ValidationResult, error) {
+	ctx, span := tracing.Start(ctx, "dependency resolution",
+		attribute.String("glasskube.package.instance", name),
+		attribute.String("glasskube.package.version", version))
+	result, err := dm.validate(ctx, name, namespace, manifest, version)
+	if result != nil {
+		span.SetAttributes(attribute.String("glasskube.dependency.status", string(result.Status)))
+	}
+	tracing.End(span, err)
+	return result, err
+}
+
+func (dm *DependendcyManager) validate(
+	ctx context.Context,
+	name, namespace string,
+	manifest *v1alpha1.PackageManifest,
+	version string,
 ) (*ValidationResult, error) {
 	if manifest == nil {
 		return nil, errors.New("manifest must not be nil")
 	}
+	return dm.validateAll(ctx, []PlannedPackage{{Name: name, Namespace: namespace, Manifest: *manifest, Version: version}})
+}
 
+// ValidateAll validates the installation of multiple packages together, as if they were installed at the same time.
+// Dependencies that are shared by the packages are only required once, and a package can depend on another one of the
+// given packages. Like in Validate, the manifests must not contain optional dependencies that are not enabled.
+//
+// In the returned result, Packages contains the given packages, ordered such that every package comes after the other
+// given packages it depends on.
+func (dm *DependendcyManager) ValidateAll(ctx context.Context, pkgs []PlannedPackage) (*ValidationResult, error) {
+	ctx, span := tracing.Start(ctx, "dependency resolution",
+		attribute.Int("glasskube.package.count", len(pkgs)))
+	result, err := dm.validateAll(ctx, pkgs)
+	if result != nil {
+		span.SetAttributes(attribute.String("glasskube.dependency.status", string(result.Status)))
+	}
+	tracing.End(span, err)
+	return result, err
+}
+
+func (dm *DependendcyManager) validateAll(ctx context.Context, pkgs []PlannedPackage) (*ValidationResult, error) {
 	g, err := dm.NewGraph(ctx)
 	if err != nil {
 		return nil, err
@@ -57,13 +100,25 @@ func (dm *DependendcyManager) Validate(
 	// is currently validated or existed before.
 	errBefore := g.Validate()
 
-	if err := dm.add(g, name, namespace, *manifest, version); err != nil {
-		return nil, err
+	// all packages are added before any dependency, so that packages that are given explicitly are never required as
+	// a dependency of another given package
+	for _, pkg := range pkgs {
+		if err := dm.add(g, pkg.Name, pkg.Namespace, pkg.Manifest, pkg.Version); err != nil {
+			return nil, err
+		}
 	}
 
-	requirements, err := dm.addDependencies(g, name, namespace, false)
-	if err != nil {
-		return nil, err
+	var requirements, installationOrder []Requirement
+	for _, pkg := range pkgs {
+		added, err := dm.addDependencies(g, pkg.Name, pkg.Namespace, false)
+		if err != nil {
+			return nil, err
+		}
+		requirements = append(requirements, added...)
+		// addDependencies returns every package before its dependencies, so the reverse is a valid installation order.
+		// Dependencies of later packages can only depend on dependencies of earlier packages, but not vice versa.
+		slices.Reverse(added)
+		installationOrder = append(installationOrder, added...)
 	}
 	slices.SortFunc(requirements, func(a, b Requirement) int { return strings.Compare(a.Name, b.Name) })
 
@@ -86,12 +141,45 @@ func (dm *DependendcyManager) Validate(
 		status = ValidationResultStatusConflict
 	}
 	return &ValidationResult{
-		Status:       status,
-		Requirements: requirements,
-		Conflicts:    conflicts,
+		Status:            status,
+		Packages:          orderPlanned(g, pkgs),
+		Requirements:      requirements,
+		InstallationOrder: installationOrder,
+		Conflicts:         conflicts,
+		Graph:             g,
 	}, nil
 }
 
+// orderPlanned returns pkgs ordered such that every package comes after the other packages in pkgs it depends on,
+// directly or via dependencies. Otherwise, the original order is kept.
+func orderPlanned(g *graph.DependencyGraph, pkgs []PlannedPackage) []PlannedPackage {
+	result := make([]PlannedPackage, 0, len(pkgs))
+	visited := make(map[graph.PackageRef]bool)
+	planned := make(map[graph.PackageRef]int, len(pkgs))
+	for i, pkg := range pkgs {
+		planned[graph.PackageRef{Name: pkg.Name, Namespace: pkg.Namespace}] = i
+	}
+	var visit func(ref graph.PackageRef)
+	visit = func(ref graph.PackageRef) {
+		if visited[ref] {
+			return
+		}
+		visited[ref] = true
+		deps := g.Dependencies(ref.Name, ref.Namespace)
+		slices.SortFunc(deps, func(a, b graph.PackageRef) int { return strings.Compare(a.String(), b.String()) })
+		for _, dep := range deps {
+			visit(graph.PackageRef{Name: dep.Name, Namespace: dep.Namespace})
+		}
+		if i, ok := planned[ref]; ok {
+			result = append(result, pkgs[i])
+		}
+	}
+	for _, pkg := range pkgs {
+		visit(graph.PackageRef{Name: pkg.Name, Namespace: pkg.Namespace})
+	}
+	return result
+}
+
 // NewGraph constructs a DependencyGraph from all packages returned by clientAdapter.ListPackages
 func (dm *DependendcyManager) NewGraph(ctx context.Context) (*graph.DependencyGraph, error) {
 	var allPkgs []ctrlpkg.Package
@@ -124,7 +212,7 @@ func (dm *DependendcyManager) NewGraph(ctx context.Context) (*graph.DependencyGr
 		} else if mf, err := dm.getManifestForInstalledPkg(ctx, pkg); repoerror.IsComplete(err) {
 			return nil, err
 		} else {
-			manifest = *mf
+			manifest = deputil.WithEnabledDependencies(*mf, pkg.GetSpec().OptionalDependencies)
 		}
 		if pkg.IsNamespaceScoped() {
 			if err := g.AddNamespaced(
@@ -5,6 +5,7 @@ import (
 	"slices"
 
 	"github.com/glasskube/glasskube/api/v1alpha1"
+	deputil "github.com/glasskube/glasskube/internal/dependency/util"
 	"github.com/glasskube/glasskube/internal/names"
 	"github.com/glasskube/glasskube/internal/repo/client/fake"
 	. "github.com/onsi/ginkgo/v2"
@@ -510,6 +511,26 @@ var _ = Describe("Dependency Manager", func() {
 						Expect(res.Requirements[1].Version).Should(Equal("1.1.7"))
 						Expect(res.Conflicts).Should(BeEmpty())
 					})
+
+					When("D depends on E", func() {
+						BeforeEach(func() {
+							fakeRepo.AddPackage("D", "1.1.7", &v1alpha1.PackageManifest{
+								Name:         "D",
+								Dependencies: []v1alpha1.Dependency{{Name: "E"}},
+							})
+						})
+
+						It("Should return E before D in the installation order", func(ctx context.Context) {
+							res, err := dm.Validate(ctx, p.Name, p.Namespace, pi.Status.Manifest, p.Spec.PackageInfo.Version)
+							Expect(err).ShouldNot(HaveOccurred())
+							Expect(res).ShouldNot(BeNil())
+							Expect(res.Status).Should(Equal(ValidationResultStatusResolvable))
+							Expect(res.InstallationOrder).Should(HaveLen(2))
+							Expect(res.InstallationOrder[0].Name).Should(Equal("E"))
+							Expect(res.InstallationOrder[0].Transitive).Should(BeTrue())
+							Expect(res.InstallationOrder[1].Name).Should(Equal("D"))
+						})
+					})
 				})
 
 			})
@@ -644,4 +665,94 @@ var _ = Describe("Dependency Manager", func() {
 			})
 		})
 	})
+
+	Describe("Validation with optional dependencies", func() {
+		BeforeEach(func() {
+			pi.Status.Manifest.Dependencies = []v1alpha1.Dependency{{Name: "D", Optional: true}}
+			fakeRepo.AddPackage("D", "1.1.7", &v1alpha1.PackageManifest{Name: "D"})
+		})
+
+		It("should not require D if it is not enabled", func(ctx context.Context) {
+			manifest := deputil.WithEnabledDependencies(*pi.Status.Manifest, nil)
+			res, err := dm.Validate(ctx, p.Name, p.Namespace, &manifest, p.Spec.PackageInfo.Version)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.Status).To(Equal(ValidationResultStatusOk))
+			Expect(res.Requirements).To(BeEmpty())
+		})
+
+		It("should require D if it is enabled", func(ctx context.Context) {
+			manifest := deputil.WithEnabledDependencies(*pi.Status.Manifest, []string{"D"})
+			res, err := dm.Validate(ctx, p.Name, p.Namespace, &manifest, p.Spec.PackageInfo.Version)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.Status).To(Equal(ValidationResultStatusResolvable))
+			Expect(res.Requirements).To(HaveLen(1))
+			Expect(res.Requirements[0].Name).To(Equal("D"))
+		})
+
+		It("should ignore D for installed packages that did not enable it", func(ctx context.Context) {
+			x, xi = createClusterPackageAndInfo("X", "1.0.0", true)
+			xi.Status.Manifest.Dependencies = []v1alpha1.Dependency{{Name: "D", Optional: true}}
+			g, err := dm.NewGraph(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(g.Dependencies("X", "")).To(BeEmpty())
+			Expect(g.Validate()).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("Validation of multiple packages", func() {
+		planned := func(pkg *v1alpha1.ClusterPackage, info *v1alpha1.PackageInfo) PlannedPackage {
+			return PlannedPackage{Name: pkg.Name, Manifest: *info.Status.Manifest, Version: pkg.Spec.PackageInfo.Version}
+		}
+
+		BeforeEach(func() {
+			x, xi = createClusterPackageAndInfo("X", "1.0.0", false)
+			fakeRepo.AddPackage("D", "1.1.7", &v1alpha1.PackageManifest{Name: "D"})
+		})
+
+		When("P and X depend on D", func() {
+			BeforeEach(func() {
+				pi.Status.Manifest.Dependencies = []v1alpha1.Dependency{{Name: "D"}}
+				xi.Status.Manifest.Dependencies = []v1alpha1.Dependency{{Name: "D"}}
+			})
+
+			It("should require D only once", func(ctx context.Context) {
+				res, err := dm.ValidateAll(ctx, []PlannedPackage{planned(p, pi), planned(x, xi)})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res.Status).To(Equal(ValidationResultStatusResolvable))
+				Expect(res.Requirements).To(HaveLen(1))
+				Expect(res.Requirements[0].Name).To(Equal("D"))
+				Expect(res.InstallationOrder).To(HaveLen(1))
+				Expect(res.Packages).To(HaveLen(2))
+			})
+		})
+
+		When("X depends on P", func() {
+			BeforeEach(func() {
+				xi.Status.Manifest.Dependencies = []v1alpha1.Dependency{{Name: "P"}}
+			})
+
+			It("should not require P and order P before X", func(ctx context.Context) {
+				res, err := dm.ValidateAll(ctx, []PlannedPackage{planned(x, xi), planned(p, pi)})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res.Status).To(Equal(ValidationResultStatusOk))
+				Expect(res.Requirements).To(BeEmpty())
+				Expect(res.Packages).To(HaveLen(2))
+				Expect(res.Packages[0].Name).To(Equal("P"))
+				Expect(res.Packages[1].Name).To(Equal("X"))
+			})
+		})
+
+		When("X requires a version of P that is not planned", func() {
+			BeforeEach(func() {
+				xi.Status.Manifest.Dependencies = []v1alpha1.Dependency{{Name: "P", Version: "<12.0.0"}}
+			})
+
+			It("should return CONFLICT", func(ctx context.Context) {
+				res, err := dm.ValidateAll(ctx, []PlannedPackage{planned(p, pi), planned(x, xi)})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res.Status).To(Equal(ValidationResultStatusConflict))
+				Expect(res.Conflicts).To(HaveLen(1))
+			})
+		})
+	})
 })
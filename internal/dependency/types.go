@@ -3,6 +3,9 @@ package dependency
 import (
 	"fmt"
 	"strings"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/dependency/graph"
 )
 
 type ValidationResultStatus string
@@ -18,6 +21,13 @@ type PackageWithVersion struct {
 	Version string
 }
 
+// PlannedPackage is a package that is about to be installed or updated
+type PlannedPackage struct {
+	Name, Namespace string
+	Manifest        v1alpha1.PackageManifest
+	Version         string
+}
+
 type Requirement struct {
 	PackageWithVersion
 	ComponentMetadata *ComponentMetadata
@@ -49,7 +59,14 @@ func (cf Conflicts) String() string {
 }
 
 type ValidationResult struct {
-	Status       ValidationResultStatus
+	Status ValidationResultStatus
+	// Packages are the validated packages in the order in which they should be installed
+	Packages     []PlannedPackage
 	Requirements []Requirement
-	Conflicts    Conflicts
+	// InstallationOrder contains the same items as Requirements, but ordered such that every package comes after all
+	// of its dependencies.
+	InstallationOrder []Requirement
+	Conflicts         Conflicts
+	// Graph is the dependency graph after the validated package and all its requirements have been added
+	Graph *graph.DependencyGraph
 }
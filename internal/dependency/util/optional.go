@@ -0,0 +1,37 @@
+package util
+
+import (
+	"slices"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+)
+
+// EnabledDependencies returns the dependencies of the manifest that should be installed. These are all required
+// dependencies and the optional dependencies whose names are contained in enabled.
+func EnabledDependencies(manifest *v1alpha1.PackageManifest, enabled []string) []v1alpha1.Dependency {
+	var result []v1alpha1.Dependency
+	for _, dep := range manifest.Dependencies {
+		if !dep.Optional || slices.Contains(enabled, dep.Name) {
+			result = append(result, dep)
+		}
+	}
+	return result
+}
+
+// WithEnabledDependencies returns a shallow copy of the manifest that only contains the dependencies returned by
+// EnabledDependencies. Skipped optional dependencies are therefore not considered by the dependency resolution.
+func WithEnabledDependencies(manifest v1alpha1.PackageManifest, enabled []string) v1alpha1.PackageManifest {
+	manifest.Dependencies = EnabledDependencies(&manifest, enabled)
+	return manifest
+}
+
+// OptionalDependencies returns the names of all optional dependencies of the manifest
+func OptionalDependencies(manifest *v1alpha1.PackageManifest) []string {
+	var result []string
+	for _, dep := range manifest.Dependencies {
+		if dep.Optional {
+			result = append(result, dep.Name)
+		}
+	}
+	return result
+}
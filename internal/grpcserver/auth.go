@@ -0,0 +1,22 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/subtle"
+
+	grpcv1 "github.com/glasskube/glasskube/api/grpc/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// authenticate returns an Unauthenticated error, unless the metadata of ctx contains the given token
+func authenticate(ctx context.Context, token string) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get(grpcv1.AuthorizationMetadataKey) {
+		if subtle.ConstantTimeCompare([]byte(value), []byte(grpcv1.BearerPrefix+token)) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "missing or invalid token")
+}
@@ -0,0 +1,40 @@
+package grpcserver
+
+import (
+	"context"
+
+	grpcv1 "github.com/glasskube/glasskube/api/grpc/v1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("authenticate", func() {
+	withAuthorization := func(values ...string) context.Context {
+		md := metadata.MD{}
+		md.Append(grpcv1.AuthorizationMetadataKey, values...)
+		return metadata.NewIncomingContext(context.Background(), md)
+	}
+
+	It("should accept the token", func() {
+		Expect(authenticate(withAuthorization("Bearer secret"), "secret")).To(Succeed())
+	})
+
+	It("should accept the token among other values", func() {
+		Expect(authenticate(withAuthorization("Basic abc", "Bearer secret"), "secret")).To(Succeed())
+	})
+
+	DescribeTable("should reject",
+		func(ctx context.Context) {
+			err := authenticate(ctx, "secret")
+			Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+		},
+		Entry("missing metadata", context.Background()),
+		Entry("missing authorization", withAuthorization()),
+		Entry("wrong token", withAuthorization("Bearer other")),
+		Entry("missing prefix", withAuthorization("secret")),
+		Entry("empty token", withAuthorization("Bearer ")),
+	)
+})
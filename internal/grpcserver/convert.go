@@ -0,0 +1,181 @@
+package grpcserver
+
+import (
+	"errors"
+	"fmt"
+
+	grpcv1 "github.com/glasskube/glasskube/api/grpc/v1"
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/pkg/client"
+	"github.com/glasskube/glasskube/pkg/describe"
+	"github.com/glasskube/glasskube/pkg/uninstall"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var errValueNotSet = errors.New("exactly one of value, value_from and template must be set")
+
+func toProtoPackage(pkg ctrlpkg.Package, status *client.PackageStatus) *grpcv1.Package {
+	spec := pkg.GetSpec()
+	result := &grpcv1.Package{
+		Ref: &grpcv1.PackageRef{Name: pkg.GetName(), Namespace: pkg.GetNamespace()},
+		Spec: &grpcv1.PackageSpec{
+			PackageName:          spec.PackageInfo.Name,
+			Version:              spec.PackageInfo.Version,
+			RepositoryName:       spec.PackageInfo.RepositoryName,
+			Values:               toProtoValues(spec.Values),
+			OptionalDependencies: spec.OptionalDependencies,
+			AutoUpdate:           pkg.AutoUpdatesEnabled(),
+			VersionConstraint:    pkg.VersionConstraint(),
+			Suspend:              spec.Suspend,
+		},
+		Paused: pkg.IsPaused(),
+	}
+	if status != nil {
+		result.Status = &grpcv1.PackageStatus{
+			Status:  status.Status,
+			Reason:  status.Reason,
+			Message: status.Message,
+			Version: pkg.GetStatus().Version,
+		}
+	}
+	return result
+}
+
+func toProtoValues(values map[string]v1alpha1.ValueConfiguration) map[string]*grpcv1.ValueConfiguration {
+	if len(values) == 0 {
+		return nil
+	}
+	result := make(map[string]*grpcv1.ValueConfiguration, len(values))
+	for name, value := range values {
+		config := &grpcv1.ValueConfiguration{Value: value.Value, Template: value.Template}
+		if ref := value.ValueFrom; ref != nil {
+			config.ValueFrom = &grpcv1.ValueReference{}
+			if ref.ConfigMapRef != nil {
+				config.ValueFrom.Source = &grpcv1.ValueReference_ConfigMapRef{
+					ConfigMapRef: toProtoObjectKeyValueSource(ref.ConfigMapRef)}
+			} else if ref.SecretRef != nil {
+				config.ValueFrom.Source = &grpcv1.ValueReference_SecretRef{
+					SecretRef: toProtoObjectKeyValueSource(ref.SecretRef)}
+			} else if ref.PackageRef != nil {
+				config.ValueFrom.Source = &grpcv1.ValueReference_PackageRef{PackageRef: &grpcv1.PackageValueSource{
+					Name: ref.PackageRef.Name, Value: ref.PackageRef.Value}}
+			}
+		}
+		result[name] = config
+	}
+	return result
+}
+
+func toProtoObjectKeyValueSource(source *v1alpha1.ObjectKeyValueSource) *grpcv1.ObjectKeyValueSource {
+	return &grpcv1.ObjectKeyValueSource{Name: source.Name, Namespace: source.Namespace, Key: source.Key}
+}
+
+// fromProtoValues converts the values of a request. Like in a package spec, every value must be configured in exactly
+// one way.
+func fromProtoValues(values map[string]*grpcv1.ValueConfiguration) (map[string]v1alpha1.ValueConfiguration, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	result := make(map[string]v1alpha1.ValueConfiguration, len(values))
+	for name, value := range values {
+		var config v1alpha1.ValueConfiguration
+		set := 0
+		if value.Value != nil {
+			config.Value = value.Value
+			set++
+		}
+		if value.Template != nil {
+			config.Template = value.Template
+			set++
+		}
+		if ref := value.GetValueFrom(); ref != nil {
+			config.ValueFrom = &v1alpha1.ValueReference{}
+			switch source := ref.Source.(type) {
+			case *grpcv1.ValueReference_ConfigMapRef:
+				config.ValueFrom.ConfigMapRef = fromProtoObjectKeyValueSource(source.ConfigMapRef)
+			case *grpcv1.ValueReference_SecretRef:
+				config.ValueFrom.SecretRef = fromProtoObjectKeyValueSource(source.SecretRef)
+			case *grpcv1.ValueReference_PackageRef:
+				config.ValueFrom.PackageRef = &v1alpha1.PackageValueSource{
+					Name:  source.PackageRef.GetName(),
+					Value: source.PackageRef.GetValue(),
+				}
+			default:
+				return nil, fmt.Errorf("value %v: value_from must have a source", name)
+			}
+			set++
+		}
+		if set != 1 {
+			return nil, fmt.Errorf("value %v: %w", name, errValueNotSet)
+		}
+		result[name] = config
+	}
+	return result, nil
+}
+
+func fromProtoObjectKeyValueSource(source *grpcv1.ObjectKeyValueSource) *v1alpha1.ObjectKeyValueSource {
+	return &v1alpha1.ObjectKeyValueSource{
+		Name:      source.GetName(),
+		Namespace: source.GetNamespace(),
+		Key:       source.GetKey(),
+	}
+}
+
+func fromProtoCascade(cascade grpcv1.Cascade) (uninstall.CascadeMode, error) {
+	switch cascade {
+	case grpcv1.Cascade_CASCADE_NONE:
+		return uninstall.CascadeNone, nil
+	case grpcv1.Cascade_CASCADE_DEPENDENTS:
+		return uninstall.CascadeDependents, nil
+	case grpcv1.Cascade_CASCADE_ORPHAN:
+		return uninstall.CascadeOrphan, nil
+	default:
+		return uninstall.CascadeNone, fmt.Errorf("invalid cascade %v", cascade)
+	}
+}
+
+func toProtoDependencies(dependencies []describe.DependencyStatus) []*grpcv1.DependencyStatus {
+	result := make([]*grpcv1.DependencyStatus, len(dependencies))
+	for i, dep := range dependencies {
+		result[i] = &grpcv1.DependencyStatus{
+			Name:             dep.Name,
+			Version:          dep.Version,
+			Optional:         dep.Optional,
+			Enabled:          dep.Enabled,
+			InstalledVersion: dep.InstalledVersion,
+			Status:           dep.Status,
+		}
+	}
+	return result
+}
+
+func toProtoResources(resources []describe.ResourceStatus) []*grpcv1.ResourceStatus {
+	result := make([]*grpcv1.ResourceStatus, len(resources))
+	for i, resource := range resources {
+		result[i] = &grpcv1.ResourceStatus{
+			ApiVersion: resource.APIVersion,
+			Kind:       resource.Kind,
+			Namespace:  resource.Namespace,
+			Name:       resource.Name,
+			Health:     resource.Health,
+			Message:    resource.Message,
+		}
+	}
+	return result
+}
+
+func toProtoEvents(events []describe.Event) []*grpcv1.Event {
+	result := make([]*grpcv1.Event, len(events))
+	for i, event := range events {
+		result[i] = &grpcv1.Event{
+			Type:     event.Type,
+			Reason:   event.Reason,
+			Object:   event.Object,
+			Message:  event.Message,
+			Count:    event.Count,
+			LastSeen: timestamppb.New(event.LastSeen),
+		}
+	}
+	return result
+}
@@ -0,0 +1,95 @@
+package grpcserver
+
+import (
+	grpcv1 "github.com/glasskube/glasskube/api/grpc/v1"
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/pkg/client"
+	"github.com/glasskube/glasskube/pkg/uninstall"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("convert", func() {
+	Describe("values", func() {
+		values := map[string]v1alpha1.ValueConfiguration{
+			"plain":    {InlineValueConfiguration: v1alpha1.InlineValueConfiguration{Value: ptr.To("foo")}},
+			"template": {Template: ptr.To("{{ .Name }}")},
+			"secret": {ValueFrom: &v1alpha1.ValueReference{
+				SecretRef: &v1alpha1.ObjectKeyValueSource{Name: "s", Namespace: "ns", Key: "k"}}},
+			"configMap": {ValueFrom: &v1alpha1.ValueReference{
+				ConfigMapRef: &v1alpha1.ObjectKeyValueSource{Name: "c", Namespace: "ns", Key: "k"}}},
+			"package": {ValueFrom: &v1alpha1.ValueReference{
+				PackageRef: &v1alpha1.PackageValueSource{Name: "p", Value: "v"}}},
+		}
+
+		It("should convert values in both directions", func() {
+			converted, err := fromProtoValues(toProtoValues(values))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(converted).To(Equal(values))
+		})
+
+		It("should return nil for no values", func() {
+			Expect(toProtoValues(nil)).To(BeNil())
+			Expect(fromProtoValues(nil)).To(BeNil())
+		})
+
+		DescribeTable("should reject invalid values",
+			func(value *grpcv1.ValueConfiguration) {
+				_, err := fromProtoValues(map[string]*grpcv1.ValueConfiguration{"foo": value})
+				Expect(err).To(MatchError(ContainSubstring("value foo")))
+			},
+			Entry("nothing set", &grpcv1.ValueConfiguration{}),
+			Entry("value and template", &grpcv1.ValueConfiguration{Value: ptr.To("a"), Template: ptr.To("b")}),
+			Entry("value_from without source", &grpcv1.ValueConfiguration{ValueFrom: &grpcv1.ValueReference{}}),
+			Entry("value and value_from", &grpcv1.ValueConfiguration{
+				Value: ptr.To("a"),
+				ValueFrom: &grpcv1.ValueReference{Source: &grpcv1.ValueReference_PackageRef{
+					PackageRef: &grpcv1.PackageValueSource{Name: "p", Value: "v"}}},
+			}),
+		)
+	})
+
+	Describe("toProtoPackage", func() {
+		It("should convert a Package", func() {
+			pkg := client.PackageBuilder("foo").
+				WithName("bar").
+				WithNamespace("ns").
+				WithVersion("v1.0.0").
+				WithRepositoryName("glasskube").
+				WithAutoUpdates(true).
+				BuildPackage()
+			pkg.Status.Version = "v0.9.0"
+			result := toProtoPackage(pkg, &client.PackageStatus{Status: "Ready", Reason: "Installed"})
+			Expect(result.Ref).To(Equal(&grpcv1.PackageRef{Name: "bar", Namespace: "ns"}))
+			Expect(result.Spec.PackageName).To(Equal("foo"))
+			Expect(result.Spec.Version).To(Equal("v1.0.0"))
+			Expect(result.Spec.RepositoryName).To(Equal("glasskube"))
+			Expect(result.Spec.AutoUpdate).To(BeTrue())
+			Expect(result.Status.Status).To(Equal("Ready"))
+			Expect(result.Status.Reason).To(Equal("Installed"))
+			Expect(result.Status.Version).To(Equal("v0.9.0"))
+		})
+
+		It("should omit the status if there is none", func() {
+			pkg := client.PackageBuilder("foo").BuildClusterPackage()
+			result := toProtoPackage(pkg, nil)
+			Expect(result.Ref).To(Equal(&grpcv1.PackageRef{Name: "foo"}))
+			Expect(result.Status).To(BeNil())
+		})
+	})
+
+	DescribeTable("fromProtoCascade",
+		func(cascade grpcv1.Cascade, expected uninstall.CascadeMode) {
+			Expect(fromProtoCascade(cascade)).To(Equal(expected))
+		},
+		Entry("none", grpcv1.Cascade_CASCADE_NONE, uninstall.CascadeNone),
+		Entry("dependents", grpcv1.Cascade_CASCADE_DEPENDENTS, uninstall.CascadeDependents),
+		Entry("orphan", grpcv1.Cascade_CASCADE_ORPHAN, uninstall.CascadeOrphan),
+	)
+
+	It("should reject unknown cascade modes", func() {
+		_, err := fromProtoCascade(grpcv1.Cascade(42))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,43 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var _ = Describe("toStatusError", func() {
+	resource := schema.GroupResource{Group: "packages.glasskube.dev", Resource: "packages"}
+
+	It("should return nil for nil", func() {
+		Expect(toStatusError(nil)).To(Succeed())
+	})
+
+	It("should keep existing status errors", func() {
+		err := status.Error(codes.FailedPrecondition, "conflict")
+		Expect(toStatusError(err)).To(Equal(err))
+	})
+
+	DescribeTable("should map errors to codes",
+		func(err error, code codes.Code) {
+			converted := toStatusError(err)
+			Expect(status.Code(converted)).To(Equal(code))
+			Expect(status.Convert(converted).Message()).To(Equal(err.Error()))
+		},
+		Entry("not found", apierrors.NewNotFound(resource, "foo"), codes.NotFound),
+		Entry("wrapped not found", fmt.Errorf("failed: %w", apierrors.NewNotFound(resource, "foo")), codes.NotFound),
+		Entry("already exists", apierrors.NewAlreadyExists(resource, "foo"), codes.AlreadyExists),
+		Entry("forbidden", apierrors.NewForbidden(resource, "foo", errors.New("denied")), codes.PermissionDenied),
+		Entry("conflict", apierrors.NewConflict(resource, "foo", errors.New("changed")), codes.Aborted),
+		Entry("bad request", apierrors.NewBadRequest("bad"), codes.InvalidArgument),
+		Entry("canceled", fmt.Errorf("watch: %w", context.Canceled), codes.Canceled),
+		Entry("other", errors.New("other"), codes.Unknown),
+	)
+})
@@ -0,0 +1,13 @@
+package grpcserver
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGrpcServer(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "gRPC Server Suite")
+}
@@ -0,0 +1,431 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	grpcv1 "github.com/glasskube/glasskube/api/grpc/v1"
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/audit"
+	"github.com/glasskube/glasskube/internal/cliutils"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	deputil "github.com/glasskube/glasskube/internal/dependency/util"
+	"github.com/glasskube/glasskube/internal/manifestvalues"
+	"github.com/glasskube/glasskube/internal/namespaces"
+	"github.com/glasskube/glasskube/internal/repo"
+	repoclient "github.com/glasskube/glasskube/internal/repo/client"
+	repotypes "github.com/glasskube/glasskube/internal/repo/types"
+	"github.com/glasskube/glasskube/pkg/client"
+	"github.com/glasskube/glasskube/pkg/describe"
+	"github.com/glasskube/glasskube/pkg/install"
+	"github.com/glasskube/glasskube/pkg/list"
+	"github.com/glasskube/glasskube/pkg/uninstall"
+	"github.com/glasskube/glasskube/pkg/update"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+type packageService struct {
+	grpcv1.UnimplementedPackageServiceServer
+}
+
+func (*packageService) ListPackages(
+	ctx context.Context,
+	req *grpcv1.ListPackagesRequest,
+) (*grpcv1.ListPackagesResponse, error) {
+	lister := list.NewListerWithRepoCache(ctx)
+	opts := list.ListOptions{
+		OnlyInstalled: !req.IncludeAvailable,
+		OnlyOutdated:  req.OnlyOutdated,
+		Repository:    req.Repository,
+	}
+	clpkgs, err := lister.GetClusterPackagesWithStatus(ctx, opts)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	opts.Namespace = req.Namespace
+	pkgs, err := lister.GetPackagesWithStatus(ctx, opts)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// packages that are available in the repositories are contained in both lists, so they are merged by name
+	var response grpcv1.ListPackagesResponse
+	listed := make(map[string]*grpcv1.ListedPackage)
+	getListed := func(item repotypes.MetaIndexItem) *grpcv1.ListedPackage {
+		if existing, ok := listed[item.Name]; ok {
+			return existing
+		}
+		result := &grpcv1.ListedPackage{
+			PackageName:      item.Name,
+			ShortDescription: item.ShortDescription,
+			LatestVersion:    item.LatestVersion,
+			Repositories:     item.Repos,
+		}
+		listed[item.Name] = result
+		response.Packages = append(response.Packages, result)
+		return result
+	}
+	for _, item := range clpkgs {
+		result := getListed(item.MetaIndexItem)
+		if item.ClusterPackage != nil {
+			result.Installed = append(result.Installed, toProtoPackage(item.ClusterPackage, item.Status))
+		}
+	}
+	for _, item := range pkgs {
+		result := getListed(item.MetaIndexItem)
+		for _, pkg := range item.Packages {
+			result.Installed = append(result.Installed, toProtoPackage(pkg.Package, pkg.Status))
+		}
+	}
+	return &response, nil
+}
+
+// DescribePackage returns as much information about a package as possible. Only an error getting the package itself
+// fails the call, all other parts are left empty if they can not be looked up.
+func (*packageService) DescribePackage(
+	ctx context.Context,
+	req *grpcv1.DescribePackageRequest,
+) (*grpcv1.DescribePackageResponse, error) {
+	pkg, manifest, err := describePackage(ctx, req.GetRef())
+	if pkg == nil {
+		return nil, toStatusError(err)
+	}
+
+	response := grpcv1.DescribePackageResponse{Package: toProtoPackage(pkg, client.GetStatusOrPending(pkg))}
+	spec := pkg.GetSpec()
+	if _, latestVersion, err := describe.DescribeLatestVersion(
+		ctx, spec.PackageInfo.RepositoryName, spec.PackageInfo.Name); err == nil {
+		response.LatestVersion = latestVersion
+	}
+	if manifest != nil {
+		dependencies, _ := describe.DescribeDependencies(ctx, pkg, manifest)
+		response.Dependencies = toProtoDependencies(dependencies)
+	}
+	if resources, err := describe.DescribeResources(ctx, pkg); err == nil {
+		response.Resources = toProtoResources(resources)
+		if req.EventLimit > 0 {
+			if events, err := describe.DescribeEvents(ctx, pkg, resources, int(req.EventLimit)); err == nil {
+				response.Events = toProtoEvents(events)
+			}
+		}
+	}
+	return &response, nil
+}
+
+// describePackage returns the package with the given ref and its manifest. If only the manifest can not be fetched,
+// the package is returned together with the error.
+func describePackage(ctx context.Context, ref *grpcv1.PackageRef) (
+	ctrlpkg.Package, *v1alpha1.PackageManifest, error) {
+	if ref.GetName() == "" {
+		return nil, nil, status.Error(codes.InvalidArgument, "ref.name is required")
+	}
+	if ref.GetNamespace() == "" {
+		pkg, manifest, err := describe.DescribeInstalledClusterPackage(ctx, ref.GetName())
+		if pkg == nil {
+			return nil, nil, err
+		}
+		return pkg, manifest, err
+	}
+	pkg, manifest, err := describe.DescribeInstalledPackage(ctx, ref.GetNamespace(), ref.GetName())
+	if pkg == nil {
+		return nil, nil, err
+	}
+	return pkg, manifest, err
+}
+
+func getPackage(ctx context.Context, ref *grpcv1.PackageRef) (ctrlpkg.Package, error) {
+	pkgClient := cliutils.PackageClient(ctx)
+	if ref.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "ref.name is required")
+	} else if ref.GetNamespace() == "" {
+		var pkg v1alpha1.ClusterPackage
+		if err := pkgClient.ClusterPackages().Get(ctx, ref.GetName(), &pkg); err != nil {
+			return nil, toStatusError(err)
+		}
+		return &pkg, nil
+	} else {
+		var pkg v1alpha1.Package
+		if err := pkgClient.Packages(ref.GetNamespace()).Get(ctx, ref.GetName(), &pkg); err != nil {
+			return nil, toStatusError(err)
+		}
+		return &pkg, nil
+	}
+}
+
+func (*packageService) InstallPackage(
+	req *grpcv1.InstallPackageRequest,
+	stream grpc.ServerStreamingServer[grpcv1.Progress],
+) error {
+	ctx := stream.Context()
+	if req.PackageName == "" {
+		return status.Error(codes.InvalidArgument, "package_name is required")
+	}
+	repoClientset := cliutils.RepositoryClientset(ctx)
+	pkgBuilder := client.PackageBuilder(req.PackageName)
+
+	repoName := req.RepositoryName
+	if repoName == "" {
+		if name, err := resolveRepository(repoClientset, req.PackageName); err != nil {
+			return err
+		} else {
+			repoName = name
+		}
+	}
+	repoClient := repoClientset.ForRepoWithName(repoName)
+	pkgBuilder.WithRepositoryName(repoName)
+
+	version := req.Version
+	if version == "" {
+		var packageIndex repo.PackageIndex
+		if err := repoClient.FetchPackageIndex(req.PackageName, &packageIndex); err != nil {
+			return status.Errorf(codes.Unavailable, "could not fetch package metadata: %v", err)
+		}
+		version = packageIndex.LatestVersion
+	} else if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	pkgBuilder.WithVersion(version)
+
+	var manifest v1alpha1.PackageManifest
+	if err := repoClient.FetchPackageManifest(req.PackageName, version, &manifest); err != nil {
+		return status.Errorf(codes.Unavailable, "could not fetch package manifest: %v", err)
+	}
+
+	if manifest.Scope.IsCluster() {
+		if req.Name != "" || req.Namespace != "" {
+			return status.Errorf(codes.InvalidArgument,
+				"%v has scope Cluster, name and namespace must not be set", req.PackageName)
+		}
+	} else {
+		if req.Namespace == "" {
+			return status.Errorf(codes.InvalidArgument, "%v has scope Namespaced, namespace is required",
+				req.PackageName)
+		}
+		name := req.Name
+		if name == "" {
+			name = req.PackageName
+		}
+		pkgBuilder.WithName(name).WithNamespace(req.Namespace)
+	}
+
+	values, err := fromProtoValues(req.Values)
+	if err == nil {
+		err = manifestvalues.ValidateValueConfigurations(manifest, values)
+	}
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid values: %v", err)
+	}
+	pkgBuilder.WithValues(values).
+		WithAutoUpdates(req.AutoUpdate).
+		WithOptionalDependencies(req.OptionalDependencies)
+	pkg := pkgBuilder.Build(manifest.Scope)
+
+	enabledManifest := deputil.WithEnabledDependencies(manifest, pkg.GetSpec().OptionalDependencies)
+	if result, err := cliutils.DependencyManager(ctx).
+		Validate(ctx, pkg.GetName(), pkg.GetNamespace(), &enabledManifest, version); err != nil {
+		return status.Errorf(codes.Internal, "could not validate dependencies: %v", err)
+	} else if len(result.Conflicts) > 0 {
+		return status.Errorf(codes.FailedPrecondition, "%v can not be installed due to conflicts: %v",
+			req.PackageName, result.Conflicts)
+	}
+
+	opts := metav1.CreateOptions{}
+	if req.DryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+
+	if pkg.IsNamespaceScoped() {
+		if err := ensureNamespace(ctx, pkg.GetNamespace(), req.CreateNamespace, opts.DryRun); err != nil {
+			return err
+		}
+	}
+
+	progress := newProgressWriter(stream)
+	pkgStatus, err := install.NewInstaller(cliutils.PackageClient(ctx)).
+		WithStatusWriter(progress).
+		WithComponents(&manifest).
+		InstallBlocking(ctx, pkg, opts)
+	if err != nil {
+		return toStatusError(err)
+	}
+	if !req.DryRun {
+		recordAuditEntry(ctx, audit.OperationInstall, pkg, "", version)
+	}
+	return progress.done(fmt.Sprintf("%v has status %v", pkg.GetName(), pkgStatus.Status),
+		toProtoPackage(pkg, pkgStatus))
+}
+
+// resolveRepository returns the name of the repository to install a package from, if no repository was requested
+func resolveRepository(repoClientset repoclient.RepoClientset, packageName string) (string, error) {
+	repos, err := repoClientset.Meta().GetReposForPackage(packageName)
+	if len(repos) == 0 {
+		if err != nil {
+			return "", status.Errorf(codes.Unavailable, "could not collect repository list: %v", err)
+		}
+		return "", status.Errorf(codes.NotFound, "%v is not available", packageName)
+	} else if len(repos) == 1 {
+		return repos[0].Name, nil
+	} else if resolution, err := repoclient.ResolveRepository(packageName, repos); err == nil {
+		return resolution.Repository.Name, nil
+	} else {
+		return "", status.Errorf(codes.InvalidArgument,
+			"%v is available from %v repositories, repository_name is required", packageName, len(repos))
+	}
+}
+
+func ensureNamespace(ctx context.Context, namespace string, create bool, dryRun []string) error {
+	cs := cliutils.KubernetesClient(ctx)
+	if ok, err := namespaces.Exists(ctx, cs, namespace); err != nil {
+		return toStatusError(err)
+	} else if ok {
+		return nil
+	} else if !create {
+		return status.Errorf(codes.FailedPrecondition,
+			"namespace %v does not exist, set create_namespace to create it", namespace)
+	}
+	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+	_, err := cs.CoreV1().Namespaces().Create(ctx, &ns, metav1.CreateOptions{DryRun: dryRun})
+	return toStatusError(err)
+}
+
+func (*packageService) UpdatePackage(
+	req *grpcv1.UpdatePackageRequest,
+	stream grpc.ServerStreamingServer[grpcv1.Progress],
+) error {
+	ctx := stream.Context()
+	pkg, err := getPackage(ctx, req.GetRef())
+	if err != nil {
+		return err
+	}
+
+	progress := newProgressWriter(stream)
+	updater := update.NewUpdater(ctx).WithStatusWriter(progress)
+	var tx *update.UpdateTransaction
+	if req.Version != "" {
+		version := req.Version
+		if !strings.HasPrefix(version, "v") {
+			version = "v" + version
+		}
+		tx, err = updater.PrepareForVersion(ctx, pkg, version)
+	} else {
+		tx, err = updater.Prepare(ctx, update.GetExact([]ctrlpkg.Package{pkg}))
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "update preparation failed: %v", err)
+	}
+
+	var conflicts []string
+	for _, item := range tx.ConflictItems {
+		for _, conflict := range item.Conflicts {
+			conflicts = append(conflicts, fmt.Sprintf("%v (required: %v, actual: %v)",
+				conflict.Actual.Name, conflict.Required.Version, conflict.Actual.Version))
+		}
+	}
+	if len(conflicts) > 0 {
+		return status.Errorf(codes.FailedPrecondition, "%v can not be updated due to dependency conflicts: %v",
+			pkg.GetName(), strings.Join(conflicts, ", "))
+	}
+	if tx.IsEmpty() {
+		return progress.done(fmt.Sprintf("%v is up-to-date", pkg.GetName()),
+			toProtoPackage(pkg, client.GetStatusOrPending(pkg)))
+	}
+
+	versionsBefore := make([]string, len(tx.Items))
+	for i, item := range tx.Items {
+		versionsBefore[i] = item.Package.GetSpec().PackageInfo.Version
+	}
+	if _, err := updater.Apply(ctx, tx, update.ApplyUpdateOptions{Blocking: !req.DryRun, DryRun: req.DryRun}); err != nil {
+		return toStatusError(err)
+	}
+	if !req.DryRun {
+		for i, item := range tx.Items {
+			if item.UpdateRequired() {
+				recordAuditEntry(ctx, audit.OperationUpdate, item.Package, versionsBefore[i], item.Version)
+			}
+		}
+		// the package has been changed by the update, so it is fetched again to return its current status
+		if updated, err := getPackage(ctx, req.GetRef()); err == nil {
+			pkg = updated
+		}
+	}
+	return progress.done(fmt.Sprintf("%v has been updated", pkg.GetName()),
+		toProtoPackage(pkg, client.GetStatusOrPending(pkg)))
+}
+
+func (*packageService) UninstallPackage(
+	req *grpcv1.UninstallPackageRequest,
+	stream grpc.ServerStreamingServer[grpcv1.Progress],
+) error {
+	ctx := stream.Context()
+	pkg, err := getPackage(ctx, req.GetRef())
+	if err != nil {
+		return err
+	}
+	cascade, err := fromProtoCascade(req.Cascade)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	pkgClient := cliutils.PackageClient(ctx)
+	g, err := cliutils.DependencyManager(ctx).NewGraph(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "could not validate uninstall: %v", err)
+	}
+	impact, err := uninstall.AnalyzeImpact(g, pkg.GetName(), pkg.GetNamespace(), cascade)
+	if errors.Is(err, uninstall.ErrHasDependents) {
+		return status.Errorf(codes.FailedPrecondition, "%v, use cascade to uninstall them as well or to keep them", err)
+	} else if err != nil {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	dependents, err := uninstall.GetPackages(ctx, pkgClient, impact.Cascaded)
+	if err != nil {
+		return toStatusError(err)
+	}
+
+	progress := newProgressWriter(stream)
+	uninstaller := uninstall.NewUninstaller(pkgClient).
+		WithRetention(cliutils.KubernetesClient(ctx), uninstall.RetainOptions{
+			PersistentVolumeClaims: req.RetainVolumes,
+			Secrets:                req.RetainSecrets,
+		}).
+		WithStatusWriter(progress)
+
+	// dependents are uninstalled first, so that no package is left without its dependencies in the meantime
+	for _, dependent := range dependents {
+		if err := uninstaller.Uninstall(ctx, dependent, req.DryRun); err != nil {
+			return status.Errorf(status.Code(toStatusError(err)), "could not uninstall %v: %v",
+				cache.MetaObjectToName(dependent), err)
+		}
+		if !req.DryRun {
+			recordAuditEntry(ctx, audit.OperationUninstall, dependent, dependent.GetSpec().PackageInfo.Version, "")
+		}
+	}
+	if err := uninstaller.UninstallBlocking(ctx, pkg, req.DryRun); err != nil {
+		return toStatusError(err)
+	}
+	if !req.DryRun {
+		recordAuditEntry(ctx, audit.OperationUninstall, pkg, pkg.GetSpec().PackageInfo.Version, "")
+	}
+	return progress.done(fmt.Sprintf("%v has been uninstalled", pkg.GetName()), toProtoPackage(pkg, nil))
+}
+
+// recordAuditEntry writes an audit log entry for an operation that has been performed successfully. Failing to write
+// the entry does not fail the call.
+func recordAuditEntry(
+	ctx context.Context,
+	operation audit.Operation,
+	pkg ctrlpkg.Package,
+	versionBefore, versionAfter string,
+) {
+	if err := audit.Record(ctx, audit.SourceGRPC, operation, pkg, versionBefore, versionAfter); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+	}
+}
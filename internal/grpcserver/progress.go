@@ -0,0 +1,74 @@
+package grpcserver
+
+import (
+	"sync"
+
+	grpcv1 "github.com/glasskube/glasskube/api/grpc/v1"
+	"github.com/glasskube/glasskube/pkg/statuswriter"
+)
+
+type progressStream interface {
+	Send(*grpcv1.Progress) error
+}
+
+// progressWriter is a statuswriter.ComponentStatusWriter that sends every status to the client of a streaming call.
+// Installers and updaters report their status from other goroutines, so sends are synchronized.
+type progressWriter struct {
+	stream progressStream
+	mutex  sync.Mutex
+	err    error
+}
+
+var _ statuswriter.ComponentStatusWriter = &progressWriter{}
+
+func newProgressWriter(stream progressStream) *progressWriter {
+	return &progressWriter{stream: stream}
+}
+
+// SetStatus implements statuswriter.StatusWriter.
+func (w *progressWriter) SetStatus(desc string) {
+	w.send(&grpcv1.Progress{Message: desc})
+}
+
+// SetComponentStatus implements statuswriter.ComponentStatusWriter.
+func (w *progressWriter) SetComponentStatus(name string, state statuswriter.ComponentState, message string) {
+	w.send(&grpcv1.Progress{
+		Component: &grpcv1.Component{Name: name, State: toProtoComponentState(state), Message: message},
+	})
+}
+
+// Start implements statuswriter.StatusWriter.
+func (w *progressWriter) Start() {}
+
+// Stop implements statuswriter.StatusWriter.
+func (w *progressWriter) Stop() {}
+
+// done sends the final message of an operation. It returns the first error that occurred while sending.
+func (w *progressWriter) done(message string, pkg *grpcv1.Package) error {
+	w.send(&grpcv1.Progress{Message: message, Package: pkg})
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.err
+}
+
+func (w *progressWriter) send(progress *grpcv1.Progress) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	// after the first failed send, the stream is broken and the operation is canceled via its context
+	if w.err == nil {
+		w.err = w.stream.Send(progress)
+	}
+}
+
+func toProtoComponentState(state statuswriter.ComponentState) grpcv1.ComponentState {
+	switch state {
+	case statuswriter.ComponentPending:
+		return grpcv1.ComponentState_COMPONENT_STATE_PENDING
+	case statuswriter.ComponentReady:
+		return grpcv1.ComponentState_COMPONENT_STATE_READY
+	case statuswriter.ComponentFailed:
+		return grpcv1.ComponentState_COMPONENT_STATE_FAILED
+	default:
+		return grpcv1.ComponentState_COMPONENT_STATE_UNSPECIFIED
+	}
+}
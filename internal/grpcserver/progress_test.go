@@ -0,0 +1,45 @@
+package grpcserver
+
+import (
+	"errors"
+
+	grpcv1 "github.com/glasskube/glasskube/api/grpc/v1"
+	"github.com/glasskube/glasskube/pkg/statuswriter"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fakeStream struct {
+	sent []*grpcv1.Progress
+	err  error
+}
+
+func (s *fakeStream) Send(progress *grpcv1.Progress) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.sent = append(s.sent, progress)
+	return nil
+}
+
+var _ = Describe("progressWriter", func() {
+	It("should send every status", func() {
+		stream := &fakeStream{}
+		w := newProgressWriter(stream)
+		w.SetStatus("Installing foo...")
+		w.SetComponentStatus("bar", statuswriter.ComponentReady, "")
+		Expect(w.done("foo is ready", &grpcv1.Package{})).To(Succeed())
+		Expect(stream.sent).To(HaveLen(3))
+		Expect(stream.sent[0].Message).To(Equal("Installing foo..."))
+		Expect(stream.sent[1].Component.Name).To(Equal("bar"))
+		Expect(stream.sent[1].Component.State).To(Equal(grpcv1.ComponentState_COMPONENT_STATE_READY))
+		Expect(stream.sent[2].Package).NotTo(BeNil())
+	})
+
+	It("should return the first error of the stream", func() {
+		stream := &fakeStream{err: errors.New("broken")}
+		w := newProgressWriter(stream)
+		w.SetStatus("Installing foo...")
+		Expect(w.done("foo is ready", nil)).To(MatchError("broken"))
+	})
+})
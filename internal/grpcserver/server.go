@@ -0,0 +1,144 @@
+// Package grpcserver serves the gRPC API of glasskube, defined in api/grpc/v1. It performs the same operations as the
+// CLI, using the clients of the context the server is started with.
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	grpcv1 "github.com/glasskube/glasskube/api/grpc/v1"
+	"github.com/glasskube/glasskube/internal/clicontext"
+	"github.com/glasskube/glasskube/internal/cliutils"
+	repoclient "github.com/glasskube/glasskube/internal/repo/client"
+	"github.com/glasskube/glasskube/pkg/client"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+type Options struct {
+	Host string
+	Port string
+	// Token must be sent by clients in the authorization metadata of every call
+	Token       string
+	TLSCertFile string
+	TLSKeyFile  string
+	// ShutdownGracePeriod is the time running calls have to finish after the context of Start is done
+	ShutdownGracePeriod time.Duration
+}
+
+type server struct {
+	Options
+	config        *rest.Config
+	rawConfig     *api.Config
+	pkgClient     client.PackageV1Alpha1Client
+	k8sClient     *kubernetes.Clientset
+	repoClientset repoclient.RepoClientset
+}
+
+func NewServer(options Options) *server {
+	return &server{Options: options}
+}
+
+// Start serves the API until ctx is done. The clients of ctx are used for all calls, so it must have been set up
+// with clicontext.
+func (s *server) Start(ctx context.Context) error {
+	if s.Token == "" {
+		return errors.New("a token is required")
+	}
+	if (s.TLSCertFile == "") != (s.TLSKeyFile == "") {
+		return errors.New("both a TLS certificate and key file must be specified")
+	}
+
+	s.config = clicontext.ConfigFromContext(ctx)
+	s.rawConfig = clicontext.RawConfigFromContext(ctx)
+	s.pkgClient = cliutils.PackageClient(ctx)
+	s.k8sClient = cliutils.KubernetesClient(ctx)
+	s.repoClientset = cliutils.RepositoryClientset(ctx)
+
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(s.unaryInterceptor),
+		grpc.ChainStreamInterceptor(s.streamInterceptor),
+	}
+	if s.TLSCertFile != "" {
+		if creds, err := credentials.NewServerTLSFromFile(s.TLSCertFile, s.TLSKeyFile); err != nil {
+			return fmt.Errorf("could not load TLS certificate: %w", err)
+		} else {
+			opts = append(opts, grpc.Creds(creds))
+		}
+	}
+
+	grpcServer := grpc.NewServer(opts...)
+	grpcv1.RegisterPackageServiceServer(grpcServer, &packageService{})
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(s.Host, s.Port))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "glasskube gRPC API is available at %v\n", listener.Addr())
+	if s.TLSCertFile == "" {
+		fmt.Fprintln(os.Stderr, "⚠️  TLS is not enabled. Tokens are sent in plain text.")
+	}
+
+	go func() {
+		<-ctx.Done()
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(s.ShutdownGracePeriod):
+			grpcServer.Stop()
+		}
+	}()
+
+	return grpcServer.Serve(listener)
+}
+
+// callContext adds the clients of the server to the context of a call
+func (s *server) callContext(ctx context.Context) context.Context {
+	ctx = clicontext.SetupContextWithClient(ctx, s.config, s.rawConfig, s.pkgClient, s.k8sClient)
+	return clicontext.ContextWithRepositoryClientset(ctx, s.repoClientset)
+}
+
+func (s *server) unaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if err := authenticate(ctx, s.Token); err != nil {
+		return nil, err
+	}
+	return handler(s.callContext(ctx), req)
+}
+
+func (s *server) streamInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if err := authenticate(ss.Context(), s.Token); err != nil {
+		return err
+	}
+	return handler(srv, &contextStream{ServerStream: ss, ctx: s.callContext(ss.Context())})
+}
+
+// contextStream replaces the context of a grpc.ServerStream
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -1,10 +1,15 @@
 package httperror
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
+	"strings"
+	"syscall"
 )
 
 type statusError struct {
@@ -38,6 +43,12 @@ func CheckResponse(response *http.Response, err error) (*http.Response, error) {
 	}
 }
 
+// FromStatusCode returns an error for the given status code that can be checked with Is, as if it had been returned by
+// CheckResponse
+func FromStatusCode(code int) error {
+	return &statusError{fmt.Sprintf("%d %s", code, http.StatusText(code)), code}
+}
+
 func Is(err error, code int) bool {
 	var statusErr *statusError
 	if errors.As(err, &statusErr) {
@@ -54,3 +65,41 @@ func IsTimeoutError(err error) bool {
 	var netErr net.Error
 	return errors.As(err, &netErr) && netErr.Timeout()
 }
+
+func IsServerError(err error) bool {
+	var statusErr *statusError
+	return errors.As(err, &statusErr) && statusErr.code >= 500
+}
+
+// IsTransient returns true if the error is likely to go away when the request is repeated. This is the case for
+// server errors (5xx), timeouts and connections that were refused or reset. Client errors (4xx) are never transient.
+func IsTransient(err error) bool {
+	return IsServerError(err) ||
+		IsTimeoutError(err) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// IsTLSError returns true if the error was caused by a failed verification of the certificate of the server, e.g.
+// because it was issued by an unknown certificate authority or for a different host name.
+func IsTLSError(err error) bool {
+	var verificationErr *tls.CertificateVerificationError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var invalidErr x509.CertificateInvalidError
+	var hostnameErr x509.HostnameError
+	return errors.As(err, &verificationErr) ||
+		errors.As(err, &unknownAuthorityErr) ||
+		errors.As(err, &invalidErr) ||
+		errors.As(err, &hostnameErr)
+}
+
+// IsProxyError returns true if the error was caused by the proxy rather than the server, e.g. because the proxy could
+// not be reached or rejected the credentials.
+func IsProxyError(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && (opErr.Op == "proxyconnect" || strings.HasPrefix(opErr.Op, "socks ")) {
+		return true
+	}
+	return Is(err, http.StatusProxyAuthRequired)
+}
@@ -0,0 +1,64 @@
+package httperror
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"syscall"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IsTransient", func() {
+	DescribeTable("should classify errors",
+		func(err error, expected bool) {
+			Expect(IsTransient(err)).To(Equal(expected))
+		},
+		Entry("nil", nil, false),
+		Entry("not found", &statusError{"404 Not Found", 404}, false),
+		Entry("unauthorized", fmt.Errorf("wrapped: %w", &statusError{"401 Unauthorized", 401}), false),
+		Entry("internal server error", &statusError{"500 Internal Server Error", 500}, true),
+		Entry("bad gateway", fmt.Errorf("wrapped: %w", &statusError{"502 Bad Gateway", 502}), true),
+		Entry("connection reset", &url.Error{Op: "Get", URL: "x", Err: syscall.ECONNRESET}, true),
+		Entry("connection refused", &url.Error{Op: "Get", URL: "x", Err: syscall.ECONNREFUSED}, true),
+		Entry("unexpected eof", io.ErrUnexpectedEOF, true),
+		Entry("timeout", &url.Error{Op: "Get", URL: "x", Err: context.DeadlineExceeded}, true),
+		Entry("other", io.EOF, false),
+	)
+})
+
+var _ = Describe("IsTLSError", func() {
+	DescribeTable("should classify errors",
+		func(err error, expected bool) {
+			Expect(IsTLSError(err)).To(Equal(expected))
+		},
+		Entry("nil", nil, false),
+		Entry("unknown authority",
+			&url.Error{Op: "Get", URL: "x", Err: &tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}}},
+			true),
+		Entry("wrong host name", fmt.Errorf("wrapped: %w", x509.HostnameError{Host: "x"}), true),
+		Entry("connection refused", &url.Error{Op: "Get", URL: "x", Err: syscall.ECONNREFUSED}, false),
+		Entry("not found", &statusError{"404 Not Found", 404}, false),
+	)
+})
+
+var _ = Describe("IsProxyError", func() {
+	DescribeTable("should classify errors",
+		func(err error, expected bool) {
+			Expect(IsProxyError(err)).To(Equal(expected))
+		},
+		Entry("nil", nil, false),
+		Entry("proxy refused connection",
+			&url.Error{Op: "Get", URL: "x", Err: &net.OpError{Op: "proxyconnect", Net: "tcp", Err: syscall.ECONNREFUSED}},
+			true),
+		Entry("socks proxy failed", fmt.Errorf("wrapped: %w", &net.OpError{Op: "socks connect", Net: "tcp"}), true),
+		Entry("proxy authentication required", &statusError{"407 Proxy Authentication Required", 407}, true),
+		Entry("connection refused", &url.Error{Op: "Get", URL: "x", Err: &net.OpError{Op: "dial", Net: "tcp"}}, false),
+		Entry("not found", &statusError{"404 Not Found", 404}, false),
+	)
+})
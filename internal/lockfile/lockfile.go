@@ -0,0 +1,203 @@
+// Package lockfile records the exact result of a dependency resolution, so that an installation can be reproduced
+// later, e.g. in another cluster.
+package lockfile
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/dependency/graph"
+	repoclient "github.com/glasskube/glasskube/internal/repo/client"
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultPath is the name of the lockfile in the current directory
+const DefaultPath = "glasskube.lock"
+
+const currentVersion = 1
+
+const header = "# This file is generated by \"glasskube install --write-lockfile\". Do not edit it manually.\n"
+
+// Lockfile contains the resolved version of a package and all its dependencies. Every version is pinned to the
+// repository it is installed from and the digest of its manifest.
+type Lockfile struct {
+	LockfileVersion int           `json:"lockfileVersion"`
+	Package         LockedPackage `json:"package"`
+	// Dependencies are all direct and transitive dependencies of the package, sorted by name
+	Dependencies []LockedPackage `json:"dependencies,omitempty"`
+}
+
+type LockedPackage struct {
+	// Name is the name of the package manifest
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	Repository string `json:"repository,omitempty"`
+	Digest     string `json:"digest,omitempty"`
+	// Component is true if the dependency is installed as component of a package that depends on it
+	Component bool `json:"component,omitempty"`
+}
+
+func (p LockedPackage) String() string {
+	if p.Component {
+		return fmt.Sprintf("%v (component) %v", p.Name, p.Version)
+	}
+	return fmt.Sprintf("%v %v", p.Name, p.Version)
+}
+
+// Read parses the lockfile at the given path
+func Read(path string) (*Lockfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var lockfile Lockfile
+	if err := yaml.UnmarshalStrict(data, &lockfile); err != nil {
+		return nil, fmt.Errorf("invalid lockfile %v: %w", path, err)
+	}
+	if lockfile.LockfileVersion != currentVersion {
+		return nil, fmt.Errorf("unsupported lockfile version %v in %v (expected %v)",
+			lockfile.LockfileVersion, path, currentVersion)
+	}
+	return &lockfile, nil
+}
+
+// Write writes the lockfile to the given path, replacing an existing file
+func (l *Lockfile) Write(path string) error {
+	data, err := yaml.Marshal(l)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append([]byte(header), data...), 0644)
+}
+
+// Dependency returns the locked dependency with the given name, which is not installed as component, or nil
+func (l *Lockfile) Dependency(name string) *LockedPackage {
+	for i := range l.Dependencies {
+		if l.Dependencies[i].Name == name && !l.Dependencies[i].Component {
+			return &l.Dependencies[i]
+		}
+	}
+	return nil
+}
+
+// Dependencies returns all direct and transitive dependencies of the given package in g with their versions. g must
+// contain the package and all its requirements, like the graph of a dependency.ValidationResult. Repository and digest
+// of the result are empty.
+func Dependencies(g *graph.DependencyGraph, name, namespace string) ([]LockedPackage, error) {
+	var result []LockedPackage
+	visited := map[graph.PackageRef]struct{}{{Name: name, Namespace: namespace}: {}}
+	queue := []graph.PackageRef{{Name: name, Namespace: namespace}}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, dep := range g.Dependencies(current.Name, current.Namespace) {
+			key := graph.PackageRef{Name: dep.Name, Namespace: dep.Namespace}
+			if _, ok := visited[key]; ok {
+				continue
+			}
+			visited[key] = struct{}{}
+			queue = append(queue, key)
+			version := g.Version(dep.Name, dep.Namespace)
+			if version == nil {
+				return nil, fmt.Errorf("dependency %v of %v is not resolved", dep, current)
+			}
+			result = append(result, LockedPackage{
+				Name:      dep.PackageName,
+				Version:   version.Original(),
+				Component: dep.Namespace != "",
+			})
+		}
+	}
+	sortPackages(result)
+	return result, nil
+}
+
+// New creates a lockfile for the given package and its dependencies. The repository of every dependency is resolved
+// in the same way as by the operator and the digests of all manifests are fetched.
+func New(repoClientset repoclient.RepoClientset, pkg LockedPackage, dependencies []LockedPackage) (*Lockfile, error) {
+	if pkg.Repository == "" || pkg.Digest == "" {
+		return nil, fmt.Errorf("the manifest digest of %v in repository %q is unknown", pkg, pkg.Repository)
+	}
+	lockfile := Lockfile{LockfileVersion: currentVersion, Package: pkg}
+	var errs []error
+	for _, dep := range dependencies {
+		repos, err := repoClientset.Meta().GetReposForPackage(dep.Name)
+		if len(repos) == 0 {
+			errs = append(errs, fmt.Errorf("no repository found for %v: %w", dep, err))
+			continue
+		}
+		resolution, err := repoclient.ResolveRepository(dep.Name, repos)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		dep.Repository = resolution.Repository.Name
+		var manifest v1alpha1.PackageManifest
+		if dep.Digest, err = repoclient.FetchPackageManifestWithDigest(
+			repoClientset.ForRepoWithName(dep.Repository), dep.Name, dep.Version, "", &manifest); err != nil {
+			errs = append(errs, fmt.Errorf("could not fetch manifest of %v: %w", dep, err))
+			continue
+		} else if dep.Digest == "" {
+			errs = append(errs, fmt.Errorf("repository %v does not support manifest digests", dep.Repository))
+			continue
+		}
+		lockfile.Dependencies = append(lockfile.Dependencies, dep)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	sortPackages(lockfile.Dependencies)
+	return &lockfile, nil
+}
+
+// Verify returns an error if the resolved dependencies differ from the locked dependencies in any way
+func (l *Lockfile) Verify(dependencies []LockedPackage) error {
+	var errs []error
+	for _, dep := range dependencies {
+		if !slices.ContainsFunc(l.Dependencies, func(locked LockedPackage) bool {
+			return locked.Name == dep.Name && locked.Component == dep.Component && locked.Version == dep.Version
+		}) {
+			errs = append(errs, fmt.Errorf("%v is required, but not locked", dep))
+		}
+	}
+	for _, locked := range l.Dependencies {
+		if !slices.ContainsFunc(dependencies, func(dep LockedPackage) bool {
+			return locked.Name == dep.Name && locked.Component == dep.Component && locked.Version == dep.Version
+		}) {
+			errs = append(errs, fmt.Errorf("%v is locked, but not required", locked))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// VerifyDigests returns an error if the manifest of any locked dependency does not have the locked digest in its
+// locked repository anymore
+func (l *Lockfile) VerifyDigests(repoClientset repoclient.RepoClientset) error {
+	var errs []error
+	for _, dep := range l.Dependencies {
+		var manifest v1alpha1.PackageManifest
+		if _, err := repoclient.FetchPackageManifestWithDigest(
+			repoClientset.ForRepoWithName(dep.Repository), dep.Name, dep.Version, dep.Digest, &manifest); err != nil {
+			errs = append(errs, fmt.Errorf("%v: %w", dep, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sortPackages(packages []LockedPackage) {
+	slices.SortFunc(packages, func(a, b LockedPackage) int {
+		if c := cmp.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		if a.Component == b.Component {
+			return 0
+		} else if a.Component {
+			return 1
+		}
+		return -1
+	})
+}
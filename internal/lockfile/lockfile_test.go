@@ -0,0 +1,103 @@
+package lockfile
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/dependency/graph"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Lockfile", func() {
+	Describe("Dependencies", func() {
+		It("should return all transitive dependencies sorted by name", func() {
+			g := graph.NewGraph()
+			Expect(g.AddCluster(v1alpha1.PackageManifest{
+				Name:             "foo",
+				Dependencies:     []v1alpha1.Dependency{{Name: "bar"}},
+				Components:       []v1alpha1.Component{{Name: "db"}},
+				DefaultNamespace: "foo",
+			}, "v1.0.0", true)).To(Succeed())
+			Expect(g.AddCluster(v1alpha1.PackageManifest{
+				Name:         "bar",
+				Dependencies: []v1alpha1.Dependency{{Name: "baz"}},
+			}, "v2.0.0", false)).To(Succeed())
+			Expect(g.AddCluster(v1alpha1.PackageManifest{Name: "baz"}, "v3.0.0+1", false)).To(Succeed())
+			Expect(g.AddNamespaced("foo-db", "foo", v1alpha1.PackageManifest{Name: "db"}, "v4.0.0", false)).
+				To(Succeed())
+
+			Expect(Dependencies(g, "foo", "")).To(Equal([]LockedPackage{
+				{Name: "bar", Version: "v2.0.0"},
+				{Name: "baz", Version: "v3.0.0+1"},
+				{Name: "db", Version: "v4.0.0", Component: true},
+			}))
+		})
+
+		It("should return an error if a dependency is not resolved", func() {
+			g := graph.NewGraph()
+			Expect(g.AddCluster(v1alpha1.PackageManifest{
+				Name:         "foo",
+				Dependencies: []v1alpha1.Dependency{{Name: "bar"}},
+			}, "v1.0.0", true)).To(Succeed())
+			_, err := Dependencies(g, "foo", "")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Verify", func() {
+		lockfile := Lockfile{Dependencies: []LockedPackage{
+			{Name: "bar", Version: "v2.0.0", Repository: "glasskube", Digest: "sha256:1"},
+			{Name: "db", Version: "v4.0.0", Component: true, Repository: "glasskube", Digest: "sha256:2"},
+		}}
+
+		It("should succeed if the dependencies match", func() {
+			Expect(lockfile.Verify([]LockedPackage{
+				{Name: "bar", Version: "v2.0.0"},
+				{Name: "db", Version: "v4.0.0", Component: true},
+			})).To(Succeed())
+		})
+
+		It("should fail if a version differs", func() {
+			Expect(lockfile.Verify([]LockedPackage{
+				{Name: "bar", Version: "v2.0.1"},
+				{Name: "db", Version: "v4.0.0", Component: true},
+			})).NotTo(Succeed())
+		})
+
+		It("should fail if a dependency is missing or additional", func() {
+			Expect(lockfile.Verify([]LockedPackage{{Name: "bar", Version: "v2.0.0"}})).NotTo(Succeed())
+			Expect(lockfile.Verify([]LockedPackage{
+				{Name: "bar", Version: "v2.0.0"},
+				{Name: "db", Version: "v4.0.0", Component: true},
+				{Name: "baz", Version: "v3.0.0"},
+			})).NotTo(Succeed())
+		})
+
+		It("should distinguish components from cluster packages", func() {
+			Expect(lockfile.Dependency("bar")).NotTo(BeNil())
+			Expect(lockfile.Dependency("db")).To(BeNil())
+		})
+	})
+
+	Describe("Read and Write", func() {
+		It("should read what was written", func() {
+			path := filepath.Join(GinkgoT().TempDir(), DefaultPath)
+			lockfile := Lockfile{
+				LockfileVersion: currentVersion,
+				Package:         LockedPackage{Name: "foo", Version: "v1.0.0", Repository: "glasskube", Digest: "sha256:0"},
+				Dependencies:    []LockedPackage{{Name: "bar", Version: "v2.0.0", Repository: "other", Digest: "sha256:1"}},
+			}
+			Expect(lockfile.Write(path)).To(Succeed())
+			Expect(Read(path)).To(Equal(&lockfile))
+		})
+
+		It("should reject unknown lockfile versions", func() {
+			path := filepath.Join(GinkgoT().TempDir(), DefaultPath)
+			Expect(os.WriteFile(path, []byte("lockfileVersion: 99\npackage:\n  name: foo\n"), 0644)).To(Succeed())
+			_, err := Read(path)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -3,10 +3,13 @@ package flux
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
 	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	"github.com/fluxcd/pkg/apis/kustomize"
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	packagesv1alpha1 "github.com/glasskube/glasskube/api/v1alpha1"
 	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
@@ -18,16 +21,20 @@ import (
 	"github.com/glasskube/glasskube/internal/names"
 	repoclient "github.com/glasskube/glasskube/internal/repo/client"
 	"github.com/glasskube/glasskube/internal/resourcepatch"
+	"github.com/glasskube/glasskube/internal/scheduling"
 	corev1 "k8s.io/api/core/v1"
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
 )
 
 type FluxHelmAdapter struct {
@@ -168,36 +175,17 @@ func (a *FluxHelmAdapter) ensureHelmRelease(
 	manifest *packagesv1alpha1.PackageManifest,
 	patches resourcepatch.TargetPatches,
 ) (*helmv2.HelmRelease, error) {
-	var namespace string
-	if pkg.IsNamespaceScoped() {
-		namespace = pkg.GetNamespace()
-	} else {
-		namespace = manifest.DefaultNamespace
-	}
 	helmRelease := helmv2.HelmRelease{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      names.HelmResourceName(pkg, manifest),
-			Namespace: namespace,
+			Namespace: helmReleaseNamespace(pkg, manifest),
 		},
 	}
 	log := ctrl.LoggerFrom(ctx).WithValues("HelmRelease", helmRelease.Name)
 	result, err := createOrUpdateWithRetry(ctx, a.Client, &helmRelease, func() error {
-		if helmRelease.Spec.Chart == nil {
-			helmRelease.Spec.Chart = &helmv2.HelmChartTemplate{}
-		}
-		helmRelease.Spec.Chart.Spec.Chart = manifest.Helm.ChartName
-		helmRelease.Spec.Chart.Spec.Version = manifest.Helm.ChartVersion
-		helmRelease.Spec.Chart.Spec.SourceRef.Kind = "HelmRepository"
-		helmRelease.Spec.Chart.Spec.SourceRef.Name = names.HelmResourceName(pkg, manifest)
-		if manifest.Helm.Values != nil {
-			helmRelease.Spec.Values = &extv1.JSON{Raw: manifest.Helm.Values.Raw[:]}
-		} else {
-			helmRelease.Spec.Values = nil
-		}
-		if err := patches.ApplyToHelmRelease(&helmRelease); err != nil {
+		if err := setHelmReleaseSpec(&helmRelease, pkg, manifest, patches); err != nil {
 			return err
 		}
-		helmRelease.Spec.Interval = metav1.Duration{Duration: 5 * time.Minute}
 		labels.SetManaged(&helmRelease)
 		return a.SetOwner(pkg, &helmRelease, owners.BlockOwnerDeletion)
 	})
@@ -209,6 +197,103 @@ func (a *FluxHelmAdapter) ensureHelmRelease(
 	}
 }
 
+// Render returns the HelmRelease in the form ensureHelmRelease would apply it, without changing anything in the
+// cluster.
+func Render(
+	pkg ctrlpkg.Package,
+	manifest *packagesv1alpha1.PackageManifest,
+	patches resourcepatch.TargetPatches,
+) (*helmv2.HelmRelease, error) {
+	helmRelease := helmv2.HelmRelease{
+		TypeMeta: metav1.TypeMeta{APIVersion: helmv2.GroupVersion.String(), Kind: helmv2.HelmReleaseKind},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      names.HelmResourceName(pkg, manifest),
+			Namespace: helmReleaseNamespace(pkg, manifest),
+		},
+	}
+	if err := setHelmReleaseSpec(&helmRelease, pkg, manifest, patches); err != nil {
+		return nil, err
+	}
+	return &helmRelease, nil
+}
+
+func setHelmReleaseSpec(
+	helmRelease *helmv2.HelmRelease,
+	pkg ctrlpkg.Package,
+	manifest *packagesv1alpha1.PackageManifest,
+	patches resourcepatch.TargetPatches,
+) error {
+	if helmRelease.Spec.Chart == nil {
+		helmRelease.Spec.Chart = &helmv2.HelmChartTemplate{}
+	}
+	helmRelease.Spec.Chart.Spec.Chart = manifest.Helm.ChartName
+	helmRelease.Spec.Chart.Spec.Version = manifest.Helm.ChartVersion
+	helmRelease.Spec.Chart.Spec.SourceRef.Kind = "HelmRepository"
+	helmRelease.Spec.Chart.Spec.SourceRef.Name = names.HelmResourceName(pkg, manifest)
+	if manifest.Helm.Values != nil {
+		helmRelease.Spec.Values = &extv1.JSON{Raw: manifest.Helm.Values.Raw[:]}
+	} else {
+		helmRelease.Spec.Values = nil
+	}
+	if postRenderers, err := schedulingPostRenderers(pkg); err != nil {
+		return err
+	} else {
+		helmRelease.Spec.PostRenderers = postRenderers
+	}
+	if err := patches.ApplyToHelmRelease(helmRelease); err != nil {
+		return err
+	}
+	helmRelease.Spec.Interval = metav1.Duration{Duration: 5 * time.Minute}
+	return nil
+}
+
+// schedulingPostRenderers returns a post renderer that merges the scheduling overrides of the package into the pod
+// templates of all workloads of the chart, or nil if the package has no scheduling overrides
+func schedulingPostRenderers(pkg ctrlpkg.Package) ([]helmv2.PostRenderer, error) {
+	overrides := pkg.GetSpec().Scheduling
+	if scheduling.IsEmpty(overrides) {
+		return nil, nil
+	}
+	podSpecPatch, err := scheduling.PodSpecPatch(overrides)
+	if err != nil {
+		return nil, err
+	}
+	kinds := slices.Sorted(maps.Keys(scheduling.Workloads))
+	patches := make([]kustomize.Patch, 0, len(kinds))
+	for _, kind := range kinds {
+		workload := scheduling.Workloads[kind]
+		gv, err := schema.ParseGroupVersion(workload.APIVersion)
+		if err != nil {
+			return nil, err
+		}
+		// the name is required for a strategic merge patch, but it is ignored because the patch has a target
+		obj := unstructured.Unstructured{Object: map[string]any{}}
+		obj.SetAPIVersion(workload.APIVersion)
+		obj.SetKind(kind)
+		obj.SetName("scheduling-overrides")
+		if err := unstructured.SetNestedMap(obj.Object, podSpecPatch, workload.PodSpecPath...); err != nil {
+			return nil, err
+		}
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return nil, err
+		}
+		patches = append(patches, kustomize.Patch{
+			Patch:  string(data),
+			Target: &kustomize.Selector{Group: gv.Group, Version: gv.Version, Kind: kind},
+		})
+	}
+	return []helmv2.PostRenderer{{Kustomize: &helmv2.Kustomize{Patches: patches}}}, nil
+}
+
+func helmReleaseNamespace(pkg ctrlpkg.Package, manifest *packagesv1alpha1.PackageManifest) string {
+	if pkg.IsNamespaceScoped() {
+		return pkg.GetNamespace()
+	} else {
+		return manifest.DefaultNamespace
+	}
+}
+
 func createOrUpdateWithRetry(ctx context.Context, c client.Client,
 	obj client.Object, f controllerutil.MutateFn) (controllerutil.OperationResult, error) {
 	var result controllerutil.OperationResult
@@ -3,18 +3,23 @@ package plain
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	packagesv1alpha1 "github.com/glasskube/glasskube/api/v1alpha1"
+	ctrladapter "github.com/glasskube/glasskube/internal/adapter/controllerruntime"
 	"github.com/glasskube/glasskube/internal/clientutils"
 	"github.com/glasskube/glasskube/internal/constants"
 	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
 	"github.com/glasskube/glasskube/internal/controller/owners"
 	ownerutils "github.com/glasskube/glasskube/internal/controller/owners/utils"
 	"github.com/glasskube/glasskube/internal/manifest"
+	"github.com/glasskube/glasskube/internal/manifest/recreate"
 	"github.com/glasskube/glasskube/internal/manifest/result"
+	"github.com/glasskube/glasskube/internal/registrymirror"
 	repoclient "github.com/glasskube/glasskube/internal/repo/client"
 	"github.com/glasskube/glasskube/internal/resourcepatch"
+	"github.com/glasskube/glasskube/internal/scheduling"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -27,7 +32,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-var fieldOwner = client.FieldOwner("packages.glasskube.dev/package-controller")
+// FieldOwner is the field manager of all resources that are applied by the adapter
+var FieldOwner = client.FieldOwner("packages.glasskube.dev/package-controller")
 
 type Adapter struct {
 	client.Client
@@ -67,13 +73,44 @@ func (a *Adapter) Reconcile(
 	pi *packagesv1alpha1.PackageInfo,
 	patches resourcepatch.TargetPatches,
 ) (*result.ReconcileResult, error) {
-	var allOwned []packagesv1alpha1.OwnedResourceRef
+	// if a retry was requested, only the resources that failed before are applied again
+	var retryOnly []packagesv1alpha1.FailedResourceRef
+	if pkg.IsRetryRequested() {
+		retryOnly = pkg.GetStatus().FailedResources
+	}
+	var allOwned, allRecreated []packagesv1alpha1.OwnedResourceRef
+	var allFailed []packagesv1alpha1.FailedResourceRef
 	for _, manifest := range pi.Status.Manifest.Manifests {
-		if owned, err := a.reconcilePlainManifest(ctx, pkg, pi, manifest, patches); err != nil {
+		owned, failed, recreated, err := a.reconcilePlainManifest(ctx, pkg, pi, manifest, patches, retryOnly)
+		if err != nil {
 			return nil, err
-		} else {
-			allOwned = append(allOwned, owned...)
 		}
+		allOwned = append(allOwned, owned...)
+		allFailed = append(allFailed, failed...)
+		allRecreated = append(allRecreated, recreated...)
+	}
+	res, err := a.checkResult(ctx, allOwned, allFailed)
+	if res != nil {
+		res.RecreatedResources = allRecreated
+	}
+	return res, err
+}
+
+// checkResult returns a failed result if any resources failed, or whether all owned workloads are ready otherwise
+func (a *Adapter) checkResult(
+	ctx context.Context,
+	allOwned []packagesv1alpha1.OwnedResourceRef,
+	allFailed []packagesv1alpha1.FailedResourceRef,
+) (*result.ReconcileResult, error) {
+
+	if len(allFailed) > 0 {
+		failedNames := make([]string, len(allFailed))
+		for i, failed := range allFailed {
+			failedNames[i] = fmt.Sprintf("%v %v", failed.Kind, types.NamespacedName{
+				Namespace: failed.Namespace, Name: failed.Name})
+		}
+		return result.PartiallyFailed(fmt.Sprintf("%v of %v resources could not be applied: %v",
+			len(allFailed), len(allFailed)+len(allOwned), strings.Join(failedNames, ",")), allOwned, allFailed), nil
 	}
 
 	var notReady []packagesv1alpha1.OwnedResourceRef
@@ -121,7 +158,130 @@ func (r *Adapter) reconcilePlainManifest(
 	pi *packagesv1alpha1.PackageInfo,
 	manifest packagesv1alpha1.PlainManifest,
 	patches resourcepatch.TargetPatches,
-) ([]packagesv1alpha1.OwnedResourceRef, error) {
+	retryOnly []packagesv1alpha1.FailedResourceRef,
+) (
+	owned []packagesv1alpha1.OwnedResourceRef,
+	failed []packagesv1alpha1.FailedResourceRef,
+	recreated []packagesv1alpha1.OwnedResourceRef,
+	err error,
+) {
+	log := ctrl.LoggerFrom(ctx)
+	objectsToApply, err := r.fetchManifest(ctx, pkg, pi, manifest)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	specHash, specHashErr := pkg.GetSpec().Hashed()
+	if specHashErr != nil {
+		log.Error(specHashErr, "failed to get spec hash for package – restarts might not happen", "package", pkg)
+	}
+
+	// TODO: check if namespace is terminating before applying
+	// Apply any modifications before changing anything on the cluster
+	for _, obj := range objectsToApply {
+		if specHashErr == nil {
+			if err := r.annotateWithSpecHash(obj, specHash); err != nil {
+				log.Error(err, "could not annotate object with spec hash", "package", pkg, "object", obj)
+			}
+		}
+		if err := r.SetOwnerIfManagedOrNotExists(r.Client, ctx, pkg, obj); err != nil {
+			return nil, nil, nil, err
+		}
+		if err := patches.ApplyToResource(obj); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	if err := r.rewriteImages(ctx, pkg, objectsToApply); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := applyScheduling(pkg, objectsToApply); err != nil {
+		return nil, nil, nil, err
+	}
+
+	if objs, err := prefixAndUpdateReferences(pkg, pi.Status.Manifest, objectsToApply); err != nil {
+		return nil, nil, nil, err
+	} else {
+		objectsToApply = objs
+	}
+
+	// All resources are applied, even if some of them fail, e.g. because they are rejected by an admission webhook.
+	// Server-side apply is idempotent, so that applying the failed resources again later is safe.
+	owned = make([]packagesv1alpha1.OwnedResourceRef, 0, len(objectsToApply))
+	for _, obj := range objectsToApply {
+		ref, err := ownerutils.ToOwnedResourceRef(r.Scheme(), obj)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if len(retryOnly) > 0 && !containsResource(retryOnly, ref) {
+			// the resource was applied successfully before, so it is still owned by the package
+			ownerutils.Add(&owned, ref)
+			continue
+		}
+		err = r.Patch(ctx, obj, client.Apply, FieldOwner, client.ForceOwnership)
+		if recreate.IsImmutableFieldError(err) && pkg.IsRecreateRequested() &&
+			recreate.CanRecreate(obj.GetObjectKind().GroupVersionKind()) {
+			if err = r.recreate(ctx, obj); err == nil {
+				recreated = append(recreated, ref)
+			}
+		}
+		if err != nil {
+			log.Error(err, "could not apply resource",
+				"kind", obj.GetObjectKind().GroupVersionKind(), "namespace", obj.GetNamespace(), "name", obj.GetName())
+			failed = append(failed, packagesv1alpha1.FailedResourceRef{
+				GroupVersionKind: ref.GroupVersionKind,
+				Name:             ref.Name,
+				Namespace:        ref.Namespace,
+				Message:          err.Error(),
+				Immutable:        recreate.IsImmutableFieldError(err),
+			})
+			continue
+		}
+		log.V(1).Info("applied resource",
+			"kind", obj.GetObjectKind().GroupVersionKind(), "namespace", obj.GetNamespace(), "name", obj.GetName())
+		if err := r.releaseRetained(ctx, obj); err != nil {
+			log.Error(err, "could not remove retained annotation", "namespace", obj.GetNamespace(), "name", obj.GetName())
+		}
+		ownerutils.Add(&owned, ref)
+	}
+	return owned, failed, recreated, nil
+}
+
+// recreate deletes obj, which can not be applied because an immutable field changed, and applies it again
+func (r *Adapter) recreate(ctx context.Context, obj client.Object) error {
+	ctrl.LoggerFrom(ctx).Info("recreating resource with immutable changes",
+		"kind", obj.GetObjectKind().GroupVersionKind(), "namespace", obj.GetNamespace(), "name", obj.GetName())
+	if err := recreate.Delete(ctx, r.Client, obj); err != nil {
+		return fmt.Errorf("could not recreate resource: %w", err)
+	}
+	return r.Patch(ctx, obj, client.Apply, FieldOwner, client.ForceOwnership)
+}
+
+func containsResource(refs []packagesv1alpha1.FailedResourceRef, ref packagesv1alpha1.OwnedResourceRef) bool {
+	return slices.ContainsFunc(refs, func(failed packagesv1alpha1.FailedResourceRef) bool {
+		return failed.GroupVersionKind == ref.GroupVersionKind && failed.Name == ref.Name &&
+			failed.Namespace == ref.Namespace
+	})
+}
+
+// releaseRetained removes the retained annotation from an object that was kept when its package was uninstalled and
+// has now been adopted again
+func (r *Adapter) releaseRetained(ctx context.Context, obj client.Object) error {
+	if _, ok := obj.GetAnnotations()[packagesv1alpha1.AnnotationRetainedFrom]; !ok {
+		return nil
+	}
+	ctrl.LoggerFrom(ctx).Info("adopted retained resource", "namespace", obj.GetNamespace(), "name", obj.GetName())
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:null}}}`, packagesv1alpha1.AnnotationRetainedFrom)
+	return r.Patch(ctx, obj, client.RawPatch(types.MergePatchType, []byte(patch)))
+}
+
+// fetchManifest fetches the resources of the given manifest and sets the namespace of all namespaced resources.
+// If a default namespace is used, the namespace resource is prepended to the result, unless it is already contained.
+func (r *Adapter) fetchManifest(
+	ctx context.Context,
+	pkg ctrlpkg.Package,
+	pi *packagesv1alpha1.PackageInfo,
+	manifest packagesv1alpha1.PlainManifest,
+) ([]client.Object, error) {
 	log := ctrl.LoggerFrom(ctx)
 	var objectsToApply []client.Object
 	if request, err := r.newManifestRequest(pi, manifest.Url); err != nil {
@@ -193,46 +353,34 @@ func (r *Adapter) reconcilePlainManifest(
 			}
 		}
 	}
+	return objectsToApply, nil
+}
 
-	specHash, specHashErr := pkg.GetSpec().Hashed()
-	if specHashErr != nil {
-		log.Error(specHashErr, "failed to get spec hash for package – restarts might not happen", "package", pkg)
+// rewriteImages replaces the registry of all container images with the mirrors configured for the package and the
+// mirrors configured for the whole cluster.
+func (r *Adapter) rewriteImages(ctx context.Context, pkg ctrlpkg.Package, objects []client.Object) error {
+	globalMirrors, err := registrymirror.Load(ctx, ctrladapter.NewKubernetesClientAdapter(r.Client))
+	if err != nil {
+		return fmt.Errorf("could not load registry mirrors: %w", err)
 	}
-
-	// TODO: check if namespace is terminating before applying
-	// Apply any modifications before changing anything on the cluster
-	for _, obj := range objectsToApply {
-		if specHashErr == nil {
-			if err := r.annotateWithSpecHash(obj, specHash); err != nil {
-				log.Error(err, "could not annotate object with spec hash", "package", pkg, "object", obj)
-			}
-		}
-		if err := r.SetOwnerIfManagedOrNotExists(r.Client, ctx, pkg, obj); err != nil {
-			return nil, err
-		}
-		if err := patches.ApplyToResource(obj); err != nil {
-			return nil, err
+	for _, obj := range objects {
+		if unstructuredObj, ok := obj.(*unstructured.Unstructured); ok {
+			registrymirror.RewriteObject(unstructuredObj, pkg.GetSpec().ImageRegistryMirrors, globalMirrors)
 		}
 	}
+	return nil
+}
 
-	if objs, err := prefixAndUpdateReferences(pkg, pi.Status.Manifest, objectsToApply); err != nil {
-		return nil, err
-	} else {
-		objectsToApply = objs
-	}
-
-	ownedResources := make([]packagesv1alpha1.OwnedResourceRef, 0, len(objectsToApply))
-	for _, obj := range objectsToApply {
-		if err := r.Patch(ctx, obj, client.Apply, fieldOwner, client.ForceOwnership); err != nil {
-			return nil, fmt.Errorf("could not apply resource: %w", err)
-		}
-		log.V(1).Info("applied resource",
-			"kind", obj.GetObjectKind().GroupVersionKind(), "namespace", obj.GetNamespace(), "name", obj.GetName())
-		if _, err := ownerutils.AddOwnedResourceRef(r.Scheme(), &ownedResources, obj); err != nil {
-			return nil, err
+// applyScheduling merges the scheduling overrides of the package into the pod templates of all workloads
+func applyScheduling(pkg ctrlpkg.Package, objects []client.Object) error {
+	for _, obj := range objects {
+		if unstructuredObj, ok := obj.(*unstructured.Unstructured); ok {
+			if _, err := scheduling.ApplyToObject(unstructuredObj, pkg.GetSpec().Scheduling); err != nil {
+				return err
+			}
 		}
 	}
-	return ownedResources, nil
+	return nil
 }
 
 // if the obj kind is Deployment or StatefulSet annotateWithSpecHash sets the AnnotationPackageSpecHashed annotation of the
@@ -0,0 +1,108 @@
+package plain
+
+import (
+	"context"
+	"fmt"
+
+	packagesv1alpha1 "github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	repoclient "github.com/glasskube/glasskube/internal/repo/client"
+	"github.com/glasskube/glasskube/internal/resourcepatch"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewRenderer returns an Adapter that is not registered with a controller and can only be used to Render manifests.
+func NewRenderer(client client.Client, repo repoclient.RepoClientset) (*Adapter, error) {
+	a := &Adapter{}
+	if err := a.ControllerInit(nil, client, repo, client.Scheme()); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Render returns the resources of all plain manifests of the given PackageInfo in the form Reconcile would apply them.
+// Owner references and the spec hash annotation are omitted and nothing is changed in the cluster.
+func (r *Adapter) Render(
+	ctx context.Context,
+	pkg ctrlpkg.Package,
+	pi *packagesv1alpha1.PackageInfo,
+	patches resourcepatch.TargetPatches,
+) ([]client.Object, error) {
+	var result []client.Object
+	for _, manifest := range pi.Status.Manifest.Manifests {
+		objects, err := r.fetchManifest(ctx, pkg, pi, manifest)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range objects {
+			if err := patches.ApplyToResource(obj); err != nil {
+				return nil, err
+			}
+		}
+		if err := r.rewriteImages(ctx, pkg, objects); err != nil {
+			return nil, err
+		}
+		if objects, err := prefixAndUpdateReferences(pkg, pi.Status.Manifest, objects); err != nil {
+			return nil, err
+		} else {
+			result = append(result, objects...)
+		}
+	}
+	return result, nil
+}
+
+// RenderHook returns the Job of the given hook in the form it should be created, and the namespace that has to exist
+// before the Job can be created, if the hook uses the default namespace of the package. The namespace is nil
+// otherwise. Like in Render, owner references are omitted and nothing is changed in the cluster.
+func (r *Adapter) RenderHook(
+	ctx context.Context,
+	pkg ctrlpkg.Package,
+	pi *packagesv1alpha1.PackageInfo,
+	hook packagesv1alpha1.PackageHook,
+	patches resourcepatch.TargetPatches,
+) (job *unstructured.Unstructured, namespace client.Object, err error) {
+	objects, err := r.fetchManifest(ctx, pkg, pi, packagesv1alpha1.PlainManifest{Url: hook.Url})
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, obj := range objects {
+		if err := patches.ApplyToResource(obj); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := r.rewriteImages(ctx, pkg, objects); err != nil {
+		return nil, nil, err
+	}
+	if err := applyScheduling(pkg, objects); err != nil {
+		return nil, nil, err
+	}
+	if objects, err = prefixAndUpdateReferences(pkg, pi.Status.Manifest, objects); err != nil {
+		return nil, nil, err
+	}
+
+	for _, obj := range objects {
+		gvk := obj.GetObjectKind().GroupVersionKind()
+		switch {
+		case gvk == r.namespaceGVK && namespace == nil:
+			namespace = obj
+		case gvk.Group == batchv1.GroupName && gvk.Kind == "Job" && job == nil:
+			if u, ok := obj.(*unstructured.Unstructured); ok {
+				job = u
+			}
+		default:
+			return nil, nil, fmt.Errorf("hook %v must contain exactly one Job, but contains %v %v",
+				hook.Name, gvk.Kind, obj.GetName())
+		}
+	}
+	if job == nil {
+		return nil, nil, fmt.Errorf("hook %v must contain exactly one Job", hook.Name)
+	} else if job.GetNamespace() == "" {
+		return nil, nil, fmt.Errorf("the Job of hook %v has no namespace and the package has no default namespace",
+			hook.Name)
+	} else if namespace != nil && namespace.GetName() != job.GetNamespace() {
+		return nil, nil, fmt.Errorf("hook %v must not contain a Namespace", hook.Name)
+	}
+	return job, namespace, nil
+}
@@ -0,0 +1,118 @@
+// Package recreate handles resources of packages that can not be applied, because a changed value maps to an
+// immutable field, e.g. the volumeClaimTemplates of a StatefulSet. Such resources can only be changed by deleting
+// and applying them again.
+package recreate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DeletionTimeout is the time Delete waits for a resource to be removed by the garbage collector
+var DeletionTimeout = 30 * time.Second
+
+// immutableMessages are parts of the messages of the API server for changes of immutable fields
+var immutableMessages = []string{
+	"field is immutable",
+	// StatefulSets only allow changes of a few fields, e.g. not of the volumeClaimTemplates
+	"updates to statefulset spec for fields other than",
+	// PersistentVolumeClaims
+	"spec is immutable",
+}
+
+// IsImmutableFieldError returns true if err was returned by the API server, because an update changes an immutable
+// field of a resource
+func IsImmutableFieldError(err error) bool {
+	if !apierrors.IsInvalid(err) {
+		return false
+	}
+	message := err.Error()
+	for _, immutable := range immutableMessages {
+		if strings.Contains(message, immutable) {
+			return true
+		}
+	}
+	return false
+}
+
+// CanRecreate returns false for resources that must never be deleted automatically, because their data would be lost
+func CanRecreate(gvk schema.GroupVersionKind) bool {
+	gk := gvk.GroupKind()
+	return gk != corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim").GroupKind() &&
+		gk != corev1.SchemeGroupVersion.WithKind("PersistentVolume").GroupKind()
+}
+
+// propagationPolicy returns how the dependents of a recreated resource are deleted. The pods of a StatefulSet are
+// orphaned, so that they keep running with their volumes until they are adopted by the new StatefulSet, like with
+// "kubectl delete --cascade=orphan". All other dependents are deleted in the background.
+func propagationPolicy(gvk schema.GroupVersionKind) metav1.DeletionPropagation {
+	if gvk.GroupKind() == appsv1.SchemeGroupVersion.WithKind("StatefulSet").GroupKind() {
+		return metav1.DeletePropagationOrphan
+	}
+	return metav1.DeletePropagationBackground
+}
+
+// Delete deletes obj so that it can be applied again, and waits until it is gone. It returns an error for resources
+// that can not be recreated, see CanRecreate.
+func Delete(ctx context.Context, c client.Client, obj client.Object) error {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if !CanRecreate(gvk) {
+		return fmt.Errorf("%v %v can not be recreated, because its data would be lost", gvk.Kind, obj.GetName())
+	}
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(gvk)
+	existing.SetNamespace(obj.GetNamespace())
+	existing.SetName(obj.GetName())
+	err := c.Delete(ctx, existing, client.PropagationPolicy(propagationPolicy(gvk)))
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return wait.PollUntilContextTimeout(ctx, 500*time.Millisecond, DeletionTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			err := c.Get(ctx, client.ObjectKeyFromObject(existing), existing)
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		})
+}
+
+// FindImmutableChanges applies objects with a server-side dry-run and returns those that can not be applied, because
+// they change an immutable field of the existing resource. Other errors of the dry-run are ignored, because they are
+// reported when the package is reconciled.
+func FindImmutableChanges(
+	ctx context.Context,
+	c client.Client,
+	objects []*unstructured.Unstructured,
+	fieldOwner client.FieldOwner,
+) []v1alpha1.FailedResourceRef {
+	var result []v1alpha1.FailedResourceRef
+	for _, obj := range objects {
+		err := c.Patch(ctx, obj.DeepCopy(), client.Apply, fieldOwner, client.ForceOwnership, client.DryRunAll)
+		if IsImmutableFieldError(err) {
+			gvk := obj.GroupVersionKind()
+			result = append(result, v1alpha1.FailedResourceRef{
+				GroupVersionKind: metav1.GroupVersionKind{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind},
+				Name:             obj.GetName(),
+				Namespace:        obj.GetNamespace(),
+				Message:          err.Error(),
+				Immutable:        true,
+			})
+		}
+	}
+	return result
+}
@@ -0,0 +1,13 @@
+package recreate
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestRecreate(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Recreate Suite")
+}
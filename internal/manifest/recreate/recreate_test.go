@@ -0,0 +1,45 @@
+package recreate
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("recreate", func() {
+	statefulSet := appsv1.SchemeGroupVersion.WithKind("StatefulSet")
+	invalid := func(message string) error {
+		return apierrors.NewInvalid(statefulSet.GroupKind(), "postgres", field.ErrorList{
+			field.Forbidden(field.NewPath("spec"), message),
+		})
+	}
+
+	It("should detect changes of immutable fields", func() {
+		Expect(IsImmutableFieldError(invalid("updates to statefulset spec for fields other than 'replicas', " +
+			"'template' are forbidden"))).To(BeTrue())
+		Expect(IsImmutableFieldError(apierrors.NewInvalid(statefulSet.GroupKind(), "postgres", field.ErrorList{
+			field.Invalid(field.NewPath("spec", "selector"), nil, "field is immutable"),
+		}))).To(BeTrue())
+		Expect(IsImmutableFieldError(invalid("must be greater than 0"))).To(BeFalse())
+		Expect(IsImmutableFieldError(errors.New("field is immutable"))).To(BeFalse())
+		Expect(IsImmutableFieldError(nil)).To(BeFalse())
+	})
+
+	It("should never recreate volumes", func() {
+		Expect(CanRecreate(statefulSet)).To(BeTrue())
+		Expect(CanRecreate(corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim"))).To(BeFalse())
+		Expect(CanRecreate(corev1.SchemeGroupVersion.WithKind("PersistentVolume"))).To(BeFalse())
+	})
+
+	It("should orphan the pods of StatefulSets", func() {
+		Expect(propagationPolicy(statefulSet)).To(Equal(metav1.DeletePropagationOrphan))
+		Expect(propagationPolicy(appsv1.SchemeGroupVersion.WithKind("Deployment"))).
+			To(Equal(metav1.DeletePropagationBackground))
+	})
+})
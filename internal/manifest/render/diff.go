@@ -0,0 +1,219 @@
+package render
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+type ChangeType string
+
+const (
+	ChangeAdded   ChangeType = "added"
+	ChangeRemoved ChangeType = "removed"
+	ChangeChanged ChangeType = "changed"
+)
+
+// ResourceDiff describes how a single resource differs between two renderings of a package
+type ResourceDiff struct {
+	schema.GroupKind
+	Namespace string
+	Name      string
+	Change    ChangeType
+	// Highlights summarizes changes of container images, replicas and helm chart versions
+	Highlights []string
+	// Diff is a unified diff of the YAML representation of the resource
+	Diff string
+}
+
+func (d ResourceDiff) String() string {
+	if d.Namespace != "" {
+		return fmt.Sprintf("%v %v/%v", d.Kind, d.Namespace, d.Name)
+	}
+	return fmt.Sprintf("%v %v", d.Kind, d.Name)
+}
+
+type resourceKey struct {
+	schema.GroupKind
+	namespace, name string
+}
+
+func keyOf(obj *unstructured.Unstructured) resourceKey {
+	return resourceKey{
+		GroupKind: obj.GroupVersionKind().GroupKind(),
+		namespace: obj.GetNamespace(),
+		name:      obj.GetName(),
+	}
+}
+
+// Diff compares the current and desired resources and returns a ResourceDiff for every resource that is added,
+// removed or changed, sorted by kind, namespace and name. Resources are matched by group, kind, namespace and name.
+func Diff(current, desired []*unstructured.Unstructured) ([]ResourceDiff, error) {
+	currentByKey := make(map[resourceKey]*unstructured.Unstructured, len(current))
+	for _, obj := range current {
+		currentByKey[keyOf(obj)] = obj
+	}
+	desiredByKey := make(map[resourceKey]*unstructured.Unstructured, len(desired))
+	for _, obj := range desired {
+		desiredByKey[keyOf(obj)] = obj
+	}
+
+	var result []ResourceDiff
+	for key, obj := range currentByKey {
+		if _, ok := desiredByKey[key]; !ok {
+			if diff, err := diffResource(key, obj, nil); err != nil {
+				return nil, err
+			} else {
+				result = append(result, *diff)
+			}
+		}
+	}
+	for key, obj := range desiredByKey {
+		if diff, err := diffResource(key, currentByKey[key], obj); err != nil {
+			return nil, err
+		} else if diff != nil {
+			result = append(result, *diff)
+		}
+	}
+
+	slices.SortFunc(result, func(a, b ResourceDiff) int {
+		return cmp.Or(
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.Group, b.Group),
+			cmp.Compare(a.Namespace, b.Namespace),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+	return result, nil
+}
+
+// diffResource returns the diff between current and desired, where either of them may be nil.
+// If both are equal, nil is returned.
+func diffResource(key resourceKey, current, desired *unstructured.Unstructured) (*ResourceDiff, error) {
+	currentYaml, err := toYaml(current)
+	if err != nil {
+		return nil, err
+	}
+	desiredYaml, err := toYaml(desired)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil && desired != nil && currentYaml == desiredYaml {
+		return nil, nil
+	}
+
+	diff := ResourceDiff{GroupKind: key.GroupKind, Namespace: key.namespace, Name: key.name}
+	switch {
+	case current == nil:
+		diff.Change = ChangeAdded
+	case desired == nil:
+		diff.Change = ChangeRemoved
+	default:
+		diff.Change = ChangeChanged
+	}
+	diff.Highlights = highlights(current, desired)
+	diff.Diff, err = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(currentYaml),
+		B:        difflib.SplitLines(desiredYaml),
+		FromFile: "current",
+		ToFile:   "desired",
+		Context:  3,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &diff, nil
+}
+
+func toYaml(obj *unstructured.Unstructured) (string, error) {
+	if obj == nil {
+		return "", nil
+	}
+	data, err := yaml.Marshal(obj.Object)
+	return string(data), err
+}
+
+// podSpecPaths are the paths of pod specs in commonly used workload resources
+var podSpecPaths = [][]string{
+	{"spec"},
+	{"spec", "template", "spec"},
+	{"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+// containerImages returns the images of all containers and init containers of obj, keyed by container name
+func containerImages(obj *unstructured.Unstructured) map[string]string {
+	images := make(map[string]string)
+	if obj == nil {
+		return images
+	}
+	for _, path := range podSpecPaths {
+		for _, field := range []string{"initContainers", "containers"} {
+			containers, _, _ := unstructured.NestedSlice(obj.Object, append(slices.Clone(path), field)...)
+			for _, c := range containers {
+				if container, ok := c.(map[string]any); ok {
+					name, _, _ := unstructured.NestedString(container, "name")
+					image, _, _ := unstructured.NestedString(container, "image")
+					if name != "" && image != "" {
+						images[name] = image
+					}
+				}
+			}
+		}
+	}
+	return images
+}
+
+func nestedString(obj *unstructured.Unstructured, fields ...string) string {
+	if obj == nil {
+		return ""
+	}
+	if value, found, err := unstructured.NestedFieldNoCopy(obj.Object, fields...); err == nil && found {
+		return fmt.Sprint(value)
+	}
+	return ""
+}
+
+// highlights returns a summary of the changes operators care about the most: container images, replicas and helm
+// chart versions
+func highlights(current, desired *unstructured.Unstructured) []string {
+	var result []string
+	change := func(what, before, after string) {
+		if before != after {
+			result = append(result, fmt.Sprintf("%v: %v -> %v", what, orNone(before), orNone(after)))
+		}
+	}
+
+	currentImages := containerImages(current)
+	desiredImages := containerImages(desired)
+	containerNames := make([]string, 0, len(currentImages)+len(desiredImages))
+	for name := range currentImages {
+		containerNames = append(containerNames, name)
+	}
+	for name := range desiredImages {
+		if _, ok := currentImages[name]; !ok {
+			containerNames = append(containerNames, name)
+		}
+	}
+	slices.Sort(containerNames)
+	for _, name := range containerNames {
+		change(fmt.Sprintf("image of container %v", name), currentImages[name], desiredImages[name])
+	}
+
+	change("replicas", nestedString(current, "spec", "replicas"), nestedString(desired, "spec", "replicas"))
+	change("chart version",
+		nestedString(current, "spec", "chart", "spec", "version"),
+		nestedString(desired, "spec", "chart", "spec", "version"))
+	return result
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
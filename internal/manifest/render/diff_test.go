@@ -0,0 +1,72 @@
+package render
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func deployment(name, image string, replicas int64) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": name, "namespace": "test"},
+		"spec": map[string]any{
+			"replicas": replicas,
+			"template": map[string]any{
+				"spec": map[string]any{
+					"containers": []any{map[string]any{"name": "app", "image": image}},
+				},
+			},
+		},
+	}}
+}
+
+func service(name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata":   map[string]any{"name": name, "namespace": "test"},
+	}}
+}
+
+var _ = Describe("Diff", func() {
+	It("should return nothing for equal resources", func() {
+		diffs, err := Diff(
+			[]*unstructured.Unstructured{deployment("a", "app:v1", 1), service("a")},
+			[]*unstructured.Unstructured{service("a"), deployment("a", "app:v1", 1)},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(BeEmpty())
+	})
+
+	It("should highlight image and replica changes", func() {
+		diffs, err := Diff(
+			[]*unstructured.Unstructured{deployment("a", "app:v1", 1)},
+			[]*unstructured.Unstructured{deployment("a", "app:v2", 3)},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(HaveLen(1))
+		Expect(diffs[0].Change).To(Equal(ChangeChanged))
+		Expect(diffs[0].String()).To(Equal("Deployment test/a"))
+		Expect(diffs[0].Highlights).To(Equal([]string{
+			"image of container app: app:v1 -> app:v2",
+			"replicas: 1 -> 3",
+		}))
+		Expect(diffs[0].Diff).To(ContainSubstring("-      - image: app:v1\n"))
+		Expect(diffs[0].Diff).To(ContainSubstring("+      - image: app:v2\n"))
+	})
+
+	It("should detect added and removed resources", func() {
+		diffs, err := Diff(
+			[]*unstructured.Unstructured{service("old")},
+			[]*unstructured.Unstructured{service("new")},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(HaveLen(2))
+		Expect(diffs[0].Name).To(Equal("new"))
+		Expect(diffs[0].Change).To(Equal(ChangeAdded))
+		Expect(diffs[1].Name).To(Equal("old"))
+		Expect(diffs[1].Change).To(Equal(ChangeRemoved))
+	})
+})
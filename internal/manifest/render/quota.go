@@ -0,0 +1,157 @@
+package render
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// QuotaUsage is the usage of a resource that is limited by a ResourceQuota, as it would be after the requested
+// resources have been added
+type QuotaUsage struct {
+	Namespace string
+	Quota     string
+	Resource  corev1.ResourceName
+	Hard      resource.Quantity
+	Used      resource.Quantity
+	Requested resource.Quantity
+}
+
+// Projected returns the usage after the requested resources have been added
+func (u QuotaUsage) Projected() resource.Quantity {
+	projected := u.Used.DeepCopy()
+	projected.Add(u.Requested)
+	return projected
+}
+
+// Exceeded returns true if the projected usage is larger than the hard limit of the quota
+func (u QuotaUsage) Exceeded() bool {
+	projected := u.Projected()
+	return projected.Cmp(u.Hard) > 0
+}
+
+// Utilization returns the projected usage in percent of the hard limit of the quota
+func (u QuotaUsage) Utilization() int {
+	projected := u.Projected()
+	if u.Hard.IsZero() {
+		if projected.IsZero() {
+			return 0
+		}
+		return 100
+	}
+	return int(float64(projected.MilliValue()) * 100 / float64(u.Hard.MilliValue()))
+}
+
+// Exceeded returns the usages whose hard limit would be exceeded
+func Exceeded(usages []QuotaUsage) []QuotaUsage {
+	var result []QuotaUsage
+	for _, usage := range usages {
+		if usage.Exceeded() {
+			result = append(result, usage)
+		}
+	}
+	return result
+}
+
+// ProjectQuotaUsage fetches the ResourceQuotas of all namespaces the given objects are created in and returns the
+// usage of every limited resource after the objects have been created (see ProjectQuotaUsageOf).
+func (r *Renderer) ProjectQuotaUsage(ctx context.Context, objects []*unstructured.Unstructured) ([]QuotaUsage, error) {
+	var quotas []corev1.ResourceQuota
+	for namespace := range objectsByNamespace(objects) {
+		var list corev1.ResourceQuotaList
+		if err := r.client.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+			return nil, err
+		}
+		quotas = append(quotas, list.Items...)
+	}
+	return ProjectQuotaUsageOf(objects, quotas)
+}
+
+// ProjectQuotaUsageOf aggregates the resources of the given objects per namespace, like SummarizeResources, and adds
+// them to the current usage of every resource that is limited by one of the given quotas.
+// Quotas with scopes are ignored, because it can not be determined which pods they apply to before they exist.
+func ProjectQuotaUsageOf(objects []*unstructured.Unstructured, quotas []corev1.ResourceQuota) ([]QuotaUsage, error) {
+	summaries := make(map[string]*ResourceSummary)
+	for namespace, namespaceObjects := range objectsByNamespace(objects) {
+		if summary, err := SummarizeResources(namespaceObjects); err != nil {
+			return nil, err
+		} else {
+			summaries[namespace] = summary
+		}
+	}
+	var result []QuotaUsage
+	for _, quota := range quotas {
+		summary, ok := summaries[quota.Namespace]
+		if !ok || len(quota.Spec.Scopes) > 0 || quota.Spec.ScopeSelector != nil {
+			continue
+		}
+		for name, hard := range quota.Spec.Hard {
+			if requested, ok := requestedQuantity(summary, name); ok {
+				result = append(result, QuotaUsage{
+					Namespace: quota.Namespace,
+					Quota:     quota.Name,
+					Resource:  name,
+					Hard:      hard,
+					Used:      quota.Status.Used[name],
+					Requested: requested,
+				})
+			}
+		}
+	}
+	slices.SortFunc(result, func(a, b QuotaUsage) int {
+		return cmp.Or(
+			cmp.Compare(a.Namespace, b.Namespace),
+			cmp.Compare(a.Quota, b.Quota),
+			cmp.Compare(a.Resource, b.Resource),
+		)
+	})
+	return result, nil
+}
+
+// requestedQuantity returns the part of the summary that counts towards the given resource of a quota, or false if
+// the resource is not summarized
+func requestedQuantity(summary *ResourceSummary, name corev1.ResourceName) (resource.Quantity, bool) {
+	switch name {
+	case corev1.ResourceCPU, corev1.ResourceRequestsCPU:
+		return summary.Requests[corev1.ResourceCPU], true
+	case corev1.ResourceMemory, corev1.ResourceRequestsMemory:
+		return summary.Requests[corev1.ResourceMemory], true
+	case corev1.ResourceLimitsCPU:
+		return summary.Limits[corev1.ResourceCPU], true
+	case corev1.ResourceLimitsMemory:
+		return summary.Limits[corev1.ResourceMemory], true
+	case corev1.ResourceRequestsStorage:
+		return summary.Storage, true
+	case corev1.ResourcePods:
+		var pods int64
+		for _, workload := range summary.Workloads {
+			pods += int64(workload.Replicas)
+		}
+		return *resource.NewQuantity(pods, resource.DecimalSI), true
+	case corev1.ResourcePersistentVolumeClaims:
+		var claims int64
+		for _, volume := range summary.Volumes {
+			claims += int64(volume.Count)
+		}
+		return *resource.NewQuantity(claims, resource.DecimalSI), true
+	default:
+		return resource.Quantity{}, false
+	}
+}
+
+// objectsByNamespace groups the namespaced objects by their namespace. Cluster-scoped objects are omitted, because
+// they do not count towards any quota.
+func objectsByNamespace(objects []*unstructured.Unstructured) map[string][]*unstructured.Unstructured {
+	result := make(map[string][]*unstructured.Unstructured)
+	for _, obj := range objects {
+		if namespace := obj.GetNamespace(); namespace != "" {
+			result[namespace] = append(result[namespace], obj)
+		}
+	}
+	return result
+}
@@ -0,0 +1,75 @@
+package render
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+)
+
+func quota(namespace string, hard, used corev1.ResourceList) corev1.ResourceQuota {
+	return corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: namespace},
+		Spec:       corev1.ResourceQuotaSpec{Hard: hard},
+		Status:     corev1.ResourceQuotaStatus{Hard: hard, Used: used},
+	}
+}
+
+var _ = Describe("ProjectQuotaUsageOf", func() {
+	objects := []*unstructured.Unstructured{
+		mustUnstructured(&appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "app"},
+			Spec: appsv1.DeploymentSpec{Replicas: ptr.To[int32](2), Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{container("500m", "256Mi", true)}},
+			}},
+		}),
+	}
+
+	It("should add the requested resources to the used resources", func() {
+		usages, err := ProjectQuotaUsageOf(objects, []corev1.ResourceQuota{quota("app",
+			corev1.ResourceList{
+				corev1.ResourceRequestsCPU:    resource.MustParse("2"),
+				corev1.ResourceLimitsMemory:   resource.MustParse("1Gi"),
+				corev1.ResourcePods:           resource.MustParse("10"),
+				corev1.ResourceServices:       resource.MustParse("5"),
+				corev1.ResourceRequestsMemory: resource.MustParse("1Gi"),
+			},
+			corev1.ResourceList{
+				corev1.ResourceRequestsCPU:    resource.MustParse("1500m"),
+				corev1.ResourceLimitsMemory:   resource.MustParse("256Mi"),
+				corev1.ResourcePods:           resource.MustParse("3"),
+				corev1.ResourceRequestsMemory: resource.MustParse("256Mi"),
+			},
+		)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(usages).To(HaveLen(4))
+		Expect(usages[0].Resource).To(Equal(corev1.ResourceLimitsMemory))
+		Expect(usages[1].Resource).To(Equal(corev1.ResourcePods))
+		Expect(usages[1].Requested.Value()).To(Equal(int64(2)))
+		Expect(usages[1].Exceeded()).To(BeFalse())
+		Expect(usages[1].Utilization()).To(Equal(50))
+		Expect(usages[2].Resource).To(Equal(corev1.ResourceRequestsCPU))
+		Expect(usages[2].Requested.Cmp(resource.MustParse("1"))).To(BeZero())
+		Expect(usages[2].Exceeded()).To(BeTrue())
+		Expect(usages[2].Utilization()).To(Equal(125))
+		Expect(usages[3].Resource).To(Equal(corev1.ResourceRequestsMemory))
+		Expect(usages[3].Exceeded()).To(BeFalse())
+		Expect(Exceeded(usages)).To(ConsistOf(usages[2]))
+	})
+
+	It("should ignore quotas of other namespaces and quotas with scopes", func() {
+		scoped := quota("app", corev1.ResourceList{corev1.ResourcePods: resource.MustParse("1")}, nil)
+		scoped.Spec.Scopes = []corev1.ResourceQuotaScope{corev1.ResourceQuotaScopeBestEffort}
+		usages, err := ProjectQuotaUsageOf(objects, []corev1.ResourceQuota{
+			quota("other", corev1.ResourceList{corev1.ResourcePods: resource.MustParse("1")}, nil),
+			scoped,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(usages).To(BeEmpty())
+	})
+})
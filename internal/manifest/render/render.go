@@ -0,0 +1,134 @@
+package render
+
+import (
+	"context"
+	"errors"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/manifest/helm/flux"
+	"github.com/glasskube/glasskube/internal/manifest/plain"
+	"github.com/glasskube/glasskube/internal/manifest/recreate"
+	"github.com/glasskube/glasskube/internal/manifesttransformations"
+	"github.com/glasskube/glasskube/internal/manifestvalues"
+	repoclient "github.com/glasskube/glasskube/internal/repo/client"
+	"github.com/glasskube/glasskube/internal/resourcepatch"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var ErrKustomizeNotSupported = errors.New("kustomize manifests can not be rendered")
+
+// Renderer renders the resources of a package without applying them. It uses the same value resolution, patches and
+// manifest adapters as the package operator, so the result is what the operator would apply.
+type Renderer struct {
+	client        client.Client
+	valueResolver *manifestvalues.Resolver
+	plainAdapter  *plain.Adapter
+}
+
+func NewRenderer(
+	client client.Client,
+	repo repoclient.RepoClientset,
+	valueResolver *manifestvalues.Resolver,
+) (*Renderer, error) {
+	plainAdapter, err := plain.NewRenderer(client, repo)
+	if err != nil {
+		return nil, err
+	}
+	return &Renderer{client: client, valueResolver: valueResolver, plainAdapter: plainAdapter}, nil
+}
+
+// Render returns the resources that would be applied for pkg with the given manifest. The manifestURL is needed to
+// resolve relative URLs of plain manifests.
+// For helm manifests, the HelmRelease is returned instead of the resources of the chart.
+func (r *Renderer) Render(
+	ctx context.Context,
+	pkg ctrlpkg.Package,
+	manifest *v1alpha1.PackageManifest,
+	manifestURL string,
+) ([]*unstructured.Unstructured, error) {
+	if manifest.Kustomize != nil {
+		return nil, ErrKustomizeNotSupported
+	}
+
+	var patches resourcepatch.TargetPatches
+	if values, err := r.valueResolver.EffectiveValues(ctx, pkg); err != nil {
+		return nil, err
+	} else if resolvedValues, err := r.valueResolver.Resolve(ctx, values); err != nil {
+		return nil, err
+	} else if err := manifestvalues.ValidateResolvedValues(*manifest, resolvedValues); err != nil {
+		return nil, err
+	} else if p, err := resourcepatch.GeneratePatches(*manifest, resolvedValues); err != nil {
+		return nil, err
+	} else {
+		patches = p
+	}
+	if p, err := manifesttransformations.ResolveAndGeneratePatches(ctx, r.client, pkg, manifest); err != nil {
+		return nil, err
+	} else {
+		patches = append(patches, p...)
+	}
+
+	var result []*unstructured.Unstructured
+	if len(manifest.Manifests) > 0 {
+		pi := v1alpha1.PackageInfo{
+			Spec: v1alpha1.PackageInfoSpec{
+				Name:           manifest.Name,
+				RepositoryName: pkg.GetSpec().PackageInfo.RepositoryName,
+			},
+			Status: v1alpha1.PackageInfoStatus{Manifest: manifest, ResolvedUrl: manifestURL},
+		}
+		if objects, err := r.plainAdapter.Render(ctx, pkg, &pi, patches); err != nil {
+			return nil, err
+		} else {
+			for _, obj := range objects {
+				if u, err := toUnstructured(obj); err != nil {
+					return nil, err
+				} else {
+					result = append(result, u)
+				}
+			}
+		}
+	}
+	if manifest.Helm != nil {
+		if helmRelease, err := flux.Render(pkg, manifest, patches); err != nil {
+			return nil, err
+		} else if u, err := toUnstructured(helmRelease); err != nil {
+			return nil, err
+		} else {
+			result = append(result, u)
+		}
+	}
+	return result, nil
+}
+
+// FindImmutableChanges renders the resources of pkg and returns those that can not be applied, because they would
+// change an immutable field of an existing resource. See recreate.FindImmutableChanges.
+func (r *Renderer) FindImmutableChanges(
+	ctx context.Context,
+	pkg ctrlpkg.Package,
+	manifest *v1alpha1.PackageManifest,
+	manifestURL string,
+) ([]v1alpha1.FailedResourceRef, error) {
+	objects, err := r.Render(ctx, pkg, manifest, manifestURL)
+	if err != nil {
+		return nil, err
+	}
+	return recreate.FindImmutableChanges(ctx, r.client, objects, plain.FieldOwner), nil
+}
+
+func toUnstructured(obj runtime.Object) (*unstructured.Unstructured, error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return u, nil
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	// typed objects always contain these fields, even if they are empty
+	unstructured.RemoveNestedField(content, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(content, "status")
+	return &unstructured.Unstructured{Object: content}, nil
+}
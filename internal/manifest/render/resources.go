@@ -0,0 +1,247 @@
+package render
+
+import (
+	"fmt"
+	"slices"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// summarizedResources are the compute resources that are aggregated for workloads
+var summarizedResources = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+
+// ResourceSummary contains the compute resources and storage requested by a set of rendered resources
+type ResourceSummary struct {
+	Workloads []WorkloadResources
+	Volumes   []VolumeResources
+	// Requests and Limits are the totals of all replicas of all workloads. DaemonSets are counted once, as if the
+	// cluster had only one node.
+	Requests corev1.ResourceList
+	Limits   corev1.ResourceList
+	// Storage is the total size requested by all volume claims
+	Storage resource.Quantity
+	// MissingLimits is true if any container has no CPU or memory limit, so that Limits is only a lower bound
+	MissingLimits bool
+	// Incomplete is true if some resources can not be inspected before they are installed, e.g. the resources of a
+	// Helm chart
+	Incomplete bool
+}
+
+type WorkloadResources struct {
+	Kind     string
+	Name     string
+	Replicas int32
+	// PerNode is true for DaemonSets, which run one replica on every node
+	PerNode bool
+	// Requests and Limits are the resources of a single replica
+	Requests      corev1.ResourceList
+	Limits        corev1.ResourceList
+	MissingLimits bool
+}
+
+type VolumeResources struct {
+	Kind string
+	Name string
+	// Count is the number of claims, e.g. the number of replicas of a StatefulSet with a volume claim template
+	Count   int32
+	Storage resource.Quantity
+}
+
+// IsEmpty returns true if the resources neither contain workloads nor volume claims
+func (s *ResourceSummary) IsEmpty() bool {
+	return len(s.Workloads) == 0 && len(s.Volumes) == 0
+}
+
+// Exceeding returns the resources whose total request is larger than the given threshold. Storage is compared using
+// the threshold of corev1.ResourceStorage. Resources without threshold are never returned.
+func (s *ResourceSummary) Exceeding(thresholds corev1.ResourceList) []corev1.ResourceName {
+	var result []corev1.ResourceName
+	for _, name := range summarizedResources {
+		if threshold, ok := thresholds[name]; ok {
+			if requested := s.Requests[name]; requested.Cmp(threshold) > 0 {
+				result = append(result, name)
+			}
+		}
+	}
+	if threshold, ok := thresholds[corev1.ResourceStorage]; ok && s.Storage.Cmp(threshold) > 0 {
+		result = append(result, corev1.ResourceStorage)
+	}
+	return result
+}
+
+// SummarizeResources aggregates the resource requests and limits of all workloads and the sizes of all volume claims
+// in the given objects, as returned by Renderer.Render.
+func SummarizeResources(objects []*unstructured.Unstructured) (*ResourceSummary, error) {
+	summary := ResourceSummary{Requests: corev1.ResourceList{}, Limits: corev1.ResourceList{}}
+	for _, name := range summarizedResources {
+		summary.Requests[name] = resource.Quantity{}
+		summary.Limits[name] = resource.Quantity{}
+	}
+	for _, obj := range objects {
+		workload, volumes, err := inspectObject(obj)
+		if err != nil {
+			return nil, fmt.Errorf("could not inspect %v %v: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		if workload != nil {
+			summary.Workloads = append(summary.Workloads, *workload)
+			summary.MissingLimits = summary.MissingLimits || workload.MissingLimits
+			for _, name := range summarizedResources {
+				addScaled(summary.Requests, name, workload.Requests[name], workload.Replicas)
+				addScaled(summary.Limits, name, workload.Limits[name], workload.Replicas)
+			}
+		}
+		for _, volume := range volumes {
+			summary.Volumes = append(summary.Volumes, volume)
+			for range volume.Count {
+				summary.Storage.Add(volume.Storage)
+			}
+		}
+		if obj.GetKind() == "HelmRelease" {
+			summary.Incomplete = true
+		}
+	}
+	return &summary, nil
+}
+
+func inspectObject(obj *unstructured.Unstructured) (*WorkloadResources, []VolumeResources, error) {
+	gvk := obj.GroupVersionKind()
+	var podSpec *corev1.PodSpec
+	var claimTemplates []corev1.PersistentVolumeClaim
+	replicas := int32(1)
+	perNode := false
+	switch {
+	case gvk.Group == appsv1.GroupName && gvk.Kind == "Deployment":
+		var deployment appsv1.Deployment
+		if err := fromUnstructured(obj, &deployment); err != nil {
+			return nil, nil, err
+		}
+		podSpec, replicas = &deployment.Spec.Template.Spec, valueOrOne(deployment.Spec.Replicas)
+	case gvk.Group == appsv1.GroupName && gvk.Kind == "StatefulSet":
+		var statefulSet appsv1.StatefulSet
+		if err := fromUnstructured(obj, &statefulSet); err != nil {
+			return nil, nil, err
+		}
+		podSpec, replicas = &statefulSet.Spec.Template.Spec, valueOrOne(statefulSet.Spec.Replicas)
+		claimTemplates = statefulSet.Spec.VolumeClaimTemplates
+	case gvk.Group == appsv1.GroupName && gvk.Kind == "DaemonSet":
+		var daemonSet appsv1.DaemonSet
+		if err := fromUnstructured(obj, &daemonSet); err != nil {
+			return nil, nil, err
+		}
+		podSpec, perNode = &daemonSet.Spec.Template.Spec, true
+	case gvk.Group == appsv1.GroupName && gvk.Kind == "ReplicaSet":
+		var replicaSet appsv1.ReplicaSet
+		if err := fromUnstructured(obj, &replicaSet); err != nil {
+			return nil, nil, err
+		}
+		podSpec, replicas = &replicaSet.Spec.Template.Spec, valueOrOne(replicaSet.Spec.Replicas)
+	case gvk.Group == batchv1.GroupName && gvk.Kind == "Job":
+		var job batchv1.Job
+		if err := fromUnstructured(obj, &job); err != nil {
+			return nil, nil, err
+		}
+		podSpec, replicas = &job.Spec.Template.Spec, valueOrOne(job.Spec.Parallelism)
+	case gvk.Group == batchv1.GroupName && gvk.Kind == "CronJob":
+		var cronJob batchv1.CronJob
+		if err := fromUnstructured(obj, &cronJob); err != nil {
+			return nil, nil, err
+		}
+		podSpec = &cronJob.Spec.JobTemplate.Spec.Template.Spec
+		replicas = valueOrOne(cronJob.Spec.JobTemplate.Spec.Parallelism)
+	case gvk.Group == corev1.GroupName && gvk.Kind == "Pod":
+		var pod corev1.Pod
+		if err := fromUnstructured(obj, &pod); err != nil {
+			return nil, nil, err
+		}
+		podSpec = &pod.Spec
+	case gvk.Group == corev1.GroupName && gvk.Kind == "PersistentVolumeClaim":
+		var claim corev1.PersistentVolumeClaim
+		if err := fromUnstructured(obj, &claim); err != nil {
+			return nil, nil, err
+		}
+		return nil, []VolumeResources{{
+			Kind:    gvk.Kind,
+			Name:    obj.GetName(),
+			Count:   1,
+			Storage: claim.Spec.Resources.Requests[corev1.ResourceStorage],
+		}}, nil
+	default:
+		return nil, nil, nil
+	}
+
+	workload := WorkloadResources{Kind: gvk.Kind, Name: obj.GetName(), Replicas: replicas, PerNode: perNode}
+	workload.Requests, workload.Limits, workload.MissingLimits = podResources(podSpec)
+	var volumes []VolumeResources
+	for _, claim := range claimTemplates {
+		volumes = append(volumes, VolumeResources{
+			Kind:    gvk.Kind,
+			Name:    fmt.Sprintf("%v/%v", obj.GetName(), claim.Name),
+			Count:   replicas,
+			Storage: claim.Spec.Resources.Requests[corev1.ResourceStorage],
+		})
+	}
+	return &workload, volumes, nil
+}
+
+// podResources returns the effective requests and limits of a pod in the way the scheduler computes them: Regular
+// containers and sidecars run at the same time, while every other init container runs alone before them.
+func podResources(spec *corev1.PodSpec) (corev1.ResourceList, corev1.ResourceList, bool) {
+	requests, limits := corev1.ResourceList{}, corev1.ResourceList{}
+	missingLimits := false
+	var initContainers []corev1.Container
+	for _, container := range spec.InitContainers {
+		if container.RestartPolicy != nil && *container.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			initContainers = append(initContainers, container)
+		}
+	}
+	for _, container := range append(slices.Clone(spec.Containers), initContainers...) {
+		for _, name := range summarizedResources {
+			addScaled(requests, name, container.Resources.Requests[name], 1)
+			if limit, ok := container.Resources.Limits[name]; ok {
+				addScaled(limits, name, limit, 1)
+			} else {
+				missingLimits = true
+			}
+		}
+	}
+	for _, container := range spec.InitContainers {
+		if container.RestartPolicy != nil && *container.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			continue
+		}
+		for _, name := range summarizedResources {
+			maxOf(requests, name, container.Resources.Requests[name])
+			maxOf(limits, name, container.Resources.Limits[name])
+		}
+	}
+	return requests, limits, missingLimits
+}
+
+func addScaled(list corev1.ResourceList, name corev1.ResourceName, quantity resource.Quantity, factor int32) {
+	total := list[name]
+	for range factor {
+		total.Add(quantity)
+	}
+	list[name] = total
+}
+
+func maxOf(list corev1.ResourceList, name corev1.ResourceName, quantity resource.Quantity) {
+	if current := list[name]; quantity.Cmp(current) > 0 {
+		list[name] = quantity
+	}
+}
+
+func valueOrOne(value *int32) int32 {
+	if value == nil {
+		return 1
+	}
+	return *value
+}
+
+func fromUnstructured(obj *unstructured.Unstructured, target any) error {
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, target)
+}
@@ -0,0 +1,125 @@
+package render
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+func container(cpu, memory string, withLimits bool) corev1.Container {
+	c := corev1.Container{Name: "app", Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}}}
+	if withLimits {
+		c.Resources.Limits = c.Resources.Requests.DeepCopy()
+	}
+	return c
+}
+
+func mustUnstructured(obj runtime.Object) *unstructured.Unstructured {
+	u, err := toUnstructured(obj)
+	Expect(err).NotTo(HaveOccurred())
+	return u
+}
+
+var _ = Describe("SummarizeResources", func() {
+	claim := corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "data"},
+		Spec: corev1.PersistentVolumeClaimSpec{Resources: corev1.VolumeResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+		}},
+	}
+
+	It("should multiply the resources of all replicas", func() {
+		summary, err := SummarizeResources([]*unstructured.Unstructured{
+			mustUnstructured(&appsv1.Deployment{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+				ObjectMeta: metav1.ObjectMeta{Name: "web"},
+				Spec: appsv1.DeploymentSpec{Replicas: ptr.To[int32](3), Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{container("100m", "128Mi", true)}},
+				}},
+			}),
+			mustUnstructured(&appsv1.StatefulSet{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "StatefulSet"},
+				ObjectMeta: metav1.ObjectMeta{Name: "db"},
+				Spec: appsv1.StatefulSetSpec{
+					Replicas: ptr.To[int32](2),
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{container("1", "1Gi", true)}},
+					},
+					VolumeClaimTemplates: []corev1.PersistentVolumeClaim{claim},
+				},
+			}),
+			mustUnstructured(&corev1.PersistentVolumeClaim{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolumeClaim"},
+				ObjectMeta: claim.ObjectMeta,
+				Spec:       claim.Spec,
+			}),
+			service("web"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(summary.Workloads).To(HaveLen(2))
+		Expect(summary.Volumes).To(HaveLen(2))
+		Expect(summary.Requests.Cpu().Cmp(resource.MustParse("2300m"))).To(BeZero())
+		Expect(summary.Requests.Memory().Cmp(resource.MustParse("2432Mi"))).To(BeZero())
+		Expect(summary.Limits.Cpu().Cmp(resource.MustParse("2300m"))).To(BeZero())
+		Expect(summary.Storage.Cmp(resource.MustParse("30Gi"))).To(BeZero())
+		Expect(summary.MissingLimits).To(BeFalse())
+		Expect(summary.Incomplete).To(BeFalse())
+	})
+
+	It("should count init containers like the scheduler", func() {
+		summary, err := SummarizeResources([]*unstructured.Unstructured{
+			mustUnstructured(&corev1.Pod{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+				ObjectMeta: metav1.ObjectMeta{Name: "pod"},
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{container("2", "64Mi", false), func() corev1.Container {
+						c := container("100m", "64Mi", false)
+						c.RestartPolicy = ptr.To(corev1.ContainerRestartPolicyAlways)
+						return c
+					}()},
+					Containers: []corev1.Container{container("500m", "256Mi", false)},
+				},
+			}),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(summary.Requests.Cpu().Cmp(resource.MustParse("2"))).To(BeZero())
+		Expect(summary.Requests.Memory().Cmp(resource.MustParse("320Mi"))).To(BeZero())
+		Expect(summary.MissingLimits).To(BeTrue())
+	})
+
+	It("should mark helm releases as incomplete", func() {
+		summary, err := SummarizeResources([]*unstructured.Unstructured{{Object: map[string]any{
+			"apiVersion": "helm.toolkit.fluxcd.io/v2",
+			"kind":       "HelmRelease",
+			"metadata":   map[string]any{"name": "chart"},
+		}}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(summary.Incomplete).To(BeTrue())
+		Expect(summary.IsEmpty()).To(BeTrue())
+	})
+
+	It("should return the resources exceeding a threshold", func() {
+		summary := ResourceSummary{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("16Gi"),
+			},
+			Storage: resource.MustParse("1Gi"),
+		}
+		Expect(summary.Exceeding(corev1.ResourceList{
+			corev1.ResourceCPU:     resource.MustParse("4"),
+			corev1.ResourceMemory:  resource.MustParse("8Gi"),
+			corev1.ResourceStorage: resource.MustParse("100Mi"),
+		})).To(Equal([]corev1.ResourceName{corev1.ResourceMemory, corev1.ResourceStorage}))
+		Expect(summary.Exceeding(nil)).To(BeEmpty())
+	})
+})
@@ -14,6 +14,11 @@ type ReconcileResult struct {
 	kind           resultKind
 	Message        string
 	OwnedResources []v1alpha1.OwnedResourceRef
+	// FailedResources are the resources that could not be applied. OwnedResources only contains the other resources.
+	FailedResources []v1alpha1.FailedResourceRef
+	// RecreatedResources are the resources that were deleted and applied again, because their immutable fields
+	// changed and a recreation was requested. They are contained in OwnedResources as well.
+	RecreatedResources []v1alpha1.OwnedResourceRef
 }
 
 func Ready(message string, ownedResources []v1alpha1.OwnedResourceRef) *ReconcileResult {
@@ -28,6 +33,20 @@ func Failed(message string, ownedResources []v1alpha1.OwnedResourceRef) *Reconci
 	return &ReconcileResult{kind: failed, Message: message, OwnedResources: ownedResources}
 }
 
+// PartiallyFailed is a failed result where only some of the resources could not be applied
+func PartiallyFailed(
+	message string,
+	ownedResources []v1alpha1.OwnedResourceRef,
+	failedResources []v1alpha1.FailedResourceRef,
+) *ReconcileResult {
+	return &ReconcileResult{
+		kind:            failed,
+		Message:         message,
+		OwnedResources:  ownedResources,
+		FailedResources: failedResources,
+	}
+}
+
 func (r *ReconcileResult) IsReady() bool {
 	return r != nil && r.kind == ready
 }
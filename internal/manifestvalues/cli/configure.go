@@ -2,6 +2,7 @@ package cli
 
 import (
 	"bytes"
+	"cmp"
 	"fmt"
 	"io"
 	"os"
@@ -11,7 +12,6 @@ import (
 	"github.com/glasskube/glasskube/api/v1alpha1"
 	"github.com/glasskube/glasskube/internal/cliutils"
 	"github.com/glasskube/glasskube/internal/manifestvalues"
-	"github.com/glasskube/glasskube/internal/maputils"
 	"github.com/glasskube/glasskube/internal/util"
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/extension"
@@ -37,7 +37,8 @@ var (
 )
 
 type ConfigureOptions struct {
-	oldValues map[string]v1alpha1.ValueConfiguration
+	oldValues       map[string]v1alpha1.ValueConfiguration
+	clusterDefaults map[string]string
 	UseDefaultValuesOption
 }
 
@@ -47,6 +48,11 @@ func WithOldValues(oldValues map[string]v1alpha1.ValueConfiguration) ConfigureOp
 	return func(co *ConfigureOptions) { co.oldValues = oldValues }
 }
 
+// WithClusterDefaults replaces the default values of value definitions that refer to a cluster default
+func WithClusterDefaults(clusterDefaults map[string]string) ConfigureOption {
+	return func(co *ConfigureOptions) { co.clusterDefaults = clusterDefaults }
+}
+
 func WithUseDefaults(opts UseDefaultValuesOption) ConfigureOption {
 	return func(co *ConfigureOptions) { co.UseDefaultValuesOption = opts }
 }
@@ -60,44 +66,60 @@ func Configure(
 		fn(&options)
 	}
 
+	manifest.ValueDefinitions = manifestvalues.WithClusterDefaults(manifest.ValueDefinitions, options.clusterDefaults)
 	newValues := make(map[string]v1alpha1.ValueConfiguration, len(manifest.ValueDefinitions))
 	if len(manifest.ValueDefinitions) > 0 {
 		fmt.Fprintf(os.Stderr, "\n%v has %v values for configuration.\n\n",
 			manifest.Name, len(manifest.ValueDefinitions))
 	}
 
-	// TODO: Preserve the order of value definitions set by the author.
-	//  This is currently not possible because the kubernetes-sigs/yaml package
-	//  converts everything to an interface{} before converting to the target type,
-	//  so even if we would use an alternative map implementation that preserves the
-	//  order of keys, they would still be different from the original.
-	//  Related issue: https://github.com/kubernetes-sigs/yaml/issues/88
-	for i, name := range maputils.KeysSorted(manifest.ValueDefinitions) {
-		def := manifest.ValueDefinitions[name]
-		var oldValuePtr *v1alpha1.ValueConfiguration
-		if oldValue, ok := options.oldValues[name]; ok {
-			oldValuePtr = &oldValue
+	// The order of value definitions set by the author can not be preserved, because the kubernetes-sigs/yaml package
+	// converts everything to an interface{} before converting to the target type. Instead, values are configured in
+	// the order of their groups and metadata.order.
+	// Related issue: https://github.com/kubernetes-sigs/yaml/issues/88
+	configured := 0
+	for _, group := range manifestvalues.GroupValues(&manifest) {
+		if group.Name != "" {
+			printGroupHeader(group)
 		}
-		if options.ShouldUseDefault(name, def) {
-			fmt.Fprintf(os.Stderr, "Using default value for %v: %v\n", name, def.DefaultValue)
-			newValues[name] = v1alpha1.ValueConfiguration{
-				InlineValueConfiguration: v1alpha1.InlineValueConfiguration{Value: util.Pointer(def.DefaultValue)},
-			}
-		} else {
-			if newValue, err := ConfigureSingle(name, def, oldValuePtr); err != nil {
+		for _, name := range group.Names {
+			if err := configureValue(name, manifest.ValueDefinitions[name], options, newValues); err != nil {
 				return nil, err
-			} else if newValue != nil {
-				newValues[name] = *newValue
 			}
+			configured++
+			fmt.Fprintf(os.Stderr, "\nProgress: %v%v\n\n",
+				green(strings.Repeat("✔", configured)),
+				faint(strings.Repeat("·", len(manifest.ValueDefinitions)-configured)),
+			)
 		}
-		fmt.Fprintf(os.Stderr, "\nProgress: %v%v\n\n",
-			green(strings.Repeat("✔", i+1)),
-			faint(strings.Repeat("·", len(manifest.ValueDefinitions)-(i+1))),
-		)
 	}
 	return newValues, nil
 }
 
+// configureValue configures a single value of Configure and adds it to newValues
+func configureValue(
+	name string,
+	def v1alpha1.ValueDefinition,
+	options ConfigureOptions,
+	newValues map[string]v1alpha1.ValueConfiguration,
+) error {
+	var oldValuePtr *v1alpha1.ValueConfiguration
+	if oldValue, ok := options.oldValues[name]; ok {
+		oldValuePtr = &oldValue
+	}
+	if options.ShouldUseDefault(name, def) {
+		fmt.Fprintf(os.Stderr, "Using default value for %v: %v\n", name, def.DefaultValue)
+		newValues[name] = v1alpha1.ValueConfiguration{
+			InlineValueConfiguration: v1alpha1.InlineValueConfiguration{Value: util.Pointer(def.DefaultValue)},
+		}
+	} else if newValue, err := ConfigureSingle(name, def, oldValuePtr); err != nil {
+		return err
+	} else if newValue != nil {
+		newValues[name] = *newValue
+	}
+	return nil
+}
+
 func ConfigureSingle(
 	name string,
 	def v1alpha1.ValueDefinition,
@@ -156,6 +178,14 @@ func ConfigureSingle(
 	}
 }
 
+func printGroupHeader(group manifestvalues.GroupedValues) {
+	fmt.Fprintf(os.Stderr, "%v\n", bold("== %v ==", cmp.Or(group.Label, group.Name)))
+	if len(group.Description) > 0 {
+		printMarkdown(os.Stderr, group.Description)
+	}
+	fmt.Fprintln(os.Stderr)
+}
+
 func printHeader(name string, def v1alpha1.ValueDefinition) {
 	title := name
 	if len(def.Metadata.Label) > 0 {
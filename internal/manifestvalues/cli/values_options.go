@@ -3,9 +3,11 @@ package cli
 import (
 	"fmt"
 	"maps"
+	"slices"
 	"strings"
 
 	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/manifestvalues"
 	"github.com/glasskube/glasskube/internal/util"
 	"github.com/spf13/cobra"
 )
@@ -45,7 +47,8 @@ func (opts *ValuesOptions) AddFlagsToCommand(cmd *cobra.Command) {
 			"For example:\n"+
 			" * Reference a ConfigMap key: --value \"name=$ConfigMapRef$namespace,name,key\"\n"+
 			" * Reference a Secret key: --value \"name=$SecretRef$namespace,name,key\"\n"+
-			" * Reference another Package value: --value \"name=$PackageRef$name,value\"\n")
+			" * Reference another Package value: --value \"name=$PackageRef$name,value\"\n"+
+			" * Compute the value from a template: --value \"name=$Template${{ .Cluster.domain }}\"\n")
 	if opts.KeepOldValuesDefault != nil {
 		flags.BoolVar(&opts.KeepOldValues, "keep-old-values", *opts.KeepOldValuesDefault,
 			"Set this to false in order to erase any values not specified via --value")
@@ -98,6 +101,11 @@ func (opts *ValuesOptions) ParseValues(
 			} else {
 				valueConfiguration.ValueFrom = &v1alpha1.ValueReference{PackageRef: source}
 			}
+		} else if tmpl, ok := strings.CutPrefix(value, "$Template$"); ok {
+			if err := manifestvalues.ValidateTemplate(tmpl); err != nil {
+				return nil, fmt.Errorf("value %v is invalid: %w", key, err)
+			}
+			valueConfiguration.Template = &tmpl
 		} else {
 			valueConfiguration.Value = &value
 		}
@@ -106,6 +114,30 @@ func (opts *ValuesOptions) ParseValues(
 	return newValues, nil
 }
 
+// FormatValues returns the --value flags that configure the given values, in the same format that is accepted by
+// ParseValues. References are formatted as references, so that the referenced data is never part of the result.
+func FormatValues(values map[string]v1alpha1.ValueConfiguration) []string {
+	result := make([]string, 0, len(values))
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		value := values[name]
+		if value.ValueFrom != nil && value.ValueFrom.ConfigMapRef != nil {
+			ref := value.ValueFrom.ConfigMapRef
+			result = append(result, fmt.Sprintf("%v=$ConfigMapRef$%v,%v,%v", name, ref.Namespace, ref.Name, ref.Key))
+		} else if value.ValueFrom != nil && value.ValueFrom.SecretRef != nil {
+			ref := value.ValueFrom.SecretRef
+			result = append(result, fmt.Sprintf("%v=$SecretRef$%v,%v,%v", name, ref.Namespace, ref.Name, ref.Key))
+		} else if value.ValueFrom != nil && value.ValueFrom.PackageRef != nil {
+			ref := value.ValueFrom.PackageRef
+			result = append(result, fmt.Sprintf("%v=$PackageRef$%v,%v", name, ref.Name, ref.Value))
+		} else if value.Template != nil {
+			result = append(result, fmt.Sprintf("%v=$Template$%v", name, *value.Template))
+		} else if value.Value != nil {
+			result = append(result, fmt.Sprintf("%v=%v", name, *value.Value))
+		}
+	}
+	return result
+}
+
 func parseObjectKeyValueSource(value, prefix string) (*v1alpha1.ObjectKeyValueSource, error) {
 	if parts, err := parseSourceParts(value, prefix, 3); err != nil {
 		return nil, err
@@ -110,3 +110,31 @@ var _ = Describe("ParseValues", func() {
 		Expect(newValues).To(Equal(expectedResult))
 	})
 })
+
+var _ = Describe("FormatValues", func() {
+	It("should format values that can be parsed again", func() {
+		values := map[string]v1alpha1.ValueConfiguration{
+			"literal": {InlineValueConfiguration: v1alpha1.InlineValueConfiguration{Value: util.Pointer("a=b")}},
+			"secret": {ValueFrom: &v1alpha1.ValueReference{SecretRef: &v1alpha1.ObjectKeyValueSource{
+				Namespace: "default", Name: "creds", Key: "password",
+			}}},
+			"configMap": {ValueFrom: &v1alpha1.ValueReference{ConfigMapRef: &v1alpha1.ObjectKeyValueSource{
+				Namespace: "default", Name: "config", Key: "host",
+			}}},
+			"package": {ValueFrom: &v1alpha1.ValueReference{PackageRef: &v1alpha1.PackageValueSource{
+				Name: "other", Value: "host",
+			}}},
+			"template": {Template: util.Pointer("app.{{ .Cluster.domain }}")},
+		}
+		flags := FormatValues(values)
+		Expect(flags).To(Equal([]string{
+			"configMap=$ConfigMapRef$default,config,host",
+			"literal=a=b",
+			"package=$PackageRef$other,host",
+			"secret=$SecretRef$default,creds,password",
+			"template=$Template$app.{{ .Cluster.domain }}",
+		}))
+		opts := ValuesOptions{Values: flags}
+		Expect(opts.ParseValues(&v1alpha1.PackageManifest{}, nil)).To(Equal(values))
+	})
+})
@@ -0,0 +1,33 @@
+package manifestvalues
+
+import (
+	"slices"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+)
+
+// CloneValues returns a copy of the values of a package in namespace from, so that they can be used for a package in
+// namespace to. ConfigMap and Secret references to the namespace of the source package are changed to the target
+// namespace, because such objects usually exist once per installation. References to other namespaces are kept.
+// The names of all values whose references have been changed are returned as well.
+func CloneValues(values map[string]v1alpha1.ValueConfiguration, from, to string) (
+	map[string]v1alpha1.ValueConfiguration,
+	[]string,
+) {
+	result := make(map[string]v1alpha1.ValueConfiguration, len(values))
+	var translated []string
+	for name, value := range values {
+		value = *value.DeepCopy()
+		if ref := value.ValueFrom; ref != nil && from != to {
+			for _, source := range []*v1alpha1.ObjectKeyValueSource{ref.ConfigMapRef, ref.SecretRef} {
+				if source != nil && source.Namespace == from {
+					source.Namespace = to
+					translated = append(translated, name)
+				}
+			}
+		}
+		result[name] = value
+	}
+	slices.Sort(translated)
+	return result, translated
+}
@@ -0,0 +1,35 @@
+package manifestvalues
+
+import (
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CloneValues", func() {
+	host := "example.com"
+	values := map[string]v1alpha1.ValueConfiguration{
+		"host": {InlineValueConfiguration: v1alpha1.InlineValueConfiguration{Value: &host}},
+		"password": {ValueFrom: &v1alpha1.ValueReference{
+			SecretRef: &v1alpha1.ObjectKeyValueSource{Name: "db", Namespace: "team-a", Key: "password"},
+		}},
+		"ca": {ValueFrom: &v1alpha1.ValueReference{
+			ConfigMapRef: &v1alpha1.ObjectKeyValueSource{Name: "ca", Namespace: "shared", Key: "ca.crt"},
+		}},
+	}
+
+	It("should change references to the namespace of the source", func() {
+		cloned, translated := CloneValues(values, "team-a", "team-b")
+		Expect(translated).To(Equal([]string{"password"}))
+		Expect(cloned["password"].ValueFrom.SecretRef.Namespace).To(Equal("team-b"))
+		Expect(cloned["ca"].ValueFrom.ConfigMapRef.Namespace).To(Equal("shared"))
+		Expect(*cloned["host"].Value).To(Equal(host))
+		Expect(values["password"].ValueFrom.SecretRef.Namespace).To(Equal("team-a"))
+	})
+
+	It("should keep all references within the same namespace", func() {
+		cloned, translated := CloneValues(values, "team-a", "team-a")
+		Expect(translated).To(BeEmpty())
+		Expect(cloned).To(Equal(values))
+	})
+})
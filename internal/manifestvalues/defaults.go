@@ -0,0 +1,41 @@
+package manifestvalues
+
+import (
+	"context"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+)
+
+// ClusterDefaults returns the cluster-wide defaults, which are the data of the cluster info ConfigMap (see
+// ClusterInfoConfigMapName). If the ConfigMap does not exist, the result is empty.
+func (r *Resolver) ClusterDefaults(ctx context.Context) (map[string]string, error) {
+	if data, err := r.templateContext(ctx); err != nil {
+		return nil, err
+	} else {
+		return data.Cluster, nil
+	}
+}
+
+// DefaultValue returns the default of the given value definition. If the definition refers to a cluster default that
+// is contained in clusterDefaults, it takes precedence over the default value of the manifest.
+func DefaultValue(def v1alpha1.ValueDefinition, clusterDefaults map[string]string) string {
+	if def.ClusterDefault != "" {
+		if value, ok := clusterDefaults[def.ClusterDefault]; ok && value != "" {
+			return value
+		}
+	}
+	return def.DefaultValue
+}
+
+// WithClusterDefaults returns a copy of the given value definitions, where the default values are replaced by the
+// matching cluster defaults
+func WithClusterDefaults(
+	defs map[string]v1alpha1.ValueDefinition, clusterDefaults map[string]string,
+) map[string]v1alpha1.ValueDefinition {
+	result := make(map[string]v1alpha1.ValueDefinition, len(defs))
+	for name, def := range defs {
+		def.DefaultValue = DefaultValue(def, clusterDefaults)
+		result[name] = def
+	}
+	return result
+}
@@ -0,0 +1,41 @@
+package manifestvalues
+
+import (
+	"context"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("cluster defaults", func() {
+	clusterDefaults := map[string]string{"storageClass": "fast", "domain": ""}
+
+	DescribeTable("DefaultValue",
+		func(def v1alpha1.ValueDefinition, expected string) {
+			Expect(DefaultValue(def, clusterDefaults)).To(Equal(expected))
+		},
+		Entry("should use the cluster default",
+			v1alpha1.ValueDefinition{DefaultValue: "standard", ClusterDefault: "storageClass"}, "fast"),
+		Entry("should fall back to the default value if the cluster default is missing",
+			v1alpha1.ValueDefinition{DefaultValue: "nginx", ClusterDefault: "ingressClass"}, "nginx"),
+		Entry("should fall back to the default value if the cluster default is empty",
+			v1alpha1.ValueDefinition{DefaultValue: "example.com", ClusterDefault: "domain"}, "example.com"),
+		Entry("should use the default value without a cluster default",
+			v1alpha1.ValueDefinition{DefaultValue: "standard"}, "standard"),
+	)
+
+	It("should read the cluster info ConfigMap", func(ctx context.Context) {
+		resolver := newTestResolver(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: ClusterInfoConfigMapName, Namespace: ClusterInfoNamespace},
+			Data:       map[string]string{"storageClass": "fast"},
+		})
+		Expect(resolver.ClusterDefaults(ctx)).To(Equal(map[string]string{"storageClass": "fast"}))
+	})
+
+	It("should be empty without the cluster info ConfigMap", func(ctx context.Context) {
+		Expect(newTestResolver().ClusterDefaults(ctx)).To(BeEmpty())
+	})
+})
@@ -71,4 +71,5 @@ var (
 	ErrConstraintMax       = fmt.Errorf("%w: Max", ErrConstraint)
 	ErrConstraintMinLength = fmt.Errorf("%w: MinLength", ErrConstraint)
 	ErrConstraintMaxLength = fmt.Errorf("%w: MaxLength", ErrConstraint)
+	ErrConstraintFormat    = fmt.Errorf("%w: Format", ErrConstraint)
 )
@@ -0,0 +1,86 @@
+package manifestvalues
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+)
+
+// EssentialGroupName is the name of the group that contains all required values. It is always shown first and can
+// not be collapsed.
+const EssentialGroupName = "essential"
+
+// OtherGroupName is the name of the group that contains all optional values without a group, or with a group that is
+// not defined in the manifest. It is shown last, unless the manifest defines it explicitly.
+const OtherGroupName = "other"
+
+// GroupedValues are the names of the values of one group, sorted by their order and name
+type GroupedValues struct {
+	v1alpha1.ValueGroup
+	Names []string
+}
+
+// GroupValues assigns every value definition of the manifest to a group and returns the groups that contain at least
+// one value, in the order in which they should be shown. If the manifest does not define any groups, all values are
+// returned in a single group without a name.
+func GroupValues(manifest *v1alpha1.PackageManifest) []GroupedValues {
+	if len(manifest.ValueDefinitions) == 0 {
+		return nil
+	} else if len(manifest.ValueGroups) == 0 {
+		return []GroupedValues{{Names: sortedValueNames(manifest.ValueDefinitions, nil)}}
+	}
+
+	groups := make([]GroupedValues, 0, len(manifest.ValueGroups)+2)
+	groups = append(groups, GroupedValues{ValueGroup: v1alpha1.ValueGroup{
+		Name:        EssentialGroupName,
+		Label:       "Essential",
+		Description: "These values are required to install the package.",
+	}})
+	for _, group := range manifest.ValueGroups {
+		if group.Name != EssentialGroupName {
+			groups = append(groups, GroupedValues{ValueGroup: group})
+		}
+	}
+	if !slices.ContainsFunc(groups, func(group GroupedValues) bool { return group.Name == OtherGroupName }) {
+		groups = append(groups, GroupedValues{ValueGroup: v1alpha1.ValueGroup{Name: OtherGroupName, Label: "Other"}})
+	}
+
+	for i := range groups {
+		groups[i].Names = sortedValueNames(manifest.ValueDefinitions, func(def v1alpha1.ValueDefinition) bool {
+			return valueGroupName(manifest, def) == groups[i].Name
+		})
+	}
+	return slices.DeleteFunc(groups, func(group GroupedValues) bool { return len(group.Names) == 0 })
+}
+
+// valueGroupName returns the name of the group that a value with the given definition belongs to
+func valueGroupName(manifest *v1alpha1.PackageManifest, def v1alpha1.ValueDefinition) string {
+	if def.Constraints.Required {
+		return EssentialGroupName
+	} else if def.Metadata.Group == "" || def.Metadata.Group == EssentialGroupName ||
+		!slices.ContainsFunc(manifest.ValueGroups, func(group v1alpha1.ValueGroup) bool {
+			return group.Name == def.Metadata.Group
+		}) {
+		return OtherGroupName
+	}
+	return def.Metadata.Group
+}
+
+// sortedValueNames returns the names of the definitions that match, sorted by their order and name. If matches is
+// nil, all names are returned.
+func sortedValueNames(
+	definitions map[string]v1alpha1.ValueDefinition,
+	matches func(def v1alpha1.ValueDefinition) bool,
+) []string {
+	var names []string
+	for name, def := range definitions {
+		if matches == nil || matches(def) {
+			names = append(names, name)
+		}
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		return cmp.Or(cmp.Compare(definitions[a].Metadata.Order, definitions[b].Metadata.Order), cmp.Compare(a, b))
+	})
+	return names
+}
@@ -0,0 +1,67 @@
+package manifestvalues
+
+import (
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GroupValues", func() {
+	def := func(group string, order int, required bool) v1alpha1.ValueDefinition {
+		return v1alpha1.ValueDefinition{
+			Type:        v1alpha1.ValueTypeText,
+			Metadata:    v1alpha1.ValueDefinitionMetadata{Group: group, Order: order},
+			Constraints: v1alpha1.ValueDefinitionConstraints{Required: required},
+		}
+	}
+
+	It("should return a single unnamed group if the manifest has no value groups", func() {
+		manifest := v1alpha1.PackageManifest{ValueDefinitions: map[string]v1alpha1.ValueDefinition{
+			"b": def("", 0, true), "a": def("", 0, false), "c": def("", -1, false),
+		}}
+		Expect(GroupValues(&manifest)).To(Equal([]GroupedValues{{Names: []string{"c", "a", "b"}}}))
+	})
+
+	It("should return nothing if the manifest has no values", func() {
+		Expect(GroupValues(&v1alpha1.PackageManifest{})).To(BeEmpty())
+	})
+
+	It("should group values in the order of the value groups", func() {
+		advanced := v1alpha1.ValueGroup{Name: "advanced", Label: "Advanced", Collapsed: true}
+		network := v1alpha1.ValueGroup{Name: "network", Description: "Ingress settings"}
+		manifest := v1alpha1.PackageManifest{
+			ValueGroups: []v1alpha1.ValueGroup{network, advanced, {Name: "empty"}},
+			ValueDefinitions: map[string]v1alpha1.ValueDefinition{
+				"host":     def("network", 0, true),
+				"tls":      def("network", 2, false),
+				"class":    def("network", 1, false),
+				"replicas": def("advanced", 0, false),
+				"debug":    def("", 0, false),
+				"unknown":  def("missing", 0, false),
+			},
+		}
+		groups := GroupValues(&manifest)
+		Expect(groups).To(HaveLen(4))
+		Expect(groups[0].Name).To(Equal(EssentialGroupName))
+		Expect(groups[0].Names).To(Equal([]string{"host"}))
+		Expect(groups[1]).To(Equal(GroupedValues{ValueGroup: network, Names: []string{"class", "tls"}}))
+		Expect(groups[2]).To(Equal(GroupedValues{ValueGroup: advanced, Names: []string{"replicas"}}))
+		Expect(groups[3].Name).To(Equal(OtherGroupName))
+		Expect(groups[3].Names).To(Equal([]string{"debug", "unknown"}))
+	})
+
+	It("should use the other group of the manifest", func() {
+		other := v1alpha1.ValueGroup{Name: OtherGroupName, Label: "Miscellaneous", Collapsed: true}
+		manifest := v1alpha1.PackageManifest{
+			ValueGroups: []v1alpha1.ValueGroup{other, {Name: "network"}},
+			ValueDefinitions: map[string]v1alpha1.ValueDefinition{
+				"debug": def("", 0, false),
+				"host":  def("network", 0, false),
+			},
+		}
+		Expect(GroupValues(&manifest)).To(Equal([]GroupedValues{
+			{ValueGroup: other, Names: []string{"debug"}},
+			{ValueGroup: v1alpha1.ValueGroup{Name: "network"}, Names: []string{"host"}},
+		}))
+	})
+})
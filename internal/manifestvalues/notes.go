@@ -0,0 +1,62 @@
+package manifestvalues
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+)
+
+// NotesContext is the data that post-install notes are executed with. In addition to the data of value templates, it
+// contains the installed package and its values.
+type NotesContext struct {
+	TemplateContext
+	Package NotesPackage
+	// Values are the resolved values of the package. Values that reference a secret are omitted, because the notes
+	// are shown to everyone who can see the package.
+	Values map[string]string
+}
+
+type NotesPackage struct {
+	Name      string
+	Namespace string
+	Version   string
+}
+
+// RenderNotes executes the post-install notes of a package manifest for the given package. The notes are sandboxed
+// like value templates: only the same functions are available and the length of the result is limited.
+func (r *Resolver) RenderNotes(ctx context.Context, pkg ctrlpkg.Package, text string) (string, error) {
+	tmpl, err := parseTemplate(text, templateFuncs(func(name, value string) (string, error) {
+		return r.resolvePackageRef(ctx, v1alpha1.PackageValueSource{Name: name, Value: value})
+	}))
+	if err != nil {
+		return "", fmt.Errorf("invalid post-install notes: %w", err)
+	}
+	templateContext, err := r.templateContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	data := NotesContext{
+		TemplateContext: *templateContext,
+		Package: NotesPackage{
+			Name:      pkg.GetName(),
+			Namespace: pkg.GetNamespace(),
+			Version:   pkg.GetSpec().PackageInfo.Version,
+		},
+		Values: make(map[string]string),
+	}
+	for name, value := range pkg.GetSpec().Values {
+		if value.ValueFrom != nil && value.ValueFrom.SecretRef != nil {
+			continue
+		}
+		if data.Values[name], err = r.ResolveValue(ctx, value); err != nil {
+			return "", fmt.Errorf("cannot resolve value %v: %w", name, err)
+		}
+	}
+	var out limitedBuilder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("cannot render post-install notes: %w", err)
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,67 @@
+package manifestvalues
+
+import (
+	"context"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+)
+
+// EnvironmentKey is the key of the cluster info ConfigMap (see ClusterInfoConfigMapName) that contains the name of
+// the environment of the cluster, e.g. "prod". It selects the overlay of every package that is merged into its values.
+const EnvironmentKey = "environment"
+
+// ValueLayer is the layer that the effective configuration of a value comes from
+type ValueLayer string
+
+const (
+	// LayerDefault means that the value is not configured, so the default of the value definition is used
+	LayerDefault ValueLayer = "default"
+	// LayerBase means that the value is configured in the values of the package
+	LayerBase ValueLayer = "base"
+	// LayerOverlay means that the value is configured in the overlay of the environment of the cluster
+	LayerOverlay ValueLayer = "overlay"
+)
+
+// Environment returns the environment of the cluster, which is empty if none is configured
+func (r *Resolver) Environment(ctx context.Context) (string, error) {
+	if clusterDefaults, err := r.ClusterDefaults(ctx); err != nil {
+		return "", err
+	} else {
+		return clusterDefaults[EnvironmentKey], nil
+	}
+}
+
+// EffectiveValues returns the values of the given package for the environment of the cluster
+func (r *Resolver) EffectiveValues(ctx context.Context, pkg ctrlpkg.Package) (
+	map[string]v1alpha1.ValueConfiguration,
+	error,
+) {
+	if len(pkg.GetSpec().Overlays) == 0 {
+		return pkg.GetSpec().Values, nil
+	} else if environment, err := r.Environment(ctx); err != nil {
+		return nil, err
+	} else {
+		values, _ := MergeOverlay(pkg.GetSpec().Values, pkg.GetSpec().Overlays[environment])
+		return values, nil
+	}
+}
+
+// MergeOverlay returns the values of base with the values of overlay taking precedence, together with the layer that
+// every resulting value comes from. Neither base nor overlay are modified.
+func MergeOverlay(base, overlay map[string]v1alpha1.ValueConfiguration) (
+	map[string]v1alpha1.ValueConfiguration,
+	map[string]ValueLayer,
+) {
+	values := make(map[string]v1alpha1.ValueConfiguration, len(base)+len(overlay))
+	layers := make(map[string]ValueLayer, len(base)+len(overlay))
+	for name, value := range base {
+		values[name] = value
+		layers[name] = LayerBase
+	}
+	for name, value := range overlay {
+		values[name] = value
+		layers[name] = LayerOverlay
+	}
+	return values, layers
+}
@@ -0,0 +1,76 @@
+package manifestvalues
+
+import (
+	"context"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("overlays", func() {
+	literal := func(value string) v1alpha1.ValueConfiguration {
+		return v1alpha1.ValueConfiguration{InlineValueConfiguration: v1alpha1.InlineValueConfiguration{Value: &value}}
+	}
+	newPackage := func() *v1alpha1.ClusterPackage {
+		return &v1alpha1.ClusterPackage{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: v1alpha1.PackageSpec{
+				Values: map[string]v1alpha1.ValueConfiguration{"host": literal("dev.example.com"), "replicas": literal("1")},
+				Overlays: map[string]map[string]v1alpha1.ValueConfiguration{
+					"prod": {"host": literal("example.com")},
+				},
+			},
+		}
+	}
+	clusterInfo := func(environment string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: ClusterInfoConfigMapName, Namespace: ClusterInfoNamespace},
+			Data:       map[string]string{EnvironmentKey: environment},
+		}
+	}
+
+	It("should merge the overlay into the base values", func() {
+		values, layers := MergeOverlay(newPackage().Spec.Values, newPackage().Spec.Overlays["prod"])
+		Expect(values).To(Equal(map[string]v1alpha1.ValueConfiguration{
+			"host": literal("example.com"), "replicas": literal("1"),
+		}))
+		Expect(layers).To(Equal(map[string]ValueLayer{"host": LayerOverlay, "replicas": LayerBase}))
+	})
+
+	It("should not modify the base values", func() {
+		pkg := newPackage()
+		_, _ = MergeOverlay(pkg.Spec.Values, pkg.Spec.Overlays["prod"])
+		Expect(pkg.Spec.Values).To(Equal(newPackage().Spec.Values))
+	})
+
+	It("should use the overlay of the environment of the cluster", func(ctx context.Context) {
+		values, err := newTestResolver(clusterInfo("prod")).EffectiveValues(ctx, newPackage())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(HaveKeyWithValue("host", literal("example.com")))
+		Expect(values).To(HaveKeyWithValue("replicas", literal("1")))
+	})
+
+	It("should use the base values if the environment has no overlay", func(ctx context.Context) {
+		values, err := newTestResolver(clusterInfo("staging")).EffectiveValues(ctx, newPackage())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(Equal(newPackage().Spec.Values))
+	})
+
+	It("should use the base values if no environment is configured", func(ctx context.Context) {
+		values, err := newTestResolver().EffectiveValues(ctx, newPackage())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(Equal(newPackage().Spec.Values))
+	})
+
+	It("should resolve package references with the overlay", func(ctx context.Context) {
+		resolver := newTestResolver(clusterInfo("prod"), newPackage())
+		value, err := resolver.ResolveValue(ctx, v1alpha1.ValueConfiguration{
+			ValueFrom: &v1alpha1.ValueReference{PackageRef: &v1alpha1.PackageValueSource{Name: "test", Value: "host"}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal("example.com"))
+	})
+})
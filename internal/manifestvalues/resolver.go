@@ -38,6 +38,8 @@ func (r *Resolver) Resolve(ctx context.Context, values map[string]v1alpha1.Value
 func (r *Resolver) ResolveValue(ctx context.Context, value v1alpha1.ValueConfiguration) (string, error) {
 	if value.Value != nil {
 		return *value.Value, nil
+	} else if value.Template != nil {
+		return r.resolveTemplate(ctx, *value.Template)
 	} else if value.ValueFrom != nil {
 		if r, err := r.resolveReference(ctx, *value.ValueFrom); err != nil {
 			return "", err
@@ -84,7 +86,9 @@ func (r *Resolver) resolveSecretRef(ctx context.Context, ref v1alpha1.ObjectKeyV
 func (r *Resolver) resolvePackageRef(ctx context.Context, ref v1alpha1.PackageValueSource) (string, error) {
 	if pkg, err := r.pkg.GetClusterPackage(ctx, ref.Name); err != nil {
 		return "", NewPackageRefError(ref, err)
-	} else if value, ok := pkg.Spec.Values[ref.Value]; !ok {
+	} else if values, err := r.EffectiveValues(ctx, pkg); err != nil {
+		return "", NewPackageRefError(ref, err)
+	} else if value, ok := values[ref.Value]; !ok {
 		return "", NewPackageRefError(ref, NewKeyError(ref.Value))
 	} else if resolved, err := r.ResolveValue(ctx, value); err != nil {
 		return "", NewPackageRefError(ref, err)
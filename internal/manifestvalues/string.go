@@ -18,6 +18,8 @@ func ValueAsString(value v1alpha1.ValueConfiguration) string {
 			return fmt.Sprintf("reference to value '%v' of Package %v",
 				value.ValueFrom.PackageRef.Value, value.ValueFrom.PackageRef.Name)
 		}
+	} else if value.Template != nil {
+		return fmt.Sprintf("template '%v'", *value.Template)
 	} else if value.Value != nil {
 		return *value.Value
 	}
@@ -0,0 +1,217 @@
+package manifestvalues
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	// ClusterInfoNamespace and ClusterInfoConfigMapName identify the ConfigMap that contains the cluster facts that are
+	// available to value templates as .Cluster, e.g. a "domain" key for the base domain of ingresses.
+	ClusterInfoNamespace     = "glasskube-system"
+	ClusterInfoConfigMapName = "glasskube-cluster-info"
+	// maxTemplateDepth limits the nesting of templates that reference values of other packages, which are templates
+	// themselves. This also prevents endless recursion if two packages reference each other.
+	maxTemplateDepth = 8
+	// maxTemplateOutputLength is the maximum length of the result of a value template
+	maxTemplateOutputLength = 64 * 1024
+)
+
+var (
+	ErrTemplateDepth  = errors.New("value templates are nested too deeply")
+	ErrTemplateOutput = fmt.Errorf("value template result is longer than %v bytes", maxTemplateOutputLength)
+)
+
+// allowedTemplateBuiltins are the builtin functions of text/template that may be used in value templates. Notably,
+// "call" is not allowed.
+var allowedTemplateBuiltins = map[string]struct{}{
+	"and": {}, "or": {}, "not": {}, "len": {}, "index": {}, "print": {}, "printf": {}, "urlquery": {},
+	"eq": {}, "ne": {}, "lt": {}, "le": {}, "gt": {}, "ge": {},
+}
+
+// TemplateContext is the data that value templates are executed with
+type TemplateContext struct {
+	// Cluster contains the data of the cluster info ConfigMap (see ClusterInfoConfigMapName)
+	Cluster map[string]string
+}
+
+type templateDepthKey struct{}
+
+// templateFuncs returns the functions that are available in value templates. Only functions that read values
+// (packageValue) or transform strings are available.
+func templateFuncs(packageValue func(name, value string) (string, error)) template.FuncMap {
+	return template.FuncMap{
+		"packageValue": packageValue,
+		"default": func(def, value string) string {
+			if value == "" {
+				return def
+			}
+			return value
+		},
+		"lower":      strings.ToLower,
+		"upper":      strings.ToUpper,
+		"trim":       strings.TrimSpace,
+		"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+		"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+		"replace":    func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+		"base64":     func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) },
+	}
+}
+
+// ValidateTemplate checks that the given value template can be parsed and only uses allowed functions, without
+// executing it
+func ValidateTemplate(text string) error {
+	_, err := parseTemplate(text, templateFuncs(func(string, string) (string, error) { return "", nil }))
+	return err
+}
+
+func parseTemplate(text string, funcs template.FuncMap) (*template.Template, error) {
+	tmpl, err := template.New("value").Option("missingkey=error").Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value template: %w", err)
+	}
+	if len(tmpl.Templates()) > 1 {
+		return nil, errors.New("invalid value template: defining templates is not allowed")
+	}
+	if err := checkTemplateNode(tmpl.Root, funcs); err != nil {
+		return nil, fmt.Errorf("invalid value template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// checkTemplateNode rejects nodes that could be used to escape the sandbox of value templates: calls of functions
+// that are not explicitly allowed, invocations of other templates and ranges over anything but the template data.
+func checkTemplateNode(node parse.Node, funcs template.FuncMap) error {
+	switch node := node.(type) {
+	case *parse.ListNode:
+		if node == nil {
+			return nil
+		}
+		for _, n := range node.Nodes {
+			if err := checkTemplateNode(n, funcs); err != nil {
+				return err
+			}
+		}
+	case *parse.ActionNode:
+		return checkTemplateNode(node.Pipe, funcs)
+	case *parse.PipeNode:
+		if node == nil {
+			return nil
+		}
+		for _, cmd := range node.Cmds {
+			if err := checkTemplateNode(cmd, funcs); err != nil {
+				return err
+			}
+		}
+	case *parse.CommandNode:
+		for _, arg := range node.Args {
+			if err := checkTemplateNode(arg, funcs); err != nil {
+				return err
+			}
+		}
+	case *parse.ChainNode:
+		return checkTemplateNode(node.Node, funcs)
+	case *parse.IfNode:
+		return checkBranchNode(&node.BranchNode, funcs)
+	case *parse.RangeNode:
+		if !isDataField(node.Pipe) {
+			return errors.New("range is only allowed over fields of the template data")
+		}
+		return checkBranchNode(&node.BranchNode, funcs)
+	case *parse.WithNode:
+		return checkBranchNode(&node.BranchNode, funcs)
+	case *parse.IdentifierNode:
+		if _, ok := funcs[node.Ident]; ok {
+			return nil
+		} else if _, ok := allowedTemplateBuiltins[node.Ident]; ok {
+			return nil
+		}
+		return fmt.Errorf("function %q is not allowed", node.Ident)
+	case *parse.TemplateNode:
+		return fmt.Errorf("invoking template %q is not allowed", node.Name)
+	}
+	return nil
+}
+
+func checkBranchNode(node *parse.BranchNode, funcs template.FuncMap) error {
+	if err := checkTemplateNode(node.Pipe, funcs); err != nil {
+		return err
+	} else if err := checkTemplateNode(node.List, funcs); err != nil {
+		return err
+	} else {
+		return checkTemplateNode(node.ElseList, funcs)
+	}
+}
+
+// isDataField checks whether the given pipeline only accesses a field of the template data, e.g. ".Cluster" or
+// "$.Cluster". Other pipelines could evaluate to an integer, such that a range over them would run for an almost
+// unlimited number of iterations without producing any output.
+func isDataField(pipe *parse.PipeNode) bool {
+	if pipe == nil || len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) != 1 {
+		return false
+	}
+	switch arg := pipe.Cmds[0].Args[0].(type) {
+	case *parse.FieldNode:
+		return true
+	case *parse.VariableNode:
+		return len(arg.Ident) > 1
+	default:
+		return false
+	}
+}
+
+func (r *Resolver) resolveTemplate(ctx context.Context, text string) (string, error) {
+	depth, _ := ctx.Value(templateDepthKey{}).(int)
+	if depth >= maxTemplateDepth {
+		return "", ErrTemplateDepth
+	}
+	ctx = context.WithValue(ctx, templateDepthKey{}, depth+1)
+
+	tmpl, err := parseTemplate(text, templateFuncs(func(name, value string) (string, error) {
+		return r.resolvePackageRef(ctx, v1alpha1.PackageValueSource{Name: name, Value: value})
+	}))
+	if err != nil {
+		return "", err
+	}
+	data, err := r.templateContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	var out limitedBuilder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+func (r *Resolver) templateContext(ctx context.Context) (*TemplateContext, error) {
+	data := TemplateContext{Cluster: map[string]string{}}
+	if cm, err := r.client.GetConfigMap(ctx, ClusterInfoConfigMapName, ClusterInfoNamespace); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("cannot read cluster info: %w", err)
+		}
+	} else if cm.Data != nil {
+		data.Cluster = cm.Data
+	}
+	return &data, nil
+}
+
+// limitedBuilder is a strings.Builder that refuses to grow beyond maxTemplateOutputLength
+type limitedBuilder struct {
+	strings.Builder
+}
+
+func (b *limitedBuilder) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > maxTemplateOutputLength {
+		return 0, ErrTemplateOutput
+	}
+	return b.Builder.Write(p)
+}
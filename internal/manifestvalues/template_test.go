@@ -0,0 +1,115 @@
+package manifestvalues
+
+import (
+	"context"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("value templates", func() {
+	template := func(text string) v1alpha1.ValueConfiguration {
+		return v1alpha1.ValueConfiguration{Template: &text}
+	}
+	clusterInfo := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: ClusterInfoConfigMapName, Namespace: ClusterInfoNamespace},
+		Data:       map[string]string{"domain": "example.com"},
+	}
+	hostname := "git.example.com"
+	otherPackage := &v1alpha1.ClusterPackage{
+		ObjectMeta: metav1.ObjectMeta{Name: "gitea"},
+		Spec: v1alpha1.PackageSpec{
+			Values: map[string]v1alpha1.ValueConfiguration{
+				"host": {InlineValueConfiguration: v1alpha1.InlineValueConfiguration{Value: &hostname}},
+				"url":  template(`https://{{ packageValue "gitea" "host" }}`),
+				"loop": template(`{{ packageValue "gitea" "loop" }}`),
+			},
+		},
+	}
+
+	DescribeTable("should resolve",
+		func(ctx context.Context, text, expected string) {
+			resolver := newTestResolver(clusterInfo, otherPackage)
+			Expect(resolver.ResolveValue(ctx, template(text))).To(Equal(expected))
+		},
+		Entry("cluster facts", "app.{{ .Cluster.domain }}", "app.example.com"),
+		Entry("values of other packages", `{{ packageValue "gitea" "host" | upper }}`, "GIT.EXAMPLE.COM"),
+		Entry("templates of other packages", `{{ packageValue "gitea" "url" }}/api`, "https://git.example.com/api"),
+		Entry("builtins", `{{ if eq .Cluster.domain "example.com" }}yes{{ else }}no{{ end }}`, "yes"),
+		Entry("defaults", `{{ index .Cluster "missing" | default "fallback" }}`, "fallback"),
+		Entry("ranges over the template data", `{{ range $key, $value := $.Cluster }}{{ $key }}{{ end }}`, "domain"),
+	)
+
+	It("should use empty cluster facts if there is no cluster info", func(ctx context.Context) {
+		resolver := newTestResolver()
+		Expect(resolver.ResolveValue(ctx, template(`{{ len .Cluster }}`))).To(Equal("0"))
+	})
+
+	DescribeTable("should reject",
+		func(ctx context.Context, text string) {
+			Expect(ValidateTemplate(text)).NotTo(Succeed())
+			resolver := newTestResolver(clusterInfo, otherPackage)
+			_, err := resolver.ResolveValue(ctx, template(text))
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("disallowed builtins", `{{ call .Cluster.domain }}`),
+		Entry("unknown functions", `{{ env "HOME" }}`),
+		Entry("template definitions", `{{ define "x" }}x{{ end }}{{ template "x" }}`),
+		Entry("blocks", `{{ block "x" . }}x{{ end }}`),
+		Entry("disallowed functions in branches", `{{ if true }}{{ call .Cluster.domain }}{{ end }}`),
+		Entry("syntax errors", `{{ .Cluster.domain`),
+		Entry("ranges over huge integers", `{{ range 9223372036854775807 }}{{ end }}`),
+		Entry("ranges over variables", `{{ $n := 9223372036854775807 }}{{ range $n }}{{ end }}`),
+		Entry("ranges over the dot", `{{ with 9223372036854775807 }}{{ range . }}{{ end }}{{ end }}`),
+	)
+
+	It("should reject recursive references", func(ctx context.Context) {
+		resolver := newTestResolver(clusterInfo, otherPackage)
+		_, err := resolver.ResolveValue(ctx, template(`{{ packageValue "gitea" "loop" }}`))
+		Expect(err).To(MatchError(ErrTemplateDepth))
+	})
+
+	It("should limit the length of the result", func(ctx context.Context) {
+		resolver := newTestResolver(clusterInfo)
+		_, err := resolver.ResolveValue(ctx, template(`{{ range .Cluster }}{{ printf "%999999s" "" }}{{ end }}`))
+		Expect(err).To(MatchError(ErrTemplateOutput))
+	})
+})
+
+var _ = Describe("post-install notes", func() {
+	host := "git.example.com"
+	pkg := &v1alpha1.Package{
+		ObjectMeta: metav1.ObjectMeta{Name: "gitea", Namespace: "git"},
+		Spec: v1alpha1.PackageSpec{
+			PackageInfo: v1alpha1.PackageInfoTemplate{Name: "gitea", Version: "v1.0.0+1"},
+			Values: map[string]v1alpha1.ValueConfiguration{
+				"host": {InlineValueConfiguration: v1alpha1.InlineValueConfiguration{Value: &host}},
+				"password": {ValueFrom: &v1alpha1.ValueReference{
+					SecretRef: &v1alpha1.ObjectKeyValueSource{Name: "admin", Namespace: "git", Key: "password"},
+				}},
+			},
+		},
+	}
+
+	It("should render the package and its values", func(ctx context.Context) {
+		resolver := newTestResolver()
+		Expect(resolver.RenderNotes(ctx, pkg,
+			"Open https://{{ .Values.host }} in {{ .Package.Namespace }}/{{ .Package.Name }} ({{ .Package.Version }})")).
+			To(Equal("Open https://git.example.com in git/gitea (v1.0.0+1)"))
+	})
+
+	It("should not expose values from secrets", func(ctx context.Context) {
+		resolver := newTestResolver()
+		_, err := resolver.RenderNotes(ctx, pkg, "{{ .Values.password }}")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject disallowed functions", func(ctx context.Context) {
+		resolver := newTestResolver()
+		_, err := resolver.RenderNotes(ctx, pkg, `{{ env "HOME" }}`)
+		Expect(err).To(HaveOccurred())
+	})
+})
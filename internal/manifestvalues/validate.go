@@ -1,13 +1,20 @@
 package manifestvalues
 
 import (
+	"errors"
 	"fmt"
+	"net/mail"
+	"net/netip"
+	"net/url"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/glasskube/glasskube/api/v1alpha1"
 	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/maputils"
 	"go.uber.org/multierr"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 type validateFn func(def v1alpha1.ValueDefinition, value string) error
@@ -29,14 +36,14 @@ var (
 
 	validateFormatNumber validateFn = func(def v1alpha1.ValueDefinition, value string) error {
 		if _, err := strconv.Atoi(value); err != nil {
-			return NewFormatError("number", err)
+			return NewFormatError("number", withoutInput(err))
 		}
 		return nil
 	}
 
 	validateFormatBoolean validateFn = func(def v1alpha1.ValueDefinition, value string) error {
 		if _, err := strconv.ParseBool(value); err != nil {
-			return NewFormatError("boolean", err)
+			return NewFormatError("boolean", withoutInput(err))
 		}
 		return nil
 	}
@@ -44,7 +51,7 @@ var (
 	validateMin validateFn = func(def v1alpha1.ValueDefinition, value string) error {
 		if def.Constraints.Min != nil {
 			if i, err := strconv.Atoi(value); err != nil {
-				return err
+				return NewFormatError("number", withoutInput(err))
 			} else if i < *def.Constraints.Min {
 				return fmt.Errorf("%w: %v", ErrConstraintMin, *def.Constraints.Min)
 			}
@@ -55,7 +62,7 @@ var (
 	validateMax validateFn = func(def v1alpha1.ValueDefinition, value string) error {
 		if def.Constraints.Max != nil {
 			if i, err := strconv.Atoi(value); err != nil {
-				return err
+				return NewFormatError("number", withoutInput(err))
 			} else if i > *def.Constraints.Max {
 				return fmt.Errorf("%w: %v", ErrConstraintMax, *def.Constraints.Max)
 			}
@@ -72,6 +79,14 @@ var (
 		return NewOptionsError(def.Options)
 	}
 
+	validateFormat validateFn = func(def v1alpha1.ValueDefinition, value string) error {
+		// an empty value is not checked, since it is not a malformed value but the absence of a value
+		if def.Constraints.Format != nil && value != "" && !isValidFormat(*def.Constraints.Format, value) {
+			return fmt.Errorf("%w: %v", ErrConstraintFormat, *def.Constraints.Format)
+		}
+		return nil
+	}
+
 	validatePattern validateFn = func(def v1alpha1.ValueDefinition, value string) error {
 		if def.Constraints.Pattern != nil {
 			if re, err := regexp.Compile(*def.Constraints.Pattern); err != nil {
@@ -84,6 +99,37 @@ var (
 	}
 )
 
+func isValidFormat(format v1alpha1.ValueFormat, value string) bool {
+	switch format {
+	case v1alpha1.ValueFormatEmail:
+		addr, err := mail.ParseAddress(value)
+		return err == nil && addr.Address == value
+	case v1alpha1.ValueFormatURI:
+		u, err := url.Parse(value)
+		return err == nil && u.Scheme != "" && (u.Host != "" || u.Opaque != "")
+	case v1alpha1.ValueFormatHostname:
+		return len(validation.IsDNS1123Subdomain(strings.ToLower(value))) == 0
+	case v1alpha1.ValueFormatIPv4:
+		addr, err := netip.ParseAddr(value)
+		return err == nil && addr.Is4()
+	case v1alpha1.ValueFormatIPv6:
+		addr, err := netip.ParseAddr(value)
+		return err == nil && addr.Is6()
+	default:
+		return false
+	}
+}
+
+// withoutInput strips the validated input from errors returned by strconv. The input might have been resolved from a
+// Secret, so it must never end up in an error message that is shown to users or written to a condition.
+func withoutInput(err error) error {
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		return numErr.Err
+	}
+	return err
+}
+
 type validateFns []validateFn
 
 func (v *validateFns) validate(def v1alpha1.ValueDefinition, value string) (err error) {
@@ -94,7 +140,7 @@ func (v *validateFns) validate(def v1alpha1.ValueDefinition, value string) (err
 }
 
 var validatorsForType = map[v1alpha1.ValueType]validateFns{
-	v1alpha1.ValueTypeText:    {validateMaxLength, validateMinLength, validatePattern},
+	v1alpha1.ValueTypeText:    {validateMaxLength, validateMinLength, validatePattern, validateFormat},
 	v1alpha1.ValueTypeNumber:  {validateFormatNumber, validateMin, validateMax, validatePattern},
 	v1alpha1.ValueTypeOptions: {validateOptions},
 	v1alpha1.ValueTypeBoolean: {validateFormatBoolean},
@@ -164,8 +210,12 @@ func targetsForResolvedValues(values map[string]string) map[string]validationTar
 }
 
 func targetsForPackage(pkg ctrlpkg.Package) map[string]validationTarget {
+	return targetsForValueConfigurations(pkg.GetSpec().Values)
+}
+
+func targetsForValueConfigurations(values map[string]v1alpha1.ValueConfiguration) map[string]validationTarget {
 	result := make(map[string]validationTarget)
-	for name, value := range pkg.GetSpec().Values {
+	for name, value := range values {
 		if value.Value != nil {
 			result[name] = acutalValue(*value.Value)
 		} else {
@@ -184,6 +234,35 @@ func ValidateResolvedValues(manifest v1alpha1.PackageManifest, values map[string
 // Reference values are **not resolved**, so constraint validation is skipped for
 // these values. If instead you want to validate **all** values, please resolve all
 // references first, using a Resolver instance, and then use ValidateResolvedValues.
-func ValidatePackage(manifest v1alpha1.PackageManifest, pkg ctrlpkg.Package) error {
-	return validate(manifest, targetsForPackage(pkg))
+// Overlays are validated as well, but the values of an overlay do not need to satisfy
+// the required constraint, because the base values already have to.
+func ValidatePackage(manifest v1alpha1.PackageManifest, pkg ctrlpkg.Package) (err error) {
+	multierr.AppendInto(&err, validate(manifest, targetsForPackage(pkg)))
+	for _, environment := range maputils.KeysSorted(pkg.GetSpec().Overlays) {
+		multierr.AppendInto(&err, validateOverlay(manifest, environment, pkg.GetSpec().Overlays[environment]))
+	}
+	return
+}
+
+func validateOverlay(
+	manifest v1alpha1.PackageManifest, environment string, overlay map[string]v1alpha1.ValueConfiguration,
+) (err error) {
+	for name, value := range targetsForValueConfigurations(overlay) {
+		if def, ok := manifest.ValueDefinitions[name]; !ok {
+			multierr.AppendInto(&err, NewValidationError(name, ErrNoDef))
+		} else if !value.Skip() {
+			multierr.AppendInto(&err, ValidateSingle(name, def, value.Get()))
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("overlay %v: %w", environment, err)
+	}
+	return nil
+}
+
+// ValidateValueConfigurations is like ValidatePackage, but validates the given value configurations before they are
+// assigned to a package.
+func ValidateValueConfigurations(
+	manifest v1alpha1.PackageManifest, values map[string]v1alpha1.ValueConfiguration) error {
+	return validate(manifest, targetsForValueConfigurations(values))
 }
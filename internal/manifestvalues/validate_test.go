@@ -19,6 +19,10 @@ var _ = Describe("ValidateResolvedValues", func() {
 	five := 5
 	ten := 10
 	pattern := "a{2,3}b+"
+	email := v1alpha1.ValueFormatEmail
+	uri := v1alpha1.ValueFormatURI
+	hostname := v1alpha1.ValueFormatHostname
+	ipv4 := v1alpha1.ValueFormatIPv4
 	manifestWithConstraints := v1alpha1.PackageManifest{
 		ValueDefinitions: map[string]v1alpha1.ValueDefinition{
 			"minmaxstr": {
@@ -45,7 +49,11 @@ var _ = Describe("ValidateResolvedValues", func() {
 				Type:    v1alpha1.ValueTypeOptions,
 				Options: []string{"foo", "bar"},
 			},
-			"bool": {Type: v1alpha1.ValueTypeBoolean},
+			"bool":     {Type: v1alpha1.ValueTypeBoolean},
+			"email":    {Type: v1alpha1.ValueTypeText, Constraints: v1alpha1.ValueDefinitionConstraints{Format: &email}},
+			"uri":      {Type: v1alpha1.ValueTypeText, Constraints: v1alpha1.ValueDefinitionConstraints{Format: &uri}},
+			"hostname": {Type: v1alpha1.ValueTypeText, Constraints: v1alpha1.ValueDefinitionConstraints{Format: &hostname}},
+			"ipv4":     {Type: v1alpha1.ValueTypeText, Constraints: v1alpha1.ValueDefinitionConstraints{Format: &ipv4}},
 		},
 	}
 	DescribeTable("Validating values",
@@ -77,5 +85,58 @@ var _ = Describe("ValidateResolvedValues", func() {
 		Entry("When correct bool format: true", manifestWithConstraints, map[string]string{"bool": "true"}, true),
 		Entry("When correct bool format: false", manifestWithConstraints, map[string]string{"bool": "false"}, true),
 		Entry("When correct bool format: 1", manifestWithConstraints, map[string]string{"bool": "1"}, true),
+		Entry("When email format violated", manifestWithConstraints, map[string]string{"email": "foo"}, false),
+		Entry("When email format not violated",
+			manifestWithConstraints, map[string]string{"email": "foo@example.com"}, true),
+		Entry("When uri format violated", manifestWithConstraints, map[string]string{"uri": "example.com"}, false),
+		Entry("When uri format not violated",
+			manifestWithConstraints, map[string]string{"uri": "https://example.com/foo"}, true),
+		Entry("When hostname format violated", manifestWithConstraints, map[string]string{"hostname": "foo_bar"}, false),
+		Entry("When hostname format not violated",
+			manifestWithConstraints, map[string]string{"hostname": "Foo.example.com"}, true),
+		Entry("When ipv4 format violated", manifestWithConstraints, map[string]string{"ipv4": "::1"}, false),
+		Entry("When ipv4 format not violated", manifestWithConstraints, map[string]string{"ipv4": "10.0.0.1"}, true),
 	)
+
+	It("should not include the value in error messages", func() {
+		for _, name := range []string{"minmax", "bool"} {
+			err := ValidateResolvedValues(manifestWithConstraints, map[string]string{name: "s3cr3t"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).NotTo(ContainSubstring("s3cr3t"))
+		}
+	})
+})
+
+var _ = Describe("ValidatePackage", func() {
+	manifest := v1alpha1.PackageManifest{
+		ValueDefinitions: map[string]v1alpha1.ValueDefinition{
+			"required": {Type: v1alpha1.ValueTypeText, Constraints: v1alpha1.ValueDefinitionConstraints{Required: true}},
+			"number":   {Type: v1alpha1.ValueTypeNumber},
+		},
+	}
+	literal := func(value string) v1alpha1.ValueConfiguration {
+		return v1alpha1.ValueConfiguration{InlineValueConfiguration: v1alpha1.InlineValueConfiguration{Value: &value}}
+	}
+	newPackage := func(overlay map[string]v1alpha1.ValueConfiguration) *v1alpha1.ClusterPackage {
+		return &v1alpha1.ClusterPackage{Spec: v1alpha1.PackageSpec{
+			Values:   map[string]v1alpha1.ValueConfiguration{"required": literal("a")},
+			Overlays: map[string]map[string]v1alpha1.ValueConfiguration{"prod": overlay},
+		}}
+	}
+
+	It("should accept overlays without required values", func() {
+		Expect(ValidatePackage(manifest, newPackage(map[string]v1alpha1.ValueConfiguration{
+			"number": literal("1"),
+		}))).To(Succeed())
+	})
+
+	It("should reject invalid values in overlays", func() {
+		err := ValidatePackage(manifest, newPackage(map[string]v1alpha1.ValueConfiguration{"number": literal("a")}))
+		Expect(err).To(MatchError(ContainSubstring("overlay prod")))
+	})
+
+	It("should reject values in overlays without value definition", func() {
+		err := ValidatePackage(manifest, newPackage(map[string]v1alpha1.ValueConfiguration{"unknown": literal("a")}))
+		Expect(err).To(MatchError(ContainSubstring("overlay prod")))
+	})
 })
@@ -1,6 +1,9 @@
 package names
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
 	"strings"
 
 	"github.com/glasskube/glasskube/api/v1alpha1"
@@ -13,9 +16,26 @@ func PackageInfoName(pkg ctrlpkg.Package) string {
 	if spec.PackageInfo.RepositoryName != "" {
 		parts = append([]string{spec.PackageInfo.RepositoryName}, parts...)
 	}
+	if _, digest, ok := strings.Cut(spec.PackageInfo.Digest, ":"); ok && len(digest) >= 12 {
+		// packages that pin a digest must not share a PackageInfo with packages that don't
+		parts = append(parts, digest[:12])
+	}
 	return escapeResourceName(strings.Join(parts, "--"))
 }
 
 func HelmResourceName(pkg ctrlpkg.Package, manifest *v1alpha1.PackageManifest) string {
 	return strings.Join([]string{pkg.GetName(), manifest.Name}, "-")
 }
+
+// HookJobName returns the name of the Job of a hook. It is unique for every version of the package and every attempt
+// to run the hook, and short enough to be used as the value of the job-name label of its pods.
+func HookJobName(pkg ctrlpkg.Package, phase v1alpha1.HookPhase, hook string, version string, attempt int32) string {
+	hash := sha256.Sum256(
+		[]byte(strings.Join([]string{pkg.GetNamespace(), string(phase), version, strconv.Itoa(int(attempt))}, "/")))
+	suffix := "-" + hex.EncodeToString(hash[:])[:8]
+	name := escapeResourceName(strings.Join([]string{pkg.GetName(), hook}, "-"))
+	if len(name)+len(suffix) > 63 {
+		name = name[:63-len(suffix)]
+	}
+	return strings.TrimRight(name, "-.") + suffix
+}
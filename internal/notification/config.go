@@ -0,0 +1,141 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/glasskube/glasskube/internal/adapter"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	Namespace     = "glasskube-system"
+	ConfigMapName = "glasskube-notifications"
+	SecretName    = "glasskube-notifications"
+
+	keyUrl       = "url"
+	keyFormat    = "format"
+	keyPackages  = "packages"
+	keySecret    = "secret"
+	keyUnhealthy = "unhealthy"
+)
+
+type Format string
+
+const (
+	FormatGeneric Format = "generic"
+	FormatSlack   Format = "slack"
+)
+
+var Formats = []Format{FormatGeneric, FormatSlack}
+
+// Config is the webhook configuration for update and health notifications. It is stored in a ConfigMap, except for
+// the secret used for signing requests, which is stored in a Secret. Both are located in the glasskube-system
+// namespace.
+type Config struct {
+	URL    string
+	Format Format
+	// Packages contains the names of all packages that notifications are sent for. If it is empty, notifications are
+	// sent for all packages.
+	Packages []string
+	// Secret is used to sign the request body with HMAC-SHA256. If it is empty, requests are not signed.
+	Secret string
+	// Unhealthy enables notifications for installed packages whose workloads become unhealthy
+	Unhealthy bool
+}
+
+func (c *Config) Enabled() bool {
+	return c != nil && c.URL != ""
+}
+
+// Watches returns true if notifications should be sent for the package with the given name
+func (c *Config) Watches(packageName string) bool {
+	return c.Enabled() && (len(c.Packages) == 0 || slices.Contains(c.Packages, packageName))
+}
+
+func (c *Config) Validate() error {
+	if c.URL != "" {
+		if u, err := url.Parse(c.URL); err != nil {
+			return fmt.Errorf("invalid webhook url: %w", err)
+		} else if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("invalid webhook url: must be an absolute http or https url")
+		}
+	}
+	if !slices.Contains(Formats, c.Format) {
+		return fmt.Errorf("invalid format: %v", c.Format)
+	}
+	return nil
+}
+
+// ConfigFromResources reads the configuration from the given ConfigMap and Secret. The secret may be nil.
+func ConfigFromResources(cm *corev1.ConfigMap, secret *corev1.Secret) *Config {
+	config := Config{
+		URL:    strings.TrimSpace(cm.Data[keyUrl]),
+		Format: Format(cm.Data[keyFormat]),
+	}
+	config.Unhealthy, _ = strconv.ParseBool(cm.Data[keyUnhealthy])
+	if config.Format == "" {
+		config.Format = FormatGeneric
+	}
+	config.Packages = ParsePackages(cm.Data[keyPackages])
+	if secret != nil {
+		config.Secret = string(secret.Data[keySecret])
+	}
+	return &config
+}
+
+// ParsePackages parses a comma separated list of package names
+func ParsePackages(value string) []string {
+	var packages []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			packages = append(packages, name)
+		}
+	}
+	return packages
+}
+
+// ConfigMap returns the ConfigMap that stores this configuration, without the secret
+func (c *Config) ConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: ConfigMapName, Namespace: Namespace},
+		Data: map[string]string{
+			keyUrl:       c.URL,
+			keyFormat:    string(c.Format),
+			keyPackages:  strings.Join(c.Packages, ","),
+			keyUnhealthy: strconv.FormatBool(c.Unhealthy),
+		},
+	}
+}
+
+// SecretResource returns the Secret that stores the signing secret of this configuration
+func (c *Config) SecretResource() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: SecretName, Namespace: Namespace},
+		Data:       map[string][]byte{keySecret: []byte(c.Secret)},
+	}
+}
+
+// LoadConfig loads the notification configuration from the cluster. If no configuration exists, nil is returned.
+func LoadConfig(ctx context.Context, client adapter.KubernetesClientAdapter) (*Config, error) {
+	cm, err := client.GetConfigMap(ctx, ConfigMapName, Namespace)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	secret, err := client.GetSecret(ctx, SecretName, Namespace)
+	if apierrors.IsNotFound(err) {
+		secret = nil
+	} else if err != nil {
+		return nil, err
+	}
+	return ConfigFromResources(cm, secret), nil
+}
@@ -0,0 +1,91 @@
+package notification
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config", func() {
+	DescribeTable("ParsePackages",
+		func(value string, expected []string) {
+			Expect(ParsePackages(value)).To(Equal(expected))
+		},
+		Entry("empty", "", nil),
+		Entry("single", "cert-manager", []string{"cert-manager"}),
+		Entry("multiple with whitespace", " cert-manager, ingress-nginx ,,", []string{"cert-manager", "ingress-nginx"}),
+	)
+
+	DescribeTable("Watches",
+		func(config *Config, name string, expected bool) {
+			Expect(config.Watches(name)).To(Equal(expected))
+		},
+		Entry("nil config", nil, "foo", false),
+		Entry("no url", &Config{Packages: []string{"foo"}}, "foo", false),
+		Entry("all packages", &Config{URL: "https://example.com"}, "foo", true),
+		Entry("listed package", &Config{URL: "https://example.com", Packages: []string{"foo"}}, "foo", true),
+		Entry("unlisted package", &Config{URL: "https://example.com", Packages: []string{"bar"}}, "foo", false),
+	)
+
+	DescribeTable("Validate",
+		func(config Config, valid bool) {
+			if valid {
+				Expect(config.Validate()).To(Succeed())
+			} else {
+				Expect(config.Validate()).NotTo(Succeed())
+			}
+		},
+		Entry("disabled", Config{Format: FormatGeneric}, true),
+		Entry("https url", Config{URL: "https://example.com/hook", Format: FormatSlack}, true),
+		Entry("relative url", Config{URL: "/hook", Format: FormatGeneric}, false),
+		Entry("unsupported scheme", Config{URL: "ftp://example.com", Format: FormatGeneric}, false),
+		Entry("unsupported format", Config{URL: "https://example.com", Format: "xml"}, false),
+	)
+})
+
+var _ = Describe("Notifier", func() {
+	updates := []UpdateAvailable{{
+		Name:             "foo",
+		PackageName:      "foo",
+		InstalledVersion: "v1.0.0+1",
+		LatestVersion:    "v1.1.0+1",
+	}}
+
+	It("should create a generic payload", func() {
+		body, err := payload(FormatGeneric, updates)
+		Expect(err).NotTo(HaveOccurred())
+		var result genericPayload
+		Expect(json.Unmarshal(body, &result)).To(Succeed())
+		Expect(result.Event).To(Equal(eventUpdateAvailable))
+		Expect(result.Packages).To(Equal(updates))
+	})
+
+	It("should create a slack payload", func() {
+		body, err := payload(FormatSlack, updates)
+		Expect(err).NotTo(HaveOccurred())
+		var result slackPayload
+		Expect(json.Unmarshal(body, &result)).To(Succeed())
+		Expect(result.Text).To(ContainSubstring("*foo* (foo): v1.0.0+1 → v1.1.0+1"))
+	})
+
+	It("should create an unhealthy payload", func() {
+		pkg := PackageUnhealthy{
+			Name: "foo", Namespace: "bar", PackageName: "foo", Message: "Deployment bar/foo is unavailable"}
+		body, err := unhealthyPayloadFor(FormatGeneric, pkg)
+		Expect(err).NotTo(HaveOccurred())
+		var result unhealthyPayload
+		Expect(json.Unmarshal(body, &result)).To(Succeed())
+		Expect(result.Event).To(Equal(eventPackageUnhealthy))
+		Expect(result.Package).To(Equal(pkg))
+
+		body, err = unhealthyPayloadFor(FormatSlack, pkg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(body)).To(ContainSubstring("*bar/foo* (foo) is unhealthy"))
+	})
+
+	It("should sign the body", func() {
+		Expect(Sign("It's a Secret to Everybody", []byte("Hello, World!"))).To(Equal(
+			"sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"))
+	})
+})
@@ -0,0 +1,149 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/glasskube/glasskube/internal/httperror"
+)
+
+// SignatureHeader contains the HMAC-SHA256 signature of the request body, if a secret is configured.
+// The format is the same as used by GitHub webhooks: "sha256=<hex encoded signature>"
+const SignatureHeader = "X-Glasskube-Signature-256"
+
+const (
+	eventUpdateAvailable  = "update-available"
+	eventPackageUnhealthy = "package-unhealthy"
+)
+
+// UpdateAvailable describes an installed package for which a newer version is available
+type UpdateAvailable struct {
+	Name             string `json:"name"`
+	Namespace        string `json:"namespace,omitempty"`
+	PackageName      string `json:"packageName"`
+	Repository       string `json:"repository,omitempty"`
+	InstalledVersion string `json:"installedVersion"`
+	LatestVersion    string `json:"latestVersion"`
+}
+
+// PackageUnhealthy describes an installed package whose workloads have become unhealthy
+type PackageUnhealthy struct {
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace,omitempty"`
+	PackageName string `json:"packageName"`
+	Message     string `json:"message"`
+}
+
+type genericPayload struct {
+	Event    string            `json:"event"`
+	Packages []UpdateAvailable `json:"packages"`
+}
+
+type unhealthyPayload struct {
+	Event   string           `json:"event"`
+	Package PackageUnhealthy `json:"package"`
+}
+
+type slackPayload struct {
+	Text string `json:"text"`
+}
+
+type Notifier struct {
+	client *http.Client
+}
+
+func NewNotifier() *Notifier {
+	return &Notifier{client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// NotifyUpdatesAvailable sends a single request containing all given updates to the configured webhook
+func (n *Notifier) NotifyUpdatesAvailable(ctx context.Context, config *Config, updates []UpdateAvailable) error {
+	if !config.Enabled() || len(updates) == 0 {
+		return nil
+	}
+	if body, err := payload(config.Format, updates); err != nil {
+		return err
+	} else {
+		return n.send(ctx, config, body)
+	}
+}
+
+// NotifyPackageUnhealthy sends a request for a package whose workloads have become unhealthy to the configured webhook
+func (n *Notifier) NotifyPackageUnhealthy(ctx context.Context, config *Config, pkg PackageUnhealthy) error {
+	if !config.Enabled() || !config.Unhealthy {
+		return nil
+	}
+	if body, err := unhealthyPayloadFor(config.Format, pkg); err != nil {
+		return err
+	} else {
+		return n.send(ctx, config, body)
+	}
+}
+
+func (n *Notifier) send(ctx context.Context, config *Config, body []byte) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, config.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if config.Secret != "" {
+		request.Header.Set(SignatureHeader, Sign(config.Secret, body))
+	}
+	if resp, err := httperror.CheckResponse(n.client.Do(request)); err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	} else {
+		return resp.Body.Close()
+	}
+}
+
+func payload(format Format, updates []UpdateAvailable) ([]byte, error) {
+	switch format {
+	case FormatSlack:
+		lines := make([]string, len(updates))
+		for i, update := range updates {
+			name := update.Name
+			if update.Namespace != "" {
+				name = update.Namespace + "/" + update.Name
+			}
+			lines[i] = fmt.Sprintf("• *%v* (%v): %v → %v",
+				name, update.PackageName, update.InstalledVersion, update.LatestVersion)
+		}
+		return json.Marshal(slackPayload{Text: "Package updates are available:\n" + strings.Join(lines, "\n")})
+	case FormatGeneric, "":
+		return json.Marshal(genericPayload{Event: eventUpdateAvailable, Packages: updates})
+	default:
+		return nil, fmt.Errorf("unsupported format: %v", format)
+	}
+}
+
+func unhealthyPayloadFor(format Format, pkg PackageUnhealthy) ([]byte, error) {
+	switch format {
+	case FormatSlack:
+		name := pkg.Name
+		if pkg.Namespace != "" {
+			name = pkg.Namespace + "/" + pkg.Name
+		}
+		return json.Marshal(slackPayload{
+			Text: fmt.Sprintf("Package *%v* (%v) is unhealthy:\n%v", name, pkg.PackageName, pkg.Message),
+		})
+	case FormatGeneric, "":
+		return json.Marshal(unhealthyPayload{Event: eventPackageUnhealthy, Package: pkg})
+	default:
+		return nil, fmt.Errorf("unsupported format: %v", format)
+	}
+}
+
+// Sign returns the value of the SignatureHeader for the given body
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
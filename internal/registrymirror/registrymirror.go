@@ -0,0 +1,175 @@
+// Package registrymirror rewrites the container images of workloads, so that they are pulled from a mirror instead of
+// their original registry.
+package registrymirror
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/adapter"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// Namespace and ConfigMapName identify the ConfigMap that contains the mirrors for the whole cluster
+	Namespace     = "glasskube-system"
+	ConfigMapName = "glasskube-registry-mirrors"
+
+	keyMirrors = "mirrors"
+
+	defaultRegistry = "docker.io"
+)
+
+// Mirrors is a list of registry mirrors. If more than one mirror matches an image, the one with the longest registry
+// is used.
+type Mirrors []v1alpha1.ImageRegistryMirror
+
+// Parse reads mirrors in the format "<registry>=<mirror>", one per line. Empty lines and lines starting with # are
+// ignored.
+func Parse(text string) (Mirrors, error) {
+	var result Mirrors
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		registry, mirror, ok := strings.Cut(line, "=")
+		registry, mirror = strings.TrimSpace(registry), strings.TrimSpace(mirror)
+		if !ok || registry == "" || mirror == "" {
+			return nil, fmt.Errorf("invalid registry mirror %q: expected <registry>=<mirror>", line)
+		}
+		if strings.Contains(registry, "://") || strings.Contains(mirror, "://") {
+			return nil, fmt.Errorf("invalid registry mirror %q: registries must not contain a scheme", line)
+		}
+		result = append(result, v1alpha1.ImageRegistryMirror{
+			Registry: strings.TrimSuffix(registry, "/"),
+			Mirror:   strings.TrimSuffix(mirror, "/"),
+		})
+	}
+	return result, nil
+}
+
+// String returns the mirrors in the format that is accepted by Parse
+func (m Mirrors) String() string {
+	lines := make([]string, len(m))
+	for i, mirror := range m {
+		lines[i] = mirror.Registry + "=" + mirror.Mirror
+	}
+	return strings.Join(lines, "\n")
+}
+
+// FromConfigMap reads the mirrors for the whole cluster from the given ConfigMap
+func FromConfigMap(cm *corev1.ConfigMap) (Mirrors, error) {
+	return Parse(cm.Data[keyMirrors])
+}
+
+// ConfigMap returns the ConfigMap that stores these mirrors for the whole cluster
+func (m Mirrors) ConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: ConfigMapName, Namespace: Namespace},
+		Data:       map[string]string{keyMirrors: m.String()},
+	}
+}
+
+// Load loads the mirrors for the whole cluster. If the ConfigMap does not exist, no mirrors are configured.
+func Load(ctx context.Context, client adapter.KubernetesClientAdapter) (Mirrors, error) {
+	cm, err := client.GetConfigMap(ctx, ConfigMapName, Namespace)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return FromConfigMap(cm)
+}
+
+// Rewrite returns the reference of the given image on its mirror. The lists of mirrors are tried in order and the
+// first list that contains a matching mirror is used. If no mirror matches, the image is returned unchanged.
+func Rewrite(image string, mirrorLists ...Mirrors) string {
+	name := normalize(image)
+	for _, mirrors := range mirrorLists {
+		var match *v1alpha1.ImageRegistryMirror
+		for i, mirror := range mirrors {
+			registry := normalizeRegistry(mirror.Registry)
+			if (name == registry || strings.HasPrefix(name, registry+"/")) &&
+				(match == nil || len(registry) > len(normalizeRegistry(match.Registry))) {
+				match = &mirrors[i]
+			}
+		}
+		if match != nil {
+			return match.Mirror + name[len(normalizeRegistry(match.Registry)):]
+		}
+	}
+	return image
+}
+
+// normalize returns the fully qualified reference of the given image, e.g. "docker.io/library/nginx:1.27" for
+// "nginx:1.27".
+func normalize(image string) string {
+	first, rest, found := strings.Cut(image, "/")
+	if !found {
+		return defaultRegistry + "/library/" + image
+	} else if !strings.ContainsAny(first, ".:") && first != "localhost" {
+		return defaultRegistry + "/" + image
+	} else {
+		return normalizeRegistry(first) + "/" + rest
+	}
+}
+
+func normalizeRegistry(registry string) string {
+	if registry == "index.docker.io" || strings.HasPrefix(registry, "index.docker.io/") {
+		return defaultRegistry + strings.TrimPrefix(registry, "index.docker.io")
+	}
+	return registry
+}
+
+// RewriteObject rewrites the images of all containers, init containers and ephemeral containers of all pod specs
+// that are contained in the given object. This includes pods, the pod templates of all built-in workloads and pod
+// templates in custom resources. It returns true if at least one image was changed.
+func RewriteObject(obj *unstructured.Unstructured, mirrorLists ...Mirrors) bool {
+	if len(mirrorLists) == 0 {
+		return false
+	}
+	return rewriteValue(obj.Object, mirrorLists)
+}
+
+func rewriteValue(value any, mirrorLists []Mirrors) bool {
+	changed := false
+	switch v := value.(type) {
+	case map[string]any:
+		if _, ok := v["containers"].([]any); ok {
+			for _, key := range []string{"containers", "initContainers", "ephemeralContainers"} {
+				if containers, ok := v[key].([]any); ok {
+					changed = rewriteContainers(containers, mirrorLists) || changed
+				}
+			}
+		}
+		for _, child := range v {
+			changed = rewriteValue(child, mirrorLists) || changed
+		}
+	case []any:
+		for _, child := range v {
+			changed = rewriteValue(child, mirrorLists) || changed
+		}
+	}
+	return changed
+}
+
+func rewriteContainers(containers []any, mirrorLists []Mirrors) bool {
+	changed := false
+	for _, container := range containers {
+		if container, ok := container.(map[string]any); ok {
+			if image, ok := container["image"].(string); ok && image != "" {
+				if rewritten := Rewrite(image, mirrorLists...); rewritten != image {
+					container["image"] = rewritten
+					changed = true
+				}
+			}
+		}
+	}
+	return changed
+}
@@ -0,0 +1,91 @@
+package registrymirror
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("registrymirror", func() {
+	mirrors := Mirrors{
+		{Registry: "docker.io", Mirror: "myregistry.internal/dockerhub"},
+		{Registry: "ghcr.io/glasskube", Mirror: "myregistry.internal/glasskube"},
+		{Registry: "ghcr.io", Mirror: "myregistry.internal/ghcr"},
+	}
+
+	DescribeTable("Rewrite",
+		func(image, expected string) {
+			Expect(Rewrite(image, mirrors)).To(Equal(expected))
+		},
+		Entry("Official image", "nginx:1.27", "myregistry.internal/dockerhub/library/nginx:1.27"),
+		Entry("Docker Hub image", "bitnami/redis", "myregistry.internal/dockerhub/bitnami/redis"),
+		Entry("Explicit Docker Hub image", "index.docker.io/bitnami/redis@sha256:abc",
+			"myregistry.internal/dockerhub/bitnami/redis@sha256:abc"),
+		Entry("Longest match", "ghcr.io/glasskube/glasskube:v0.1.0", "myregistry.internal/glasskube/glasskube:v0.1.0"),
+		Entry("Other path", "ghcr.io/fluxcd/helm-controller", "myregistry.internal/ghcr/fluxcd/helm-controller"),
+		Entry("Path prefix must match a whole segment", "ghcr.io/glasskube-dev/foo",
+			"myregistry.internal/ghcr/glasskube-dev/foo"),
+		Entry("Unknown registry", "quay.io/jetstack/cert-manager", "quay.io/jetstack/cert-manager"),
+		Entry("Registry with port", "localhost:5000/foo", "localhost:5000/foo"),
+	)
+
+	It("should prefer earlier lists of mirrors", func() {
+		packageMirrors := Mirrors{{Registry: "docker.io/bitnami", Mirror: "bitnami.internal"}}
+		Expect(Rewrite("bitnami/redis", Mirrors{{Registry: "docker.io", Mirror: "other.internal"}}, mirrors)).
+			To(Equal("other.internal/bitnami/redis"))
+		Expect(Rewrite("bitnami/redis", packageMirrors, mirrors)).To(Equal("bitnami.internal/redis"))
+		Expect(Rewrite("nginx", packageMirrors, mirrors)).To(Equal("myregistry.internal/dockerhub/library/nginx"))
+	})
+
+	It("should rewrite all containers of a workload", func() {
+		obj := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "batch/v1",
+			"kind":       "CronJob",
+			"spec": map[string]any{
+				"jobTemplate": map[string]any{"spec": map[string]any{"template": map[string]any{"spec": map[string]any{
+					"initContainers": []any{map[string]any{"name": "sidecar", "image": "envoyproxy/envoy"}},
+					"containers":     []any{map[string]any{"name": "main", "image": "ghcr.io/glasskube/job"}},
+				}}}},
+			},
+		}}
+		Expect(RewriteObject(obj, mirrors)).To(BeTrue())
+		initContainers, _, _ := unstructured.NestedFieldNoCopy(obj.Object,
+			"spec", "jobTemplate", "spec", "template", "spec", "initContainers")
+		Expect(initContainers.([]any)[0].(map[string]any)["image"]).
+			To(Equal("myregistry.internal/dockerhub/envoyproxy/envoy"))
+		containers, _, _ := unstructured.NestedFieldNoCopy(obj.Object,
+			"spec", "jobTemplate", "spec", "template", "spec", "containers")
+		Expect(containers.([]any)[0].(map[string]any)["image"]).
+			To(Equal("myregistry.internal/glasskube/job"))
+	})
+
+	It("should parse and format mirrors", func() {
+		parsed, err := Parse("# comment\ndocker.io = myregistry.internal/dockerhub/\n\nghcr.io=myregistry.internal/ghcr")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed).To(Equal(Mirrors{
+			{Registry: "docker.io", Mirror: "myregistry.internal/dockerhub"},
+			{Registry: "ghcr.io", Mirror: "myregistry.internal/ghcr"},
+		}))
+		Expect(Parse(parsed.String())).To(Equal(parsed))
+		Expect(FromConfigMap(parsed.ConfigMap())).To(Equal(parsed))
+	})
+
+	DescribeTable("Parse should reject invalid mirrors",
+		func(text string) {
+			_, err := Parse(text)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("Missing mirror", "docker.io"),
+		Entry("Empty mirror", "docker.io="),
+		Entry("Scheme", "docker.io=https://myregistry.internal"),
+	)
+
+	It("should not change objects without mirrors", func() {
+		obj := &unstructured.Unstructured{Object: map[string]any{
+			"spec": map[string]any{"containers": []any{map[string]any{"name": "main", "image": "nginx"}}},
+		}}
+		Expect(RewriteObject(obj)).To(BeFalse())
+		Expect(RewriteObject(obj, Mirrors{{Registry: "quay.io", Mirror: "mirror"}})).To(BeFalse())
+		Expect(obj.Object["spec"].(map[string]any)["containers"].([]any)[0].(map[string]any)["image"]).To(Equal("nginx"))
+	})
+})
@@ -14,6 +14,7 @@ import (
 	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
 	"github.com/glasskube/glasskube/internal/repo/client/auth"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 type defaultClientsetClient struct {
@@ -28,6 +29,7 @@ type defaultClientset struct {
 	repoMutex               sync.Mutex
 	maxCacheAge             time.Duration
 	clientInfoCheckInterval time.Duration
+	retryBackoff            wait.Backoff
 }
 
 var _ RepoClientset = &defaultClientset{}
@@ -58,9 +60,19 @@ func NewClientsetWithMaxCacheAge(pkgClient adapter.PackageClientAdapter, k8sClie
 		clients:                 make(map[string]repoClientWithState),
 		maxCacheAge:             maxCacheAge,
 		clientInfoCheckInterval: clientInfoCheckInterval,
+		retryBackoff:            DefaultRetryBackoff,
 	}
 }
 
+// NewClientsetWithRetryBackoff creates a RepoClientset whose clients use the given backoff to retry transient
+// failures when fetching from a repository.
+func NewClientsetWithRetryBackoff(pkgClient adapter.PackageClientAdapter, k8sClient adapter.KubernetesClientAdapter,
+	retryBackoff wait.Backoff) RepoClientset {
+	clientset := NewClientset(pkgClient, k8sClient).(*defaultClientset)
+	clientset.retryBackoff = retryBackoff
+	return clientset
+}
+
 // ForPackage implements RepoClientset.
 func (d *defaultClientset) ForPackage(pkg ctrlpkg.Package) RepoClient {
 	return d.ForRepoWithName(pkg.GetSpec().PackageInfo.RepositoryName)
@@ -108,8 +120,27 @@ func (d *defaultClientset) ForRepo(repo v1alpha1.PackageRepository) RepoClient {
 	} else {
 		if auth, err := d.newAuthenticator(repo); err != nil {
 			return &errorclient{err: fmt.Errorf("invalid auth config: %w", err)}
+		} else if tlsConfig, err := d.newTLSConfig(repo); err != nil {
+			return &errorclient{err: fmt.Errorf("invalid TLS config: %w", err)}
+		} else if caBundle, err := d.getCABundle(repo); err != nil {
+			return &errorclient{err: fmt.Errorf("invalid TLS config: %w", err)}
+		} else if proxy, err := d.newProxyConfig(repo); err != nil {
+			return &errorclient{err: fmt.Errorf("invalid proxy config: %w", err)}
+		} else if provider, err := ProviderFor(repo); err != nil {
+			return &errorclient{err: err}
+		} else if client, err := provider.NewClient(repo, ProviderOptions{
+			Authenticator: auth,
+			TLSConfig:     tlsConfig,
+			CABundle:      caBundle,
+			MaxCacheAge:   d.maxCacheAge,
+			RetryBackoff:  d.retryBackoff,
+			proxy:         proxy,
+		}); err != nil {
+			return &errorclient{err: err}
 		} else {
-			client := New(repo.Spec.Url, auth, d.maxCacheAge)
+			if repo.Spec.Signature != nil {
+				client = newVerifyingClient(client, *repo.Spec.Signature)
+			}
 			d.clients[repo.Name] = repoClientWithState{
 				client:              client,
 				lastCheckedRepoSpec: time.Now(),
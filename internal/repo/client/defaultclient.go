@@ -15,15 +15,29 @@ import (
 	"github.com/glasskube/glasskube/internal/httperror"
 	"github.com/glasskube/glasskube/internal/repo/client/auth"
 	"github.com/glasskube/glasskube/internal/repo/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/util/retry"
 )
 
+// DefaultRetryBackoff is used to retry transient failures when fetching from a repository
+var DefaultRetryBackoff = wait.Backoff{
+	Duration: 500 * time.Millisecond,
+	Factor:   2,
+	Jitter:   0.2,
+	Steps:    4,
+	Cap:      5 * time.Second,
+}
+
 type defaultClient struct {
 	auth.Authenticator
-	url         string
-	maxCacheAge time.Duration
-	cache       sync.Map
-	debug       bool
+	url          string
+	maxCacheAge  time.Duration
+	retryBackoff wait.Backoff
+	// transport is used for all requests, if it is set. Otherwise, the default transport is used.
+	transport http.RoundTripper
+	cache     sync.Map
+	debug     bool
 }
 
 type cacheItem struct {
@@ -33,7 +47,12 @@ type cacheItem struct {
 }
 
 func New(url string, authenticator auth.Authenticator, maxCacheAge time.Duration) *defaultClient {
-	return &defaultClient{url: url, Authenticator: authenticator, maxCacheAge: maxCacheAge}
+	return &defaultClient{
+		url:           url,
+		Authenticator: authenticator,
+		maxCacheAge:   maxCacheAge,
+		retryBackoff:  DefaultRetryBackoff,
+	}
 }
 
 func NewDebug(url string, authenticator auth.Authenticator, maxCacheAge time.Duration) *defaultClient {
@@ -43,6 +62,12 @@ func NewDebug(url string, authenticator auth.Authenticator, maxCacheAge time.Dur
 }
 
 var _ RepoClient = &defaultClient{}
+var _ CacheInvalidator = &defaultClient{}
+
+// InvalidateCache implements CacheInvalidator.
+func (c *defaultClient) InvalidateCache() {
+	c.cache.Clear()
+}
 
 // FetchLatestPackageManifest implements repo.RepoClient.
 func (c *defaultClient) FetchLatestPackageManifest(name string, target *v1alpha1.PackageManifest) (
@@ -101,12 +126,56 @@ func (c *defaultClient) GetLatestVersion(pkgName string) (string, error) {
 }
 
 func (c *defaultClient) fetchYAMLOrJSON(url string, target any) error {
+	if path, ok := isFileURL(url); ok {
+		// local files are always read directly from disk, there is no need for caching
+		return readYAMLOrJSONFile(path, target)
+	}
+	if bytes, err := c.fetchCached(url, true); err != nil {
+		return err
+	} else {
+		return yaml.Unmarshal(bytes, target)
+	}
+}
+
+var _ RawManifestSource = &defaultClient{}
+
+// FetchPackageManifestBytes implements RawManifestSource.
+func (c *defaultClient) FetchPackageManifestBytes(name, version string) ([]byte, error) {
+	if url, err := c.GetPackageManifestURL(name, version); err != nil {
+		return nil, err
+	} else if path, ok := isFileURL(url); ok {
+		return os.ReadFile(path)
+	} else {
+		return c.fetchCached(url, true)
+	}
+}
+
+// FetchPackageManifestSignature implements RawManifestSource.
+func (c *defaultClient) FetchPackageManifestSignature(name, version string) ([]byte, error) {
+	pathSegments := []string{url.PathEscape(name), url.PathEscape(version), packageManifestSignatureFile}
+	if sigURL, err := url.JoinPath(c.getBaseURL(), pathSegments...); err != nil {
+		return nil, err
+	} else if path, ok := isFileURL(sigURL); ok {
+		if bytes, err := os.ReadFile(path); errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		} else {
+			return bytes, err
+		}
+	} else if bytes, err := c.fetchCached(sigURL, false); httperror.IsNotFound(err) {
+		return nil, nil
+	} else {
+		return bytes, err
+	}
+}
+
+// fetchCached returns the response for url from the cache, or fetches it if it is not cached or too old
+func (c *defaultClient) fetchCached(url string, checkContentType bool) ([]byte, error) {
 	cached := &cacheItem{}
 	if c, hit := c.cache.LoadOrStore(url, cached); hit {
 		if c, ok := c.(*cacheItem); ok {
 			cached = c
 		} else {
-			return errors.New("unexpected cache type")
+			return nil, errors.New("unexpected cache type")
 		}
 	}
 
@@ -118,37 +187,75 @@ func (c *defaultClient) fetchYAMLOrJSON(url string, target any) error {
 		if c.debug {
 			fmt.Fprintln(os.Stderr, "cache hit (after lock)", url)
 		}
-		return yaml.Unmarshal(cached.bytes, target)
+		return cached.bytes, nil
 	}
 
 	if c.debug {
 		fmt.Fprintln(os.Stderr, "cache miss", url)
 	}
 
-	request, err := http.NewRequest(http.MethodGet, url, nil)
+	bytes, err := c.fetchWithRetry(url, checkContentType)
 	if err != nil {
+		return nil, err
+	}
+	cached.bytes = bytes
+	cached.updated = time.Now()
+	return bytes, nil
+}
+
+// fetchWithRetry repeats the request with exponential backoff as long as it fails with a transient error (server
+// errors, timeouts, connection errors). Client errors (4xx) are returned immediately.
+func (c *defaultClient) fetchWithRetry(url string, checkContentType bool) (bytes []byte, err error) {
+	attempt := 0
+	err = retry.OnError(c.retryBackoff, httperror.IsTransient, func() error {
+		if attempt++; attempt > 1 && c.debug {
+			fmt.Fprintln(os.Stderr, "retry", attempt, url)
+		}
+		bytes, err = c.fetch(url, checkContentType)
 		return err
+	})
+	return
+}
+
+// fetch requests url. If checkContentType is true, the response must be JSON or YAML.
+func (c *defaultClient) fetch(url string, checkContentType bool) ([]byte, error) {
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
 	}
 	c.Authenticate(request)
-	request.Header.Add("Accept", contenttype.MediaTypeJSON)
-	request.Header.Add("Accept", contenttype.MediaTypeYAML)
-	resp, err := httperror.CheckResponse(http.DefaultClient.Do(request))
+	if checkContentType {
+		request.Header.Add("Accept", contenttype.MediaTypeJSON)
+		request.Header.Add("Accept", contenttype.MediaTypeYAML)
+	}
+	resp, err := httperror.CheckResponse(c.httpClient().Do(request))
 	if err != nil {
-		return fmt.Errorf("failed to fetch %v: %w", url, err)
+		return nil, fmt.Errorf("failed to fetch %v: %w", url, err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	if err := contenttype.IsJsonOrYaml(resp); err != nil {
-		return fmt.Errorf("could not decode %v: %w", url, err)
+	if checkContentType {
+		if err := contenttype.IsJsonOrYaml(resp); err != nil {
+			return nil, fmt.Errorf("could not decode %v: %w", url, err)
+		}
 	}
 
-	if bytes, err := io.ReadAll(resp.Body); err != nil {
-		return err
+	return io.ReadAll(resp.Body)
+}
+
+func (c *defaultClient) httpClient() *http.Client {
+	if c.transport == nil {
+		return http.DefaultClient
+	}
+	return &http.Client{Transport: c.transport}
+}
+
+func readYAMLOrJSONFile(path string, target any) error {
+	if bytes, err := os.ReadFile(path); err != nil {
+		return fmt.Errorf("failed to read local repository file: %w", err)
 	} else if err := yaml.Unmarshal(bytes, target); err != nil {
-		return err
+		return fmt.Errorf("could not decode %v: %w", path, err)
 	} else {
-		cached.bytes = bytes
-		cached.updated = time.Now()
 		return nil
 	}
 }
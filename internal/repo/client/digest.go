@@ -0,0 +1,54 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const digestAlgorithm = "sha256"
+
+var ErrDigestMismatch = errors.New("manifest digest mismatch")
+
+// ManifestDigest returns the digest of the raw content of a package manifest, e.g. "sha256:…"
+func ManifestDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return digestAlgorithm + ":" + hex.EncodeToString(sum[:])
+}
+
+// FetchPackageManifestWithDigest fetches the manifest of the given package version and returns the digest of its
+// content. If digest is not empty, the manifest is only decoded into target if it has this digest. Otherwise, an error
+// wrapping ErrDigestMismatch is returned.
+//
+// Clients that can not provide the raw manifest are only supported if digest is empty. In that case, the manifest is
+// fetched as usual and an empty digest is returned.
+func FetchPackageManifestWithDigest(
+	client RepoClient,
+	name, version, digest string,
+	target *v1alpha1.PackageManifest,
+) (string, error) {
+	source, ok := client.(RawManifestSource)
+	if !ok {
+		if digest != "" {
+			return "", errors.New("manifest digests are not supported for this repository")
+		}
+		return "", client.FetchPackageManifest(name, version, target)
+	}
+	data, err := source.FetchPackageManifestBytes(name, version)
+	if err != nil {
+		return "", err
+	}
+	actual := ManifestDigest(data)
+	if digest != "" && digest != actual {
+		return actual, fmt.Errorf("%w: %v version %v has digest %v, but %v was expected",
+			ErrDigestMismatch, name, version, actual, digest)
+	}
+	if err := yaml.Unmarshal(data, target); err != nil {
+		return actual, fmt.Errorf("could not decode manifest of %v version %v: %w", name, version, err)
+	}
+	return actual, nil
+}
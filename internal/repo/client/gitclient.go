@@ -0,0 +1,297 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/httperror"
+	"github.com/glasskube/glasskube/internal/repo/client/auth"
+	"github.com/glasskube/glasskube/internal/repo/types"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const gitRemoteName = "origin"
+
+// GitSyncer is implemented by clients of package repositories that are backed by a git repository.
+type GitSyncer interface {
+	// Sync fetches the tracked ref from the remote and returns the SHA of the commit it resolves to.
+	Sync() (commit string, err error)
+}
+
+// gitClient is a RepoClient for package repositories that are read from a git repository. The repository is cloned
+// into memory and files are read from the commit that the configured ref resolves to, using the same layout as an
+// HTTP repository (index.yaml, <package>/versions.yaml and <package>/<version>/package.yaml below the configured path).
+//
+// The ref can be a branch, a tag or a (possibly abbreviated) commit SHA. Only commits that are reachable from a branch
+// or tag of the remote can be used. Because the files of a git repository cannot be downloaded individually, package
+// manifests that reference other files with a relative URL are not supported.
+type gitClient struct {
+	auth.Authenticator
+	url         string
+	ref         string
+	path        string
+	maxCacheAge time.Duration
+	// caBundle contains PEM encoded CA certificates that are trusted in addition to the system trust store
+	caBundle []byte
+	// proxyUrl is the proxy used instead of the one from the environment, if it is not empty
+	proxyUrl      string
+	mutex         sync.Mutex
+	repo          *git.Repository
+	defaultBranch string
+	commit        *object.Commit
+	fetched       time.Time
+}
+
+func NewGit(url string, spec v1alpha1.PackageRepositoryGitSpec, authenticator auth.Authenticator,
+	maxCacheAge time.Duration) *gitClient {
+	return &gitClient{
+		Authenticator: authenticator,
+		url:           url,
+		ref:           spec.Ref,
+		path:          spec.Path,
+		maxCacheAge:   maxCacheAge,
+	}
+}
+
+var _ RepoClient = &gitClient{}
+var _ GitSyncer = &gitClient{}
+var _ CacheInvalidator = &gitClient{}
+
+// InvalidateCache implements CacheInvalidator.
+// The next request fetches the tracked ref from the remote again.
+func (c *gitClient) InvalidateCache() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.fetched = time.Time{}
+}
+
+// Sync implements GitSyncer.
+func (c *gitClient) Sync() (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err := c.fetch(); err != nil {
+		return "", err
+	}
+	return c.commit.Hash.String(), nil
+}
+
+// FetchLatestPackageManifest implements RepoClient.
+func (c *gitClient) FetchLatestPackageManifest(name string, target *v1alpha1.PackageManifest) (string, error) {
+	var versions types.PackageIndex
+	if err := c.FetchPackageIndex(name, &versions); err != nil {
+		return "", err
+	}
+	return versions.LatestVersion, c.FetchPackageManifest(name, versions.LatestVersion, target)
+}
+
+// FetchPackageManifest implements RepoClient.
+func (c *gitClient) FetchPackageManifest(name, version string, target *v1alpha1.PackageManifest) error {
+	return c.readFile(path.Join(name, version, "package.yaml"), target)
+}
+
+// FetchPackageIndex implements RepoClient.
+func (c *gitClient) FetchPackageIndex(name string, target *types.PackageIndex) error {
+	return c.readFile(path.Join(name, "versions.yaml"), target)
+}
+
+// FetchPackageRepoIndex implements RepoClient.
+func (c *gitClient) FetchPackageRepoIndex(target *types.PackageRepoIndex) error {
+	return c.readFile("index.yaml", target)
+}
+
+// GetLatestVersion implements RepoClient.
+func (c *gitClient) GetLatestVersion(pkgName string) (string, error) {
+	var idx types.PackageRepoIndex
+	if err := c.FetchPackageRepoIndex(&idx); err != nil {
+		return "", err
+	}
+	for _, pkg := range idx.Packages {
+		if pkg.Name == pkgName {
+			return pkg.LatestVersion, nil
+		}
+	}
+	return "", nil
+}
+
+// GetPackageManifestURL implements RepoClient.
+// The returned URL uses the "<repository>//<path>?ref=<ref>" notation that is common for files in git repositories.
+// It identifies the manifest, but can not be fetched with a plain HTTP request.
+func (c *gitClient) GetPackageManifestURL(name, version string) (string, error) {
+	query := url.Values{}
+	if c.ref != "" {
+		query.Set("ref", c.ref)
+	}
+	result := c.url + "//" + path.Join(c.path, name, version, "package.yaml")
+	if len(query) > 0 {
+		result += "?" + query.Encode()
+	}
+	return result, nil
+}
+
+var _ RawManifestSource = &gitClient{}
+
+// FetchPackageManifestBytes implements RawManifestSource.
+func (c *gitClient) FetchPackageManifestBytes(name, version string) ([]byte, error) {
+	return c.readBytes(path.Join(name, version, "package.yaml"))
+}
+
+// FetchPackageManifestSignature implements RawManifestSource.
+func (c *gitClient) FetchPackageManifestSignature(name, version string) ([]byte, error) {
+	if bytes, err := c.readBytes(path.Join(name, version, packageManifestSignatureFile)); httperror.IsNotFound(err) {
+		return nil, nil
+	} else {
+		return bytes, err
+	}
+}
+
+func (c *gitClient) readFile(name string, target any) error {
+	if bytes, err := c.readBytes(name); err != nil {
+		return err
+	} else if err := yaml.Unmarshal(bytes, target); err != nil {
+		return fmt.Errorf("could not decode %v: %w", path.Join(c.path, name), err)
+	} else {
+		return nil
+	}
+}
+
+func (c *gitClient) readBytes(name string) ([]byte, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.commit == nil || c.fetched.Add(c.maxCacheAge).Before(time.Now()) {
+		if err := c.fetch(); err != nil {
+			return nil, err
+		}
+	}
+	filePath := path.Join(c.path, name)
+	if file, err := c.commit.File(filePath); errors.Is(err, object.ErrFileNotFound) {
+		return nil, fmt.Errorf("%v not found at commit %v: %w",
+			filePath, c.commit.Hash, httperror.FromStatusCode(http.StatusNotFound))
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read %v: %w", filePath, err)
+	} else if contents, err := file.Contents(); err != nil {
+		return nil, fmt.Errorf("failed to read %v: %w", filePath, err)
+	} else {
+		return []byte(contents), nil
+	}
+}
+
+// fetch clones the repository on first use and fetches all branches and tags afterwards. Then, the configured ref is
+// resolved to a commit. The caller must hold the mutex.
+func (c *gitClient) fetch() error {
+	if c.repo == nil {
+		repo, err := git.Clone(memory.NewStorage(), nil, &git.CloneOptions{
+			URL:          c.url,
+			RemoteName:   gitRemoteName,
+			Auth:         c.authMethod(),
+			Tags:         git.AllTags,
+			CABundle:     c.caBundle,
+			ProxyOptions: transport.ProxyOptions{URL: c.proxyUrl},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to clone %v: %w", c.url, convertGitError(err))
+		}
+		if head, err := repo.Head(); err != nil {
+			return fmt.Errorf("failed to determine default branch of %v: %w", c.url, err)
+		} else {
+			c.defaultBranch = head.Name().Short()
+		}
+		c.repo = repo
+	} else {
+		err := c.repo.Fetch(&git.FetchOptions{
+			RemoteName: gitRemoteName,
+			RefSpecs: []config.RefSpec{
+				config.RefSpec(fmt.Sprintf(config.DefaultFetchRefSpec, gitRemoteName)),
+				"+refs/tags/*:refs/tags/*",
+			},
+			Auth:         c.authMethod(),
+			Tags:         git.AllTags,
+			Force:        true,
+			CABundle:     c.caBundle,
+			ProxyOptions: transport.ProxyOptions{URL: c.proxyUrl},
+		})
+		if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+			return fmt.Errorf("failed to fetch %v: %w", c.url, convertGitError(err))
+		}
+	}
+
+	if commit, err := c.resolve(); err != nil {
+		return err
+	} else {
+		c.commit = commit
+		c.fetched = time.Now()
+		return nil
+	}
+}
+
+// convertGitError unwraps errors of the git transport, which don't support unwrapping, so that callers can check the
+// cause, e.g. with httperror.IsTLSError.
+func convertGitError(err error) error {
+	var unexpectedErr *plumbing.UnexpectedError
+	if errors.As(err, &unexpectedErr) {
+		return unexpectedErr.Err
+	}
+	return err
+}
+
+// resolve returns the commit that the configured ref points to. Like git, branches take precedence over tags, which
+// take precedence over commit SHAs.
+func (c *gitClient) resolve() (*object.Commit, error) {
+	ref := c.ref
+	if ref == "" {
+		ref = c.defaultBranch
+	}
+	candidates := []plumbing.Revision{
+		plumbing.Revision(plumbing.NewRemoteReferenceName(gitRemoteName, ref)),
+		plumbing.Revision(plumbing.NewTagReferenceName(ref)),
+		plumbing.Revision(ref),
+	}
+	for _, candidate := range candidates {
+		if hash, err := c.repo.ResolveRevision(candidate); err == nil {
+			return c.repo.CommitObject(*hash)
+		}
+	}
+	return nil, fmt.Errorf("ref %v not found in %v: %w", ref, c.url, httperror.FromStatusCode(http.StatusNotFound))
+}
+
+func (c *gitClient) authMethod() githttp.AuthMethod {
+	if c.Authenticator == nil {
+		return nil
+	}
+	if _, ok := c.Authenticator.(auth.NoopAuthenticator); ok {
+		return nil
+	}
+	return &gitAuthMethod{c.Authenticator}
+}
+
+// gitAuthMethod adapts an Authenticator, so that it can be used for git operations over HTTP
+type gitAuthMethod struct {
+	auth.Authenticator
+}
+
+// Name implements githttp.AuthMethod.
+func (a *gitAuthMethod) Name() string {
+	return "glasskube-repository-auth"
+}
+
+// String implements githttp.AuthMethod.
+func (a *gitAuthMethod) String() string {
+	return a.Name()
+}
+
+// SetAuth implements githttp.AuthMethod.
+func (a *gitAuthMethod) SetAuth(r *http.Request) {
+	a.Authenticate(r)
+}
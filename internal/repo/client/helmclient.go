@@ -0,0 +1,298 @@
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/httperror"
+	"github.com/glasskube/glasskube/internal/maputils"
+	"github.com/glasskube/glasskube/internal/repo/client/auth"
+	"github.com/glasskube/glasskube/internal/repo/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	helmChartValuesFile       = "values.yaml"
+	helmChartValuesSchemaFile = "values.schema.json"
+)
+
+// helmClient is a RepoClient for Helm chart repositories. Every chart in the index.yaml of the repository is
+// presented as a package, whose manifest is generated from the chart: It installs the chart with the Helm adapter and
+// has a value definition for every scalar value of the chart (see generateHelmValueDefinitions).
+// Requests are made by a defaultClient, so authentication, TLS, proxies, retries and caching work the same as for
+// other HTTP repositories. It is not embedded, because its raw manifests must not be used for signature verification.
+type helmClient struct {
+	auth.Authenticator
+	url    string
+	client *defaultClient
+	// charts restricts the available charts, if it is not empty
+	charts []string
+}
+
+type helmRepoIndex struct {
+	Entries map[string][]helmChartVersion `json:"entries"`
+}
+
+type helmChartVersion struct {
+	Name        string   `json:"name"`
+	Version     string   `json:"version"`
+	Description string   `json:"description,omitempty"`
+	Icon        string   `json:"icon,omitempty"`
+	Keywords    []string `json:"keywords,omitempty"`
+	URLs        []string `json:"urls,omitempty"`
+	Deprecated  bool     `json:"deprecated,omitempty"`
+}
+
+func NewHelm(
+	url string, spec v1alpha1.PackageRepositoryHelmSpec, authenticator auth.Authenticator, maxCacheAge time.Duration,
+) *helmClient {
+	return &helmClient{
+		Authenticator: authenticator,
+		url:           url,
+		client:        New(url, authenticator, maxCacheAge),
+		charts:        spec.Charts,
+	}
+}
+
+var _ RepoClient = &helmClient{}
+var _ CacheInvalidator = &helmClient{}
+
+// InvalidateCache implements CacheInvalidator.
+func (c *helmClient) InvalidateCache() {
+	c.client.InvalidateCache()
+}
+
+// FetchPackageRepoIndex implements RepoClient.
+func (c *helmClient) FetchPackageRepoIndex(target *types.PackageRepoIndex) error {
+	index, err := c.fetchHelmIndex()
+	if err != nil {
+		return err
+	}
+	target.Packages = nil
+	for _, name := range maputils.KeysSorted(index.Entries) {
+		if versions := sortedChartVersions(index.Entries[name]); len(versions) > 0 {
+			latest := versions[len(versions)-1]
+			target.Packages = append(target.Packages, types.PackageRepoIndexItem{
+				Name:             name,
+				ShortDescription: latest.Description,
+				IconUrl:          latest.Icon,
+				LatestVersion:    latest.Version,
+				Keywords:         latest.Keywords,
+			})
+		}
+	}
+	return nil
+}
+
+// FetchPackageIndex implements RepoClient.
+func (c *helmClient) FetchPackageIndex(name string, target *types.PackageIndex) error {
+	versions, err := c.fetchChartVersions(name)
+	if err != nil {
+		return err
+	}
+	target.Versions = make([]types.PackageIndexItem, len(versions))
+	for i, v := range versions {
+		target.Versions[i] = types.PackageIndexItem{Version: v.Version}
+	}
+	target.LatestVersion = versions[len(versions)-1].Version
+	return nil
+}
+
+// GetLatestVersion implements RepoClient.
+func (c *helmClient) GetLatestVersion(pkgName string) (string, error) {
+	var idx types.PackageRepoIndex
+	if err := c.FetchPackageRepoIndex(&idx); err != nil {
+		return "", err
+	}
+	for _, pkg := range idx.Packages {
+		if pkg.Name == pkgName {
+			return pkg.LatestVersion, nil
+		}
+	}
+	return "", nil
+}
+
+// FetchLatestPackageManifest implements RepoClient.
+func (c *helmClient) FetchLatestPackageManifest(name string, target *v1alpha1.PackageManifest) (string, error) {
+	var versions types.PackageIndex
+	if err := c.FetchPackageIndex(name, &versions); err != nil {
+		return "", err
+	}
+	return versions.LatestVersion, c.FetchPackageManifest(name, versions.LatestVersion, target)
+}
+
+// FetchPackageManifest implements RepoClient. The manifest is generated from the chart archive of the given version.
+func (c *helmClient) FetchPackageManifest(name, version string, target *v1alpha1.PackageManifest) error {
+	chart, err := c.fetchChartVersion(name, version)
+	if err != nil {
+		return err
+	}
+	chartURL, err := c.chartURL(chart)
+	if err != nil {
+		return err
+	}
+	archive, err := c.fetchBytes(chartURL)
+	if err != nil {
+		return err
+	}
+	values, schema, err := readHelmChartValues(archive)
+	if err != nil {
+		return fmt.Errorf("could not read chart %v: %w", chartURL, err)
+	}
+	manifest, err := generateHelmManifest(c.url, chart, values, schema)
+	if err != nil {
+		return fmt.Errorf("could not generate package manifest for chart %v: %w", chartURL, err)
+	}
+	*target = *manifest
+	return nil
+}
+
+// GetPackageManifestURL implements RepoClient. Because the manifest is generated, the URL of the chart archive it is
+// generated from is returned.
+func (c *helmClient) GetPackageManifestURL(name, version string) (string, error) {
+	if chart, err := c.fetchChartVersion(name, version); err != nil {
+		return "", err
+	} else {
+		return c.chartURL(chart)
+	}
+}
+
+func (c *helmClient) fetchHelmIndex() (*helmRepoIndex, error) {
+	indexURL, err := url.JoinPath(c.url, "index.yaml")
+	if err != nil {
+		return nil, err
+	}
+	// Helm repositories often serve their index with a generic content type, so it is not checked
+	data, err := c.fetchBytes(indexURL)
+	if err != nil {
+		return nil, err
+	}
+	var index helmRepoIndex
+	if err := yaml.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("could not decode %v: %w", indexURL, err)
+	}
+	if len(c.charts) > 0 {
+		for name := range index.Entries {
+			if !slices.Contains(c.charts, name) {
+				delete(index.Entries, name)
+			}
+		}
+	}
+	return &index, nil
+}
+
+// fetchChartVersions returns all versions of the given chart, sorted from oldest to newest
+func (c *helmClient) fetchChartVersions(name string) ([]helmChartVersion, error) {
+	index, err := c.fetchHelmIndex()
+	if err != nil {
+		return nil, err
+	}
+	if versions := sortedChartVersions(index.Entries[name]); len(versions) > 0 {
+		return versions, nil
+	}
+	return nil, fmt.Errorf("chart %v not found: %w", name, httperror.FromStatusCode(http.StatusNotFound))
+}
+
+func (c *helmClient) fetchChartVersion(name, version string) (*helmChartVersion, error) {
+	versions, err := c.fetchChartVersions(name)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range versions {
+		if v.Version == version {
+			return &v, nil
+		}
+	}
+	return nil, fmt.Errorf("chart %v has no version %v: %w", name, version,
+		httperror.FromStatusCode(http.StatusNotFound))
+}
+
+// chartURL returns the URL of the chart archive, which may be given relative to the repository URL in the index
+func (c *helmClient) chartURL(chart *helmChartVersion) (string, error) {
+	if len(chart.URLs) == 0 {
+		return "", fmt.Errorf("chart %v version %v has no URL", chart.Name, chart.Version)
+	}
+	base, err := url.Parse(strings.TrimSuffix(c.url, "/") + "/")
+	if err != nil {
+		return "", err
+	}
+	ref, err := url.Parse(chart.URLs[0])
+	if err != nil {
+		return "", err
+	}
+	return base.ResolveReference(ref).String(), nil
+}
+
+func (c *helmClient) fetchBytes(rawUrl string) ([]byte, error) {
+	if path, ok := isFileURL(rawUrl); ok {
+		return os.ReadFile(path)
+	}
+	return c.client.fetchCached(rawUrl, false)
+}
+
+// sortedChartVersions returns the versions with a valid semantic version that are not deprecated, sorted from oldest
+// to newest
+func sortedChartVersions(versions []helmChartVersion) []helmChartVersion {
+	type parsedVersion struct {
+		helmChartVersion
+		semver *semver.Version
+	}
+	var parsed []parsedVersion
+	for _, v := range versions {
+		if sv, err := semver.NewVersion(v.Version); err == nil && !v.Deprecated {
+			parsed = append(parsed, parsedVersion{v, sv})
+		}
+	}
+	slices.SortFunc(parsed, func(a, b parsedVersion) int { return a.semver.Compare(b.semver) })
+	result := make([]helmChartVersion, len(parsed))
+	for i, v := range parsed {
+		result[i] = v.helmChartVersion
+	}
+	return result
+}
+
+// readHelmChartValues returns the values.yaml and values.schema.json of the chart in a chart archive. Both files are
+// optional.
+func readHelmChartValues(archive []byte) (values []byte, schema []byte, err error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = gz.Close() }()
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return values, schema, nil
+		} else if err != nil {
+			return nil, nil, err
+		}
+		// only the files of the chart itself are used, not those of its subcharts
+		dir, file := path.Split(path.Clean(header.Name))
+		if header.Typeflag != tar.TypeReg || path.Dir(path.Clean(dir)) != "." {
+			continue
+		}
+		switch file {
+		case helmChartValuesFile:
+			values, err = io.ReadAll(tr)
+		case helmChartValuesSchemaFile:
+			schema, err = io.ReadAll(tr)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+}
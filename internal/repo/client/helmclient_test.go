@@ -0,0 +1,180 @@
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/repo/client/auth"
+	"github.com/glasskube/glasskube/internal/repo/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const helmTestIndex = `apiVersion: v1
+entries:
+  redis:
+  - name: redis
+    version: 1.10.0
+    description: Redis chart
+    urls: [redis-1.10.0.tgz]
+  - name: redis
+    version: 1.9.0
+    urls: [redis-1.9.0.tgz]
+  - name: redis
+    version: 2.0.0
+    deprecated: true
+    urls: [redis-2.0.0.tgz]
+  postgresql:
+  - name: postgresql
+    version: 3.0.0
+    urls: [https://charts.example.com/postgresql-3.0.0.tgz]
+`
+
+const helmTestValues = `replicas: 1
+auth:
+  enabled: true
+  password: ""
+image:
+  tag: "7.2"
+  pullSecrets: []
+resources:
+  limits:
+    cpu: 100m
+ratio: 0.5
+`
+
+const helmTestSchema = `{
+  "properties": {
+    "architecture": {"type": "string", "enum": ["standalone", "replication"], "default": "standalone"},
+    "replicas": {"type": "integer", "minimum": 1, "description": "Number of replicas"}
+  },
+  "required": ["replicas"]
+}`
+
+func helmChartArchive(files map[string]string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		Expect(tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)),
+			Typeflag: tar.TypeReg})).To(Succeed())
+		_, err := tw.Write([]byte(content))
+		Expect(err).NotTo(HaveOccurred())
+	}
+	Expect(tw.Close()).To(Succeed())
+	Expect(gz.Close()).To(Succeed())
+	return buf.Bytes()
+}
+
+var _ = Describe("helmClient", func() {
+	var client *helmClient
+
+	BeforeEach(func() {
+		archive := helmChartArchive(map[string]string{
+			"redis/Chart.yaml":                   "name: redis",
+			"redis/values.yaml":                  helmTestValues,
+			"redis/values.schema.json":           helmTestSchema,
+			"redis/charts/common/values.yaml":    "ignored: true",
+			"redis/templates/statefulset.yaml":   "",
+			"redis/charts/common/templates/x.md": "",
+		})
+		mux := http.NewServeMux()
+		mux.HandleFunc("/charts/index.yaml", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(helmTestIndex))
+		})
+		mux.HandleFunc("/charts/redis-1.10.0.tgz", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(archive)
+		})
+		server := httptest.NewServer(mux)
+		DeferCleanup(server.Close)
+		client = NewHelm(server.URL+"/charts", v1alpha1.PackageRepositoryHelmSpec{}, auth.Noop(), time.Minute)
+	})
+
+	It("should list the charts with their latest version", func() {
+		var index types.PackageRepoIndex
+		Expect(client.FetchPackageRepoIndex(&index)).To(Succeed())
+		Expect(index.Packages).To(Equal([]types.PackageRepoIndexItem{
+			{Name: "postgresql", LatestVersion: "3.0.0"},
+			{Name: "redis", ShortDescription: "Redis chart", LatestVersion: "1.10.0"},
+		}))
+	})
+
+	It("should only list the configured charts", func() {
+		client.charts = []string{"redis"}
+		var index types.PackageRepoIndex
+		Expect(client.FetchPackageRepoIndex(&index)).To(Succeed())
+		Expect(index.Packages).To(HaveLen(1))
+		Expect(index.Packages[0].Name).To(Equal("redis"))
+	})
+
+	It("should list the versions of a chart without deprecated versions", func() {
+		var index types.PackageIndex
+		Expect(client.FetchPackageIndex("redis", &index)).To(Succeed())
+		Expect(index.Versions).To(Equal([]types.PackageIndexItem{{Version: "1.9.0"}, {Version: "1.10.0"}}))
+		Expect(index.LatestVersion).To(Equal("1.10.0"))
+	})
+
+	It("should resolve chart URLs", func() {
+		Expect(client.GetPackageManifestURL("redis", "1.10.0")).To(Equal(client.url + "/redis-1.10.0.tgz"))
+		Expect(client.GetPackageManifestURL("postgresql", "3.0.0")).
+			To(Equal("https://charts.example.com/postgresql-3.0.0.tgz"))
+	})
+
+	It("should fail for unknown charts", func() {
+		var manifest v1alpha1.PackageManifest
+		Expect(client.FetchPackageManifest("mysql", "1.0.0", &manifest)).NotTo(Succeed())
+	})
+
+	It("should generate the package manifest", func() {
+		var manifest v1alpha1.PackageManifest
+		version, err := client.FetchLatestPackageManifest("redis", &manifest)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(version).To(Equal("1.10.0"))
+		Expect(manifest.Name).To(Equal("redis"))
+		Expect(manifest.DefaultNamespace).To(Equal("redis"))
+		Expect(manifest.Helm).NotTo(BeNil())
+		Expect(manifest.Helm.RepositoryUrl).To(Equal(client.url))
+		Expect(manifest.Helm.ChartName).To(Equal("redis"))
+		Expect(manifest.Helm.ChartVersion).To(Equal("1.10.0"))
+		Expect(string(manifest.Helm.Values.Raw)).To(MatchJSON(`{"auth": {}, "image": {}}`))
+
+		Expect(manifest.ValueDefinitions).To(HaveLen(5))
+		Expect(manifest.ValueDefinitions).NotTo(HaveKey("ignored"))
+		Expect(manifest.ValueDefinitions).NotTo(HaveKey("ratio"))
+		Expect(manifest.ValueDefinitions).NotTo(HaveKey("image.pullSecrets"))
+		Expect(manifest.ValueDefinitions).NotTo(HaveKey("resources.limits"))
+
+		replicas := manifest.ValueDefinitions["replicas"]
+		Expect(replicas.Type).To(Equal(v1alpha1.ValueTypeNumber))
+		Expect(replicas.DefaultValue).To(Equal("1"))
+		Expect(replicas.Metadata.Description).To(Equal("Number of replicas"))
+		Expect(replicas.Constraints.Required).To(BeTrue())
+		Expect(replicas.Constraints.Min).To(HaveValue(Equal(1)))
+		Expect(replicas.Targets).To(HaveLen(1))
+		Expect(replicas.Targets[0].ChartName).To(HaveValue(Equal("redis")))
+		Expect(replicas.Targets[0].Patch).To(Equal(v1alpha1.PartialJsonPatch{Op: "add", Path: "/replicas"}))
+		Expect(replicas.Targets[0].ValueTemplate).To(Equal("{{.}}"))
+
+		architecture := manifest.ValueDefinitions["architecture"]
+		Expect(architecture.Type).To(Equal(v1alpha1.ValueTypeOptions))
+		Expect(architecture.Options).To(Equal([]string{"standalone", "replication"}))
+		Expect(architecture.DefaultValue).To(Equal("standalone"))
+
+		authEnabled := manifest.ValueDefinitions["auth.enabled"]
+		Expect(authEnabled.Type).To(Equal(v1alpha1.ValueTypeBoolean))
+		Expect(authEnabled.DefaultValue).To(Equal("true"))
+		Expect(authEnabled.Targets[0].Patch.Path).To(Equal("/auth/enabled"))
+
+		imageTag := manifest.ValueDefinitions["image.tag"]
+		Expect(imageTag.Type).To(Equal(v1alpha1.ValueTypeText))
+		Expect(imageTag.DefaultValue).To(Equal("7.2"))
+		Expect(imageTag.Targets[0].ValueTemplate).To(BeEmpty())
+
+		Expect(manifest.ValueDefinitions).To(HaveKey("auth.password"))
+	})
+})
@@ -0,0 +1,224 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/maputils"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// helmValueMaxDepth is the maximum nesting depth of the chart values for which value definitions are generated, e.g.
+// "image.tag" is included, but "primary.resources.limits" is not. Deeper values are rarely configured and would
+// clutter the configuration form.
+const helmValueMaxDepth = 2
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// helmValuesSchema is the subset of the JSON schema in values.schema.json that is used to generate value definitions
+type helmValuesSchema struct {
+	Type        any                          `json:"type,omitempty"`
+	Description string                       `json:"description,omitempty"`
+	Enum        []any                        `json:"enum,omitempty"`
+	Default     any                          `json:"default,omitempty"`
+	Minimum     *float64                     `json:"minimum,omitempty"`
+	Maximum     *float64                     `json:"maximum,omitempty"`
+	MinLength   *int                         `json:"minLength,omitempty"`
+	MaxLength   *int                         `json:"maxLength,omitempty"`
+	Pattern     *string                      `json:"pattern,omitempty"`
+	Required    []string                     `json:"required,omitempty"`
+	Properties  map[string]*helmValuesSchema `json:"properties,omitempty"`
+}
+
+func (s *helmValuesSchema) typeName() string {
+	if s != nil {
+		if t, ok := s.Type.(string); ok {
+			return t
+		}
+	}
+	return ""
+}
+
+// generateHelmManifest returns the package manifest for a chart of a Helm repository. values and schema are the
+// contents of the values.yaml and values.schema.json of the chart and may be empty.
+func generateHelmManifest(
+	repoURL string, chart *helmChartVersion, values, schema []byte,
+) (*v1alpha1.PackageManifest, error) {
+	var valuesMap map[string]any
+	if len(values) > 0 {
+		if err := yaml.Unmarshal(values, &valuesMap); err != nil {
+			return nil, fmt.Errorf("invalid %v: %w", helmChartValuesFile, err)
+		}
+	}
+	var valuesSchema *helmValuesSchema
+	if len(schema) > 0 {
+		if err := json.Unmarshal(schema, &valuesSchema); err != nil {
+			return nil, fmt.Errorf("invalid %v: %w", helmChartValuesSchemaFile, err)
+		}
+	}
+
+	definitions, parents := generateHelmValueDefinitions(chart.Name, valuesMap, valuesSchema)
+	manifest := v1alpha1.PackageManifest{
+		Name:             chart.Name,
+		ShortDescription: chart.Description,
+		IconUrl:          chart.Icon,
+		Keywords:         chart.Keywords,
+		DefaultNamespace: chart.Name,
+		Helm: &v1alpha1.HelmManifest{
+			RepositoryUrl: repoURL,
+			ChartName:     chart.Name,
+			ChartVersion:  chart.Version,
+		},
+		ValueDefinitions: definitions,
+	}
+	if len(parents) > 0 {
+		if raw, err := json.Marshal(parents); err != nil {
+			return nil, err
+		} else {
+			manifest.Helm.Values = &v1alpha1.JSON{Raw: raw}
+		}
+	}
+	return &manifest, nil
+}
+
+// generateHelmValueDefinitions returns a value definition for every scalar value of the chart, which is named after
+// its path in the chart values, e.g. "image.tag". The type, default and constraints are taken from the schema if it
+// exists, otherwise they are derived from the value in values.yaml.
+// Because a JSON patch can only add a value to an existing object, the empty objects that contain the nested values
+// are returned as well. They must be used as initial values of the release, which does not change the result, because
+// Helm merges them with the defaults of the chart.
+func generateHelmValueDefinitions(
+	chartName string, values map[string]any, schema *helmValuesSchema,
+) (map[string]v1alpha1.ValueDefinition, map[string]any) {
+	definitions := map[string]v1alpha1.ValueDefinition{}
+	parents := map[string]any{}
+	var walk func(path []string, value any, schema *helmValuesSchema, required bool)
+	walk = func(path []string, value any, schema *helmValuesSchema, required bool) {
+		if object, ok := value.(map[string]any); ok || (value == nil && schema.typeName() == "object") {
+			if len(path) >= helmValueMaxDepth {
+				return
+			}
+			keys := maputils.KeysSorted(object)
+			if schema != nil {
+				keys = append(keys, maputils.KeysSorted(schema.Properties)...)
+				slices.Sort(keys)
+				keys = slices.Compact(keys)
+			}
+			for _, key := range keys {
+				var propertySchema *helmValuesSchema
+				if schema != nil {
+					propertySchema = schema.Properties[key]
+				}
+				walk(append(slices.Clone(path), key), object[key], propertySchema,
+					schema != nil && slices.Contains(schema.Required, key))
+			}
+		} else if len(path) > 0 {
+			if def, ok := helmValueDefinition(value, schema, required); ok {
+				escaped := make([]string, len(path))
+				for i, segment := range path {
+					escaped[i] = jsonPointerEscaper.Replace(segment)
+				}
+				def.Targets[0].ChartName = &chartName
+				def.Targets[0].Patch = v1alpha1.PartialJsonPatch{Op: "add", Path: "/" + strings.Join(escaped, "/")}
+				definitions[strings.Join(path, ".")] = def
+				parent := parents
+				for _, segment := range path[:len(path)-1] {
+					if _, ok := parent[segment]; !ok {
+						parent[segment] = map[string]any{}
+					}
+					parent = parent[segment].(map[string]any)
+				}
+			}
+		}
+	}
+	walk(nil, values, schema, false)
+	return definitions, parents
+}
+
+// helmValueDefinition returns the definition for a scalar chart value, with a single target whose chart name and
+// patch must still be set. If the type of the value is not supported, false is returned.
+func helmValueDefinition(
+	value any, schema *helmValuesSchema, required bool,
+) (def v1alpha1.ValueDefinition, ok bool) {
+	if value == nil && schema != nil {
+		value = schema.Default
+	}
+	typeName := schema.typeName()
+	if typeName == "" {
+		switch value.(type) {
+		case bool:
+			typeName = "boolean"
+		case string:
+			typeName = "string"
+		case int64, float64:
+			typeName = "number"
+		}
+	}
+
+	def.Targets = []v1alpha1.ValueDefinitionTarget{{}}
+	def.Constraints.Required = required
+	if schema != nil {
+		def.Metadata.Description = schema.Description
+	}
+	switch typeName {
+	case "boolean":
+		def.Type = v1alpha1.ValueTypeBoolean
+		if v, isBool := value.(bool); isBool {
+			def.DefaultValue = strconv.FormatBool(v)
+		}
+	case "integer", "number":
+		// number values must be integers
+		def.Type = v1alpha1.ValueTypeNumber
+		switch v := value.(type) {
+		case int64:
+			def.DefaultValue = strconv.FormatInt(v, 10)
+		case float64:
+			if v != math.Trunc(v) {
+				return def, false
+			}
+			def.DefaultValue = strconv.FormatInt(int64(v), 10)
+		}
+		if schema != nil {
+			def.Constraints.Min = integerConstraint(schema.Minimum)
+			def.Constraints.Max = integerConstraint(schema.Maximum)
+		}
+	case "string":
+		def.Type = v1alpha1.ValueTypeText
+		if v, isString := value.(string); isString {
+			def.DefaultValue = v
+		}
+		if schema != nil {
+			for _, option := range schema.Enum {
+				if s, isString := option.(string); isString {
+					def.Options = append(def.Options, s)
+				}
+			}
+			if len(def.Options) > 0 {
+				def.Type = v1alpha1.ValueTypeOptions
+			}
+			def.Constraints.MinLength = schema.MinLength
+			def.Constraints.MaxLength = schema.MaxLength
+			def.Constraints.Pattern = schema.Pattern
+		}
+	default:
+		return def, false
+	}
+	if def.Type != v1alpha1.ValueTypeText && def.Type != v1alpha1.ValueTypeOptions {
+		// the value is decoded as JSON, so that it is passed to the chart as boolean or number instead of a string
+		def.Targets[0].ValueTemplate = "{{.}}"
+	}
+	return def, true
+}
+
+func integerConstraint(f *float64) *int {
+	if f == nil || *f != math.Trunc(*f) {
+		return nil
+	}
+	i := int(*f)
+	return &i
+}
@@ -0,0 +1,324 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/httperror"
+	"github.com/glasskube/glasskube/internal/repo/client/auth"
+	"github.com/glasskube/glasskube/internal/repo/types"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	// MediaTypePackageManifest is the media type of the layer that contains the package.yaml of a package artifact
+	MediaTypePackageManifest = "application/vnd.glasskube.package.manifest.v1+yaml"
+	// MediaTypePackageManifestSignature is the media type of the optional layer that contains the signature of the
+	// package.yaml of a package artifact (see the signature package for the format)
+	MediaTypePackageManifestSignature = "application/vnd.glasskube.package.manifest.signature.v1"
+	// MediaTypeRepoIndex is the media type of the layer that contains the index.yaml of a repository index artifact
+	MediaTypeRepoIndex = "application/vnd.glasskube.repository.index.v1+yaml"
+
+	// ociIndexRepository is the name of the OCI repository (relative to the base URL) that contains the repository
+	// index artifact, tagged with ociIndexTag
+	ociIndexRepository = "index"
+	ociIndexTag        = "latest"
+)
+
+// ociClient is a RepoClient for package repositories that are hosted in an OCI registry. Given a repository URL
+// oci://registry.example.com/glasskube, the artifacts are expected to be laid out as follows:
+//
+//   - registry.example.com/glasskube/index:latest contains the repository index (index.yaml)
+//   - registry.example.com/glasskube/<package>:<version> contains the package manifest (package.yaml) and optionally
+//     its signature in a second layer with media type MediaTypePackageManifestSignature
+//
+// Because OCI tags may not contain "+", it is replaced with "_" in the tag of a version.
+// The available versions of a package are determined by listing the tags of its OCI repository.
+type ociClient struct {
+	auth.Authenticator
+	url          string
+	maxCacheAge  time.Duration
+	retryBackoff wait.Backoff
+	// transport is used for all requests to the registry, if it is set
+	transport http.RoundTripper
+	cache     sync.Map
+}
+
+func NewOCI(url string, authenticator auth.Authenticator, maxCacheAge time.Duration) *ociClient {
+	return &ociClient{
+		url:           url,
+		Authenticator: authenticator,
+		maxCacheAge:   maxCacheAge,
+		retryBackoff:  DefaultRetryBackoff,
+	}
+}
+
+var _ RepoClient = &ociClient{}
+var _ CacheInvalidator = &ociClient{}
+
+// InvalidateCache implements CacheInvalidator.
+func (c *ociClient) InvalidateCache() {
+	c.cache.Clear()
+}
+
+// FetchLatestPackageManifest implements RepoClient.
+func (c *ociClient) FetchLatestPackageManifest(name string, target *v1alpha1.PackageManifest) (string, error) {
+	var versions types.PackageIndex
+	if err := c.FetchPackageIndex(name, &versions); err != nil {
+		return "", err
+	}
+	return versions.LatestVersion, c.FetchPackageManifest(name, versions.LatestVersion, target)
+}
+
+// FetchPackageManifest implements RepoClient.
+func (c *ociClient) FetchPackageManifest(name, version string, target *v1alpha1.PackageManifest) error {
+	if ref, err := c.packageReference(name, version); err != nil {
+		return err
+	} else {
+		return c.fetchArtifact(ref, MediaTypePackageManifest, target)
+	}
+}
+
+// FetchPackageIndex implements RepoClient.
+func (c *ociClient) FetchPackageIndex(name string, target *types.PackageIndex) error {
+	repo, err := c.repository(name)
+	if err != nil {
+		return err
+	}
+	tags, err := remote.List(repo, c.remoteOptions()...)
+	if err != nil {
+		return fmt.Errorf("failed to list tags of %v: %w", repo, convertOCIError(err))
+	}
+	var versions []*semver.Version
+	for _, tag := range tags {
+		if v, err := semver.NewVersion(tagToVersion(tag)); err == nil {
+			versions = append(versions, v)
+		}
+	}
+	if len(versions) == 0 {
+		return fmt.Errorf("%v has no versions: %w", repo, httperror.FromStatusCode(http.StatusNotFound))
+	}
+	slices.SortFunc(versions, func(a, b *semver.Version) int { return a.Compare(b) })
+	target.Versions = make([]types.PackageIndexItem, len(versions))
+	for i, v := range versions {
+		target.Versions[i] = types.PackageIndexItem{Version: v.Original()}
+	}
+	target.LatestVersion = versions[len(versions)-1].Original()
+	return nil
+}
+
+// FetchPackageRepoIndex implements RepoClient.
+func (c *ociClient) FetchPackageRepoIndex(target *types.PackageRepoIndex) error {
+	if repo, err := c.repository(ociIndexRepository); err != nil {
+		return err
+	} else {
+		return c.fetchArtifact(repo.Tag(ociIndexTag), MediaTypeRepoIndex, target)
+	}
+}
+
+// GetLatestVersion implements RepoClient.
+func (c *ociClient) GetLatestVersion(pkgName string) (string, error) {
+	var idx types.PackageRepoIndex
+	if err := c.FetchPackageRepoIndex(&idx); err != nil {
+		return "", err
+	}
+	for _, pkg := range idx.Packages {
+		if pkg.Name == pkgName {
+			return pkg.LatestVersion, nil
+		}
+	}
+	return "", nil
+}
+
+// GetPackageManifestURL implements RepoClient.
+func (c *ociClient) GetPackageManifestURL(name, version string) (string, error) {
+	if ref, err := c.packageReference(name, version); err != nil {
+		return "", err
+	} else {
+		return schemeOCI + "://" + ref.String(), nil
+	}
+}
+
+func (c *ociClient) repository(repoName string) (name.Repository, error) {
+	base := strings.TrimSuffix(strings.TrimPrefix(c.url, schemeOCI+"://"), "/")
+	return name.NewRepository(base+"/"+repoName, name.StrictValidation)
+}
+
+func (c *ociClient) packageReference(pkgName, version string) (name.Tag, error) {
+	if repo, err := c.repository(pkgName); err != nil {
+		return name.Tag{}, err
+	} else {
+		return repo.Tag(versionToTag(version)), nil
+	}
+}
+
+// fetchArtifact pulls the artifact with the given reference and decodes the content of its layer with the given media
+// type into target. If no layer has this media type, but the artifact has exactly one layer, that layer is used.
+func (c *ociClient) fetchArtifact(ref name.Reference, mediaType string, target any) error {
+	if bytes, err := c.fetchLayer(ref, mediaType, true); err != nil {
+		return err
+	} else if err := yaml.Unmarshal(bytes, target); err != nil {
+		return fmt.Errorf("could not decode %v: %w", ref, err)
+	} else {
+		return nil
+	}
+}
+
+var _ RawManifestSource = &ociClient{}
+
+// FetchPackageManifestBytes implements RawManifestSource.
+func (c *ociClient) FetchPackageManifestBytes(name, version string) ([]byte, error) {
+	if ref, err := c.packageReference(name, version); err != nil {
+		return nil, err
+	} else {
+		return c.fetchLayer(ref, MediaTypePackageManifest, true)
+	}
+}
+
+// FetchPackageManifestSignature implements RawManifestSource.
+func (c *ociClient) FetchPackageManifestSignature(name, version string) ([]byte, error) {
+	if ref, err := c.packageReference(name, version); err != nil {
+		return nil, err
+	} else if bytes, err := c.fetchLayer(ref, MediaTypePackageManifestSignature, false); errors.Is(err, errNoLayer) {
+		return nil, nil
+	} else {
+		return bytes, err
+	}
+}
+
+// fetchLayer returns the content of the layer with the given media type from the cache, or pulls it if it is not
+// cached or too old.
+func (c *ociClient) fetchLayer(ref name.Reference, mediaType string, fallbackToSingleLayer bool) ([]byte, error) {
+	key := ref.String() + "@" + mediaType
+	cached := &cacheItem{}
+	if item, hit := c.cache.LoadOrStore(key, cached); hit {
+		if item, ok := item.(*cacheItem); ok {
+			cached = item
+		} else {
+			return nil, errors.New("unexpected cache type")
+		}
+	}
+
+	cached.mutex.Lock()
+	defer cached.mutex.Unlock()
+
+	if cached.updated.Add(c.maxCacheAge).After(time.Now()) {
+		return cached.bytes, nil
+	}
+
+	bytes, err := c.pullLayer(ref, mediaType, fallbackToSingleLayer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %v: %w", ref, convertOCIError(err))
+	}
+	cached.bytes = bytes
+	cached.updated = time.Now()
+	return bytes, nil
+}
+
+var errNoLayer = errors.New("artifact has no layer with this media type")
+
+func (c *ociClient) pullLayer(ref name.Reference, mediaType string, fallbackToSingleLayer bool) ([]byte, error) {
+	desc, err := remote.Get(ref, c.remoteOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := v1.ParseManifest(strings.NewReader(string(desc.Manifest)))
+	if err != nil {
+		return nil, err
+	}
+	var layerDesc *v1.Descriptor
+	for i := range manifest.Layers {
+		if string(manifest.Layers[i].MediaType) == mediaType {
+			layerDesc = &manifest.Layers[i]
+			break
+		}
+	}
+	if layerDesc == nil && fallbackToSingleLayer && len(manifest.Layers) == 1 {
+		layerDesc = &manifest.Layers[0]
+	} else if layerDesc == nil {
+		return nil, fmt.Errorf("%w: %v", errNoLayer, mediaType)
+	}
+
+	layer, err := remote.Layer(ref.Context().Digest(layerDesc.Digest.String()), c.remoteOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := layer.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rc.Close() }()
+	return io.ReadAll(rc)
+}
+
+func (c *ociClient) remoteOptions() []remote.Option {
+	options := []remote.Option{
+		remote.WithContext(context.TODO()),
+		remote.WithRetryBackoff(remote.Backoff{
+			Duration: c.retryBackoff.Duration,
+			Factor:   c.retryBackoff.Factor,
+			Jitter:   c.retryBackoff.Jitter,
+			Steps:    c.retryBackoff.Steps,
+			Cap:      c.retryBackoff.Cap,
+		}),
+		c.authOption(),
+	}
+	if c.transport != nil {
+		options = append(options, remote.WithTransport(c.transport))
+	}
+	return options
+}
+
+// authOption converts the Authenticator of this client into credentials for the registry. If the repository has no
+// credentials configured, the docker config of the current user (e.g. ~/.docker/config.json) is used instead.
+func (c *ociClient) authOption() remote.Option {
+	request, _ := http.NewRequest(http.MethodGet, "/", nil)
+	if c.Authenticator != nil {
+		c.Authenticate(request)
+	}
+	header := request.Header.Get("Authorization")
+	if basic, ok := strings.CutPrefix(header, "Basic "); ok {
+		return remote.WithAuth(authn.FromConfig(authn.AuthConfig{Auth: basic}))
+	} else if bearer, ok := strings.CutPrefix(header, "Bearer "); ok {
+		return remote.WithAuth(authn.FromConfig(authn.AuthConfig{RegistryToken: bearer}))
+	}
+	return remote.WithAuthFromKeychain(authn.DefaultKeychain)
+}
+
+// convertOCIError converts registry errors with a status code into errors that can be checked with the httperror
+// package, so that callers can handle them the same way for all kinds of repositories.
+func convertOCIError(err error) error {
+	var transportErr *transport.Error
+	if errors.As(err, &transportErr) && transportErr.StatusCode != 0 {
+		return fmt.Errorf("%w: %v", httperror.FromStatusCode(transportErr.StatusCode), err)
+	}
+	return err
+}
+
+func versionToTag(version string) string {
+	return strings.ReplaceAll(version, "+", "_")
+}
+
+func tagToVersion(tag string) string {
+	return strings.ReplaceAll(tag, "_", "+")
+}
+
+// isOCIURL returns true if the given URL points to an OCI registry (oci://)
+func isOCIURL(rawUrl string) bool {
+	return strings.HasPrefix(rawUrl, schemeOCI+"://")
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+)
+
+var ErrAmbiguousRepository = errors.New("available from multiple repositories with the same priority")
+
+// RepositoryResolution is the decision which repository a package is installed from, if no repository is given
+// explicitly
+type RepositoryResolution struct {
+	Repository v1alpha1.PackageRepository
+	// Alternatives are the other repositories that provide the package, ordered by priority
+	Alternatives []v1alpha1.PackageRepository
+}
+
+func (r RepositoryResolution) String() string {
+	if len(r.Alternatives) == 0 {
+		return r.Repository.Name
+	}
+	alternatives := make([]string, len(r.Alternatives))
+	for i, repo := range r.Alternatives {
+		alternatives[i] = fmt.Sprintf("%v (priority %v)", repo.Name, repo.Spec.Priority)
+	}
+	return fmt.Sprintf("%v (priority %v), preferred over %v",
+		r.Repository.Name, r.Repository.Spec.Priority, strings.Join(alternatives, ", "))
+}
+
+// SortByPriority sorts repositories by descending priority. Repositories with the same priority are sorted by name.
+func SortByPriority(repos []v1alpha1.PackageRepository) {
+	slices.SortStableFunc(repos, func(a, b v1alpha1.PackageRepository) int {
+		return cmp.Or(cmp.Compare(b.Spec.Priority, a.Spec.Priority), cmp.Compare(a.Name, b.Name))
+	})
+}
+
+// ResolveRepository selects the repository with the highest priority from repos, which are all repositories that
+// provide the package with the given name. If multiple repositories share the highest priority, an error wrapping
+// ErrAmbiguousRepository is returned, because the choice would be arbitrary.
+func ResolveRepository(name string, repos []v1alpha1.PackageRepository) (*RepositoryResolution, error) {
+	if len(repos) == 0 {
+		return nil, fmt.Errorf("%v is not available in any repository", name)
+	}
+	sorted := slices.Clone(repos)
+	SortByPriority(sorted)
+	if len(sorted) > 1 && sorted[0].Spec.Priority == sorted[1].Spec.Priority {
+		var candidates []string
+		for _, repo := range sorted {
+			if repo.Spec.Priority == sorted[0].Spec.Priority {
+				candidates = append(candidates, repo.Name)
+			}
+		}
+		return nil, fmt.Errorf("%v is %w (%v)", name, ErrAmbiguousRepository, strings.Join(candidates, ", "))
+	}
+	return &RepositoryResolution{Repository: sorted[0], Alternatives: sorted[1:]}, nil
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func repoWithPriority(name string, priority int) v1alpha1.PackageRepository {
+	return v1alpha1.PackageRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       v1alpha1.PackageRepositorySpec{Priority: priority},
+	}
+}
+
+var _ = Describe("ResolveRepository", func() {
+	It("should use the only repository", func() {
+		resolution, err := ResolveRepository("pkg", []v1alpha1.PackageRepository{repoWithPriority("a", 0)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resolution.Repository.Name).To(Equal("a"))
+		Expect(resolution.Alternatives).To(BeEmpty())
+		Expect(resolution.String()).To(Equal("a"))
+	})
+
+	It("should prefer the repository with the highest priority", func() {
+		resolution, err := ResolveRepository("pkg", []v1alpha1.PackageRepository{
+			repoWithPriority("a", 0), repoWithPriority("b", 10), repoWithPriority("c", -1),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resolution.Repository.Name).To(Equal("b"))
+		Expect(resolution.String()).To(Equal("b (priority 10), preferred over a (priority 0), c (priority -1)"))
+	})
+
+	It("should fail if the highest priority is shared", func() {
+		_, err := ResolveRepository("pkg", []v1alpha1.PackageRepository{
+			repoWithPriority("b", 5), repoWithPriority("a", 5), repoWithPriority("c", 0),
+		})
+		Expect(err).To(MatchError(ErrAmbiguousRepository))
+		Expect(err.Error()).To(ContainSubstring("(a, b)"))
+	})
+
+	It("should fail without repositories", func() {
+		_, err := ResolveRepository("pkg", nil)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,188 @@
+package client
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/repo/client/auth"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// ProviderGit is the key of the provider of repositories with a git spec, regardless of the scheme of their URL
+	ProviderGit = "git"
+	// ProviderHelm is the key of the provider of repositories with a Helm spec, regardless of the scheme of their URL
+	ProviderHelm = "helm"
+)
+
+// Provider creates clients for one kind of package repository source. Providers are registered for a URL scheme with
+// RegisterProvider, so new kinds of sources can be added without changing the clientset.
+//
+// The returned RepoClient lists the packages of the repository (FetchPackageRepoIndex), fetches their manifests
+// (FetchPackageManifest) and resolves the URLs of manifests (GetPackageManifestURL). Clients that cache responses
+// should implement CacheInvalidator, and clients of sources that are synchronized as a whole should implement
+// GitSyncer, so that a sync of the repository can be requested. Clients should also implement RawManifestSource,
+// because signature verification and manifest digests are not supported for repositories of clients without it.
+type Provider interface {
+	NewClient(repo v1alpha1.PackageRepository, opts ProviderOptions) (RepoClient, error)
+}
+
+// URLValidator can be implemented by a Provider to check the URLs of its scheme in ValidateURL. URLs of schemes whose
+// provider does not implement it are accepted as is.
+type URLValidator interface {
+	ValidateURL(u *url.URL) error
+}
+
+// ProviderFunc is a Provider that is implemented by a function
+type ProviderFunc func(repo v1alpha1.PackageRepository, opts ProviderOptions) (RepoClient, error)
+
+// NewClient implements Provider.
+func (f ProviderFunc) NewClient(repo v1alpha1.PackageRepository, opts ProviderOptions) (RepoClient, error) {
+	return f(repo, opts)
+}
+
+// ProviderOptions is the configuration of a repository that the clientset resolved from its spec, including secrets
+type ProviderOptions struct {
+	Authenticator auth.Authenticator
+	// TLSConfig is nil if the repository does not configure custom certificates
+	TLSConfig *tls.Config
+	// CABundle contains the PEM encoded CA certificates of TLSConfig, for clients that can not use a tls.Config
+	CABundle     []byte
+	MaxCacheAge  time.Duration
+	RetryBackoff wait.Backoff
+	proxy        *proxyConfig
+}
+
+// Transport returns the transport for HTTP requests to the repository, or nil if the default transport can be used
+func (opts ProviderOptions) Transport() http.RoundTripper {
+	return newTransport(opts.TLSConfig, opts.proxy)
+}
+
+// ProxyURL returns the proxy that overrides the proxy environment variables. If ok is false, the environment is used.
+// Otherwise, a nil URL means that no proxy is used.
+func (opts ProviderOptions) ProxyURL() (proxyUrl *url.URL, ok bool) {
+	if opts.proxy == nil {
+		return nil, false
+	}
+	return opts.proxy.url, true
+}
+
+var providers = struct {
+	mutex sync.RWMutex
+	byKey map[string]Provider
+}{
+	byKey: map[string]Provider{
+		schemeHttp:   ProviderFunc(newHttpProviderClient),
+		schemeHttps:  ProviderFunc(newHttpProviderClient),
+		schemeFile:   ProviderFunc(newHttpProviderClient),
+		schemeOCI:    ProviderFunc(newOCIProviderClient),
+		ProviderGit:  ProviderFunc(newGitProviderClient),
+		ProviderHelm: ProviderFunc(newHelmProviderClient),
+	},
+}
+
+// RegisterProvider makes a provider available for repositories with URLs of the given scheme. Like sql.Register, it
+// is meant to be called during initialization and panics if provider is nil or a provider for scheme already exists.
+func RegisterProvider(scheme string, provider Provider) {
+	providers.mutex.Lock()
+	defer providers.mutex.Unlock()
+	if provider == nil {
+		panic("repo client: provider is nil")
+	} else if _, ok := providers.byKey[scheme]; ok {
+		panic("repo client: provider for " + scheme + " is already registered")
+	}
+	providers.byKey[scheme] = provider
+}
+
+// RegisteredProviders returns the keys of all registered providers, sorted alphabetically
+func RegisteredProviders() []string {
+	providers.mutex.RLock()
+	defer providers.mutex.RUnlock()
+	keys := make([]string, 0, len(providers.byKey))
+	for key := range providers.byKey {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func getProvider(key string) (Provider, bool) {
+	providers.mutex.RLock()
+	defer providers.mutex.RUnlock()
+	provider, ok := providers.byKey[key]
+	return provider, ok
+}
+
+// ProviderFor returns the provider that creates clients for the given repository. Repositories with a git or Helm
+// spec are handled by the providers ProviderGit and ProviderHelm, all others by the provider of their URL scheme.
+func ProviderFor(repo v1alpha1.PackageRepository) (Provider, error) {
+	key, err := providerKey(repo)
+	if err != nil {
+		return nil, err
+	}
+	if provider, ok := getProvider(key); ok {
+		return provider, nil
+	}
+	return nil, fmt.Errorf("unsupported repository source: %v", key)
+}
+
+func providerKey(repo v1alpha1.PackageRepository) (string, error) {
+	if repo.IsGitRepository() && repo.IsHelmRepository() {
+		return "", errors.New("a repository can not be a git and a Helm repository at once")
+	} else if repo.IsGitRepository() {
+		return ProviderGit, nil
+	} else if repo.IsHelmRepository() {
+		return ProviderHelm, nil
+	} else if parsed, err := url.Parse(repo.Spec.Url); err != nil {
+		return "", err
+	} else if parsed.Scheme == ProviderGit || parsed.Scheme == ProviderHelm {
+		// these providers require a spec, so they can not be selected by the URL alone
+		return "", fmt.Errorf("unsupported repository source: %v", parsed.Scheme)
+	} else {
+		return parsed.Scheme, nil
+	}
+}
+
+func newHttpProviderClient(repo v1alpha1.PackageRepository, opts ProviderOptions) (RepoClient, error) {
+	client := New(repo.Spec.Url, opts.Authenticator, opts.MaxCacheAge)
+	client.retryBackoff = opts.RetryBackoff
+	client.transport = opts.Transport()
+	return client, nil
+}
+
+func newOCIProviderClient(repo v1alpha1.PackageRepository, opts ProviderOptions) (RepoClient, error) {
+	client := NewOCI(repo.Spec.Url, opts.Authenticator, opts.MaxCacheAge)
+	client.retryBackoff = opts.RetryBackoff
+	client.transport = opts.Transport()
+	return client, nil
+}
+
+func newGitProviderClient(repo v1alpha1.PackageRepository, opts ProviderOptions) (RepoClient, error) {
+	if opts.TLSConfig != nil && len(opts.TLSConfig.Certificates) > 0 {
+		return nil, errors.New("invalid TLS config: client certificates are not supported for git repositories")
+	}
+	client := NewGit(repo.Spec.Url, *repo.Spec.Git, opts.Authenticator, opts.MaxCacheAge)
+	client.caBundle = opts.CABundle
+	// git can not be told to ignore the proxy environment variables, so only an explicit proxy is used
+	if proxyUrl, ok := opts.ProxyURL(); ok && proxyUrl != nil {
+		client.proxyUrl = proxyUrl.String()
+	}
+	return client, nil
+}
+
+func newHelmProviderClient(repo v1alpha1.PackageRepository, opts ProviderOptions) (RepoClient, error) {
+	if isOCIURL(repo.Spec.Url) {
+		return nil, errors.New("Helm charts in OCI registries are not supported")
+	}
+	client := NewHelm(repo.Spec.Url, *repo.Spec.Helm, opts.Authenticator, opts.MaxCacheAge)
+	client.client.retryBackoff = opts.RetryBackoff
+	client.client.transport = opts.Transport()
+	return client, nil
+}
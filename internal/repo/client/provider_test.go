@@ -0,0 +1,62 @@
+package client
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type testProvider struct{}
+
+func (testProvider) NewClient(repo v1alpha1.PackageRepository, opts ProviderOptions) (RepoClient, error) {
+	return &errorclient{err: errors.New("not implemented")}, nil
+}
+
+func (testProvider) ValidateURL(u *url.URL) error {
+	if u.Host == "" {
+		return errors.New("test URL must have a host")
+	}
+	return nil
+}
+
+var _ = Describe("Provider", func() {
+	repoWithUrl := func(rawUrl string) v1alpha1.PackageRepository {
+		return v1alpha1.PackageRepository{Spec: v1alpha1.PackageRepositorySpec{Url: rawUrl}}
+	}
+
+	RegisterProvider("test", testProvider{})
+
+	It("should select built-in providers by spec and scheme", func() {
+		Expect(providerKey(repoWithUrl("https://packages.dl.glasskube.dev/packages"))).To(Equal(schemeHttps))
+		Expect(providerKey(repoWithUrl("oci://ghcr.io/org/packages"))).To(Equal(schemeOCI))
+		gitRepo := repoWithUrl("https://github.com/org/packages.git")
+		gitRepo.Spec.Git = &v1alpha1.PackageRepositoryGitSpec{}
+		Expect(providerKey(gitRepo)).To(Equal(ProviderGit))
+		helmRepo := repoWithUrl("https://charts.example.com")
+		helmRepo.Spec.Helm = &v1alpha1.PackageRepositoryHelmSpec{}
+		Expect(providerKey(helmRepo)).To(Equal(ProviderHelm))
+	})
+
+	It("should not select git or Helm providers without a spec", func() {
+		_, err := ProviderFor(repoWithUrl("git://github.com/org/packages.git"))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should use registered providers", func() {
+		provider, err := ProviderFor(repoWithUrl("test://example.com"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(provider).To(Equal(testProvider{}))
+		Expect(RegisteredProviders()).To(ContainElement("test"))
+		Expect(func() { RegisterProvider("test", testProvider{}) }).To(Panic())
+	})
+
+	It("should validate URLs of registered providers", func() {
+		Expect(ValidateURL("test://example.com")).To(Succeed())
+		Expect(ValidateURL("test:///path")).NotTo(Succeed())
+		Expect(ValidateURL("unknown://example.com")).NotTo(Succeed())
+		Expect(ValidateURL("helm://example.com")).NotTo(Succeed())
+	})
+})
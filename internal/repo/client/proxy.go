@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+)
+
+// proxyConfig is the proxy of a repository that overrides the proxy environment variables
+type proxyConfig struct {
+	// url is nil if the repository is accessed without a proxy
+	url *url.URL
+}
+
+// newProxyConfig returns the proxy configuration of the given repository, or nil if the repository does not configure
+// a proxy, so that the environment is used
+func (d *defaultClientset) newProxyConfig(repo v1alpha1.PackageRepository) (*proxyConfig, error) {
+	spec := repo.Spec.Proxy
+	if spec == nil {
+		return nil, nil
+	}
+	rawUrl := spec.Url
+	if spec.UrlSecretRef != nil {
+		if secret, err := d.client.GetSecret(context.TODO(), spec.UrlSecretRef.Name, "glasskube-system"); err != nil {
+			return nil, fmt.Errorf("cannot get proxy url: %w", err)
+		} else if data, err := getBytesFromSecret(secret, spec.UrlSecretRef.Key); err != nil {
+			return nil, fmt.Errorf("cannot get proxy url: %w", err)
+		} else {
+			rawUrl = string(data)
+		}
+	}
+	if rawUrl == "" {
+		return &proxyConfig{}, nil
+	}
+	proxyUrl, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, err
+	}
+	switch proxyUrl.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q (must be one of http, https, socks5, socks5h)",
+			proxyUrl.Scheme)
+	}
+	if proxyUrl.Host == "" {
+		return nil, fmt.Errorf("proxy url %v has no host", proxyUrl.Redacted())
+	}
+	return &proxyConfig{url: proxyUrl}, nil
+}
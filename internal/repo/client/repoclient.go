@@ -21,6 +21,12 @@ type RepoClient interface {
 	GetPackageManifestURL(name, version string) (string, error)
 }
 
+// CacheInvalidator is implemented by clients that cache what they fetch from a repository
+type CacheInvalidator interface {
+	// InvalidateCache discards all cached responses, so that the next request fetches from the repository again
+	InvalidateCache()
+}
+
 type RepoMetaclient interface {
 	LatestVersionGetter
 	FetchMetaIndex(target *types.MetaIndex) error
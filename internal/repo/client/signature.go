@@ -0,0 +1,130 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/repo/signature"
+	"github.com/glasskube/glasskube/internal/repo/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// packageManifestSignatureFile is the name of the file next to package.yaml that contains its signature
+const packageManifestSignatureFile = "package.yaml.sig"
+
+// SignatureVerifier is implemented by clients of repositories that have signature verification configured
+type SignatureVerifier interface {
+	// VerifyPackageManifest checks the signature of the manifest of the given package version. The returned error wraps
+	// signature.ErrUnsigned or signature.ErrInvalid if the signature could not be verified.
+	VerifyPackageManifest(name, version string) error
+}
+
+// RawManifestSource is implemented by clients that can provide the raw package manifest and its signature. It is
+// required for signature verification and manifest digests.
+type RawManifestSource interface {
+	// FetchPackageManifestBytes returns the unparsed content of the manifest of the given package version.
+	FetchPackageManifestBytes(name, version string) ([]byte, error)
+	// FetchPackageManifestSignature returns the signature of the manifest of the given package version. A missing
+	// signature is reported as empty signature without an error.
+	FetchPackageManifestSignature(name, version string) ([]byte, error)
+}
+
+// verifyingClient wraps the client of a repository with signature verification. If signatures are required, package
+// manifests are only returned if their signature could be verified.
+type verifyingClient struct {
+	RepoClient
+	source   RawManifestSource
+	verifier *signature.Verifier
+	required bool
+}
+
+func newVerifyingClient(client RepoClient, spec v1alpha1.PackageRepositorySignatureSpec) RepoClient {
+	if source, ok := client.(RawManifestSource); !ok {
+		return &errorclient{err: errors.New("signature verification is not supported for this repository")}
+	} else if verifier, err := signature.NewVerifier(spec); err != nil {
+		return &errorclient{err: fmt.Errorf("invalid signature config: %w", err)}
+	} else {
+		return &verifyingClient{RepoClient: client, source: source, verifier: verifier, required: spec.Required}
+	}
+}
+
+var _ RepoClient = &verifyingClient{}
+var _ SignatureVerifier = &verifyingClient{}
+var _ CacheInvalidator = &verifyingClient{}
+var _ GitSyncer = &verifyingClient{}
+
+// VerifyPackageManifest implements SignatureVerifier.
+func (c *verifyingClient) VerifyPackageManifest(name, version string) error {
+	_, err := c.fetchVerified(name, version)
+	return err
+}
+
+// FetchLatestPackageManifest implements RepoClient.
+func (c *verifyingClient) FetchLatestPackageManifest(name string, target *v1alpha1.PackageManifest) (string, error) {
+	var versions types.PackageIndex
+	if err := c.FetchPackageIndex(name, &versions); err != nil {
+		return "", err
+	}
+	return versions.LatestVersion, c.FetchPackageManifest(name, versions.LatestVersion, target)
+}
+
+// FetchPackageManifest implements RepoClient.
+func (c *verifyingClient) FetchPackageManifest(name, version string, target *v1alpha1.PackageManifest) error {
+	if !c.required {
+		return c.RepoClient.FetchPackageManifest(name, version, target)
+	}
+	if manifest, err := c.fetchVerified(name, version); err != nil {
+		return fmt.Errorf("refusing to use %v version %v: %w", name, version, err)
+	} else if err := yaml.Unmarshal(manifest, target); err != nil {
+		return fmt.Errorf("could not decode manifest of %v version %v: %w", name, version, err)
+	} else {
+		return nil
+	}
+}
+
+// InvalidateCache implements CacheInvalidator, if the underlying client does
+func (c *verifyingClient) InvalidateCache() {
+	if invalidator, ok := c.RepoClient.(CacheInvalidator); ok {
+		invalidator.InvalidateCache()
+	}
+}
+
+// Sync implements GitSyncer, if the underlying client does. Otherwise, it does nothing.
+func (c *verifyingClient) Sync() (string, error) {
+	if syncer, ok := c.RepoClient.(GitSyncer); ok {
+		return syncer.Sync()
+	}
+	return "", nil
+}
+
+var _ RawManifestSource = &verifyingClient{}
+
+// FetchPackageManifestBytes implements RawManifestSource.
+func (c *verifyingClient) FetchPackageManifestBytes(name, version string) ([]byte, error) {
+	if !c.required {
+		return c.source.FetchPackageManifestBytes(name, version)
+	}
+	if manifest, err := c.fetchVerified(name, version); err != nil {
+		return nil, fmt.Errorf("refusing to use %v version %v: %w", name, version, err)
+	} else {
+		return manifest, nil
+	}
+}
+
+// FetchPackageManifestSignature implements RawManifestSource.
+func (c *verifyingClient) FetchPackageManifestSignature(name, version string) ([]byte, error) {
+	return c.source.FetchPackageManifestSignature(name, version)
+}
+
+func (c *verifyingClient) fetchVerified(name, version string) ([]byte, error) {
+	if manifest, err := c.source.FetchPackageManifestBytes(name, version); err != nil {
+		return nil, err
+	} else if sig, err := c.source.FetchPackageManifestSignature(name, version); err != nil {
+		return nil, fmt.Errorf("failed to fetch signature: %w", err)
+	} else if err := c.verifier.Verify(manifest, sig); err != nil {
+		return nil, err
+	} else {
+		return manifest, nil
+	}
+}
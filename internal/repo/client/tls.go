@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// newTLSConfig returns the TLS configuration for connections to the given repository, or nil if the repository does
+// not configure custom certificates. Custom CA certificates are trusted in addition to the system trust store.
+func (d *defaultClientset) newTLSConfig(repo v1alpha1.PackageRepository) (*tls.Config, error) {
+	spec := repo.Spec.TLS
+	if spec == nil {
+		return nil, nil
+	}
+	caBundle, err := d.getCABundle(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(caBundle) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, errors.New("CA bundle does not contain any PEM encoded certificate")
+		}
+		config.RootCAs = pool
+	}
+	if spec.ClientCertificateSecretRef != nil {
+		secret, err := d.client.GetSecret(context.TODO(), spec.ClientCertificateSecretRef.Name, "glasskube-system")
+		if err != nil {
+			return nil, fmt.Errorf("cannot get client certificate: %w", err)
+		}
+		certPEM, err := getBytesFromSecret(secret, corev1.TLSCertKey)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get client certificate: %w", err)
+		}
+		keyPEM, err := getBytesFromSecret(secret, corev1.TLSPrivateKeyKey)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get client certificate: %w", err)
+		}
+		if cert, err := tls.X509KeyPair(certPEM, keyPEM); err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		} else {
+			config.Certificates = []tls.Certificate{cert}
+		}
+	}
+	return config, nil
+}
+
+// getCABundle returns the PEM encoded CA certificates that are configured for the given repository, or nil if there
+// are none
+func (d *defaultClientset) getCABundle(repo v1alpha1.PackageRepository) ([]byte, error) {
+	spec := repo.Spec.TLS
+	if spec == nil {
+		return nil, nil
+	}
+	caBundle := []byte(spec.CABundle)
+	if spec.CABundleSecretRef != nil {
+		if secret, err := d.client.GetSecret(context.TODO(), spec.CABundleSecretRef.Name, "glasskube-system"); err != nil {
+			return nil, fmt.Errorf("cannot get CA bundle: %w", err)
+		} else if data, err := getBytesFromSecret(secret, spec.CABundleSecretRef.Key); err != nil {
+			return nil, fmt.Errorf("cannot get CA bundle: %w", err)
+		} else {
+			caBundle = append(append(caBundle, '\n'), data...)
+		}
+	}
+	return caBundle, nil
+}
+
+// newTransport returns a transport that uses the given TLS and proxy configuration, or nil if both are nil, so that
+// the default transport is used
+func newTransport(config *tls.Config, proxy *proxyConfig) http.RoundTripper {
+	if config == nil && proxy == nil {
+		return nil
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = config
+	if proxy != nil {
+		transport.Proxy = http.ProxyURL(proxy.url)
+	}
+	return transport
+}
+
+func getBytesFromSecret(secret *corev1.Secret, key string) ([]byte, error) {
+	if data, ok := secret.Data[key]; ok {
+		return data, nil
+	}
+	return nil, fmt.Errorf("%v has no key %v", secret.Name, key)
+}
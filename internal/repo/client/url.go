@@ -0,0 +1,55 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+)
+
+const (
+	schemeHttp  = "http"
+	schemeHttps = "https"
+	schemeFile  = "file"
+	schemeOCI   = "oci"
+)
+
+// ValidateURL checks whether rawUrl can be used as URL of a package repository.
+// Besides http and https, local directories can be used as repository with the file scheme (e.g. file:///path/to/repo)
+// and OCI registries with the oci scheme (e.g. oci://ghcr.io/org/packages). URLs of other schemes are accepted if a
+// Provider is registered for the scheme.
+func ValidateURL(rawUrl string) error {
+	parsed, err := url.ParseRequestURI(rawUrl)
+	if err != nil {
+		return err
+	}
+	switch parsed.Scheme {
+	case schemeHttp, schemeHttps, schemeOCI:
+		if parsed.Host == "" {
+			return fmt.Errorf("%v URL must have a host", parsed.Scheme)
+		}
+		return nil
+	case schemeFile:
+		if parsed.Host != "" && parsed.Host != "localhost" {
+			return errors.New("file URL must not have a host other than localhost")
+		} else if !filepath.IsAbs(filepath.FromSlash(parsed.Path)) {
+			return errors.New("file URL must have an absolute path")
+		}
+		return nil
+	default:
+		if provider, ok := getProvider(parsed.Scheme); !ok || parsed.Scheme == ProviderGit ||
+			parsed.Scheme == ProviderHelm {
+			return fmt.Errorf("unsupported URL scheme: %v", parsed.Scheme)
+		} else if validator, ok := provider.(URLValidator); ok {
+			return validator.ValidateURL(parsed)
+		}
+		return nil
+	}
+}
+
+func isFileURL(rawUrl string) (string, bool) {
+	if parsed, err := url.Parse(rawUrl); err == nil && parsed.Scheme == schemeFile {
+		return filepath.FromSlash(parsed.Path), true
+	}
+	return "", false
+}
@@ -0,0 +1,358 @@
+// Package signature verifies the signatures of package manifests. Signatures are created with cosign:
+//
+//	cosign sign-blob --key cosign.key --output-signature package.yaml.sig package.yaml
+//	cosign sign-blob --bundle package.yaml.sig package.yaml
+//
+// The first command creates a plain signature with a key pair, the second one creates a bundle that contains the
+// signature, the short-lived certificate of the signer and the entry of the signature in the transparency log
+// ("keyless" signing). Bundles created with a key pair are accepted as well.
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+)
+
+var (
+	// ErrUnsigned is returned for package manifests that have no signature
+	ErrUnsigned = errors.New("package manifest is not signed")
+	// ErrInvalid is returned for package manifests whose signature could not be verified
+	ErrInvalid = errors.New("package manifest signature is invalid")
+)
+
+var (
+	// oidIssuer is the extension of a Fulcio certificate that contains the OIDC issuer as raw string
+	oidIssuer = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+	// oidIssuerV2 is the extension of a Fulcio certificate that contains the OIDC issuer as DER encoded UTF8String
+	oidIssuerV2 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+)
+
+// Bundle is the format written by "cosign sign-blob --bundle"
+type Bundle struct {
+	Base64Signature string       `json:"base64Signature"`
+	Cert            string       `json:"cert,omitempty"`
+	RekorBundle     *RekorBundle `json:"rekorBundle,omitempty"`
+}
+
+// RekorBundle proves that a signature was recorded in the transparency log at a certain time
+type RekorBundle struct {
+	SignedEntryTimestamp []byte       `json:"SignedEntryTimestamp"`
+	Payload              RekorPayload `json:"Payload"`
+}
+
+type RekorPayload struct {
+	Body           string `json:"body"`
+	IntegratedTime int64  `json:"integratedTime"`
+	LogIndex       int64  `json:"logIndex"`
+	LogID          string `json:"logID"`
+}
+
+// hashedRekord is the relevant part of a transparency log entry of the kind "hashedrekord"
+type hashedRekord struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		Data struct {
+			Hash struct {
+				Algorithm string `json:"algorithm"`
+				Value     string `json:"value"`
+			} `json:"hash"`
+		} `json:"data"`
+		Signature struct {
+			Content string `json:"content"`
+		} `json:"signature"`
+	} `json:"spec"`
+}
+
+// ParseBundle parses either a bundle or a plain base64 encoded signature
+func ParseBundle(data []byte) (*Bundle, error) {
+	var bundle Bundle
+	trimmed := strings.TrimSpace(string(data))
+	if strings.HasPrefix(trimmed, "{") {
+		if err := json.Unmarshal([]byte(trimmed), &bundle); err != nil {
+			return nil, fmt.Errorf("%w: could not decode bundle: %w", ErrInvalid, err)
+		}
+	} else {
+		bundle.Base64Signature = trimmed
+	}
+	if bundle.Base64Signature == "" {
+		return nil, fmt.Errorf("%w: signature is empty", ErrInvalid)
+	}
+	return &bundle, nil
+}
+
+type keylessPolicy struct {
+	identities    []v1alpha1.PackageRepositoryKeylessIdentity
+	roots         *x509.CertPool
+	intermediates *x509.CertPool
+	tlogKey       crypto.PublicKey
+}
+
+// Verifier checks signatures against the trusted keys and identities of a repository
+type Verifier struct {
+	publicKeys []crypto.PublicKey
+	keyless    *keylessPolicy
+}
+
+func NewVerifier(spec v1alpha1.PackageRepositorySignatureSpec) (*Verifier, error) {
+	var v Verifier
+	for _, key := range spec.PublicKeys {
+		if keys, err := ParsePublicKeys([]byte(key)); err != nil {
+			return nil, err
+		} else {
+			v.publicKeys = append(v.publicKeys, keys...)
+		}
+	}
+	if spec.Keyless != nil {
+		policy := keylessPolicy{
+			identities:    spec.Keyless.Identities,
+			roots:         x509.NewCertPool(),
+			intermediates: x509.NewCertPool(),
+		}
+		if len(policy.identities) == 0 {
+			return nil, errors.New("keyless signature verification needs at least one identity")
+		}
+		if certs, err := parseCertificates([]byte(spec.Keyless.RootCertificates)); err != nil {
+			return nil, fmt.Errorf("invalid root certificates: %w", err)
+		} else if len(certs) == 0 {
+			return nil, errors.New("keyless signature verification needs at least one root certificate")
+		} else {
+			for _, cert := range certs {
+				if cert.CheckSignatureFrom(cert) == nil {
+					policy.roots.AddCert(cert)
+				} else {
+					policy.intermediates.AddCert(cert)
+				}
+			}
+		}
+		if keys, err := ParsePublicKeys([]byte(spec.Keyless.TransparencyLogPublicKey)); err != nil {
+			return nil, fmt.Errorf("invalid transparency log public key: %w", err)
+		} else if len(keys) != 1 {
+			return nil, errors.New("keyless signature verification needs exactly one transparency log public key")
+		} else {
+			policy.tlogKey = keys[0]
+		}
+		v.keyless = &policy
+	}
+	if len(v.publicKeys) == 0 && v.keyless == nil {
+		return nil, errors.New("signature verification needs at least one public key or a keyless policy")
+	}
+	return &v, nil
+}
+
+// Verify checks that signature is a valid signature of content, made with one of the trusted keys or by one of the
+// trusted identities. If signature is empty, ErrUnsigned is returned. All other errors wrap ErrInvalid.
+func (v *Verifier) Verify(content, signature []byte) error {
+	if len(signature) == 0 {
+		return ErrUnsigned
+	}
+	bundle, err := ParseBundle(signature)
+	if err != nil {
+		return err
+	}
+	sig, err := base64.StdEncoding.DecodeString(bundle.Base64Signature)
+	if err != nil {
+		return fmt.Errorf("%w: could not decode signature: %w", ErrInvalid, err)
+	}
+	if bundle.Cert != "" {
+		return v.verifyKeyless(content, sig, bundle)
+	}
+	for _, key := range v.publicKeys {
+		if verifySignature(key, content, sig) == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: signature does not match any of the trusted public keys", ErrInvalid)
+}
+
+func (v *Verifier) verifyKeyless(content, sig []byte, bundle *Bundle) error {
+	if v.keyless == nil {
+		return fmt.Errorf("%w: signature was made with a certificate, but keyless signing is not trusted", ErrInvalid)
+	}
+	cert, err := parseBundleCertificate(bundle.Cert)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+	if bundle.RekorBundle == nil {
+		return fmt.Errorf("%w: signature has no transparency log entry", ErrInvalid)
+	}
+	if err := v.keyless.verifyRekorBundle(*bundle.RekorBundle, content, bundle.Base64Signature); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+	_, err = cert.Verify(x509.VerifyOptions{
+		Roots:         v.keyless.roots,
+		Intermediates: v.keyless.intermediates,
+		CurrentTime:   time.Unix(bundle.RekorBundle.Payload.IntegratedTime, 0),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	})
+	if err != nil {
+		return fmt.Errorf("%w: untrusted certificate: %w", ErrInvalid, err)
+	}
+	if !v.keyless.matchesIdentity(cert) {
+		return fmt.Errorf("%w: certificate was not issued to any of the trusted identities", ErrInvalid)
+	}
+	if err := verifySignature(cert.PublicKey, content, sig); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+	return nil
+}
+
+// verifyRekorBundle checks that the signed entry timestamp was created by the transparency log and that the entry is
+// about this signature of this content
+func (p *keylessPolicy) verifyRekorBundle(bundle RekorBundle, content []byte, base64Signature string) error {
+	// The signed entry timestamp is a signature over the canonical JSON of the payload. For a payload that only
+	// contains strings without special characters and integers, this is what json.Marshal produces for a map.
+	canonical, err := json.Marshal(map[string]any{
+		"body":           bundle.Payload.Body,
+		"integratedTime": bundle.Payload.IntegratedTime,
+		"logIndex":       bundle.Payload.LogIndex,
+		"logID":          bundle.Payload.LogID,
+	})
+	if err != nil {
+		return err
+	}
+	if err := verifySignature(p.tlogKey, canonical, bundle.SignedEntryTimestamp); err != nil {
+		return fmt.Errorf("invalid transparency log entry: %w", err)
+	}
+	var entry hashedRekord
+	if body, err := base64.StdEncoding.DecodeString(bundle.Payload.Body); err != nil {
+		return fmt.Errorf("could not decode transparency log entry: %w", err)
+	} else if err := json.Unmarshal(body, &entry); err != nil {
+		return fmt.Errorf("could not decode transparency log entry: %w", err)
+	}
+	digest := sha256.Sum256(content)
+	if entry.Kind != "hashedrekord" || entry.Spec.Data.Hash.Algorithm != "sha256" ||
+		entry.Spec.Data.Hash.Value != hex.EncodeToString(digest[:]) ||
+		entry.Spec.Signature.Content != base64Signature {
+		return errors.New("transparency log entry does not match the signature")
+	}
+	return nil
+}
+
+func (p *keylessPolicy) matchesIdentity(cert *x509.Certificate) bool {
+	issuer := certificateIssuer(cert)
+	subjects := slices.Clone(cert.EmailAddresses)
+	for _, uri := range cert.URIs {
+		subjects = append(subjects, uri.String())
+	}
+	for _, identity := range p.identities {
+		if identity.Issuer == issuer && slices.Contains(subjects, identity.Subject) {
+			return true
+		}
+	}
+	return false
+}
+
+func certificateIssuer(cert *x509.Certificate) string {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidIssuerV2) {
+			var issuer string
+			if _, err := asn1.Unmarshal(ext.Value, &issuer); err == nil {
+				return issuer
+			}
+		} else if ext.Id.Equal(oidIssuer) {
+			return string(ext.Value)
+		}
+	}
+	return ""
+}
+
+func verifySignature(key crypto.PublicKey, content, sig []byte) error {
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, ecdsaDigest(key.Curve, content), sig) {
+			return errors.New("ecdsa signature verification failed")
+		}
+		return nil
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, content, sig) {
+			return errors.New("ed25519 signature verification failed")
+		}
+		return nil
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(content)
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig)
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+// ecdsaDigest hashes content with the hash function that matches the size of the curve, like cosign does
+func ecdsaDigest(curve elliptic.Curve, content []byte) []byte {
+	switch curve {
+	case elliptic.P384():
+		digest := sha512.Sum384(content)
+		return digest[:]
+	case elliptic.P521():
+		digest := sha512.Sum512(content)
+		return digest[:]
+	default:
+		digest := sha256.Sum256(content)
+		return digest[:]
+	}
+}
+
+// ParsePublicKeys parses all PEM encoded public keys in data
+func ParsePublicKeys(data []byte) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "PUBLIC KEY" {
+			return nil, fmt.Errorf("unexpected PEM block %v", block.Type)
+		} else if key, err := x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+			return nil, fmt.Errorf("invalid public key: %w", err)
+		} else {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no PEM encoded public key found")
+	}
+	return keys, nil
+}
+
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected PEM block %v", block.Type)
+		} else if cert, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return nil, err
+		} else {
+			certs = append(certs, cert)
+		}
+	}
+	return certs, nil
+}
+
+// parseBundleCertificate parses the certificate of a bundle, which is a PEM encoded certificate that is either
+// base64 encoded again (as written by cosign) or not
+func parseBundleCertificate(cert string) (*x509.Certificate, error) {
+	data := []byte(cert)
+	if decoded, err := base64.StdEncoding.DecodeString(cert); err == nil {
+		data = decoded
+	}
+	if certs, err := parseCertificates(data); err != nil {
+		return nil, fmt.Errorf("invalid certificate: %w", err)
+	} else if len(certs) == 0 {
+		return nil, errors.New("no PEM encoded certificate found")
+	} else {
+		return certs[0], nil
+	}
+}
@@ -0,0 +1,245 @@
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"time"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var manifest = []byte("name: foo\nshortDescription: A package\n")
+
+func publicKeyPEM(key crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	Expect(err).NotTo(HaveOccurred())
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func certificatePEM(der []byte) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func signECDSA(key *ecdsa.PrivateKey, content []byte) []byte {
+	digest := sha256.Sum256(content)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	Expect(err).NotTo(HaveOccurred())
+	return sig
+}
+
+var _ = Describe("Verifier", func() {
+	Describe("with public keys", func() {
+		var ecdsaKey *ecdsa.PrivateKey
+		var ed25519Key ed25519.PrivateKey
+		var verifier *Verifier
+
+		BeforeEach(func() {
+			var err error
+			ecdsaKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+			_, ed25519Key, err = ed25519.GenerateKey(rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+			verifier, err = NewVerifier(v1alpha1.PackageRepositorySignatureSpec{
+				PublicKeys: []string{publicKeyPEM(&ecdsaKey.PublicKey), publicKeyPEM(ed25519Key.Public())},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should accept a plain ECDSA signature", func() {
+			sig := base64.StdEncoding.EncodeToString(signECDSA(ecdsaKey, manifest))
+			Expect(verifier.Verify(manifest, []byte(sig+"\n"))).To(Succeed())
+		})
+
+		It("should accept an Ed25519 signature in a bundle", func() {
+			bundle, err := json.Marshal(Bundle{
+				Base64Signature: base64.StdEncoding.EncodeToString(ed25519.Sign(ed25519Key, manifest)),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(verifier.Verify(manifest, bundle)).To(Succeed())
+		})
+
+		It("should reject a signature of different content", func() {
+			sig := base64.StdEncoding.EncodeToString(signECDSA(ecdsaKey, []byte("name: bar")))
+			Expect(verifier.Verify(manifest, []byte(sig))).To(MatchError(ErrInvalid))
+		})
+
+		It("should reject a signature made with another key", func() {
+			otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+			sig := base64.StdEncoding.EncodeToString(signECDSA(otherKey, manifest))
+			Expect(verifier.Verify(manifest, []byte(sig))).To(MatchError(ErrInvalid))
+		})
+
+		It("should report a missing signature", func() {
+			Expect(verifier.Verify(manifest, nil)).To(MatchError(ErrUnsigned))
+		})
+
+		It("should reject keyless signatures", func() {
+			bundle, err := json.Marshal(Bundle{
+				Base64Signature: base64.StdEncoding.EncodeToString(signECDSA(ecdsaKey, manifest)),
+				Cert:            "invalid",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(verifier.Verify(manifest, bundle)).To(MatchError(ErrInvalid))
+		})
+	})
+
+	Describe("keyless", func() {
+		const issuer = "https://token.actions.githubusercontent.com"
+		const subject = "https://github.com/glasskube/packages/.github/workflows/release.yaml@refs/heads/main"
+
+		var tlogKey *ecdsa.PrivateKey
+		var spec v1alpha1.PackageRepositorySignatureSpec
+		var bundle Bundle
+
+		BeforeEach(func() {
+			caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+			caTemplate := &x509.Certificate{
+				SerialNumber:          big.NewInt(1),
+				Subject:               pkix.Name{CommonName: "test-ca"},
+				NotBefore:             time.Now().Add(-time.Hour),
+				NotAfter:              time.Now().Add(time.Hour),
+				IsCA:                  true,
+				BasicConstraintsValid: true,
+				KeyUsage:              x509.KeyUsageCertSign,
+			}
+			caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+			Expect(err).NotTo(HaveOccurred())
+			ca, err := x509.ParseCertificate(caDER)
+			Expect(err).NotTo(HaveOccurred())
+
+			// like Fulcio, the signing certificate was only valid at the time the signature was created
+			signedAt := time.Now().Add(-30 * time.Minute)
+			signerKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+			issuerExt, err := asn1.Marshal(issuer)
+			Expect(err).NotTo(HaveOccurred())
+			signerURI, err := url.Parse(subject)
+			Expect(err).NotTo(HaveOccurred())
+			leafTemplate := &x509.Certificate{
+				SerialNumber:    big.NewInt(2),
+				NotBefore:       signedAt.Add(-time.Minute),
+				NotAfter:        signedAt.Add(9 * time.Minute),
+				KeyUsage:        x509.KeyUsageDigitalSignature,
+				ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+				ExtraExtensions: []pkix.Extension{{Id: oidIssuerV2, Value: issuerExt}},
+				URIs:            []*url.URL{signerURI},
+			}
+			leafDER, err := x509.CreateCertificate(rand.Reader, leafTemplate, ca, &signerKey.PublicKey, caKey)
+			Expect(err).NotTo(HaveOccurred())
+
+			tlogKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+
+			sig := base64.StdEncoding.EncodeToString(signECDSA(signerKey, manifest))
+			digest := sha256.Sum256(manifest)
+			body, err := json.Marshal(map[string]any{
+				"apiVersion": "0.0.1",
+				"kind":       "hashedrekord",
+				"spec": map[string]any{
+					"data": map[string]any{
+						"hash": map[string]any{"algorithm": "sha256", "value": hex.EncodeToString(digest[:])},
+					},
+					"signature": map[string]any{"content": sig},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			bundle = Bundle{
+				Base64Signature: sig,
+				Cert:            base64.StdEncoding.EncodeToString([]byte(certificatePEM(leafDER))),
+				RekorBundle: &RekorBundle{Payload: RekorPayload{
+					Body:           base64.StdEncoding.EncodeToString(body),
+					IntegratedTime: signedAt.Unix(),
+					LogIndex:       42,
+					LogID:          "c0d23d6ad406973f9559f3ba2d1ca01f84147d8ffc5b8445c224f98b9591801d",
+				}},
+			}
+			bundle.RekorBundle.SignedEntryTimestamp = signRekorPayload(tlogKey, bundle.RekorBundle.Payload)
+
+			spec = v1alpha1.PackageRepositorySignatureSpec{
+				Keyless: &v1alpha1.PackageRepositoryKeylessSpec{
+					Identities:               []v1alpha1.PackageRepositoryKeylessIdentity{{Issuer: issuer, Subject: subject}},
+					RootCertificates:         certificatePEM(caDER),
+					TransparencyLogPublicKey: publicKeyPEM(&tlogKey.PublicKey),
+				},
+			}
+		})
+
+		verify := func() error {
+			verifier, err := NewVerifier(spec)
+			Expect(err).NotTo(HaveOccurred())
+			data, err := json.Marshal(bundle)
+			Expect(err).NotTo(HaveOccurred())
+			return verifier.Verify(manifest, data)
+		}
+
+		It("should accept a signature of a trusted identity", func() {
+			Expect(verify()).To(Succeed())
+		})
+
+		It("should reject a signature of another identity", func() {
+			spec.Keyless.Identities[0].Subject = "https://github.com/someone/else"
+			Expect(verify()).To(MatchError(ErrInvalid))
+		})
+
+		It("should reject a signature of another issuer", func() {
+			spec.Keyless.Identities[0].Issuer = "https://accounts.google.com"
+			Expect(verify()).To(MatchError(ErrInvalid))
+		})
+
+		It("should reject a forged transparency log entry", func() {
+			bundle.RekorBundle.Payload.IntegratedTime = time.Now().Unix()
+			Expect(verify()).To(MatchError(ErrInvalid))
+		})
+
+		It("should reject a signature that was logged after the certificate expired", func() {
+			bundle.RekorBundle.Payload.IntegratedTime = time.Now().Unix()
+			bundle.RekorBundle.SignedEntryTimestamp = signRekorPayload(tlogKey, bundle.RekorBundle.Payload)
+			Expect(verify()).To(MatchError(ErrInvalid))
+		})
+
+		It("should reject a bundle without transparency log entry", func() {
+			bundle.RekorBundle = nil
+			Expect(verify()).To(MatchError(ErrInvalid))
+		})
+	})
+
+	DescribeTable("NewVerifier should reject invalid configs",
+		func(spec v1alpha1.PackageRepositorySignatureSpec) {
+			_, err := NewVerifier(spec)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("Empty", v1alpha1.PackageRepositorySignatureSpec{}),
+		Entry("Invalid key", v1alpha1.PackageRepositorySignatureSpec{PublicKeys: []string{"not a key"}}),
+		Entry("Keyless without identities", v1alpha1.PackageRepositorySignatureSpec{
+			Keyless: &v1alpha1.PackageRepositoryKeylessSpec{},
+		}),
+	)
+})
+
+func signRekorPayload(key *ecdsa.PrivateKey, payload RekorPayload) []byte {
+	canonical, err := json.Marshal(map[string]any{
+		"body":           payload.Body,
+		"integratedTime": payload.IntegratedTime,
+		"logIndex":       payload.LogIndex,
+		"logID":          payload.LogID,
+	})
+	Expect(err).NotTo(HaveOccurred())
+	return signECDSA(key, canonical)
+}
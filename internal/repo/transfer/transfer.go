@@ -0,0 +1,281 @@
+// Package transfer exports the package repositories of a cluster to YAML and imports them into another cluster.
+// Credentials are only exported as references to Secrets, which have to be created in the target cluster separately.
+package transfer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/pkg/client"
+	"github.com/glasskube/glasskube/pkg/condition"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	kyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/yaml"
+)
+
+const kind = "PackageRepository"
+
+// DefaultTimeout is the time Import waits for the imported repositories to be synced by the operator
+const DefaultTimeout = 60 * time.Second
+
+// Sanitize returns a copy of the repository that only contains what is needed to recreate it in another cluster: the
+// name, the default repository annotation and the spec. Inline credentials are removed, only references to Secrets
+// are kept. The names of the removed fields are returned, so that the user can be warned about them.
+func Sanitize(repo v1alpha1.PackageRepository) (v1alpha1.PackageRepository, []string) {
+	result := v1alpha1.PackageRepository{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.GroupVersion.String(), Kind: kind},
+		ObjectMeta: metav1.ObjectMeta{Name: repo.Name},
+		Spec:       *repo.Spec.DeepCopy(),
+	}
+	result.SetDefaultRepositoryBool(repo.IsDefaultRepository())
+	var removed []string
+	if auth := result.Spec.Auth; auth != nil {
+		if auth.Basic != nil && auth.Basic.Password != nil {
+			auth.Basic.Password = nil
+			removed = append(removed, "spec.auth.basic.password")
+		}
+		if auth.Bearer != nil && auth.Bearer.Token != nil {
+			auth.Bearer.Token = nil
+			removed = append(removed, "spec.auth.bearer.token")
+		}
+	}
+	if proxy := result.Spec.Proxy; proxy != nil && proxy.Url != "" {
+		if proxyUrl, err := url.Parse(proxy.Url); err == nil && proxyUrl.User != nil {
+			if _, ok := proxyUrl.User.Password(); ok {
+				proxyUrl.User = url.User(proxyUrl.User.Username())
+				proxy.Url = proxyUrl.String()
+				removed = append(removed, "spec.proxy.url (password)")
+			}
+		}
+	}
+	return result, removed
+}
+
+// Export serializes the given repositories as a multi-document YAML stream, sorted by name. See Sanitize for what is
+// included. The names of removed fields are returned for every repository that had inline credentials.
+func Export(repos []v1alpha1.PackageRepository) ([]byte, map[string][]string, error) {
+	sorted := slices.Clone(repos)
+	slices.SortFunc(sorted, func(a, b v1alpha1.PackageRepository) int { return strings.Compare(a.Name, b.Name) })
+	var buffer bytes.Buffer
+	removedFields := make(map[string][]string)
+	for _, repo := range sorted {
+		sanitized, removed := Sanitize(repo)
+		if len(removed) > 0 {
+			removedFields[repo.Name] = removed
+		}
+		data, err := marshal(sanitized)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to export repository %v: %w", repo.Name, err)
+		}
+		buffer.WriteString("---\n")
+		buffer.Write(data)
+	}
+	return buffer.Bytes(), removedFields, nil
+}
+
+// marshal converts the repository to YAML, omitting the empty status and creation timestamp
+func marshal(repo v1alpha1.PackageRepository) ([]byte, error) {
+	var repoAsMap map[string]any
+	if data, err := json.Marshal(repo); err != nil {
+		return nil, err
+	} else if err := json.Unmarshal(data, &repoAsMap); err != nil {
+		return nil, err
+	}
+	delete(repoAsMap, "status")
+	if metadata, ok := repoAsMap["metadata"].(map[string]any); ok {
+		delete(metadata, "creationTimestamp")
+	}
+	return yaml.Marshal(repoAsMap)
+}
+
+// Parse reads repositories from a YAML or JSON stream, as created by Export. Every document must be a
+// PackageRepository with a name, every name may only occur once and only one repository may be the default.
+func Parse(reader io.Reader) ([]v1alpha1.PackageRepository, error) {
+	var repos []v1alpha1.PackageRepository
+	names := sets.New[string]()
+	decoder := kyaml.NewYAMLOrJSONDecoder(reader, 4096)
+	for {
+		var obj map[string]any
+		if err := decoder.Decode(&obj); errors.Is(err, io.EOF) {
+			if defaults := slices.DeleteFunc(slices.Clone(repos), func(repo v1alpha1.PackageRepository) bool {
+				return !repo.IsDefaultRepository()
+			}); len(defaults) > 1 {
+				return nil, errors.New("only one repository can be the default repository")
+			}
+			return repos, nil
+		} else if err != nil {
+			return nil, err
+		} else if obj == nil {
+			// empty document
+			continue
+		} else if obj["kind"] != kind {
+			return nil, fmt.Errorf("unsupported kind: %v", obj["kind"])
+		}
+		var repo v1alpha1.PackageRepository
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, &repo); err != nil {
+			return nil, err
+		} else if repo.Name == "" {
+			return nil, errors.New("repository without name")
+		} else if repo.Spec.Url == "" {
+			return nil, fmt.Errorf("repository %v has no url", repo.Name)
+		} else if names.Has(repo.Name) {
+			return nil, fmt.Errorf("duplicate repository %v", repo.Name)
+		}
+		names.Insert(repo.Name)
+		sanitized, _ := Sanitize(repo)
+		repos = append(repos, sanitized)
+	}
+}
+
+// Result is the outcome of importing a single repository
+type Result struct {
+	Name string
+	// Created is true if the repository did not exist before, false if an existing repository was updated
+	Created bool
+	// Ready is true if the repository was synced successfully after the import
+	Ready bool
+	// Message is the message of the Ready condition of the repository, or the reason why it was not imported
+	Message string
+	Err     error
+}
+
+// Import creates the given repositories, or updates the spec of existing repositories with the same name, and
+// requests a sync for each of them. It then waits until the operator has synced all of them, or the timeout expires,
+// and reports whether each repository is ready. If an imported repository is marked as default, the default
+// annotation is removed from the current default repository.
+func Import(
+	ctx context.Context,
+	pkgClient client.PackageV1Alpha1Client,
+	repos []v1alpha1.PackageRepository,
+	timeout time.Duration,
+) ([]Result, error) {
+	var existing v1alpha1.PackageRepositoryList
+	if err := pkgClient.PackageRepositories().GetAll(ctx, &existing); err != nil {
+		return nil, fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	watchCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	watcher, err := pkgClient.PackageRepositories().Watch(watchCtx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch repositories: %w", err)
+	}
+	defer watcher.Stop()
+
+	// sync request times are stored with a precision of seconds
+	requestedAt := time.Now().Truncate(time.Second)
+	results := make([]Result, len(repos))
+	pending := make(map[string]int)
+	for i, repo := range repos {
+		results[i] = importOne(ctx, pkgClient, existing.Items, repo)
+		if results[i].Err == nil {
+			pending[repo.Name] = i
+		}
+	}
+	if slices.ContainsFunc(repos, v1alpha1.PackageRepository.IsDefaultRepository) {
+		if err := unsetOtherDefaults(ctx, pkgClient, existing.Items, repos); err != nil {
+			return results, err
+		}
+	}
+
+	for len(pending) > 0 {
+		select {
+		case <-watchCtx.Done():
+			for name, i := range pending {
+				results[i].Message = fmt.Sprintf("%v was not synced within %v", name, timeout)
+			}
+			return results, nil
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return results, errors.New("watch of repositories was closed")
+			}
+			repo, ok := event.Object.(*v1alpha1.PackageRepository)
+			if !ok || (event.Type != watch.Added && event.Type != watch.Modified) {
+				continue
+			}
+			if i, ok := pending[repo.Name]; ok && isSyncedSince(*repo, requestedAt) {
+				if cond := meta.FindStatusCondition(repo.Status.Conditions, string(condition.Ready)); cond != nil {
+					results[i].Ready = cond.Status == metav1.ConditionTrue
+					results[i].Message = cond.Message
+				}
+				delete(pending, repo.Name)
+			}
+		}
+	}
+	return results, nil
+}
+
+// isSyncedSince returns true if the repository was synced after a sync was requested at the given time or later
+func isSyncedSince(repo v1alpha1.PackageRepository, requestedAt time.Time) bool {
+	syncRequestedAt, ok := repo.SyncRequestedAt()
+	return ok && !syncRequestedAt.Before(requestedAt) && repo.Status.LastSyncTime != nil && !repo.IsSyncRequested()
+}
+
+func importOne(
+	ctx context.Context,
+	pkgClient client.PackageV1Alpha1Client,
+	existing []v1alpha1.PackageRepository,
+	repo v1alpha1.PackageRepository,
+) Result {
+	result := Result{Name: repo.Name}
+	index := slices.IndexFunc(existing, func(r v1alpha1.PackageRepository) bool { return r.Name == repo.Name })
+	if index < 0 {
+		repo.RequestSync(time.Now())
+		if err := pkgClient.PackageRepositories().Create(ctx, &repo, metav1.CreateOptions{}); err != nil {
+			result.Err = fmt.Errorf("failed to create repository %v: %w", repo.Name, err)
+		} else {
+			result.Created = true
+		}
+		return result
+	}
+	current := existing[index].DeepCopy()
+	current.Spec = repo.Spec
+	current.SetDefaultRepositoryBool(repo.IsDefaultRepository() || current.IsDefaultRepository())
+	current.RequestSync(time.Now())
+	if err := pkgClient.PackageRepositories().Update(ctx, current, metav1.UpdateOptions{}); err != nil {
+		if apierrors.IsConflict(err) {
+			err = errors.New("the repository was modified concurrently, please try again")
+		}
+		result.Err = fmt.Errorf("failed to update repository %v: %w", repo.Name, err)
+	}
+	return result
+}
+
+// unsetOtherDefaults removes the default annotation from every existing repository that is not imported as default
+func unsetOtherDefaults(
+	ctx context.Context,
+	pkgClient client.PackageV1Alpha1Client,
+	existing []v1alpha1.PackageRepository,
+	imported []v1alpha1.PackageRepository,
+) error {
+	for _, repo := range existing {
+		if !repo.IsDefaultRepository() || slices.ContainsFunc(imported, func(r v1alpha1.PackageRepository) bool {
+			return r.Name == repo.Name && r.IsDefaultRepository()
+		}) {
+			continue
+		}
+		var current v1alpha1.PackageRepository
+		if err := pkgClient.PackageRepositories().Get(ctx, repo.Name, &current); err != nil {
+			return fmt.Errorf("failed to get current default repository %v: %w", repo.Name, err)
+		}
+		current.SetDefaultRepositoryBool(false)
+		if err := pkgClient.PackageRepositories().Update(ctx, &current, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to update current default repository %v: %w", repo.Name, err)
+		}
+	}
+	return nil
+}
@@ -21,6 +21,8 @@ type PackageRepoIndexItem struct {
 	IconUrl          string                 `json:"iconUrl,omitempty"`
 	LatestVersion    string                 `json:"latestVersion,omitempty"`
 	Scope            *v1alpha1.PackageScope `json:"scope,omitempty"`
+	Keywords         []string               `json:"keywords,omitempty"`
+	Categories       []string               `json:"categories,omitempty"`
 }
 
 type MetaIndex struct {
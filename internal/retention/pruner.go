@@ -0,0 +1,135 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	ctrladapter "github.com/glasskube/glasskube/internal/adapter/controllerruntime"
+	"github.com/glasskube/glasskube/internal/audit"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DefaultPruneInterval is the time between two runs of the Pruner
+const DefaultPruneInterval = time.Hour
+
+//+kubebuilder:rbac:groups=core,resources=configmaps,verbs=delete
+
+// Pruner applies the retention policy to the revision history of all packages and to the audit log. It is added to
+// the manager of the operator.
+type Pruner struct {
+	client.Client
+	// Interval is the time between two runs. If it is zero, DefaultPruneInterval is used.
+	Interval time.Duration
+	now      func() time.Time
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, so that only one operator prunes at a time
+func (p *Pruner) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implements manager.Runnable
+func (p *Pruner) Start(ctx context.Context) error {
+	interval := p.Interval
+	if interval == 0 {
+		interval = DefaultPruneInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := p.Prune(ctx); err != nil {
+			log.FromContext(ctx).Error(err, "failed to apply retention policy")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Prune loads the retention policy and removes all revisions and audit log entries that exceed it
+func (p *Pruner) Prune(ctx context.Context) error {
+	now := time.Now
+	if p.now != nil {
+		now = p.now
+	}
+	config, err := LoadConfig(ctx, ctrladapter.NewKubernetesClientAdapter(p.Client))
+	if err != nil {
+		return err
+	}
+	var errs error
+	if !config.Revisions.IsZero() {
+		errs = errors.Join(errs, p.pruneRevisions(ctx, config.Revisions, now()))
+	}
+	if !config.AuditLog.IsZero() {
+		errs = errors.Join(errs, p.pruneAuditLog(ctx, config.AuditLog, now()))
+	}
+	return errs
+}
+
+func (p *Pruner) pruneRevisions(ctx context.Context, policy Policy, now time.Time) error {
+	var pkgs []ctrlpkg.Package
+	var clpkgList v1alpha1.ClusterPackageList
+	if err := p.List(ctx, &clpkgList); err != nil {
+		return err
+	}
+	for i := range clpkgList.Items {
+		pkgs = append(pkgs, &clpkgList.Items[i])
+	}
+	var pkgList v1alpha1.PackageList
+	if err := p.List(ctx, &pkgList); err != nil {
+		return err
+	}
+	for i := range pkgList.Items {
+		pkgs = append(pkgs, &pkgList.Items[i])
+	}
+
+	var errs error
+	for _, pkg := range pkgs {
+		if !PruneRevisions(pkg.GetStatus(), policy, now) {
+			continue
+		}
+		log.FromContext(ctx).Info("pruning revision history", "package", pkg.GetName(),
+			"namespace", pkg.GetNamespace())
+		// a conflict means that the operator updated the status in the meantime, so it is pruned in the next run
+		if err := p.Status().Update(ctx, pkg); err != nil && !apierrors.IsConflict(err) {
+			errs = errors.Join(errs, err)
+		}
+	}
+	return errs
+}
+
+func (p *Pruner) pruneAuditLog(ctx context.Context, policy Policy, now time.Time) error {
+	var list corev1.ConfigMapList
+	if err := p.List(ctx, &list, client.InNamespace(audit.Namespace), client.HasLabels{audit.LabelAuditLog}); err != nil {
+		return err
+	}
+	records := make([]Record, len(list.Items))
+	byName := make(map[string]*corev1.ConfigMap, len(list.Items))
+	for i := range list.Items {
+		cm := &list.Items[i]
+		byName[cm.Name] = cm
+		records[i] = Record{Name: cm.Name, CreatedAt: cm.CreationTimestamp.Time}
+		if entry, err := audit.EntryFromConfigMap(cm); err == nil {
+			records[i].CreatedAt = entry.Timestamp
+		}
+	}
+	expired := Expired(records, policy, now)
+	if len(expired) > 0 {
+		log.FromContext(ctx).Info("pruning audit log", "entries", len(expired))
+	}
+	var errs error
+	for _, name := range expired {
+		if err := p.Delete(ctx, byName[name]); err != nil && !apierrors.IsNotFound(err) {
+			errs = errors.Join(errs, err)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,150 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/adapter"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	Namespace     = "glasskube-system"
+	ConfigMapName = "glasskube-retention"
+
+	keyRevisionsMaxCount   = "revisionsMaxCount"
+	keyRevisionsMaxAgeDays = "revisionsMaxAgeDays"
+	keyAuditMaxCount       = "auditLogMaxCount"
+	keyAuditMaxAgeDays     = "auditLogMaxAgeDays"
+)
+
+// Policy limits how many records are kept and for how long. Zero values mean that there is no limit.
+type Policy struct {
+	MaxCount   int
+	MaxAgeDays int
+}
+
+func (p Policy) IsZero() bool {
+	return p.MaxCount == 0 && p.MaxAgeDays == 0
+}
+
+func (p Policy) Validate() error {
+	if p.MaxCount < 0 {
+		return fmt.Errorf("maximum count must not be negative: %v", p.MaxCount)
+	} else if p.MaxAgeDays < 0 {
+		return fmt.Errorf("maximum age must not be negative: %v", p.MaxAgeDays)
+	}
+	return nil
+}
+
+// isExpired returns true if a record created at the given time is older than the maximum age
+func (p Policy) isExpired(createdAt, now time.Time) bool {
+	return p.MaxAgeDays > 0 && createdAt.Before(now.AddDate(0, 0, -p.MaxAgeDays))
+}
+
+// Config is the retention policy for the revision history of packages and for the audit log. It is stored in a
+// ConfigMap in the glasskube-system namespace and applied periodically by the Pruner of the operator.
+type Config struct {
+	// Revisions applies to the revision history in the status of every package. The most recent revision is always
+	// kept, because it is the one that is currently installed. The revision history limit of the operator still
+	// applies, so MaxCount can only make the history shorter.
+	Revisions Policy
+	AuditLog  Policy
+}
+
+func (c *Config) Validate() error {
+	if err := c.Revisions.Validate(); err != nil {
+		return fmt.Errorf("invalid revision retention: %w", err)
+	} else if err := c.AuditLog.Validate(); err != nil {
+		return fmt.Errorf("invalid audit log retention: %w", err)
+	}
+	return nil
+}
+
+// ConfigFromConfigMap reads the configuration from the given ConfigMap. Invalid values are treated as no limit.
+func ConfigFromConfigMap(cm *corev1.ConfigMap) *Config {
+	parse := func(key string) int {
+		if value, err := strconv.Atoi(strings.TrimSpace(cm.Data[key])); err == nil && value > 0 {
+			return value
+		}
+		return 0
+	}
+	return &Config{
+		Revisions: Policy{MaxCount: parse(keyRevisionsMaxCount), MaxAgeDays: parse(keyRevisionsMaxAgeDays)},
+		AuditLog:  Policy{MaxCount: parse(keyAuditMaxCount), MaxAgeDays: parse(keyAuditMaxAgeDays)},
+	}
+}
+
+// ConfigMap returns the ConfigMap that stores this configuration
+func (c *Config) ConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: ConfigMapName, Namespace: Namespace},
+		Data: map[string]string{
+			keyRevisionsMaxCount:   strconv.Itoa(c.Revisions.MaxCount),
+			keyRevisionsMaxAgeDays: strconv.Itoa(c.Revisions.MaxAgeDays),
+			keyAuditMaxCount:       strconv.Itoa(c.AuditLog.MaxCount),
+			keyAuditMaxAgeDays:     strconv.Itoa(c.AuditLog.MaxAgeDays),
+		},
+	}
+}
+
+// LoadConfig loads the retention configuration from the cluster. If no configuration exists, an empty configuration
+// without any limits is returned.
+func LoadConfig(ctx context.Context, client adapter.KubernetesClientAdapter) (*Config, error) {
+	cm, err := client.GetConfigMap(ctx, ConfigMapName, Namespace)
+	if apierrors.IsNotFound(err) {
+		return &Config{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return ConfigFromConfigMap(cm), nil
+}
+
+// PruneRevisions removes all revisions from status that exceed the policy, except for the most recent one. The return
+// value indicates whether status was changed.
+func PruneRevisions(status *v1alpha1.PackageStatus, policy Policy, now time.Time) bool {
+	if len(status.Revisions) <= 1 {
+		return false
+	}
+	// revisions are ordered from oldest to newest
+	keep := status.Revisions
+	if policy.MaxCount > 0 && len(keep) > policy.MaxCount {
+		keep = keep[len(keep)-policy.MaxCount:]
+	}
+	current := keep[len(keep)-1]
+	keep = slices.DeleteFunc(slices.Clone(keep[:len(keep)-1]), func(revision v1alpha1.PackageRevision) bool {
+		return policy.isExpired(revision.InstalledAt.Time, now)
+	})
+	keep = append(keep, current)
+	if len(keep) == len(status.Revisions) {
+		return false
+	}
+	status.Revisions = keep
+	return true
+}
+
+// Record is a record that is subject to a retention policy, e.g. an audit log entry
+type Record struct {
+	Name      string
+	CreatedAt time.Time
+}
+
+// Expired returns the names of all records that exceed the policy
+func Expired(records []Record, policy Policy, now time.Time) []string {
+	sorted := slices.Clone(records)
+	slices.SortStableFunc(sorted, func(a, b Record) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	var result []string
+	for i, record := range sorted {
+		if (policy.MaxCount > 0 && i >= policy.MaxCount) || policy.isExpired(record.CreatedAt, now) {
+			result = append(result, record.Name)
+		}
+	}
+	return result
+}
@@ -0,0 +1,120 @@
+package retention
+
+import (
+	"context"
+	"time"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/audit"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var now = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func revisionsInstalledDaysAgo(days ...int) []v1alpha1.PackageRevision {
+	result := make([]v1alpha1.PackageRevision, len(days))
+	for i, d := range days {
+		result[i] = v1alpha1.PackageRevision{Revision: int64(i + 1), InstalledAt: metav1.NewTime(now.AddDate(0, 0, -d))}
+	}
+	return result
+}
+
+func revisionNumbers(status v1alpha1.PackageStatus) []int64 {
+	var result []int64
+	for _, revision := range status.Revisions {
+		result = append(result, revision.Revision)
+	}
+	return result
+}
+
+func auditEntry(name string, timestamp time.Time) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: audit.Namespace,
+			Labels:    map[string]string{audit.LabelAuditLog: "true"},
+		},
+		Data: map[string]string{"entry": `{"timestamp":"` + timestamp.Format(time.RFC3339) + `"}`},
+	}
+}
+
+var _ = Describe("Retention", func() {
+	It("should survive a round trip through a ConfigMap", func() {
+		config := Config{Revisions: Policy{MaxCount: 3}, AuditLog: Policy{MaxCount: 100, MaxAgeDays: 90}}
+		Expect(*ConfigFromConfigMap(config.ConfigMap())).To(Equal(config))
+	})
+
+	It("should treat invalid values as no limit", func() {
+		config := ConfigFromConfigMap(&corev1.ConfigMap{Data: map[string]string{
+			keyRevisionsMaxCount: "-1",
+			keyAuditMaxAgeDays:   "forever",
+		}})
+		Expect(config.Revisions.IsZero()).To(BeTrue())
+		Expect(config.AuditLog.IsZero()).To(BeTrue())
+	})
+
+	DescribeTable("PruneRevisions",
+		func(policy Policy, days []int, changed bool, expected []int64) {
+			status := v1alpha1.PackageStatus{Revisions: revisionsInstalledDaysAgo(days...)}
+			Expect(PruneRevisions(&status, policy, now)).To(Equal(changed))
+			Expect(revisionNumbers(status)).To(Equal(expected))
+		},
+		Entry("no limit", Policy{}, []int{30, 20, 10}, false, []int64{1, 2, 3}),
+		Entry("by count", Policy{MaxCount: 2}, []int{30, 20, 10}, true, []int64{2, 3}),
+		Entry("by age", Policy{MaxAgeDays: 15}, []int{30, 20, 10}, true, []int64{3}),
+		Entry("keeps the current revision", Policy{MaxAgeDays: 5}, []int{30, 20, 10}, true, []int64{3}),
+		Entry("by count and age", Policy{MaxCount: 2, MaxAgeDays: 25}, []int{30, 20, 10}, true, []int64{2, 3}),
+	)
+
+	DescribeTable("Expired",
+		func(policy Policy, expected []string) {
+			records := []Record{
+				{Name: "b", CreatedAt: now.AddDate(0, 0, -20)},
+				{Name: "a", CreatedAt: now.AddDate(0, 0, -30)},
+				{Name: "c", CreatedAt: now.AddDate(0, 0, -10)},
+			}
+			Expect(Expired(records, policy, now)).To(Equal(expected))
+		},
+		Entry("no limit", Policy{}, nil),
+		Entry("by count", Policy{MaxCount: 1}, []string{"b", "a"}),
+		Entry("by age", Policy{MaxAgeDays: 25}, []string{"a"}),
+	)
+
+	Describe("Pruner", func() {
+		It("should prune revisions and audit log entries", func(ctx context.Context) {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+			config := Config{Revisions: Policy{MaxCount: 1}, AuditLog: Policy{MaxAgeDays: 7}}
+			pkg := &v1alpha1.ClusterPackage{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Status:     v1alpha1.PackageStatus{Revisions: revisionsInstalledDaysAgo(3, 2, 1)},
+			}
+			pruner := Pruner{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(pkg).WithObjects(
+					config.ConfigMap(),
+					pkg,
+					auditEntry("glasskube-audit-old", now.AddDate(0, 0, -8)),
+					auditEntry("glasskube-audit-new", now.AddDate(0, 0, -6)),
+				).Build(),
+				now: func() time.Time { return now },
+			}
+			Expect(pruner.Prune(ctx)).To(Succeed())
+
+			var updated v1alpha1.ClusterPackage
+			Expect(pruner.Get(ctx, client.ObjectKeyFromObject(pkg), &updated)).To(Succeed())
+			Expect(revisionNumbers(updated.Status)).To(Equal([]int64{3}))
+			var entries corev1.ConfigMapList
+			Expect(pruner.List(ctx, &entries, client.HasLabels{audit.LabelAuditLog})).To(Succeed())
+			Expect(entries.Items).To(HaveLen(1))
+			Expect(entries.Items[0].Name).To(Equal("glasskube-audit-new"))
+		})
+	})
+})
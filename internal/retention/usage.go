@@ -0,0 +1,38 @@
+package retention
+
+import (
+	"encoding/json"
+
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Usage is an estimate of the storage used by the revision history and the audit log. Sizes are the size of the
+// serialized records, the actual size in etcd is slightly larger.
+type Usage struct {
+	Revisions     int
+	RevisionBytes int
+	AuditEntries  int
+	AuditLogBytes int
+}
+
+// EstimateUsage calculates the storage used by the revision history of the given packages and by the given audit log
+// ConfigMaps
+func EstimateUsage(pkgs []ctrlpkg.Package, auditConfigMaps []corev1.ConfigMap) Usage {
+	var usage Usage
+	for _, pkg := range pkgs {
+		for _, revision := range pkg.GetStatus().Revisions {
+			usage.Revisions++
+			if data, err := json.Marshal(revision); err == nil {
+				usage.RevisionBytes += len(data)
+			}
+		}
+	}
+	for _, cm := range auditConfigMaps {
+		usage.AuditEntries++
+		for key, value := range cm.Data {
+			usage.AuditLogBytes += len(key) + len(value)
+		}
+	}
+	return usage
+}
@@ -0,0 +1,74 @@
+package sandbox
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DefaultCleanupInterval is the time between two searches for expired sandboxes
+const DefaultCleanupInterval = time.Minute
+
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=delete
+
+// Cleaner deletes the namespaces of expired sandboxes. It is added to the manager of the operator.
+type Cleaner struct {
+	client.Client
+	// Interval is the time between two cleanups. If it is zero, DefaultCleanupInterval is used.
+	Interval time.Duration
+	now      func() time.Time
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, so that only one operator deletes sandboxes
+func (c *Cleaner) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implements manager.Runnable
+func (c *Cleaner) Start(ctx context.Context) error {
+	interval := c.Interval
+	if interval == 0 {
+		interval = DefaultCleanupInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := c.Cleanup(ctx); err != nil {
+			log.FromContext(ctx).Error(err, "failed to clean up expired sandboxes")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Cleanup deletes the namespaces of all sandboxes that have expired. Deleting the namespace also deletes the packages
+// in it, which are then uninstalled by the operator.
+func (c *Cleaner) Cleanup(ctx context.Context) error {
+	now := time.Now
+	if c.now != nil {
+		now = c.now
+	}
+	var list corev1.NamespaceList
+	if err := c.List(ctx, &list, client.HasLabels{SandboxLabel}); err != nil {
+		return err
+	}
+	for i := range list.Items {
+		ns := &list.Items[i]
+		if ns.DeletionTimestamp != nil || !IsExpired(ns, now()) {
+			continue
+		}
+		log.FromContext(ctx).Info("deleting expired sandbox", "namespace", ns.Name)
+		err := c.Delete(ctx, ns, client.PropagationPolicy("Background"), client.Preconditions{UID: &ns.UID})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+// Package sandbox installs packages into temporary namespaces, so that they can be tried before they are installed
+// for real. A sandbox is deleted by the operator once it has expired, unless its package has been promoted to a
+// regular installation before.
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/pkg/client"
+	"github.com/glasskube/glasskube/pkg/install"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// SandboxLabel marks namespaces and packages that belong to a sandbox
+	SandboxLabel = "packages.glasskube.dev/sandbox"
+	// ExpiresAtAnnotation contains the time after which the sandbox is deleted, in RFC 3339 format
+	ExpiresAtAnnotation = "packages.glasskube.dev/sandbox-expires-at"
+
+	// DefaultTTL is the lifetime of a sandbox if no other is given
+	DefaultTTL = time.Hour
+	// MaxTTL is the longest allowed lifetime of a sandbox
+	MaxTTL = 7 * 24 * time.Hour
+)
+
+var ErrNotSandbox = errors.New("not a sandbox")
+
+// ValidateTTL returns an error if ttl is not a valid lifetime for a sandbox
+func ValidateTTL(ttl time.Duration) error {
+	if ttl <= 0 || ttl > MaxTTL {
+		return fmt.Errorf("the lifetime of a sandbox must be positive and at most %v (got %v)", MaxTTL, ttl)
+	}
+	return nil
+}
+
+// Mark labels obj as part of a sandbox that expires at the given time
+func Mark(obj metav1.Object, expiresAt time.Time) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[SandboxLabel] = "true"
+	obj.SetLabels(labels)
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[ExpiresAtAnnotation] = expiresAt.UTC().Format(time.RFC3339)
+	obj.SetAnnotations(annotations)
+}
+
+// Unmark removes the labels and annotations added by Mark
+func Unmark(obj metav1.Object) {
+	labels := obj.GetLabels()
+	delete(labels, SandboxLabel)
+	obj.SetLabels(labels)
+	annotations := obj.GetAnnotations()
+	delete(annotations, ExpiresAtAnnotation)
+	obj.SetAnnotations(annotations)
+}
+
+// IsSandbox returns true if obj has been marked as part of a sandbox
+func IsSandbox(obj metav1.Object) bool {
+	return obj.GetLabels()[SandboxLabel] == "true"
+}
+
+// ExpiresAt returns the time at which the sandbox of obj expires. If obj is not part of a sandbox or the annotation is
+// invalid, false is returned.
+func ExpiresAt(obj metav1.Object) (time.Time, bool) {
+	if !IsSandbox(obj) {
+		return time.Time{}, false
+	}
+	expiresAt, err := time.Parse(time.RFC3339, obj.GetAnnotations()[ExpiresAtAnnotation])
+	return expiresAt, err == nil
+}
+
+// IsExpired returns true if obj is part of a sandbox that has expired at the given time. Sandboxes without a valid
+// expiry are treated as expired, so that they are not kept forever.
+func IsExpired(obj metav1.Object, now time.Time) bool {
+	if !IsSandbox(obj) {
+		return false
+	}
+	expiresAt, ok := ExpiresAt(obj)
+	return !ok || !now.Before(expiresAt)
+}
+
+// Create installs pkg into a new sandbox namespace that expires after ttl. The namespace of pkg is replaced with the
+// generated name of the sandbox namespace.
+func Create(
+	ctx context.Context,
+	k8sClient kubernetes.Interface,
+	pkgClient client.PackageV1Alpha1Client,
+	pkg *v1alpha1.Package,
+	ttl time.Duration,
+) error {
+	if err := ValidateTTL(ttl); err != nil {
+		return err
+	}
+	expiresAt := time.Now().Add(ttl)
+	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{GenerateName: fmt.Sprintf("try-%v-", pkg.Spec.PackageInfo.Name)}}
+	Mark(&ns, expiresAt)
+	created, err := k8sClient.CoreV1().Namespaces().Create(ctx, &ns, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create sandbox namespace: %w", err)
+	}
+	pkg.SetNamespace(created.Name)
+	Mark(pkg, expiresAt)
+	if err := install.NewInstaller(pkgClient).Install(ctx, pkg, metav1.CreateOptions{}); err != nil {
+		return errors.Join(err, Delete(ctx, k8sClient, created.Name))
+	}
+	return nil
+}
+
+// Promote installs a copy of the package in the given sandbox namespace as a regular package with the given
+// namespace and name, and deletes the sandbox afterwards. The target namespace must exist.
+func Promote(
+	ctx context.Context,
+	k8sClient kubernetes.Interface,
+	pkgClient client.PackageV1Alpha1Client,
+	sandbox *v1alpha1.Package,
+	namespace, name string,
+) (*v1alpha1.Package, error) {
+	if !IsSandbox(sandbox) {
+		return nil, fmt.Errorf("%v/%v: %w", sandbox.Namespace, sandbox.Name, ErrNotSandbox)
+	}
+	if namespace == sandbox.Namespace {
+		return nil, errors.New("a package can not be promoted into its sandbox namespace")
+	}
+	if _, err := k8sClient.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to get namespace %v: %w", namespace, err)
+	}
+	pkg := v1alpha1.Package{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        name,
+			Labels:      maps.Clone(sandbox.GetLabels()),
+			Annotations: maps.Clone(sandbox.GetAnnotations()),
+		},
+		Spec: *sandbox.Spec.DeepCopy(),
+	}
+	Unmark(&pkg)
+	if err := install.NewInstaller(pkgClient).Install(ctx, &pkg, metav1.CreateOptions{}); err != nil {
+		return nil, err
+	}
+	return &pkg, Delete(ctx, k8sClient, sandbox.Namespace)
+}
+
+// Delete deletes the sandbox namespace with the given name, including all packages installed in it. Namespaces that
+// are not sandboxes are never deleted.
+func Delete(ctx context.Context, k8sClient kubernetes.Interface, namespace string) error {
+	ns, err := k8sClient.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	} else if !IsSandbox(ns) {
+		return fmt.Errorf("namespace %v: %w", namespace, ErrNotSandbox)
+	}
+	propagation := metav1.DeletePropagationBackground
+	err = k8sClient.CoreV1().Namespaces().Delete(ctx, namespace, metav1.DeleteOptions{
+		PropagationPolicy: &propagation,
+		Preconditions:     &metav1.Preconditions{UID: &ns.UID},
+	})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,95 @@
+package sandbox
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var now = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func sandboxNamespace(name string, expiresAt time.Time) *corev1.Namespace {
+	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	Mark(&ns, expiresAt)
+	return &ns
+}
+
+var _ = Describe("Sandbox", func() {
+	Describe("IsExpired", func() {
+		It("should not expire regular namespaces", func() {
+			Expect(IsExpired(&corev1.Namespace{}, now)).To(BeFalse())
+		})
+		It("should expire sandboxes after their expiry", func() {
+			ns := sandboxNamespace("try-foo-abc", now.Add(time.Minute))
+			Expect(IsExpired(ns, now)).To(BeFalse())
+			Expect(IsExpired(ns, now.Add(time.Minute))).To(BeTrue())
+		})
+		It("should expire sandboxes with an invalid expiry", func() {
+			ns := sandboxNamespace("try-foo-abc", now)
+			ns.Annotations[ExpiresAtAnnotation] = "tomorrow"
+			Expect(IsExpired(ns, now)).To(BeTrue())
+		})
+	})
+
+	Describe("Unmark", func() {
+		It("should remove the sandbox label and annotation only", func() {
+			ns := sandboxNamespace("try-foo-abc", now)
+			ns.Labels["foo"] = "bar"
+			Unmark(ns)
+			Expect(IsSandbox(ns)).To(BeFalse())
+			Expect(ns.Labels).To(Equal(map[string]string{"foo": "bar"}))
+			Expect(ns.Annotations).To(BeEmpty())
+		})
+	})
+
+	Describe("ValidateTTL", func() {
+		It("should reject lifetimes out of range", func() {
+			Expect(ValidateTTL(DefaultTTL)).To(Succeed())
+			Expect(ValidateTTL(0)).NotTo(Succeed())
+			Expect(ValidateTTL(MaxTTL + time.Second)).NotTo(Succeed())
+		})
+	})
+
+	Describe("Delete", func() {
+		It("should delete sandbox namespaces only", func(ctx context.Context) {
+			k8sClient := k8sfake.NewClientset(sandboxNamespace("try-foo-abc", now),
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}})
+			Expect(Delete(ctx, k8sClient, "try-foo-abc")).To(Succeed())
+			Expect(Delete(ctx, k8sClient, "default")).To(MatchError(ErrNotSandbox))
+			Expect(Delete(ctx, k8sClient, "does-not-exist")).To(Succeed())
+			list, err := k8sClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.Items).To(HaveLen(1))
+			Expect(list.Items[0].Name).To(Equal("default"))
+		})
+	})
+
+	Describe("Cleaner", func() {
+		It("should delete expired sandboxes only", func(ctx context.Context) {
+			cleaner := Cleaner{
+				Client: fake.NewClientBuilder().WithObjects(
+					sandboxNamespace("try-foo-expired", now.Add(-time.Minute)),
+					sandboxNamespace("try-foo-active", now.Add(time.Minute)),
+					&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+				).Build(),
+				now: func() time.Time { return now },
+			}
+			Expect(cleaner.Cleanup(ctx)).To(Succeed())
+			var list corev1.NamespaceList
+			Expect(cleaner.List(ctx, &list)).To(Succeed())
+			var names []string
+			for _, ns := range list.Items {
+				names = append(names, ns.Name)
+			}
+			Expect(names).To(ConsistOf("try-foo-active", "default"))
+			Expect(cleaner.Get(ctx, client.ObjectKey{Name: "try-foo-expired"}, &corev1.Namespace{})).NotTo(Succeed())
+		})
+	})
+})
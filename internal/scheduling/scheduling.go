@@ -0,0 +1,140 @@
+// Package scheduling merges node selectors, tolerations and affinity into the pod templates of all workloads of a
+// package, e.g. to run them on GPU nodes.
+package scheduling
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+// Workload is a kind of resource that contains a pod spec
+type Workload struct {
+	APIVersion string
+	// PodSpecPath is the path of the pod spec in the resource
+	PodSpecPath []string
+}
+
+// Workloads contains all kinds of resources that overrides are applied to
+var Workloads = map[string]Workload{
+	"Pod":         {APIVersion: "v1", PodSpecPath: []string{"spec"}},
+	"Deployment":  {APIVersion: "apps/v1", PodSpecPath: []string{"spec", "template", "spec"}},
+	"StatefulSet": {APIVersion: "apps/v1", PodSpecPath: []string{"spec", "template", "spec"}},
+	"DaemonSet":   {APIVersion: "apps/v1", PodSpecPath: []string{"spec", "template", "spec"}},
+	"ReplicaSet":  {APIVersion: "apps/v1", PodSpecPath: []string{"spec", "template", "spec"}},
+	"Job":         {APIVersion: "batch/v1", PodSpecPath: []string{"spec", "template", "spec"}},
+	"CronJob":     {APIVersion: "batch/v1", PodSpecPath: []string{"spec", "jobTemplate", "spec", "template", "spec"}},
+}
+
+// Parse reads overrides from YAML with the optional keys nodeSelector, tolerations and affinity, which have the same
+// format as in a pod spec. Unknown keys are rejected. Parse returns nil if text is empty.
+func Parse(text string) (*v1alpha1.SchedulingOverrides, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	var overrides v1alpha1.SchedulingOverrides
+	if err := yaml.UnmarshalStrict([]byte(text), &overrides); err != nil {
+		return nil, fmt.Errorf("invalid scheduling overrides: %w", err)
+	} else if err := Validate(&overrides); err != nil {
+		return nil, fmt.Errorf("invalid scheduling overrides: %w", err)
+	} else if IsEmpty(&overrides) {
+		return nil, nil
+	}
+	return &overrides, nil
+}
+
+// String returns the overrides in the format that is accepted by Parse
+func String(overrides *v1alpha1.SchedulingOverrides) string {
+	if IsEmpty(overrides) {
+		return ""
+	}
+	if data, err := yaml.Marshal(overrides); err != nil {
+		return ""
+	} else {
+		return string(data)
+	}
+}
+
+func IsEmpty(overrides *v1alpha1.SchedulingOverrides) bool {
+	return overrides == nil ||
+		(len(overrides.NodeSelector) == 0 && len(overrides.Tolerations) == 0 && overrides.Affinity == nil)
+}
+
+// Validate checks the labels of the node selector and the operators and effects of the tolerations. The affinity is
+// validated by the API server when the workloads are applied.
+func Validate(overrides *v1alpha1.SchedulingOverrides) error {
+	var errs error
+	for key, value := range overrides.NodeSelector {
+		for _, msg := range validation.IsQualifiedName(key) {
+			errs = errors.Join(errs, fmt.Errorf("node selector key %q: %v", key, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(value) {
+			errs = errors.Join(errs, fmt.Errorf("node selector value %q: %v", value, msg))
+		}
+	}
+	for i, toleration := range overrides.Tolerations {
+		switch toleration.Operator {
+		case corev1.TolerationOpEqual, "":
+			if toleration.Key == "" {
+				errs = errors.Join(errs, fmt.Errorf("toleration %v: operator must be Exists if key is empty", i))
+			}
+		case corev1.TolerationOpExists:
+			if toleration.Value != "" {
+				errs = errors.Join(errs, fmt.Errorf("toleration %v: value must be empty if operator is Exists", i))
+			}
+		default:
+			errs = errors.Join(errs, fmt.Errorf("toleration %v: unsupported operator %q", i, toleration.Operator))
+		}
+		switch toleration.Effect {
+		case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		default:
+			errs = errors.Join(errs, fmt.Errorf("toleration %v: unsupported effect %q", i, toleration.Effect))
+		}
+	}
+	return errs
+}
+
+// PodSpecPatch returns the strategic merge patch of a pod spec that applies the overrides
+func PodSpecPatch(overrides *v1alpha1.SchedulingOverrides) (map[string]any, error) {
+	var patch map[string]any
+	if data, err := json.Marshal(overrides); err != nil {
+		return nil, err
+	} else if err := json.Unmarshal(data, &patch); err != nil {
+		return nil, err
+	}
+	return patch, nil
+}
+
+// ApplyToObject merges the overrides into the pod spec of the given object, if it is a workload. It returns true if
+// the object was changed.
+func ApplyToObject(obj *unstructured.Unstructured, overrides *v1alpha1.SchedulingOverrides) (bool, error) {
+	workload, ok := Workloads[obj.GetKind()]
+	if !ok || IsEmpty(overrides) {
+		return false, nil
+	}
+	path := workload.PodSpecPath
+	podSpec, found, err := unstructured.NestedMap(obj.Object, path...)
+	if err != nil || !found {
+		return false, err
+	}
+	patch, err := PodSpecPatch(overrides)
+	if err != nil {
+		return false, err
+	}
+	patched, err := strategicpatch.StrategicMergeMapPatch(podSpec, patch, corev1.PodSpec{})
+	if err != nil {
+		return false, fmt.Errorf("could not apply scheduling overrides to %v %v: %w", obj.GetKind(), obj.GetName(), err)
+	}
+	if err := unstructured.SetNestedMap(obj.Object, patched, path...); err != nil {
+		return false, err
+	}
+	return true, nil
+}
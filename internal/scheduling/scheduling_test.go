@@ -0,0 +1,95 @@
+package scheduling
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("scheduling", func() {
+	const overridesYaml = `
+nodeSelector:
+  nvidia.com/gpu.present: "true"
+tolerations:
+  - key: nvidia.com/gpu
+    operator: Exists
+    effect: NoSchedule
+`
+
+	Describe("Parse", func() {
+		It("should parse overrides", func() {
+			overrides, err := Parse(overridesYaml)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(overrides.NodeSelector).To(HaveKeyWithValue("nvidia.com/gpu.present", "true"))
+			Expect(overrides.Tolerations).To(HaveLen(1))
+			Expect(overrides.Affinity).To(BeNil())
+		})
+		It("should return nil for empty text", func() {
+			Expect(Parse("  \n")).To(BeNil())
+		})
+		DescribeTable("should reject invalid overrides",
+			func(text string) {
+				_, err := Parse(text)
+				Expect(err).To(HaveOccurred())
+			},
+			Entry("unknown key", "nodeSelectors: {}"),
+			Entry("invalid syntax", "nodeSelector: ["),
+			Entry("invalid label", "nodeSelector: {\"invalid key!\": a}"),
+			Entry("invalid operator", "tolerations: [{key: a, operator: In}]"),
+			Entry("value with Exists", "tolerations: [{key: a, operator: Exists, value: b}]"),
+			Entry("invalid effect", "tolerations: [{key: a, effect: Never}]"),
+		)
+		It("should be the inverse of String", func() {
+			overrides, err := Parse(overridesYaml)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(Parse(String(overrides))).To(Equal(overrides))
+		})
+	})
+
+	Describe("ApplyToObject", func() {
+		It("should merge the overrides into the pod template", func() {
+			obj := &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata":   map[string]any{"name": "test"},
+				"spec": map[string]any{"template": map[string]any{"spec": map[string]any{
+					"nodeSelector": map[string]any{"kubernetes.io/os": "linux"},
+					"tolerations":  []any{map[string]any{"key": "other", "operator": "Exists"}},
+					"containers":   []any{map[string]any{"name": "test", "image": "nginx"}},
+				}}},
+			}}
+			overrides, err := Parse(overridesYaml)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ApplyToObject(obj, overrides)).To(BeTrue())
+			podSpec, _, _ := unstructured.NestedMap(obj.Object, "spec", "template", "spec")
+			Expect(podSpec["nodeSelector"]).To(Equal(map[string]any{
+				"kubernetes.io/os":       "linux",
+				"nvidia.com/gpu.present": "true",
+			}))
+			Expect(podSpec["tolerations"]).To(Equal([]any{
+				map[string]any{"key": "nvidia.com/gpu", "operator": "Exists", "effect": "NoSchedule"},
+			}))
+			Expect(podSpec["containers"]).To(HaveLen(1))
+		})
+		It("should apply overrides to cron jobs", func() {
+			obj := &unstructured.Unstructured{Object: map[string]any{
+				"kind": "CronJob",
+				"spec": map[string]any{"jobTemplate": map[string]any{"spec": map[string]any{
+					"template": map[string]any{"spec": map[string]any{}},
+				}}},
+			}}
+			overrides, err := Parse(overridesYaml)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ApplyToObject(obj, overrides)).To(BeTrue())
+			nodeSelector, _, _ := unstructured.NestedStringMap(obj.Object,
+				"spec", "jobTemplate", "spec", "template", "spec", "nodeSelector")
+			Expect(nodeSelector).To(HaveKeyWithValue("nvidia.com/gpu.present", "true"))
+		})
+		It("should ignore other resources", func() {
+			obj := &unstructured.Unstructured{Object: map[string]any{"kind": "ConfigMap"}}
+			overrides, err := Parse(overridesYaml)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ApplyToObject(obj, overrides)).To(BeFalse())
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package semver
 
 import (
+	"slices"
 	"strconv"
 
 	"github.com/Masterminds/semver/v3"
@@ -46,3 +47,42 @@ func isUpgradableMetadata(installed, desired *semver.Version) bool {
 		return installedMetaInt < desiredMetadataInt
 	}
 }
+
+// VersionsBetween returns the versions that are newer than installed, up to and including target, ordered from the
+// newest to the oldest. Versions that can not be parsed as semver are ignored. If installed or target can not be
+// parsed, only target is returned.
+func VersionsBetween(versions []string, installed, target string) []string {
+	parsedInstalled, err := semver.NewVersion(installed)
+	if err != nil {
+		return []string{target}
+	}
+	parsedTarget, err := semver.NewVersion(target)
+	if err != nil {
+		return []string{target}
+	}
+	type parsedVersion struct {
+		raw    string
+		parsed *semver.Version
+	}
+	var between []parsedVersion
+	for _, version := range versions {
+		if parsed, err := semver.NewVersion(version); err != nil {
+			continue
+		} else if IsVersionUpgradable(parsedInstalled, parsed) && !IsVersionUpgradable(parsedTarget, parsed) {
+			between = append(between, parsedVersion{version, parsed})
+		}
+	}
+	slices.SortStableFunc(between, func(a, b parsedVersion) int {
+		if IsVersionUpgradable(a.parsed, b.parsed) {
+			return 1
+		} else if IsVersionUpgradable(b.parsed, a.parsed) {
+			return -1
+		}
+		return 0
+	})
+	result := make([]string, len(between))
+	for i, v := range between {
+		result[i] = v.raw
+	}
+	return result
+}
@@ -70,3 +70,17 @@ var _ = Describe("IsUpgradable", func() {
 		})
 	}
 })
+
+var _ = Describe("VersionsBetween", func() {
+	versions := []string{"v1.0.0", "v1.3.0", "v1.1.0", "v1.2.0+1", "v1.2.0", "v2.0.0", "not a version"}
+
+	DescribeTable("Listing the versions of an update",
+		func(installed, target string, expected []string) {
+			Expect(VersionsBetween(versions, installed, target)).To(Equal(expected))
+		},
+		Entry("When versions are skipped", "v1.0.0", "v1.3.0", []string{"v1.3.0", "v1.2.0+1", "v1.2.0", "v1.1.0"}),
+		Entry("When updating to the next version", "v1.2.0", "v1.2.0+1", []string{"v1.2.0+1"}),
+		Entry("When target is not newer", "v1.3.0", "v1.3.0", []string{}),
+		Entry("When installed is not a version", "not a version", "v2.0.0", []string{"v2.0.0"}),
+	)
+})
@@ -0,0 +1,59 @@
+package semver
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// ParseConstraint checks whether the given string is a valid semver constraint (e.g. "~1.2.0")
+func ParseConstraint(constraint string) (*semver.Constraints, error) {
+	if parsed, err := semver.NewConstraint(constraint); err != nil {
+		return nil, fmt.Errorf("invalid version constraint %v: %w", constraint, err)
+	} else {
+		return parsed, nil
+	}
+}
+
+// IsUpgradableWithConstraint checks if desired is upgradable from installed (see IsUpgradable) and additionally
+// satisfies the given constraint. An empty constraint is always satisfied.
+func IsUpgradableWithConstraint(installed, desired, constraint string) bool {
+	if constraint == "" {
+		return IsUpgradable(installed, desired)
+	}
+	return IsUpgradable(installed, desired) && ValidateConstraint(desired, constraint) == nil
+}
+
+// LatestVersionWithConstraint returns the highest of the given versions that satisfies the given constraint.
+// Versions that can not be parsed as semver are ignored. If no version satisfies the constraint, an empty string is
+// returned.
+func LatestVersionWithConstraint(versions []string, constraint string) (string, error) {
+	parsedConstraint, err := ParseConstraint(constraint)
+	if err != nil {
+		return "", err
+	}
+	var latest *semver.Version
+	var latestStr string
+	for _, version := range versions {
+		if parsed, err := semver.NewVersion(version); err != nil {
+			continue
+		} else if ValidateVersionConstraint(parsed, parsedConstraint) != nil {
+			continue
+		} else if latest == nil || IsVersionUpgradable(latest, parsed) {
+			latest = parsed
+			latestStr = version
+		}
+	}
+	return latestStr, nil
+}
+
+// ConstraintSuggestions returns commonly used constraints for the given version, e.g. "~1.2.0" and "^1.2.0" for
+// version "v1.2.0+1". If version can not be parsed, no suggestions are returned.
+func ConstraintSuggestions(version string) []string {
+	if parsed, err := semver.NewVersion(version); err != nil {
+		return nil
+	} else {
+		base := fmt.Sprintf("%d.%d.%d", parsed.Major(), parsed.Minor(), parsed.Patch())
+		return []string{"~" + base, "^" + base, ">=" + base}
+	}
+}
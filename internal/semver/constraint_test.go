@@ -0,0 +1,38 @@
+package semver
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LatestVersionWithConstraint", func() {
+	versions := []string{"v1.1.0", "v1.2.0", "v1.2.1", "v1.2.1+1", "v1.3.0", "v2.0.0", "not a version"}
+
+	DescribeTable("Picking the highest version within a constraint",
+		func(constraint string, expected string) {
+			Expect(LatestVersionWithConstraint(versions, constraint)).To(Equal(expected))
+		},
+		Entry("When minor version is pinned with ~", "~1.2.0", "v1.2.1+1"),
+		Entry("When major version is pinned with ^", "^1.1.0", "v1.3.0"),
+		Entry("When only a lower bound is given", ">=1.0.0", "v2.0.0"),
+		Entry("When an exact version is given", "=1.2.0", "v1.2.0"),
+		Entry("When no version satisfies the constraint", "~3.0.0", ""),
+	)
+
+	It("should return an error for an invalid constraint", func() {
+		_, err := LatestVersionWithConstraint(versions, "not a constraint")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("IsUpgradableWithConstraint", func() {
+	DescribeTable("Checking upgradability with a constraint",
+		func(installed, desired, constraint string, expected bool) {
+			Expect(IsUpgradableWithConstraint(installed, desired, constraint)).To(Equal(expected))
+		},
+		Entry("When no constraint is given", "v1.2.0", "v2.0.0", "", true),
+		Entry("When desired satisfies the constraint", "v1.2.0", "v1.2.1", "~1.2.0", true),
+		Entry("When desired does not satisfy the constraint", "v1.2.0", "v1.3.0", "~1.2.0", false),
+		Entry("When desired is not newer", "v1.2.1", "v1.2.0", "~1.2.0", false),
+	)
+})
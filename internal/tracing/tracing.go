@@ -0,0 +1,105 @@
+// Package tracing provides OpenTelemetry tracing for package operations. Spans are only exported if an OTLP endpoint
+// is configured with the standard OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT environment
+// variables. Otherwise, all spans are discarded.
+package tracing
+
+import (
+	"context"
+	"os"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const tracerName = "github.com/glasskube/glasskube"
+
+var propagator = propagation.TraceContext{}
+
+// IsConfigured returns whether an OTLP endpoint for traces is configured
+func IsConfigured() bool {
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// Setup installs a global tracer provider that exports spans via OTLP, if an endpoint is configured. The returned
+// function flushes all pending spans and must be called before the process exits.
+func Setup(ctx context.Context, serviceName string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagator)
+	if !IsConfigured() {
+		return func(context.Context) error { return nil }, nil
+	}
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(config.Version),
+	))
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Start creates a span as child of the span in ctx, if there is one
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End ends the span and marks it as failed, if err is not nil
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// PackageAttributes returns the attributes that identify the given package in a span
+func PackageAttributes(obj metav1.Object, packageName, version string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.String("glasskube.package.name", packageName),
+		attribute.String("glasskube.package.version", version),
+		attribute.String("glasskube.package.instance", obj.GetName()),
+	}
+	if ns := obj.GetNamespace(); ns != "" {
+		attrs = append(attrs, attribute.String("glasskube.package.namespace", ns))
+	}
+	return attrs
+}
+
+// Inject stores the span context of ctx in an annotation of obj, so that the controller that reconciles obj can
+// continue the trace. Nothing is stored if ctx does not contain a sampled span.
+func Inject(ctx context.Context, obj metav1.Object) {
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	if traceParent := carrier.Get("traceparent"); traceParent != "" {
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[v1alpha1.AnnotationTraceParent] = traceParent
+		obj.SetAnnotations(annotations)
+	}
+}
+
+// Extract returns a context that contains the span context stored in the annotation of obj by Inject, or ctx itself
+// if there is none
+func Extract(ctx context.Context, obj metav1.Object) context.Context {
+	if traceParent, ok := obj.GetAnnotations()[v1alpha1.AnnotationTraceParent]; ok {
+		return propagator.Extract(ctx, propagation.MapCarrier{"traceparent": traceParent})
+	}
+	return ctx
+}
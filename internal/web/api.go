@@ -0,0 +1,145 @@
+package web
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	repotypes "github.com/glasskube/glasskube/internal/repo/types"
+	"github.com/glasskube/glasskube/internal/web/handler"
+	"github.com/glasskube/glasskube/pkg/client"
+	"k8s.io/client-go/tools/cache"
+)
+
+type apiPackage struct {
+	Name             string   `json:"name"`
+	Namespace        string   `json:"namespace,omitempty"`
+	PackageName      string   `json:"packageName"`
+	Repositories     []string `json:"repositories,omitempty"`
+	Installed        bool     `json:"installed"`
+	InstalledVersion string   `json:"installedVersion,omitempty"`
+	LatestVersion    string   `json:"latestVersion,omitempty"`
+	Upgradable       bool     `json:"upgradable"`
+	Status           string   `json:"status,omitempty"`
+	Suspended        bool     `json:"suspended"`
+	Paused           bool     `json:"paused"`
+	AutoUpdate       bool     `json:"autoUpdate"`
+}
+
+type apiPackageList struct {
+	Items            []apiPackage `json:"items"`
+	UpdatesAvailable bool         `json:"updatesAvailable"`
+}
+
+func (s *server) apiClusterPackages(w http.ResponseWriter, r *http.Request) error {
+	overview, err := s.getClusterPackagesOverview(r.Context())
+	if err != nil && len(overview.clusterPackages) == 0 {
+		return err
+	}
+
+	result := apiPackageList{Items: []apiPackage{}, UpdatesAvailable: overview.updatesAvailable}
+	for _, item := range overview.clusterPackages {
+		apiPkg := newApiPackage(item.PackageRepoIndexItem, item.Repos)
+		if item.ClusterPackage != nil {
+			setInstalledApiPackage(&apiPkg, item.ClusterPackage, item.Status)
+			apiPkg.Upgradable = overview.updateAvailable[item.Name]
+		}
+		result.Items = append(result.Items, apiPkg)
+	}
+	writeJSON(w, r, http.StatusOK, result)
+	return nil
+}
+
+func (s *server) apiPackages(w http.ResponseWriter, r *http.Request) error {
+	overview, err := s.getPackagesOverview(r.Context())
+	if err != nil && len(overview.installed) == 0 && len(overview.available) == 0 {
+		return err
+	}
+
+	result := apiPackageList{Items: []apiPackage{}, UpdatesAvailable: overview.updatesAvailable}
+	for _, item := range overview.installed {
+		for _, pkgWithStatus := range item.Packages {
+			apiPkg := newApiPackage(item.PackageRepoIndexItem, item.Repos)
+			setInstalledApiPackage(&apiPkg, pkgWithStatus.Package, pkgWithStatus.Status)
+			apiPkg.Upgradable = overview.updateAvailable[cache.MetaObjectToName(pkgWithStatus.Package).String()]
+			result.Items = append(result.Items, apiPkg)
+		}
+	}
+	for _, item := range overview.available {
+		result.Items = append(result.Items, newApiPackage(*item, overview.repos[item.Name]))
+	}
+	writeJSON(w, r, http.StatusOK, result)
+	return nil
+}
+
+func newApiPackage(item repotypes.PackageRepoIndexItem, repos []string) apiPackage {
+	return apiPackage{
+		Name:          item.Name,
+		PackageName:   item.Name,
+		Repositories:  repos,
+		LatestVersion: item.LatestVersion,
+	}
+}
+
+func setInstalledApiPackage(apiPkg *apiPackage, pkg ctrlpkg.Package, status *client.PackageStatus) {
+	apiPkg.Installed = true
+	apiPkg.Name = pkg.GetName()
+	apiPkg.Namespace = pkg.GetNamespace()
+	apiPkg.InstalledVersion = pkg.GetSpec().PackageInfo.Version
+	apiPkg.Suspended = pkg.GetSpec().Suspend
+	apiPkg.Paused = pkg.IsPaused()
+	apiPkg.AutoUpdate = pkg.AutoUpdatesEnabled()
+	if status != nil {
+		apiPkg.Status = status.Status
+	}
+}
+
+// writeJSON writes the given value as JSON. Successful responses get an ETag, which is derived from the content, so
+// clients that poll the API can use If-None-Match to avoid transferring the same data again.
+func writeJSON(w http.ResponseWriter, r *http.Request, status int, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if status == http.StatusOK {
+		hash := sha256.Sum256(data)
+		etag := `"` + hex.EncodeToString(hash[:16]) + `"`
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", "no-cache")
+		if etagMatches(r.Header.Get("If-None-Match"), etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+}
+
+// etagMatches checks whether the value of an If-None-Match header matches the given etag using weak comparison
+func etagMatches(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *server) requireReadyApi(h apiHandlerFunc) http.Handler {
+	return &handler.PreconditionHandler{
+		Precondition: func(r *http.Request) error {
+			if err := s.ensureBootstrapped(r.Context()); err != nil {
+				return err
+			}
+			return nil
+		},
+		Handler:       apiErrorMiddleware(h),
+		FailedHandler: writeApiError,
+	}
+}
@@ -0,0 +1,110 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/httperror"
+	"github.com/glasskube/glasskube/internal/workloads"
+	"github.com/glasskube/glasskube/pkg/condition"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/multierr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var _ = Describe("JSON API", func() {
+	DescribeTable("ETag matching",
+		func(ifNoneMatch string, etag string, result bool) {
+			Expect(etagMatches(ifNoneMatch, etag)).To(Equal(result))
+		},
+		Entry("No header", "", `"abc"`, false),
+		Entry("Same etag", `"abc"`, `"abc"`, true),
+		Entry("Different etag", `"abd"`, `"abc"`, false),
+		Entry("Weak etag", `W/"abc"`, `"abc"`, true),
+		Entry("List of etags", `"xyz", "abc"`, `"abc"`, true),
+		Entry("Wildcard", "*", `"abc"`, true),
+	)
+
+	DescribeTable("error classification",
+		func(err error, status int, code apiErrorCode) {
+			apiErr := toApiError(err)
+			Expect(apiErr.status).To(Equal(status))
+			Expect(apiErr.Code).To(Equal(code))
+			Expect(apiErr.Message).To(Equal(err.Error()))
+		},
+		Entry("Unknown error", errors.New("boom"), http.StatusInternalServerError, apiErrorInternal),
+		Entry("Package not found",
+			fmt.Errorf("wrapped: %w", apierrors.NewNotFound(schema.GroupResource{Resource: "packages"}, "a")),
+			http.StatusNotFound, apiErrorNotFound),
+		Entry("Manifest not found", httperror.FromStatusCode(http.StatusNotFound),
+			http.StatusNotFound, apiErrorNotFound),
+		Entry("Invalid resource",
+			apierrors.NewInvalid(schema.GroupKind{Kind: "Package"}, "a", nil),
+			http.StatusBadRequest, apiErrorValidationFailed),
+		Entry("Repository unreachable", httperror.FromStatusCode(http.StatusBadGateway),
+			http.StatusBadGateway, apiErrorRepositoryUnavailable),
+		Entry("Not bootstrapped", newBootstrapErr(errors.New("not bootstrapped")),
+			http.StatusServiceUnavailable, apiErrorNotReady),
+		Entry("Timeout", fmt.Errorf("wrapped: %w", context.DeadlineExceeded),
+			http.StatusGatewayTimeout, apiErrorTimeout),
+		Entry("Explicit error", newApiError(http.StatusTooManyRequests, apiErrorRateLimited, errTooManyRequests),
+			http.StatusTooManyRequests, apiErrorRateLimited),
+	)
+
+	It("should list combined errors as details", func() {
+		apiErr := toApiError(multierr.Combine(errors.New("a"), errors.New("b")))
+		Expect(apiErr.Details).To(Equal([]string{"a", "b"}))
+	})
+	Describe("package events", func() {
+		pkg := &v1alpha1.ClusterPackage{
+			ObjectMeta: metav1.ObjectMeta{Name: "cert-manager"},
+			Spec: v1alpha1.PackageSpec{
+				PackageInfo: v1alpha1.PackageInfoTemplate{Name: "cert-manager", Version: "v1.2.0+1"},
+			},
+			Status: v1alpha1.PackageStatus{
+				Version: "v1.1.0+1",
+				Conditions: []metav1.Condition{
+					{Type: string(condition.Failed), Status: metav1.ConditionTrue, Reason: "InstallationFailed"},
+				},
+				FailedResources: []v1alpha1.FailedResourceRef{{
+					GroupVersionKind: metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+					Name:             "webhook",
+					Namespace:        "cert-manager",
+					Message:          "denied",
+				}},
+			},
+		}
+
+		It("should contain the phase, components and failed resources", func() {
+			event := newApiPackageEvent(pkg, []workloads.Status{
+				{Kind: "Deployment", Namespace: "cert-manager", Name: "controller", Desired: 2, Ready: 1},
+			})
+			Expect(event.Phase).To(Equal("Failed"))
+			Expect(event.Reason).To(Equal("InstallationFailed"))
+			Expect(event.Version).To(Equal("v1.2.0+1"))
+			Expect(event.InstalledVersion).To(Equal("v1.1.0+1"))
+			Expect(event.Components).To(Equal([]apiComponentStatus{
+				{Kind: "Deployment", Namespace: "cert-manager", Name: "controller", Desired: 2, Ready: 1},
+			}))
+			Expect(event.FailedResources).To(Equal([]apiFailedResource{
+				{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "cert-manager", Name: "webhook", Message: "denied"},
+			}))
+		})
+
+		It("should encode empty lists as arrays", func() {
+			recorder := httptest.NewRecorder()
+			Expect(writeApiEvent(recorder, apiEventStatus, newApiPackageEvent(&v1alpha1.ClusterPackage{}, nil))).
+				To(Succeed())
+			Expect(recorder.Body.String()).To(HavePrefix("event: status\ndata: {"))
+			Expect(recorder.Body.String()).To(ContainSubstring(`"components":[]`))
+			Expect(recorder.Body.String()).To(HaveSuffix("}\n\n"))
+		})
+	})
+})
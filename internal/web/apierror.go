@@ -0,0 +1,153 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/glasskube/glasskube/internal/httperror"
+	"github.com/glasskube/glasskube/internal/web/components/toast"
+	"go.uber.org/multierr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// apiPathPrefix is the prefix of all routes of the JSON API. Errors of these routes are sent as apiErrorResponse
+// instead of a toast.
+const apiPathPrefix = "/api/"
+
+// apiErrorCode identifies the kind of error in an apiErrorResponse. Clients can rely on these codes, so existing codes
+// must never be changed or reused for other errors.
+type apiErrorCode string
+
+const (
+	apiErrorInternal              apiErrorCode = "internal_error"
+	apiErrorNotFound              apiErrorCode = "not_found"
+	apiErrorValidationFailed      apiErrorCode = "validation_failed"
+	apiErrorRepositoryUnavailable apiErrorCode = "repository_unavailable"
+	apiErrorClusterUnavailable    apiErrorCode = "cluster_unavailable"
+	apiErrorNotReady              apiErrorCode = "not_ready"
+	apiErrorUnauthorized          apiErrorCode = "unauthorized"
+	apiErrorForbidden             apiErrorCode = "forbidden"
+	apiErrorMethodNotAllowed      apiErrorCode = "method_not_allowed"
+	apiErrorRateLimited           apiErrorCode = "rate_limited"
+	apiErrorTimeout               apiErrorCode = "timeout"
+)
+
+// apiError is the machine-readable description of an error returned by the JSON API
+type apiError struct {
+	Code    apiErrorCode `json:"code"`
+	Message string       `json:"message"`
+	// Details contain the individual messages, if the error is a combination of multiple errors
+	Details []string `json:"details,omitempty"`
+	status  int
+}
+
+type apiErrorResponse struct {
+	Error apiError `json:"error"`
+}
+
+func (e *apiError) Error() string {
+	return e.Message
+}
+
+// newApiError returns an error with the given code and status, which is sent as is by the API
+func newApiError(status int, code apiErrorCode, err error) *apiError {
+	result := apiError{Code: code, Message: err.Error(), status: status}
+	if errs := multierr.Errors(err); len(errs) > 1 {
+		for _, err := range errs {
+			result.Details = append(result.Details, err.Error())
+		}
+	}
+	return &result
+}
+
+// toApiError classifies err and returns the status code and error code that describe it best. Unknown errors are
+// internal errors.
+func toApiError(err error) *apiError {
+	var apiErr *apiError
+	var configErr ServerConfigError
+	var urlErr *url.Error
+	switch {
+	case errors.As(err, &apiErr):
+		return apiErr
+	case errors.As(err, &configErr):
+		return newApiError(http.StatusServiceUnavailable, apiErrorNotReady, err)
+	case apierrors.IsNotFound(err), httperror.IsNotFound(err):
+		return newApiError(http.StatusNotFound, apiErrorNotFound, err)
+	case apierrors.IsInvalid(err), apierrors.IsBadRequest(err):
+		return newApiError(http.StatusBadRequest, apiErrorValidationFailed, err)
+	case apierrors.IsUnauthorized(err):
+		return newApiError(http.StatusUnauthorized, apiErrorUnauthorized, err)
+	case apierrors.IsForbidden(err):
+		return newApiError(http.StatusForbidden, apiErrorForbidden, err)
+	case apierrors.IsTooManyRequests(err):
+		return newApiError(http.StatusTooManyRequests, apiErrorRateLimited, err)
+	case errors.Is(err, context.DeadlineExceeded), apierrors.IsTimeout(err), apierrors.IsServerTimeout(err):
+		return newApiError(http.StatusGatewayTimeout, apiErrorTimeout, err)
+	case httperror.IsServerError(err), httperror.IsTLSError(err), httperror.IsProxyError(err),
+		httperror.Is(err, http.StatusUnauthorized), httperror.Is(err, http.StatusForbidden):
+		// these are only returned by the clients of package repositories
+		return newApiError(http.StatusBadGateway, apiErrorRepositoryUnavailable, err)
+	case apierrors.IsServiceUnavailable(err), apierrors.IsInternalError(err), errors.As(err, &urlErr):
+		return newApiError(http.StatusBadGateway, apiErrorClusterUnavailable, err)
+	default:
+		return newApiError(http.StatusInternalServerError, apiErrorInternal, err)
+	}
+}
+
+func writeApiError(w http.ResponseWriter, r *http.Request, err error) {
+	apiErr := toApiError(err)
+	if apiErr.status >= http.StatusInternalServerError {
+		fmt.Fprintf(os.Stderr, "%v %v failed: %v\n", r.Method, r.URL.Path, err)
+	}
+	writeJSON(w, r, apiErr.status, apiErrorResponse{Error: *apiErr})
+}
+
+func isApiRequest(r *http.Request) bool {
+	return strings.HasPrefix(r.URL.Path, apiPathPrefix)
+}
+
+// sendError rejects a request with the given status code. API requests get an apiErrorResponse, all other requests a
+// toast. It is used by middlewares, which handle both kinds of requests.
+func (s *server) sendError(w http.ResponseWriter, r *http.Request, status int, code apiErrorCode, err error) {
+	if isApiRequest(r) {
+		writeApiError(w, r, newApiError(status, code, err))
+	} else {
+		s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(status))
+	}
+}
+
+// apiHandlerFunc is a handler of the JSON API. A returned error is sent as apiErrorResponse, so the handler must not
+// have written anything in that case.
+type apiHandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// apiErrorMiddleware turns an apiHandlerFunc into a handler, which sends all errors as apiErrorResponse. Because the
+// API is read-only, all methods other than GET and HEAD are rejected. Panics are sent as internal errors as well.
+func apiErrorMiddleware(h apiHandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				if recovered == http.ErrAbortHandler {
+					panic(recovered)
+				}
+				writeApiError(w, r, fmt.Errorf("panic: %v", recovered))
+			}
+		}()
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			writeApiError(w, r, newApiError(http.StatusMethodNotAllowed, apiErrorMethodNotAllowed,
+				fmt.Errorf("method %v is not allowed", r.Method)))
+		} else if err := h(w, r); err != nil {
+			writeApiError(w, r, err)
+		}
+	}
+}
+
+// apiNotFound is the handler of all paths of the API that do not exist
+func apiNotFound(w http.ResponseWriter, r *http.Request) error {
+	return newApiError(http.StatusNotFound, apiErrorNotFound, fmt.Errorf("%v does not exist", r.URL.Path))
+}
@@ -0,0 +1,196 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/workloads"
+	"github.com/glasskube/glasskube/pkg/client"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// apiEventHeartbeatInterval is the interval in which a comment is sent to idle event streams, so that proxies do not
+// close the connection
+const apiEventHeartbeatInterval = 15 * time.Second
+
+const (
+	// apiEventStatus is sent when the stream is opened and whenever the status of the package changes
+	apiEventStatus = "status"
+	// apiEventDeleted is the last event of a stream, which is sent when the package has been uninstalled
+	apiEventDeleted = "deleted"
+)
+
+// apiPhaseUninstalled is the phase of the package in the deleted event
+const apiPhaseUninstalled = "Uninstalled"
+
+// apiPackageEvent is the data of an event of the package event stream. Clients can rely on this schema, so existing
+// fields must never be changed or removed.
+type apiPackageEvent struct {
+	Name             string               `json:"name"`
+	Namespace        string               `json:"namespace,omitempty"`
+	PackageName      string               `json:"packageName"`
+	Version          string               `json:"version"`
+	InstalledVersion string               `json:"installedVersion,omitempty"`
+	Phase            string               `json:"phase"`
+	Reason           string               `json:"reason,omitempty"`
+	Message          string               `json:"message,omitempty"`
+	Suspended        bool                 `json:"suspended"`
+	Paused           bool                 `json:"paused"`
+	Components       []apiComponentStatus `json:"components"`
+	FailedResources  []apiFailedResource  `json:"failedResources"`
+	Time             time.Time            `json:"time"`
+}
+
+// apiComponentStatus is the readiness of a workload of the package
+type apiComponentStatus struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Desired   int32  `json:"desired"`
+	Ready     int32  `json:"ready"`
+	IsReady   bool   `json:"isReady"`
+}
+
+// apiFailedResource is a resource of the package that could not be applied
+type apiFailedResource struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	Message    string `json:"message"`
+}
+
+func newApiPackageEvent(pkg ctrlpkg.Package, workloadList []workloads.Status) apiPackageEvent {
+	event := apiPackageEvent{
+		Name:             pkg.GetName(),
+		Namespace:        pkg.GetNamespace(),
+		PackageName:      pkg.GetSpec().PackageInfo.Name,
+		Version:          pkg.GetSpec().PackageInfo.Version,
+		InstalledVersion: pkg.GetStatus().Version,
+		Suspended:        pkg.GetSpec().Suspend,
+		Paused:           pkg.IsPaused(),
+		Components:       []apiComponentStatus{},
+		FailedResources:  []apiFailedResource{},
+	}
+	if status := client.GetStatusOrPending(pkg); status != nil {
+		event.Phase = status.Status
+		event.Reason = status.Reason
+		event.Message = status.Message
+	}
+	for _, workload := range workloadList {
+		event.Components = append(event.Components, apiComponentStatus{
+			Kind:      workload.Kind,
+			Namespace: workload.Namespace,
+			Name:      workload.Name,
+			Desired:   workload.Desired,
+			Ready:     workload.Ready,
+			IsReady:   workload.IsReady(),
+		})
+	}
+	for _, ref := range pkg.GetStatus().FailedResources {
+		event.FailedResources = append(event.FailedResources, apiFailedResource{
+			APIVersion: schema.GroupVersion{Group: ref.Group, Version: ref.Version}.String(),
+			Kind:       ref.Kind,
+			Namespace:  ref.Namespace,
+			Name:       ref.Name,
+			Message:    ref.Message,
+		})
+	}
+	return event
+}
+
+// writeApiEvent writes a single server sent event with the given data encoded as JSON
+func writeApiEvent(w http.ResponseWriter, event string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	return err
+}
+
+// apiPackageEvents streams the progress of an installation or update of a package as server sent events. A status
+// event is sent when the stream is opened and whenever the phase, the readiness of a component or the failed
+// resources of the package change. Idle streams receive a heartbeat comment.
+func (s *server) apiPackageEvents(w http.ResponseWriter, r *http.Request) error {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return fmt.Errorf("server sent events not supported")
+	}
+	pkg, err := s.getPackageFromRequest(r)
+	if err != nil {
+		return err
+	}
+
+	changed, unsubscribe := s.broadcaster.Subscribe(pkg)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// disables response buffering of nginx, which would otherwise delay events
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodHead {
+		return nil
+	}
+
+	heartbeat := time.NewTicker(apiEventHeartbeatInterval)
+	defer heartbeat.Stop()
+	var last *apiPackageEvent
+	for {
+		workloadList, err := s.getWorkloads(r.Context(), pkg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to get workloads of %v: %v\n", pkg.GetName(), err)
+		}
+		// events are only sent if something other than the time has changed
+		if event := newApiPackageEvent(pkg, workloadList); last == nil || !reflect.DeepEqual(*last, event) {
+			sent := event
+			last = &sent
+			event.Time = time.Now().UTC()
+			if err := writeApiEvent(w, apiEventStatus, event); err != nil {
+				return nil
+			}
+			flusher.Flush()
+		}
+
+	wait:
+		for {
+			select {
+			case <-r.Context().Done():
+				return nil
+			case _, ok := <-changed:
+				if !ok {
+					return nil
+				}
+				break wait
+			case <-heartbeat.C:
+				if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+					return nil
+				}
+				flusher.Flush()
+			}
+		}
+
+		if pkg, err = s.getPackageFromRequest(r); apierrors.IsNotFound(err) {
+			event := *last
+			event.Phase = apiPhaseUninstalled
+			event.Reason = ""
+			event.Message = ""
+			event.Components = []apiComponentStatus{}
+			event.Time = time.Now().UTC()
+			_ = writeApiEvent(w, apiEventDeleted, event)
+			flusher.Flush()
+			return nil
+		} else if err != nil {
+			// the status code has already been sent, so the client can only find out by reconnecting
+			fmt.Fprintf(os.Stderr, "failed to get package %v: %v\n", last.Name, err)
+			return nil
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/glasskube/glasskube/internal/audit"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/web/util"
+)
+
+const auditDateFormat = time.DateOnly
+
+// recordPackageOperation counts a successful package operation in the metrics and writes it to the audit log
+func (s *server) recordPackageOperation(
+	ctx context.Context,
+	operation audit.Operation,
+	pkg ctrlpkg.Package,
+	versionBefore, versionAfter string,
+) {
+	s.metrics.packageOperation(operation, pkg)
+	if err := audit.Record(ctx, audit.SourceUI, operation, pkg, versionBefore, versionAfter); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+	}
+}
+
+func (s *server) auditPage(w http.ResponseWriter, r *http.Request) {
+	filter := audit.Filter{PackageName: r.FormValue("package")}
+	var err error
+	if from := r.FormValue("from"); from != "" {
+		if filter.From, err = time.Parse(auditDateFormat, from); err != nil {
+			err = fmt.Errorf("invalid from date: %w", err)
+		}
+	}
+	if to := r.FormValue("to"); to != "" && err == nil {
+		if filter.To, err = time.Parse(auditDateFormat, to); err != nil {
+			err = fmt.Errorf("invalid to date: %w", err)
+		} else {
+			// the to date is inclusive
+			filter.To = filter.To.AddDate(0, 0, 1)
+		}
+	}
+
+	var entries []audit.Entry
+	if err == nil {
+		entries, err = audit.List(r.Context(), s.k8sClient, filter)
+	}
+
+	tmplErr := s.executePage(w, s.templatesFor(r).auditPageTmpl, "audit", s.enrichPage(r, map[string]any{
+		"Entries": entries,
+		"Package": r.FormValue("package"),
+		"From":    r.FormValue("from"),
+		"To":      r.FormValue("to"),
+	}, err))
+	util.CheckTmplError(tmplErr, "audit")
+}
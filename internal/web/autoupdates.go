@@ -0,0 +1,141 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/glasskube/glasskube/internal/autoupdate"
+	"github.com/glasskube/glasskube/internal/web/components/toast"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// getAutoUpdateFreeze returns the global auto-update freeze from the informer cache. If it can not be determined, nil
+// is returned, which is treated like updates are not suspended.
+func (s *server) getAutoUpdateFreeze() *autoupdate.Freeze {
+	if s.configMapLister == nil {
+		return nil
+	}
+	cm, err := (*s.configMapLister).ConfigMaps(autoupdate.Namespace).Get(autoupdate.ConfigMapName)
+	if apierrors.IsNotFound(err) {
+		return &autoupdate.Freeze{}
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to get auto-update freeze: %v\n", err)
+		return nil
+	}
+	return autoupdate.FreezeFromConfigMap(cm)
+}
+
+// getAutoUpdateWindow returns the maintenance window for automatic updates from the informer cache. If no window is
+// configured or it can not be determined, nil is returned.
+func (s *server) getAutoUpdateWindow() *autoupdate.MaintenanceWindow {
+	if s.configMapLister == nil {
+		return nil
+	}
+	cm, err := (*s.configMapLister).ConfigMaps(autoupdate.Namespace).Get(autoupdate.ConfigMapName)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to get auto-update maintenance window: %v\n", err)
+		return nil
+	}
+	window, err := autoupdate.MaintenanceWindowFromConfigMap(cm)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to get auto-update maintenance window: %v\n", err)
+	}
+	return window
+}
+
+// autoUpdateSettings suspends or resumes automatic updates of all packages. The freeze is honored by the auto-updater
+// before any package is updated.
+func (s *server) autoUpdateSettings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+
+	freeze := autoupdate.Freeze{Suspended: r.PostForm.Get("suspended") == "on"}
+	if freeze.Suspended {
+		freeze.Reason = r.PostForm.Get("reason")
+		freeze.Since = time.Now()
+		if existing := s.getAutoUpdateFreeze(); existing.IsActive() {
+			freeze.Since = existing.Since
+		} else if pkgs, err := s.listAllInstalledPackages(r.Context()); err != nil {
+			s.sendToast(w, toast.WithErr(err))
+			return
+		} else if !s.confirmImpact(w, r, autoUpdateSuspensionImpact(pkgs)) {
+			return
+		}
+	}
+	if err := s.saveAutoUpdateFreeze(r.Context(), &freeze); err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to save auto-update settings: %w", err)))
+		return
+	}
+
+	// the freeze state is shown on every page, so a full reload is needed to apply the change
+	w.Header().Add("Hx-Refresh", "true")
+}
+
+// autoUpdateWindowSettings configures the maintenance window in which automatic updates are applied. If no start is
+// given, the window is removed and updates are applied whenever the auto-updater runs.
+func (s *server) autoUpdateWindowSettings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+
+	var window *autoupdate.MaintenanceWindow
+	if start := r.PostForm.Get("start"); start != "" {
+		var err error
+		window, err = autoupdate.ParseMaintenanceWindow(strings.Join(r.PostForm["days"], ","),
+			start, r.PostForm.Get("end"), r.PostForm.Get("timezone"))
+		if err != nil {
+			s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+			return
+		}
+	}
+	if err := s.saveAutoUpdateConfigMap(r.Context(), window.ApplyTo); err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to save maintenance window: %w", err)))
+		return
+	}
+
+	// pending updates are shown on every page, so a full reload is needed to apply the change
+	w.Header().Add("Hx-Refresh", "true")
+}
+
+func (s *server) saveAutoUpdateFreeze(ctx context.Context, freeze *autoupdate.Freeze) error {
+	return s.saveAutoUpdateConfigMap(ctx, freeze.ApplyTo)
+}
+
+// saveAutoUpdateConfigMap creates or updates the ConfigMap containing the auto-update settings. Since it contains
+// both the freeze and the maintenance window, apply only modifies the keys of one of them.
+func (s *server) saveAutoUpdateConfigMap(ctx context.Context, apply func(*corev1.ConfigMap)) error {
+	configMaps := s.k8sClient.CoreV1().ConfigMaps(autoupdate.Namespace)
+	if existing, err := configMaps.Get(ctx, autoupdate.ConfigMapName, metav1.GetOptions{}); apierrors.IsNotFound(err) {
+		cm := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: autoupdate.ConfigMapName, Namespace: autoupdate.Namespace},
+		}
+		apply(&cm)
+		_, err := configMaps.Create(ctx, &cm, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	} else {
+		apply(existing)
+		_, err := configMaps.Update(ctx, existing, metav1.UpdateOptions{})
+		return err
+	}
+}
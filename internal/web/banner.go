@@ -0,0 +1,98 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/glasskube/glasskube/internal/banner"
+	"github.com/glasskube/glasskube/internal/web/components/toast"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const dismissedBannerKey = "dismissedBanner"
+
+// getBanner returns the banner from the informer cache, so that changes take effect on the next page load. If no
+// banner is configured or it can not be determined, an empty banner is returned.
+func (s *server) getBanner() *banner.Banner {
+	if s.configMapLister == nil {
+		return &banner.Banner{Severity: banner.SeverityInfo}
+	}
+	cm, err := (*s.configMapLister).ConfigMaps(banner.Namespace).Get(banner.ConfigMapName)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			fmt.Fprintf(os.Stderr, "failed to get banner: %v\n", err)
+		}
+		return &banner.Banner{Severity: banner.SeverityInfo}
+	}
+	return banner.FromConfigMap(cm)
+}
+
+// getVisibleBanner returns the banner, unless it is empty or the current user has dismissed it
+func (s *server) getVisibleBanner(r *http.Request) *banner.Banner {
+	if b := s.getBanner(); b.Enabled() {
+		if c, err := r.Cookie(dismissedBannerKey); err != nil || c.Value != b.ID() {
+			return b
+		}
+	}
+	return nil
+}
+
+// bannerSettings stores the banner that is shown to all users. An empty text removes the banner.
+func (s *server) bannerSettings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+
+	b := banner.Banner{Text: r.PostForm.Get("text"), Severity: banner.Severity(r.PostForm.Get("severity"))}
+	if err := b.Validate(); err != nil {
+		s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+	if err := s.saveBanner(r.Context(), &b); err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to save banner: %w", err)))
+		return
+	}
+	// the banner is part of the base layout, so the page is reloaded to show it
+	w.Header().Add("Hx-Refresh", "true")
+}
+
+func (s *server) saveBanner(ctx context.Context, b *banner.Banner) error {
+	configMaps := s.k8sClient.CoreV1().ConfigMaps(banner.Namespace)
+	cm := b.ConfigMap()
+	if existing, err := configMaps.Get(ctx, cm.Name, metav1.GetOptions{}); apierrors.IsNotFound(err) {
+		_, err := configMaps.Create(ctx, cm, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	} else {
+		existing.Data = cm.Data
+		_, err := configMaps.Update(ctx, existing, metav1.UpdateOptions{})
+		return err
+	}
+}
+
+// dismissBanner hides the banner with the given id for the current user. A changed banner is shown again.
+func (s *server) dismissBanner(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     dismissedBannerKey,
+		Value:    r.FormValue("id"),
+		Path:     "/",
+		MaxAge:   60 * 60 * 24 * 365,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,84 @@
+package web
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+
+	repotypes "github.com/glasskube/glasskube/internal/repo/types"
+	"github.com/glasskube/glasskube/internal/web/util"
+)
+
+// uncategorized is the category of packages that do not declare any category
+const uncategorized = "Uncategorized"
+
+// packageCategory is a category with all packages that belong to it, as shown on the categories page
+type packageCategory struct {
+	Name     string
+	Packages []categoryPackage
+}
+
+type categoryPackage struct {
+	repotypes.PackageRepoIndexItem
+	Installed bool
+}
+
+// CompareHref returns the URL of the comparison page for all packages of the category. An empty string is returned
+// if the category contains only one package or too many packages to compare them at once.
+func (c packageCategory) CompareHref() string {
+	if len(c.Packages) < 2 || len(c.Packages) > maxComparedPackages {
+		return ""
+	}
+	names := make([]string, len(c.Packages))
+	for i, pkg := range c.Packages {
+		names[i] = pkg.Name
+	}
+	return compareHref(names)
+}
+
+// byCategory groups all packages of the overview by their categories. A package with several categories is
+// contained in each of them. Categories are sorted by name, packages without a category come last.
+func (overview *packagesOverview) byCategory() []packageCategory {
+	packages := make(map[string][]categoryPackage)
+	add := func(item repotypes.PackageRepoIndexItem, installed bool) {
+		categories := item.Categories
+		if len(categories) == 0 {
+			categories = []string{uncategorized}
+		}
+		for _, category := range slices.Compact(slices.Sorted(slices.Values(categories))) {
+			packages[category] = append(packages[category], categoryPackage{item, installed})
+		}
+	}
+	for _, pkgs := range overview.installed {
+		add(pkgs.PackageRepoIndexItem, true)
+	}
+	for _, item := range overview.available {
+		add(*item, false)
+	}
+
+	result := make([]packageCategory, 0, len(packages))
+	for name, pkgs := range packages {
+		slices.SortFunc(pkgs, func(a, b categoryPackage) int { return cmp.Compare(a.Name, b.Name) })
+		result = append(result, packageCategory{Name: name, Packages: pkgs})
+	}
+	slices.SortFunc(result, func(a, b packageCategory) int {
+		if (a.Name == uncategorized) != (b.Name == uncategorized) {
+			if a.Name == uncategorized {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return result
+}
+
+// categoriesPage shows all packages of the active repositories grouped by their categories
+func (s *server) categoriesPage(w http.ResponseWriter, r *http.Request) {
+	overview, listErr := s.getPackagesOverview(r.Context())
+	tmplErr := s.executePage(w, s.templatesFor(r).categoriesPageTmpl, "categories", s.enrichPage(r, map[string]any{
+		"Categories": overview.byCategory(),
+		"Favorites":  favoritesSet(getFavoritesFromCookie(r)),
+	}, listErr))
+	util.CheckTmplError(tmplErr, "categories")
+}
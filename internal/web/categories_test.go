@@ -0,0 +1,51 @@
+package web
+
+import (
+	repotypes "github.com/glasskube/glasskube/internal/repo/types"
+	"github.com/glasskube/glasskube/pkg/list"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Categories", func() {
+	overview := &packagesOverview{
+		installed: []*list.PackagesWithStatus{
+			{MetaIndexItem: repotypes.MetaIndexItem{PackageRepoIndexItem: repotypes.PackageRepoIndexItem{
+				Name: "cloudnative-pg", Categories: []string{"Databases", "Operators"},
+			}}},
+		},
+		available: []*repotypes.PackageRepoIndexItem{
+			{Name: "cert-manager", Categories: []string{"Security", "Operators"}},
+			{Name: "hello"},
+		},
+	}
+
+	It("should group packages by category", func() {
+		categories := overview.byCategory()
+		names := make([]string, len(categories))
+		for i, c := range categories {
+			names[i] = c.Name
+		}
+		Expect(names).To(Equal([]string{"Databases", "Operators", "Security", uncategorized}))
+		Expect(categories[1].Packages).To(HaveLen(2))
+		Expect(categories[1].Packages[0].Name).To(Equal("cert-manager"))
+		Expect(categories[1].Packages[1].Installed).To(BeTrue())
+		Expect(categories[3].Packages[0].Name).To(Equal("hello"))
+	})
+
+	It("should only link categories with several packages to the comparison", func() {
+		categories := overview.byCategory()
+		Expect(categories[1].CompareHref()).To(Equal("/compare?packages=cert-manager&packages=cloudnative-pg"))
+		Expect(categories[0].CompareHref()).To(BeEmpty())
+	})
+
+	It("should list uncategorized as the last category", func() {
+		Expect(overview.categories()).To(Equal([]string{"Databases", "Operators", "Security", uncategorized}))
+	})
+
+	It("should filter packages without category", func() {
+		filter := packageFilter{Category: "uncategorized"}
+		Expect(filter.Matches(*overview.available[1], nil)).To(BeTrue())
+		Expect(filter.Matches(*overview.available[0], nil)).To(BeFalse())
+	})
+})
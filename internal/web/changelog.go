@@ -0,0 +1,131 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	repotypes "github.com/glasskube/glasskube/internal/repo/types"
+	"github.com/glasskube/glasskube/internal/semver"
+	"github.com/glasskube/glasskube/internal/web/components/toast"
+	"github.com/glasskube/glasskube/internal/web/util"
+	"github.com/glasskube/glasskube/pkg/update"
+)
+
+// versionReleaseNotes are the release notes of a single version of a package
+type versionReleaseNotes struct {
+	Version string
+	Notes   string
+	Url     string
+	Err     error
+}
+
+// packageChangelog contains the release notes of all versions between the installed and the latest version of a
+// package, ordered from the newest to the oldest version
+type packageChangelog struct {
+	Package          ctrlpkg.Package
+	InstalledVersion string
+	LatestVersion    string
+	Versions         []versionReleaseNotes
+	// References of the latest version are shown instead, if no version has release notes
+	References []v1alpha1.PackageReference
+	// RepositoryUrl is the manifest URL of the latest version, which is shown as the last fallback
+	RepositoryUrl string
+}
+
+// HasReleaseNotes returns true if at least one version has embedded or linked release notes
+func (c packageChangelog) HasReleaseNotes() bool {
+	for _, v := range c.Versions {
+		if v.Notes != "" || v.Url != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// updateChangelog is a GET endpoint that renders the release notes of all packages of the given scope that can be
+// updated, for all versions between the installed and the latest version
+func (s *server) updateChangelog(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := r.Context()
+	pkgs, err := s.listInstalledPackages(ctx, r.FormValue("scope"))
+	if err != nil {
+		s.sendToast(w, toast.WithErr(err))
+		return
+	}
+	var changelogs []packageChangelog
+	if len(pkgs) > 0 {
+		changelogs, err = s.getChangelogs(ctx, pkgs)
+	}
+	tmplErr := s.templatesFor(r).pkgChangelogTmpl.ExecuteTemplate(w, "pkg-changelog", map[string]any{
+		"Changelogs": changelogs,
+		"Error":      err,
+	})
+	util.CheckTmplError(tmplErr, "pkg-changelog")
+}
+
+func (s *server) getChangelogs(ctx context.Context, pkgs []ctrlpkg.Package) ([]packageChangelog, error) {
+	tx, err := update.NewUpdater(ctx).Prepare(ctx, update.GetExact(pkgs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for updates: %w", err)
+	}
+	var changelogs []packageChangelog
+	addChangelog := func(pkg ctrlpkg.Package, version string) {
+		if version != "" && pkg.GetDeletionTimestamp().IsZero() {
+			changelogs = append(changelogs, s.getChangelog(pkg, version))
+		}
+	}
+	for _, item := range tx.Items {
+		addChangelog(item.Package, item.Version)
+	}
+	for _, item := range tx.ConflictItems {
+		addChangelog(item.Package, item.Version)
+	}
+	return changelogs, nil
+}
+
+// getChangelog collects the release notes of every version between the installed version of pkg and latestVersion
+// that the repository knows about. Errors are recorded per version, so that a single missing manifest does not hide
+// the notes of all other versions.
+func (s *server) getChangelog(pkg ctrlpkg.Package, latestVersion string) packageChangelog {
+	info := pkg.GetSpec().PackageInfo
+	changelog := packageChangelog{
+		Package:          pkg,
+		InstalledVersion: info.Version,
+		LatestVersion:    latestVersion,
+	}
+	repoClient := s.repoClientset.ForPackage(pkg)
+	versions := []string{latestVersion}
+	var index repotypes.PackageIndex
+	if err := repoClient.FetchPackageIndex(info.Name, &index); err == nil {
+		available := make([]string, len(index.Versions))
+		for i, item := range index.Versions {
+			available[i] = item.Version
+		}
+		versions = semver.VersionsBetween(available, info.Version, latestVersion)
+	}
+	for _, version := range versions {
+		var manifest v1alpha1.PackageManifest
+		if err := repoClient.FetchPackageManifest(info.Name, version, &manifest); err != nil {
+			changelog.Versions = append(changelog.Versions, versionReleaseNotes{Version: version, Err: err})
+			continue
+		}
+		changelog.Versions = append(changelog.Versions, versionReleaseNotes{
+			Version: version,
+			Notes:   manifest.ReleaseNotes,
+			Url:     manifest.ReleaseNotesUrl,
+		})
+		if version == latestVersion {
+			changelog.References = manifest.References
+		}
+	}
+	if url, err := repoClient.GetPackageManifestURL(info.Name, latestVersion); err == nil {
+		changelog.RepositoryUrl = url
+	}
+	return changelog
+}
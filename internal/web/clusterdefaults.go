@@ -0,0 +1,113 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/glasskube/glasskube/internal/manifestvalues"
+	"github.com/glasskube/glasskube/internal/maputils"
+	"github.com/glasskube/glasskube/internal/web/components/toast"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// clusterDefaultSettings stores the cluster-wide defaults in the cluster info ConfigMap. They pre-fill the values of
+// packages that refer to them and are available to value templates as .Cluster. The environment of the cluster, which
+// selects the overlays of packages, is stored in the same ConfigMap but has its own input.
+func (s *server) clusterDefaultSettings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+
+	defaults, err := parseClusterDefaults(r.PostForm.Get("defaults"))
+	if err != nil {
+		s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	} else if _, ok := defaults[manifestvalues.EnvironmentKey]; ok {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("%v can not be set as default, please use the environment input",
+			manifestvalues.EnvironmentKey)), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+	environment := strings.TrimSpace(r.PostForm.Get("environment"))
+	if environment != "" {
+		defaults[manifestvalues.EnvironmentKey] = environment
+	}
+
+	if impact, err := s.getEnvironmentChangeImpact(r.Context(), environment); err != nil {
+		s.sendToast(w, toast.WithErr(err))
+		return
+	} else if !s.confirmImpact(w, r, impact) {
+		return
+	}
+	if err := s.saveClusterDefaults(r.Context(), defaults); err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to save cluster defaults: %w", err)))
+		return
+	}
+	s.sendToast(w, toast.WithMessage("Cluster defaults saved"))
+}
+
+func (s *server) saveClusterDefaults(ctx context.Context, defaults map[string]string) error {
+	configMaps := s.k8sClient.CoreV1().ConfigMaps(manifestvalues.ClusterInfoNamespace)
+	name := manifestvalues.ClusterInfoConfigMapName
+	if existing, err := configMaps.Get(ctx, name, metav1.GetOptions{}); apierrors.IsNotFound(err) {
+		cm := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: manifestvalues.ClusterInfoNamespace,
+			},
+			Data: defaults,
+		}
+		_, err := configMaps.Create(ctx, &cm, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	} else {
+		existing.Data = defaults
+		_, err := configMaps.Update(ctx, existing, metav1.UpdateOptions{})
+		return err
+	}
+}
+
+// parseClusterDefaults parses one "key=value" pair per line. Empty lines and lines starting with "#" are ignored.
+func parseClusterDefaults(text string) (map[string]string, error) {
+	defaults := map[string]string{}
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok {
+			return nil, fmt.Errorf("line %v: expected key=value, got %q", i+1, line)
+		} else if errs := validation.IsConfigMapKey(key); len(errs) > 0 {
+			return nil, fmt.Errorf("line %v: invalid key %q: %v", i+1, key, strings.Join(errs, ", "))
+		} else if _, exists := defaults[key]; exists {
+			return nil, fmt.Errorf("line %v: %v is defined more than once", i+1, key)
+		}
+		defaults[key] = value
+	}
+	return defaults, nil
+}
+
+// formatClusterDefaults is the inverse of parseClusterDefaults, with the keys sorted. The environment is omitted,
+// because it has its own input.
+func formatClusterDefaults(defaults map[string]string) string {
+	var sb strings.Builder
+	for _, key := range maputils.KeysSorted(defaults) {
+		if key == manifestvalues.EnvironmentKey {
+			continue
+		}
+		fmt.Fprintf(&sb, "%v=%v\n", key, defaults[key])
+	}
+	return sb.String()
+}
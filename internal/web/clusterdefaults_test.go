@@ -0,0 +1,29 @@
+package web
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cluster defaults", func() {
+	It("should parse key=value lines", func() {
+		Expect(parseClusterDefaults("# comment\nstorageClass = fast\n\ndomain=example.com\nempty=\n")).
+			To(Equal(map[string]string{"storageClass": "fast", "domain": "example.com", "empty": ""}))
+	})
+
+	DescribeTable("should reject invalid lines",
+		func(text string) {
+			_, err := parseClusterDefaults(text)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("missing separator", "storageClass"),
+		Entry("invalid key", "storage class=fast"),
+		Entry("duplicate key", "domain=a\ndomain=b"),
+	)
+
+	It("should format defaults sorted by key", func() {
+		defaults := map[string]string{"storageClass": "fast", "domain": "example.com"}
+		Expect(formatClusterDefaults(defaults)).To(Equal("domain=example.com\nstorageClass=fast\n"))
+		Expect(parseClusterDefaults(formatClusterDefaults(defaults))).To(Equal(defaults))
+	})
+})
@@ -0,0 +1,57 @@
+package web
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/manifestvalues/cli"
+	"sigs.k8s.io/yaml"
+)
+
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9_./:=@%+,-]+$`)
+
+// kubectlApplyCommand returns a kubectl command that creates or updates the given package from the YAML in a heredoc.
+// Like the YAML editor, only the metadata and spec are included, so value references stay references.
+func kubectlApplyCommand(pkg ctrlpkg.Package) (string, error) {
+	data, err := yaml.Marshal(toEditableObject(pkg))
+	if err != nil {
+		return "", err
+	}
+	return "kubectl apply -f - <<'EOF'\n" + string(data) + "EOF\n", nil
+}
+
+// glasskubeInstallCommand returns a glasskube install command that installs the given package with the same version,
+// repository and values. Value references are passed as references (see cli.FormatValues) and are never resolved.
+func glasskubeInstallCommand(pkg ctrlpkg.Package) string {
+	spec := pkg.GetSpec()
+	command := []string{"glasskube install " + shellQuote(spec.PackageInfo.Name)}
+	addFlag := func(flag string, value string) {
+		command = append(command, "--"+flag+" "+shellQuote(value))
+	}
+	if pkg.IsNamespaceScoped() {
+		command[0] += " " + shellQuote(pkg.GetName())
+		addFlag("namespace", pkg.GetNamespace())
+	}
+	if pkg.AutoUpdatesEnabled() {
+		command = append(command, "--enable-auto-updates")
+	} else if spec.PackageInfo.Version != "" {
+		addFlag("version", spec.PackageInfo.Version)
+	}
+	if spec.PackageInfo.RepositoryName != "" {
+		addFlag("repository", spec.PackageInfo.RepositoryName)
+	}
+	for _, value := range cli.FormatValues(spec.Values) {
+		addFlag("value", value)
+	}
+	command = append(command, "--yes")
+	return strings.Join(command, " \\\n  ") + "\n"
+}
+
+func shellQuote(s string) string {
+	if shellSafe.MatchString(s) {
+		return s
+	}
+	return fmt.Sprintf("'%v'", strings.ReplaceAll(s, "'", `'\''`))
+}
@@ -0,0 +1,46 @@
+package web
+
+import (
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("glasskubeInstallCommand", func() {
+	It("should reproduce a package with values", func() {
+		pkg := &v1alpha1.Package{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: "apps"},
+			Spec: v1alpha1.PackageSpec{
+				PackageInfo: v1alpha1.PackageInfoTemplate{Name: "app", Version: "v1.0.0+1", RepositoryName: "glasskube"},
+				Values: map[string]v1alpha1.ValueConfiguration{
+					"greeting": {InlineValueConfiguration: v1alpha1.InlineValueConfiguration{Value: util.Pointer("it's me")}},
+					"password": {ValueFrom: &v1alpha1.ValueReference{SecretRef: &v1alpha1.ObjectKeyValueSource{
+						Namespace: "apps", Name: "credentials", Key: "password",
+					}}},
+				},
+			},
+		}
+		Expect(glasskubeInstallCommand(pkg)).To(Equal("glasskube install app my-app \\\n" +
+			"  --namespace apps \\\n" +
+			"  --version v1.0.0+1 \\\n" +
+			"  --repository glasskube \\\n" +
+			"  --value 'greeting=it'\\''s me' \\\n" +
+			"  --value 'password=$SecretRef$apps,credentials,password' \\\n" +
+			"  --yes\n"))
+	})
+
+	It("should not pin the version of a cluster package with auto updates", func() {
+		pkg := &v1alpha1.ClusterPackage{
+			ObjectMeta: metav1.ObjectMeta{Name: "cert-manager"},
+			Spec: v1alpha1.PackageSpec{
+				PackageInfo: v1alpha1.PackageInfoTemplate{Name: "cert-manager", Version: "v1.14.2+1"},
+			},
+		}
+		pkg.SetAutoUpdatesEnabled(true)
+		Expect(glasskubeInstallCommand(pkg)).To(Equal("glasskube install cert-manager \\\n" +
+			"  --enable-auto-updates \\\n" +
+			"  --yes\n"))
+	})
+})
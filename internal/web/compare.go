@@ -0,0 +1,155 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	repoerror "github.com/glasskube/glasskube/internal/repo/error"
+	repotypes "github.com/glasskube/glasskube/internal/repo/types"
+	"github.com/glasskube/glasskube/internal/web/util"
+)
+
+// maxComparedPackages limits the number of columns of the comparison page
+const maxComparedPackages = 5
+
+// comparedPackage contains everything that is shown in the column of a package on the comparison page
+type comparedPackage struct {
+	Name           string
+	Href           string
+	RepositoryName string
+	Repositories   []string
+	LatestVersion  string
+	VersionCount   int
+	Manifest       *v1alpha1.PackageManifest
+	// Installed are the installed instances of the package
+	Installed []comparedInstallation
+	// Err is set if the package could not be fetched. All other fields except Name may be empty in this case.
+	Err error
+}
+
+type comparedInstallation struct {
+	Namespace string
+	Name      string
+	Version   string
+}
+
+// comparePackageNames returns the distinct, non-empty package names of the request in the order in which they were
+// given
+func comparePackageNames(values []string) ([]string, error) {
+	var names []string
+	for _, value := range values {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" && !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	if len(names) > maxComparedPackages {
+		return names[:maxComparedPackages],
+			fmt.Errorf("at most %v packages can be compared at once", maxComparedPackages)
+	}
+	return names, nil
+}
+
+// compareHref returns the URL of the comparison page for the given packages
+func compareHref(names []string) string {
+	return "/compare?" + url.Values{"packages": names}.Encode()
+}
+
+// comparePage shows the metadata of the packages given in the "packages" query parameter side by side
+func (s *server) comparePage(w http.ResponseWriter, r *http.Request) {
+	names, err := comparePackageNames(r.URL.Query()["packages"])
+
+	var index repotypes.MetaIndex
+	if indexErr := s.repoClientset.Meta().FetchMetaIndex(&index); indexErr != nil && repoerror.IsComplete(indexErr) {
+		err = errors.Join(err, fmt.Errorf("failed to fetch package index: %w", indexErr))
+	}
+	available := make([]string, 0, len(index.Packages))
+	for _, item := range index.Packages {
+		available = append(available, item.Name)
+	}
+	slices.Sort(available)
+
+	installed, installedErr := s.getInstalledByManifestName(r.Context())
+	if installedErr != nil {
+		err = errors.Join(err, installedErr)
+	}
+	packages := make([]comparedPackage, len(names))
+	selected := make(map[string]bool, len(names))
+	for i, name := range names {
+		selected[name] = true
+		packages[i] = s.getComparedPackage(r.Context(), name)
+		packages[i].Installed = installed[name]
+	}
+
+	tmplErr := s.executePage(w, s.templatesFor(r).comparePageTmpl, "compare", s.enrichPage(r, map[string]any{
+		"Packages":          packages,
+		"Selected":          selected,
+		"AvailablePackages": available,
+		"MaxPackages":       maxComparedPackages,
+	}, err))
+	util.CheckTmplError(tmplErr, "compare")
+}
+
+// getComparedPackage fetches the index and the latest manifest of a package from the repository that would be used
+// to install it, like the package detail page does if no repository and version are requested
+func (s *server) getComparedPackage(ctx context.Context, name string) comparedPackage {
+	result := comparedPackage{Name: name, Href: util.GetNamespacedPkgHref(name, "", "")}
+	repositoryName, repos, _, err := s.resolveRepos(ctx, name, "")
+	if repoerror.IsComplete(err) {
+		result.Err = err
+		return result
+	}
+	result.RepositoryName = repositoryName
+	for _, repo := range repos {
+		result.Repositories = append(result.Repositories, repo.Name)
+	}
+
+	idx, latestVersion, _, err := s.resolveVersions(repositoryName, name, "")
+	if err != nil {
+		result.Err = fmt.Errorf("failed to fetch package index: %w", err)
+		return result
+	}
+	result.LatestVersion = latestVersion
+	result.VersionCount = len(idx.Versions)
+
+	var manifest v1alpha1.PackageManifest
+	if err := s.repoClientset.ForRepoWithName(repositoryName).
+		FetchPackageManifest(name, latestVersion, &manifest); err != nil {
+		result.Err = fmt.Errorf("failed to fetch manifest of %v (%v): %w", name, latestVersion, err)
+		return result
+	}
+	result.Manifest = &manifest
+	if manifest.Scope.IsCluster() {
+		result.Href = util.GetClusterPkgHref(name)
+	}
+	return result
+}
+
+// getInstalledByManifestName returns all installed packages and clusterpackages, keyed by the name of their manifest
+func (s *server) getInstalledByManifestName(ctx context.Context) (map[string][]comparedInstallation, error) {
+	result := make(map[string][]comparedInstallation)
+	var clpkgs v1alpha1.ClusterPackageList
+	if err := s.pkgClient.ClusterPackages().GetAll(ctx, &clpkgs); err != nil {
+		return result, fmt.Errorf("failed to fetch installed clusterpackages: %w", err)
+	}
+	for _, pkg := range clpkgs.Items {
+		result[pkg.Spec.PackageInfo.Name] = append(result[pkg.Spec.PackageInfo.Name],
+			comparedInstallation{Name: pkg.Name, Version: pkg.Spec.PackageInfo.Version})
+	}
+	var pkgs v1alpha1.PackageList
+	if err := s.pkgClient.Packages("").GetAll(ctx, &pkgs); err != nil {
+		return result, fmt.Errorf("failed to fetch installed packages: %w", err)
+	}
+	for _, pkg := range pkgs.Items {
+		result[pkg.Spec.PackageInfo.Name] = append(result[pkg.Spec.PackageInfo.Name],
+			comparedInstallation{Namespace: pkg.Namespace, Name: pkg.Name, Version: pkg.Spec.PackageInfo.Version})
+	}
+	return result, nil
+}
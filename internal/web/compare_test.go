@@ -0,0 +1,20 @@
+package web
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Compare", func() {
+	It("should accept repeated and comma separated package names", func() {
+		names, err := comparePackageNames([]string{"ingress-nginx, traefik", "", "ingress-nginx", "caddy"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(Equal([]string{"ingress-nginx", "traefik", "caddy"}))
+	})
+
+	It("should limit the number of packages", func() {
+		names, err := comparePackageNames([]string{"a,b,c,d,e,f"})
+		Expect(err).To(HaveOccurred())
+		Expect(names).To(HaveLen(maxComparedPackages))
+	})
+})
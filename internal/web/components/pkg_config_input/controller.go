@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/glasskube/glasskube/internal/manifestvalues"
 	"github.com/glasskube/glasskube/internal/web/util"
 
 	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
@@ -14,6 +15,10 @@ import (
 type PkgConfigInputRenderOptions struct {
 	Autofocus      bool
 	DesiredRefKind *string
+	// Values are shown instead of the values of the package, e.g. when a profile is loaded
+	Values map[string]v1alpha1.ValueConfiguration
+	// ClusterDefaults pre-fill values that refer to a cluster default and are not configured yet
+	ClusterDefaults map[string]string
 }
 
 type PkgConfigInputDatalistOptions struct {
@@ -29,6 +34,7 @@ type pkgConfigInputInput struct {
 	ValueName          string
 	FormValueName      string // ValueName prefixed with "values."
 	ValueDefinition    v1alpha1.ValueDefinition
+	InputType          string
 	StringValue        string
 	BoolValue          bool
 	FormLabel          string
@@ -36,26 +42,39 @@ type pkgConfigInputInput struct {
 	ContainerId        string
 	ValueReference     v1alpha1.ValueReference
 	ValueReferenceKind string
+	ValueTemplate      string
 	ValueError         error
 	Autofocus          bool
 	DatalistOptions    *PkgConfigInputDatalistOptions
 	PackageHref        string
+	// ClusterDefaultKey is the key of the cluster default that is used as default value, if it is set in the cluster
+	ClusterDefaultKey string
+	// IsOverridden is true if the value is configured with a reference or a value that differs from the default
+	IsOverridden bool
+	// Layer is the layer that the effective value of the installed package comes from
+	Layer manifestvalues.ValueLayer
+	// HasOverlays is true if the installed package has overlays, so that the input shows the base layer
+	HasOverlays bool
+	// Environment is the environment of the cluster, whose overlay is applied to the package
+	Environment string
+	// OverlayValue describes the effective value if Layer is the overlay layer
+	OverlayValue string
 }
 
-func getStringValue(pkg ctrlpkg.Package, valueName string, valueDefinition *v1alpha1.ValueDefinition) string {
-	if !pkg.IsNil() {
-		if valueConfiguration, ok := pkg.GetSpec().Values[valueName]; ok {
-			if valueConfiguration.Value != nil {
-				return *valueConfiguration.Value
-			}
+func getStringValue(
+	values map[string]v1alpha1.ValueConfiguration, valueName string, valueDefinition *v1alpha1.ValueDefinition) string {
+	if valueConfiguration, ok := values[valueName]; ok {
+		if valueConfiguration.Value != nil {
+			return *valueConfiguration.Value
 		}
 	}
 	return valueDefinition.DefaultValue
 }
 
-func getBoolValue(pkg ctrlpkg.Package, valueName string, valueDefinition *v1alpha1.ValueDefinition) bool {
+func getBoolValue(
+	values map[string]v1alpha1.ValueConfiguration, valueName string, valueDefinition *v1alpha1.ValueDefinition) bool {
 	if valueDefinition.Type == v1alpha1.ValueTypeBoolean {
-		strVal := getStringValue(pkg, valueName, valueDefinition)
+		strVal := getStringValue(values, valueName, valueDefinition)
 		if valBool, err := strconv.ParseBool(strVal); err == nil {
 			return valBool
 		}
@@ -63,6 +82,24 @@ func getBoolValue(pkg ctrlpkg.Package, valueName string, valueDefinition *v1alph
 	return false
 }
 
+// isOverridden returns true if the input does not show the default value of the value definition
+func isOverridden(
+	values map[string]v1alpha1.ValueConfiguration,
+	valueName string,
+	valueDefinition *v1alpha1.ValueDefinition,
+	valueReferenceKind string,
+) bool {
+	if valueReferenceKind != "" {
+		return true
+	} else if _, ok := values[valueName]; !ok {
+		return false
+	} else if valueDefinition.Type == v1alpha1.ValueTypeBoolean {
+		defaultBool, _ := strconv.ParseBool(valueDefinition.DefaultValue)
+		return getBoolValue(values, valueName, valueDefinition) != defaultBool
+	}
+	return getStringValue(values, valueName, valueDefinition) != valueDefinition.DefaultValue
+}
+
 func getLabel(valueName string, valueDefinition *v1alpha1.ValueDefinition) string {
 	inputLabel := valueName
 	if valueDefinition.Metadata.Label != "" {
@@ -71,26 +108,48 @@ func getLabel(valueName string, valueDefinition *v1alpha1.ValueDefinition) strin
 	return inputLabel
 }
 
-func getExistingReferenceAndKind(pkg ctrlpkg.Package, valueName string) (*v1alpha1.ValueReference, string) {
-	if !pkg.IsNil() {
-		if val, ok := pkg.GetSpec().Values[valueName]; ok {
-			if val.Value == nil && val.ValueFrom != nil {
-				if val.ValueFrom.ConfigMapRef != nil {
-					return val.ValueFrom, "ConfigMap"
-				} else if val.ValueFrom.SecretRef != nil {
-					return val.ValueFrom, "Secret"
-				} else if val.ValueFrom.PackageRef != nil {
-					return val.ValueFrom, "Package"
-				}
+// getInputType returns the type of the html input for text values, so that browsers can validate the format
+func getInputType(valueDefinition *v1alpha1.ValueDefinition) string {
+	if valueDefinition.Constraints.Format != nil {
+		switch *valueDefinition.Constraints.Format {
+		case v1alpha1.ValueFormatEmail:
+			return "email"
+		case v1alpha1.ValueFormatURI:
+			return "url"
+		}
+	}
+	return "text"
+}
+
+func getExistingReferenceAndKind(
+	values map[string]v1alpha1.ValueConfiguration, valueName string) (*v1alpha1.ValueReference, string) {
+	if val, ok := values[valueName]; ok {
+		if val.Value == nil && val.Template != nil {
+			return nil, "Template"
+		} else if val.Value == nil && val.ValueFrom != nil {
+			if val.ValueFrom.ConfigMapRef != nil {
+				return val.ValueFrom, "ConfigMap"
+			} else if val.ValueFrom.SecretRef != nil {
+				return val.ValueFrom, "Secret"
+			} else if val.ValueFrom.PackageRef != nil {
+				return val.ValueFrom, "Package"
 			}
 		}
 	}
 	return nil, ""
 }
 
+func getValueTemplate(values map[string]v1alpha1.ValueConfiguration, valueName string) string {
+	if val, ok := values[valueName]; ok && val.Template != nil {
+		return *val.Template
+	}
+	return ""
+}
+
 func getOrCreateReference(
-	pkg ctrlpkg.Package, valueName string, desiredRefKind *string) (v1alpha1.ValueReference, string) {
-	existingReference, existingRefKind := getExistingReferenceAndKind(pkg, valueName)
+	values map[string]v1alpha1.ValueConfiguration, valueName string, desiredRefKind *string,
+) (v1alpha1.ValueReference, string) {
+	existingReference, existingRefKind := getExistingReferenceAndKind(values, valueName)
 	if desiredRefKind != nil && *desiredRefKind != existingRefKind {
 		return v1alpha1.ValueReference{}, *desiredRefKind
 	} else if existingReference != nil {
@@ -114,7 +173,17 @@ func ForPkgConfigInput(
 	if options == nil {
 		options = &PkgConfigInputRenderOptions{}
 	}
-	valueReference, valueReferenceKind := getOrCreateReference(pkg, valueName, options.DesiredRefKind)
+	values := options.Values
+	if values == nil && !pkg.IsNil() {
+		values = pkg.GetSpec().Values
+	}
+	valueReference, valueReferenceKind := getOrCreateReference(values, valueName, options.DesiredRefKind)
+	var clusterDefaultKey string
+	if valueDefinition.ClusterDefault != "" && options.ClusterDefaults[valueDefinition.ClusterDefault] != "" {
+		clusterDefaultKey = valueDefinition.ClusterDefault
+	}
+	valueDefinition.DefaultValue = manifestvalues.DefaultValue(valueDefinition, options.ClusterDefaults)
+	layer, environment, overlayValue := getLayer(pkg, values, options, valueName)
 	return &pkgConfigInputInput{
 		RepositoryName:     repositoryName,
 		SelectedVersion:    selectedVersion,
@@ -122,16 +191,47 @@ func ForPkgConfigInput(
 		ValueName:          valueName,
 		FormValueName:      fmt.Sprintf("values.%v", valueName),
 		ValueDefinition:    valueDefinition,
-		StringValue:        getStringValue(pkg, valueName, &valueDefinition),
-		BoolValue:          getBoolValue(pkg, valueName, &valueDefinition),
+		InputType:          getInputType(&valueDefinition),
+		StringValue:        getStringValue(values, valueName, &valueDefinition),
+		BoolValue:          getBoolValue(values, valueName, &valueDefinition),
 		FormLabel:          getLabel(valueName, &valueDefinition),
 		FormId:             fmt.Sprintf("input-%v", valueName),
 		ContainerId:        fmt.Sprintf("input-container-%v", valueName),
 		ValueReference:     valueReference,
 		ValueReferenceKind: valueReferenceKind,
+		ValueTemplate:      getValueTemplate(values, valueName),
 		ValueError:         valueError,
 		Autofocus:          options.Autofocus,
 		DatalistOptions:    datalistOptions,
 		PackageHref:        util.GetPackageHrefWithFallback(pkg, manifest),
+		ClusterDefaultKey:  clusterDefaultKey,
+		IsOverridden:       isOverridden(values, valueName, &valueDefinition, valueReferenceKind),
+		Layer:              layer,
+		HasOverlays:        !pkg.IsNil() && len(pkg.GetSpec().Overlays) > 0,
+		Environment:        environment,
+		OverlayValue:       overlayValue,
+	}
+}
+
+// getLayer returns the layer of the effective value of an installed package. The inputs always show the base values,
+// which are changed by the form, so values from the overlay of the current environment are only described.
+func getLayer(
+	pkg ctrlpkg.Package,
+	values map[string]v1alpha1.ValueConfiguration,
+	options *PkgConfigInputRenderOptions,
+	valueName string,
+) (layer manifestvalues.ValueLayer, environment string, overlayValue string) {
+	environment = options.ClusterDefaults[manifestvalues.EnvironmentKey]
+	var overlay map[string]v1alpha1.ValueConfiguration
+	if options.Values == nil && !pkg.IsNil() {
+		overlay = pkg.GetSpec().Overlays[environment]
+	}
+	effective, layers := manifestvalues.MergeOverlay(values, overlay)
+	if layer, ok := layers[valueName]; !ok {
+		return manifestvalues.LayerDefault, environment, ""
+	} else if layer == manifestvalues.LayerOverlay {
+		return layer, environment, manifestvalues.ValueAsString(effective[valueName])
+	} else {
+		return layer, environment, ""
 	}
 }
@@ -22,6 +22,8 @@ type pkgDetailBtnsInput struct {
 	Pkg             ctrlpkg.Package
 	PackageHref     string
 	GitopsMode      bool
+	// ReadOnly disables all actions that modify the package
+	ReadOnly bool
 }
 
 func getId(pkgName string) string {
@@ -35,6 +37,7 @@ func ForPkgDetailBtns(
 	pkg ctrlpkg.Package,
 	updateAvailable bool,
 	gitopsMode bool,
+	readOnly bool,
 ) *pkgDetailBtnsInput {
 	id := getId(pkgName)
 	return &pkgDetailBtnsInput{
@@ -46,5 +49,6 @@ func ForPkgDetailBtns(
 		Pkg:             pkg,
 		PackageHref:     util.GetPackageHref(pkg, manifest),
 		GitopsMode:      gitopsMode,
+		ReadOnly:        readOnly,
 	}
 }
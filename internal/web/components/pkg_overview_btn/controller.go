@@ -20,13 +20,23 @@ type clpkgOverviewBtnInput struct {
 	UpdateAvailable bool
 	InDeletion      bool
 	PackageHref     string
+	Favorite        *favoriteBtnInput
+}
+
+type favoriteBtnInput struct {
+	PackageName string
+	Favorite    bool
 }
 
 func getButtonId(pkgName string) string {
 	return fmt.Sprintf("%v-%v", templateId, pkgName)
 }
 
-func ForClPkgOverviewBtn(packageWithStatus *list.PackageWithStatus, updateAvailable bool) *clpkgOverviewBtnInput {
+func ForClPkgOverviewBtn(
+	packageWithStatus *list.PackageWithStatus,
+	updateAvailable bool,
+	favorite bool,
+) *clpkgOverviewBtnInput {
 	buttonId := getButtonId(packageWithStatus.Name)
 	inDeletion := false
 	if packageWithStatus.ClusterPackage != nil {
@@ -40,5 +50,28 @@ func ForClPkgOverviewBtn(packageWithStatus *list.PackageWithStatus, updateAvaila
 		UpdateAvailable: updateAvailable,
 		InDeletion:      inDeletion,
 		PackageHref:     util.GetClusterPkgHref(packageWithStatus.Name),
+		Favorite:        ForFavoriteBtn(packageWithStatus.Name, favorite),
 	}
 }
+
+func ForFavoriteBtn(pkgName string, favorite bool) *favoriteBtnInput {
+	return &favoriteBtnInput{PackageName: pkgName, Favorite: favorite}
+}
+
+type repoBadgesInput struct {
+	PackageHref string
+	// Origins are the repositories the installed instances of the package were installed from
+	Origins []string
+	// Repos are the repositories the package is available in
+	Repos []string
+}
+
+// Ambiguous is true if the package is not installed and available in more than one repository, so the user has to pick
+// the repository to install it from
+func (input *repoBadgesInput) Ambiguous() bool {
+	return len(input.Origins) == 0 && len(input.Repos) > 1
+}
+
+func ForRepoBadges(packageHref string, repos []string, origins []string) *repoBadgesInput {
+	return &repoBadgesInput{PackageHref: packageHref, Repos: repos, Origins: origins}
+}
@@ -1,15 +1,38 @@
 package pkg_update_alert
 
+import "github.com/glasskube/glasskube/internal/autoupdate"
+
 const TemplateId = "pkg-update-alert"
 
 type pkgUpdateAlertInput struct {
 	UpdatesAvailable bool
 	PackageHref      string
+	UpdateAllScope   string
+	GitopsMode       bool
+	ReadOnly         bool
+	// AutoUpdatesSuspended is true if automatic updates are suspended globally
+	AutoUpdatesSuspended bool
+	// AutoUpdateWindow is the maintenance window in which automatic updates are applied, if it is configured
+	AutoUpdateWindow *autoupdate.MaintenanceWindow
+	// UpdateCount is the number of packages for which an update is available. It may be zero even if UpdatesAvailable
+	// is true, because updating all packages at once can require updates that are not possible individually.
+	UpdateCount int
 }
 
 func ForPkgUpdateAlert(data map[string]any) *pkgUpdateAlertInput {
+	gitopsMode, _ := data["GitopsMode"].(bool)
+	readOnly, _ := data["ReadOnly"].(bool)
+	freeze, _ := data["AutoUpdateFreeze"].(*autoupdate.Freeze)
+	window, _ := data["AutoUpdateWindow"].(*autoupdate.MaintenanceWindow)
+	updateCount, _ := data["UpdateCount"].(int)
 	return &pkgUpdateAlertInput{
-		UpdatesAvailable: data["UpdatesAvailable"].(bool),
-		PackageHref:      data["PackageHref"].(string),
+		UpdatesAvailable:     data["UpdatesAvailable"].(bool),
+		PackageHref:          data["PackageHref"].(string),
+		UpdateAllScope:       data["UpdateAllScope"].(string),
+		GitopsMode:           gitopsMode,
+		ReadOnly:             readOnly,
+		AutoUpdatesSuspended: freeze.IsActive(),
+		AutoUpdateWindow:     window,
+		UpdateCount:          updateCount,
 	}
 }
@@ -1,9 +1,14 @@
 package toast
 
+import "time"
+
 type ToastInput struct {
 	Message     string
 	Dismissible bool
 	Severity    severity
+	// Duration is the time after which the toast is dismissed automatically. If it is zero, the toast is shown until
+	// it is dismissed by the user.
+	Duration time.Duration
 }
 
 func ForToast(err error, severity severity, dismissible bool) ToastInput {
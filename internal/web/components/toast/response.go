@@ -2,12 +2,19 @@ package toast
 
 import (
 	"net/http"
+	"time"
 )
 
+// DefaultDuration is the time after which success and info toasts are dismissed, unless another duration is given
+const DefaultDuration = 5 * time.Second
+
 type Response struct {
 	ToastInput
 	StatusCode int
 	Err        error
+	// duration is the requested time until the toast is dismissed automatically. Zero selects the default of the
+	// severity, a negative value keeps the toast until it is dismissed by the user.
+	duration time.Duration
 }
 
 type ResponseOption func(options *Response)
@@ -52,9 +59,25 @@ func WithDismissible(dismissible bool) ResponseOption {
 	}
 }
 
+// WithDuration dismisses the toast automatically after the given duration, regardless of its severity
+func WithDuration(duration time.Duration) ResponseOption {
+	return func(options *Response) {
+		options.duration = duration
+	}
+}
+
+// WithPersistence keeps the toast until it is dismissed by the user, regardless of its severity
+func WithPersistence() ResponseOption {
+	return func(options *Response) {
+		options.duration = -1
+	}
+}
+
 // Apply sets some reasonable defaults: if only an error is given, status code will be 500 and css class will be danger.
 // If no error is given, status 200 OK and the success class are assumed, and the given Message will be used.
 // However, all parts (Message, status code, class) can be set individually too.
+// Success and info toasts are dismissed after DefaultDuration, while warnings and errors are kept until they are
+// dismissed by the user, so that they can not be missed. Toasts that are kept are always dismissible.
 func (r *Response) Apply() {
 	if r.Err != nil {
 		if r.Message == "" {
@@ -76,4 +99,16 @@ func (r *Response) Apply() {
 			r.Severity = Success
 		}
 	}
+
+	switch {
+	case r.duration > 0:
+		r.Duration = r.duration
+	case r.duration < 0:
+		r.Duration = 0
+	case r.Severity == Success || r.Severity == Info:
+		r.Duration = DefaultDuration
+	}
+	if r.Duration == 0 {
+		r.Dismissible = true
+	}
 }
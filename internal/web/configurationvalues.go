@@ -9,11 +9,14 @@ import (
 	"strconv"
 	"strings"
 
+	deputil "github.com/glasskube/glasskube/internal/dependency/util"
+	repoerror "github.com/glasskube/glasskube/internal/repo/error"
 	"github.com/glasskube/glasskube/internal/web/components/toast"
 	"github.com/glasskube/glasskube/internal/web/util"
 
 	"github.com/glasskube/glasskube/pkg/manifest"
 
+	"github.com/glasskube/glasskube/internal/manifestvalues"
 	"github.com/glasskube/glasskube/internal/maputils"
 	"github.com/glasskube/glasskube/internal/web/components/pkg_config_input"
 	"github.com/glasskube/glasskube/pkg/describe"
@@ -32,10 +35,14 @@ const (
 	keyKey           = "key"
 	packageKey       = "package"
 	valueKey         = "value"
+	templateKey      = "template"
 	refKindKey       = "refKind"
 	refKindConfigMap = "ConfigMap"
 	refKindSecret    = "Secret"
 	refKindPackage   = "Package"
+	refKindTemplate  = "Template"
+
+	optionalDependenciesKey = "optionalDependencies"
 )
 
 func formKey(valueName string, key string) string {
@@ -68,6 +75,12 @@ func extractValues(r *http.Request, manifest *v1alpha1.PackageManifest) (map[str
 					PackageRef: extractPackageValueSource(r, valueName),
 				},
 			}
+		} else if refKindVal == refKindTemplate {
+			tmpl := r.Form.Get(formKey(valueName, templateKey))
+			if err := manifestvalues.ValidateTemplate(tmpl); err != nil {
+				return nil, fmt.Errorf("value %v is invalid: %w", valueName, err)
+			}
+			values[valueName] = v1alpha1.ValueConfiguration{Template: &tmpl}
 		} else if refKindVal == "" {
 			formVal := r.Form.Get(fmt.Sprintf("%v.%v", formValuePrefix, valueName))
 			if valueDef.Type == v1alpha1.ValueTypeBoolean {
@@ -189,17 +202,70 @@ func (s *server) handleConfigurationInput(w http.ResponseWriter, r *http.Request
 				options.Names = opts
 			}
 		}
+		clusterDefaults, err := s.valueResolver.ClusterDefaults(r.Context())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to get cluster defaults: %v\n", err)
+		}
+		renderOptions := pkg_config_input.PkgConfigInputRenderOptions{
+			Autofocus:       true,
+			DesiredRefKind:  &refKind,
+			ClusterDefaults: clusterDefaults,
+		}
+		if r.URL.Query().Get("reset") == "true" {
+			// without any values, the input shows the default value of the value definition
+			renderOptions.Values = map[string]v1alpha1.ValueConfiguration{}
+		}
 		input := pkg_config_input.ForPkgConfigInput(
 			d.pkg, d.request.repositoryName, d.request.version, d.manifest, valueName, valueDefinition, nil, &options,
-			&pkg_config_input.PkgConfigInputRenderOptions{
-				Autofocus:      true,
-				DesiredRefKind: &refKind,
-			})
-		err := s.templates.pkgConfigInput.Execute(w, input)
+			&renderOptions)
+		err = s.templatesFor(r).pkgConfigInput.Execute(w, input)
 		util.CheckTmplError(err, fmt.Sprintf("package config input (%s, %s)", d.request.manifestName, valueName))
 	}
 }
 
+// configurationValidation is a GET endpoint, which validates the form value of the value with the given valueName
+// against the constraints of its value definition. It returns the html snippet showing the validation error, which is
+// empty if the value is valid.
+func (s *server) configurationValidation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	manifestName := mux.Vars(r)["manifestName"]
+	if manifestName == "" {
+		manifestName = mux.Vars(r)["pkgName"]
+	}
+	valueName := mux.Vars(r)["valueName"]
+	pkg, err := s.getInstalledPackageForRequest(ctx, r)
+	if err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to fetch package %v: %w", manifestName, err)))
+		return
+	}
+	repositoryName, version := r.FormValue("repositoryName"), r.FormValue("version")
+	if !pkg.IsNil() {
+		if repositoryName == "" {
+			repositoryName = pkg.GetSpec().PackageInfo.RepositoryName
+		}
+		if version == "" {
+			version = pkg.GetSpec().PackageInfo.Version
+		}
+	}
+	mf, err := s.resolveManifest(ctx, pkg, repositoryName, manifestName, version)
+	if repoerror.IsPartial(err) {
+		fmt.Fprintf(os.Stderr, "problem fetching manifest and repo, but value can be validated: %v\n", err)
+	} else if err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to get manifest of %v: %w", manifestName, err)))
+		return
+	}
+
+	var valueErr error
+	if def, ok := mf.ValueDefinitions[valueName]; ok {
+		valueErr = manifestvalues.ValidateSingle(valueName, def, r.FormValue(formValuePrefix+"."+valueName))
+	}
+	err = s.templatesFor(r).pkgConfigInput.ExecuteTemplate(w, "pkg-config-input-value-error", map[string]any{
+		"ValueName":  valueName,
+		"ValueError": valueErr,
+	})
+	util.CheckTmplError(err, fmt.Sprintf("package config validation (%s, %s)", manifestName, valueName))
+}
+
 // namesDatalist is a GET endpoint returning an html datalist, containing options depending on the given valueName,
 // kind of reference and namespace. It is only usable for ConfigMap and Secret refs, since packages don't have a
 // namespace. In case the refKind is ConfigMap, the datalist contains the config maps of the given namespace; in case
@@ -225,7 +291,7 @@ func (s *server) namesDatalist(w http.ResponseWriter, r *http.Request) {
 			options = opts
 		}
 	}
-	tmplErr := s.templates.datalistTmpl.Execute(w, map[string]any{
+	tmplErr := s.templatesFor(r).datalistTmpl.Execute(w, map[string]any{
 		"Options": options,
 		"Id":      id,
 	})
@@ -256,7 +322,7 @@ func (s *server) keysDatalist(w http.ResponseWriter, r *http.Request) {
 			fmt.Fprintf(os.Stderr, "Failed to get package value options of %v: %v\n", pkg, err)
 		}
 	}
-	tmplErr := s.templates.datalistTmpl.Execute(w, map[string]any{
+	tmplErr := s.templatesFor(r).datalistTmpl.Execute(w, map[string]any{
 		"Options": options,
 		"Id":      r.FormValue("id"),
 	})
@@ -385,3 +451,15 @@ func sortedNames[T metav1.Object](objects []T) []string {
 	slices.Sort(names)
 	return names
 }
+
+// extractOptionalDependencies returns the optional dependencies of the manifest that are checked in the form
+func extractOptionalDependencies(r *http.Request, manifest *v1alpha1.PackageManifest) []string {
+	var result []string
+	optional := deputil.OptionalDependencies(manifest)
+	for _, name := range r.Form[optionalDependenciesKey] {
+		if slices.Contains(optional, name) && !slices.Contains(result, name) {
+			result = append(result, name)
+		}
+	}
+	return result
+}
@@ -30,3 +30,88 @@ func setAdvancedOptionsCookie(w http.ResponseWriter, advancedOptionsEnabled bool
 	}
 	http.SetCookie(w, &cookie)
 }
+
+const themeKey = "theme"
+
+const (
+	themeLight = "light"
+	themeDark  = "dark"
+	// themeAuto means that no explicit choice has been made and the theme follows prefers-color-scheme
+	themeAuto = ""
+)
+
+func isValidTheme(theme string) bool {
+	return theme == themeLight || theme == themeDark || theme == themeAuto
+}
+
+func getThemeFromCookie(r *http.Request) string {
+	if c, err := r.Cookie(themeKey); err == nil && isValidTheme(c.Value) {
+		return c.Value
+	}
+	return themeAuto
+}
+
+func setThemeCookie(w http.ResponseWriter, theme string) {
+	cookie := http.Cookie{
+		Name:     themeKey,
+		Value:    theme,
+		MaxAge:   60 * 60 * 24 * 365,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	}
+	if theme == themeAuto {
+		cookie.MaxAge = -1
+	}
+	http.SetCookie(w, &cookie)
+}
+
+const codeStyleKey = "codeStyle"
+
+func getCodeStyleFromCookie(r *http.Request) string {
+	if c, err := r.Cookie(codeStyleKey); err == nil && isValidCodeStyle(c.Value) {
+		return c.Value
+	}
+	return ""
+}
+
+func setCodeStyleCookie(w http.ResponseWriter, codeStyle string) {
+	cookie := http.Cookie{
+		Name:     codeStyleKey,
+		Value:    codeStyle,
+		MaxAge:   60 * 60 * 24 * 365,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	}
+	if codeStyle == "" {
+		cookie.MaxAge = -1
+	}
+	http.SetCookie(w, &cookie)
+}
+
+const localeKey = "locale"
+
+// getLocaleFromCookie returns the locale chosen in the settings. An empty string means that the locale is selected
+// according to the Accept-Language header.
+func getLocaleFromCookie(r *http.Request) string {
+	if c, err := r.Cookie(localeKey); err == nil {
+		return c.Value
+	}
+	return ""
+}
+
+func setLocaleCookie(w http.ResponseWriter, locale string) {
+	cookie := http.Cookie{
+		Name:     localeKey,
+		Value:    locale,
+		MaxAge:   60 * 60 * 24 * 365,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	}
+	if locale == "" {
+		cookie.MaxAge = -1
+	}
+	http.SetCookie(w, &cookie)
+}
@@ -0,0 +1,132 @@
+package web
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	csrfCookieKey = "csrfToken"
+	// csrfHeader is sent by htmx with every request originating from a page, see the hx-headers of the base layout
+	csrfHeader = "X-CSRF-Token"
+	// csrfFormKey is used by plain HTML forms, which can not send custom headers
+	csrfFormKey = "csrf_token"
+	// csrfTokenMaxAge is the age after which a token is replaced on the next full page load
+	csrfTokenMaxAge = 12 * time.Hour
+)
+
+var errCSRFTokenMismatch = errors.New("your session has expired or the request was not sent by Glasskube. " +
+	"Please reload the page and try again")
+
+type csrfTokenContextKey struct{}
+
+// csrfMiddleware protects all requests that may change something against cross-site request forgery, using the
+// double submit cookie pattern: Every browser session gets a random token in a session cookie, which is embedded in
+// every page and sent back with each request. Requests with a method other than GET, HEAD or OPTIONS are rejected
+// with 403 unless the token they carry matches the cookie. Safe requests, like the SSE connection and the JSON API,
+// are never rejected.
+//
+// Tokens are rotated on full page loads once they are older than csrfTokenMaxAge. Other tabs with a page that was
+// loaded before must be reloaded then.
+func (s *server) csrfMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, issuedAt, ok := csrfTokenFromCookie(r)
+		if isSafeMethod(r.Method) {
+			if !ok || (isFullPageLoad(r) && time.Since(issuedAt) > csrfTokenMaxAge) {
+				token = newCSRFToken(time.Now())
+				setCSRFCookie(w, token)
+			}
+		} else if !ok || !csrfTokensEqual(token, csrfTokenFromRequest(r)) {
+			s.sendError(w, r, http.StatusForbidden, apiErrorForbidden, errCSRFTokenMismatch)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), csrfTokenContextKey{}, token)))
+	})
+}
+
+// csrfTokenFromContext returns the token of the current session, so that it can be embedded in a page
+func csrfTokenFromContext(r *http.Request) string {
+	if token, ok := r.Context().Value(csrfTokenContextKey{}).(string); ok {
+		return token
+	}
+	return ""
+}
+
+// csrfTokenFromRequest returns the token that was submitted with a request, either as header or as form value
+func csrfTokenFromRequest(r *http.Request) string {
+	if token := r.Header.Get(csrfHeader); token != "" {
+		return token
+	}
+	return r.FormValue(csrfFormKey)
+}
+
+func csrfTokenFromCookie(r *http.Request) (string, time.Time, bool) {
+	if c, err := r.Cookie(csrfCookieKey); err == nil {
+		if issuedAt, ok := parseCSRFToken(c.Value); ok {
+			return c.Value, issuedAt, true
+		}
+	}
+	return "", time.Time{}, false
+}
+
+func setCSRFCookie(w http.ResponseWriter, token string) {
+	// no MaxAge, so that the cookie is discarded when the browser session ends
+	cookie := http.Cookie{
+		Name:     csrfCookieKey,
+		Value:    token,
+		Path:     "/",
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	}
+	http.SetCookie(w, &cookie)
+}
+
+func deleteCSRFCookie(w http.ResponseWriter) {
+	cookie := http.Cookie{
+		Name:     csrfCookieKey,
+		Path:     "/",
+		MaxAge:   -1,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	}
+	http.SetCookie(w, &cookie)
+}
+
+// newCSRFToken returns a random token, prefixed with the time it was issued at
+func newCSRFToken(now time.Time) string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return fmt.Sprintf("%v.%v", now.Unix(), base64.RawURLEncoding.EncodeToString(b))
+}
+
+func parseCSRFToken(token string) (time.Time, bool) {
+	if issuedAt, random, ok := strings.Cut(token, "."); !ok || random == "" {
+		return time.Time{}, false
+	} else if unix, err := strconv.ParseInt(issuedAt, 10, 64); err != nil {
+		return time.Time{}, false
+	} else {
+		return time.Unix(unix, 0), true
+	}
+}
+
+func csrfTokensEqual(expected, actual string) bool {
+	return expected != "" && subtle.ConstantTimeCompare([]byte(expected), []byte(actual)) == 1
+}
+
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+func isFullPageLoad(r *http.Request) bool {
+	return r.Header.Get("Hx-Request") != "true" && strings.Contains(r.Header.Get("Accept"), "text/html")
+}
@@ -0,0 +1,56 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CSRF", func() {
+	It("should issue tokens with the time they were issued at", func() {
+		now := time.Unix(1700000000, 0)
+		token := newCSRFToken(now)
+		Expect(token).NotTo(Equal(newCSRFToken(now)))
+		issuedAt, ok := parseCSRFToken(token)
+		Expect(ok).To(BeTrue())
+		Expect(issuedAt).To(Equal(now))
+	})
+
+	DescribeTable("parseCSRFToken",
+		func(token string, valid bool) {
+			_, ok := parseCSRFToken(token)
+			Expect(ok).To(Equal(valid))
+		},
+		Entry("Empty", "", false),
+		Entry("No timestamp", "abc", false),
+		Entry("Invalid timestamp", "abc.def", false),
+		Entry("No random part", "1700000000.", false),
+		Entry("Valid", "1700000000.abc", true),
+	)
+
+	DescribeTable("csrfTokensEqual",
+		func(expected, actual string, result bool) {
+			Expect(csrfTokensEqual(expected, actual)).To(Equal(result))
+		},
+		Entry("Same token", "1.abc", "1.abc", true),
+		Entry("Different token", "1.abc", "1.abd", false),
+		Entry("Missing token", "1.abc", "", false),
+		Entry("No expected token", "", "", false),
+	)
+
+	It("should read the token from the header or form", func() {
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		r.Header.Set(csrfHeader, "1.header")
+		Expect(csrfTokenFromRequest(r)).To(Equal("1.header"))
+
+		form := url.Values{csrfFormKey: {"1.form"}}
+		r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		Expect(csrfTokenFromRequest(r)).To(Equal("1.form"))
+	})
+})
@@ -0,0 +1,278 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/glasskube/glasskube/internal/web/sse"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const dashboardPath = "/metrics/dashboard.json"
+
+// grafanaDashboardModel is the subset of the Grafana dashboard JSON model that is needed for the glasskube dashboard
+type grafanaDashboardModel struct {
+	Title         string              `json:"title"`
+	UID           string              `json:"uid"`
+	Description   string              `json:"description"`
+	Tags          []string            `json:"tags"`
+	SchemaVersion int                 `json:"schemaVersion"`
+	Version       int                 `json:"version"`
+	Editable      bool                `json:"editable"`
+	Refresh       string              `json:"refresh"`
+	Time          grafanaTimeRange    `json:"time"`
+	Templating    grafanaTemplating   `json:"templating"`
+	Panels        []grafanaPanelModel `json:"panels"`
+}
+
+type grafanaTimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type grafanaTemplating struct {
+	List []grafanaVariable `json:"list"`
+}
+
+type grafanaVariable struct {
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	Type  string `json:"type"`
+	Query string `json:"query"`
+}
+
+type grafanaDatasource struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+type grafanaPanelModel struct {
+	ID          int                 `json:"id"`
+	Type        string              `json:"type"`
+	Title       string              `json:"title"`
+	Description string              `json:"description,omitempty"`
+	GridPos     grafanaGridPos      `json:"gridPos"`
+	Datasource  *grafanaDatasource  `json:"datasource,omitempty"`
+	Targets     []grafanaTarget     `json:"targets,omitempty"`
+	FieldConfig *grafanaFieldConfig `json:"fieldConfig,omitempty"`
+}
+
+type grafanaGridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type grafanaTarget struct {
+	RefID        string `json:"refId"`
+	Expr         string `json:"expr"`
+	LegendFormat string `json:"legendFormat,omitempty"`
+}
+
+type grafanaFieldConfig struct {
+	Defaults grafanaFieldDefaults `json:"defaults"`
+}
+
+type grafanaFieldDefaults struct {
+	Unit string   `json:"unit,omitempty"`
+	Min  *float64 `json:"min,omitempty"`
+	Max  *float64 `json:"max,omitempty"`
+}
+
+// dashboardSection is a row of panels of the glasskube dashboard
+type dashboardSection struct {
+	title  string
+	panels []dashboardPanel
+}
+
+// dashboardQuery is a PromQL expression of a panel, together with the legend of its series
+type dashboardQuery struct {
+	legend string
+	expr   string
+}
+
+// dashboardPanel is a panel of the glasskube dashboard
+type dashboardPanel struct {
+	title       string
+	description string
+	panelType   string
+	unit        string
+	// percent panels have a fixed range from 0 to 1
+	percent bool
+	queries []dashboardQuery
+}
+
+// metricName returns the fully qualified name of a metric of the web server. Metrics without a subsystem are
+// evaluated from the state of the cluster, see clusterStateCollector.
+func metricName(subsystem, name string) string {
+	return prometheus.BuildFQName(metricsNamespace, subsystem, name)
+}
+
+// dashboardSections returns the sections of the glasskube dashboard. All queries are built from the names of the
+// metrics of the web server, so that renaming a metric also updates the dashboard.
+func dashboardSections() []dashboardSection {
+	packagesByStatus := metricName("", packagesByStatusMetric)
+	packageOperations := metricName(metricsSubsystem, packageOperationsMetric)
+	repositoryReady := metricName("", repositoryReadyMetric)
+	repositoryFetchDuration := metricName(metricsSubsystem, repositoryFetchDurationMetric)
+	packagesOutdated := metricName("", packagesOutdatedMetric)
+	packagesInstalled := metricName("", packagesInstalledMetric)
+	return []dashboardSection{
+		{title: "Installations", panels: []dashboardPanel{
+			{
+				title:       "Install success rate",
+				description: "Share of installed packages that are ready",
+				panelType:   "stat",
+				unit:        "percentunit",
+				percent:     true,
+				queries: []dashboardQuery{{"", fmt.Sprintf(`sum(%v{status="Ready"}) / sum(%v)`,
+					packagesByStatus, packagesByStatus)}},
+			},
+			{
+				title:       "Packages by status",
+				description: "Number of installed packages, by status",
+				panelType:   "timeseries",
+				queries:     []dashboardQuery{{"{{status}}", fmt.Sprintf(`sum by (status) (%v)`, packagesByStatus)}},
+			},
+			{
+				title:       "Package operations",
+				description: "Package operations performed via the UI per second",
+				panelType:   "timeseries",
+				unit:        "ops",
+				queries: []dashboardQuery{{"{{operation}}",
+					fmt.Sprintf(`sum by (operation) (rate(%v[$__rate_interval]))`, packageOperations)}},
+			},
+		}},
+		{title: "Repositories", panels: []dashboardPanel{
+			{
+				title:       "Repository sync health",
+				description: "Whether the last sync of every repository succeeded (1) or not (0)",
+				panelType:   "timeseries",
+				queries:     []dashboardQuery{{"{{repository}}", repositoryReady}},
+			},
+			{
+				title:       "Last sync duration",
+				description: "Duration of the last sync of every repository by the operator",
+				panelType:   "timeseries",
+				unit:        "s",
+				queries: []dashboardQuery{
+					{"{{repository}}", metricName("", repositoryLastSyncDurationMetric)},
+				},
+			},
+			{
+				title:       "Repository fetch duration (p95)",
+				description: "95th percentile of the duration of fetching indices and manifests, including cache hits",
+				panelType:   "timeseries",
+				unit:        "s",
+				queries: []dashboardQuery{{"{{repository}} {{resource}}", fmt.Sprintf(
+					`histogram_quantile(0.95, sum by (le, repository, resource) (rate(%v_bucket[$__rate_interval])))`,
+					repositoryFetchDuration)}},
+			},
+		}},
+		{title: "Clients", panels: []dashboardPanel{
+			{
+				title:       "Connected clients",
+				description: "Number of clients connected to the server sent events and websocket endpoints",
+				panelType:   "timeseries",
+				queries: []dashboardQuery{
+					{"server sent events", metricName(metricsSubsystem, sse.ConnectedClientsMetric)},
+					{"websocket", metricName(metricsSubsystem, sse.WebsocketConnectedClientsMetric)},
+				},
+			},
+			{
+				title:       "Rate limited requests",
+				description: "Requests rejected by the rate limiting per second",
+				panelType:   "timeseries",
+				unit:        "reqps",
+				queries: []dashboardQuery{{"{{limit}}", fmt.Sprintf(`sum by (limit) (rate(%v[$__rate_interval]))`,
+					metricName(metricsSubsystem, rateLimitedRequestsMetric))}},
+			},
+		}},
+		{title: "Updates", panels: []dashboardPanel{
+			{
+				title:       "Outdated packages",
+				description: "Share of installed packages whose version is older than the latest version in their repository",
+				panelType:   "stat",
+				unit:        "percentunit",
+				percent:     true,
+				queries:     []dashboardQuery{{"", fmt.Sprintf(`sum(%v) / sum(%v)`, packagesOutdated, packagesInstalled)}},
+			},
+			{
+				title:       "Update lag",
+				description: "Number of installed packages with a newer version in their repository",
+				panelType:   "timeseries",
+				queries:     []dashboardQuery{{"{{scope}}", fmt.Sprintf(`sum by (scope) (%v)`, packagesOutdated)}},
+			},
+		}},
+	}
+}
+
+// grafanaDashboard returns a Grafana dashboard for the metrics of the web server. It uses a data source variable, so
+// it can be imported into any Grafana instance with a Prometheus data source that scrapes the /metrics endpoint.
+func grafanaDashboard() grafanaDashboardModel {
+	const width = 24
+	datasource := &grafanaDatasource{Type: "prometheus", UID: "${datasource}"}
+	dashboard := grafanaDashboardModel{
+		Title:         "Glasskube",
+		UID:           "glasskube",
+		Description:   "Packages, repositories and clients of the Glasskube web server",
+		Tags:          []string{"glasskube"},
+		SchemaVersion: 39,
+		Version:       1,
+		Editable:      true,
+		Refresh:       "30s",
+		Time:          grafanaTimeRange{From: "now-6h", To: "now"},
+		Templating: grafanaTemplating{List: []grafanaVariable{
+			{Name: "datasource", Label: "Data source", Type: "datasource", Query: "prometheus"},
+		}},
+	}
+	y := 0
+	for _, section := range dashboardSections() {
+		dashboard.Panels = append(dashboard.Panels, grafanaPanelModel{
+			ID:      len(dashboard.Panels) + 1,
+			Type:    "row",
+			Title:   section.title,
+			GridPos: grafanaGridPos{H: 1, W: width, X: 0, Y: y},
+		})
+		y++
+		panelWidth := width / len(section.panels)
+		for i, panel := range section.panels {
+			model := grafanaPanelModel{
+				ID:          len(dashboard.Panels) + 1,
+				Type:        panel.panelType,
+				Title:       panel.title,
+				Description: panel.description,
+				GridPos:     grafanaGridPos{H: 8, W: panelWidth, X: i * panelWidth, Y: y},
+				Datasource:  datasource,
+				FieldConfig: &grafanaFieldConfig{Defaults: grafanaFieldDefaults{Unit: panel.unit}},
+			}
+			if panel.percent {
+				minValue, maxValue := 0.0, 1.0
+				model.FieldConfig.Defaults.Min = &minValue
+				model.FieldConfig.Defaults.Max = &maxValue
+			}
+			for j, query := range panel.queries {
+				model.Targets = append(model.Targets, grafanaTarget{
+					RefID:        string(rune('A' + j)),
+					Expr:         query.expr,
+					LegendFormat: query.legend,
+				})
+			}
+			dashboard.Panels = append(dashboard.Panels, model)
+		}
+		y += 8
+	}
+	return dashboard
+}
+
+// grafanaDashboardHandler serves the Grafana dashboard for the metrics of the web server as JSON, ready for import
+func grafanaDashboardHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(grafanaDashboard()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
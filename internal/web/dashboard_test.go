@@ -0,0 +1,69 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+
+	"github.com/glasskube/glasskube/internal/web/sse"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var _ = Describe("Grafana dashboard", func() {
+	// registeredMetrics returns the names of all metrics that are described by the collectors of the server
+	registeredMetrics := func() map[string]struct{} {
+		s := &server{metrics: newMetrics(), broadcaster: sse.NewBroadcaster()}
+		s.registerMetrics()
+		fqName := regexp.MustCompile(`fqName: "([^"]+)"`)
+		names := make(map[string]struct{})
+		for _, collector := range s.metrics.collectors {
+			ch := make(chan *prometheus.Desc)
+			go func() {
+				collector.Describe(ch)
+				close(ch)
+			}()
+			for desc := range ch {
+				if match := fqName.FindStringSubmatch(desc.String()); match != nil {
+					names[match[1]] = struct{}{}
+				}
+			}
+		}
+		return names
+	}
+
+	It("should only query registered metrics", func() {
+		names := registeredMetrics()
+		metric := regexp.MustCompile(metricsNamespace + `_[a-z_]+`)
+		for _, panel := range grafanaDashboard().Panels {
+			for _, target := range panel.Targets {
+				for _, name := range metric.FindAllString(target.Expr, -1) {
+					Expect(names).To(HaveKey(strings.TrimSuffix(name, "_bucket")), "panel %v", panel.Title)
+				}
+			}
+		}
+	})
+
+	It("should have unique panel ids and queries for every panel", func() {
+		ids := make(map[int]struct{})
+		for _, panel := range grafanaDashboard().Panels {
+			Expect(ids).NotTo(HaveKey(panel.ID))
+			ids[panel.ID] = struct{}{}
+			if panel.Type != "row" {
+				Expect(panel.Targets).NotTo(BeEmpty(), "panel %v", panel.Title)
+			}
+		}
+	})
+
+	It("should be served as JSON", func() {
+		w := httptest.NewRecorder()
+		grafanaDashboardHandler(w, httptest.NewRequest("GET", dashboardPath, nil))
+		Expect(w.Header().Get("Content-Type")).To(Equal("application/json"))
+		var dashboard map[string]any
+		Expect(json.Unmarshal(w.Body.Bytes(), &dashboard)).To(Succeed())
+		Expect(dashboard).To(HaveKeyWithValue("uid", "glasskube"))
+		Expect(dashboard).To(HaveKey("panels"))
+	})
+})
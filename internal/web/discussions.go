@@ -33,6 +33,9 @@ func (s *server) packageDiscussion(w http.ResponseWriter, r *http.Request) {
 	namespace := mux.Vars(r)["namespace"]
 	name := mux.Vars(r)["name"]
 	repositoryName := mux.Vars(r)["repositoryName"]
+	if s.redirectDiscussion(w, r, manifestName) {
+		return
+	}
 	pkg, manifest, err := describe.DescribeInstalledPackage(r.Context(), namespace, name)
 	if err != nil && !errors.IsNotFound(err) {
 		s.sendToast(w,
@@ -58,6 +61,9 @@ func (s *server) clusterPackageDiscussion(w http.ResponseWriter, r *http.Request
 	}
 	pkgName := mux.Vars(r)["pkgName"]
 	repositoryName := mux.Vars(r)["repositoryName"]
+	if s.redirectDiscussion(w, r, pkgName) {
+		return
+	}
 	pkg, manifest, err := describe.DescribeInstalledClusterPackage(r.Context(), pkgName)
 	if err != nil && !errors.IsNotFound(err) {
 		s.sendToast(w,
@@ -75,6 +81,19 @@ func (s *server) clusterPackageDiscussion(w http.ResponseWriter, r *http.Request
 	})
 }
 
+// redirectDiscussion handles requests to the built-in discussion page, if discussions are disabled or held on an
+// external page. It returns true if the request was handled.
+func (s *server) redirectDiscussion(w http.ResponseWriter, r *http.Request, pkgName string) bool {
+	if !s.DiscussionsEnabled() {
+		http.NotFound(w, r)
+		return true
+	} else if s.ExternalDiscussions() {
+		http.Redirect(w, r, s.ExternalDiscussionHref(pkgName), http.StatusFound)
+		return true
+	}
+	return false
+}
+
 func (s *server) handleGiscus(r *http.Request) {
 	githubUrl := r.FormValue("githubUrl")
 	telemetry.SetUserProperty("github_url", githubUrl)
@@ -105,7 +124,7 @@ func (s *server) handlePackageDiscussionPage(w http.ResponseWriter, r *http.Requ
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to check whether auto updater is installed: %v\n", err)
 	}
-	err = s.templates.pkgDiscussionPageTmpl.Execute(w, s.enrichPage(r, map[string]any{
+	err = s.executePage(w, s.templatesFor(r).pkgDiscussionPageTmpl, "discussion", s.enrichPage(r, map[string]any{
 		"Giscus":               giscus.Client().Config,
 		"Package":              d.pkg,
 		"Status":               client.GetStatusOrPending(d.pkg),
@@ -125,6 +144,11 @@ func (s *server) discussionBadge(w http.ResponseWriter, r *http.Request) {
 	if pkgName == "" {
 		pkgName = mux.Vars(r)["manifestName"]
 	}
+	if !s.DiscussionsEnabled() || s.ExternalDiscussions() {
+		// there are no counts for external discussions
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
 
 	var totalCount int
 	if counts, err := giscus.Client().GetCountsFor(pkgName); err != nil {
@@ -136,7 +160,7 @@ func (s *server) discussionBadge(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var err error
-	err = s.templates.pkgDiscussionBadgeTmpl.Execute(w, s.enrichPage(r, map[string]any{
+	err = s.templatesFor(r).pkgDiscussionBadgeTmpl.Execute(w, s.enrichPage(r, map[string]any{
 		"TotalCount": totalCount,
 	}, err))
 	util.CheckTmplError(err, fmt.Sprintf("discussion-badge (%s)", pkgName))
@@ -0,0 +1,154 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	repotypes "github.com/glasskube/glasskube/internal/repo/types"
+	"github.com/glasskube/glasskube/internal/web/components/toast"
+	"github.com/glasskube/glasskube/pkg/list"
+	"github.com/gorilla/mux"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	favoritesKey = "favorites"
+	// maxFavorites keeps the favorites cookie well below the size limit of browsers
+	maxFavorites = 100
+	// favoritesChangedEvent is triggered on the client after the favorites have changed, so that the overview pages
+	// can refresh themselves
+	favoritesChangedEvent = "favorites-changed"
+)
+
+// getFavoritesFromCookie returns the names of the packages that the current user has marked as favorite
+func getFavoritesFromCookie(r *http.Request) []string {
+	if c, err := r.Cookie(favoritesKey); err == nil {
+		return parseFavorites(c.Value)
+	}
+	return nil
+}
+
+func setFavoritesCookie(w http.ResponseWriter, favorites []string) {
+	cookie := http.Cookie{
+		Name:     favoritesKey,
+		Value:    strings.Join(favorites, ","),
+		Path:     "/",
+		MaxAge:   60 * 60 * 24 * 365,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	}
+	if len(favorites) == 0 {
+		cookie.MaxAge = -1
+	}
+	http.SetCookie(w, &cookie)
+}
+
+// parseFavorites parses a list of package names, separated by commas or whitespace (e.g. one per line, as in an
+// exported list). Invalid names are ignored and the result is sorted and free of duplicates.
+func parseFavorites(value string) []string {
+	favorites := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\r' || r == '\n'
+	})
+	favorites = slices.DeleteFunc(favorites, func(name string) bool {
+		return len(validation.IsDNS1123Subdomain(name)) > 0
+	})
+	slices.Sort(favorites)
+	favorites = slices.Compact(favorites)
+	if len(favorites) > maxFavorites {
+		favorites = favorites[:maxFavorites]
+	}
+	return favorites
+}
+
+// favoritesSet converts the favorites into a map that can be used in templates, e.g. {{ index $.Favorites .Name }}
+func favoritesSet(favorites []string) map[string]bool {
+	set := make(map[string]bool, len(favorites))
+	for _, name := range favorites {
+		set[name] = true
+	}
+	return set
+}
+
+// toggleFavorite adds the package to the favorites of the current user, or removes it if it already is a favorite
+func (s *server) toggleFavorite(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	pkgName := mux.Vars(r)["pkgName"]
+	favorites := getFavoritesFromCookie(r)
+	if i := slices.Index(favorites, pkgName); i >= 0 {
+		favorites = slices.Delete(favorites, i, i+1)
+	} else if len(favorites) >= maxFavorites {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("you can not have more than %v favorites", maxFavorites)),
+			toast.WithStatusCode(http.StatusBadRequest))
+		return
+	} else if errs := validation.IsDNS1123Subdomain(pkgName); len(errs) > 0 {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("invalid package name %v", pkgName)),
+			toast.WithStatusCode(http.StatusBadRequest))
+		return
+	} else {
+		favorites = append(favorites, pkgName)
+		slices.Sort(favorites)
+	}
+	setFavoritesCookie(w, favorites)
+	w.Header().Set("Hx-Trigger", favoritesChangedEvent)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// exportFavorites downloads the favorites of the current user as text file with one package name per line, which can
+// be shared with others and imported on the settings page
+func (s *server) exportFavorites(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="glasskube-favorites.txt"`)
+	for _, name := range getFavoritesFromCookie(r) {
+		fmt.Fprintln(w, name)
+	}
+}
+
+// importFavorites replaces the favorites of the current user with the given list of package names
+func (s *server) importFavorites(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+	favorites := parseFavorites(r.PostForm.Get("favorites"))
+	setFavoritesCookie(w, favorites)
+	w.Header().Set("Hx-Trigger", favoritesChangedEvent)
+	s.sendToast(w, toast.WithMessage(fmt.Sprintf("%v favorites saved", len(favorites))))
+}
+
+// sortFavoritesFirst moves the favorites of the user to the top of the overview, keeping the order otherwise
+func (overview *packagesOverview) sortFavoritesFirst(favorites map[string]bool) {
+	slices.SortStableFunc(overview.installed, func(a, b *list.PackagesWithStatus) int {
+		return compareFavorites(favorites, a.Name, b.Name)
+	})
+	slices.SortStableFunc(overview.available, func(a, b *repotypes.PackageRepoIndexItem) int {
+		return compareFavorites(favorites, a.Name, b.Name)
+	})
+}
+
+// sortFavoritesFirst moves the favorites of the user to the top of the overview, keeping the order otherwise
+func (overview *clusterPackagesOverview) sortFavoritesFirst(favorites map[string]bool) {
+	slices.SortStableFunc(overview.clusterPackages, func(a, b *list.PackageWithStatus) int {
+		return compareFavorites(favorites, a.Name, b.Name)
+	})
+}
+
+func compareFavorites(favorites map[string]bool, a, b string) int {
+	switch {
+	case favorites[a] == favorites[b]:
+		return 0
+	case favorites[a]:
+		return -1
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,38 @@
+package web
+
+import (
+	repotypes "github.com/glasskube/glasskube/internal/repo/types"
+	"github.com/glasskube/glasskube/pkg/list"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Favorites", func() {
+	DescribeTable("parseFavorites",
+		func(value string, expected []string) {
+			Expect(parseFavorites(value)).To(Equal(expected))
+		},
+		Entry("Empty", "", []string{}),
+		Entry("Comma separated", "b,a", []string{"a", "b"}),
+		Entry("One per line", "cert-manager\r\nkube-prometheus-stack\n", []string{"cert-manager", "kube-prometheus-stack"}),
+		Entry("Duplicates", "a, a,b", []string{"a", "b"}),
+		Entry("Invalid names", "a,Not_Valid,<script>", []string{"a"}),
+	)
+
+	It("should sort favorites first and keep the order otherwise", func() {
+		item := func(name string) *repotypes.PackageRepoIndexItem {
+			return &repotypes.PackageRepoIndexItem{Name: name}
+		}
+		installed := func(name string) *list.PackagesWithStatus {
+			return &list.PackagesWithStatus{MetaIndexItem: repotypes.MetaIndexItem{PackageRepoIndexItem: *item(name)}}
+		}
+		overview := packagesOverview{
+			installed: []*list.PackagesWithStatus{installed("a"), installed("b"), installed("c")},
+			available: []*repotypes.PackageRepoIndexItem{item("d"), item("e"), item("f"), item("g")},
+		}
+		overview.sortFavoritesFirst(favoritesSet([]string{"c", "e", "g"}))
+		Expect(overview.installed).To(Equal([]*list.PackagesWithStatus{installed("c"), installed("a"), installed("b")}))
+		Expect(overview.available).To(Equal([]*repotypes.PackageRepoIndexItem{item("e"), item("g"), item("d"), item("f")}))
+	})
+})
@@ -0,0 +1,96 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/glasskube/glasskube/internal/repo/types"
+)
+
+const (
+	subsystemKubeClient = "kubeClient"
+	subsystemCaches     = "caches"
+	subsystemRepoSync   = "repoSync"
+	subsystemTemplates  = "templates"
+)
+
+type subsystemStatus struct {
+	Ready   bool   `json:"ready"`
+	Message string `json:"message,omitempty"`
+}
+
+type healthResponse struct {
+	Status     string                     `json:"status"`
+	Subsystems map[string]subsystemStatus `json:"subsystems,omitempty"`
+}
+
+// healthz is the liveness probe. It only checks that the server is able to handle requests.
+func (s *server) healthz(w http.ResponseWriter, r *http.Request) {
+	writeHealthResponse(w, http.StatusOK, healthResponse{Status: "ok"})
+}
+
+// readyz is the readiness probe. The server is ready once it is connected to a bootstrapped cluster, the informer
+// caches have synced and at least one repository index has been fetched successfully.
+func (s *server) readyz(w http.ResponseWriter, r *http.Request) {
+	subsystems := map[string]subsystemStatus{
+		subsystemTemplates: s.templatesStatus(),
+	}
+	if err := s.ensureBootstrapped(r.Context()); err != nil {
+		subsystems[subsystemKubeClient] = subsystemStatus{Message: err.Error()}
+	} else {
+		subsystems[subsystemKubeClient] = s.kubeClientStatus()
+		subsystems[subsystemCaches] = s.cachesStatus()
+		subsystems[subsystemRepoSync] = s.repoSyncStatus()
+	}
+
+	response := healthResponse{Status: "ready", Subsystems: subsystems}
+	status := http.StatusOK
+	for _, subsystem := range []string{subsystemKubeClient, subsystemCaches, subsystemRepoSync, subsystemTemplates} {
+		if !subsystems[subsystem].Ready {
+			response.Status = "not ready"
+			status = http.StatusServiceUnavailable
+			break
+		}
+	}
+	writeHealthResponse(w, status, response)
+}
+
+func (s *server) templatesStatus() subsystemStatus {
+	if s.templates.baseTemplate == nil {
+		return subsystemStatus{Message: "templates have not been parsed"}
+	}
+	return subsystemStatus{Ready: true}
+}
+
+func (s *server) kubeClientStatus() subsystemStatus {
+	if _, err := s.k8sClient.Discovery().ServerVersion(); err != nil {
+		return subsystemStatus{Message: err.Error()}
+	}
+	return subsystemStatus{Ready: true}
+}
+
+func (s *server) cachesStatus() subsystemStatus {
+	if len(s.cacheControllers) == 0 || !s.allControllersInitiallySynced(s.cacheControllers...) {
+		return subsystemStatus{Message: "caches have not synced yet"}
+	}
+	return subsystemStatus{Ready: true}
+}
+
+// repoSyncStatus reports whether a repository index has been fetched successfully. If this has not happened yet, the
+// index of the default repository is fetched once, so the server does not depend on user interaction to become ready.
+func (s *server) repoSyncStatus() subsystemStatus {
+	if !s.repoSynced.Load() {
+		var index types.PackageRepoIndex
+		if err := s.repoClientset.Default().FetchPackageRepoIndex(&index); err != nil {
+			return subsystemStatus{Message: err.Error()}
+		}
+	}
+	return subsystemStatus{Ready: true}
+}
+
+func writeHealthResponse(w http.ResponseWriter, status int, response healthResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(response)
+}
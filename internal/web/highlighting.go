@@ -0,0 +1,78 @@
+package web
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/styles"
+)
+
+const (
+	// defaultLightCodeStyle and defaultDarkCodeStyle are used if no code style has been chosen explicitly
+	defaultLightCodeStyle = "github"
+	defaultDarkCodeStyle  = "github-dark"
+)
+
+// highlightedLanguages contains the languages of fenced code blocks that get syntax highlighting.
+// Code blocks in any other language are rendered as plain text.
+var highlightedLanguages = []string{
+	"bash", "console", "css", "diff", "dockerfile", "go", "hcl", "html", "ini", "java", "javascript", "js",
+	"json", "markdown", "md", "python", "py", "sh", "shell", "sql", "terraform", "toml", "ts", "typescript",
+	"xml", "yaml", "yml", "zsh",
+}
+
+// codeStyles contains the chroma styles that can be chosen on the settings page
+var codeStyles = []string{
+	"dracula", "github", "github-dark", "monokai", "nord", "onedark", "solarized-dark", "solarized-light", "vs",
+}
+
+func isHighlightedLanguage(language string) bool {
+	return slices.Contains(highlightedLanguages, strings.ToLower(language))
+}
+
+func isValidCodeStyle(codeStyle string) bool {
+	return codeStyle == "" || slices.Contains(codeStyles, codeStyle)
+}
+
+// syntaxHighlightingCss serves the stylesheet for highlighted code blocks. If no explicit style is requested, the
+// default styles for the light and dark theme are used.
+func (s *server) syntaxHighlightingCss(w http.ResponseWriter, r *http.Request) {
+	codeStyle := r.URL.Query().Get("style")
+	if !isValidCodeStyle(codeStyle) {
+		http.Error(w, fmt.Sprintf("invalid code style: %v", codeStyle), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "text/css; charset=utf-8")
+	w.Header().Set("Cache-Control", "max-age=86400")
+	var err error
+	if codeStyle != "" {
+		err = writeCodeStyleCss(w, codeStyle)
+	} else {
+		err = writeThemedCodeStyleCss(w, "light", defaultLightCodeStyle)
+		if err == nil {
+			err = writeThemedCodeStyleCss(w, "dark", defaultDarkCodeStyle)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(w, "/* failed to write code style: %v */\n", err)
+	}
+}
+
+func writeThemedCodeStyleCss(w io.Writer, theme string, codeStyle string) error {
+	if _, err := fmt.Fprintf(w, "[data-bs-theme=%v] {\n", theme); err != nil {
+		return err
+	}
+	if err := writeCodeStyleCss(w, codeStyle); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
+
+func writeCodeStyleCss(w io.Writer, codeStyle string) error {
+	return chromahtml.New(chromahtml.WithClasses(true)).WriteCSS(w, styles.Get(codeStyle))
+}
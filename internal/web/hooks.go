@@ -0,0 +1,39 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// getHookLogs returns the most recent log lines of the last pod of the Job of a hook, so that users can find out why
+// it failed without access to the cluster
+func (s *server) getHookLogs(ctx context.Context, hook *v1alpha1.HookStatus) (string, error) {
+	selector := labels.SelectorFromSet(labels.Set{batchv1.JobNameLabel: hook.JobName})
+	pods, err := s.k8sClient.CoreV1().Pods(hook.JobNamespace).
+		List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return "", fmt.Errorf("failed to list pods of Job %v: %w", hook.JobName, err)
+	} else if len(pods.Items) == 0 {
+		return "", fmt.Errorf("the Job %v has no pods", hook.JobName)
+	}
+	pod := slices.MaxFunc(pods.Items, func(a, b corev1.Pod) int {
+		return a.CreationTimestamp.Compare(b.CreationTimestamp.Time)
+	})
+	tailLines := int64(workloadLogTailLines)
+	options := corev1.PodLogOptions{TailLines: &tailLines}
+	if len(pod.Spec.Containers) > 0 {
+		options.Container = pod.Spec.Containers[0].Name
+	}
+	logs, err := s.k8sClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &options).DoRaw(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get logs of pod %v: %w", pod.Name, err)
+	}
+	return string(logs), nil
+}
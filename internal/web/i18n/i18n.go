@@ -0,0 +1,202 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultLocale is used if no other locale matches the preferences of the user. Messages that are missing in another
+// locale are taken from the default locale.
+const DefaultLocale = "en"
+
+// message is either a plain text or a set of texts for the plural forms of a count. Message files contain plural
+// messages as mapping with the CLDR plural categories (zero, one, two, few, many, other) as keys.
+type message struct {
+	text   string
+	plural map[plural.Form]string
+}
+
+var pluralForms = map[string]plural.Form{
+	"zero":  plural.Zero,
+	"one":   plural.One,
+	"two":   plural.Two,
+	"few":   plural.Few,
+	"many":  plural.Many,
+	"other": plural.Other,
+}
+
+func (m *message) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &m.text); err == nil {
+		return nil
+	}
+	var forms map[string]string
+	if err := json.Unmarshal(data, &forms); err != nil {
+		return fmt.Errorf("message must be a string or a mapping of plural forms")
+	}
+	if _, ok := forms["other"]; !ok {
+		return fmt.Errorf("plural message must contain the form \"other\"")
+	}
+	m.plural = make(map[plural.Form]string, len(forms))
+	for name, text := range forms {
+		if form, ok := pluralForms[name]; !ok {
+			return fmt.Errorf("unknown plural form %q", name)
+		} else {
+			m.plural[form] = text
+		}
+	}
+	return nil
+}
+
+// Catalog contains the messages of a single locale
+type Catalog struct {
+	locale   string
+	tag      language.Tag
+	messages map[string]message
+	// fallback is used for messages that are missing in this catalog. It is nil for the default locale.
+	fallback *Catalog
+}
+
+// Locale returns the name of the locale, which is also a valid BCP 47 language tag
+func (c *Catalog) Locale() string {
+	return c.locale
+}
+
+// T returns the message with the given key. If args are given, the message is used as format string for them. If the
+// message does not exist in this catalog nor in the fallback, the key itself is returned.
+func (c *Catalog) T(key string, args ...any) string {
+	if msg, ok := c.messages[key]; ok {
+		text := msg.text
+		if msg.plural != nil {
+			text = msg.plural[plural.Other]
+		}
+		return format(text, args)
+	} else if c.fallback != nil {
+		return c.fallback.T(key, args...)
+	}
+	return key
+}
+
+// TN returns the plural form of the message with the given key that matches count in the language of this catalog.
+// count is always the first argument of the format string, followed by args.
+func (c *Catalog) TN(key string, count int, args ...any) string {
+	msg, ok := c.messages[key]
+	if !ok {
+		if c.fallback != nil {
+			return c.fallback.TN(key, count, args...)
+		}
+		return key
+	}
+	args = append([]any{count}, args...)
+	if msg.plural == nil {
+		return format(msg.text, args)
+	}
+	text, ok := msg.plural[plural.Cardinal.MatchPlural(c.tag, count, 0, 0, 0, 0)]
+	if !ok {
+		text = msg.plural[plural.Other]
+	}
+	return format(text, args)
+}
+
+func format(text string, args []any) string {
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// Bundle contains the catalogs of all supported locales
+type Bundle struct {
+	catalogs map[string]*Catalog
+	// locales are the names of all catalogs, starting with DefaultLocale
+	locales []string
+	matcher language.Matcher
+}
+
+// Load reads all message files named <locale>.yaml in dir. A message file for DefaultLocale must exist.
+func Load(fsys fs.FS, dir string) (*Bundle, error) {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	bundle := Bundle{catalogs: make(map[string]*Catalog, len(files))}
+	for _, file := range files {
+		locale := strings.TrimSuffix(path.Base(file), ".yaml")
+		tag, err := language.Parse(locale)
+		if err != nil {
+			return nil, fmt.Errorf("invalid locale of message file %v: %w", file, err)
+		}
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		catalog := Catalog{locale: locale, tag: tag}
+		if err := yaml.Unmarshal(data, &catalog.messages); err != nil {
+			return nil, fmt.Errorf("invalid message file %v: %w", file, err)
+		}
+		bundle.catalogs[locale] = &catalog
+		bundle.locales = append(bundle.locales, locale)
+	}
+	defaultCatalog, ok := bundle.catalogs[DefaultLocale]
+	if !ok {
+		return nil, fmt.Errorf("message file for default locale %v not found in %v", DefaultLocale, dir)
+	}
+	for _, catalog := range bundle.catalogs {
+		if catalog != defaultCatalog {
+			catalog.fallback = defaultCatalog
+		}
+	}
+	slices.SortFunc(bundle.locales, func(a, b string) int {
+		if a == DefaultLocale {
+			return -1
+		} else if b == DefaultLocale {
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+	tags := make([]language.Tag, len(bundle.locales))
+	for i, locale := range bundle.locales {
+		tags[i] = bundle.catalogs[locale].tag
+	}
+	bundle.matcher = language.NewMatcher(tags)
+	return &bundle, nil
+}
+
+// Locales returns the names of all locales, starting with DefaultLocale
+func (b *Bundle) Locales() []string {
+	return b.locales
+}
+
+// Catalog returns the catalog of the given locale or of DefaultLocale, if the locale is not supported
+func (b *Bundle) Catalog(locale string) *Catalog {
+	if catalog, ok := b.catalogs[locale]; ok {
+		return catalog
+	}
+	return b.catalogs[DefaultLocale]
+}
+
+// Match returns the supported locale that fits the given preferences best. Every preference can be a single language
+// tag or the value of an Accept-Language header. Preferences are considered in order, empty or invalid ones are
+// skipped. If none of them matches, DefaultLocale is returned.
+func (b *Bundle) Match(preferences ...string) string {
+	for _, preference := range preferences {
+		if preference == "" {
+			continue
+		}
+		tags, _, err := language.ParseAcceptLanguage(preference)
+		if err != nil || len(tags) == 0 {
+			continue
+		}
+		if _, index, confidence := b.matcher.Match(tags...); confidence != language.No {
+			return b.locales[index]
+		}
+	}
+	return DefaultLocale
+}
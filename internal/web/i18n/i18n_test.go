@@ -0,0 +1,85 @@
+package i18n
+
+import (
+	"testing/fstest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Bundle", func() {
+	var bundle *Bundle
+
+	BeforeEach(func() {
+		var err error
+		bundle, err = Load(fstest.MapFS{
+			"locales/en.yaml": {Data: []byte(`
+greeting: Hello %v
+packages:
+  one: "%d package"
+  other: "%d packages"
+only.english: Only English
+`)},
+			"locales/de.yaml": {Data: []byte(`
+greeting: Hallo %v
+packages:
+  one: "%d Paket"
+  other: "%d Pakete"
+`)},
+			"locales/pl.yaml": {Data: []byte(`
+packages:
+  one: "%d pakiet"
+  few: "%d pakiety"
+  other: "%d pakietów"
+`)},
+		}, "locales")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should list the default locale first", func() {
+		Expect(bundle.Locales()).To(Equal([]string{"en", "de", "pl"}))
+	})
+
+	DescribeTable("Match",
+		func(preferences []string, expected string) {
+			Expect(bundle.Match(preferences...)).To(Equal(expected))
+		},
+		Entry("No preference", nil, "en"),
+		Entry("Accept-Language", []string{"", "fr;q=0.9, de-AT;q=0.8"}, "de"),
+		Entry("Preference before Accept-Language", []string{"pl", "de"}, "pl"),
+		Entry("Invalid preference", []string{"???", "de"}, "de"),
+		Entry("Unsupported", []string{"fr"}, "en"),
+	)
+
+	It("should translate messages", func() {
+		Expect(bundle.Catalog("de").T("greeting", "Welt")).To(Equal("Hallo Welt"))
+		Expect(bundle.Catalog("fr").T("greeting", "World")).To(Equal("Hello World"))
+	})
+
+	It("should fall back to english and the key", func() {
+		Expect(bundle.Catalog("de").T("only.english")).To(Equal("Only English"))
+		Expect(bundle.Catalog("de").T("missing")).To(Equal("missing"))
+		Expect(bundle.Catalog("de").TN("missing", 2)).To(Equal("missing"))
+	})
+
+	DescribeTable("TN",
+		func(locale string, count int, expected string) {
+			Expect(bundle.Catalog(locale).TN("packages", count)).To(Equal(expected))
+		},
+		Entry("en one", "en", 1, "1 package"),
+		Entry("en other", "en", 0, "0 packages"),
+		Entry("de other", "de", 2, "2 Pakete"),
+		Entry("pl few", "pl", 3, "3 pakiety"),
+		Entry("pl many falls back to other", "pl", 5, "5 pakietów"),
+	)
+
+	It("should reject message files without default locale", func() {
+		_, err := Load(fstest.MapFS{"locales/de.yaml": {Data: []byte("a: b")}}, "locales")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject plural messages without other form", func() {
+		_, err := Load(fstest.MapFS{"locales/en.yaml": {Data: []byte("a:\n  one: b")}}, "locales")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,44 @@
+package web
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/glasskube/glasskube/internal/web/i18n"
+)
+
+type language struct {
+	Locale string
+	// Name is the name of the language in the language itself
+	Name string
+}
+
+// requestLocale returns the locale of the messages shown for the request. The locale chosen in the settings takes
+// precedence over the Accept-Language header.
+func (s *server) requestLocale(r *http.Request) string {
+	if s.templates.messages == nil {
+		return i18n.DefaultLocale
+	}
+	return s.templates.messages.Match(getLocaleFromCookie(r), r.Header.Get("Accept-Language"))
+}
+
+// templatesFor returns the templates with the messages of the locale of the request
+func (s *server) templatesFor(r *http.Request) *parsedTemplates {
+	return s.templates.forLocale(s.requestLocale(r))
+}
+
+// isValidLocale returns true if locale is supported or empty, which resets the choice
+func (s *server) isValidLocale(locale string) bool {
+	return locale == "" || s.templates.messages != nil && slices.Contains(s.templates.messages.Locales(), locale)
+}
+
+func (s *server) languages() []language {
+	if s.templates.messages == nil {
+		return nil
+	}
+	result := make([]language, 0, len(s.templates.messages.Locales()))
+	for _, locale := range s.templates.messages.Locales() {
+		result = append(result, language{Locale: locale, Name: s.templates.messages.Catalog(locale).T("language.name")})
+	}
+	return result
+}
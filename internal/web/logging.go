@@ -0,0 +1,150 @@
+package web
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/gorilla/mux"
+	"k8s.io/klog/v2"
+)
+
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+
+	requestIdHeader = "X-Request-Id"
+	// pageRequestIdHeader is sent by htmx with every request originating from a page. It contains the request ID of
+	// the request that initially loaded the page, so that partial refreshes can be correlated with the page load.
+	pageRequestIdHeader = "X-Page-Request-Id"
+)
+
+type requestIdContextKey struct{}
+
+func newLogger(format string, level int) (*slog.Logger, error) {
+	opts := slog.HandlerOptions{Level: slog.LevelInfo}
+	if level > 0 {
+		opts.Level = slog.LevelDebug
+	}
+	switch format {
+	case LogFormatJSON:
+		return slog.New(slog.NewJSONHandler(os.Stderr, &opts)), nil
+	case LogFormatText, "":
+		return slog.New(slog.NewTextHandler(os.Stderr, &opts)), nil
+	default:
+		return nil, fmt.Errorf("unsupported log format: %v", format)
+	}
+}
+
+// loggingMiddleware logs one line per request. Requests that are triggered by htmx partial refreshes (e.g. after an
+// SSE event) and metrics scrapes are only logged on debug level, to not spam the log with a line per refresh event.
+func (s *server) loggingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		requestId := newRequestId()
+		w.Header().Set(requestIdHeader, requestId)
+		r = r.WithContext(context.WithValue(r.Context(), requestIdContextKey{}, requestId))
+		sw := &statusRecordingResponseWriter{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(sw, r)
+
+		attrs := []any{
+			slog.String("request_id", requestId),
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", sw.status),
+			slog.Int64("duration_ms", time.Since(start).Milliseconds()),
+		}
+		if pageRequestId := r.Header.Get(pageRequestIdHeader); pageRequestId != "" {
+			attrs = append(attrs, slog.String("page_request_id", pageRequestId))
+		}
+		if pkgName := packageNameFromRequest(r); pkgName != "" {
+			attrs = append(attrs, slog.String("package", pkgName))
+		}
+		level := slog.LevelInfo
+		if isPartialRefresh(r) || r.URL.Path == "/metrics" {
+			level = slog.LevelDebug
+		}
+		s.logger.Log(r.Context(), level, "handled request", attrs...)
+	})
+}
+
+func packageNameFromRequest(r *http.Request) string {
+	vars := mux.Vars(r)
+	if pkgName := vars["pkgName"]; pkgName != "" {
+		return pkgName
+	}
+	return vars["manifestName"]
+}
+
+func isPartialRefresh(r *http.Request) bool {
+	return r.Method == http.MethodGet && r.Header.Get("Hx-Request") == "true" && r.Header.Get("Hx-Boosted") != "true"
+}
+
+func requestIdFromRequest(r *http.Request) string {
+	if requestId, ok := r.Context().Value(requestIdContextKey{}).(string); ok {
+		return requestId
+	}
+	return ""
+}
+
+func newRequestId() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// initKlog makes sure that log lines of client-go and friends use the same format as the web server
+func initKlog(logger *slog.Logger, format string) {
+	if format == LogFormatJSON {
+		klog.SetSlogLogger(logger)
+	}
+}
+
+type statusRecordingResponseWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (w *statusRecordingResponseWriter) WriteHeader(statusCode int) {
+	if !w.wroteHeader {
+		w.status = statusCode
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *statusRecordingResponseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush implements http.Flusher, which is required for server sent events
+func (w *statusRecordingResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker, which is required for websocket connections
+func (w *statusRecordingResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if hijacker, ok := w.ResponseWriter.(http.Hijacker); ok {
+		w.wroteHeader = true
+		w.status = http.StatusSwitchingProtocols
+		return hijacker.Hijack()
+	}
+	return nil, nil, errors.New("hijacking is not supported")
+}
+
+func (w *statusRecordingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,95 @@
+package web
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"html/template"
+	"net/url"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const defaultMarkdownCacheSize = 256
+
+// markdownCache is a LRU cache for rendered markdown. Entries are keyed by a hash of the source and the base URL that
+// relative references are resolved against. Because the base URL contains the version of the package, entries of an
+// outdated manifest version are no longer hit and are eventually evicted.
+type markdownCache struct {
+	mutex    sync.Mutex
+	size     int
+	entries  map[markdownCacheKey]*list.Element
+	order    *list.List
+	requests *prometheus.CounterVec
+}
+
+type markdownCacheKey [sha256.Size]byte
+
+type markdownCacheEntry struct {
+	key  markdownCacheKey
+	html template.HTML
+}
+
+func newMarkdownCache(size int) *markdownCache {
+	if size <= 0 {
+		size = defaultMarkdownCacheSize
+	}
+	return &markdownCache{
+		size:    size,
+		entries: make(map[markdownCacheKey]*list.Element, size),
+		order:   list.New(),
+	}
+}
+
+func newMarkdownCacheKey(source string, baseUrl *url.URL) markdownCacheKey {
+	var base string
+	if baseUrl != nil {
+		base = baseUrl.String()
+	}
+	return sha256.Sum256([]byte(base + "\x00" + source))
+}
+
+func (c *markdownCache) get(key markdownCacheKey) (template.HTML, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	elem, ok := c.entries[key]
+	c.observe(ok)
+	if !ok {
+		return "", false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*markdownCacheEntry).html, true
+}
+
+func (c *markdownCache) add(key markdownCacheKey, html template.HTML) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*markdownCacheEntry).html = html
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&markdownCacheEntry{key: key, html: html})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*markdownCacheEntry).key)
+	}
+}
+
+func (c *markdownCache) len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.order.Len()
+}
+
+func (c *markdownCache) observe(hit bool) {
+	if c.requests == nil {
+		return
+	}
+	if hit {
+		c.requests.WithLabelValues("hit").Inc()
+	} else {
+		c.requests.WithLabelValues("miss").Inc()
+	}
+}
@@ -0,0 +1,34 @@
+package web
+
+import (
+	"html/template"
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Markdown Cache", func() {
+	It("should evict the least recently used entry", func() {
+		cache := newMarkdownCache(2)
+		a, b, c := newMarkdownCacheKey("a", nil), newMarkdownCacheKey("b", nil), newMarkdownCacheKey("c", nil)
+		cache.add(a, template.HTML("<p>a</p>"))
+		cache.add(b, template.HTML("<p>b</p>"))
+		_, ok := cache.get(a)
+		Expect(ok).To(BeTrue())
+		cache.add(c, template.HTML("<p>c</p>"))
+		Expect(cache.len()).To(Equal(2))
+		_, ok = cache.get(b)
+		Expect(ok).To(BeFalse())
+		html, ok := cache.get(a)
+		Expect(ok).To(BeTrue())
+		Expect(html).To(Equal(template.HTML("<p>a</p>")))
+	})
+
+	It("should use different keys for different base URLs", func() {
+		v1, _ := url.Parse("https://example.com/packages/foo/v1.0.0/package.yaml")
+		v2, _ := url.Parse("https://example.com/packages/foo/v2.0.0/package.yaml")
+		Expect(newMarkdownCacheKey("x", v1)).NotTo(Equal(newMarkdownCacheKey("x", v2)))
+		Expect(newMarkdownCacheKey("x", v1)).To(Equal(newMarkdownCacheKey("x", v1)))
+	})
+})
@@ -0,0 +1,188 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/audit"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/web/components/toast"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// metadataEntry is a single label or annotation
+type metadataEntry struct {
+	Key   string
+	Value string
+}
+
+// editableMetadata are the labels and annotations of a package as they are shown on the detail page. Entries that are
+// managed by glasskube or can not be represented in a single line are shown, but can not be edited.
+type editableMetadata struct {
+	Labels      string
+	Annotations string
+	Fixed       []metadataEntry
+}
+
+func newEditableMetadata(pkg ctrlpkg.Package) editableMetadata {
+	var result editableMetadata
+	var fixedLabels, fixedAnnotations []metadataEntry
+	result.Labels, fixedLabels = formatMetadata(pkg.GetLabels())
+	result.Annotations, fixedAnnotations = formatMetadata(pkg.GetAnnotations())
+	result.Fixed = append(fixedLabels, fixedAnnotations...)
+	return result
+}
+
+// isFixedMetadata returns true if the entry must not be changed in the UI. These are all keys in the glasskube.dev
+// domain, which are used by glasskube itself, the last applied configuration of kubectl and multi-line values.
+func isFixedMetadata(key, value string) bool {
+	if key == corev1.LastAppliedConfigAnnotation || strings.Contains(value, "\n") {
+		return true
+	}
+	prefix, _, found := strings.Cut(key, "/")
+	return found && (prefix == "glasskube.dev" || strings.HasSuffix(prefix, ".glasskube.dev"))
+}
+
+// formatMetadata returns the editable entries in the format that is accepted by parseMetadata and all other entries
+// separately
+func formatMetadata(metadata map[string]string) (string, []metadataEntry) {
+	var lines []string
+	var fixed []metadataEntry
+	for _, key := range slices.Sorted(maps.Keys(metadata)) {
+		if value := metadata[key]; isFixedMetadata(key, value) {
+			fixed = append(fixed, metadataEntry{Key: key, Value: value})
+		} else {
+			lines = append(lines, key+"="+value)
+		}
+	}
+	return strings.Join(lines, "\n"), fixed
+}
+
+// parseMetadata parses one key=value entry per line. Keys must be qualified names and values are checked with
+// validateValue, which may be nil.
+func parseMetadata(text string, validateValue func(string) []string) (map[string]string, error) {
+	result := make(map[string]string)
+	var errs []error
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok {
+			errs = append(errs, fmt.Errorf("invalid entry %q: expected <key>=<value>", line))
+			continue
+		}
+		if msgs := validation.IsQualifiedName(key); len(msgs) > 0 {
+			errs = append(errs, fmt.Errorf("invalid key %q: %v", key, strings.Join(msgs, "; ")))
+		} else if isFixedMetadata(key, value) {
+			errs = append(errs, fmt.Errorf("key %q is managed by glasskube and can not be changed", key))
+		} else if _, exists := result[key]; exists {
+			errs = append(errs, fmt.Errorf("duplicate key %q", key))
+		}
+		if validateValue != nil {
+			if msgs := validateValue(value); len(msgs) > 0 {
+				errs = append(errs, fmt.Errorf("invalid value of %q: %v", key, strings.Join(msgs, "; ")))
+			}
+		}
+		result[key] = value
+	}
+	return result, errors.Join(errs...)
+}
+
+// metadataMergePatch returns the changes from current to desired as part of a JSON merge patch. Removed entries are
+// set to nil, so that they are deleted. Fixed entries of current are never changed.
+func metadataMergePatch(current, desired map[string]string) map[string]*string {
+	patch := make(map[string]*string)
+	for key, value := range current {
+		if _, ok := desired[key]; !ok && !isFixedMetadata(key, value) {
+			patch[key] = nil
+		}
+	}
+	for key, value := range desired {
+		if currentValue, ok := current[key]; !ok || currentValue != value {
+			patch[key] = &value
+		}
+	}
+	return patch
+}
+
+// packageMetadata updates the labels and annotations of an installed package (POST). The changes are applied as
+// merge patch with the resource version that was shown to the user, so that concurrent changes are not overwritten.
+func (s *server) packageMetadata(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+	pkg, err := s.getPackageFromRequest(r)
+	if err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to fetch package: %w", err)))
+		return
+	}
+
+	labels, labelErr := parseMetadata(r.PostForm.Get("labels"), validation.IsValidLabelValue)
+	annotations, annotationErr := parseMetadata(r.PostForm.Get("annotations"), nil)
+	if labelErr != nil || annotationErr != nil {
+		err := errors.Join(
+			wrapMetadataErr("invalid labels", labelErr),
+			wrapMetadataErr("invalid annotations", annotationErr),
+		)
+		s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"resourceVersion": r.PostForm.Get("resourceVersion"),
+			"labels":          metadataMergePatch(pkg.GetLabels(), labels),
+			"annotations":     metadataMergePatch(pkg.GetAnnotations(), annotations),
+		},
+	})
+	if err != nil {
+		s.sendToast(w, toast.WithErr(err))
+		return
+	}
+	opts := metav1.PatchOptions{}
+	if s.isGitopsModeEnabled() {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	switch p := pkg.(type) {
+	case *v1alpha1.ClusterPackage:
+		err = s.pkgClient.ClusterPackages().Patch(r.Context(), p, types.MergePatchType, patch, opts)
+	case *v1alpha1.Package:
+		err = s.pkgClient.Packages(p.GetNamespace()).Patch(r.Context(), p, types.MergePatchType, patch, opts)
+	}
+	if err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to update metadata: %w", err)))
+		return
+	}
+
+	if s.isGitopsModeEnabled() {
+		s.sendYamlModal(w, pkg, nil)
+		return
+	}
+	version := pkg.GetSpec().PackageInfo.Version
+	s.recordPackageOperation(r.Context(), audit.OperationConfigure, pkg, version, version)
+	s.sendToast(w, toast.WithMessage(fmt.Sprintf("Labels and annotations of %v have been saved", pkg.GetName())))
+}
+
+func wrapMetadataErr(msg string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%v: %w", msg, err)
+}
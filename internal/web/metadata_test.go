@@ -0,0 +1,53 @@
+package web
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Metadata", func() {
+	It("should keep entries managed by glasskube out of the editable text", func() {
+		text, fixed := formatMetadata(map[string]string{
+			"team":                               "a",
+			"example.com/owner":                  "b",
+			"packages.glasskube.dev/auto-update": "true",
+		})
+		Expect(text).To(Equal("example.com/owner=b\nteam=a"))
+		Expect(fixed).To(Equal([]metadataEntry{{Key: "packages.glasskube.dev/auto-update", Value: "true"}}))
+	})
+
+	DescribeTable("parseMetadata",
+		func(text string, expected map[string]string, valid bool) {
+			result, err := parseMetadata(text, validation.IsValidLabelValue)
+			if valid {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(expected))
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("Empty", "", map[string]string{}, true),
+		Entry("Entries", " team = a \r\n\n# comment\nexample.com/empty=", map[string]string{
+			"team":              "a",
+			"example.com/empty": "",
+		}, true),
+		Entry("Missing separator", "team", nil, false),
+		Entry("Invalid key", "not valid=a", nil, false),
+		Entry("Invalid value", "team=not valid", nil, false),
+		Entry("Duplicate key", "team=a\nteam=b", nil, false),
+		Entry("Managed key", "packages.glasskube.dev/auto-update=false", nil, false),
+	)
+
+	It("should only patch changed entries and never remove managed ones", func() {
+		patch := metadataMergePatch(
+			map[string]string{"keep": "a", "change": "b", "remove": "c", "packages.glasskube.dev/instance": "d"},
+			map[string]string{"keep": "a", "change": "x", "add": "y"},
+		)
+		Expect(patch).To(HaveLen(3))
+		Expect(patch).To(HaveKeyWithValue("remove", BeNil()))
+		Expect(*patch["change"]).To(Equal("x"))
+		Expect(*patch["add"]).To(Equal("y"))
+	})
+})
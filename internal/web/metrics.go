@@ -0,0 +1,361 @@
+package web
+
+import (
+	"context"
+	"html/template"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/audit"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	repoclient "github.com/glasskube/glasskube/internal/repo/client"
+	"github.com/glasskube/glasskube/internal/repo/types"
+	"github.com/glasskube/glasskube/internal/semver"
+	"github.com/glasskube/glasskube/pkg/client"
+	"github.com/glasskube/glasskube/pkg/condition"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+const (
+	metricsNamespace = "glasskube"
+	metricsSubsystem = "web"
+)
+
+// names of the metrics of the web server, which are also used by the Grafana dashboard (see grafanaDashboard)
+const (
+	packageOperationsMetric          = "package_operations_total"
+	repositoryFetchDurationMetric    = "repository_fetch_duration_seconds"
+	templateRenderDurationMetric     = "template_render_duration_seconds"
+	markdownCacheRequestsMetric      = "markdown_cache_requests_total"
+	rateLimitedRequestsMetric        = "rate_limited_requests_total"
+	packagesInstalledMetric          = "packages_installed"
+	packagesByStatusMetric           = "packages_by_status"
+	packagesOutdatedMetric           = "packages_outdated"
+	repositoryReadyMetric            = "repository_ready"
+	repositoryLastSyncDurationMetric = "repository_last_sync_duration_seconds"
+)
+
+type metrics struct {
+	registry *prometheus.Registry
+	// collectors are all collectors that are registered in the registry
+	collectors              []prometheus.Collector
+	packageOperations       *prometheus.CounterVec
+	repositoryFetchDuration *prometheus.HistogramVec
+	templateRenderDuration  *prometheus.HistogramVec
+	markdownCacheRequests   *prometheus.CounterVec
+	rateLimitedRequests     *prometheus.CounterVec
+}
+
+func newMetrics() *metrics {
+	m := metrics{
+		registry: prometheus.NewRegistry(),
+		packageOperations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      packageOperationsMetric,
+			Help:      "Number of package operations (install, update, configure, rollback, uninstall) performed via the UI",
+		}, []string{"operation", "scope"}),
+		repositoryFetchDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      repositoryFetchDurationMetric,
+			Help:      "Duration of fetching indices and manifests from package repositories, including cache hits",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 4, 8),
+		}, []string{"repository", "resource"}),
+		templateRenderDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      templateRenderDurationMetric,
+			Help:      "Duration of rendering a page template",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 12),
+		}, []string{"page"}),
+		markdownCacheRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      markdownCacheRequestsMetric,
+			Help:      "Number of lookups in the cache for rendered markdown, by result (hit or miss)",
+		}, []string{"result"}),
+		rateLimitedRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      rateLimitedRequestsMetric,
+			Help:      "Number of requests rejected by the rate limiting, by limit (requests or events)",
+		}, []string{"limit"}),
+	}
+	m.register(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.packageOperations,
+		m.repositoryFetchDuration,
+		m.templateRenderDuration,
+		m.markdownCacheRequests,
+		m.rateLimitedRequests,
+	)
+	return &m
+}
+
+func (m *metrics) register(cs ...prometheus.Collector) {
+	m.registry.MustRegister(cs...)
+	m.collectors = append(m.collectors, cs...)
+}
+
+func (m *metrics) handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+func (m *metrics) packageOperation(operation audit.Operation, pkg ctrlpkg.Package) {
+	scope := "cluster"
+	if pkg.IsNamespaceScoped() {
+		scope = "namespaced"
+	}
+	m.packageOperations.WithLabelValues(string(operation), scope).Inc()
+}
+
+// registerMetrics registers the gauges of the broadcaster, gauges for the number of installed packages and the
+// clusterStateCollector. The latter are evaluated on every scrape using the (cached) package client of the server.
+func (s *server) registerMetrics() {
+	s.metrics.register(
+		s.broadcaster.ConnectedClients(),
+		s.broadcaster.WebsocketConnectedClients(),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace:   metricsNamespace,
+			Name:        packagesInstalledMetric,
+			Help:        "Number of installed packages",
+			ConstLabels: prometheus.Labels{"scope": "cluster"},
+		}, func() float64 {
+			var list v1alpha1.ClusterPackageList
+			if !s.isBootstrapped || s.pkgClient.ClusterPackages().GetAll(context.Background(), &list) != nil {
+				return 0
+			}
+			return float64(len(list.Items))
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace:   metricsNamespace,
+			Name:        packagesInstalledMetric,
+			Help:        "Number of installed packages",
+			ConstLabels: prometheus.Labels{"scope": "namespaced"},
+		}, func() float64 {
+			var list v1alpha1.PackageList
+			if !s.isBootstrapped || s.pkgClient.Packages("").GetAll(context.Background(), &list) != nil {
+				return 0
+			}
+			return float64(len(list.Items))
+		}),
+		newClusterStateCollector(s),
+	)
+}
+
+// clusterStateCollector collects metrics about the status of the installed packages and repositories of the cluster
+type clusterStateCollector struct {
+	server                     *server
+	packagesByStatus           *prometheus.Desc
+	packagesOutdated           *prometheus.Desc
+	repositoryReady            *prometheus.Desc
+	repositoryLastSyncDuration *prometheus.Desc
+}
+
+func newClusterStateCollector(s *server) *clusterStateCollector {
+	return &clusterStateCollector{
+		server: s,
+		packagesByStatus: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", packagesByStatusMetric),
+			"Number of installed packages, by status (e.g. Ready or Failed)",
+			[]string{"scope", "status"}, nil),
+		packagesOutdated: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", packagesOutdatedMetric),
+			"Number of installed packages whose version is older than the latest version in their repository",
+			[]string{"scope"}, nil),
+		repositoryReady: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", repositoryReadyMetric),
+			"Whether the last sync of a package repository succeeded (1) or not (0)",
+			[]string{"repository"}, nil),
+		repositoryLastSyncDuration: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", repositoryLastSyncDurationMetric),
+			"Duration of the last sync of a package repository by the operator",
+			[]string{"repository"}, nil),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *clusterStateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.packagesByStatus
+	ch <- c.packagesOutdated
+	ch <- c.repositoryReady
+	ch <- c.repositoryLastSyncDuration
+}
+
+// Collect implements prometheus.Collector
+func (c *clusterStateCollector) Collect(ch chan<- prometheus.Metric) {
+	if !c.server.isBootstrapped {
+		return
+	}
+	ctx := context.Background()
+	if pkgs, err := c.server.listInstalledPackages(ctx, updateAllScopeCluster); err == nil {
+		c.collectPackages(ch, "cluster", pkgs)
+	}
+	if pkgs, err := c.server.listInstalledPackages(ctx, updateAllScopeNamespaced); err == nil {
+		c.collectPackages(ch, "namespaced", pkgs)
+	}
+	var repos v1alpha1.PackageRepositoryList
+	if err := c.server.pkgClient.PackageRepositories().GetAll(ctx, &repos); err == nil {
+		for _, repo := range repos.Items {
+			ready := 0.0
+			if meta.IsStatusConditionTrue(repo.Status.Conditions, string(condition.Ready)) {
+				ready = 1
+			}
+			ch <- prometheus.MustNewConstMetric(c.repositoryReady, prometheus.GaugeValue, ready, repo.Name)
+			if repo.Status.LastSyncDuration != nil {
+				ch <- prometheus.MustNewConstMetric(c.repositoryLastSyncDuration, prometheus.GaugeValue,
+					repo.Status.LastSyncDuration.Seconds(), repo.Name)
+			}
+		}
+	}
+}
+
+func (c *clusterStateCollector) collectPackages(ch chan<- prometheus.Metric, scope string, pkgs []ctrlpkg.Package) {
+	byStatus := make(map[string]int)
+	outdated := 0
+	// the index of every repository is only fetched once per scrape
+	latestVersions := make(map[string]map[string]string)
+	for _, pkg := range pkgs {
+		if status := client.GetStatusOrPending(pkg); status != nil {
+			byStatus[status.Status]++
+		}
+		repoName := pkg.GetSpec().PackageInfo.RepositoryName
+		if _, ok := latestVersions[repoName]; !ok {
+			latestVersions[repoName] = make(map[string]string)
+			var index types.PackageRepoIndex
+			if err := c.server.repoClientset.ForPackage(pkg).FetchPackageRepoIndex(&index); err == nil {
+				for _, item := range index.Packages {
+					latestVersions[repoName][item.Name] = item.LatestVersion
+				}
+			}
+		}
+		info := pkg.GetSpec().PackageInfo
+		if latest := latestVersions[repoName][info.Name]; latest != "" && semver.IsUpgradable(info.Version, latest) {
+			outdated++
+		}
+	}
+	for status, count := range byStatus {
+		ch <- prometheus.MustNewConstMetric(c.packagesByStatus, prometheus.GaugeValue, float64(count), scope, status)
+	}
+	ch <- prometheus.MustNewConstMetric(c.packagesOutdated, prometheus.GaugeValue, float64(outdated), scope)
+}
+
+// serveMetrics serves the metrics endpoint on a separate port, so it can be exposed independently of the UI
+func (s *server) serveMetrics() error {
+	listener, err := net.Listen("tcp", net.JoinHostPort(s.Host, s.MetricsPort))
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", s.metrics.handler())
+	mux.HandleFunc(dashboardPath, grafanaDashboardHandler)
+	s.metricsServer = &http.Server{Handler: mux}
+	go func() {
+		if err := s.metricsServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+			s.logger.Error("metrics server stopped", "error", err)
+		}
+	}()
+	return nil
+}
+
+// executePage executes the template of a page and observes the time it took to render
+func (s *server) executePage(w io.Writer, tmpl *template.Template, page string, data any) error {
+	start := time.Now()
+	defer func() {
+		s.metrics.templateRenderDuration.WithLabelValues(page).Observe(time.Since(start).Seconds())
+	}()
+	return tmpl.Execute(w, data)
+}
+
+// instrumentedRepoClientset observes the fetch durations of all repo clients it hands out. It also records whether a
+// repository index has been fetched successfully at least once, which is used by the readiness probe.
+type instrumentedRepoClientset struct {
+	repoclient.RepoClientset
+	metrics *metrics
+	synced  *atomic.Bool
+}
+
+func (cs *instrumentedRepoClientset) ForPackage(pkg ctrlpkg.Package) repoclient.RepoClient {
+	return cs.instrument(cs.RepoClientset.ForPackage(pkg), pkg.GetSpec().PackageInfo.RepositoryName)
+}
+
+func (cs *instrumentedRepoClientset) ForRepoWithName(name string) repoclient.RepoClient {
+	return cs.instrument(cs.RepoClientset.ForRepoWithName(name), name)
+}
+
+func (cs *instrumentedRepoClientset) ForRepo(repo v1alpha1.PackageRepository) repoclient.RepoClient {
+	return cs.instrument(cs.RepoClientset.ForRepo(repo), repo.Name)
+}
+
+func (cs *instrumentedRepoClientset) Default() repoclient.RepoClient {
+	return cs.instrument(cs.RepoClientset.Default(), "")
+}
+
+func (cs *instrumentedRepoClientset) instrument(client repoclient.RepoClient, repoName string) repoclient.RepoClient {
+	instrumented := &instrumentedRepoClient{RepoClient: client, repoName: repoName, metrics: cs.metrics, synced: cs.synced}
+	if verifier, ok := client.(repoclient.SignatureVerifier); ok {
+		// only clients of repositories that verify signatures must implement SignatureVerifier
+		return &instrumentedVerifyingRepoClient{instrumentedRepoClient: instrumented, verifier: verifier}
+	}
+	return instrumented
+}
+
+type instrumentedRepoClient struct {
+	repoclient.RepoClient
+	repoName string
+	metrics  *metrics
+	synced   *atomic.Bool
+}
+
+// InvalidateCache implements repoclient.CacheInvalidator, if the underlying client does
+func (c *instrumentedRepoClient) InvalidateCache() {
+	if invalidator, ok := c.RepoClient.(repoclient.CacheInvalidator); ok {
+		invalidator.InvalidateCache()
+	}
+}
+
+type instrumentedVerifyingRepoClient struct {
+	*instrumentedRepoClient
+	verifier repoclient.SignatureVerifier
+}
+
+// VerifyPackageManifest implements repoclient.SignatureVerifier
+func (c *instrumentedVerifyingRepoClient) VerifyPackageManifest(name, version string) error {
+	defer c.observe("package_signature", time.Now())
+	return c.verifier.VerifyPackageManifest(name, version)
+}
+
+func (c *instrumentedRepoClient) FetchPackageRepoIndex(target *types.PackageRepoIndex) error {
+	defer c.observe("repo_index", time.Now())
+	err := c.RepoClient.FetchPackageRepoIndex(target)
+	if err == nil && c.synced != nil {
+		c.synced.Store(true)
+	}
+	return err
+}
+
+func (c *instrumentedRepoClient) FetchPackageIndex(name string, target *types.PackageIndex) error {
+	defer c.observe("package_index", time.Now())
+	return c.RepoClient.FetchPackageIndex(name, target)
+}
+
+func (c *instrumentedRepoClient) FetchPackageManifest(
+	name, version string,
+	target *v1alpha1.PackageManifest,
+) error {
+	defer c.observe("package_manifest", time.Now())
+	return c.RepoClient.FetchPackageManifest(name, version, target)
+}
+
+func (c *instrumentedRepoClient) observe(resource string, start time.Time) {
+	c.metrics.repositoryFetchDuration.WithLabelValues(c.repoName, resource).Observe(time.Since(start).Seconds())
+}
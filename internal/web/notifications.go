@@ -0,0 +1,96 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	clientadapter "github.com/glasskube/glasskube/internal/adapter/goclient"
+	"github.com/glasskube/glasskube/internal/notification"
+	"github.com/glasskube/glasskube/internal/web/components/toast"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (s *server) getNotificationConfig(ctx context.Context) (*notification.Config, error) {
+	config, err := notification.LoadConfig(ctx, clientadapter.NewKubernetesClientAdapter(s.k8sClient))
+	if err != nil {
+		return nil, err
+	} else if config == nil {
+		config = &notification.Config{Format: notification.FormatGeneric}
+	}
+	return config, nil
+}
+
+// notificationSettings stores the webhook configuration for update and health notifications, which is picked up by
+// the operator on the next repository sync. If the secret field is left empty, a previously configured secret is kept.
+func (s *server) notificationSettings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+
+	config, err := s.getNotificationConfig(r.Context())
+	if err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to load notification config: %w", err)))
+		return
+	}
+	config.URL = r.PostForm.Get("url")
+	config.Format = notification.Format(r.PostForm.Get("format"))
+	config.Packages = notification.ParsePackages(r.PostForm.Get("packages"))
+	config.Unhealthy = r.PostForm.Get("unhealthy") == "on"
+	if r.PostForm.Get("removeSecret") == "on" {
+		config.Secret = ""
+	} else if secret := r.PostForm.Get("secret"); secret != "" {
+		config.Secret = secret
+	}
+	if err := config.Validate(); err != nil {
+		s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+
+	if err := s.saveNotificationConfig(r.Context(), config); err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to save notification config: %w", err)))
+		return
+	}
+	s.sendToast(w, toast.WithMessage("Notification settings saved"))
+}
+
+func (s *server) saveNotificationConfig(ctx context.Context, config *notification.Config) error {
+	configMaps := s.k8sClient.CoreV1().ConfigMaps(notification.Namespace)
+	cm := config.ConfigMap()
+	if existing, err := configMaps.Get(ctx, cm.Name, metav1.GetOptions{}); apierrors.IsNotFound(err) {
+		if _, err := configMaps.Create(ctx, cm, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	} else {
+		existing.Data = cm.Data
+		if _, err := configMaps.Update(ctx, existing, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+
+	secrets := s.k8sClient.CoreV1().Secrets(notification.Namespace)
+	secret := config.SecretResource()
+	if existing, err := secrets.Get(ctx, secret.Name, metav1.GetOptions{}); apierrors.IsNotFound(err) {
+		if config.Secret == "" {
+			return nil
+		}
+		_, err := secrets.Create(ctx, secret, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	} else if config.Secret == "" {
+		return secrets.Delete(ctx, secret.Name, metav1.DeleteOptions{})
+	} else {
+		existing.Data = secret.Data
+		_, err := secrets.Update(ctx, existing, metav1.UpdateOptions{})
+		return err
+	}
+}
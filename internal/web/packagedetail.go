@@ -8,6 +8,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/glasskube/glasskube/internal/namespaces"
 	"github.com/glasskube/glasskube/pkg/install"
@@ -15,6 +16,7 @@ import (
 	v12 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	deputil "github.com/glasskube/glasskube/internal/dependency/util"
 	repoerror "github.com/glasskube/glasskube/internal/repo/error"
 
 	"go.uber.org/multierr"
@@ -26,19 +28,29 @@ import (
 	webutil "github.com/glasskube/glasskube/internal/web/util"
 
 	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/audit"
 	"github.com/glasskube/glasskube/internal/clientutils"
 	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
 	"github.com/glasskube/glasskube/internal/dependency"
+	"github.com/glasskube/glasskube/internal/registrymirror"
 	"github.com/glasskube/glasskube/internal/repo"
+	repoclient "github.com/glasskube/glasskube/internal/repo/client"
 	"github.com/glasskube/glasskube/internal/repo/types"
+	"github.com/glasskube/glasskube/internal/scheduling"
+	"github.com/glasskube/glasskube/internal/semver"
+	"github.com/glasskube/glasskube/internal/tracing"
 	"github.com/glasskube/glasskube/internal/util"
 	"github.com/glasskube/glasskube/internal/web/components/pkg_config_input"
 	"github.com/glasskube/glasskube/pkg/client"
 	"github.com/glasskube/glasskube/pkg/describe"
 	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/apimachinery/pkg/api/errors"
 )
 
+// resetToDefaultsKey is the form value that makes the configuration form show the default values of the manifest
+const resetToDefaultsKey = "defaults"
+
 type packageContextRequest struct {
 	repositoryName string
 	version        string
@@ -175,22 +187,32 @@ func (s *server) renderPackageDetailPage(ctx context.Context, r *http.Request, w
 	var lostValueDefinitions []string
 	valueErrors := make(map[string]error)
 	datalistOptions := make(map[string]*pkg_config_input.PkgConfigInputDatalistOptions)
+	var profile *v1alpha1.PackageProfile
+	var profileOptions []string
+	var clonedFrom string
+	var clonedReferences []string
+	var cloneOptions []string
+	var clusterDefaults map[string]string
+	resetToDefaults := r.FormValue(resetToDefaultsKey) == "true"
 
 	if !headerOnly {
 		// TODO properly componentize header away and use view model objects
 		if p.pkg.IsNil() {
+			// optional dependencies are not enabled by default
+			mf := deputil.WithEnabledDependencies(*p.manifest, nil)
 			if p.manifest.Scope.IsCluster() {
 				validationResult, validationErr =
-					s.dependencyMgr.Validate(r.Context(), p.request.manifestName, "", p.manifest, p.request.version)
+					s.dependencyMgr.Validate(r.Context(), p.request.manifestName, "", &mf, p.request.version)
 			} else {
 				// In this case we don't know the actual namespace, but we can assume the default
 				// TODO: make name and namespace depend on user input
 				validationResult, validationErr =
-					s.dependencyMgr.Validate(r.Context(), p.request.manifestName, p.manifest.DefaultNamespace, p.manifest, p.request.version)
+					s.dependencyMgr.Validate(r.Context(), p.request.manifestName, p.manifest.DefaultNamespace, &mf, p.request.version)
 			}
 		} else if migrateManifest {
+			mf := deputil.WithEnabledDependencies(*p.manifest, p.pkg.GetSpec().OptionalDependencies)
 			validationResult, validationErr =
-				s.dependencyMgr.Validate(r.Context(), p.pkg.GetName(), p.pkg.GetNamespace(), p.manifest, p.request.version)
+				s.dependencyMgr.Validate(r.Context(), p.pkg.GetName(), p.pkg.GetNamespace(), &mf, p.request.version)
 		}
 		if validationErr != nil {
 			s.sendToast(w,
@@ -198,11 +220,50 @@ func (s *server) renderPackageDetailPage(ctx context.Context, r *http.Request, w
 					p.request.manifestName, p.request.version, validationErr)))
 			return
 		}
+		if validationResult.Graph == nil {
+			// nothing was validated, so the dependency tree is shown as it is currently installed
+			if g, err := s.dependencyMgr.NewGraph(r.Context()); err != nil {
+				fmt.Fprintf(os.Stderr, "failed to create dependency graph: %v\n", err)
+			} else {
+				validationResult.Graph = g
+			}
+		}
 
-		nsOptions, _ := s.getNamespaceOptions()
+		var values map[string]v1alpha1.ValueConfiguration
 		if !p.pkg.IsNil() {
+			values = p.pkg.GetSpec().Values
+		}
+		if profileName := r.FormValue(profileKey); profileName != "" {
+			if profile, err = s.getPackageProfile(ctx, profileName, p.request.manifestName); err != nil {
+				s.sendToast(w, toast.WithErr(err))
+				return
+			}
+			values = profile.Spec.Values
+		} else if cloneFrom := r.FormValue(cloneFromKey); cloneFrom != "" && p.manifest.Scope.IsNamespaced() {
+			clonedFrom = cloneFrom
+			if profile, clonedReferences, err = s.getClonedProfile(
+				ctx, cloneFrom, p.request.manifestName, installNamespace(r, p.pkg, p.manifest)); err != nil {
+				s.sendToast(w, toast.WithErr(err))
+				return
+			}
+			values = profile.Spec.Values
+		}
+		if resetToDefaults {
+			values = nil
+		}
+		if profileOptions, err = s.getProfileOptions(ctx, p.request.manifestName); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to get profile options: %v\n", err)
+		}
+		if p.manifest.Scope.IsNamespaced() {
+			if cloneOptions, err = s.getCloneOptions(ctx, p.request.manifestName, p.pkg); err != nil {
+				fmt.Fprintf(os.Stderr, "failed to get clone options: %v\n", err)
+			}
+		}
+
+		nsOptions, _ := s.getNamespaceOptions()
+		if values != nil {
 			pkgsOptions, _ := s.getPackagesOptions(r.Context())
-			for key, v := range p.pkg.GetSpec().Values {
+			for key, v := range values {
 				if resolved, err := s.valueResolver.ResolveValue(r.Context(), v); err != nil {
 					valueErrors[key] = util.GetRootCause(err)
 				} else if valDef, exists := p.manifest.ValueDefinitions[key]; !exists {
@@ -221,6 +282,9 @@ func (s *server) renderPackageDetailPage(ctx context.Context, r *http.Request, w
 			}
 		}
 		datalistOptions[""] = &pkg_config_input.PkgConfigInputDatalistOptions{Namespaces: nsOptions}
+		if clusterDefaults, err = s.valueResolver.ClusterDefaults(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to get cluster defaults: %v\n", err)
+		}
 	}
 
 	advancedOptions, err := getAdvancedOptionsFromCookie(r)
@@ -232,37 +296,144 @@ func (s *server) renderPackageDetailPage(ctx context.Context, r *http.Request, w
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to check whether auto updater is installed: %v\n", err)
 	}
+	var postInstallNotes string
+	var postInstallNotesErr error
+	if !p.pkg.IsNil() && !migrateManifest && p.manifest.PostInstallNotes != "" && !headerOnly {
+		postInstallNotes, postInstallNotesErr = s.valueResolver.RenderNotes(ctx, p.pkg, p.manifest.PostInstallNotes)
+	}
+
+	var failedHook *v1alpha1.HookStatus
+	var failedHookLogs string
+	var failedHookLogsErr error
+	if !p.pkg.IsNil() {
+		if failedHook = p.pkg.GetStatus().FailedHook(); failedHook != nil {
+			failedHookLogs, failedHookLogsErr = s.getHookLogs(ctx, failedHook)
+		}
+	}
+
 	templateData := map[string]any{
-		"Package":              p.pkg,
-		"Status":               client.GetStatusOrPending(p.pkg),
-		"Manifest":             p.manifest,
-		"LatestVersion":        latestVersion,
-		"UpdateAvailable":      s.isUpdateAvailableForPkg(r.Context(), p.pkg),
-		"ValidationResult":     validationResult,
-		"ShowConflicts":        validationResult.Status == dependency.ValidationResultStatusConflict,
-		"SelectedVersion":      p.request.version,
-		"PackageIndex":         &idx,
-		"Repositories":         repos,
-		"RepositoryName":       p.request.repositoryName,
-		"ShowConfiguration":    (!p.pkg.IsNil() && len(p.manifest.ValueDefinitions) > 0 && p.pkg.GetDeletionTimestamp().IsZero()) || p.pkg.IsNil(),
-		"ValueErrors":          valueErrors,
-		"DatalistOptions":      datalistOptions,
-		"ShowDiscussionLink":   usedRepo.IsGlasskubeRepo(),
-		"PackageHref":          webutil.GetPackageHrefWithFallback(p.pkg, p.manifest),
-		"AdvancedOptions":      advancedOptions,
-		"LostValueDefinitions": lostValueDefinitions,
-		"AutoUpdaterInstalled": autoUpdaterInstalled,
+		"Package":                  p.pkg,
+		"Status":                   client.GetStatusOrPending(p.pkg),
+		"Manifest":                 p.manifest,
+		"LatestVersion":            latestVersion,
+		"UpdateAvailable":          s.isUpdateAvailableForPkg(r.Context(), p.pkg),
+		"ValidationResult":         validationResult,
+		"ShowConflicts":            validationResult.Status == dependency.ValidationResultStatusConflict,
+		"SelectedVersion":          p.request.version,
+		"PackageIndex":             &idx,
+		"Repositories":             repos,
+		"RepositoryName":           p.request.repositoryName,
+		"ShowConfiguration":        (!p.pkg.IsNil() && isConfigurable(p.manifest) && p.pkg.GetDeletionTimestamp().IsZero()) || p.pkg.IsNil(),
+		"OptionalDependencies":     deputil.OptionalDependencies(p.manifest),
+		"ValueErrors":              valueErrors,
+		"ValueGroups":              configValueGroups(p.manifest, valueErrors),
+		"DatalistOptions":          datalistOptions,
+		"ShowDiscussionLink":       usedRepo.IsGlasskubeRepo() && s.DiscussionsEnabled(),
+		"PackageHref":              webutil.GetPackageHrefWithFallback(p.pkg, p.manifest),
+		"AdvancedOptions":          advancedOptions,
+		"LostValueDefinitions":     lostValueDefinitions,
+		"AutoUpdaterInstalled":     autoUpdaterInstalled,
+		"VersionConstraintOptions": semver.ConstraintSuggestions(p.request.version),
+		"ResolvedVersion":          resolveVersionWithConstraint(p.pkg, &idx),
+		"Signature":                s.getSignatureStatus(p.request.repositoryName, p.request.manifestName, p.request.version),
+		"Profile":                  profile,
+		"ProfileOptions":           profileOptions,
+		"ClonedFrom":               clonedFrom,
+		"ClonedReferences":         clonedReferences,
+		"CloneOptions":             cloneOptions,
+		"InstallNamespace":         installNamespace(r, p.pkg, p.manifest),
+		"ConfigInputOptions":       configInputOptions(profile, resetToDefaults, clusterDefaults),
+		"ResetToDefaults":          resetToDefaults,
+		"PostInstallNotes":         postInstallNotes,
+		"PostInstallNotesError":    postInstallNotesErr,
+		"FailedHook":               failedHook,
+		"FailedHookLogs":           failedHookLogs,
+		"FailedHookLogsError":      failedHookLogsErr,
 	}
 
 	if headerOnly {
-		repoErr = s.templates.pkgDetailHeaderTmpl.Execute(w, s.enrichPage(r, templateData, repoErr))
+		repoErr = s.templatesFor(r).pkgDetailHeaderTmpl.Execute(w, s.enrichPage(r, templateData, repoErr))
 		webutil.CheckTmplError(repoErr, fmt.Sprintf("package-detail-header (%s)", p.request.manifestName))
 	} else {
-		repoErr = s.templates.pkgPageTmpl.Execute(w, s.enrichPage(r, templateData, repoErr))
+		repoErr = s.executePage(w, s.templatesFor(r).pkgPageTmpl, "package", s.enrichPage(r, templateData, repoErr))
 		webutil.CheckTmplError(repoErr, fmt.Sprintf("package-detail (%s)", p.request.manifestName))
 	}
 }
 
+// installNamespace returns the namespace of pkg, or the namespace selected in the install form if it is not installed
+// yet, which defaults to the default namespace of the manifest
+func installNamespace(r *http.Request, pkg ctrlpkg.Package, manifest *v1alpha1.PackageManifest) string {
+	if !pkg.IsNil() {
+		return pkg.GetNamespace()
+	} else if namespace := r.FormValue("namespace"); namespace != "" {
+		return namespace
+	}
+	return manifest.DefaultNamespace
+}
+
+// isConfigurable returns true if the configuration form has any inputs for an installed package with this manifest
+func isConfigurable(manifest *v1alpha1.PackageManifest) bool {
+	return len(manifest.ValueDefinitions) > 0 || len(deputil.OptionalDependencies(manifest)) > 0
+}
+
+// configValueGroup is a section of the configuration form, see manifestvalues.GroupValues
+type configValueGroup struct {
+	manifestvalues.GroupedValues
+	// Collapsible is false for the essential group and for the single group of manifests without value groups
+	Collapsible bool
+	// Open is true if the group is not collapsed by default or contains a value with an error
+	Open bool
+}
+
+// configValueGroups returns the sections of the configuration form for the manifest
+func configValueGroups(manifest *v1alpha1.PackageManifest, valueErrors map[string]error) []configValueGroup {
+	var result []configValueGroup
+	for _, group := range manifestvalues.GroupValues(manifest) {
+		result = append(result, configValueGroup{
+			GroupedValues: group,
+			Collapsible:   group.Name != "" && group.Name != manifestvalues.EssentialGroupName,
+			Open: !group.Collapsed || slices.ContainsFunc(group.Names, func(name string) bool {
+				return valueErrors[name] != nil
+			}),
+		})
+	}
+	return result
+}
+
+// configInputOptions returns the render options for the inputs of the configuration form, which show the values of
+// the loaded profile instead of the values of the package and pre-fill missing values with the cluster defaults.
+// If resetToDefaults is true, all inputs show the default values of the manifest instead.
+func configInputOptions(
+	profile *v1alpha1.PackageProfile, resetToDefaults bool, clusterDefaults map[string]string,
+) *pkg_config_input.PkgConfigInputRenderOptions {
+	options := pkg_config_input.PkgConfigInputRenderOptions{ClusterDefaults: clusterDefaults}
+	if resetToDefaults {
+		options.Values = map[string]v1alpha1.ValueConfiguration{}
+	} else if profile != nil {
+		options.Values = profile.Spec.Values
+		if options.Values == nil {
+			options.Values = map[string]v1alpha1.ValueConfiguration{}
+		}
+	}
+	return &options
+}
+
+// signatureStatus is shown on the package detail page if the repository of the package verifies signatures
+type signatureStatus struct {
+	Verified bool
+	Error    error
+}
+
+func (s *server) getSignatureStatus(repositoryName, pkgName, version string) *signatureStatus {
+	if verifier, ok := s.repoClientset.ForRepoWithName(repositoryName).(repoclient.SignatureVerifier); !ok {
+		return nil
+	} else if err := verifier.VerifyPackageManifest(pkgName, version); err != nil {
+		return &signatureStatus{Error: err}
+	} else {
+		return &signatureStatus{Verified: true}
+	}
+}
+
 func (s *server) resolveVersions(repositoryName string, pkgName string, selectedVersion string) (repo.PackageIndex, string, string, error) {
 	var idx repo.PackageIndex
 	if err := s.repoClientset.ForRepoWithName(repositoryName).FetchPackageIndex(pkgName, &idx); err != nil {
@@ -280,6 +451,24 @@ func (s *server) resolveVersions(repositoryName string, pkgName string, selected
 	return idx, latestVersion, selectedVersion, nil
 }
 
+// resolveVersionWithConstraint returns the highest version from the index that satisfies the version constraint of
+// the given package. An empty string is returned if the package has no version constraint.
+func resolveVersionWithConstraint(pkg ctrlpkg.Package, idx *repo.PackageIndex) string {
+	if pkg.IsNil() || pkg.VersionConstraint() == "" {
+		return ""
+	}
+	versions := make([]string, len(idx.Versions))
+	for i, item := range idx.Versions {
+		versions[i] = item.Version
+	}
+	if version, err := semver.LatestVersionWithConstraint(versions, pkg.VersionConstraint()); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to resolve version constraint of %v: %v\n", pkg.GetName(), err)
+		return ""
+	} else {
+		return version
+	}
+}
+
 func (s *server) resolveRepos(ctx context.Context, manifestName string, repositoryName string) (
 	string, []v1alpha1.PackageRepository, *v1alpha1.PackageRepository, error) {
 	var repos []v1alpha1.PackageRepository
@@ -294,10 +483,16 @@ func (s *server) resolveRepos(ctx context.Context, manifestName string, reposito
 		if len(repos) == 0 {
 			return "", nil, nil, fmt.Errorf("%v not found in any repository", manifestName)
 		}
-		for _, r := range repos {
-			repositoryName = r.Name
-			if r.IsDefaultRepository() {
-				break
+		repoclient.SortByPriority(repos)
+		if resolution, err := repoclient.ResolveRepository(manifestName, repos); err == nil {
+			repositoryName = resolution.Repository.Name
+		} else {
+			// the highest priority is shared by multiple repositories, of which the default repository is preferred
+			repositoryName = repos[0].Name
+			for _, r := range repos {
+				if r.Spec.Priority == repos[0].Spec.Priority && r.IsDefaultRepository() {
+					repositoryName = r.Name
+				}
 			}
 		}
 	}
@@ -315,10 +510,21 @@ func (s *server) installOrConfigurePackage(w http.ResponseWriter, r *http.Reques
 	ctx := r.Context()
 	namespace := r.FormValue("namespace")
 	name := r.FormValue("name")
+	createNamespace := strings.ToLower(r.FormValue("createNamespace")) == "on"
 	autoUpdate := strings.ToLower(r.FormValue("autoUpdate")) == "on"
+	versionConstraint := strings.TrimSpace(r.FormValue("versionConstraint"))
 	dryRun, _ := strconv.ParseBool(r.FormValue("dryRun"))
 
-	var err error
+	registryMirrors, err := registrymirror.Parse(r.FormValue("imageRegistryMirrors"))
+	if err != nil {
+		s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+	schedulingOverrides, err := scheduling.Parse(r.FormValue("scheduling"))
+	if err != nil {
+		s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
 	pkg := &v1alpha1.Package{}
 	var mf *v1alpha1.PackageManifest
 	if err := s.pkgClient.Packages(p.namespace).Get(ctx, p.name, pkg); err != nil && !errors.IsNotFound(err) {
@@ -336,6 +542,11 @@ func (s *server) installOrConfigurePackage(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
+	if err := validateVersionConstraint(p.version, versionConstraint); err != nil {
+		s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+
 	mf, err = s.resolveManifest(ctx, pkg, p.repositoryName, p.manifestName, p.version)
 	if repoerror.IsPartial(err) {
 		fmt.Fprintf(os.Stderr, "problem fetching manifest and repo, but installation can continue: %v", err)
@@ -347,7 +558,26 @@ func (s *server) installOrConfigurePackage(w http.ResponseWriter, r *http.Reques
 	if values, err := extractValues(r, mf); err != nil {
 		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to parse values: %w", err)))
 		return
+	} else if err := manifestvalues.ValidateValueConfigurations(*mf, values); err != nil {
+		// only the first error is shown, all errors are shown inline when the inputs are changed
+		s.sendToast(w, toast.WithErr(multierr.Errors(err)[0]), toast.WithStatusCode(http.StatusBadRequest))
+		return
 	} else if pkg == nil {
+		pkg = client.PackageBuilder(p.manifestName).
+			WithVersion(p.version).
+			WithRepositoryName(p.repositoryName).
+			WithAutoUpdates(autoUpdate).
+			WithVersionConstraint(versionConstraint).
+			WithValues(values).
+			WithImageRegistryMirrors(registryMirrors).
+			WithScheduling(schedulingOverrides).
+			WithOptionalDependencies(extractOptionalDependencies(r, mf)).
+			WithNamespace(namespace).
+			WithName(name).
+			BuildPackage()
+		if !s.confirmQuota(w, r, pkg, mf) {
+			return
+		}
 		opts := v1.CreateOptions{}
 		if dryRun {
 			opts.DryRun = []string{v1.DryRunAll}
@@ -355,6 +585,10 @@ func (s *server) installOrConfigurePackage(w http.ResponseWriter, r *http.Reques
 		if exists, err := namespaces.Exists(ctx, s.k8sClient, namespace); err != nil {
 			s.sendToast(w, toast.WithErr(fmt.Errorf("failed to check namespace: %w", err)))
 			return
+		} else if !exists && !createNamespace {
+			s.sendToast(w, toast.WithErr(fmt.Errorf("namespace %v does not exist", namespace)),
+				toast.WithStatusCode(http.StatusBadRequest))
+			return
 		} else if !exists {
 			ns := v12.Namespace{
 				ObjectMeta: v1.ObjectMeta{
@@ -366,32 +600,39 @@ func (s *server) installOrConfigurePackage(w http.ResponseWriter, r *http.Reques
 				return
 			}
 		}
-		pkg = client.PackageBuilder(p.manifestName).
-			WithVersion(p.version).
-			WithRepositoryName(p.repositoryName).
-			WithAutoUpdates(autoUpdate).
-			WithValues(values).
-			WithNamespace(namespace).
-			WithName(name).
-			BuildPackage()
 		err := install.NewInstaller(s.pkgClient).Install(ctx, pkg, opts)
 		if err != nil {
 			s.sendToast(w, toast.WithErr(fmt.Errorf("failed to install %v: %w", p.manifestName, err)))
 		} else if dryRun {
-			if yamlOutput, err := clientutils.Format(clientutils.OutputFormatYAML, false, pkg); err != nil {
-				s.sendToast(w, toast.WithErr(fmt.Errorf("failed to render yaml: %w", err)))
+			s.sendYamlModal(w, pkg, nil)
+		} else {
+			s.recordPackageOperation(ctx, audit.OperationInstall, pkg, "", p.version)
+			if mf.PostInstallNotes != "" {
+				// the detail page shows the notes as soon as the package is installed
+				s.swappingRedirect(w, webutil.GetPackageHref(pkg, mf), "main", "main")
 			} else {
-				s.sendYamlModal(w, yamlOutput, nil)
+				s.swappingRedirect(w, "/packages", "main", "main")
 			}
-		} else {
-			s.swappingRedirect(w, "/packages", "main", "main")
 			w.WriteHeader(http.StatusAccepted)
 		}
 	} else {
-		pkg.Spec.PackageInfo.Version = p.version
+		versionBefore := pkg.Spec.PackageInfo.Version
+		operation := audit.OperationConfigure
+		if versionBefore != p.version {
+			operation = audit.OperationUpdate
+		}
+		pkg.Spec.PackageInfo.SetVersion(p.version)
 		pkg.Spec.PackageInfo.RepositoryName = p.repositoryName
 		pkg.Spec.Values = values
 		pkg.SetAutoUpdatesEnabled(autoUpdate)
+		pkg.SetVersionConstraint(versionConstraint)
+		pkg.Spec.ImageRegistryMirrors = registryMirrors
+		pkg.Spec.Scheduling = schedulingOverrides
+		pkg.Spec.OptionalDependencies = extractOptionalDependencies(r, mf)
+		tracing.Inject(ctx, pkg)
+		if !s.confirmImmutableChanges(w, r, pkg, mf) {
+			return
+		}
 		opts := v1.UpdateOptions{}
 		if dryRun {
 			opts.DryRun = []string{v1.DryRunAll}
@@ -399,14 +640,12 @@ func (s *server) installOrConfigurePackage(w http.ResponseWriter, r *http.Reques
 		if err := s.pkgClient.Packages(pkg.GetNamespace()).Update(ctx, pkg, opts); err != nil {
 			s.sendToast(w, toast.WithErr(fmt.Errorf("failed to configure %v: %w", p.manifestName, err)))
 			return
+		} else if !dryRun {
+			s.recordPackageOperation(ctx, operation, pkg, versionBefore, p.version)
 		}
 		_, resolveErr := s.valueResolver.Resolve(ctx, values)
 		if dryRun {
-			if yamlOutput, err := clientutils.Format(clientutils.OutputFormatYAML, false, pkg); err != nil {
-				s.sendToast(w, toast.WithErr(fmt.Errorf("failed to render yaml: %w", err)))
-			} else {
-				s.sendYamlModal(w, yamlOutput, resolveErr)
-			}
+			s.sendYamlModal(w, pkg, resolveErr)
 		} else if resolveErr != nil {
 			s.sendToast(w,
 				toast.WithErr(fmt.Errorf("some values could not be resolved: %w", resolveErr)),
@@ -425,9 +664,19 @@ func (s *server) installOrConfigurePackage(w http.ResponseWriter, r *http.Reques
 func (s *server) installOrConfigureClusterPackage(w http.ResponseWriter, r *http.Request, p *packageContextRequest) {
 	ctx := r.Context()
 	autoUpdate := strings.ToLower(r.FormValue("autoUpdate")) == "on"
+	versionConstraint := strings.TrimSpace(r.FormValue("versionConstraint"))
 	dryRun, _ := strconv.ParseBool(r.FormValue("dryRun"))
 
-	var err error
+	registryMirrors, err := registrymirror.Parse(r.FormValue("imageRegistryMirrors"))
+	if err != nil {
+		s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+	schedulingOverrides, err := scheduling.Parse(r.FormValue("scheduling"))
+	if err != nil {
+		s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
 	pkg := &v1alpha1.ClusterPackage{}
 	var mf *v1alpha1.PackageManifest
 	if err = s.pkgClient.ClusterPackages().Get(ctx, p.manifestName, pkg); err != nil && !errors.IsNotFound(err) {
@@ -445,6 +694,11 @@ func (s *server) installOrConfigureClusterPackage(w http.ResponseWriter, r *http
 		}
 	}
 
+	if err := validateVersionConstraint(p.version, versionConstraint); err != nil {
+		s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+
 	mf, err = s.resolveManifest(ctx, pkg, p.repositoryName, p.manifestName, p.version)
 	if repoerror.IsPartial(err) {
 		fmt.Fprintf(os.Stderr, "problem fetching manifest and repo, but installation can continue: %v", err)
@@ -456,13 +710,24 @@ func (s *server) installOrConfigureClusterPackage(w http.ResponseWriter, r *http
 	if values, err := extractValues(r, mf); err != nil {
 		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to parse values: %w", err)))
 		return
+	} else if err := manifestvalues.ValidateValueConfigurations(*mf, values); err != nil {
+		// only the first error is shown, all errors are shown inline when the inputs are changed
+		s.sendToast(w, toast.WithErr(multierr.Errors(err)[0]), toast.WithStatusCode(http.StatusBadRequest))
+		return
 	} else if pkg == nil {
 		pkg = client.PackageBuilder(p.manifestName).
 			WithVersion(p.version).
 			WithRepositoryName(p.repositoryName).
 			WithAutoUpdates(autoUpdate).
+			WithVersionConstraint(versionConstraint).
 			WithValues(values).
+			WithImageRegistryMirrors(registryMirrors).
+			WithScheduling(schedulingOverrides).
+			WithOptionalDependencies(extractOptionalDependencies(r, mf)).
 			BuildClusterPackage()
+		if !s.confirmQuota(w, r, pkg, mf) {
+			return
+		}
 		opts := v1.CreateOptions{}
 		if dryRun {
 			opts.DryRun = []string{v1.DryRunAll}
@@ -472,17 +737,28 @@ func (s *server) installOrConfigureClusterPackage(w http.ResponseWriter, r *http
 			s.sendToast(w, toast.WithErr(fmt.Errorf("failed to install %v: %w", p.manifestName, err)))
 			return
 		} else if dryRun {
-			if yamlOutput, err := clientutils.Format(clientutils.OutputFormatYAML, false, pkg); err != nil {
-				s.sendToast(w, toast.WithErr(fmt.Errorf("failed to render yaml: %w", err)))
-			} else {
-				s.sendYamlModal(w, yamlOutput, nil)
-			}
+			s.sendYamlModal(w, pkg, nil)
+		} else {
+			s.recordPackageOperation(ctx, audit.OperationInstall, pkg, "", p.version)
 		}
 	} else {
-		pkg.Spec.PackageInfo.Version = p.version
+		versionBefore := pkg.Spec.PackageInfo.Version
+		operation := audit.OperationConfigure
+		if versionBefore != p.version {
+			operation = audit.OperationUpdate
+		}
+		pkg.Spec.PackageInfo.SetVersion(p.version)
 		pkg.Spec.PackageInfo.RepositoryName = p.repositoryName
 		pkg.Spec.Values = values
 		pkg.SetAutoUpdatesEnabled(autoUpdate)
+		pkg.SetVersionConstraint(versionConstraint)
+		pkg.Spec.ImageRegistryMirrors = registryMirrors
+		pkg.Spec.Scheduling = schedulingOverrides
+		pkg.Spec.OptionalDependencies = extractOptionalDependencies(r, mf)
+		tracing.Inject(ctx, pkg)
+		if !s.confirmImmutableChanges(w, r, pkg, mf) {
+			return
+		}
 		opts := v1.UpdateOptions{}
 		if dryRun {
 			opts.DryRun = []string{v1.DryRunAll}
@@ -490,14 +766,12 @@ func (s *server) installOrConfigureClusterPackage(w http.ResponseWriter, r *http
 		if err := s.pkgClient.ClusterPackages().Update(ctx, pkg, opts); err != nil {
 			s.sendToast(w, toast.WithErr(fmt.Errorf("failed to configure %v: %w", p.manifestName, err)))
 			return
+		} else if !dryRun {
+			s.recordPackageOperation(ctx, operation, pkg, versionBefore, p.version)
 		}
 		_, resolveErr := s.valueResolver.Resolve(ctx, values)
 		if dryRun {
-			if yamlOutput, err := clientutils.Format(clientutils.OutputFormatYAML, false, pkg); err != nil {
-				s.sendToast(w, toast.WithErr(fmt.Errorf("failed to render yaml: %w", err)))
-			} else {
-				s.sendYamlModal(w, yamlOutput, resolveErr)
-			}
+			s.sendYamlModal(w, pkg, resolveErr)
 		} else if resolveErr != nil {
 			s.sendToast(w,
 				toast.WithErr(fmt.Errorf("some values could not be resolved: %w", resolveErr)),
@@ -509,6 +783,57 @@ func (s *server) installOrConfigureClusterPackage(w http.ResponseWriter, r *http
 	}
 }
 
+// confirmImmutableChanges returns true if the changed configuration of pkg can be applied. If it changes immutable
+// fields of resources, the user has to confirm that these resources are recreated first, see confirmImpact.
+func (s *server) confirmImmutableChanges(
+	w http.ResponseWriter,
+	r *http.Request,
+	pkg ctrlpkg.Package,
+	mf *v1alpha1.PackageManifest,
+) bool {
+	impact, err := s.getImmutableChangeImpact(r.Context(), pkg, mf)
+	if err != nil {
+		// the resources are applied by the operator either way, which reports any failures
+		fmt.Fprintf(os.Stderr, "failed to check %v for changes of immutable fields: %v\n", pkg.GetName(), err)
+		return true
+	} else if !s.confirmImpact(w, r, impact) {
+		return false
+	} else if !impact.isEmpty() {
+		pkg.RequestRecreate(time.Now())
+	}
+	return true
+}
+
+// confirmQuota returns true if pkg can be installed. If its resource requests exceed a ResourceQuota of its namespace,
+// the user has to confirm the installation first, see confirmImpact. With StrictQuota, it can not be confirmed at all.
+func (s *server) confirmQuota(
+	w http.ResponseWriter,
+	r *http.Request,
+	pkg ctrlpkg.Package,
+	mf *v1alpha1.PackageManifest,
+) bool {
+	impact, err := s.getQuotaImpact(r.Context(), pkg, mf)
+	if err != nil {
+		// exceeding a quota does not prevent creating the package, so a failed check should not either
+		fmt.Fprintf(os.Stderr, "failed to check the resource quotas for %v: %v\n", pkg.GetName(), err)
+		return true
+	}
+	return s.confirmImpact(w, r, impact)
+}
+
+// validateVersionConstraint checks that constraint is either empty or a valid semver constraint that is satisfied by
+// the selected version
+func validateVersionConstraint(version string, constraint string) error {
+	if constraint == "" {
+		return nil
+	} else if _, err := semver.ParseConstraint(constraint); err != nil {
+		return err
+	} else if err := semver.ValidateConstraint(version, constraint); err != nil {
+		return fmt.Errorf("selected version %v does not satisfy the version constraint %v: %w", version, constraint, err)
+	}
+	return nil
+}
+
 func (s *server) resolveManifest(ctx context.Context, pkg ctrlpkg.Package, repositoryName string, manifestName string, selectedVersion string) (
 	*v1alpha1.PackageManifest, error) {
 
@@ -516,8 +841,14 @@ func (s *server) resolveManifest(ctx context.Context, pkg ctrlpkg.Package, repos
 	var repoErr error
 	if pkg.IsNil() ||
 		(pkg.GetSpec().PackageInfo.RepositoryName != repositoryName || pkg.GetSpec().PackageInfo.Version != selectedVersion) {
+		_, span := tracing.Start(ctx, "repository fetch",
+			attribute.String("glasskube.repository.name", repositoryName),
+			attribute.String("glasskube.package.name", manifestName),
+			attribute.String("glasskube.package.version", selectedVersion))
 		repoClient := s.repoClientset.ForRepoWithName(repositoryName)
-		if err := repoClient.FetchPackageManifest(manifestName, selectedVersion, &mf); err != nil {
+		err := repoClient.FetchPackageManifest(manifestName, selectedVersion, &mf)
+		tracing.End(span, err)
+		if err != nil {
 			return nil, multierr.Append(err, repoErr)
 		}
 	} else {
@@ -0,0 +1,193 @@
+package web
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	repotypes "github.com/glasskube/glasskube/internal/repo/types"
+	"github.com/glasskube/glasskube/pkg/list"
+	"k8s.io/client-go/tools/cache"
+)
+
+// packageFilter is the search and filter state of the packages overview. It is read from the query string and also
+// rendered back into it, so that it survives SSE refreshes and can be shared as a link.
+type packageFilter struct {
+	Query      string
+	Category   string
+	Repository string
+	Installed  bool
+	Upgradable bool
+}
+
+func packageFilterFromRequest(r *http.Request) packageFilter {
+	installed, _ := strconv.ParseBool(r.FormValue("installed"))
+	upgradable, _ := strconv.ParseBool(r.FormValue("upgradable"))
+	return packageFilter{
+		Query:      strings.TrimSpace(r.FormValue("q")),
+		Category:   strings.TrimSpace(r.FormValue("category")),
+		Repository: strings.TrimSpace(r.FormValue("repository")),
+		Installed:  installed,
+		Upgradable: upgradable,
+	}
+}
+
+func (f packageFilter) IsEmpty() bool {
+	return f == packageFilter{}
+}
+
+// QueryString encodes the filter as a query string (without the leading "?")
+func (f packageFilter) QueryString() string {
+	values := url.Values{}
+	if f.Query != "" {
+		values.Set("q", f.Query)
+	}
+	if f.Category != "" {
+		values.Set("category", f.Category)
+	}
+	if f.Repository != "" {
+		values.Set("repository", f.Repository)
+	}
+	if f.Installed {
+		values.Set("installed", "true")
+	}
+	if f.Upgradable {
+		values.Set("upgradable", "true")
+	}
+	return values.Encode()
+}
+
+// Matches returns true if the given package matches the search query and category of the filter. The query is split
+// into words and every word must be contained in either the name, description, keywords or categories of the
+// package. The manifest is optional and only used for installed packages, whose manifest may differ from the index.
+// The category "Uncategorized" matches packages without any category.
+func (f packageFilter) Matches(item repotypes.PackageRepoIndexItem, manifest *v1alpha1.PackageManifest) bool {
+	categories := slices.Clone(item.Categories)
+	searchable := []string{item.Name, item.ShortDescription}
+	searchable = append(searchable, item.Keywords...)
+	if manifest != nil {
+		categories = append(categories, manifest.Categories...)
+		searchable = append(searchable, manifest.ShortDescription)
+		searchable = append(searchable, manifest.Keywords...)
+	}
+	searchable = append(searchable, categories...)
+
+	if f.Category != "" && !slices.ContainsFunc(categories, func(c string) bool {
+		return strings.EqualFold(c, f.Category)
+	}) && !(len(categories) == 0 && strings.EqualFold(f.Category, uncategorized)) {
+		return false
+	}
+	for _, word := range strings.Fields(strings.ToLower(f.Query)) {
+		if !slices.ContainsFunc(searchable, func(s string) bool {
+			return strings.Contains(strings.ToLower(s), word)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// apply removes all packages from the overview that do not match the filter
+func (f packageFilter) apply(overview *packagesOverview) {
+	if f.IsEmpty() {
+		return
+	}
+	overview.installed = slices.DeleteFunc(overview.installed, func(pkgs *list.PackagesWithStatus) bool {
+		if f.Repository != "" {
+			pkgs.Packages = slices.DeleteFunc(slices.Clone(pkgs.Packages), func(pkg *list.PackageWithStatus) bool {
+				return packageOrigin(pkg.Package) != f.Repository
+			})
+		}
+		if f.Upgradable {
+			pkgs.Packages = slices.DeleteFunc(slices.Clone(pkgs.Packages), func(pkg *list.PackageWithStatus) bool {
+				return !overview.updateAvailable[cache.MetaObjectToName(pkg.Package).String()]
+			})
+		}
+		if len(pkgs.Packages) == 0 {
+			return true
+		}
+		return !f.Matches(pkgs.PackageRepoIndexItem, pkgs.Packages[0].InstalledManifest)
+	})
+	if f.Installed || f.Upgradable {
+		overview.available = nil
+	} else {
+		overview.available = slices.DeleteFunc(overview.available, func(item *repotypes.PackageRepoIndexItem) bool {
+			return !f.Matches(*item, nil) ||
+				(f.Repository != "" && !slices.Contains(overview.repos[item.Name], f.Repository))
+		})
+	}
+}
+
+// categories returns the sorted, distinct categories of all packages in the overview. If any package does not have a
+// category, "Uncategorized" is added as the last category.
+func (overview *packagesOverview) categories() []string {
+	var categories []string
+	hasUncategorized := false
+	for _, pkgs := range overview.installed {
+		categories = append(categories, pkgs.Categories...)
+		hasUncategorized = hasUncategorized || len(pkgs.Categories) == 0
+	}
+	for _, item := range overview.available {
+		categories = append(categories, item.Categories...)
+		hasUncategorized = hasUncategorized || len(item.Categories) == 0
+	}
+	slices.Sort(categories)
+	categories = slices.Compact(categories)
+	if hasUncategorized && !slices.Contains(categories, uncategorized) {
+		categories = append(categories, uncategorized)
+	}
+	return categories
+}
+
+// repositories returns the sorted, distinct repositories that packages in the overview are available in or were
+// installed from
+func (overview *packagesOverview) repositories() []string {
+	var repos []string
+	for _, r := range overview.repos {
+		repos = append(repos, r...)
+	}
+	for _, r := range overview.origins {
+		repos = append(repos, r...)
+	}
+	slices.Sort(repos)
+	return slices.Compact(repos)
+}
+
+// packageOrigin returns the name of the repository the given package was installed from. This is the repository that
+// the repo clientset resolves for the package.
+func packageOrigin(pkg ctrlpkg.Package) string {
+	if pkg == nil || pkg.IsNil() {
+		return ""
+	}
+	return pkg.GetSpec().PackageInfo.RepositoryName
+}
+
+// repositories returns the sorted, distinct repositories that clusterpackages in the overview are available in or
+// were installed from
+func (overview *clusterPackagesOverview) repositories() []string {
+	var repos []string
+	for _, item := range overview.clusterPackages {
+		repos = append(repos, item.Repos...)
+		repos = append(repos, overview.origins[item.Name]...)
+	}
+	slices.Sort(repos)
+	return slices.Compact(repos)
+}
+
+// filterRepository removes all clusterpackages that were not installed from the given repository or, if they are not
+// installed, are not available in it
+func (overview *clusterPackagesOverview) filterRepository(repository string) {
+	if repository == "" {
+		return
+	}
+	overview.clusterPackages = slices.DeleteFunc(overview.clusterPackages, func(item *list.PackageWithStatus) bool {
+		if item.ClusterPackage != nil {
+			return packageOrigin(item.ClusterPackage) != repository
+		}
+		return !slices.Contains(item.Repos, repository)
+	})
+}
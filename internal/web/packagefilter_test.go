@@ -0,0 +1,76 @@
+package web
+
+import (
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	repotypes "github.com/glasskube/glasskube/internal/repo/types"
+	"github.com/glasskube/glasskube/pkg/list"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Package Filter", func() {
+	item := repotypes.PackageRepoIndexItem{
+		Name:             "cert-manager",
+		ShortDescription: "X.509 certificate management for Kubernetes",
+		Keywords:         []string{"tls", "acme"},
+		Categories:       []string{"Security"},
+	}
+
+	DescribeTable("Matches",
+		func(filter packageFilter, manifest *v1alpha1.PackageManifest, expected bool) {
+			Expect(filter.Matches(item, manifest)).To(Equal(expected))
+		},
+		Entry("Empty filter", packageFilter{}, nil, true),
+		Entry("Name", packageFilter{Query: "cert"}, nil, true),
+		Entry("Description, case insensitive", packageFilter{Query: "KUBERNETES"}, nil, true),
+		Entry("Keyword", packageFilter{Query: "acme"}, nil, true),
+		Entry("Category as query", packageFilter{Query: "security"}, nil, true),
+		Entry("All words must match", packageFilter{Query: "cert tls"}, nil, true),
+		Entry("One word does not match", packageFilter{Query: "cert postgres"}, nil, false),
+		Entry("Category", packageFilter{Category: "security"}, nil, true),
+		Entry("Other category", packageFilter{Category: "Databases"}, nil, false),
+		Entry("Keyword of installed manifest", packageFilter{Query: "issuer"},
+			&v1alpha1.PackageManifest{Keywords: []string{"issuer"}}, true),
+		Entry("Category of installed manifest", packageFilter{Category: "Networking"},
+			&v1alpha1.PackageManifest{Categories: []string{"Networking"}}, true),
+	)
+
+	DescribeTable("QueryString",
+		func(filter packageFilter, expected string) {
+			Expect(filter.QueryString()).To(Equal(expected))
+		},
+		Entry("Empty filter", packageFilter{}, ""),
+		Entry("All fields", packageFilter{Query: "a b", Category: "Security", Installed: true, Upgradable: true},
+			"category=Security&installed=true&q=a+b&upgradable=true"),
+		Entry("Repository", packageFilter{Repository: "internal"}, "repository=internal"),
+	)
+
+	It("should filter by repository", func() {
+		pkg := func(name, repo string) *list.PackageWithStatus {
+			return &list.PackageWithStatus{Package: &v1alpha1.Package{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec:       v1alpha1.PackageSpec{PackageInfo: v1alpha1.PackageInfoTemplate{RepositoryName: repo}},
+			}}
+		}
+		overview := packagesOverview{
+			installed: []*list.PackagesWithStatus{
+				{
+					MetaIndexItem: repotypes.MetaIndexItem{PackageRepoIndexItem: repotypes.PackageRepoIndexItem{Name: "a"}},
+					Packages:      []*list.PackageWithStatus{pkg("a1", "glasskube"), pkg("a2", "internal")},
+				},
+				{
+					MetaIndexItem: repotypes.MetaIndexItem{PackageRepoIndexItem: repotypes.PackageRepoIndexItem{Name: "b"}},
+					Packages:      []*list.PackageWithStatus{pkg("b1", "glasskube")},
+				},
+			},
+			available: []*repotypes.PackageRepoIndexItem{{Name: "c"}, {Name: "d"}},
+			repos:     map[string][]string{"c": {"glasskube", "internal"}, "d": {"glasskube"}},
+		}
+		packageFilter{Repository: "internal"}.apply(&overview)
+		Expect(overview.installed).To(HaveLen(1))
+		Expect(overview.installed[0].Packages).To(HaveLen(1))
+		Expect(overview.installed[0].Packages[0].Package.Name).To(Equal("a2"))
+		Expect(overview.available).To(Equal([]*repotypes.PackageRepoIndexItem{{Name: "c"}}))
+	})
+})
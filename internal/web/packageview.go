@@ -0,0 +1,261 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	semverutil "github.com/glasskube/glasskube/internal/semver"
+	"github.com/glasskube/glasskube/internal/web/components/toast"
+	"github.com/glasskube/glasskube/pkg/client"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	packagesViewKey = "packagesView"
+	// packagesViewChangedEvent is triggered on the client after the view has been changed, so that the overview can
+	// refresh itself
+	packagesViewChangedEvent = "packages-view-changed"
+
+	packagesViewCards = "cards"
+	packagesViewTable = "table"
+)
+
+// sortColumns are the columns of the table view that packages can be sorted by
+var sortColumns = []string{"name", "namespace", "version", "latest", "status"}
+
+// getPackagesViewFromCookie returns the view of the packages overview that the current user has chosen
+func getPackagesViewFromCookie(r *http.Request) string {
+	if c, err := r.Cookie(packagesViewKey); err == nil && c.Value == packagesViewTable {
+		return packagesViewTable
+	}
+	return packagesViewCards
+}
+
+func setPackagesViewCookie(w http.ResponseWriter, view string) {
+	cookie := http.Cookie{
+		Name:     packagesViewKey,
+		Value:    view,
+		Path:     "/",
+		MaxAge:   60 * 60 * 24 * 365,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	}
+	if view == packagesViewCards {
+		cookie.MaxAge = -1
+	}
+	http.SetCookie(w, &cookie)
+}
+
+// setPackagesView switches the packages overview of the current user between the card and the table view
+func (s *server) setPackagesView(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	view := r.FormValue("view")
+	if view != packagesViewCards && view != packagesViewTable {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("invalid view %v", view)), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+	setPackagesViewCookie(w, view)
+	w.Header().Set("Hx-Trigger", packagesViewChangedEvent)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// packageSort is the sort order of the table view. Like the packageFilter, it is read from and rendered back into
+// the query string. If Column is empty, the order of the card view is kept.
+type packageSort struct {
+	Column string
+	Desc   bool
+	// page is used to create the links of the column headers, which keep the filter and the page size
+	page pagination
+}
+
+func packageSortFromRequest(r *http.Request) packageSort {
+	column := r.FormValue("sort")
+	if !slices.Contains(sortColumns, column) {
+		return packageSort{}
+	}
+	return packageSort{Column: column, Desc: r.FormValue("order") == "desc"}
+}
+
+// QueryString encodes the sort order as a query string (without the leading "?")
+func (s packageSort) QueryString() string {
+	values := url.Values{}
+	if s.Column != "" {
+		values.Set("sort", s.Column)
+		if s.Desc {
+			values.Set("order", "desc")
+		}
+	}
+	return values.Encode()
+}
+
+// Href returns the link that sorts by the given column. If the table is already sorted by it ascending, the link
+// sorts descending instead. Sorting always starts at the first page.
+func (s packageSort) Href(column string) string {
+	p := s.page
+	p.query = url.Values{}
+	for key, values := range s.page.query {
+		p.query[key] = values
+	}
+	p.query.Set("sort", column)
+	if column == s.Column && !s.Desc {
+		p.query.Set("order", "desc")
+	} else {
+		p.query.Del("order")
+	}
+	return p.Href(1)
+}
+
+// Indicator returns the arrow shown in the header of the given column if the table is sorted by it
+func (s packageSort) Indicator(column string) string {
+	if column != s.Column {
+		return ""
+	} else if s.Desc {
+		return "▼"
+	}
+	return "▲"
+}
+
+// packageTableRow is a single row of the table view: either an installed package or a package that is available,
+// but not installed.
+type packageTableRow struct {
+	Name             string
+	ShortDescription string
+	IconUrl          string
+	Repos            []string
+	// Package is nil if the package is not installed
+	Package         *v1alpha1.Package
+	Status          *client.PackageStatus
+	LatestVersion   string
+	UpdateAvailable bool
+}
+
+func (row packageTableRow) Namespace() string {
+	if row.Package != nil {
+		return row.Package.Namespace
+	}
+	return ""
+}
+
+func (row packageTableRow) Version() string {
+	if row.Package != nil {
+		return row.Package.Spec.PackageInfo.Version
+	}
+	return ""
+}
+
+func (row packageTableRow) StatusText() string {
+	if row.Status != nil {
+		return row.Status.Status
+	}
+	return ""
+}
+
+func (row packageTableRow) Href() string {
+	if row.Package != nil {
+		return fmt.Sprintf("/packages/%v/%v/%v", row.Name, row.Package.Namespace, row.Package.Name)
+	}
+	return "/packages/" + row.Name
+}
+
+// tableRows flattens the overview into the rows of the table view: one row for every installed package, followed by
+// one row for every available package
+func (overview *packagesOverview) tableRows() []packageTableRow {
+	var rows []packageTableRow
+	for _, pkgs := range overview.installed {
+		for _, pkg := range pkgs.Packages {
+			rows = append(rows, packageTableRow{
+				Name:             pkgs.Name,
+				ShortDescription: pkgs.ShortDescription,
+				IconUrl:          pkgs.IconUrl,
+				Repos:            pkgs.Repos,
+				Package:          pkg.Package,
+				Status:           pkg.Status,
+				LatestVersion:    pkgs.LatestVersion,
+				UpdateAvailable:  overview.updateAvailable[cache.MetaObjectToName(pkg.Package).String()],
+			})
+		}
+	}
+	for _, item := range overview.available {
+		rows = append(rows, packageTableRow{
+			Name:             item.Name,
+			ShortDescription: item.ShortDescription,
+			IconUrl:          item.IconUrl,
+			Repos:            overview.repos[item.Name],
+			LatestVersion:    item.LatestVersion,
+		})
+	}
+	return rows
+}
+
+// apply sorts the rows by the column of the sort order. Rows with an empty value in this column, e.g. the namespace
+// of packages that are not installed, are always sorted last.
+func (s packageSort) apply(rows []packageTableRow) {
+	if s.Column == "" {
+		return
+	}
+	key := func(row packageTableRow) string {
+		switch s.Column {
+		case "namespace":
+			return row.Namespace()
+		case "version":
+			return row.Version()
+		case "latest":
+			return row.LatestVersion
+		case "status":
+			return row.StatusText()
+		default:
+			return row.Name
+		}
+	}
+	compareKeys := strings.Compare
+	if s.Column == "version" || s.Column == "latest" {
+		compareKeys = compareVersions
+	}
+	slices.SortStableFunc(rows, func(a, b packageTableRow) int {
+		keyA, keyB := key(a), key(b)
+		if keyA == "" && keyB != "" {
+			return 1
+		} else if keyA != "" && keyB == "" {
+			return -1
+		}
+		result := compareKeys(keyA, keyB)
+		if result == 0 && s.Column == "name" && a.Package != nil && b.Package != nil {
+			result = strings.Compare(a.Package.Name, b.Package.Name)
+		}
+		if s.Desc {
+			result = -result
+		}
+		return result
+	})
+}
+
+// compareVersions compares two versions by semantic versioning, including numeric build metadata like the
+// revision of a package version. Invalid versions are compared as strings and sorted before valid versions.
+func compareVersions(a, b string) int {
+	versionA, errA := semver.NewVersion(a)
+	versionB, errB := semver.NewVersion(b)
+	switch {
+	case errA == nil && errB == nil:
+		if semverutil.IsVersionUpgradable(versionA, versionB) {
+			return -1
+		} else if semverutil.IsVersionUpgradable(versionB, versionA) {
+			return 1
+		}
+		return 0
+	case errA != nil && errB != nil:
+		return strings.Compare(a, b)
+	case errA != nil:
+		return -1
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,84 @@
+package web
+
+import (
+	"net/http/httptest"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/pkg/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Package Table View", func() {
+	installed := func(name, namespace, instance, version, status string) packageTableRow {
+		return packageTableRow{
+			Name: name,
+			Package: &v1alpha1.Package{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: instance},
+				Spec:       v1alpha1.PackageSpec{PackageInfo: v1alpha1.PackageInfoTemplate{Version: version}},
+			},
+			Status: &client.PackageStatus{Status: status},
+		}
+	}
+	rows := func() []packageTableRow {
+		return []packageTableRow{
+			installed("b", "ns2", "b", "v1.10.0+1", "Ready"),
+			installed("a", "ns1", "a2", "v1.9.0+1", "Failed"),
+			{Name: "c", LatestVersion: "v2.0.0+1"},
+			installed("a", "ns3", "a1", "v1.9.0+2", "Ready"),
+		}
+	}
+	keys := func(rows []packageTableRow) []string {
+		var result []string
+		for _, row := range rows {
+			if row.Package != nil {
+				result = append(result, row.Name+"/"+row.Package.Name)
+			} else {
+				result = append(result, row.Name)
+			}
+		}
+		return result
+	}
+
+	DescribeTable("apply",
+		func(sort packageSort, expected []string) {
+			r := rows()
+			sort.apply(r)
+			Expect(keys(r)).To(Equal(expected))
+		},
+		Entry("unsorted", packageSort{}, []string{"b/b", "a/a2", "c", "a/a1"}),
+		Entry("by name", packageSort{Column: "name"}, []string{"a/a1", "a/a2", "b/b", "c"}),
+		Entry("by name descending", packageSort{Column: "name", Desc: true}, []string{"c", "b/b", "a/a2", "a/a1"}),
+		Entry("by version", packageSort{Column: "version"}, []string{"a/a2", "a/a1", "b/b", "c"}),
+		Entry("by version descending keeps empty last", packageSort{Column: "version", Desc: true},
+			[]string{"b/b", "a/a1", "a/a2", "c"}),
+		Entry("by namespace", packageSort{Column: "namespace"}, []string{"a/a2", "b/b", "a/a1", "c"}),
+		Entry("by status", packageSort{Column: "status"}, []string{"a/a2", "b/b", "a/a1", "c"}),
+	)
+
+	It("should ignore unknown sort columns", func() {
+		req := httptest.NewRequest("GET", "/packages?sort=foo&order=desc", nil)
+		Expect(packageSortFromRequest(req)).To(Equal(packageSort{}))
+	})
+
+	It("should create sort links that keep the filter", func() {
+		req := httptest.NewRequest("GET", "/packages?q=db&sort=name", nil)
+		sort := packageSortFromRequest(req)
+		sort.page = paginationFromRequest(req, "q=db&"+sort.QueryString())
+		Expect(sort.Href("name")).To(Equal("/packages?order=desc&q=db&sort=name"))
+		Expect(sort.Href("version")).To(Equal("/packages?q=db&sort=version"))
+		Expect(sort.Indicator("name")).To(Equal("▲"))
+		Expect(sort.Indicator("version")).To(BeEmpty())
+	})
+
+	It("should read the view from the cookie", func() {
+		req := httptest.NewRequest("GET", "/packages", nil)
+		Expect(getPackagesViewFromCookie(req)).To(Equal(packagesViewCards))
+		rec := httptest.NewRecorder()
+		setPackagesViewCookie(rec, packagesViewTable)
+		req.AddCookie(rec.Result().Cookies()[0])
+		Expect(getPackagesViewFromCookie(req)).To(Equal(packagesViewTable))
+	})
+})
@@ -0,0 +1,134 @@
+package web
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	// defaultPageSize is divisible by the number of columns of the package grid (3 or 4), so that all rows are full
+	defaultPageSize = 48
+	maxPageSize     = 240
+)
+
+// pageSizeOptions are offered in the page size selection of the overview
+var pageSizeOptions = []int{24, 48, 96, 240}
+
+// pagination is the paging state of an overview. Like the packageFilter, it is read from and rendered back into the
+// query string, so that the current page survives SSE refreshes and can be linked to.
+type pagination struct {
+	// Page is the current page, starting at 1
+	Page       int
+	PageSize   int
+	TotalCount int
+	path       string
+	query      url.Values
+}
+
+// paginationFromRequest reads the page and page size from the request. The query parameters of the given filter
+// (without page and page size) are kept in all links created by the pagination.
+func paginationFromRequest(r *http.Request, filterQuery string) pagination {
+	p := pagination{Page: 1, PageSize: defaultPageSize, path: r.URL.Path}
+	p.query, _ = url.ParseQuery(filterQuery)
+	if page, err := strconv.Atoi(r.FormValue("page")); err == nil && page > 0 {
+		p.Page = page
+	}
+	if pageSize, err := strconv.Atoi(r.FormValue("pageSize")); err == nil && pageSize > 0 {
+		p.PageSize = min(pageSize, maxPageSize)
+	}
+	return p
+}
+
+// setTotalCount sets the number of items of all pages. If the current page is out of range, the last page is used
+// instead.
+func (p *pagination) setTotalCount(totalCount int) {
+	p.TotalCount = totalCount
+	p.Page = max(1, min(p.Page, p.PageCount()))
+}
+
+func (p pagination) PageCount() int {
+	return max(1, (p.TotalCount+p.PageSize-1)/p.PageSize)
+}
+
+// Start returns the offset of the first item on the current page
+func (p pagination) Start() int {
+	return (p.Page - 1) * p.PageSize
+}
+
+// End returns the offset after the last item on the current page
+func (p pagination) End() int {
+	return min(p.TotalCount, p.Page*p.PageSize)
+}
+
+// FirstItem returns the 1-based number of the first item on the current page, as shown to the user
+func (p pagination) FirstItem() int {
+	return min(p.Start()+1, p.TotalCount)
+}
+
+func (p pagination) HasPrevious() bool {
+	return p.Page > 1
+}
+
+func (p pagination) HasNext() bool {
+	return p.Page < p.PageCount()
+}
+
+// Pages returns the page numbers that are linked in the page controls: the first and last page and the pages around
+// the current page. Gaps are represented by 0.
+func (p pagination) Pages() []int {
+	var pages []int
+	for page := 1; page <= p.PageCount(); page++ {
+		if page == 1 || page == p.PageCount() || (page >= p.Page-2 && page <= p.Page+2) {
+			pages = append(pages, page)
+		} else if len(pages) > 0 && pages[len(pages)-1] != 0 {
+			pages = append(pages, 0)
+		}
+	}
+	return pages
+}
+
+func (p pagination) PageSizeOptions() []int {
+	return pageSizeOptions
+}
+
+// Href returns the link to the given page, including the filter and page size
+func (p pagination) Href(page int) string {
+	query := url.Values{}
+	for key, values := range p.query {
+		query[key] = values
+	}
+	if page > 1 {
+		query.Set("page", strconv.Itoa(page))
+	}
+	if p.PageSize != defaultPageSize {
+		query.Set("pageSize", strconv.Itoa(p.PageSize))
+	}
+	if len(query) == 0 {
+		return p.path
+	}
+	return p.path + "?" + query.Encode()
+}
+
+// CurrentHref returns the link to the current page
+func (p pagination) CurrentHref() string {
+	return p.Href(p.Page)
+}
+
+func (p pagination) PreviousHref() string {
+	return p.Href(p.Page - 1)
+}
+
+func (p pagination) NextHref() string {
+	return p.Href(p.Page + 1)
+}
+
+// apply removes all packages from the overview that are not shown on the current page. Installed packages come
+// before available packages, like on the page.
+func (p *pagination) apply(overview *packagesOverview) {
+	p.setTotalCount(len(overview.installed) + len(overview.available))
+	start, end := p.Start(), p.End()
+	installedCount := len(overview.installed)
+	overview.installed = overview.installed[min(start, installedCount):min(end, installedCount)]
+	overview.available = overview.available[max(0, start-installedCount):max(0, end-installedCount)]
+}
@@ -0,0 +1,71 @@
+package web
+
+import (
+	"net/http/httptest"
+
+	repotypes "github.com/glasskube/glasskube/internal/repo/types"
+	"github.com/glasskube/glasskube/pkg/list"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pagination", func() {
+	names := func(overview packagesOverview) []string {
+		var result []string
+		for _, pkgs := range overview.installed {
+			result = append(result, pkgs.Name)
+		}
+		for _, item := range overview.available {
+			result = append(result, item.Name)
+		}
+		return result
+	}
+
+	DescribeTable("apply",
+		func(page, pageSize int, expected []string) {
+			overview := packagesOverview{
+				installed: []*list.PackagesWithStatus{
+					{MetaIndexItem: repotypes.MetaIndexItem{PackageRepoIndexItem: repotypes.PackageRepoIndexItem{Name: "a"}}},
+					{MetaIndexItem: repotypes.MetaIndexItem{PackageRepoIndexItem: repotypes.PackageRepoIndexItem{Name: "b"}}},
+				},
+				available: []*repotypes.PackageRepoIndexItem{{Name: "c"}, {Name: "d"}, {Name: "e"}},
+			}
+			p := pagination{Page: page, PageSize: pageSize}
+			p.apply(&overview)
+			Expect(names(overview)).To(Equal(expected))
+			Expect(p.TotalCount).To(Equal(5))
+		},
+		Entry("First page", 1, 2, []string{"a", "b"}),
+		Entry("Installed and available", 1, 3, []string{"a", "b", "c"}),
+		Entry("Available only", 2, 2, []string{"c", "d"}),
+		Entry("Last page", 3, 2, []string{"e"}),
+		Entry("Out of range", 10, 2, []string{"e"}),
+		Entry("Everything", 1, 48, []string{"a", "b", "c", "d", "e"}),
+	)
+
+	DescribeTable("Pages",
+		func(page, totalCount int, expected []int) {
+			p := pagination{Page: page, PageSize: 10}
+			p.setTotalCount(totalCount)
+			Expect(p.Pages()).To(Equal(expected))
+		},
+		Entry("No items", 1, 0, []int{1}),
+		Entry("Few pages", 2, 30, []int{1, 2, 3}),
+		Entry("Gap at the end", 1, 100, []int{1, 2, 3, 0, 10}),
+		Entry("Gaps on both sides", 5, 100, []int{1, 0, 3, 4, 5, 6, 7, 0, 10}),
+	)
+
+	It("should keep the filter and page size in links", func() {
+		r := httptest.NewRequest("GET", "/packages?q=cert&page=2&pageSize=24", nil)
+		p := paginationFromRequest(r, "q=cert")
+		p.setTotalCount(100)
+		Expect(p.CurrentHref()).To(Equal("/packages?page=2&pageSize=24&q=cert"))
+		Expect(p.PreviousHref()).To(Equal("/packages?pageSize=24&q=cert"))
+	})
+
+	It("should limit the page size", func() {
+		r := httptest.NewRequest("GET", "/packages?pageSize=100000", nil)
+		Expect(paginationFromRequest(r, "").PageSize).To(Equal(maxPageSize))
+	})
+})
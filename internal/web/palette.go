@@ -0,0 +1,252 @@
+package web
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	webutil "github.com/glasskube/glasskube/internal/web/util"
+	"k8s.io/client-go/tools/cache"
+)
+
+type paletteItemKind string
+
+const (
+	palettePage    paletteItemKind = "page"
+	palettePackage paletteItemKind = "package"
+	paletteAction  paletteItemKind = "action"
+)
+
+const (
+	paletteDefaultLimit = 20
+	paletteMaxLimit     = 100
+)
+
+// paletteItem is an entry of the command palette. Selecting it navigates to Href or, if Modal is set, loads the
+// element matching ModalSelect from Modal into the modal container.
+type paletteItem struct {
+	Kind        paletteItemKind `json:"kind"`
+	Title       string          `json:"title"`
+	Subtitle    string          `json:"subtitle,omitempty"`
+	Icon        string          `json:"icon,omitempty"`
+	Href        string          `json:"href"`
+	Modal       string          `json:"modal,omitempty"`
+	ModalSelect string          `json:"modalSelect,omitempty"`
+	Installed   bool            `json:"installed"`
+	// keywords are matched in addition to the title, but with a lower rank
+	keywords []string
+}
+
+type paletteResponse struct {
+	Items []paletteItem `json:"items"`
+}
+
+// apiPalette returns the entries of the command palette matching the query parameter "q", best matches first. It is
+// based on the same data as the overview pages, so installed and available packages are included.
+func (s *server) apiPalette(w http.ResponseWriter, r *http.Request) error {
+	limit := paletteDefaultLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed > 0 {
+			limit = min(parsed, paletteMaxLimit)
+		}
+	}
+	items, err := s.getPaletteItems(r)
+	if err != nil && len(items) == 0 {
+		return err
+	}
+	writeJSON(w, r, http.StatusOK, paletteResponse{Items: rankPaletteItems(items, r.URL.Query().Get("q"), limit)})
+	return nil
+}
+
+func (s *server) getPaletteItems(r *http.Request) ([]paletteItem, error) {
+	ctx := r.Context()
+	t := func(key string, args ...any) string {
+		if s.templates.messages == nil {
+			return key
+		}
+		return s.templates.messages.Catalog(s.requestLocale(r)).T(key, args...)
+	}
+	withActions := !s.ReadOnly && !s.isGitopsModeEnabled()
+
+	items := []paletteItem{
+		{Kind: palettePage, Title: t("nav.clusterPackages"), Icon: "bi-box-seam", Href: "/clusterpackages"},
+		{Kind: palettePage, Title: t("nav.packages"), Icon: "bi-boxes", Href: "/packages"},
+		{Kind: palettePage, Title: t("nav.categories"), Icon: "bi-tags", Href: "/categories"},
+		{Kind: palettePage, Title: t("nav.audit"), Icon: "bi-journal-text", Href: "/audit"},
+		{Kind: palettePage, Title: t("nav.settings"), Icon: "bi-gear", Href: "/settings",
+			keywords: []string{"repositories", "notifications", "theme", "language"}},
+	}
+
+	var repos v1alpha1.PackageRepositoryList
+	if err := s.pkgClient.PackageRepositories().GetAll(ctx, &repos); err == nil {
+		for _, repo := range repos.Items {
+			items = append(items, paletteItem{
+				Kind:     palettePage,
+				Title:    t("palette.repository", repo.Name),
+				Subtitle: repo.Spec.Url,
+				Icon:     "bi-database",
+				Href:     "/settings/repository/" + repo.Name,
+				keywords: []string{repo.Name},
+			})
+		}
+	}
+
+	addInstalled := func(pkg ctrlpkg.Package, packageName, href string, upgradable bool) {
+		title := pkg.GetName()
+		if pkg.IsNamespaceScoped() {
+			title = cache.MetaObjectToName(pkg).String()
+		}
+		keywords := []string{packageName, pkg.GetName()}
+		items = append(items, paletteItem{
+			Kind:      palettePackage,
+			Title:     title,
+			Subtitle:  t("palette.installed", pkg.GetSpec().PackageInfo.Version),
+			Icon:      "bi-box",
+			Href:      href,
+			Installed: true,
+			keywords:  keywords,
+		})
+		if !withActions {
+			return
+		}
+		if upgradable {
+			items = append(items, paletteItem{
+				Kind:      paletteAction,
+				Title:     t("palette.update", title),
+				Icon:      "bi-arrow-repeat",
+				Href:      href,
+				Installed: true,
+				keywords:  keywords,
+			})
+		}
+		items = append(items, paletteItem{
+			Kind:        paletteAction,
+			Title:       t("palette.uninstall", title),
+			Icon:        "bi-trash",
+			Href:        href,
+			Modal:       href + "/uninstall",
+			ModalSelect: "#pkg-uninstall-modal",
+			Installed:   true,
+			keywords:    keywords,
+		})
+	}
+	addAvailable := func(name, description, href string) {
+		items = append(items, paletteItem{
+			Kind:     palettePackage,
+			Title:    name,
+			Subtitle: description,
+			Icon:     "bi-box",
+			Href:     href,
+		})
+		if withActions {
+			items = append(items, paletteItem{
+				Kind:     paletteAction,
+				Title:    t("palette.install", name),
+				Icon:     "bi-download",
+				Href:     href,
+				keywords: []string{name},
+			})
+		}
+	}
+
+	clusterOverview, clusterErr := s.getClusterPackagesOverview(ctx)
+	for _, item := range clusterOverview.clusterPackages {
+		href := webutil.GetClusterPkgHref(item.Name)
+		if item.ClusterPackage != nil {
+			addInstalled(item.ClusterPackage, item.Name, href, clusterOverview.updateAvailable[item.Name])
+		} else {
+			addAvailable(item.Name, item.ShortDescription, href)
+		}
+	}
+	overview, err := s.getPackagesOverview(ctx)
+	for _, item := range overview.installed {
+		for _, pkgWithStatus := range item.Packages {
+			pkg := pkgWithStatus.Package
+			addInstalled(pkg, item.Name, webutil.GetNamespacedPkgHref(item.Name, pkg.Namespace, pkg.Name),
+				overview.updateAvailable[cache.MetaObjectToName(pkg).String()])
+		}
+		// namespaced packages can be installed multiple times
+		addAvailable(item.Name, item.ShortDescription, webutil.GetNamespacedPkgHref(item.Name, "", ""))
+	}
+	for _, item := range overview.available {
+		addAvailable(item.Name, item.ShortDescription, webutil.GetNamespacedPkgHref(item.Name, "", ""))
+	}
+	return items, cmp.Or(clusterErr, err)
+}
+
+// rankPaletteItems returns at most limit items that match the query, best matches first. Without a query, pages come
+// first, followed by installed packages.
+func rankPaletteItems(items []paletteItem, query string, limit int) []paletteItem {
+	type scored struct {
+		item  paletteItem
+		score int
+	}
+	query = strings.ToLower(strings.TrimSpace(query))
+	var matches []scored
+	for _, item := range items {
+		score := paletteMatchScore(strings.ToLower(item.Title), query) * 2
+		for _, keyword := range item.keywords {
+			score = max(score, paletteMatchScore(strings.ToLower(keyword), query))
+		}
+		if score == 0 {
+			continue
+		}
+		if query == "" && item.Kind == palettePage {
+			score += 2
+		}
+		if item.Installed {
+			score++
+		}
+		matches = append(matches, scored{item, score})
+	}
+	slices.SortStableFunc(matches, func(a, b scored) int {
+		return cmp.Or(cmp.Compare(b.score, a.score), cmp.Compare(a.item.Title, b.item.Title))
+	})
+	result := make([]paletteItem, 0, min(len(matches), limit))
+	for _, match := range matches[:min(len(matches), limit)] {
+		result = append(result, match.item)
+	}
+	return result
+}
+
+// paletteMatchScore rates how well text matches query: exact matches are rated best, followed by prefixes, prefixes
+// of words, substrings and finally texts that contain the characters of the query in the same order. Zero means no
+// match.
+func paletteMatchScore(text, query string) int {
+	switch {
+	case query == "":
+		return 1
+	case text == query:
+		return 50
+	case strings.HasPrefix(text, query):
+		return 40
+	case slices.ContainsFunc(strings.FieldsFunc(text, isPaletteSeparator), func(word string) bool {
+		return strings.HasPrefix(word, query)
+	}):
+		return 30
+	case strings.Contains(text, query):
+		return 20
+	case isSubsequence(text, query):
+		return 10
+	default:
+		return 0
+	}
+}
+
+func isPaletteSeparator(r rune) bool {
+	return r == ' ' || r == '-' || r == '/' || r == '_' || r == '.'
+}
+
+func isSubsequence(text, query string) bool {
+	remaining := []rune(query)
+	for _, r := range text {
+		if len(remaining) > 0 && r == remaining[0] {
+			remaining = remaining[1:]
+		}
+	}
+	return len(remaining) == 0
+}
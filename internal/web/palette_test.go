@@ -0,0 +1,42 @@
+package web
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Command palette", func() {
+	items := []paletteItem{
+		{Kind: palettePage, Title: "Settings", keywords: []string{"repositories"}},
+		{Kind: palettePackage, Title: "argo-cd"},
+		{Kind: paletteAction, Title: "Install argo-cd", keywords: []string{"argo-cd"}},
+		{Kind: palettePackage, Title: "default/argo-workflows", Installed: true, keywords: []string{"argo-workflows"}},
+		{Kind: palettePackage, Title: "cert-manager", Installed: true},
+	}
+	titles := func(items []paletteItem) []string {
+		result := make([]string, len(items))
+		for i, item := range items {
+			result[i] = item.Title
+		}
+		return result
+	}
+
+	It("should rank pages and installed packages first without a query", func() {
+		Expect(titles(rankPaletteItems(items, "", 3))).
+			To(Equal([]string{"Settings", "cert-manager", "default/argo-workflows"}))
+	})
+
+	It("should rank prefix matches before word and keyword matches", func() {
+		Expect(titles(rankPaletteItems(items, "argo", 10))).
+			To(Equal([]string{"argo-cd", "default/argo-workflows", "Install argo-cd"}))
+	})
+
+	It("should match keywords and subsequences", func() {
+		Expect(titles(rankPaletteItems(items, "repos", 10))).To(Equal([]string{"Settings"}))
+		Expect(titles(rankPaletteItems(items, "crtmgr", 10))).To(Equal([]string{"cert-manager"}))
+	})
+
+	It("should return nothing if nothing matches", func() {
+		Expect(rankPaletteItems(items, "xyz", 10)).To(BeEmpty())
+	})
+})
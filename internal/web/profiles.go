@@ -0,0 +1,211 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/manifestvalues"
+	repoerror "github.com/glasskube/glasskube/internal/repo/error"
+	"github.com/glasskube/glasskube/internal/web/components/toast"
+	"github.com/gorilla/mux"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	profileKey = "profile"
+	// cloneFromKey is the form value with the namespace and name of an installed package whose values are loaded into
+	// the configuration form, like the values of a profile
+	cloneFromKey = "cloneFrom"
+)
+
+// savePackageProfile is a POST endpoint that stores the values of the configuration form as a PackageProfile, named
+// by the "profile" form value. An existing profile of the same package is overwritten.
+func (s *server) savePackageProfile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := r.Context()
+	manifestName := mux.Vars(r)["manifestName"]
+	if manifestName == "" {
+		manifestName = mux.Vars(r)["pkgName"]
+	}
+	profileName := strings.TrimSpace(r.FormValue(profileKey))
+	if errs := validation.IsDNS1123Subdomain(profileName); len(errs) > 0 {
+		s.sendToast(w,
+			toast.WithErr(fmt.Errorf("invalid profile name %q: %v", profileName, strings.Join(errs, ", "))),
+			toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+
+	pkg, err := s.getInstalledPackageForRequest(ctx, r)
+	if err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to fetch package %v: %w", manifestName, err)))
+		return
+	}
+	repositoryName, version := r.FormValue("repositoryName"), r.FormValue("version")
+	if !pkg.IsNil() {
+		// disabled form elements are not submitted, so the installed repo and version are the fallback
+		if repositoryName == "" {
+			repositoryName = pkg.GetSpec().PackageInfo.RepositoryName
+		}
+		if version == "" {
+			version = pkg.GetSpec().PackageInfo.Version
+		}
+	}
+
+	mf, err := s.resolveManifest(ctx, pkg, repositoryName, manifestName, version)
+	if repoerror.IsPartial(err) {
+		fmt.Fprintf(os.Stderr, "problem fetching manifest and repo, but profile can be saved: %v\n", err)
+	} else if err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to get manifest of %v: %w", manifestName, err)))
+		return
+	}
+	values, err := extractValues(r, mf)
+	if err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to parse values: %w", err)))
+		return
+	}
+
+	spec := v1alpha1.PackageProfileSpec{
+		PackageName:    manifestName,
+		PackageVersion: version,
+		RepositoryName: repositoryName,
+		Values:         values,
+	}
+	var profile v1alpha1.PackageProfile
+	if err := s.pkgClient.PackageProfiles().Get(ctx, profileName, &profile); errors.IsNotFound(err) {
+		profile = v1alpha1.PackageProfile{ObjectMeta: metav1.ObjectMeta{Name: profileName}, Spec: spec}
+		err = s.pkgClient.PackageProfiles().Create(ctx, &profile, metav1.CreateOptions{})
+		if err != nil {
+			s.sendToast(w, toast.WithErr(fmt.Errorf("failed to create profile %v: %w", profileName, err)))
+			return
+		}
+	} else if err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to fetch profile %v: %w", profileName, err)))
+		return
+	} else if profile.Spec.PackageName != manifestName {
+		s.sendToast(w,
+			toast.WithErr(fmt.Errorf("profile %v already exists for package %v", profileName, profile.Spec.PackageName)),
+			toast.WithStatusCode(http.StatusConflict))
+		return
+	} else {
+		profile.Spec = spec
+		if err := s.pkgClient.PackageProfiles().Update(ctx, &profile, metav1.UpdateOptions{}); err != nil {
+			s.sendToast(w, toast.WithErr(fmt.Errorf("failed to update profile %v: %w", profileName, err)))
+			return
+		}
+	}
+	s.sendToast(w, toast.WithMessage(fmt.Sprintf("Profile %v saved", profileName)))
+}
+
+// getInstalledPackageForRequest returns the package or clusterpackage identified by the path of the request, or nil if
+// it is not installed
+func (s *server) getInstalledPackageForRequest(ctx context.Context, r *http.Request) (ctrlpkg.Package, error) {
+	vars := mux.Vars(r)
+	if pkgName, ok := vars["pkgName"]; ok {
+		var pkg v1alpha1.ClusterPackage
+		if err := s.pkgClient.ClusterPackages().Get(ctx, pkgName, &pkg); errors.IsNotFound(err) {
+			return (*v1alpha1.ClusterPackage)(nil), nil
+		} else if err != nil {
+			return nil, err
+		}
+		return &pkg, nil
+	} else if vars["namespace"] != "" && vars["name"] != "" {
+		var pkg v1alpha1.Package
+		if err := s.pkgClient.Packages(vars["namespace"]).Get(ctx, vars["name"], &pkg); errors.IsNotFound(err) {
+			return (*v1alpha1.Package)(nil), nil
+		} else if err != nil {
+			return nil, err
+		}
+		return &pkg, nil
+	}
+	return (*v1alpha1.Package)(nil), nil
+}
+
+// getPackageProfile returns the profile with the given name, if it belongs to the given package
+func (s *server) getPackageProfile(
+	ctx context.Context, profileName string, manifestName string) (*v1alpha1.PackageProfile, error) {
+	var profile v1alpha1.PackageProfile
+	if err := s.pkgClient.PackageProfiles().Get(ctx, profileName, &profile); err != nil {
+		return nil, fmt.Errorf("failed to fetch profile %v: %w", profileName, err)
+	} else if profile.Spec.PackageName != manifestName {
+		return nil, fmt.Errorf("profile %v belongs to package %v", profileName, profile.Spec.PackageName)
+	}
+	return &profile, nil
+}
+
+// getProfileOptions returns the names of all profiles of the given package
+func (s *server) getProfileOptions(ctx context.Context, manifestName string) ([]string, error) {
+	var profiles v1alpha1.PackageProfileList
+	if err := s.pkgClient.PackageProfiles().GetAll(ctx, &profiles); err != nil {
+		return nil, err
+	}
+	options := make([]string, 0)
+	for _, profile := range profiles.Items {
+		if profile.Spec.PackageName == manifestName {
+			options = append(options, profile.Name)
+		}
+	}
+	slices.Sort(options)
+	return options, nil
+}
+
+// getClonedProfile returns a profile with the values of the installed package identified by cloneFrom
+// ("namespace/name"), which must be an instance of the given package. References to the namespace of the source are
+// changed to targetNamespace (see manifestvalues.CloneValues). The names of the changed values are returned as well.
+func (s *server) getClonedProfile(
+	ctx context.Context,
+	cloneFrom string,
+	manifestName string,
+	targetNamespace string,
+) (*v1alpha1.PackageProfile, []string, error) {
+	namespace, name, ok := strings.Cut(cloneFrom, "/")
+	if !ok || namespace == "" || name == "" {
+		return nil, nil, fmt.Errorf("invalid package %q, expected namespace/name", cloneFrom)
+	}
+	var source v1alpha1.Package
+	if err := s.pkgClient.Packages(namespace).Get(ctx, name, &source); err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch package %v: %w", cloneFrom, err)
+	} else if source.Spec.PackageInfo.Name != manifestName {
+		return nil, nil, fmt.Errorf("%v is an instance of package %v", cloneFrom, source.Spec.PackageInfo.Name)
+	}
+	values, translated := manifestvalues.CloneValues(source.Spec.Values, namespace, targetNamespace)
+	return &v1alpha1.PackageProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: cloneFrom},
+		Spec: v1alpha1.PackageProfileSpec{
+			PackageName:    manifestName,
+			PackageVersion: source.Spec.PackageInfo.Version,
+			RepositoryName: source.Spec.PackageInfo.RepositoryName,
+			Values:         values,
+		},
+	}, translated, nil
+}
+
+// getCloneOptions returns the namespaces and names of all installed instances of the given package except pkg, whose
+// values can be cloned into the configuration form of pkg
+func (s *server) getCloneOptions(ctx context.Context, manifestName string, pkg ctrlpkg.Package) ([]string, error) {
+	pkgs, err := s.listInstalledPackages(ctx, updateAllScopeNamespaced)
+	if err != nil {
+		return nil, err
+	}
+	options := make([]string, 0)
+	for _, p := range pkgs {
+		if p.GetSpec().PackageInfo.Name != manifestName ||
+			(!pkg.IsNil() && p.GetNamespace() == pkg.GetNamespace() && p.GetName() == pkg.GetName()) {
+			continue
+		}
+		options = append(options, cache.MetaObjectToName(p).String())
+	}
+	slices.Sort(options)
+	return options, nil
+}
@@ -0,0 +1,194 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// eventsPath is the path of the SSE endpoint. Connections to it are long-lived, so they are limited by the number
+	// of concurrent connections instead of the request rate.
+	eventsPath = "/events"
+	// websocketPath is the path of the websocket endpoint. Like eventsPath, it is limited by concurrent connections.
+	websocketPath = "/ws"
+	// apiEventsSuffix is the suffix of the event streams of the JSON API, which are limited like eventsPath
+	apiEventsSuffix = "/events"
+	// rateLimitClientMaxIdle is the time after which the state of a client without requests is forgotten
+	rateLimitClientMaxIdle = 10 * time.Minute
+)
+
+var errTooManyRequests = errors.New("too many requests, please try again later")
+
+// RateLimitOptions configure the per-IP rate limiting of the web server
+type RateLimitOptions struct {
+	// RequestsPerSecond is the sustained number of requests a client may send. Zero disables rate limiting.
+	RequestsPerSecond float64
+	// Burst is the number of requests a client may send at once
+	Burst int
+	// MaxEventConnections is the number of SSE and websocket connections a client may keep open at the same time.
+	// Zero disables the limit.
+	MaxEventConnections int
+	// TrustedCIDRs are networks whose clients are never limited, e.g. internal networks
+	TrustedCIDRs []string
+}
+
+func DefaultRateLimitOptions() RateLimitOptions {
+	return RateLimitOptions{RequestsPerSecond: 20, Burst: 100, MaxEventConnections: 16}
+}
+
+type rateLimitClient struct {
+	limiter          *rate.Limiter
+	eventConnections int
+	lastSeen         time.Time
+}
+
+// rateLimiter keeps track of the request rate and the number of SSE connections of every client IP
+type rateLimiter struct {
+	RateLimitOptions
+	trusted     []netip.Prefix
+	mutex       sync.Mutex
+	clients     map[netip.Addr]*rateLimitClient
+	lastCleanup time.Time
+	now         func() time.Time
+}
+
+func newRateLimiter(opts RateLimitOptions) (*rateLimiter, error) {
+	l := rateLimiter{RateLimitOptions: opts, clients: make(map[netip.Addr]*rateLimitClient), now: time.Now}
+	for _, cidr := range opts.TrustedCIDRs {
+		if prefix, err := netip.ParsePrefix(cidr); err != nil {
+			return nil, fmt.Errorf("invalid trusted CIDR %v: %w", cidr, err)
+		} else {
+			l.trusted = append(l.trusted, prefix.Masked())
+		}
+	}
+	return &l, nil
+}
+
+func (l *rateLimiter) isTrusted(addr netip.Addr) bool {
+	for _, prefix := range l.trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// client returns the state of the given client. The caller must hold the mutex.
+func (l *rateLimiter) client(addr netip.Addr) *rateLimitClient {
+	now := l.now()
+	if now.Sub(l.lastCleanup) > rateLimitClientMaxIdle {
+		for a, c := range l.clients {
+			if c.eventConnections == 0 && now.Sub(c.lastSeen) > rateLimitClientMaxIdle {
+				delete(l.clients, a)
+			}
+		}
+		l.lastCleanup = now
+	}
+	c, ok := l.clients[addr]
+	if !ok {
+		c = &rateLimitClient{limiter: rate.NewLimiter(rate.Limit(l.RequestsPerSecond), max(1, l.Burst))}
+		l.clients[addr] = c
+	}
+	c.lastSeen = now
+	return c
+}
+
+// allowRequest returns whether a client may send a request now. Otherwise, it also returns the time after which the
+// request would be allowed.
+func (l *rateLimiter) allowRequest(addr netip.Addr) (bool, time.Duration) {
+	if l.RequestsPerSecond <= 0 || l.isTrusted(addr) {
+		return true, 0
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	now := l.now()
+	reservation := l.client(addr).limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// acquireEventConnection returns whether a client may open another SSE connection. If true is returned, the
+// connection must be released with releaseEventConnection when it is closed.
+func (l *rateLimiter) acquireEventConnection(addr netip.Addr) bool {
+	if l.MaxEventConnections <= 0 || l.isTrusted(addr) {
+		return true
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	c := l.client(addr)
+	if c.eventConnections >= l.MaxEventConnections {
+		return false
+	}
+	c.eventConnections++
+	return true
+}
+
+func (l *rateLimiter) releaseEventConnection(addr netip.Addr) {
+	if l.MaxEventConnections <= 0 || l.isTrusted(addr) {
+		return
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if c, ok := l.clients[addr]; ok && c.eventConnections > 0 {
+		c.eventConnections--
+		c.lastSeen = l.now()
+	}
+}
+
+// rateLimitMiddleware rejects requests of clients that exceed the configured limits with 429 Too Many Requests.
+// Clients are identified by their remote IP. If the server is behind a reverse proxy, the proxy should limit the
+// requests instead, or its address should be trusted.
+func (s *server) rateLimitMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		addr, ok := clientAddr(r)
+		if !ok {
+			next.ServeHTTP(w, r)
+		} else if isEventStreamPath(r.URL.Path) {
+			if !s.rateLimiter.acquireEventConnection(addr) {
+				s.metrics.rateLimitedRequests.WithLabelValues("events").Inc()
+				w.Header().Set("Retry-After", "60")
+				http.Error(w, errTooManyRequests.Error(), http.StatusTooManyRequests)
+				return
+			}
+			defer s.rateLimiter.releaseEventConnection(addr)
+			next.ServeHTTP(w, r)
+		} else if allowed, retryAfter := s.rateLimiter.allowRequest(addr); !allowed {
+			s.metrics.rateLimitedRequests.WithLabelValues("requests").Inc()
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			s.sendError(w, r, http.StatusTooManyRequests, apiErrorRateLimited, errTooManyRequests)
+		} else {
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// isEventStreamPath returns true if path is the path (or route template) of a long-lived connection
+func isEventStreamPath(path string) bool {
+	return path == eventsPath || path == websocketPath ||
+		(strings.HasPrefix(path, apiPathPrefix) && strings.HasSuffix(path, apiEventsSuffix))
+}
+
+func clientAddr(r *http.Request) (netip.Addr, bool) {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if addr, err := netip.ParseAddr(host); err != nil {
+		return netip.Addr{}, false
+	} else {
+		return addr.Unmap(), true
+	}
+}
@@ -0,0 +1,73 @@
+package web
+
+import (
+	"net/netip"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("rateLimiter", func() {
+	client := netip.MustParseAddr("203.0.113.7")
+	var now time.Time
+	var limiter *rateLimiter
+
+	BeforeEach(func() {
+		var err error
+		now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		limiter, err = newRateLimiter(RateLimitOptions{
+			RequestsPerSecond:   1,
+			Burst:               2,
+			MaxEventConnections: 1,
+			TrustedCIDRs:        []string{"10.0.0.0/8"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		limiter.now = func() time.Time { return now }
+	})
+
+	It("should reject requests that exceed the burst until tokens are available again", func() {
+		Expect(limiter.allowRequest(client)).To(BeTrue())
+		Expect(limiter.allowRequest(client)).To(BeTrue())
+		allowed, retryAfter := limiter.allowRequest(client)
+		Expect(allowed).To(BeFalse())
+		Expect(retryAfter).To(Equal(time.Second))
+		Expect(limiter.allowRequest(netip.MustParseAddr("203.0.113.8"))).To(BeTrue())
+		now = now.Add(time.Second)
+		Expect(limiter.allowRequest(client)).To(BeTrue())
+	})
+
+	It("should limit concurrent event connections", func() {
+		Expect(limiter.acquireEventConnection(client)).To(BeTrue())
+		Expect(limiter.acquireEventConnection(client)).To(BeFalse())
+		limiter.releaseEventConnection(client)
+		Expect(limiter.acquireEventConnection(client)).To(BeTrue())
+	})
+
+	It("should not limit trusted clients", func() {
+		trusted := netip.MustParseAddr("10.1.2.3")
+		for range 5 {
+			Expect(limiter.allowRequest(trusted)).To(BeTrue())
+			Expect(limiter.acquireEventConnection(trusted)).To(BeTrue())
+		}
+	})
+
+	It("should forget idle clients", func() {
+		Expect(limiter.allowRequest(client)).To(BeTrue())
+		now = now.Add(2 * rateLimitClientMaxIdle)
+		Expect(limiter.allowRequest(netip.MustParseAddr("203.0.113.8"))).To(BeTrue())
+		Expect(limiter.clients).NotTo(HaveKey(client))
+	})
+
+	It("should reject invalid CIDRs", func() {
+		_, err := newRateLimiter(RateLimitOptions{TrustedCIDRs: []string{"10.0.0.0"}})
+		Expect(err).To(HaveOccurred())
+	})
+	It("should treat event streams as long-lived connections", func() {
+		Expect(isEventStreamPath(eventsPath)).To(BeTrue())
+		Expect(isEventStreamPath("/api/v1/packages/default/a/events")).To(BeTrue())
+		Expect(isEventStreamPath("/api/v1/clusterpackages/{pkgName}/events")).To(BeTrue())
+		Expect(isEventStreamPath("/api/v1/packages")).To(BeFalse())
+		Expect(isEventStreamPath("/packages/events")).To(BeFalse())
+	})
+})
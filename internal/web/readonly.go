@@ -0,0 +1,48 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/gorilla/mux"
+)
+
+var errReadOnly = errors.New("this action is not available, because the web UI is running in read-only mode")
+
+// readOnlyAllowedRoutes are the routes that accept unsafe requests in read-only mode, because they only change
+// preferences of the current user or open a connection to a package, but do not modify the cluster
+var readOnlyAllowedRoutes = []string{
+	"/settings",
+	"/logout",
+	"/banner/dismiss",
+	"/favorites/import",
+	"/packages/view",
+	"/favorites/packages/{pkgName}",
+	"/packages/{manifestName}/{namespace}/{name}/open",
+	"/clusterpackages/{pkgName}/open",
+}
+
+// readOnlyMiddleware rejects all requests that could modify the cluster with 403 Forbidden, if the server runs in
+// read-only mode. Hiding the actions in the UI alone is not sufficient, because requests can be sent directly.
+func (s *server) readOnlyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.ReadOnly && !isAllowedInReadOnlyMode(r) {
+			s.sendError(w, r, http.StatusForbidden, apiErrorForbidden, errReadOnly)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func isAllowedInReadOnlyMode(r *http.Request) bool {
+	if isSafeMethod(r.Method) {
+		return true
+	}
+	if route := mux.CurrentRoute(r); route != nil {
+		if tmpl, err := route.GetPathTemplate(); err == nil {
+			return slices.Contains(readOnlyAllowedRoutes, tmpl)
+		}
+	}
+	return false
+}
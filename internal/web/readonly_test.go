@@ -0,0 +1,37 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gorilla/mux"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("read-only mode", func() {
+	DescribeTable("isAllowedInReadOnlyMode",
+		func(method, path string, allowed bool) {
+			router := mux.NewRouter()
+			var result bool
+			handler := func(w http.ResponseWriter, r *http.Request) { result = isAllowedInReadOnlyMode(r) }
+			router.HandleFunc("/settings", handler)
+			router.HandleFunc("/logout", handler)
+			router.HandleFunc("/packages/view", handler)
+			router.HandleFunc("/settings/notifications", handler)
+			router.HandleFunc("/packages/{manifestName}/{namespace}/{name}", handler)
+			router.HandleFunc("/packages/{manifestName}/{namespace}/{name}/open", handler)
+			router.HandleFunc("/clusterpackages/{pkgName}/uninstall", handler)
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, path, nil))
+			Expect(result).To(Equal(allowed))
+		},
+		Entry("GET of a package", http.MethodGet, "/packages/foo/default/foo", true),
+		Entry("install of a package", http.MethodPost, "/packages/foo/default/foo", false),
+		Entry("uninstall of a package", http.MethodPost, "/clusterpackages/foo/uninstall", false),
+		Entry("opening a package", http.MethodPost, "/packages/foo/default/foo/open", true),
+		Entry("user preferences", http.MethodPost, "/settings", true),
+		Entry("logout", http.MethodPost, "/logout", true),
+		Entry("packages view preference", http.MethodPost, "/packages/view", true),
+		Entry("cluster settings", http.MethodPost, "/settings/notifications", false),
+	)
+})
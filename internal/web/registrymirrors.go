@@ -0,0 +1,56 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	clientadapter "github.com/glasskube/glasskube/internal/adapter/goclient"
+	"github.com/glasskube/glasskube/internal/registrymirror"
+	"github.com/glasskube/glasskube/internal/web/components/toast"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (s *server) getRegistryMirrors(ctx context.Context) (registrymirror.Mirrors, error) {
+	return registrymirror.Load(ctx, clientadapter.NewKubernetesClientAdapter(s.k8sClient))
+}
+
+// registryMirrorSettings stores the image registry mirrors for the whole cluster. They are applied by the operator
+// the next time a package is reconciled.
+func (s *server) registryMirrorSettings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+
+	mirrors, err := registrymirror.Parse(r.PostForm.Get("mirrors"))
+	if err != nil {
+		s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+	if err := s.saveRegistryMirrors(r.Context(), mirrors); err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to save registry mirrors: %w", err)))
+		return
+	}
+	s.sendToast(w, toast.WithMessage("Registry mirrors saved"))
+}
+
+func (s *server) saveRegistryMirrors(ctx context.Context, mirrors registrymirror.Mirrors) error {
+	configMaps := s.k8sClient.CoreV1().ConfigMaps(registrymirror.Namespace)
+	cm := mirrors.ConfigMap()
+	if existing, err := configMaps.Get(ctx, cm.Name, metav1.GetOptions{}); apierrors.IsNotFound(err) {
+		_, err := configMaps.Create(ctx, cm, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	} else {
+		existing.Data = cm.Data
+		_, err := configMaps.Update(ctx, existing, metav1.UpdateOptions{})
+		return err
+	}
+}
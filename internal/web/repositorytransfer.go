@@ -0,0 +1,62 @@
+package web
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/repo/transfer"
+	"github.com/glasskube/glasskube/internal/web/components/toast"
+	"github.com/glasskube/glasskube/internal/web/util"
+)
+
+// exportRepositories downloads the package repositories of the cluster as YAML, which can be imported into another
+// cluster on the settings page or with "glasskube repo import"
+func (s *server) exportRepositories(w http.ResponseWriter, r *http.Request) {
+	var repos v1alpha1.PackageRepositoryList
+	if err := s.pkgClient.PackageRepositories().GetAll(r.Context(), &repos); err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to fetch repositories: %w", err)))
+		return
+	}
+	data, removed, err := transfer.Export(repos.Items)
+	if err != nil {
+		s.sendToast(w, toast.WithErr(err))
+		return
+	}
+	for _, name := range slices.Sorted(maps.Keys(removed)) {
+		fmt.Fprintf(os.Stderr, "inline credentials of repository %v are not exported: %v\n",
+			name, strings.Join(removed[name], ", "))
+	}
+	w.Header().Set("Content-Type", "application/yaml")
+	w.Header().Set("Content-Disposition", `attachment; filename="glasskube-repositories.yaml"`)
+	_, _ = w.Write(data)
+}
+
+// importRepositories creates or updates the package repositories contained in the posted YAML and shows whether each
+// of them could be synced
+func (s *server) importRepositories(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	repos, err := transfer.Parse(strings.NewReader(r.FormValue("repositories")))
+	if err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("invalid repositories: %w", err)),
+			toast.WithStatusCode(http.StatusBadRequest))
+		return
+	} else if len(repos) == 0 {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("no repositories to import")),
+			toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+	results, err := transfer.Import(r.Context(), s.pkgClient, repos, transfer.DefaultTimeout)
+	tmplErr := s.templatesFor(r).repoImportResultTmpl.ExecuteTemplate(w, "repo-import-result", map[string]any{
+		"Results": results,
+		"Error":   err,
+	})
+	util.CheckTmplError(tmplErr, "repo-import-result")
+}
@@ -0,0 +1,216 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/manifest/render"
+	repoerror "github.com/glasskube/glasskube/internal/repo/error"
+	"github.com/glasskube/glasskube/internal/util"
+	"github.com/glasskube/glasskube/internal/web/components/toast"
+	webutil "github.com/glasskube/glasskube/internal/web/util"
+	"github.com/glasskube/glasskube/pkg/client"
+	"github.com/gorilla/mux"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var errRendererNotAvailable = errors.New("the resources of packages can not be rendered")
+
+// ResourceThresholds are the total resource requests of a package above which its detail page shows a warning.
+// Every value is a Kubernetes quantity, empty values disable the warning for that resource.
+type ResourceThresholds struct {
+	CPU     string
+	Memory  string
+	Storage string
+}
+
+func DefaultResourceThresholds() ResourceThresholds {
+	return ResourceThresholds{CPU: "4", Memory: "8Gi", Storage: "100Gi"}
+}
+
+func (t ResourceThresholds) resourceList() (corev1.ResourceList, error) {
+	result := corev1.ResourceList{}
+	for name, value := range map[corev1.ResourceName]string{
+		corev1.ResourceCPU:     t.CPU,
+		corev1.ResourceMemory:  t.Memory,
+		corev1.ResourceStorage: t.Storage,
+	} {
+		if value == "" {
+			continue
+		}
+		if quantity, err := resource.ParseQuantity(value); err != nil {
+			return nil, fmt.Errorf("invalid %v threshold %q: %w", name, value, err)
+		} else {
+			result[name] = quantity
+		}
+	}
+	return result, nil
+}
+
+// resourceSummary is used as template func to aggregate the resources that were rendered for a package. If they can
+// not be summarized, nil is returned.
+func resourceSummary(objects []*unstructured.Unstructured) *render.ResourceSummary {
+	summary, err := render.SummarizeResources(objects)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to summarize resources: %v\n", err)
+		return nil
+	}
+	return summary
+}
+
+// formatQuantity is used as template func, because the String method of resource.Quantity has a pointer receiver
+func formatQuantity(q resource.Quantity) string {
+	return q.String()
+}
+
+// formatResourceQuantity is used as template func to format a single entry of a resource list
+func formatResourceQuantity(list corev1.ResourceList, name string) string {
+	return formatQuantity(list[corev1.ResourceName(name)])
+}
+
+// packageResources renders the resources of the requested version of a package like a dry-run of the operator would,
+// so that the detail page can show the resources it is going to request. Installed packages are rendered with their
+// current values, all others with the default values of the manifest.
+func (s *server) packageResources(w http.ResponseWriter, r *http.Request) {
+	pkg, err := s.getInstalledPackageFromRequest(r)
+	if err != nil {
+		s.sendToast(w, toast.WithErr(err))
+		return
+	}
+	manifestName, repositoryName, version := mux.Vars(r)["manifestName"], r.FormValue("repositoryName"),
+		r.FormValue("version")
+	if manifestName == "" {
+		manifestName = mux.Vars(r)["pkgName"]
+	}
+	if !pkg.IsNil() {
+		if repositoryName == "" {
+			repositoryName = pkg.GetSpec().PackageInfo.RepositoryName
+		}
+		if version == "" {
+			version = pkg.GetSpec().PackageInfo.Version
+		}
+	}
+
+	objects, err := s.renderPackageResources(r, pkg, manifestName, repositoryName, version, "")
+	if err != nil {
+		err = fmt.Errorf("failed to render the resources of %v (%v): %w", manifestName, version, err)
+	}
+	err = s.templatesFor(r).pkgResourcesTmpl.ExecuteTemplate(w, "pkg-resources", map[string]any{
+		"Objects":    objects,
+		"Thresholds": s.resourceThresholds,
+		"Error":      err,
+	})
+	webutil.CheckTmplError(err, fmt.Sprintf("pkg-resources (%v)", manifestName))
+}
+
+// packageQuota shows how much of the ResourceQuotas of the target namespaces the requested version of a package
+// would use once it is installed. Like packageResources, packages that are not installed yet are rendered with the
+// default values of the manifest, in the namespace selected in the install form.
+func (s *server) packageQuota(w http.ResponseWriter, r *http.Request) {
+	pkg, err := s.getInstalledPackageFromRequest(r)
+	if err != nil {
+		s.sendToast(w, toast.WithErr(err))
+		return
+	}
+	manifestName := mux.Vars(r)["manifestName"]
+	if manifestName == "" {
+		manifestName = mux.Vars(r)["pkgName"]
+	}
+	var usages []render.QuotaUsage
+	objects, err := s.renderPackageResources(r, pkg, manifestName, r.FormValue("repositoryName"),
+		r.FormValue("version"), r.FormValue("namespace"))
+	if err == nil {
+		usages, err = s.renderer.ProjectQuotaUsage(r.Context(), objects)
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to check the resource quotas for %v: %w", manifestName, err)
+	}
+	err = s.templatesFor(r).pkgResourcesTmpl.ExecuteTemplate(w, "pkg-quota", map[string]any{
+		"Usages":      usages,
+		"Exceeded":    render.Exceeded(usages),
+		"StrictQuota": s.StrictQuota,
+		"Error":       err,
+	})
+	webutil.CheckTmplError(err, fmt.Sprintf("pkg-quota (%v)", manifestName))
+}
+
+// renderPackageResources renders the resources of pkg, or of a new package of the given manifest if pkg is nil. New
+// packages are rendered in namespace, or the default namespace of the manifest if it is empty.
+func (s *server) renderPackageResources(
+	r *http.Request,
+	pkg ctrlpkg.Package,
+	manifestName, repositoryName, version, namespace string,
+) ([]*unstructured.Unstructured, error) {
+	if s.renderer == nil {
+		return nil, errRendererNotAvailable
+	}
+	repositoryName, _, _, err := s.resolveRepos(r.Context(), manifestName, repositoryName)
+	if repoerror.IsComplete(err) {
+		return nil, err
+	}
+	if _, _, version, err = s.resolveVersions(repositoryName, manifestName, version); err != nil {
+		return nil, err
+	}
+	repoClient := s.repoClientset.ForRepoWithName(repositoryName)
+	var manifest v1alpha1.PackageManifest
+	if err := repoClient.FetchPackageManifest(manifestName, version, &manifest); err != nil {
+		return nil, err
+	}
+	manifestURL, err := repoClient.GetPackageManifestURL(manifestName, version)
+	if err != nil {
+		return nil, err
+	}
+	if pkg.IsNil() {
+		values := make(map[string]v1alpha1.ValueConfiguration)
+		for name, def := range manifest.ValueDefinitions {
+			if def.DefaultValue != "" {
+				values[name] = v1alpha1.ValueConfiguration{
+					InlineValueConfiguration: v1alpha1.InlineValueConfiguration{Value: util.Pointer(def.DefaultValue)},
+				}
+			}
+		}
+		if namespace == "" {
+			// same assumption as in the dependency validation: the package would be installed in the default namespace
+			namespace = manifest.DefaultNamespace
+		}
+		pkg = client.PackageBuilder(manifestName).
+			WithName(manifestName).
+			WithNamespace(namespace).
+			WithRepositoryName(repositoryName).
+			WithVersion(version).
+			WithValues(values).
+			Build(manifest.Scope)
+	}
+	return s.renderer.Render(r.Context(), pkg, &manifest, manifestURL)
+}
+
+// getInstalledPackageFromRequest is like getPackageFromRequest, but returns nil if the package is not installed,
+// including the placeholder "-" that is used in the URL of packages that are not installed yet
+func (s *server) getInstalledPackageFromRequest(r *http.Request) (ctrlpkg.Package, error) {
+	if name := mux.Vars(r)["pkgName"]; name != "" {
+		var cp v1alpha1.ClusterPackage
+		if err := s.pkgClient.ClusterPackages().Get(r.Context(), name, &cp); apierrors.IsNotFound(err) {
+			return (*v1alpha1.ClusterPackage)(nil), nil
+		} else if err != nil {
+			return nil, err
+		}
+		return &cp, nil
+	} else if namespace, name := mux.Vars(r)["namespace"], mux.Vars(r)["name"]; namespace != "" && namespace != "-" &&
+		name != "" && name != "-" {
+		var p v1alpha1.Package
+		if err := s.pkgClient.Packages(namespace).Get(r.Context(), name, &p); apierrors.IsNotFound(err) {
+			return (*v1alpha1.Package)(nil), nil
+		} else if err != nil {
+			return nil, err
+		}
+		return &p, nil
+	}
+	return (*v1alpha1.Package)(nil), nil
+}
@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"os"
 
+	"github.com/glasskube/glasskube/internal/clientutils"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
 	"github.com/glasskube/glasskube/internal/web/components/toast"
 	"github.com/glasskube/glasskube/internal/web/util"
 )
@@ -47,7 +49,21 @@ func (s *server) swappingRedirect(w http.ResponseWriter, path string, target str
 	w.Header().Add("Hx-Location", string(locationJson))
 }
 
-func (s *server) sendYamlModal(w http.ResponseWriter, obj string, alertContent any) {
+// sendYamlModal shows the given package as YAML in a modal, together with commands that reproduce it.
+// The commands must be rendered before the YAML, because formatting the YAML prunes the metadata of the package.
+func (s *server) sendYamlModal(w http.ResponseWriter, pkg ctrlpkg.Package, alertContent any) {
+	installCommand := glasskubeInstallCommand(pkg)
+	kubectlCommand, err := kubectlApplyCommand(pkg)
+	if err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to render kubectl command: %w", err)))
+		return
+	}
+	yamlOutput, err := clientutils.Format(clientutils.OutputFormatYAML, false, pkg)
+	if err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to render yaml: %w", err)))
+		return
+	}
+
 	// htmx headers to overwrite any existing/inherited hx-select, hx-swap, hx-target on the client
 	w.Header().Add("Hx-Reselect", "#yaml-modal")
 	w.Header().Add("Hx-Reswap", "innerHTML")
@@ -56,8 +72,10 @@ func (s *server) sendYamlModal(w http.ResponseWriter, obj string, alertContent a
 	w.WriteHeader(http.StatusOK)
 
 	e := s.templates.yamlModalTmpl.Execute(w, map[string]any{
-		"AlertContent": alertContent,
-		"Object":       obj,
+		"AlertContent":   alertContent,
+		"Object":         yamlOutput,
+		"InstallCommand": installCommand,
+		"KubectlCommand": kubectlCommand,
 	})
 	util.CheckTmplError(e, "yaml-modal")
 }
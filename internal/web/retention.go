@@ -0,0 +1,109 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/audit"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/retention"
+	"github.com/glasskube/glasskube/internal/web/components/toast"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// retentionSettings configures how long the revision history of packages and the audit log are kept. The policy is
+// applied by the operator, so the settings take effect with its next pruning run.
+func (s *server) retentionSettings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+
+	var config retention.Config
+	var err error
+	for key, target := range map[string]*int{
+		"revisionsMaxCount":   &config.Revisions.MaxCount,
+		"revisionsMaxAgeDays": &config.Revisions.MaxAgeDays,
+		"auditLogMaxCount":    &config.AuditLog.MaxCount,
+		"auditLogMaxAgeDays":  &config.AuditLog.MaxAgeDays,
+	} {
+		if value := strings.TrimSpace(r.PostForm.Get(key)); value != "" {
+			if *target, err = strconv.Atoi(value); err != nil {
+				s.sendToast(w, toast.WithErr(fmt.Errorf("invalid number %q", value)),
+					toast.WithStatusCode(http.StatusBadRequest))
+				return
+			}
+		}
+	}
+	if err := config.Validate(); err != nil {
+		s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+	if err := s.saveRetentionConfig(r.Context(), &config); err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to save retention policy: %w", err)))
+		return
+	}
+	s.sendToast(w, toast.WithMessage("Retention policy saved. It is applied by the operator within the next hour."))
+}
+
+func (s *server) getRetentionConfig(ctx context.Context) (*retention.Config, error) {
+	configMaps := s.k8sClient.CoreV1().ConfigMaps(retention.Namespace)
+	if cm, err := configMaps.Get(ctx, retention.ConfigMapName, metav1.GetOptions{}); apierrors.IsNotFound(err) {
+		return &retention.Config{}, nil
+	} else if err != nil {
+		return &retention.Config{}, err
+	} else {
+		return retention.ConfigFromConfigMap(cm), nil
+	}
+}
+
+func (s *server) saveRetentionConfig(ctx context.Context, config *retention.Config) error {
+	configMaps := s.k8sClient.CoreV1().ConfigMaps(retention.Namespace)
+	cm := config.ConfigMap()
+	if existing, err := configMaps.Get(ctx, cm.Name, metav1.GetOptions{}); apierrors.IsNotFound(err) {
+		_, err := configMaps.Create(ctx, cm, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	} else {
+		existing.Data = cm.Data
+		_, err := configMaps.Update(ctx, existing, metav1.UpdateOptions{})
+		return err
+	}
+}
+
+// getRetentionUsage estimates the storage currently used by the revision history and the audit log
+func (s *server) getRetentionUsage(ctx context.Context) (retention.Usage, error) {
+	var pkgs []ctrlpkg.Package
+	var clpkgList v1alpha1.ClusterPackageList
+	if err := s.pkgClient.ClusterPackages().GetAll(ctx, &clpkgList); err != nil {
+		return retention.Usage{}, err
+	}
+	for i := range clpkgList.Items {
+		pkgs = append(pkgs, &clpkgList.Items[i])
+	}
+	var pkgList v1alpha1.PackageList
+	if err := s.pkgClient.Packages("").GetAll(ctx, &pkgList); err != nil {
+		return retention.Usage{}, err
+	}
+	for i := range pkgList.Items {
+		pkgs = append(pkgs, &pkgList.Items[i])
+	}
+	auditLog, err := s.k8sClient.CoreV1().ConfigMaps(audit.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.Set{audit.LabelAuditLog: "true"}.String(),
+	})
+	if err != nil {
+		return retention.Usage{}, err
+	}
+	return retention.EstimateUsage(pkgs, auditLog.Items), nil
+}
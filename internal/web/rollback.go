@@ -0,0 +1,56 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/glasskube/glasskube/internal/audit"
+	"github.com/glasskube/glasskube/internal/web/components/toast"
+	"github.com/glasskube/glasskube/pkg/rollback"
+)
+
+// handleRollback rolls a package back to the revision before the current one. Like an update, the dependencies of
+// the target version are validated first and the rollback is rejected if there are conflicts.
+func (s *server) handleRollback(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := r.Context()
+	pkg, err := s.getPackageFromRequest(r)
+	if err != nil {
+		s.sendToast(w, toast.WithErr(err))
+		return
+	}
+
+	rollbacker := rollback.NewRollbacker(ctx)
+	tx, err := rollbacker.Prepare(ctx, pkg, 0)
+	if errors.Is(err, rollback.ErrNoPreviousRevision) {
+		s.sendToast(w, toast.WithMessage(fmt.Sprintf("%v has no previous revision to roll back to", pkg.GetName())),
+			toast.WithSeverity(toast.Info))
+		return
+	} else if err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to prepare rollback: %w", err)))
+		return
+	} else if len(tx.Conflicts) > 0 {
+		s.sendToast(w,
+			toast.WithErr(fmt.Errorf("cannot roll back %v to version %v due to dependency conflicts: %v",
+				pkg.GetName(), tx.Revision.Version, tx.Conflicts)),
+			toast.WithStatusCode(http.StatusConflict))
+		return
+	}
+
+	versionBefore := pkg.GetSpec().PackageInfo.Version
+	opts := rollback.ApplyRollbackOptions{DryRun: s.isGitopsModeEnabled()}
+	if err := rollbacker.Apply(ctx, tx, opts); err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to roll back %v: %w", pkg.GetName(), err)))
+	} else if s.isGitopsModeEnabled() {
+		s.sendYamlModal(w, pkg, nil)
+	} else {
+		s.recordPackageOperation(ctx, audit.OperationRollback, pkg, versionBefore, tx.Revision.Version)
+		s.sendToast(w, toast.WithMessage(fmt.Sprintf("%v is being rolled back to revision %v (%v)",
+			pkg.GetName(), tx.Revision.Revision, tx.Revision.Version)))
+	}
+}
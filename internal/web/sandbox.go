@@ -0,0 +1,109 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/audit"
+	"github.com/glasskube/glasskube/internal/manifestvalues"
+	repoerror "github.com/glasskube/glasskube/internal/repo/error"
+	"github.com/glasskube/glasskube/internal/sandbox"
+	"github.com/glasskube/glasskube/internal/web/components/toast"
+	webutil "github.com/glasskube/glasskube/internal/web/util"
+	"github.com/glasskube/glasskube/pkg/client"
+	"github.com/gorilla/mux"
+	"go.uber.org/multierr"
+)
+
+// tryPackage installs a package that is not installed yet into a new sandbox namespace, with the values of the
+// installation form. The sandbox is deleted by the operator after the requested time.
+func (s *server) tryPackage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := r.Context()
+	manifestName, repositoryName, version := mux.Vars(r)["manifestName"], r.FormValue("repositoryName"),
+		r.FormValue("version")
+	ttl, err := time.ParseDuration(r.FormValue("ttl"))
+	if err == nil {
+		err = sandbox.ValidateTTL(ttl)
+	}
+	if err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("invalid sandbox lifetime: %w", err)),
+			toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+
+	mf, err := s.resolveManifest(ctx, (*v1alpha1.Package)(nil), repositoryName, manifestName, version)
+	if repoerror.IsPartial(err) {
+		fmt.Fprintf(os.Stderr, "problem fetching manifest and repo, but installation can continue: %v", err)
+	} else if err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to get manifest and repo of %v: %w", manifestName, err)))
+		return
+	}
+	if mf.Scope.IsCluster() {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("%v can not be installed in a sandbox namespace", manifestName)),
+			toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+
+	values, err := extractValues(r, mf)
+	if err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to parse values: %w", err)))
+		return
+	} else if err := manifestvalues.ValidateValueConfigurations(*mf, values); err != nil {
+		s.sendToast(w, toast.WithErr(multierr.Errors(err)[0]), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+
+	pkg := client.PackageBuilder(manifestName).
+		WithVersion(version).
+		WithRepositoryName(repositoryName).
+		WithValues(values).
+		WithName(manifestName).
+		BuildPackage()
+	if err := sandbox.Create(ctx, s.k8sClient, s.pkgClient, pkg, ttl); err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to try %v: %w", manifestName, err)))
+		return
+	}
+	s.recordPackageOperation(ctx, audit.OperationInstall, pkg, "", version)
+	s.swappingRedirect(w, webutil.GetPackageHref(pkg, mf), "main", "main")
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// promoteSandbox installs the package of a sandbox for real in the namespace and with the name from the form and
+// deletes the sandbox afterwards
+func (s *server) promoteSandbox(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := r.Context()
+	namespace, name := r.FormValue("namespace"), r.FormValue("name")
+	if namespace == "" || name == "" {
+		s.sendToast(w, toast.WithErr(errors.New("namespace and name are required")),
+			toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+	var sandboxPkg v1alpha1.Package
+	if err := s.pkgClient.Packages(mux.Vars(r)["namespace"]).Get(ctx, mux.Vars(r)["name"], &sandboxPkg); err != nil {
+		s.sendToast(w, toast.WithErr(err))
+		return
+	}
+	pkg, err := sandbox.Promote(ctx, s.k8sClient, s.pkgClient, &sandboxPkg, namespace, name)
+	if pkg == nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to promote %v: %w", sandboxPkg.Name, err)))
+		return
+	}
+	s.recordPackageOperation(ctx, audit.OperationInstall, pkg, "", pkg.Spec.PackageInfo.Version)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to delete sandbox %v after promotion: %v\n", sandboxPkg.Namespace, err)
+	}
+	s.swappingRedirect(w, webutil.GetNamespacedPkgHref(pkg.Spec.PackageInfo.Name, namespace, name), "main", "main")
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -2,26 +2,29 @@ package web
 
 import (
 	"context"
+	"crypto/tls"
 	"embed"
 	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"io/fs"
+	"log/slog"
 	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"os/signal"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
-	"github.com/glasskube/glasskube/internal/dependency/graph"
 	"github.com/glasskube/glasskube/internal/telemetry/annotations"
 
 	"github.com/glasskube/glasskube/internal/web/components/toast"
@@ -37,14 +40,20 @@ import (
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/audit"
+	"github.com/glasskube/glasskube/internal/autoupdate"
+	"github.com/glasskube/glasskube/internal/banner"
 	"github.com/glasskube/glasskube/internal/clientutils"
 	"github.com/glasskube/glasskube/internal/cliutils"
 	"github.com/glasskube/glasskube/internal/config"
 	"github.com/glasskube/glasskube/internal/dependency"
+	"github.com/glasskube/glasskube/internal/manifest/render"
 	"github.com/glasskube/glasskube/internal/manifestvalues"
+	"github.com/glasskube/glasskube/internal/notification"
 	repoclient "github.com/glasskube/glasskube/internal/repo/client"
 	repotypes "github.com/glasskube/glasskube/internal/repo/types"
 	"github.com/glasskube/glasskube/internal/telemetry"
+	"github.com/glasskube/glasskube/internal/tracing"
 	"github.com/glasskube/glasskube/internal/web/handler"
 	"github.com/glasskube/glasskube/pkg/bootstrap"
 	"github.com/glasskube/glasskube/pkg/client"
@@ -53,6 +62,7 @@ import (
 	"github.com/glasskube/glasskube/pkg/uninstall"
 	"github.com/glasskube/glasskube/pkg/update"
 	"github.com/gorilla/mux"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
@@ -64,27 +74,53 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/klog/v2"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 //go:embed root
 //go:embed templates
+//go:embed locales
 var embeddedFs embed.FS
 var webFs fs.FS = embeddedFs
 
-func init() {
-	if config.IsDevBuild() {
-		if _, err := os.Lstat(templatesBaseDir); err == nil {
-			webFs = os.DirFS(templatesBaseDir)
-		}
+// useLocalWebFs serves templates and static files from the working directory instead of the embedded files, if the
+// working directory is the root of the glasskube repository
+func useLocalWebFs() bool {
+	if _, err := os.Lstat(templatesBaseDir); err == nil {
+		webFs = os.DirFS(templatesBaseDir)
+		return true
 	}
+	return false
 }
 
+const defaultShutdownGracePeriod = 10 * time.Second
+
 type ServerOptions struct {
 	Host               string
 	Port               string
 	Kubeconfig         string
 	LogLevel           int
+	LogFormat          string
+	MetricsPort        string
 	SkipOpeningBrowser bool
+	// MarkdownCacheSize is the maximum number of rendered markdown descriptions that are cached
+	MarkdownCacheSize int
+	// Dev enables the development mode, in which templates are read from the working directory and parsed again
+	// whenever they change. Otherwise, the embedded templates are parsed once at startup.
+	Dev bool
+	// ShutdownGracePeriod is the time in-flight requests are given to complete when the server shuts down
+	ShutdownGracePeriod time.Duration
+	// ReadOnly hides all actions that modify the cluster and rejects the corresponding requests
+	ReadOnly bool
+	SupportOptions
+	RateLimitOptions
+	AuthOptions
+	// ResourceThresholds are the total resource requests above which a package is flagged on its detail page
+	ResourceThresholds
+	// StrictQuota rejects installing packages whose resource requests would exceed a ResourceQuota of their namespace,
+	// instead of only asking for confirmation
+	StrictQuota bool
+	TLSOptions
 }
 
 func NewServer(options ServerOptions) *server {
@@ -93,15 +129,21 @@ func NewServer(options ServerOptions) *server {
 		configLoader:            &defaultConfigLoader{options.Kubeconfig},
 		forwarders:              make(map[string]*open.OpenResult),
 		templates:               templates{},
+		metrics:                 newMetrics(),
 		stopCh:                  make(chan struct{}, 1),
 		httpServerHasShutdownCh: make(chan struct{}, 1),
+		sessions:                newSessionStore(options.SessionTimeout),
 	}
+	server.templates.markdownCache = newMarkdownCache(options.MarkdownCacheSize)
+	server.templates.markdownCache.requests = server.metrics.markdownCacheRequests
 	return &server
 }
 
 type server struct {
 	ServerOptions
 	configLoader
+	logger                  *slog.Logger
+	shutdownTracing         func(context.Context) error
 	listener                net.Listener
 	restConfig              *rest.Config
 	rawConfig               *api.Config
@@ -112,7 +154,12 @@ type server struct {
 	broadcaster             *sse.Broadcaster
 	namespaceLister         *corev1.NamespaceLister
 	configMapLister         *corev1.ConfigMapLister
+	rateLimiter             *rateLimiter
+	sessions                *sessionStore
+	renderer                *render.Renderer
+	resourceThresholds      v1.ResourceList
 	secretLister            *corev1.SecretLister
+	workloadListers         *workloadListers
 	forwarders              map[string]*open.OpenResult
 	forwardersMutex         sync.Mutex
 	dependencyMgr           *dependency.DependendcyManager
@@ -120,8 +167,15 @@ type server struct {
 	isBootstrapped          bool
 	templates               templates
 	httpServer              *http.Server
+	updateAllMutex          sync.Mutex
+	metrics                 *metrics
+	metricsServer           *http.Server
+	redirectServer          *http.Server
+	repoSynced              atomic.Bool
+	cacheControllers        []cache.Controller
 	httpServerHasShutdownCh chan struct{}
 	stopCh                  chan struct{}
+	shutdownOnce            sync.Once
 }
 
 func (s *server) RestConfig() *rest.Config {
@@ -160,14 +214,60 @@ func (s *server) Start(ctx context.Context) error {
 	} else if config.IsDevBuild() {
 		initLogging(5)
 	}
+	if logger, err := newLogger(s.LogFormat, s.LogLevel); err != nil {
+		return err
+	} else {
+		s.logger = logger
+		initKlog(logger, s.LogFormat)
+	}
 
-	s.templates.parseTemplates()
-	if config.IsDevBuild() {
-		if err := s.templates.watchTemplates(); err != nil {
+	if shutdownTracing, err := tracing.Setup(ctx, "glasskube-web"); err != nil {
+		return fmt.Errorf("could not set up tracing: %w", err)
+	} else {
+		s.shutdownTracing = shutdownTracing
+	}
+
+	if rateLimiter, err := newRateLimiter(s.RateLimitOptions); err != nil {
+		return err
+	} else {
+		s.rateLimiter = rateLimiter
+	}
+
+	if err := s.AuthOptions.validate(); err != nil {
+		return err
+	}
+
+	if thresholds, err := s.ResourceThresholds.resourceList(); err != nil {
+		return err
+	} else {
+		s.resourceThresholds = thresholds
+	}
+
+	var tlsConfig *tls.Config
+	if err := s.TLSOptions.validate(); err != nil {
+		return err
+	} else if s.TLSOptions.Enabled() {
+		if cfg, err := s.initTLS(); err != nil {
+			return err
+		} else {
+			tlsConfig = cfg
+		}
+	}
+
+	watchTemplates := s.Dev && useLocalWebFs()
+	if s.Dev && !watchTemplates {
+		fmt.Fprintf(os.Stderr, "%v not found, using embedded templates\n", templatesBaseDir)
+	}
+	if err := s.templates.parseTemplates(); err != nil {
+		return err
+	}
+	if watchTemplates {
+		if err := s.templates.watchTemplates(s.stopCh); err != nil {
 			fmt.Fprintf(os.Stderr, "templates will not be parsed after changes: %v\n", err)
 		}
 	}
 	s.broadcaster = sse.NewBroadcaster()
+	s.registerMetrics()
 	_ = s.ensureBootstrapped(ctx)
 
 	root, err := fs.Sub(webFs, "root")
@@ -178,16 +278,32 @@ func (s *server) Start(ctx context.Context) error {
 	fileServer := http.FileServer(http.FS(root))
 
 	router := mux.NewRouter()
+	router.Use(s.loggingMiddleware)
+	router.Use(s.tracingMiddleware)
+	router.Use(s.rateLimitMiddleware)
+	router.Use(s.sessionMiddleware)
+	router.Use(s.csrfMiddleware)
+	router.Use(s.readOnlyMiddleware)
 	router.Use(telemetry.HttpMiddleware(telemetry.WithPathRedactor(packagesPathRedactor)))
 	router.PathPrefix("/static/").Handler(fileServer)
 	router.Handle("/favicon.ico", fileServer)
-	router.HandleFunc("/events", s.broadcaster.Handler)
+	router.HandleFunc(eventsPath, s.broadcaster.Handler)
+	router.HandleFunc(websocketPath, s.broadcaster.WebsocketHandler)
+	router.HandleFunc("/syntax-highlighting.css", s.syntaxHighlightingCss)
+	if s.MetricsPort == "" {
+		router.Handle("/metrics", s.metrics.handler())
+		router.HandleFunc(dashboardPath, grafanaDashboardHandler)
+	} else if err := s.serveMetrics(); err != nil {
+		return err
+	}
 	router.HandleFunc("/support", s.supportPage)
 	router.HandleFunc("/kubeconfig", s.kubeconfigPage)
 	router.Handle("/bootstrap", s.requireKubeconfig(s.bootstrapPage))
 	router.Handle("/kubeconfig/persist", s.requireKubeconfig(s.persistKubeconfig))
 	// overview pages
 	router.Handle("/packages", s.requireReady(s.packages))
+	router.Handle("/categories", s.requireReady(s.categoriesPage))
+	router.Handle("/compare", s.requireReady(s.comparePage))
 	router.Handle("/clusterpackages", s.requireReady(s.clusterPackages))
 
 	// detail page endpoints
@@ -208,6 +324,14 @@ func (s *server) Start(ctx context.Context) error {
 	router.Handle(pkgBasePath+"/configuration/{valueName}", s.requireReady(s.packageConfigurationInput))
 	router.Handle(installedPkgBasePath+"/configuration/{valueName}", s.requireReady(s.packageConfigurationInput))
 	router.Handle(clpkgBasePath+"/configuration/{valueName}", s.requireReady(s.clusterPackageConfigurationInput))
+	router.Handle(pkgBasePath+"/configuration/{valueName}/validation", s.requireReady(s.configurationValidation))
+	router.Handle(installedPkgBasePath+"/configuration/{valueName}/validation",
+		s.requireReady(s.configurationValidation))
+	router.Handle(clpkgBasePath+"/configuration/{valueName}/validation", s.requireReady(s.configurationValidation))
+	// profile endpoints
+	router.Handle(pkgBasePath+"/profiles", s.requireReady(s.savePackageProfile))
+	router.Handle(installedPkgBasePath+"/profiles", s.requireReady(s.savePackageProfile))
+	router.Handle(clpkgBasePath+"/profiles", s.requireReady(s.savePackageProfile))
 	// open endpoints
 	router.Handle(installedPkgBasePath+"/open", s.requireReady(s.open))
 	router.Handle(clpkgBasePath+"/open", s.requireReady(s.open))
@@ -219,16 +343,82 @@ func (s *server) Start(ctx context.Context) error {
 	router.Handle(clpkgBasePath+"/resume", s.requireReady(s.handleResume))
 	router.Handle(installedPkgBasePath+"/suspend", s.requireReady(s.handleSuspend))
 	router.Handle(installedPkgBasePath+"/resume", s.requireReady(s.handleResume))
+	router.Handle(clpkgBasePath+"/pause", s.requireReady(s.handlePause))
+	router.Handle(clpkgBasePath+"/unpause", s.requireReady(s.handleUnpause))
+	router.Handle(installedPkgBasePath+"/pause", s.requireReady(s.handlePause))
+	router.Handle(installedPkgBasePath+"/unpause", s.requireReady(s.handleUnpause))
+	router.Handle(clpkgBasePath+"/retry", s.requireReady(s.handleRetry))
+	router.Handle(installedPkgBasePath+"/retry", s.requireReady(s.handleRetry))
+	router.Handle(clpkgBasePath+"/recreate", s.requireReady(s.handleRecreate))
+	router.Handle(installedPkgBasePath+"/recreate", s.requireReady(s.handleRecreate))
+	// rollback endpoints
+	router.Handle(clpkgBasePath+"/rollback", s.requireReady(s.handleRollback))
+	router.Handle(installedPkgBasePath+"/rollback", s.requireReady(s.handleRollback))
+
+	router.Handle(pkgBasePath+"/try", s.requireReady(s.tryPackage))
+	router.Handle(installedPkgBasePath+"/promote", s.requireReady(s.promoteSandbox))
+	// workload endpoints
+	router.Handle(clpkgBasePath+"/workloads", s.requireReady(s.packageWorkloads))
+	router.Handle(clpkgBasePath+"/workloads/logs", s.requireReady(s.packageWorkloadLogs))
+	router.Handle(installedPkgBasePath+"/workloads", s.requireReady(s.packageWorkloads))
+	router.Handle(installedPkgBasePath+"/workloads/logs", s.requireReady(s.packageWorkloadLogs))
+	// why endpoints
+	router.Handle(clpkgBasePath+"/why", s.requireReady(s.packageWhy))
+	router.Handle(installedPkgBasePath+"/why", s.requireReady(s.packageWhy))
+	router.Handle(clpkgBasePath+"/yaml", s.requireReady(s.packageYamlEditor))
+	router.Handle(installedPkgBasePath+"/yaml", s.requireReady(s.packageYamlEditor))
+	router.Handle(clpkgBasePath+"/metadata", s.requireReady(s.packageMetadata))
+	router.Handle(installedPkgBasePath+"/metadata", s.requireReady(s.packageMetadata))
+	router.Handle(pkgBasePath+"/resources", s.requireReady(s.packageResources))
+	router.Handle(installedPkgBasePath+"/resources", s.requireReady(s.packageResources))
+	router.Handle(clpkgBasePath+"/resources", s.requireReady(s.packageResources))
+	router.Handle(pkgBasePath+"/quota", s.requireReady(s.packageQuota))
+	router.Handle(installedPkgBasePath+"/quota", s.requireReady(s.packageQuota))
+	router.Handle(clpkgBasePath+"/quota", s.requireReady(s.packageQuota))
 
 	// configuration datalist endpoints
 	router.Handle("/datalists/{valueName}/names", s.requireReady(s.namesDatalist))
 	router.Handle("/datalists/{valueName}/keys", s.requireReady(s.keysDatalist))
+	// bulk update endpoints
+	router.Handle("/updates", s.requireReady(s.updateAll))
+	router.Handle("/updates/changelog", s.requireReady(s.updateChangelog))
+	// JSON API
+	router.Handle("/api/v1/packages", s.requireReadyApi(s.apiPackages))
+	router.Handle("/api/v1/clusterpackages", s.requireReadyApi(s.apiClusterPackages))
+	router.Handle("/api/v1/clusterpackages/{pkgName}/events", s.requireReadyApi(s.apiPackageEvents))
+	router.Handle("/api/v1/packages/{namespace}/{name}/events", s.requireReadyApi(s.apiPackageEvents))
+	router.Handle("/api/v1/palette", s.requireReadyApi(s.apiPalette))
+	router.PathPrefix(apiPathPrefix).Handler(apiErrorMiddleware(apiNotFound))
 	// settings
 	router.Handle("/settings", s.requireReady(s.settingsPage))
+	router.Handle("/settings/repositories/export", s.requireReady(s.exportRepositories))
+	router.Handle("/settings/repositories/import", s.requireReady(s.importRepositories))
 	router.Handle("/settings/repository/{repoName}", s.requireReady(s.repositoryConfig))
+	router.Handle("/settings/repository/{repoName}/sync", s.requireReady(s.repositorySync))
+	router.Handle("/settings/repository/{repoName}/delete", s.requireReady(s.repositoryDelete))
+	router.Handle("/settings/notifications", s.requireReady(s.notificationSettings))
+	router.Handle("/settings/auto-updates", s.requireReady(s.autoUpdateSettings))
+	router.Handle("/settings/auto-updates/window", s.requireReady(s.autoUpdateWindowSettings))
+	router.Handle("/settings/registry-mirrors", s.requireReady(s.registryMirrorSettings))
+	router.Handle("/settings/cluster-defaults", s.requireReady(s.clusterDefaultSettings))
+	router.Handle("/settings/banner", s.requireReady(s.bannerSettings))
+	router.Handle("/settings/retention", s.requireReady(s.retentionSettings))
+	router.HandleFunc("/banner/dismiss", s.dismissBanner)
+	router.HandleFunc("/settings/session", s.sessionSettings)
+	router.HandleFunc(sessionLoginPath, s.login)
+	router.HandleFunc("/logout", s.logout)
+	// audit log
+	router.Handle("/audit", s.requireReady(s.auditPage))
+	router.HandleFunc("/packages/view", s.setPackagesView)
+	router.HandleFunc("/favorites/export", s.exportFavorites)
+	router.HandleFunc("/favorites/import", s.importFavorites)
+	router.HandleFunc("/favorites/packages/{pkgName}", s.toggleFavorite)
 	router.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, "/clusterpackages", http.StatusFound)
 	})
+	// probes are registered outside of the router, so they are neither logged nor instrumented
+	http.HandleFunc("/healthz", s.healthz)
+	http.HandleFunc("/readyz", s.readyz)
 	http.Handle("/", s.enrichContext(router))
 
 	s.listener, err = net.Listen("tcp", net.JoinHostPort(s.Host, s.Port))
@@ -249,14 +439,23 @@ func (s *server) Start(ctx context.Context) error {
 		}
 	}
 
-	browseUrl := fmt.Sprintf("http://%s", s.listener.Addr())
+	scheme := "http"
+	if tlsConfig != nil {
+		scheme = "https"
+		if s.RedirectPort != "" {
+			if err := s.serveHTTPRedirect(); err != nil {
+				return err
+			}
+		}
+	}
+	browseUrl := fmt.Sprintf("%s://%s", scheme, s.listener.Addr())
 	fmt.Fprintln(os.Stderr, "glasskube UI is available at", browseUrl)
 	if !s.SkipOpeningBrowser {
 		_ = cliutils.OpenInBrowser(browseUrl)
 	}
 
 	go s.broadcaster.Run(s.stopCh)
-	s.httpServer = &http.Server{}
+	s.httpServer = &http.Server{TLSConfig: tlsConfig}
 
 	var receivedSig *os.Signal
 	go func() {
@@ -267,7 +466,12 @@ func (s *server) Start(ctx context.Context) error {
 		s.shutdown()
 	}()
 
-	err = s.httpServer.Serve(s.listener)
+	if tlsConfig != nil {
+		// the certificate is provided by the GetCertificate callback of tlsConfig
+		err = s.httpServer.ServeTLS(s.listener, "", "")
+	} else {
+		err = s.httpServer.Serve(s.listener)
+	}
 	if err != nil && err != http.ErrServerClosed {
 		return err
 	}
@@ -278,14 +482,41 @@ func (s *server) Start(ctx context.Context) error {
 	return nil
 }
 
+// shutdown stops the server gracefully: New connections are not accepted anymore, event stream clients are told to
+// reconnect later and in-flight requests are given the configured grace period to complete. It is safe to call shutdown
+// more than once.
 func (s *server) shutdown() {
-	close(s.stopCh)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	if err := s.httpServer.Shutdown(ctx); err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to shutdown server: %v\n", err)
-	}
-	close(s.httpServerHasShutdownCh)
+	s.shutdownOnce.Do(func() {
+		// closing stopCh also stops the event hub, which ends all event streams, so that they don't delay the shutdown
+		close(s.stopCh)
+		gracePeriod := s.ShutdownGracePeriod
+		if gracePeriod <= 0 {
+			gracePeriod = defaultShutdownGracePeriod
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), gracePeriod)
+		defer cancel()
+		if s.httpServer != nil {
+			if err := s.httpServer.Shutdown(ctx); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to shutdown server: %v\n", err)
+			}
+		}
+		if s.metricsServer != nil {
+			if err := s.metricsServer.Shutdown(ctx); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to shutdown metrics server: %v\n", err)
+			}
+		}
+		if s.redirectServer != nil {
+			if err := s.redirectServer.Shutdown(ctx); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to shutdown HTTP redirect server: %v\n", err)
+			}
+		}
+		if s.shutdownTracing != nil {
+			if err := s.shutdownTracing(ctx); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to flush traces: %v\n", err)
+			}
+		}
+		close(s.httpServerHasShutdownCh)
+	})
 }
 
 // uninstall is an endpoint, which returns the modal html for GET requests, and performs the uninstallation for POST
@@ -296,76 +527,92 @@ func (s *server) uninstall(w http.ResponseWriter, r *http.Request) {
 	namespace := mux.Vars(r)["namespace"]
 	name := mux.Vars(r)["name"]
 
+	cascade, err := uninstall.ParseCascadeMode(r.FormValue("cascade"))
+	if err != nil {
+		s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+
 	if r.Method == http.MethodPost {
-		uninstaller := uninstall.NewUninstaller(s.pkgClient)
-		if pkgName != "" {
-			var pkg v1alpha1.ClusterPackage
-			if err := s.pkgClient.ClusterPackages().Get(ctx, pkgName, &pkg); err != nil {
-				s.sendToast(w, toast.WithErr(fmt.Errorf("failed to fetch clusterpackage %v: %w", pkgName, err)))
-				return
-			}
-			if err := uninstaller.Uninstall(ctx, &pkg, false); err != nil {
-				s.sendToast(w, toast.WithErr(fmt.Errorf("failed to uninstall clusterpackage %v: %w", pkgName, err)))
-				return
-			}
-		} else {
-			var pkg v1alpha1.Package
-			if err := s.pkgClient.Packages(namespace).Get(ctx, name, &pkg); err != nil {
-				s.sendToast(w, toast.WithErr(fmt.Errorf("failed to fetch package %v/%v: %w", namespace, name, err)))
-				return
-			}
-			if err := uninstaller.Uninstall(ctx, &pkg, false); err != nil {
-				s.sendToast(w, toast.WithErr(fmt.Errorf("failed to uninstall package %v/%v: %w", namespace, name, err)))
+		pkg, err := s.getPackageFromRequest(r)
+		if err != nil {
+			s.sendToast(w, toast.WithErr(fmt.Errorf("failed to fetch package: %w", err)))
+			return
+		}
+		// the impact is validated again, because the dependencies might have changed since the modal was shown
+		impact, err := s.uninstallImpact(ctx, pkg.GetName(), pkg.GetNamespace(), cascade)
+		if err != nil {
+			s.sendToast(w, toast.WithErr(err))
+			return
+		}
+		dependents, err := uninstall.GetPackages(ctx, s.pkgClient, impact.Cascaded)
+		if err != nil {
+			s.sendToast(w, toast.WithErr(err))
+			return
+		}
+		uninstaller := uninstall.NewUninstaller(s.pkgClient).
+			WithRetention(s.k8sClient, uninstall.RetainOptions{
+				PersistentVolumeClaims: r.FormValue("retainVolumes") == "on",
+				Secrets:                r.FormValue("retainSecrets") == "on",
+			})
+		// dependents are uninstalled first, so that no package is left without its dependencies in the meantime
+		for _, p := range append(dependents, pkg) {
+			if err := uninstaller.Uninstall(ctx, p, false); err != nil {
+				s.sendToast(w, toast.WithErr(fmt.Errorf("failed to uninstall %v: %w", cache.MetaObjectToName(p), err)))
 				return
 			}
+			s.recordPackageOperation(ctx, audit.OperationUninstall, p, p.GetSpec().PackageInfo.Version, "")
 		}
 	} else {
+		data := map[string]any{
+			"Cascade":        cascade,
+			"CascadeOptions": uninstallCascadeOptions,
+			"GitopsMode":     s.isGitopsModeEnabled(),
+			"ReadOnly":       s.ReadOnly,
+		}
+		var impact *uninstall.Impact
 		if pkgName != "" {
-			var pruned []graph.PackageRef
-			var err error
-			if g, err1 := s.dependencyMgr.NewGraph(r.Context()); err1 != nil {
-				err = fmt.Errorf("error validating uninstall: %w", err1)
-			} else {
-				g.Delete(pkgName, "")
-				pruned = g.Prune()
-				if err1 := g.Validate(); err1 != nil {
-					err = fmt.Errorf("%v cannot be uninstalled: %w", pkgName, err1)
-				}
-			}
-			err = s.templates.pkgUninstallModalTmpl.Execute(w, map[string]any{
-				"PackageName": pkgName,
-				"Pruned":      pruned,
-				"Err":         err,
-				"PackageHref": util.GetClusterPkgHref(pkgName),
-				"GitopsMode":  s.isGitopsModeEnabled(),
-			})
-			util.CheckTmplError(err, "pkgUninstallModalTmpl")
+			impact, err = s.uninstallImpact(ctx, pkgName, "", cascade)
+			data["PackageName"] = pkgName
+			data["PackageHref"] = util.GetClusterPkgHref(pkgName)
 		} else {
-			var pruned []graph.PackageRef
-			var err error
-			// TODO: refactor this duplicate code segment
-			if g, err1 := s.dependencyMgr.NewGraph(r.Context()); err1 != nil {
-				err = fmt.Errorf("error validating uninstall: %w", err1)
-			} else {
-				g.Delete(name, namespace)
-				pruned = g.Prune()
-				if err1 := g.Validate(); err1 != nil {
-					err = fmt.Errorf("%v cannot be uninstalled: %w", pkgName, err1)
-				}
-			}
-			err = s.templates.pkgUninstallModalTmpl.Execute(w, map[string]any{
-				"Namespace":   namespace,
-				"Name":        name,
-				"Pruned":      pruned,
-				"Err":         err,
-				"PackageHref": util.GetNamespacedPkgHref(manifestName, namespace, name),
-				"GitopsMode":  s.isGitopsModeEnabled(),
-			})
-			util.CheckTmplError(err, "pkgUninstallModalTmpl")
+			impact, err = s.uninstallImpact(ctx, name, namespace, cascade)
+			data["Namespace"] = namespace
+			data["Name"] = name
+			data["PackageHref"] = util.GetNamespacedPkgHref(manifestName, namespace, name)
 		}
+		data["Impact"] = impact
+		data["Err"] = err
+		data["HasDependents"] = errors.Is(err, uninstall.ErrHasDependents)
+		err = s.templatesFor(r).pkgUninstallModalTmpl.Execute(w, data)
+		util.CheckTmplError(err, "pkgUninstallModalTmpl")
 	}
 }
 
+// uninstallCascadeOptions are the choices that are offered in the uninstall modal if other packages depend on the
+// package
+var uninstallCascadeOptions = []struct {
+	Value uninstall.CascadeMode
+	Label string
+}{
+	{uninstall.CascadeNone, "Do not uninstall anything"},
+	{uninstall.CascadeDependents, "Uninstall them as well"},
+	{uninstall.CascadeOrphan, "Keep them, although they will not work correctly"},
+}
+
+// uninstallImpact computes which packages are affected if the given package is uninstalled with the cascade mode
+func (s *server) uninstallImpact(
+	ctx context.Context,
+	name, namespace string,
+	cascade uninstall.CascadeMode,
+) (*uninstall.Impact, error) {
+	g, err := s.dependencyMgr.NewGraph(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error validating uninstall: %w", err)
+	}
+	return uninstall.AnalyzeImpact(g, name, namespace, cascade)
+}
+
 func (s *server) open(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	pkgName := mux.Vars(r)["pkgName"]
@@ -465,7 +712,44 @@ func (s *server) handleOpen(ctx context.Context, w http.ResponseWriter, pkg ctrl
 }
 
 func (s *server) clusterPackages(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+	overview, listErr := s.getClusterPackagesOverview(r.Context())
+	repository := strings.TrimSpace(r.FormValue("repository"))
+	repositories := overview.repositories()
+	overview.filterRepository(repository)
+	favorites := favoritesSet(getFavoritesFromCookie(r))
+	overview.sortFavoritesFirst(favorites)
+	href := "/clusterpackages"
+	if repository != "" {
+		href += "?" + url.Values{"repository": {repository}}.Encode()
+	}
+	tmpl := s.templatesFor(r).clusterPkgsPageTemplate
+	tmplErr := s.executePage(w, tmpl, "clusterpackages", s.enrichPage(r, map[string]any{
+		"CurrentHref":                   href,
+		"Repository":                    repository,
+		"Repositories":                  repositories,
+		"PackageOrigins":                overview.origins,
+		"ClusterPackages":               overview.clusterPackages,
+		"Favorites":                     favorites,
+		"ClusterPackageUpdateAvailable": overview.updateAvailable,
+		"UpdatesAvailable":              overview.updatesAvailable,
+		"UpdateCount":                   countUpdatesAvailable(overview.updateAvailable),
+		"PackageHref":                   util.GetClusterPkgHref("-"),
+		"UpdateAllScope":                updateAllScopeCluster,
+	}, listErr))
+	util.CheckTmplError(tmplErr, "clusterpackages")
+}
+
+type clusterPackagesOverview struct {
+	clusterPackages []*list.PackageWithStatus
+	// origins contains the repository every installed clusterpackage was installed from, keyed by its name
+	origins          map[string][]string
+	updateAvailable  map[string]bool
+	updatesAvailable bool
+}
+
+// getClusterPackagesOverview collects the data shown on the clusterpackages overview page. It is also used by the
+// JSON API, so both always show the same state.
+func (s *server) getClusterPackagesOverview(ctx context.Context) (*clusterPackagesOverview, error) {
 	clpkgs, listErr := list.NewLister(ctx).GetClusterPackagesWithStatus(ctx, list.ListOptions{IncludePackageInfos: true})
 	if listErr != nil && len(clpkgs) == 0 {
 		listErr = fmt.Errorf("could not load clusterpackages: %w", listErr)
@@ -477,29 +761,92 @@ func (s *server) clusterPackages(w http.ResponseWriter, r *http.Request) {
 	// conflicts could be resolvable by installing individual clpkgs.
 	installedClpkgs := make([]ctrlpkg.Package, 0, len(clpkgs))
 	clpkgUpdateAvailable := map[string]bool{}
+	origins := map[string][]string{}
 	for _, pkg := range clpkgs {
 		if pkg.ClusterPackage != nil {
 			installedClpkgs = append(installedClpkgs, pkg.ClusterPackage)
+			if origin := packageOrigin(pkg.ClusterPackage); origin != "" {
+				origins[pkg.Name] = []string{origin}
+			}
 		}
-		clpkgUpdateAvailable[pkg.Name] = s.isUpdateAvailableForPkg(r.Context(), pkg.ClusterPackage)
+		clpkgUpdateAvailable[pkg.Name] = s.isUpdateAvailableForPkg(ctx, pkg.ClusterPackage)
 	}
 
 	overallUpdatesAvailable := false
 	if len(installedClpkgs) > 0 {
-		overallUpdatesAvailable = s.isUpdateAvailable(r.Context(), installedClpkgs)
+		overallUpdatesAvailable = s.isUpdateAvailable(ctx, installedClpkgs)
 	}
 
-	tmplErr := s.templates.clusterPkgsPageTemplate.Execute(w, s.enrichPage(r, map[string]any{
-		"ClusterPackages":               clpkgs,
-		"ClusterPackageUpdateAvailable": clpkgUpdateAvailable,
-		"UpdatesAvailable":              overallUpdatesAvailable,
-		"PackageHref":                   util.GetClusterPkgHref("-"),
-	}, listErr))
-	util.CheckTmplError(tmplErr, "clusterpackages")
+	return &clusterPackagesOverview{
+		clusterPackages:  clpkgs,
+		origins:          origins,
+		updateAvailable:  clpkgUpdateAvailable,
+		updatesAvailable: overallUpdatesAvailable,
+	}, listErr
 }
 
 func (s *server) packages(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+	overview, listErr := s.getPackagesOverview(r.Context())
+	filter := packageFilterFromRequest(r)
+	categories := overview.categories()
+	repositories := overview.repositories()
+	filter.apply(overview)
+	favorites := favoritesSet(getFavoritesFromCookie(r))
+	overview.sortFavoritesFirst(favorites)
+	installedCount := len(overview.installed)
+	view := getPackagesViewFromCookie(r)
+	sort := packageSortFromRequest(r)
+	page := paginationFromRequest(r, strings.Trim(filter.QueryString()+"&"+sort.QueryString(), "&"))
+	var rows []packageTableRow
+	if view == packagesViewTable {
+		rows = overview.tableRows()
+		sort.apply(rows)
+		page.setTotalCount(len(rows))
+		rows = rows[page.Start():page.End()]
+	} else {
+		page.apply(overview)
+	}
+	sort.page = page
+	tmplErr := s.executePage(w, s.templatesFor(r).pkgsPageTmpl, "packages", s.enrichPage(r, map[string]any{
+		"Filter":                 filter,
+		"Pagination":             page,
+		"PackagesView":           view,
+		"Sort":                   sort,
+		"SortColumns":            sortColumns,
+		"TableRows":              rows,
+		"InstalledCount":         installedCount,
+		"Favorites":              favorites,
+		"Categories":             categories,
+		"Repositories":           repositories,
+		"PackageRepositories":    overview.repos,
+		"PackageOrigins":         overview.origins,
+		"InstalledPackages":      overview.installed,
+		"AvailablePackages":      overview.available,
+		"PackageUpdateAvailable": overview.updateAvailable,
+		"UpdatesAvailable":       overview.updatesAvailable,
+		"UpdateCount":            countUpdatesAvailable(overview.updateAvailable),
+		"PackageHref":            util.GetNamespacedPkgHref("-", "-", "-"),
+		"UpdateAllScope":         updateAllScopeNamespaced,
+	}, listErr))
+	util.CheckTmplError(tmplErr, "packages")
+}
+
+type packagesOverview struct {
+	installed []*list.PackagesWithStatus
+	available []*repotypes.PackageRepoIndexItem
+	// repos contains the repositories every package is available in, keyed by the name of the package
+	repos map[string][]string
+	// origins contains the repositories the installed instances of every package were installed from, keyed by the
+	// name of the package
+	origins map[string][]string
+	// updateAvailable is keyed by the namespaced name of the installed package
+	updateAvailable  map[string]bool
+	updatesAvailable bool
+}
+
+// getPackagesOverview collects the data shown on the packages overview page. It is also used by the JSON API, so
+// both always show the same state.
+func (s *server) getPackagesOverview(ctx context.Context) (*packagesOverview, error) {
 	allPkgs, listErr := list.NewLister(ctx).GetPackagesWithStatus(ctx, list.ListOptions{IncludePackageInfos: true})
 	if listErr != nil {
 		listErr = fmt.Errorf("could not load packages: %w", listErr)
@@ -508,13 +855,19 @@ func (s *server) packages(w http.ResponseWriter, r *http.Request) {
 	}
 
 	packageUpdateAvailable := map[string]bool{}
+	repos := map[string][]string{}
+	origins := map[string][]string{}
 	var installed []*list.PackagesWithStatus
 	var available []*repotypes.PackageRepoIndexItem
 	var installedPkgs []ctrlpkg.Package
 	for _, pkgsWithStatus := range allPkgs {
+		repos[pkgsWithStatus.Name] = pkgsWithStatus.Repos
 		if len(pkgsWithStatus.Packages) > 0 {
 			for _, pkgWithStatus := range pkgsWithStatus.Packages {
 				installedPkgs = append(installedPkgs, pkgWithStatus.Package)
+				if origin := packageOrigin(pkgWithStatus.Package); origin != "" {
+					origins[pkgsWithStatus.Name] = append(origins[pkgsWithStatus.Name], origin)
+				}
 
 				// Call isUpdateAvailable for each installed package.
 				// This is not the same as getting all updates in a single transaction, because some dependency
@@ -522,6 +875,8 @@ func (s *server) packages(w http.ResponseWriter, r *http.Request) {
 				packageUpdateAvailable[cache.MetaObjectToName(pkgWithStatus.Package).String()] =
 					s.isUpdateAvailableForPkg(ctx, pkgWithStatus.Package)
 			}
+			slices.Sort(origins[pkgsWithStatus.Name])
+			origins[pkgsWithStatus.Name] = slices.Compact(origins[pkgsWithStatus.Name])
 			installed = append(installed, pkgsWithStatus)
 		} else {
 			available = append(available, &pkgsWithStatus.PackageRepoIndexItem)
@@ -530,17 +885,17 @@ func (s *server) packages(w http.ResponseWriter, r *http.Request) {
 
 	overallUpdatesAvailable := false
 	if len(installedPkgs) > 0 {
-		overallUpdatesAvailable = s.isUpdateAvailable(r.Context(), installedPkgs)
+		overallUpdatesAvailable = s.isUpdateAvailable(ctx, installedPkgs)
 	}
 
-	tmplErr := s.templates.pkgsPageTmpl.Execute(w, s.enrichPage(r, map[string]any{
-		"InstalledPackages":      installed,
-		"AvailablePackages":      available,
-		"PackageUpdateAvailable": packageUpdateAvailable,
-		"UpdatesAvailable":       overallUpdatesAvailable,
-		"PackageHref":            util.GetNamespacedPkgHref("-", "-", "-"),
-	}, listErr))
-	util.CheckTmplError(tmplErr, "packages")
+	return &packagesOverview{
+		installed:        installed,
+		available:        available,
+		repos:            repos,
+		origins:          origins,
+		updateAvailable:  packageUpdateAvailable,
+		updatesAvailable: overallUpdatesAvailable,
+	}, listErr
 }
 
 func (s *server) isGitopsModeEnabled() bool {
@@ -558,10 +913,14 @@ func (s *server) supportPage(w http.ResponseWriter, r *http.Request) {
 			http.Redirect(w, r, "/bootstrap", http.StatusFound)
 			return
 		}
-		err := s.templates.supportPageTmpl.Execute(w, &map[string]any{
+		err := s.executePage(w, s.templatesFor(r).supportPageTmpl, "support", &map[string]any{
 			"CurrentContext":            "",
 			"KubeconfigDefaultLocation": clientcmd.RecommendedHomeFile,
 			"Err":                       err,
+			"Request":                   r,
+			"CodeStyle":                 getCodeStyleFromCookie(r),
+			"CSRFToken":                 csrfTokenFromContext(r),
+			"Support":                   s.SupportOptions,
 		})
 		util.CheckTmplError(err, "support")
 	} else {
@@ -575,10 +934,12 @@ func (s *server) bootstrapPage(w http.ResponseWriter, r *http.Request) {
 		client := bootstrap.NewBootstrapClient(s.restConfig)
 		if _, err := client.Bootstrap(ctx, bootstrap.DefaultOptions()); err != nil {
 			fmt.Fprintf(os.Stderr, "\nAn error occurred during bootstrap:\n%v\n", err)
-			err := s.templates.bootstrapPageTmpl.ExecuteTemplate(w, "bootstrap-failure", nil)
+			err := s.templatesFor(r).bootstrapPageTmpl.ExecuteTemplate(w, "bootstrap-failure",
+				map[string]any{"Support": s.SupportOptions})
 			util.CheckTmplError(err, "bootstrap-failure")
 		} else {
-			err := s.templates.bootstrapPageTmpl.ExecuteTemplate(w, "bootstrap-success", nil)
+			err := s.templatesFor(r).bootstrapPageTmpl.ExecuteTemplate(w, "bootstrap-success",
+				map[string]any{"Support": s.SupportOptions})
 			util.CheckTmplError(err, "bootstrap-success")
 		}
 	} else {
@@ -589,10 +950,14 @@ func (s *server) bootstrapPage(w http.ResponseWriter, r *http.Request) {
 			http.Redirect(w, r, "/", http.StatusFound)
 			return
 		}
-		tplErr := s.templates.bootstrapPageTmpl.Execute(w, &map[string]any{
+		tplErr := s.executePage(w, s.templatesFor(r).bootstrapPageTmpl, "bootstrap", &map[string]any{
 			"CloudId":        telemetry.GetMachineId(),
 			"CurrentContext": s.rawConfig.CurrentContext,
 			"Err":            err,
+			"Request":        r,
+			"CodeStyle":      getCodeStyleFromCookie(r),
+			"CSRFToken":      csrfTokenFromContext(r),
+			"Support":        s.SupportOptions,
 		})
 		util.CheckTmplError(tplErr, "bootstrap")
 	}
@@ -623,20 +988,56 @@ func (s *server) kubeconfigPage(w http.ResponseWriter, r *http.Request) {
 	if s.rawConfig != nil {
 		currentContext = s.rawConfig.CurrentContext
 	}
-	tplErr := s.templates.kubeconfigPageTmpl.Execute(w, map[string]any{
+	tplErr := s.executePage(w, s.templatesFor(r).kubeconfigPageTmpl, "kubeconfig", map[string]any{
 		"CloudId":                   telemetry.GetMachineId(),
 		"CurrentContext":            currentContext,
 		"ConfigErr":                 configErr,
 		"KubeconfigDefaultLocation": clientcmd.RecommendedHomeFile,
 		"DefaultKubeconfigExists":   defaultKubeconfigExists(),
+		"Request":                   r,
+		"CodeStyle":                 getCodeStyleFromCookie(r),
+		"CSRFToken":                 csrfTokenFromContext(r),
 	})
 	util.CheckTmplError(tplErr, "kubeconfig")
 }
 
 func (s *server) settingsPage(w http.ResponseWriter, r *http.Request) {
 	if r.Method == http.MethodPost {
-		formVal := r.FormValue(advancedOptionsKey)
-		setAdvancedOptionsCookie(w, formVal == "on")
+		if err := r.ParseForm(); err != nil {
+			s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+			return
+		}
+		if r.PostForm.Has(themeKey) {
+			theme := r.PostForm.Get(themeKey)
+			if !isValidTheme(theme) {
+				s.sendToast(w, toast.WithErr(fmt.Errorf("invalid theme: %v", theme)),
+					toast.WithStatusCode(http.StatusBadRequest))
+				return
+			}
+			setThemeCookie(w, theme)
+		} else if r.PostForm.Has(codeStyleKey) {
+			codeStyle := r.PostForm.Get(codeStyleKey)
+			if !isValidCodeStyle(codeStyle) {
+				s.sendToast(w, toast.WithErr(fmt.Errorf("invalid code style: %v", codeStyle)),
+					toast.WithStatusCode(http.StatusBadRequest))
+				return
+			}
+			setCodeStyleCookie(w, codeStyle)
+			// the stylesheet is linked in the page head, so a full reload is needed to apply the change
+			w.Header().Add("Hx-Refresh", "true")
+		} else if r.PostForm.Has(localeKey) {
+			locale := r.PostForm.Get(localeKey)
+			if !s.isValidLocale(locale) {
+				s.sendToast(w, toast.WithErr(fmt.Errorf("invalid language: %v", locale)),
+					toast.WithStatusCode(http.StatusBadRequest))
+				return
+			}
+			setLocaleCookie(w, locale)
+			w.Header().Add("Hx-Refresh", "true")
+		} else {
+			formVal := r.PostForm.Get(advancedOptionsKey)
+			setAdvancedOptionsCookie(w, formVal == "on")
+		}
 	} else if r.Method == http.MethodGet {
 		var repos v1alpha1.PackageRepositoryList
 		if err := s.pkgClient.PackageRepositories().GetAll(r.Context(), &repos); err != nil {
@@ -648,9 +1049,43 @@ func (s *server) settingsPage(w http.ResponseWriter, r *http.Request) {
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "failed to get advanced options from cookie: %v\n", err)
 		}
-		tmplErr := s.templates.settingsPageTmpl.Execute(w, s.enrichPage(r, map[string]any{
-			"Repositories":    repos.Items,
-			"AdvancedOptions": advancedOptions,
+		notificationConfig, err := s.getNotificationConfig(r.Context())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to get notification config: %v\n", err)
+		}
+		registryMirrors, err := s.getRegistryMirrors(r.Context())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to get registry mirrors: %v\n", err)
+		}
+		clusterDefaults, err := s.valueResolver.ClusterDefaults(r.Context())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to get cluster defaults: %v\n", err)
+		}
+		retentionConfig, err := s.getRetentionConfig(r.Context())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to get retention policy: %v\n", err)
+		}
+		retentionUsage, err := s.getRetentionUsage(r.Context())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to estimate retention usage: %v\n", err)
+		}
+		tmplErr := s.executePage(w, s.templatesFor(r).settingsPageTmpl, "settings", s.enrichPage(r, map[string]any{
+			"Repositories":        repos.Items,
+			"AdvancedOptions":     advancedOptions,
+			"CodeStyles":          codeStyles,
+			"Languages":           s.languages(),
+			"NotificationConfig":  notificationConfig,
+			"NotificationFormats": notification.Formats,
+			"Favorites":           getFavoritesFromCookie(r),
+			"RegistryMirrors":     registryMirrors.String(),
+			"ClusterDefaults":     formatClusterDefaults(clusterDefaults),
+			"Environment":         clusterDefaults[manifestvalues.EnvironmentKey],
+			"CurrentBanner":       s.getBanner(),
+			"Retention":           retentionConfig,
+			"RetentionUsage":      retentionUsage,
+			"BannerSeverities":    banner.Severities,
+			"Weekdays":            autoupdate.Weekdays,
+			"SessionTimeout":      s.sessionTimeoutMinutes(),
 		}, nil))
 		util.CheckTmplError(tmplErr, "settings")
 	}
@@ -674,7 +1109,7 @@ func (s *server) getHandleRepositoryConfig(w http.ResponseWriter, r *http.Reques
 		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to fetch repositories: %w", err)))
 		return
 	}
-	tmplErr := s.templates.repositoryPageTmpl.Execute(w, s.enrichPage(r, map[string]any{
+	tmplErr := s.executePage(w, s.templatesFor(r).repositoryPageTmpl, "repository", s.enrichPage(r, map[string]any{
 		"Repository": repo,
 	}, nil))
 	util.CheckTmplError(tmplErr, "repository")
@@ -694,9 +1129,10 @@ func (s *server) getUpdateRepositoryConfig(w http.ResponseWriter, r *http.Reques
 		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to fetch repositories: %w", err)))
 		return
 	}
+	original := *repo.DeepCopy()
 
 	if repoUrl != "" {
-		if _, err := url.ParseRequestURI(repoUrl); err != nil {
+		if err := repoclient.ValidateURL(repoUrl); err != nil {
 			s.sendToast(w, toast.WithErr(fmt.Errorf("use a valid URL for the package repository (got %v)", err)))
 			return
 		}
@@ -705,6 +1141,37 @@ func (s *server) getUpdateRepositoryConfig(w http.ResponseWriter, r *http.Reques
 
 	repo.Spec.Auth = nil
 
+	if syncInterval := r.FormValue("syncInterval"); syncInterval == "" {
+		repo.Spec.SyncInterval = nil
+	} else if d, err := time.ParseDuration(syncInterval); err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("use a valid duration for the sync interval, e.g. 5m (got %v)", err)),
+			toast.WithStatusCode(http.StatusBadRequest))
+		return
+	} else if d < v1alpha1.MinSyncInterval {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("the sync interval must be at least %v", v1alpha1.MinSyncInterval)),
+			toast.WithStatusCode(http.StatusBadRequest))
+		return
+	} else {
+		repo.Spec.SyncInterval = &metav1.Duration{Duration: d}
+	}
+
+	if priority := strings.TrimSpace(r.FormValue("priority")); priority == "" {
+		repo.Spec.Priority = 0
+	} else if p, err := strconv.Atoi(priority); err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("use a whole number for the priority (got %v)", priority)),
+			toast.WithStatusCode(http.StatusBadRequest))
+		return
+	} else {
+		repo.Spec.Priority = p
+	}
+
+	if impact, err := s.getRepositoryUpdateImpact(r.Context(), original, repoUrl, checkDefault == "on"); err != nil {
+		s.sendToast(w, toast.WithErr(err))
+		return
+	} else if !s.confirmImpact(w, r, impact) {
+		return
+	}
+
 	if checkDefault == "on" {
 		defaultRepo, err = cliutils.GetDefaultRepo(r.Context())
 		if errors.Is(err, cliutils.NoDefaultRepo) {
@@ -735,6 +1202,85 @@ func (s *server) getUpdateRepositoryConfig(w http.ResponseWriter, r *http.Reques
 	s.swappingRedirect(w, "/settings", "main", "main")
 }
 
+// repositoryDelete deletes the repository after the user has confirmed the impact. Like "glasskube repo delete", it
+// refuses to delete a repository from which packages are installed.
+func (s *server) repositoryDelete(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+	repoName := mux.Vars(r)["repoName"]
+	var repo v1alpha1.PackageRepository
+	if err := s.pkgClient.PackageRepositories().Get(r.Context(), repoName, &repo); err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to fetch repository %v: %w", repoName, err)))
+		return
+	}
+	var repos v1alpha1.PackageRepositoryList
+	if err := s.pkgClient.PackageRepositories().GetAll(r.Context(), &repos); err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to fetch repositories: %w", err)))
+		return
+	}
+	installed, err := s.packagesOfRepository(r.Context(), repo)
+	if err != nil {
+		s.sendToast(w, toast.WithErr(err))
+		return
+	}
+	if !s.confirmImpact(w, r, repositoryDeletionImpact(repo, len(repos.Items), installed)) {
+		return
+	}
+	if err := s.pkgClient.PackageRepositories().Delete(r.Context(), &repo, metav1.DeleteOptions{}); err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to delete repository %v: %w", repoName, err)))
+		return
+	}
+	s.swappingRedirect(w, "/settings", "main", "main")
+}
+
+// repositorySyncDebounce is the minimum time between two manual syncs of a repository
+const repositorySyncDebounce = 10 * time.Second
+
+// repositorySync requests an immediate sync of the repository. Requests that arrive shortly after the last sync or
+// sync request are ignored, so that the remote is not hit with a request for every click.
+func (s *server) repositorySync(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	repoName := mux.Vars(r)["repoName"]
+	var repo v1alpha1.PackageRepository
+	if err := s.pkgClient.PackageRepositories().Get(r.Context(), repoName, &repo); err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to fetch repository %v: %w", repoName, err)))
+		return
+	}
+	if repo.IsSyncRequested() {
+		s.sendToast(w, toast.WithMessage("A sync of this repository is already in progress"))
+		return
+	}
+	lastSync := time.Time{}
+	if repo.Status.LastSyncTime != nil {
+		lastSync = repo.Status.LastSyncTime.Time
+	}
+	if requestedAt, ok := repo.SyncRequestedAt(); ok && requestedAt.After(lastSync) {
+		lastSync = requestedAt
+	}
+	if time.Since(lastSync) < repositorySyncDebounce {
+		s.sendToast(w, toast.WithMessage("This repository was synced just now"))
+		return
+	}
+	repo.RequestSync(time.Now())
+	if err := s.pkgClient.PackageRepositories().Update(r.Context(), &repo, metav1.UpdateOptions{}); err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to request sync of repository %v: %w", repoName, err)))
+		return
+	}
+	if invalidator, ok := s.repoClientset.ForRepo(repo).(repoclient.CacheInvalidator); ok {
+		invalidator.InvalidateCache()
+	}
+	s.sendToast(w, toast.WithMessage(fmt.Sprintf("Sync of repository %v requested", repoName)))
+}
+
 func (s *server) enrichPage(r *http.Request, data map[string]any, err error) map[string]any {
 	data["CloudId"] = telemetry.GetMachineId()
 	if pathParts := strings.Split(r.URL.Path, "/"); len(pathParts) >= 2 {
@@ -743,6 +1289,10 @@ func (s *server) enrichPage(r *http.Request, data map[string]any, err error) map
 	data["Error"] = err
 	data["CurrentContext"] = s.rawConfig.CurrentContext
 	data["GitopsMode"] = s.isGitopsModeEnabled()
+	data["ReadOnly"] = s.ReadOnly
+	data["AutoUpdateFreeze"] = s.getAutoUpdateFreeze()
+	data["AutoUpdateWindow"] = s.getAutoUpdateWindow()
+	data["Banner"] = s.getVisibleBanner(r)
 	operatorVersion, clientVersion, err := s.getGlasskubeVersions(r.Context())
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to check for version mismatch: %v\n", err)
@@ -764,6 +1314,13 @@ func (s *server) enrichPage(r *http.Request, data map[string]any, err error) map
 		}
 	}
 	data["CacheBustingString"] = config.Version
+	data["Request"] = r
+	data["CodeStyle"] = getCodeStyleFromCookie(r)
+	data["PreferredLocale"] = getLocaleFromCookie(r)
+	data["RequestId"] = requestIdFromRequest(r)
+	data["CSRFToken"] = csrfTokenFromContext(r)
+	data["Support"] = s.SupportOptions
+	data["User"] = userFromContext(r)
 	return data
 }
 
@@ -869,14 +1426,19 @@ func (server *server) initWhenBootstrapped(ctx context.Context) {
 	server.configMapLister = &configMapLister
 	secretLister := factory.Core().V1().Secrets().Lister()
 	server.secretLister = &secretLister
+	server.initWorkloadInformers(context.WithoutCancel(ctx), factory)
 	factory.Start(c)
 }
 
 func (server *server) initClientDependentComponents() {
-	server.repoClientset = repoclient.NewClientset(
-		clientadapter.NewPackageClientAdapter(server.pkgClient),
-		clientadapter.NewKubernetesClientAdapter(server.k8sClient),
-	)
+	server.repoClientset = &instrumentedRepoClientset{
+		RepoClientset: repoclient.NewClientset(
+			clientadapter.NewPackageClientAdapter(server.pkgClient),
+			clientadapter.NewKubernetesClientAdapter(server.k8sClient),
+		),
+		metrics: server.metrics,
+		synced:  &server.repoSynced,
+	}
 	server.templates.repoClientset = server.repoClientset
 	server.dependencyMgr = dependency.NewDependencyManager(
 		clientadapter.NewPackageClientAdapter(server.pkgClient),
@@ -886,6 +1448,13 @@ func (server *server) initClientDependentComponents() {
 		clientadapter.NewPackageClientAdapter(server.pkgClient),
 		clientadapter.NewKubernetesClientAdapter(server.k8sClient),
 	)
+	if c, err := ctrlclient.New(server.restConfig, ctrlclient.Options{}); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create client for rendering package resources: %v\n", err)
+	} else if renderer, err := render.NewRenderer(c, server.repoClientset, server.valueResolver); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create renderer for package resources: %v\n", err)
+	} else {
+		server.renderer = renderer
+	}
 }
 
 func (server *server) initCachedClient(ctx context.Context) {
@@ -894,6 +1463,9 @@ func (server *server) initCachedClient(ctx context.Context) {
 	packageInfoStore, packageInfoController := server.initPackageInfoStoreAndController(ctx)
 	packageRepoStore, packageRepoController := server.initPackageRepoStoreAndController(ctx)
 	server.pkgClient = server.nonCachedClient.WithStores(clusterPackageStore, packageStore, packageInfoStore, packageRepoStore)
+	server.cacheControllers = []cache.Controller{
+		clusterPackageController, packageController, packageInfoController, packageRepoController,
+	}
 
 	clpkgVerifier := newVerifier(server.restConfig, clusterPackageVerifyLister)
 	pkgVerifier := newVerifier(server.restConfig, packageVerifyLister)
@@ -1148,7 +1720,13 @@ func (s *server) initPackageRepoStoreAndController(ctx context.Context) (cache.S
 			},
 		},
 		ObjectType: &v1alpha1.PackageRepository{},
-		Handler:    cache.ResourceEventHandlerFuncs{}, // TODO we might also want to update here?
+		Handler: cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(oldObj, newObj any) {
+				if repo, ok := newObj.(*v1alpha1.PackageRepository); ok {
+					s.broadcaster.RepositoryChanged(repo.Name)
+				}
+			},
+		},
 	})
 }
 
@@ -1159,6 +1737,17 @@ func (s *server) isUpdateAvailableForPkg(ctx context.Context, pkg ctrlpkg.Packag
 	return s.isUpdateAvailable(ctx, []ctrlpkg.Package{pkg})
 }
 
+// countUpdatesAvailable returns the number of packages for which an update is available individually
+func countUpdatesAvailable(updateAvailable map[string]bool) int {
+	count := 0
+	for _, available := range updateAvailable {
+		if available {
+			count++
+		}
+	}
+	return count
+}
+
 func (s *server) isUpdateAvailable(ctx context.Context, pkgs []ctrlpkg.Package) bool {
 	if tx, err := update.NewUpdater(ctx).Prepare(ctx, update.GetExact(pkgs)); err != nil {
 		fmt.Fprintf(os.Stderr, "Error checking for updates: %v\n", err)
@@ -0,0 +1,317 @@
+package web
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/glasskube/glasskube/internal/web/components/toast"
+)
+
+const (
+	sessionCookieKey = "session"
+	// sessionCleanupInterval is the minimum time between two removals of expired sessions
+	sessionCleanupInterval = time.Minute
+	minSessionTimeout      = time.Minute
+	maxSessionTimeout      = 24 * time.Hour
+	// sessionLoginPath starts a new session after the proxy has authenticated the user again. LoginURL must lead here
+	// once the user has logged in, because no other request starts a session after the previous one has ended.
+	sessionLoginPath = "/login"
+)
+
+var (
+	errNotAuthenticated = errors.New("you are not authenticated. Please log in again")
+	errSessionExpired   = errors.New("your session has expired. Please log in again")
+)
+
+// AuthOptions configure the sessions of users that have been authenticated by a reverse proxy in front of the web
+// server, e.g. oauth2-proxy
+type AuthOptions struct {
+	// UserHeader is the request header in which the proxy passes the name of the authenticated user, e.g.
+	// X-Forwarded-User. Sessions are only used if it is set.
+	UserHeader string
+	// SessionTimeout is the time after which a session without activity expires. It can be changed on the settings
+	// page until the server is restarted.
+	SessionTimeout time.Duration
+	// LoginURL is opened after the session has expired or the user has logged out. It must authenticate the user at
+	// the proxy again and then redirect to sessionLoginPath. It is required if UserHeader is set.
+	LoginURL string
+	// LogoutURL is opened after the user has logged out. It must end the session of the proxy as well, because the proxy
+	// keeps passing UserHeader otherwise, so that the user could start a new session without logging in. It is
+	// required if UserHeader is set.
+	LogoutURL string
+}
+
+func DefaultAuthOptions() AuthOptions {
+	return AuthOptions{SessionTimeout: 30 * time.Minute}
+}
+
+func (opts AuthOptions) validate() error {
+	if !opts.sessionsEnabled() {
+		return nil
+	} else if opts.LoginURL == "" || opts.LoginURL == "/" {
+		return errors.New("a login URL that authenticates users at the proxy again is required if sessions are used")
+	} else if opts.LogoutURL == "" {
+		return errors.New("a logout URL that ends the session of the proxy is required if sessions are used")
+	}
+	return nil
+}
+
+func (opts AuthOptions) sessionsEnabled() bool {
+	return opts.UserHeader != ""
+}
+
+type session struct {
+	user         string
+	lastActivity time.Time
+}
+
+// sessionStore keeps the sessions of all users in memory, so that they can be invalidated on logout. Users whose
+// session has ended can only start a new one via sessionLoginPath, see create.
+type sessionStore struct {
+	mutex    sync.Mutex
+	sessions map[string]*session
+	// ended contains the users whose last session has expired or who have logged out, with the time it ended
+	ended       map[string]time.Time
+	timeout     time.Duration
+	lastCleanup time.Time
+	now         func() time.Time
+}
+
+func newSessionStore(timeout time.Duration) *sessionStore {
+	return &sessionStore{
+		sessions: make(map[string]*session),
+		ended:    make(map[string]time.Time),
+		timeout:  timeout,
+		now:      time.Now,
+	}
+}
+
+func (store *sessionStore) Timeout() time.Duration {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return store.timeout
+}
+
+// SetTimeout changes the idle timeout of all sessions, including the existing ones
+func (store *sessionStore) SetTimeout(timeout time.Duration) error {
+	if timeout < minSessionTimeout || timeout > maxSessionTimeout {
+		return fmt.Errorf("session timeout must be between %v and %v", minSessionTimeout, maxSessionTimeout)
+	}
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	store.timeout = timeout
+	return nil
+}
+
+// create starts a new session for the given user and returns its id. It must only be called for users whose session
+// has not ended or who have been authenticated again, because it clears the end of their previous session.
+func (store *sessionStore) create(user string) string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	id := base64.RawURLEncoding.EncodeToString(b)
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	now := store.now()
+	if now.Sub(store.lastCleanup) > sessionCleanupInterval {
+		for key, s := range store.sessions {
+			if now.Sub(s.lastActivity) > store.timeout {
+				delete(store.sessions, key)
+				store.ended[s.user] = now
+			}
+		}
+		store.lastCleanup = now
+	}
+	delete(store.ended, user)
+	store.sessions[id] = &session{user: user, lastActivity: now}
+	return id
+}
+
+// touch records activity in the session with the given id. It returns errSessionExpired if the session does not
+// exist, has expired or belongs to another user.
+func (store *sessionStore) touch(id, user string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	s, ok := store.sessions[id]
+	if !ok || s.user != user {
+		return errSessionExpired
+	}
+	now := store.now()
+	if now.Sub(s.lastActivity) > store.timeout {
+		delete(store.sessions, id)
+		store.ended[s.user] = now
+		return errSessionExpired
+	}
+	s.lastActivity = now
+	return nil
+}
+
+// delete ends the session with the given id, e.g. on logout
+func (store *sessionStore) delete(id string) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	if s, ok := store.sessions[id]; ok {
+		delete(store.sessions, id)
+		store.ended[s.user] = store.now()
+	}
+}
+
+// hasEnded returns true if the last session of the given user has expired or the user has logged out, and the user
+// has not started a new session via sessionLoginPath since
+func (store *sessionStore) hasEnded(user string) bool {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	_, ok := store.ended[user]
+	return ok
+}
+
+type userContextKey struct{}
+
+// sessionMiddleware enforces an idle timeout for users that have been authenticated by a proxy. The first full page
+// load of a user starts a session. Once it has expired or the user has logged out, all requests are rejected until
+// the user has been authenticated again and the proxy opens sessionLoginPath, which starts a new session. Requests
+// that may change something are rejected with 401 Unauthorized if there is no session, and htmx is told to open the
+// login URL. Other requests, e.g. partial page updates triggered by server-sent events, neither require nor extend a
+// session, so that an open tab alone does not keep a session alive.
+func (s *server) sessionMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.AuthOptions.sessionsEnabled() || isSessionExemptPath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		user := r.Header.Get(s.AuthOptions.UserHeader)
+		if user == "" {
+			s.rejectUnauthenticated(w, r, errNotAuthenticated)
+			return
+		}
+		id := getSessionFromCookie(r)
+		if err := s.sessions.touch(id, user); err != nil && r.URL.Path != sessionLoginPath {
+			if s.sessions.hasEnded(user) {
+				s.rejectUnauthenticated(w, r, err)
+				return
+			} else if isSafeMethod(r.Method) && isFullPageLoad(r) {
+				setSessionCookie(w, s.sessions.create(user))
+			} else if !isSafeMethod(r.Method) {
+				s.rejectUnauthenticated(w, r, err)
+				return
+			}
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), userContextKey{}, user)))
+	})
+}
+
+func (s *server) rejectUnauthenticated(w http.ResponseWriter, r *http.Request, err error) {
+	if isFullPageLoad(r) && isSafeMethod(r.Method) {
+		http.Redirect(w, r, s.AuthOptions.LoginURL, http.StatusSeeOther)
+		return
+	} else if !isApiRequest(r) {
+		w.Header().Set("Hx-Redirect", s.AuthOptions.LoginURL)
+	}
+	s.sendError(w, r, http.StatusUnauthorized, apiErrorUnauthorized, err)
+}
+
+func isSessionExemptPath(path string) bool {
+	return strings.HasPrefix(path, "/static/") || path == "/favicon.ico" || path == "/metrics" ||
+		path == dashboardPath
+}
+
+// userFromContext returns the name of the authenticated user or an empty string if sessions are not used
+func userFromContext(r *http.Request) string {
+	if user, ok := r.Context().Value(userContextKey{}).(string); ok {
+		return user
+	}
+	return ""
+}
+
+// login starts a new session for a user that has been authenticated again by the proxy, see sessionLoginPath, and
+// opens the overview afterwards
+func (s *server) login(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if user := userFromContext(r); user != "" {
+		setSessionCookie(w, s.sessions.create(user))
+	}
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+// logout ends the current session and removes the CSRF token, so that neither can be used again. Afterwards, the
+// browser is sent to the logout URL.
+func (s *server) logout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if id := getSessionFromCookie(r); id != "" {
+		s.sessions.delete(id)
+	}
+	setSessionCookie(w, "")
+	deleteCSRFCookie(w)
+	w.Header().Set("Hx-Redirect", s.AuthOptions.LogoutURL)
+}
+
+// sessionSettings changes the idle timeout of all sessions. The timeout is given in minutes.
+func (s *server) sessionSettings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.AuthOptions.sessionsEnabled() {
+		s.sendToast(w, toast.WithErr(errors.New("sessions are not enabled")), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+	minutes, err := strconv.Atoi(r.PostForm.Get("timeout"))
+	if err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("invalid session timeout: %w", err)),
+			toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+	if err := s.sessions.SetTimeout(time.Duration(minutes) * time.Minute); err != nil {
+		s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+	s.sendToast(w, toast.WithMessage(fmt.Sprintf("Sessions now expire after %v minutes without activity", minutes)))
+}
+
+// sessionTimeoutMinutes returns the current idle timeout for the settings page or zero if sessions are not used
+func (s *server) sessionTimeoutMinutes() int {
+	if !s.AuthOptions.sessionsEnabled() {
+		return 0
+	}
+	return int(s.sessions.Timeout() / time.Minute)
+}
+
+func getSessionFromCookie(r *http.Request) string {
+	if c, err := r.Cookie(sessionCookieKey); err == nil {
+		return c.Value
+	}
+	return ""
+}
+
+func setSessionCookie(w http.ResponseWriter, id string) {
+	// no MaxAge, so that the cookie is discarded when the browser session ends
+	cookie := http.Cookie{
+		Name:     sessionCookieKey,
+		Value:    id,
+		Path:     "/",
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	}
+	if id == "" {
+		cookie.MaxAge = -1
+	}
+	http.SetCookie(w, &cookie)
+}
@@ -0,0 +1,164 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gorilla/mux"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("sessionStore", func() {
+	var store *sessionStore
+	var now time.Time
+
+	BeforeEach(func() {
+		now = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+		store = newSessionStore(10 * time.Minute)
+		store.now = func() time.Time { return now }
+	})
+
+	It("should extend a session on activity", func() {
+		id := store.create("alice")
+		now = now.Add(9 * time.Minute)
+		Expect(store.touch(id, "alice")).To(Succeed())
+		now = now.Add(9 * time.Minute)
+		Expect(store.touch(id, "alice")).To(Succeed())
+	})
+
+	It("should expire an idle session", func() {
+		id := store.create("alice")
+		now = now.Add(11 * time.Minute)
+		Expect(store.touch(id, "alice")).To(MatchError(errSessionExpired))
+		now = now.Add(-11 * time.Minute)
+		Expect(store.touch(id, "alice")).To(MatchError(errSessionExpired))
+	})
+
+	It("should reject unknown sessions and sessions of other users", func() {
+		id := store.create("alice")
+		Expect(store.touch("unknown", "alice")).To(MatchError(errSessionExpired))
+		Expect(store.touch(id, "bob")).To(MatchError(errSessionExpired))
+	})
+
+	It("should end a deleted session", func() {
+		id := store.create("alice")
+		store.delete(id)
+		Expect(store.touch(id, "alice")).To(MatchError(errSessionExpired))
+	})
+
+	It("should apply a new timeout to existing sessions", func() {
+		id := store.create("alice")
+		Expect(store.SetTimeout(5 * time.Minute)).To(Succeed())
+		now = now.Add(6 * time.Minute)
+		Expect(store.touch(id, "alice")).To(MatchError(errSessionExpired))
+	})
+
+	It("should reject timeouts out of range", func() {
+		Expect(store.SetTimeout(time.Second)).NotTo(Succeed())
+		Expect(store.SetTimeout(48 * time.Hour)).NotTo(Succeed())
+		Expect(store.Timeout()).To(Equal(10 * time.Minute))
+	})
+})
+
+var _ = Describe("sessionMiddleware", func() {
+	const loginURL = "https://auth.example.com/start?rd=/login"
+	const logoutURL = "https://auth.example.com/sign_out"
+	var store *sessionStore
+	var now time.Time
+	var router *mux.Router
+
+	BeforeEach(func() {
+		now = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+		store = newSessionStore(10 * time.Minute)
+		store.now = func() time.Time { return now }
+		s := &server{
+			ServerOptions: ServerOptions{AuthOptions: AuthOptions{
+				UserHeader: "X-Forwarded-User",
+				LoginURL:   loginURL,
+				LogoutURL:  logoutURL,
+			}},
+			sessions: store,
+		}
+		router = mux.NewRouter()
+		router.Use(s.sessionMiddleware)
+		router.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {})
+		router.HandleFunc(sessionLoginPath, s.login)
+		router.HandleFunc("/logout", s.logout)
+	})
+
+	// serve sends a request of alice with the given session and returns the response and the session cookie, if any
+	serve := func(method, path, id string) (*httptest.ResponseRecorder, *http.Cookie) {
+		r := httptest.NewRequest(method, path, nil)
+		r.Header.Set("X-Forwarded-User", "alice")
+		r.Header.Set("Accept", "text/html")
+		if id != "" {
+			r.AddCookie(&http.Cookie{Name: sessionCookieKey, Value: id})
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		for _, c := range w.Result().Cookies() {
+			if c.Name == sessionCookieKey {
+				return w, c
+			}
+		}
+		return w, nil
+	}
+
+	It("should start a session on the first page load", func() {
+		w, cookie := serve(http.MethodGet, "/", "")
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(cookie).NotTo(BeNil())
+		Expect(store.touch(cookie.Value, "alice")).To(Succeed())
+	})
+
+	It("should not start a new session after logout until the user logs in again", func() {
+		_, cookie := serve(http.MethodGet, "/", "")
+		w, _ := serve(http.MethodPost, "/logout", cookie.Value)
+		Expect(w.Header().Get("Hx-Redirect")).To(Equal(logoutURL))
+
+		w, newCookie := serve(http.MethodGet, "/", cookie.Value)
+		Expect(w.Code).To(Equal(http.StatusSeeOther))
+		Expect(w.Header().Get("Location")).To(Equal(loginURL))
+		Expect(newCookie).To(BeNil())
+		w, newCookie = serve(http.MethodGet, "/", "")
+		Expect(w.Code).To(Equal(http.StatusSeeOther))
+		Expect(newCookie).To(BeNil())
+
+		w, newCookie = serve(http.MethodGet, sessionLoginPath, "")
+		Expect(w.Code).To(Equal(http.StatusSeeOther))
+		Expect(w.Header().Get("Location")).To(Equal("/"))
+		Expect(newCookie).NotTo(BeNil())
+		w, _ = serve(http.MethodGet, "/", newCookie.Value)
+		Expect(w.Code).To(Equal(http.StatusOK))
+	})
+
+	It("should not start a new session after the session has expired", func() {
+		_, cookie := serve(http.MethodGet, "/", "")
+		now = now.Add(11 * time.Minute)
+
+		w, newCookie := serve(http.MethodGet, "/", cookie.Value)
+		Expect(w.Code).To(Equal(http.StatusSeeOther))
+		Expect(w.Header().Get("Location")).To(Equal(loginURL))
+		Expect(newCookie).To(BeNil())
+		w, newCookie = serve(http.MethodGet, "/", "")
+		Expect(w.Code).To(Equal(http.StatusSeeOther))
+		Expect(newCookie).To(BeNil())
+	})
+})
+
+var _ = Describe("AuthOptions", func() {
+	It("should require a login and a logout URL if sessions are used", func() {
+		Expect(AuthOptions{}.validate()).To(Succeed())
+		Expect(AuthOptions{UserHeader: "X-Forwarded-User"}.validate()).NotTo(Succeed())
+		Expect(AuthOptions{UserHeader: "X-Forwarded-User", LoginURL: "/"}.validate()).NotTo(Succeed())
+		Expect(AuthOptions{UserHeader: "X-Forwarded-User", LoginURL: "/oauth2/start?rd=/login"}.validate()).
+			NotTo(Succeed())
+		Expect(AuthOptions{
+			UserHeader: "X-Forwarded-User",
+			LoginURL:   "/oauth2/start?rd=/login",
+			LogoutURL:  "/oauth2/sign_out?rd=%2Foauth2%2Fstart%3Frd%3D%2Flogin",
+		}.validate()).To(Succeed())
+	})
+})
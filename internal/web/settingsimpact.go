@@ -0,0 +1,370 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/manifest/recreate"
+	"github.com/glasskube/glasskube/internal/manifest/render"
+	"github.com/glasskube/glasskube/internal/web/util"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+)
+
+// confirmedKey is set in the form of a settings change once the user has confirmed its impact
+const confirmedKey = "confirmed"
+
+// settingsImpact summarizes the consequences of a settings change. It is computed by the server, because the client
+// does not know which packages are affected.
+type settingsImpact struct {
+	// Title is the heading of the confirmation modal, e.g. "Delete repository glasskube"
+	Title string
+	Items []settingsImpactItem
+	// Blocked explains why the change can not be applied at all. If it is set, the modal can not be confirmed.
+	Blocked string
+}
+
+// settingsImpactItem is a single consequence of a settings change, optionally with the names of affected packages
+type settingsImpactItem struct {
+	Message  string
+	Packages []string
+}
+
+func (impact *settingsImpact) add(packages []string, format string, args ...any) {
+	impact.Items = append(impact.Items, settingsImpactItem{Message: fmt.Sprintf(format, args...), Packages: packages})
+}
+
+func (impact *settingsImpact) isEmpty() bool {
+	return impact == nil || (len(impact.Items) == 0 && impact.Blocked == "")
+}
+
+// confirmImpact returns true if the settings change can be applied, because it has no impact or the user has already
+// confirmed it. Otherwise, a modal that summarizes the impact is shown instead. Confirming it posts the same form to
+// the same path again, with confirmedKey set.
+func (s *server) confirmImpact(w http.ResponseWriter, r *http.Request, impact *settingsImpact) bool {
+	if impact.isEmpty() || (impact.Blocked == "" && r.PostFormValue(confirmedKey) == "true") {
+		return true
+	}
+	values := make(map[string][]string, len(r.PostForm))
+	for key, value := range r.PostForm {
+		if key != confirmedKey {
+			values[key] = value
+		}
+	}
+	// htmx headers to overwrite any existing/inherited hx-select, hx-swap, hx-target on the client
+	w.Header().Add("Hx-Reselect", "#settings-confirm-modal")
+	w.Header().Add("Hx-Reswap", "innerHTML")
+	w.Header().Add("Hx-Retarget", "#modal-container")
+	w.Header().Add("Hx-Trigger-After-Settle", "show-modal")
+	err := s.templatesFor(r).settingsConfirmModalTmpl.ExecuteTemplate(w, "settings-confirm-modal", map[string]any{
+		"Impact":       impact,
+		"Action":       r.URL.Path,
+		"Values":       values,
+		"ConfirmedKey": confirmedKey,
+	})
+	util.CheckTmplError(err, "settings-confirm-modal")
+	return false
+}
+
+// packagesOfRepository returns the names of all installed packages that come from the given repository. Packages
+// without a repository come from the default repository.
+func (s *server) packagesOfRepository(ctx context.Context, repo v1alpha1.PackageRepository) ([]string, error) {
+	pkgs, err := s.listAllInstalledPackages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return packageNamesWithRepository(pkgs, func(repoName string) bool {
+		return repoName == repo.Name || (repoName == "" && repo.IsDefaultRepository())
+	}), nil
+}
+
+// packageNamesWithRepository returns the sorted names of the packages whose repository name matches
+func packageNamesWithRepository(pkgs []ctrlpkg.Package, matches func(repoName string) bool) []string {
+	var result []string
+	for _, pkg := range pkgs {
+		if matches(pkg.GetSpec().PackageInfo.RepositoryName) {
+			result = append(result, cache.MetaObjectToName(pkg).String())
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+// listAllInstalledPackages returns the installed packages of both scopes
+func (s *server) listAllInstalledPackages(ctx context.Context) ([]ctrlpkg.Package, error) {
+	clpkgs, err := s.listInstalledPackages(ctx, updateAllScopeCluster)
+	if err != nil {
+		return nil, err
+	}
+	pkgs, err := s.listInstalledPackages(ctx, updateAllScopeNamespaced)
+	if err != nil {
+		return nil, err
+	}
+	return append(clpkgs, pkgs...), nil
+}
+
+// getRepositoryUpdateImpact computes the impact of changing the URL of repo to newUrl and, if makeDefault is true,
+// making it the default repository
+func (s *server) getRepositoryUpdateImpact(
+	ctx context.Context,
+	repo v1alpha1.PackageRepository,
+	newUrl string,
+	makeDefault bool,
+) (*settingsImpact, error) {
+	var repos v1alpha1.PackageRepositoryList
+	if err := s.pkgClient.PackageRepositories().GetAll(ctx, &repos); err != nil {
+		return nil, fmt.Errorf("failed to fetch repositories: %w", err)
+	}
+	var oldDefault *v1alpha1.PackageRepository
+	if makeDefault {
+		for i := range repos.Items {
+			if repos.Items[i].IsDefaultRepository() {
+				oldDefault = &repos.Items[i]
+			}
+		}
+	}
+	pkgs, err := s.listAllInstalledPackages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	installed := packageNamesWithRepository(pkgs, func(repoName string) bool {
+		return repoName == repo.Name || (repoName == "" && repo.IsDefaultRepository())
+	})
+	installedWithoutRepo := packageNamesWithRepository(pkgs, func(repoName string) bool { return repoName == "" })
+	return repositoryUpdateImpact(repo, newUrl, oldDefault, installed, installedWithoutRepo), nil
+}
+
+// repositoryDeletionImpact computes the impact of deleting repo. Like "glasskube repo delete", a repository can not be
+// deleted as long as packages are installed from it.
+func repositoryDeletionImpact(
+	repo v1alpha1.PackageRepository,
+	repoCount int,
+	installed []string,
+) *settingsImpact {
+	impact := settingsImpact{Title: fmt.Sprintf("Delete repository %v", repo.Name)}
+	if len(installed) > 0 {
+		impact.Blocked = fmt.Sprintf("%v can not be deleted, because %v from this repository. "+
+			"Uninstall them or change their repository first.", repo.Name, installedPackagesText(len(installed)))
+		impact.add(installed, "Installed from %v:", repo.Name)
+		return &impact
+	}
+	impact.add(nil, "The packages of %v will no longer be available for installation.", repo.Name)
+	if repoCount <= 1 {
+		impact.add(nil, "%v is the only repository. No packages can be installed until another repository is added.",
+			repo.Name)
+	} else if repo.IsDefaultRepository() {
+		impact.add(nil, "%v is the default repository. Packages can not be installed without selecting a repository "+
+			"until another repository is made the default.", repo.Name)
+	}
+	return &impact
+}
+
+// repositoryUpdateImpact computes the impact of changing the URL of repo to newUrl or making it the default instead of
+// oldDefault, which is nil if the default does not change
+func repositoryUpdateImpact(
+	repo v1alpha1.PackageRepository,
+	newUrl string,
+	oldDefault *v1alpha1.PackageRepository,
+	installed []string,
+	installedWithoutRepo []string,
+) *settingsImpact {
+	impact := settingsImpact{Title: fmt.Sprintf("Update repository %v", repo.Name)}
+	if newUrl != "" && newUrl != repo.Spec.Url && len(installed) > 0 {
+		impact.add(installed, "%v this repository. Their manifests and updates will be fetched from %v.",
+			installedPackagesText(len(installed))+" from", newUrl)
+	}
+	if oldDefault != nil && oldDefault.Name != repo.Name {
+		impact.add(nil, "The default repository changes from %v to %v.", oldDefault.Name, repo.Name)
+		if len(installedWithoutRepo) > 0 {
+			impact.add(installedWithoutRepo, "%v without an explicit repository. Their manifests and updates will be "+
+				"fetched from %v.", installedPackagesText(len(installedWithoutRepo)), repo.Name)
+		}
+	}
+	return &impact
+}
+
+// autoUpdateSuspensionImpact computes the impact of suspending automatic updates globally
+func autoUpdateSuspensionImpact(pkgs []ctrlpkg.Package) *settingsImpact {
+	impact := settingsImpact{Title: "Suspend automatic updates"}
+	var names []string
+	for _, pkg := range pkgs {
+		if pkg.AutoUpdatesEnabled() {
+			names = append(names, cache.MetaObjectToName(pkg).String())
+		}
+	}
+	if len(names) > 0 {
+		slices.Sort(names)
+		impact.add(names, "%v will no longer be updated automatically until updates are resumed.",
+			packagesText(len(names), "package has", "packages have")+" automatic updates enabled and")
+	}
+	return &impact
+}
+
+// getEnvironmentChangeImpact computes the impact of changing the environment of the cluster to newEnvironment
+func (s *server) getEnvironmentChangeImpact(ctx context.Context, newEnvironment string) (*settingsImpact, error) {
+	oldEnvironment, err := s.valueResolver.Environment(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pkgs, err := s.listAllInstalledPackages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return environmentChangeImpact(pkgs, oldEnvironment, newEnvironment), nil
+}
+
+// environmentChangeImpact computes the impact of changing the environment of the cluster. Packages that have an
+// overlay for the old or the new environment are rendered with different values afterwards.
+func environmentChangeImpact(pkgs []ctrlpkg.Package, oldEnvironment, newEnvironment string) *settingsImpact {
+	impact := settingsImpact{Title: "Change environment"}
+	if oldEnvironment == newEnvironment {
+		return &impact
+	}
+	var names []string
+	for _, pkg := range pkgs {
+		overlays := pkg.GetSpec().Overlays
+		if _, ok := overlays[oldEnvironment]; ok && oldEnvironment != "" {
+			names = append(names, cache.MetaObjectToName(pkg).String())
+		} else if _, ok := overlays[newEnvironment]; ok && newEnvironment != "" {
+			names = append(names, cache.MetaObjectToName(pkg).String())
+		}
+	}
+	if len(names) == 0 {
+		return &impact
+	}
+	slices.Sort(names)
+	var environments []string
+	for _, environment := range []string{oldEnvironment, newEnvironment} {
+		if environment != "" {
+			environments = append(environments, environment)
+		}
+	}
+	effect := "Their base values will be used without an overlay."
+	if newEnvironment != "" {
+		effect = fmt.Sprintf("Their overlay for %v will be used, if they have one.", newEnvironment)
+	}
+	impact.add(names, "%v an overlay for %v. %v",
+		packagesText(len(names), "installed package has", "installed packages have"),
+		strings.Join(environments, " or "), effect)
+	return &impact
+}
+
+// getImmutableChangeImpact computes the impact of applying the changed configuration of pkg. Resources whose immutable
+// fields would change can only be updated by recreating them, which the operator only does once it is requested.
+func (s *server) getImmutableChangeImpact(
+	ctx context.Context,
+	pkg ctrlpkg.Package,
+	mf *v1alpha1.PackageManifest,
+) (*settingsImpact, error) {
+	if s.renderer == nil {
+		return nil, nil
+	}
+	info := pkg.GetSpec().PackageInfo
+	manifestURL, err := s.repoClientset.ForRepoWithName(info.RepositoryName).GetPackageManifestURL(info.Name, info.Version)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := s.renderer.FindImmutableChanges(ctx, pkg, mf, manifestURL)
+	if err != nil {
+		return nil, err
+	}
+	return immutableChangeImpact(pkg, changes), nil
+}
+
+// immutableChangeImpact computes the impact of recreating the resources of pkg whose immutable fields change
+func immutableChangeImpact(pkg ctrlpkg.Package, changes []v1alpha1.FailedResourceRef) *settingsImpact {
+	impact := settingsImpact{Title: fmt.Sprintf("Recreate resources of %v", pkg.GetName())}
+	var recreated, kept []string
+	var statefulSets bool
+	for _, change := range changes {
+		gvk := schema.GroupVersionKind(change.GroupVersionKind)
+		name := fmt.Sprintf("%v %v", change.Kind, cache.NewObjectName(change.Namespace, change.Name))
+		if !recreate.CanRecreate(gvk) {
+			kept = append(kept, name)
+			continue
+		}
+		recreated = append(recreated, name)
+		statefulSets = statefulSets || gvk.GroupKind() == appsv1.SchemeGroupVersion.WithKind("StatefulSet").GroupKind()
+	}
+	if len(recreated) > 0 {
+		slices.Sort(recreated)
+		impact.add(recreated, "The changed values affect immutable fields, so these resources will be deleted and "+
+			"created again. They are unavailable in the meantime.")
+	}
+	if statefulSets {
+		impact.add(nil, "The pods and volume claims of StatefulSets are kept and adopted by the new StatefulSet. "+
+			"Existing volume claims are not changed.")
+	}
+	if len(kept) > 0 {
+		slices.Sort(kept)
+		impact.add(kept, "These resources are never recreated automatically, because their data would be lost. "+
+			"They will fail to apply until they are migrated manually.")
+	}
+	return &impact
+}
+
+// getQuotaImpact computes the impact of installing pkg on the ResourceQuotas of the namespaces its resources are
+// created in
+func (s *server) getQuotaImpact(
+	ctx context.Context,
+	pkg ctrlpkg.Package,
+	mf *v1alpha1.PackageManifest,
+) (*settingsImpact, error) {
+	if s.renderer == nil {
+		return nil, nil
+	}
+	info := pkg.GetSpec().PackageInfo
+	manifestURL, err := s.repoClientset.ForRepoWithName(info.RepositoryName).GetPackageManifestURL(info.Name, info.Version)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := s.renderer.Render(ctx, pkg, mf, manifestURL)
+	if err != nil {
+		return nil, err
+	}
+	usages, err := s.renderer.ProjectQuotaUsage(ctx, objects)
+	if err != nil {
+		return nil, err
+	}
+	return quotaImpact(pkg, usages, s.StrictQuota), nil
+}
+
+// quotaImpact computes the impact of installing pkg, whose resources would change the usage of ResourceQuotas as
+// described by usages. Exceeding a quota is only a warning, unless strict is true.
+func quotaImpact(pkg ctrlpkg.Package, usages []render.QuotaUsage, strict bool) *settingsImpact {
+	impact := settingsImpact{Title: fmt.Sprintf("Install %v", pkg.GetName())}
+	var exceeded []string
+	for _, usage := range render.Exceeded(usages) {
+		projected := usage.Projected()
+		exceeded = append(exceeded, fmt.Sprintf("%v of %v: %v of %v (%v%%)", usage.Resource,
+			cache.NewObjectName(usage.Namespace, usage.Quota), projected.String(), usage.Hard.String(),
+			usage.Utilization()))
+	}
+	if len(exceeded) == 0 {
+		return &impact
+	}
+	impact.add(exceeded, "The resource requests of %v exceed the available quota. Pods and volume claims that do not "+
+		"fit into it will not be created until the quota is raised.", pkg.GetName())
+	if strict {
+		impact.Blocked = fmt.Sprintf("%v can not be installed, because it would exceed a resource quota. Raise the "+
+			"quota or configure the package to request fewer resources.", pkg.GetName())
+	}
+	return &impact
+}
+
+func installedPackagesText(n int) string {
+	return packagesText(n, "installed package is", "installed packages are")
+}
+
+func packagesText(n int, singular, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %v", singular)
+	}
+	return fmt.Sprintf("%v %v", n, plural)
+}
@@ -0,0 +1,129 @@
+package web
+
+import (
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/manifest/render"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("settings impact", func() {
+	repo := func(name string, isDefault bool) v1alpha1.PackageRepository {
+		r := v1alpha1.PackageRepository{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       v1alpha1.PackageRepositorySpec{Url: "https://" + name + ".example.com"},
+		}
+		if isDefault {
+			r.SetDefaultRepository()
+		}
+		return r
+	}
+
+	Describe("repository deletion", func() {
+		It("should be blocked while packages are installed from the repository", func() {
+			impact := repositoryDeletionImpact(repo("a", false), 2, []string{"cert-manager", "default/keycloak"})
+			Expect(impact.Blocked).To(ContainSubstring("2 installed packages are from this repository"))
+			Expect(impact.Items[0].Packages).To(Equal([]string{"cert-manager", "default/keycloak"}))
+		})
+
+		It("should warn about deleting the default repository", func() {
+			impact := repositoryDeletionImpact(repo("a", true), 2, nil)
+			Expect(impact.Blocked).To(BeEmpty())
+			Expect(impact.Items).To(HaveLen(2))
+			Expect(impact.Items[1].Message).To(ContainSubstring("default repository"))
+		})
+	})
+
+	Describe("repository update", func() {
+		It("should have no impact if nothing relevant changes", func() {
+			Expect(repositoryUpdateImpact(repo("a", false), "https://a.example.com", nil, []string{"x"}, nil).isEmpty()).
+				To(BeTrue())
+			Expect(repositoryUpdateImpact(repo("a", false), "https://b.example.com", nil, nil, nil).isEmpty()).
+				To(BeTrue())
+		})
+
+		It("should summarize URL and default changes", func() {
+			oldDefault := repo("glasskube", true)
+			impact := repositoryUpdateImpact(repo("a", false), "https://b.example.com", &oldDefault,
+				[]string{"x"}, []string{"y", "z"})
+			Expect(impact.Items).To(HaveLen(3))
+			Expect(impact.Items[0].Message).To(HavePrefix("1 installed package is from this repository"))
+			Expect(impact.Items[1].Message).To(Equal("The default repository changes from glasskube to a."))
+			Expect(impact.Items[2].Packages).To(Equal([]string{"y", "z"}))
+		})
+	})
+
+	It("should list the packages with automatic updates when suspending them", func() {
+		withAutoUpdates := &v1alpha1.ClusterPackage{ObjectMeta: metav1.ObjectMeta{Name: "b"}}
+		withAutoUpdates.SetAutoUpdatesEnabled(true)
+		impact := autoUpdateSuspensionImpact([]ctrlpkg.Package{
+			&v1alpha1.ClusterPackage{ObjectMeta: metav1.ObjectMeta{Name: "a"}},
+			withAutoUpdates,
+		})
+		Expect(impact.Items).To(HaveLen(1))
+		Expect(impact.Items[0].Packages).To(Equal([]string{"b"}))
+		Expect(autoUpdateSuspensionImpact(nil).isEmpty()).To(BeTrue())
+	})
+
+	It("should list the packages with overlays for the old or new environment when changing the environment", func() {
+		withOverlay := func(name, environment string) ctrlpkg.Package {
+			return &v1alpha1.ClusterPackage{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec: v1alpha1.PackageSpec{
+					Overlays: map[string]map[string]v1alpha1.ValueConfiguration{environment: {}},
+				},
+			}
+		}
+		pkgs := []ctrlpkg.Package{withOverlay("a", "prod"), withOverlay("b", "dev"), withOverlay("c", "staging")}
+		impact := environmentChangeImpact(pkgs, "dev", "prod")
+		Expect(impact.Items).To(HaveLen(1))
+		Expect(impact.Items[0].Packages).To(Equal([]string{"a", "b"}))
+		Expect(environmentChangeImpact(pkgs, "", "staging").Items[0].Packages).To(Equal([]string{"c"}))
+		Expect(environmentChangeImpact(pkgs, "prod", "prod").isEmpty()).To(BeTrue())
+		Expect(environmentChangeImpact(pkgs, "", "test").isEmpty()).To(BeTrue())
+	})
+
+	It("should list the resources with immutable changes that are recreated", func() {
+		change := func(group, kind, name string) v1alpha1.FailedResourceRef {
+			return v1alpha1.FailedResourceRef{
+				GroupVersionKind: metav1.GroupVersionKind{Group: group, Version: "v1", Kind: kind},
+				Name:             name,
+				Namespace:        "db",
+				Immutable:        true,
+			}
+		}
+		pkg := &v1alpha1.Package{ObjectMeta: metav1.ObjectMeta{Name: "postgres", Namespace: "db"}}
+		impact := immutableChangeImpact(pkg, []v1alpha1.FailedResourceRef{
+			change("apps", "StatefulSet", "postgres"),
+			change("batch", "Job", "migrate"),
+			change("", "PersistentVolumeClaim", "data"),
+		})
+		Expect(impact.Title).To(Equal("Recreate resources of postgres"))
+		Expect(impact.Items).To(HaveLen(3))
+		Expect(impact.Items[0].Packages).To(Equal([]string{"Job db/migrate", "StatefulSet db/postgres"}))
+		Expect(impact.Items[2].Packages).To(Equal([]string{"PersistentVolumeClaim db/data"}))
+		Expect(immutableChangeImpact(pkg, []v1alpha1.FailedResourceRef{change("batch", "Job", "migrate")}).Items).
+			To(HaveLen(1))
+		Expect(immutableChangeImpact(pkg, nil).isEmpty()).To(BeTrue())
+	})
+
+	It("should warn about exceeded quotas and block them if strict", func() {
+		pkg := &v1alpha1.Package{ObjectMeta: metav1.ObjectMeta{Name: "postgres", Namespace: "db"}}
+		usages := []render.QuotaUsage{
+			{Namespace: "db", Quota: "compute", Resource: corev1.ResourceRequestsCPU, Hard: resource.MustParse("2"),
+				Used: resource.MustParse("1"), Requested: resource.MustParse("1500m")},
+			{Namespace: "db", Quota: "compute", Resource: corev1.ResourcePods, Hard: resource.MustParse("10"),
+				Used: resource.MustParse("1"), Requested: resource.MustParse("1")},
+		}
+		impact := quotaImpact(pkg, usages, false)
+		Expect(impact.Items).To(HaveLen(1))
+		Expect(impact.Items[0].Packages).To(Equal([]string{"requests.cpu of db/compute: 2500m of 2 (125%)"}))
+		Expect(impact.Blocked).To(BeEmpty())
+		Expect(quotaImpact(pkg, usages, true).Blocked).NotTo(BeEmpty())
+		Expect(quotaImpact(pkg, usages[1:], true).isEmpty()).To(BeTrue())
+	})
+})
@@ -6,55 +6,126 @@ import (
 
 	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
 	"github.com/glasskube/glasskube/internal/web/sse/refresh"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
+const toastEvent = "toast"
+
+const (
+	// ConnectedClientsMetric is the name of the gauge returned by ConnectedClients
+	ConnectedClientsMetric = "sse_connected_clients"
+	// WebsocketConnectedClientsMetric is the name of the gauge returned by WebsocketConnectedClients
+	WebsocketConnectedClientsMetric = "websocket_connected_clients"
+)
+
+// ToastEventId returns the id of the event that carries rendered toasts to all clients
+func ToastEventId() string {
+	return toastEvent
+}
+
+// Broadcaster sends events to the connected web clients. Events that concern all clients, like toasts and overview
+// refreshes, are sent via server sent events. Refreshes of package detail pages are only sent via websocket to the
+// clients that subscribed to them.
 type Broadcaster struct {
-	sseHub *sseHub
+	sseHub        *sseHub
+	wsHub         *wsHub
+	subscriptions *pkgSubscriptions
 }
 
 func NewBroadcaster() *Broadcaster {
 	return &Broadcaster{
-		sseHub: newHub(),
+		sseHub:        newHub(),
+		wsHub:         newWsHub(),
+		subscriptions: newPkgSubscriptions(),
 	}
 }
 
 func (b *Broadcaster) Run(stopCh chan struct{}) {
+	go func() {
+		<-stopCh
+		b.wsHub.stop()
+		b.subscriptions.stop()
+	}()
 	b.sseHub.run(stopCh)
 }
 
+// ConnectedClients returns a gauge of the number of currently connected clients
+func (b *Broadcaster) ConnectedClients() prometheus.Gauge {
+	return b.sseHub.connectedClients
+}
+
+// WebsocketConnectedClients returns a gauge of the number of currently connected websocket clients
+func (b *Broadcaster) WebsocketConnectedClients() prometheus.Gauge {
+	return b.wsHub.connectedClients
+}
+
 func (b *Broadcaster) Handler(w http.ResponseWriter, r *http.Request) {
-	b.sseHub.handler(w)
+	b.sseHub.handler(w, r)
+}
+
+// WebsocketHandler upgrades the request to a websocket, over which the client subscribes to refresh ids
+func (b *Broadcaster) WebsocketHandler(w http.ResponseWriter, r *http.Request) {
+	b.wsHub.handler(w, r)
+}
+
+// Subscribe returns a channel that receives a signal whenever the given package or one of its workloads changes. The
+// channel is closed when the returned function is called or the broadcaster stops.
+func (b *Broadcaster) Subscribe(pkg ctrlpkg.Package) (<-chan struct{}, func()) {
+	return b.subscriptions.subscribe(pkg)
+}
+
+// Toast sends the given, already rendered toast to all connected clients
+func (b *Broadcaster) Toast(html string) {
+	b.sseHub.send(&sse{
+		event: toastEvent,
+		data:  html,
+	})
 }
 
 func (b *Broadcaster) UpdatesAvailable(headerOnly refresh.RefreshTriggerHeaderOnly, pkgs ...ctrlpkg.Package) {
 	pkgsOverviewDone := false
 	clpkgsOverviewDone := false
 	for _, pkg := range pkgs {
-		b.sseHub.broadcast <- &sse{
-			event: refresh.GetPackageRefreshDetailId(pkg, headerOnly),
-		}
+		b.wsHub.publish(refresh.GetPackageRefreshDetailId(pkg, headerOnly))
+		b.subscriptions.publish(pkg)
 
 		// for each package scope, the overview trigger should sent at most once
 		if pkg.IsNamespaceScoped() {
 			if pkgsOverviewDone {
 				continue
 			}
-			b.sseHub.broadcast <- &sse{
+			b.sseHub.send(&sse{
 				event: refresh.RefreshPackageOverview,
-			}
+			})
 			pkgsOverviewDone = true
 		} else {
 			if clpkgsOverviewDone {
 				continue
 			}
-			b.sseHub.broadcast <- &sse{
+			b.sseHub.send(&sse{
 				event: refresh.RefreshClusterPackageOverview,
-			}
+			})
 			clpkgsOverviewDone = true
 		}
 	}
 }
 
+// WorkloadsChanged tells the subscribed clients to refresh the workloads section of the detail page of the given
+// packages
+func (b *Broadcaster) WorkloadsChanged(pkgs ...ctrlpkg.Package) {
+	for _, pkg := range pkgs {
+		b.wsHub.publish(refresh.GetPackageRefreshWorkloadsId(pkg))
+		b.subscriptions.publish(pkg)
+	}
+}
+
+// RepositoryChanged tells all clients to refresh the page of the repository with the given name
+func (b *Broadcaster) RepositoryChanged(repoName string) {
+	b.sseHub.send(&sse{
+		event: refresh.RepositoryRefreshId(repoName),
+	})
+}
+
 func (b *Broadcaster) UpdatesAvailableForPackage(oldPkg ctrlpkg.Package, newPkg ctrlpkg.Package) {
 	if oldPkg != nil && !oldPkg.IsNil() && newPkg != nil && !newPkg.IsNil() {
 		if !reflect.DeepEqual(oldPkg.GetSpec(), newPkg.GetSpec()) {
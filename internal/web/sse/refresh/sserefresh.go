@@ -17,6 +17,7 @@ const (
 const scopeClusterPackage = "clusterpackage"
 const scopePackage = "package"
 const segmentHeader = "header"
+const segmentWorkloads = "workloads"
 const RefreshPackageOverview = "refresh-package-overview"
 const RefreshClusterPackageOverview = "refresh-clusterpackage-overview"
 
@@ -28,19 +29,17 @@ func GetPackageRefreshDetailId(pkg ctrlpkg.Package, headerOnly RefreshTriggerHea
 	if headerOnly {
 		segment = segmentHeader
 	}
-
-	var id string
-	var scope string
-	if !pkg.IsNamespaceScoped() {
-		id = pkg.GetName()
-		scope = scopeClusterPackage
-	} else if !pkg.IsNil() {
-		id = getNamespacedNameId(pkg)
-		scope = scopePackage
-	}
+	scope, id := getScopeAndIdOfInstalled(pkg)
 	return getRefreshId(scope, segment, id)
 }
 
+// GetPackageRefreshWorkloadsId returns the refresh id for the workloads section of the package detail page. Like
+// GetPackageRefreshDetailId, the pkg has to be installed.
+func GetPackageRefreshWorkloadsId(pkg ctrlpkg.Package) string {
+	scope, id := getScopeAndIdOfInstalled(pkg)
+	return getRefreshId(scope, segmentWorkloads, id)
+}
+
 // PackageRefreshDetailId the refresh id for the package detail page (or only its header). It is meant to be called
 // in situations where the manifest is at hand (e.g. during template rendering).
 func PackageRefreshDetailId(manifest *v1alpha1.PackageManifest, pkg ctrlpkg.Package) string {
@@ -54,6 +53,17 @@ func PackageRefreshDetailHeaderId(manifest *v1alpha1.PackageManifest, pkg ctrlpk
 	return getRefreshId(scope, segmentHeader, id)
 }
 
+// PackageRefreshWorkloadsId is like PackageRefreshDetailId but only for the workloads section.
+func PackageRefreshWorkloadsId(manifest *v1alpha1.PackageManifest, pkg ctrlpkg.Package) string {
+	scope, id := getScopeAndId(manifest, pkg)
+	return getRefreshId(scope, segmentWorkloads, id)
+}
+
+// RepositoryRefreshId returns the id of the event that is sent when the repository with the given name has changed
+func RepositoryRefreshId(repoName string) string {
+	return fmt.Sprintf("refresh-repository-%s", repoName)
+}
+
 func PackageOverviewRefreshId() string {
 	return RefreshPackageOverview
 }
@@ -72,6 +82,17 @@ func getScopeAndId(manifest *v1alpha1.PackageManifest, pkg ctrlpkg.Package) (str
 	}
 }
 
+func getScopeAndIdOfInstalled(pkg ctrlpkg.Package) (scope string, id string) {
+	if !pkg.IsNamespaceScoped() {
+		id = pkg.GetName()
+		scope = scopeClusterPackage
+	} else if !pkg.IsNil() {
+		id = getNamespacedNameId(pkg)
+		scope = scopePackage
+	}
+	return
+}
+
 func getNamespacedNameId(pkg ctrlpkg.Package) string {
 	return fmt.Sprintf("%s-%s", pkg.GetNamespace(), pkg.GetName())
 }
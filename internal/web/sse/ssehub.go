@@ -6,6 +6,9 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // sseHub maintains the set of active clients and broadcasts messages to the clients.
@@ -22,16 +25,33 @@ type sseHub struct {
 	// Registered clients.
 	clients sync.Map // map[*sseClient]struct{}
 
-	stopped bool
+	// connectedClients is the number of currently registered clients
+	connectedClients prometheus.Gauge
+
+	// done is closed when the hub has stopped. Afterwards, messages are dropped and new clients are rejected.
+	done chan struct{}
 }
 
+// reconnectEvent is the last event that clients receive before the server shuts down
+const reconnectEvent = "reconnect"
+
+// reconnectDelay is the time clients wait before they reconnect after the server has shut down, so that a restarted
+// server has some time to come up again
+const reconnectDelay = 3 * time.Second
+
 type sse struct {
 	event string
 	data  string
+	// retry sets the reconnection time of the client, if not zero
+	retry time.Duration
 }
 
 func (evt *sse) ClientBytes() []byte {
-	return []byte(fmt.Sprintf("event: %s\ndata: %s\n\n", evt.event, strings.ReplaceAll(evt.data, "\n", "")))
+	var retry string
+	if evt.retry > 0 {
+		retry = fmt.Sprintf("retry: %d\n", evt.retry.Milliseconds())
+	}
+	return []byte(fmt.Sprintf("%sevent: %s\ndata: %s\n\n", retry, evt.event, strings.ReplaceAll(evt.data, "\n", "")))
 }
 
 type sseClient struct {
@@ -45,28 +65,45 @@ func newHub() *sseHub {
 		register:   make(chan *sseClient),
 		unregister: make(chan *sseClient),
 		clients:    sync.Map{},
+		done:       make(chan struct{}),
+		connectedClients: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "glasskube",
+			Subsystem: "web",
+			Name:      ConnectedClientsMetric,
+			Help:      "Number of clients currently connected to the server sent events endpoint",
+		}),
 	}
 }
 
-// run handles communication operations with sseHub
+// run handles communication operations with sseHub until stopCh is closed. Before it returns, all clients are told
+// to reconnect later and their streams are ended.
 func (h *sseHub) run(stopCh chan struct{}) {
+	defer close(h.done)
 	for {
 		select {
 		case <-stopCh:
-			h.stopped = true
 			h.clients.Range(func(key, value any) bool {
 				if client, ok := key.(*sseClient); ok {
-					client.send <- &sse{event: "close"}
+					// the client might not have taken the previous message yet, but ending its stream is enough for
+					// the browser to reconnect, so this must not block.
+					select {
+					case client.send <- &sse{event: reconnectEvent, retry: reconnectDelay}:
+					default:
+					}
 					close(client.send)
 				}
+				h.clients.Delete(key)
 				return true
 			})
+			h.connectedClients.Set(0)
 			return
 		case client := <-h.register:
 			h.clients.Store(client, struct{}{})
+			h.connectedClients.Inc()
 		case client := <-h.unregister:
 			close(client.send)
 			h.clients.Delete(client)
+			h.connectedClients.Dec()
 		case message := <-h.broadcast:
 			h.clients.Range(func(key, value any) bool {
 				if client, ok := key.(*sseClient); ok {
@@ -78,30 +115,66 @@ func (h *sseHub) run(stopCh chan struct{}) {
 	}
 }
 
-func (h *sseHub) handler(w http.ResponseWriter) {
+// send broadcasts the message to all clients. If the hub has stopped, the message is dropped.
+func (h *sseHub) send(message *sse) {
+	select {
+	case h.broadcast <- message:
+	case <-h.done:
+	}
+}
+
+func (h *sseHub) handler(w http.ResponseWriter, r *http.Request) {
 	flusher, ok := w.(http.Flusher)
 	if !ok {
 		fmt.Fprintf(os.Stderr, "server sent events not supported\n")
 		return
 	}
 
-	w.Header().Set("Content-Type", "text/event-stream")
 	client := &sseClient{
 		send: make(chan *sse, 1),
 	}
-	h.register <- client
+	select {
+	case h.register <- client:
+	case <-h.done:
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	defer h.unregisterClient(client)
 
+	w.Header().Set("Content-Type", "text/event-stream")
 	// for some reason we need to send some initial data – otherwise following updates are not acknowledged by the browser
 	_, _ = w.Write((&sse{}).ClientBytes())
 	flusher.Flush()
 
-	for evt := range client.send {
-		if _, err := w.Write(evt.ClientBytes()); err != nil {
-			break
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case evt, ok := <-client.send:
+			if !ok {
+				return
+			}
+			if _, err := w.Write(evt.ClientBytes()); err != nil {
+				return
+			}
+			flusher.Flush()
 		}
-		flusher.Flush()
 	}
-	if !h.stopped {
-		h.unregister <- client
+}
+
+// unregisterClient removes the client from the hub. Messages that are sent to the client in the meantime are
+// discarded, so that a concurrent broadcast does not block the hub.
+func (h *sseHub) unregisterClient(client *sseClient) {
+	for {
+		select {
+		case h.unregister <- client:
+			return
+		case <-h.done:
+			return
+		case _, ok := <-client.send:
+			if !ok {
+				return
+			}
+		}
 	}
 }
@@ -0,0 +1,75 @@
+package sse
+
+import (
+	"sync"
+
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/web/sse/refresh"
+)
+
+// pkgSubscriptions notifies subscribers whenever a single package, or one of its workloads, changes. Subscribers
+// only receive a signal, the current state has to be looked up by themselves. Like the pending ids of a wsClient,
+// signals are coalesced, so a slow subscriber never blocks the publisher.
+type pkgSubscriptions struct {
+	mutex       sync.Mutex
+	subscribers map[string]map[chan struct{}]struct{}
+	// stopped is true after the subscriptions have been stopped. Afterwards, new subscriptions are closed immediately.
+	stopped bool
+}
+
+func newPkgSubscriptions() *pkgSubscriptions {
+	return &pkgSubscriptions{subscribers: make(map[string]map[chan struct{}]struct{})}
+}
+
+func (s *pkgSubscriptions) subscribe(pkg ctrlpkg.Package) (<-chan struct{}, func()) {
+	id := refresh.GetPackageRefreshDetailId(pkg, refresh.RefreshTriggerAll)
+	ch := make(chan struct{}, 1)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.stopped {
+		close(ch)
+		return ch, func() {}
+	}
+	if s.subscribers[id] == nil {
+		s.subscribers[id] = make(map[chan struct{}]struct{})
+	}
+	s.subscribers[id][ch] = struct{}{}
+	return ch, func() { s.unsubscribe(id, ch) }
+}
+
+func (s *pkgSubscriptions) unsubscribe(id string, ch chan struct{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.subscribers[id][ch]; ok {
+		delete(s.subscribers[id], ch)
+		if len(s.subscribers[id]) == 0 {
+			delete(s.subscribers, id)
+		}
+		close(ch)
+	}
+}
+
+func (s *pkgSubscriptions) publish(pkg ctrlpkg.Package) {
+	id := refresh.GetPackageRefreshDetailId(pkg, refresh.RefreshTriggerAll)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for ch := range s.subscribers[id] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// stop closes all subscriptions
+func (s *pkgSubscriptions) stop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.stopped = true
+	for id, subscribers := range s.subscribers {
+		for ch := range subscribers {
+			close(ch)
+		}
+		delete(s.subscribers, id)
+	}
+}
@@ -0,0 +1,265 @@
+package sse
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// wsWriteTimeout is the time after which a client that does not accept messages is disconnected
+	wsWriteTimeout = 10 * time.Second
+	// wsPongTimeout is the time after which a client that does not answer pings is disconnected
+	wsPongTimeout  = 60 * time.Second
+	wsPingInterval = wsPongTimeout * 9 / 10
+	// wsMaxMessageSize limits the size of messages sent by clients, which only contain refresh ids
+	wsMaxMessageSize = 8 * 1024
+	// wsMaxSubscriptions limits the number of refresh ids a single client may subscribe to
+	wsMaxSubscriptions = 64
+)
+
+const (
+	wsMessageSubscribe   = "subscribe"
+	wsMessageUnsubscribe = "unsubscribe"
+	wsMessageRefresh     = "refresh"
+)
+
+// wsClientMessage is sent by clients to change their subscriptions
+type wsClientMessage struct {
+	Type string   `json:"type"`
+	Ids  []string `json:"ids"`
+}
+
+// wsServerMessage is sent to clients. Refresh messages contain the id that should be refreshed, reconnect messages
+// contain the delay in milliseconds after which the client should reconnect.
+type wsServerMessage struct {
+	Type  string `json:"type"`
+	Id    string `json:"id,omitempty"`
+	Retry int64  `json:"retry,omitempty"`
+}
+
+// wsHub maintains the set of websocket clients and sends refresh ids only to the clients that subscribed to them.
+// Unlike the sseHub, which sends every event to every client, this avoids refreshing all open detail pages whenever
+// any package changes.
+type wsHub struct {
+	upgrader websocket.Upgrader
+
+	mutex   sync.Mutex
+	clients map[*wsClient]struct{}
+	// stopped is true after the hub has stopped. Afterwards, new clients are rejected.
+	stopped bool
+
+	// connectedClients is the number of currently registered clients
+	connectedClients prometheus.Gauge
+}
+
+type wsClient struct {
+	conn *websocket.Conn
+
+	mutex         sync.Mutex
+	subscriptions map[string]struct{}
+	// pending are the subscribed ids that have been published but not sent yet. An id is sent at most once, no matter
+	// how often it was published in the meantime, so a slow client never causes more than one buffered message per
+	// subscription.
+	pending []string
+	// notify signals the writer that pending is not empty
+	notify chan struct{}
+	// stop is closed when the hub stops, to tell the client to reconnect later
+	stop chan struct{}
+	// done is closed when the connection is closed
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newWsHub() *wsHub {
+	return &wsHub{
+		clients: make(map[*wsClient]struct{}),
+		connectedClients: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "glasskube",
+			Subsystem: "web",
+			Name:      WebsocketConnectedClientsMetric,
+			Help:      "Number of clients currently connected to the websocket endpoint",
+		}),
+	}
+}
+
+func newWsClient(conn *websocket.Conn) *wsClient {
+	return &wsClient{
+		conn:          conn,
+		subscriptions: make(map[string]struct{}),
+		notify:        make(chan struct{}, 1),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+}
+
+// stop tells all clients to reconnect later and rejects new clients
+func (h *wsHub) stop() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.stopped = true
+	for client := range h.clients {
+		close(client.stop)
+		delete(h.clients, client)
+	}
+	h.connectedClients.Set(0)
+}
+
+func (h *wsHub) register(client *wsClient) bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.stopped {
+		return false
+	}
+	h.clients[client] = struct{}{}
+	h.connectedClients.Inc()
+	return true
+}
+
+func (h *wsHub) unregister(client *wsClient) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if _, ok := h.clients[client]; ok {
+		delete(h.clients, client)
+		h.connectedClients.Dec()
+	}
+}
+
+// publish sends the refresh id to all clients that subscribed to it. It never blocks on slow clients.
+func (h *wsHub) publish(id string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	for client := range h.clients {
+		client.enqueue(id)
+	}
+}
+
+func (h *wsHub) handler(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader has already replied with an error
+		return
+	}
+	client := newWsClient(conn)
+	if !h.register(client) {
+		_ = conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseTryAgainLater, ""), time.Now().Add(wsWriteTimeout))
+		_ = conn.Close()
+		return
+	}
+	defer h.unregister(client)
+	go client.writeLoop()
+	client.readLoop()
+}
+
+func (c *wsClient) close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		_ = c.conn.Close()
+	})
+}
+
+func (c *wsClient) enqueue(id string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.subscriptions[id]; !ok {
+		return
+	}
+	for _, pending := range c.pending {
+		if pending == id {
+			return
+		}
+	}
+	c.pending = append(c.pending, id)
+	select {
+	case c.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (c *wsClient) takePending() []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	pending := c.pending
+	c.pending = nil
+	return pending
+}
+
+func (c *wsClient) subscribe(ids []string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, id := range ids {
+		if len(c.subscriptions) >= wsMaxSubscriptions {
+			return
+		}
+		c.subscriptions[id] = struct{}{}
+	}
+}
+
+func (c *wsClient) unsubscribe(ids []string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, id := range ids {
+		delete(c.subscriptions, id)
+	}
+}
+
+// readLoop handles subscription messages until the connection is closed or the client stops answering pings
+func (c *wsClient) readLoop() {
+	defer c.close()
+	c.conn.SetReadLimit(wsMaxMessageSize)
+	_ = c.conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	})
+	for {
+		var msg wsClientMessage
+		if err := c.conn.ReadJSON(&msg); err != nil {
+			return
+		}
+		switch msg.Type {
+		case wsMessageSubscribe:
+			c.subscribe(msg.Ids)
+		case wsMessageUnsubscribe:
+			c.unsubscribe(msg.Ids)
+		}
+	}
+}
+
+// writeLoop is the only goroutine that writes to the connection after it has been registered
+func (c *wsClient) writeLoop() {
+	ticker := time.NewTicker(wsPingInterval)
+	defer ticker.Stop()
+	defer c.close()
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-c.stop:
+			_ = c.write(wsServerMessage{Type: reconnectEvent, Retry: reconnectDelay.Milliseconds()})
+			_ = c.conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseGoingAway, ""), time.Now().Add(wsWriteTimeout))
+			return
+		case <-ticker.C:
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout)); err != nil {
+				return
+			}
+		case <-c.notify:
+			for _, id := range c.takePending() {
+				if err := c.write(wsServerMessage{Type: wsMessageRefresh, Id: id}); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (c *wsClient) write(msg wsServerMessage) error {
+	if err := c.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout)); err != nil {
+		return err
+	}
+	return c.conn.WriteJSON(msg)
+}
@@ -0,0 +1,79 @@
+package web
+
+import (
+	"net/url"
+	"strings"
+)
+
+const (
+	DefaultSupportURL = "https://github.com/glasskube/glasskube"
+	DefaultChatURL    = "https://discord.gg/SxH6KUCGH7"
+	// discussionURLPackagePlaceholder is replaced with the name of the package in SupportOptions.DiscussionURL
+	discussionURLPackagePlaceholder = "{package}"
+)
+
+// SupportOptions configure where users are sent to for help. Internal deployments can point these links to their own
+// channels, e.g. an internal wiki or chat.
+type SupportOptions struct {
+	// SupportURL is the place to ask questions and report bugs. If empty, the link is hidden.
+	SupportURL string
+	// ChatURL is the chat of the community (or team). If empty, the link is hidden.
+	ChatURL string
+	// DiscussionURL replaces the built-in discussion page of packages, if set. The placeholder "{package}" is replaced
+	// with the name of the package.
+	DiscussionURL string
+	// DisableDiscussions hides the discussion link and badge of packages entirely
+	DisableDiscussions bool
+}
+
+func DefaultSupportOptions() SupportOptions {
+	return SupportOptions{SupportURL: DefaultSupportURL, ChatURL: DefaultChatURL}
+}
+
+// DiscussionsEnabled is true, if the discussion link should be shown for packages
+func (opts SupportOptions) DiscussionsEnabled() bool {
+	return !opts.DisableDiscussions
+}
+
+// ExternalDiscussions is true, if discussions are not held on the built-in discussion page. Discussion counts are
+// only available for the built-in page.
+func (opts SupportOptions) ExternalDiscussions() bool {
+	return opts.DiscussionURL != ""
+}
+
+// ExternalDiscussionHref returns the link to the discussion of the given package on the configured external page
+func (opts SupportOptions) ExternalDiscussionHref(pkgName string) string {
+	return strings.ReplaceAll(opts.DiscussionURL, discussionURLPackagePlaceholder, url.PathEscape(pkgName))
+}
+
+func (opts SupportOptions) SupportLabel() string {
+	return linkLabel(opts.SupportURL)
+}
+
+func (opts SupportOptions) ChatLabel() string {
+	return linkLabel(opts.ChatURL)
+}
+
+// linkLabel returns a human-readable name for the given link. Well-known hosts are shown by their product name,
+// other links by their hostname.
+func linkLabel(link string) string {
+	parsed, err := url.Parse(link)
+	if err != nil || parsed.Hostname() == "" {
+		return link
+	}
+	host := strings.TrimPrefix(parsed.Hostname(), "www.")
+	switch host {
+	case "github.com":
+		return "GitHub"
+	case "gitlab.com":
+		return "GitLab"
+	case "discord.gg", "discord.com":
+		return "Discord"
+	case "slack.com":
+		return "Slack"
+	}
+	if strings.HasSuffix(host, ".slack.com") {
+		return "Slack"
+	}
+	return host
+}
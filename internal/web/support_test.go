@@ -0,0 +1,26 @@
+package web
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SupportOptions", func() {
+	DescribeTable("linkLabel",
+		func(link, expected string) {
+			Expect(linkLabel(link)).To(Equal(expected))
+		},
+		Entry("GitHub", DefaultSupportURL, "GitHub"),
+		Entry("Discord", DefaultChatURL, "Discord"),
+		Entry("Slack workspace", "https://acme.slack.com/archives/C0123", "Slack"),
+		Entry("Other host", "https://www.wiki.example.com/glasskube", "wiki.example.com"),
+		Entry("No URL", "mailto:support@example.com", "mailto:support@example.com"),
+	)
+
+	It("should insert the package name into the discussion link", func() {
+		opts := SupportOptions{DiscussionURL: "https://wiki.example.com/packages/{package}#comments"}
+		Expect(opts.ExternalDiscussions()).To(BeTrue())
+		Expect(opts.ExternalDiscussionHref("cert-manager")).
+			To(Equal("https://wiki.example.com/packages/cert-manager#comments"))
+	})
+})
@@ -5,7 +5,6 @@ import (
 	"net/http"
 
 	"github.com/glasskube/glasskube/api/v1alpha1"
-	"github.com/glasskube/glasskube/internal/clientutils"
 	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
 	"github.com/glasskube/glasskube/internal/web/components/toast"
 	"github.com/glasskube/glasskube/pkg/suspend"
@@ -29,11 +28,7 @@ func (s *server) handleSuspend(w http.ResponseWriter, r *http.Request) {
 		s.sendToast(w, toast.WithErr(err))
 	} else if suspended {
 		if s.isGitopsModeEnabled() {
-			if yamlOutput, err := clientutils.Format(clientutils.OutputFormatYAML, false, pkg); err != nil {
-				s.sendToast(w, toast.WithErr(fmt.Errorf("failed to render yaml: %w", err)))
-			} else {
-				s.sendYamlModal(w, yamlOutput, nil)
-			}
+			s.sendYamlModal(w, pkg, nil)
 		} else {
 			s.sendToast(w, toast.WithMessage(fmt.Sprintf("%v has been suspended", pkg.GetName())),
 				toast.WithSeverity(toast.Info))
@@ -61,11 +56,7 @@ func (s *server) handleResume(w http.ResponseWriter, r *http.Request) {
 		s.sendToast(w, toast.WithErr(err))
 	} else if resumed {
 		if s.isGitopsModeEnabled() {
-			if yamlOutput, err := clientutils.Format(clientutils.OutputFormatYAML, false, pkg); err != nil {
-				s.sendToast(w, toast.WithErr(fmt.Errorf("failed to render yaml: %w", err)))
-			} else {
-				s.sendYamlModal(w, yamlOutput, nil)
-			}
+			s.sendYamlModal(w, pkg, nil)
 		} else {
 			s.sendToast(w, toast.WithMessage(fmt.Sprintf("%v has been resumed", pkg.GetName())))
 		}
@@ -75,6 +66,125 @@ func (s *server) handleResume(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handlePause sets the pause annotation of a package, so that the operator stops reconciling it until it is unpaused
+func (s *server) handlePause(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var options suspend.Options
+	if s.isGitopsModeEnabled() {
+		options = append(options, suspend.DryRun())
+	}
+
+	if pkg, err := s.getPackageFromRequest(r); err != nil {
+		s.sendToast(w, toast.WithErr(err))
+	} else if paused, err := suspend.Pause(r.Context(), pkg, options...); err != nil {
+		s.sendToast(w, toast.WithErr(err))
+	} else if paused {
+		if s.isGitopsModeEnabled() {
+			s.sendYamlModal(w, pkg, nil)
+		} else {
+			s.sendToast(w, toast.WithMessage(
+				fmt.Sprintf("%v has been paused. Drift of its resources will not be corrected.", pkg.GetName())),
+				toast.WithSeverity(toast.Warning))
+		}
+	} else {
+		s.sendToast(w, toast.WithMessage(fmt.Sprintf("%v was already paused", pkg.GetName())),
+			toast.WithSeverity(toast.Info))
+	}
+}
+
+func (s *server) handleUnpause(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var options suspend.Options
+	if s.isGitopsModeEnabled() {
+		options = append(options, suspend.DryRun())
+	}
+
+	if pkg, err := s.getPackageFromRequest(r); err != nil {
+		s.sendToast(w, toast.WithErr(err))
+	} else if unpaused, err := suspend.Unpause(r.Context(), pkg, options...); err != nil {
+		s.sendToast(w, toast.WithErr(err))
+	} else if unpaused {
+		if s.isGitopsModeEnabled() {
+			s.sendYamlModal(w, pkg, nil)
+		} else {
+			s.sendToast(w, toast.WithMessage(fmt.Sprintf("%v has been unpaused", pkg.GetName())))
+		}
+	} else {
+		s.sendToast(w, toast.WithMessage(fmt.Sprintf("%v was not paused", pkg.GetName())),
+			toast.WithSeverity(toast.Info))
+	}
+}
+
+// handleRetry requests that the operator applies the failed resources of a package again, or runs its failed hook again
+func (s *server) handleRetry(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var options suspend.Options
+	if s.isGitopsModeEnabled() {
+		options = append(options, suspend.DryRun())
+	}
+
+	if pkg, err := s.getPackageFromRequest(r); err != nil {
+		s.sendToast(w, toast.WithErr(err))
+	} else if retried, err := suspend.RetryFailed(r.Context(), pkg, options...); err != nil {
+		s.sendToast(w, toast.WithErr(err))
+	} else if retried {
+		if s.isGitopsModeEnabled() {
+			s.sendYamlModal(w, pkg, nil)
+		} else if hook := pkg.GetStatus().FailedHook(); hook != nil {
+			s.sendToast(w, toast.WithMessage(
+				fmt.Sprintf("The hook %v of %v will be run again", hook.Name, pkg.GetName())))
+		} else {
+			s.sendToast(w, toast.WithMessage(
+				fmt.Sprintf("The failed resources of %v will be applied again", pkg.GetName())))
+		}
+	} else {
+		s.sendToast(w, toast.WithMessage(fmt.Sprintf("%v has nothing to retry", pkg.GetName())),
+			toast.WithSeverity(toast.Info))
+	}
+}
+
+// handleRecreate requests that the operator deletes and applies the failed resources of a package again, whose
+// immutable fields were changed
+func (s *server) handleRecreate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var options suspend.Options
+	if s.isGitopsModeEnabled() {
+		options = append(options, suspend.DryRun())
+	}
+
+	if pkg, err := s.getPackageFromRequest(r); err != nil {
+		s.sendToast(w, toast.WithErr(err))
+	} else if recreated, err := suspend.RecreateFailed(r.Context(), pkg, options...); err != nil {
+		s.sendToast(w, toast.WithErr(err))
+	} else if recreated {
+		if s.isGitopsModeEnabled() {
+			s.sendYamlModal(w, pkg, nil)
+		} else {
+			s.sendToast(w, toast.WithMessage(
+				fmt.Sprintf("The resources of %v with immutable changes will be recreated", pkg.GetName())))
+		}
+	} else {
+		s.sendToast(w, toast.WithMessage(fmt.Sprintf("%v has no resources with immutable changes", pkg.GetName())),
+			toast.WithSeverity(toast.Info))
+	}
+}
+
 func (s *server) getPackageFromRequest(r *http.Request) (ctrlpkg.Package, error) {
 	var pkg ctrlpkg.Package
 	if name := mux.Vars(r)["pkgName"]; name != "" {
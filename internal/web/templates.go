@@ -2,18 +2,31 @@ package web
 
 import (
 	"bytes"
+	"fmt"
 	"html/template"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
 	"path"
 	"reflect"
+	"slices"
+	"time"
 
+	"github.com/glasskube/glasskube/internal/dependency/graph"
 	depUtil "github.com/glasskube/glasskube/internal/dependency/util"
 
 	webutil "github.com/glasskube/glasskube/internal/web/sse/refresh"
 
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
 	"github.com/fsnotify/fsnotify"
 	"github.com/glasskube/glasskube/api/v1alpha1"
 	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/controller/revisions"
+	"github.com/glasskube/glasskube/internal/registrymirror"
 	repoclient "github.com/glasskube/glasskube/internal/repo/client"
+	"github.com/glasskube/glasskube/internal/sandbox"
+	"github.com/glasskube/glasskube/internal/scheduling"
 	"github.com/glasskube/glasskube/internal/semver"
 	"github.com/glasskube/glasskube/internal/web/components/datalist"
 	"github.com/glasskube/glasskube/internal/web/components/pkg_config_input"
@@ -21,8 +34,12 @@ import (
 	"github.com/glasskube/glasskube/internal/web/components/pkg_overview_btn"
 	"github.com/glasskube/glasskube/internal/web/components/pkg_update_alert"
 	"github.com/glasskube/glasskube/internal/web/components/toast"
+	"github.com/glasskube/glasskube/internal/web/i18n"
+	"github.com/glasskube/glasskube/internal/web/sse"
+	"github.com/glasskube/glasskube/pkg/client"
 	"github.com/glasskube/glasskube/pkg/condition"
 	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
 	"github.com/yuin/goldmark/ast"
 	"github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/parser"
@@ -34,25 +51,45 @@ import (
 )
 
 type templates struct {
-	templateFuncs           template.FuncMap
-	baseTemplate            *template.Template
-	clusterPkgsPageTemplate *template.Template
-	pkgsPageTmpl            *template.Template
-	pkgPageTmpl             *template.Template
-	pkgDiscussionPageTmpl   *template.Template
-	supportPageTmpl         *template.Template
-	bootstrapPageTmpl       *template.Template
-	kubeconfigPageTmpl      *template.Template
-	settingsPageTmpl        *template.Template
-	repositoryPageTmpl      *template.Template
-	pkgDetailHeaderTmpl     *template.Template
-	pkgConfigInput          *template.Template
-	pkgUninstallModalTmpl   *template.Template
-	toastTmpl               *template.Template
-	datalistTmpl            *template.Template
-	pkgDiscussionBadgeTmpl  *template.Template
-	yamlModalTmpl           *template.Template
-	repoClientset           repoclient.RepoClientset
+	// parsedTemplates are the templates with the messages of the default locale
+	parsedTemplates
+	// localized contains the templates of every supported locale, keyed by the locale
+	localized     map[string]*parsedTemplates
+	messages      *i18n.Bundle
+	templateFuncs template.FuncMap
+	repoClientset repoclient.RepoClientset
+	markdownCache *markdownCache
+}
+
+// parsedTemplates are all templates that are parsed together, so that they can be replaced at once
+type parsedTemplates struct {
+	baseTemplate             *template.Template
+	clusterPkgsPageTemplate  *template.Template
+	pkgsPageTmpl             *template.Template
+	pkgPageTmpl              *template.Template
+	pkgDiscussionPageTmpl    *template.Template
+	supportPageTmpl          *template.Template
+	bootstrapPageTmpl        *template.Template
+	kubeconfigPageTmpl       *template.Template
+	settingsPageTmpl         *template.Template
+	repositoryPageTmpl       *template.Template
+	auditPageTmpl            *template.Template
+	categoriesPageTmpl       *template.Template
+	comparePageTmpl          *template.Template
+	pkgDetailHeaderTmpl      *template.Template
+	pkgConfigInput           *template.Template
+	pkgUninstallModalTmpl    *template.Template
+	settingsConfirmModalTmpl *template.Template
+	toastTmpl                *template.Template
+	datalistTmpl             *template.Template
+	pkgDiscussionBadgeTmpl   *template.Template
+	pkgWorkloadsTmpl         *template.Template
+	pkgWhyTmpl               *template.Template
+	repoImportResultTmpl     *template.Template
+	pkgResourcesTmpl         *template.Template
+	pkgChangelogTmpl         *template.Template
+	yamlModalTmpl            *template.Template
+	yamlEditorModalTmpl      *template.Template
 }
 
 var (
@@ -60,31 +97,54 @@ var (
 	templatesDir     = "templates"
 	componentsDir    = path.Join(templatesDir, "components")
 	pagesDir         = path.Join(templatesDir, "pages")
+	localesDir       = "locales"
 )
 
-func (t *templates) watchTemplates() error {
+// watchTemplates parses the templates again whenever a template file changes, until stopCh is closed
+func (t *templates) watchTemplates(stopCh chan struct{}) error {
 	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
 	err = multierr.Combine(
 		err,
 		watcher.Add(path.Join(templatesBaseDir, componentsDir)),
 		watcher.Add(path.Join(templatesBaseDir, templatesDir, "layout")),
 		watcher.Add(path.Join(templatesBaseDir, pagesDir)),
+		watcher.Add(path.Join(templatesBaseDir, localesDir)),
 	)
-	if err == nil {
-		go func() {
-			for range watcher.Events {
-				t.parseTemplates()
-			}
-		}()
+	if err != nil {
+		_ = watcher.Close()
+		return err
 	}
-	return err
+	go func() {
+		defer func() { _ = watcher.Close() }()
+		for {
+			select {
+			case <-stopCh:
+				return
+			case _, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if err := t.parseTemplates(); err != nil {
+					fmt.Fprintf(os.Stderr, "templates were not updated: %v\n", err)
+				}
+			}
+		}
+	}()
+	return nil
 }
 
-func (t *templates) parseTemplates() {
+// parseTemplates parses all templates from webFs. If any template can not be parsed, an error is returned and the
+// previously parsed templates are kept.
+func (t *templates) parseTemplates() error {
 	t.templateFuncs = template.FuncMap{
 		"ForClPkgOverviewBtn": pkg_overview_btn.ForClPkgOverviewBtn,
+		"ForFavoriteBtn":      pkg_overview_btn.ForFavoriteBtn,
 		"ForPkgDetailBtns":    pkg_detail_btns.ForPkgDetailBtns,
 		"ForPkgUpdateAlert":   pkg_update_alert.ForPkgUpdateAlert,
+		"ForRepoBadges":       pkg_overview_btn.ForRepoBadges,
 		"PackageManifestUrl": func(pkg ctrlpkg.Package) string {
 			if !pkg.IsNil() {
 				url, err := t.repoClientset.ForPackage(pkg).
@@ -95,29 +155,18 @@ func (t *templates) parseTemplates() {
 			}
 			return ""
 		},
-		"ForToast":          toast.ForToast,
-		"ForPkgConfigInput": pkg_config_input.ForPkgConfigInput,
-		"ForDatalist":       datalist.ForDatalist,
-		"IsUpgradable":      semver.IsUpgradable,
-		"Markdown": func(source string) template.HTML {
-			var buf bytes.Buffer
-
-			converter := goldmark.New(
-				goldmark.WithExtensions(
-					extension.Linkify,
-				),
-				goldmark.WithParserOptions(
-					parser.WithASTTransformers(
-						util.Prioritized(&ASTTransformer{}, 1000),
-					),
-				),
-			)
-
-			if err := converter.Convert([]byte(source), &buf); err != nil {
-				return template.HTML("<p>" + source + "</p>")
-			}
-
-			return template.HTML(buf.String())
+		"PreferredTheme":      t.preferredTheme,
+		"ForToast":            toast.ForToast,
+		"ForPkgConfigInput":   pkg_config_input.ForPkgConfigInput,
+		"ForDatalist":         datalist.ForDatalist,
+		"IsUpgradable":        isUpgradable,
+		"IsDowngrade":         isDowngrade,
+		"IsOutsideConstraint": isOutsideConstraint,
+		"Markdown": func(pkg ctrlpkg.Package, source string) template.HTML {
+			return t.renderMarkdown(t.markdownBaseUrl(pkg), source)
+		},
+		"ManifestMarkdown": func(repositoryName, manifestName, version, source string) template.HTML {
+			return t.renderMarkdown(t.manifestBaseUrl(repositoryName, manifestName, version), source)
 		},
 		"Reversed": func(param any) any {
 			kind := reflect.TypeOf(param).Kind()
@@ -143,67 +192,330 @@ func (t *templates) parseTemplates() {
 			cond := meta.FindStatusCondition(repo.Status.Conditions, string(condition.Ready))
 			return cond != nil && cond.Status == metav1.ConditionTrue
 		},
+		"IsRepoStatusRetrying": func(repo v1alpha1.PackageRepository) bool {
+			cond := meta.FindStatusCondition(repo.Status.Conditions, string(condition.Ready))
+			return cond != nil && cond.Reason == string(condition.SyncRetrying)
+		},
+		"RepoStatusMessage": func(repo v1alpha1.PackageRepository) string {
+			if cond := meta.FindStatusCondition(repo.Status.Conditions, string(condition.Ready)); cond != nil {
+				return cond.Message
+			}
+			return ""
+		},
 		"PackageDetailRefreshId":          webutil.PackageRefreshDetailId,
+		"PackageDetailWorkloadsRefreshId": webutil.PackageRefreshWorkloadsId,
 		"PackageDetailHeaderRefreshId":    webutil.PackageRefreshDetailHeaderId,
 		"PackageOverviewRefreshId":        webutil.PackageOverviewRefreshId,
 		"ClusterPackageOverviewRefreshId": webutil.ClusterPackageOverviewRefreshId,
+		"RepositoryRefreshId":             webutil.RepositoryRefreshId,
+		"ToastEventId":                    sse.ToastEventId,
 		"ComponentName":                   depUtil.ComponentName,
+		"ResourceSummary":                 resourceSummary,
+		"Quantity":                        formatQuantity,
+		"ResourceQuantity":                formatResourceQuantity,
+		"DependencyTree": func(g *graph.DependencyGraph, pkg ctrlpkg.Package, manifest *v1alpha1.PackageManifest) *graph.TreeNode {
+			if g == nil || manifest == nil {
+				return nil
+			}
+			var tree graph.TreeNode
+			if pkg != nil && !pkg.IsNil() {
+				tree = g.Tree(pkg.GetName(), pkg.GetNamespace())
+			} else if manifest.Scope.IsCluster() {
+				tree = g.Tree(manifest.Name, "")
+			} else {
+				// same assumption as in the dependency validation: the package would be installed in the default namespace
+				tree = g.Tree(manifest.Name, manifest.DefaultNamespace)
+			}
+			return &tree
+		},
+		"FormatBytes": func(bytes int) string {
+			switch {
+			case bytes >= 1<<20:
+				return fmt.Sprintf("%.1f MiB", float64(bytes)/(1<<20))
+			case bytes >= 1<<10:
+				return fmt.Sprintf("%.1f KiB", float64(bytes)/(1<<10))
+			default:
+				return fmt.Sprintf("%v B", bytes)
+			}
+		},
+		"PackageRefHref": func(ref graph.PackageRef) string {
+			if ref.Namespace == "" {
+				return "/clusterpackages/" + ref.Name
+			}
+			return fmt.Sprintf("/packages/%v/%v/%v", ref.PackageName, ref.Namespace, ref.Name)
+		},
+		"InstalledAsDependency": func(pkg ctrlpkg.Package) bool {
+			return pkg != nil && !pkg.IsNil() && pkg.InstalledAsDependency()
+		},
 		"AutoUpdateEnabled": func(pkg ctrlpkg.Package) bool {
 			if pkg != nil && !pkg.IsNil() {
 				return pkg.AutoUpdatesEnabled()
 			}
 			return false
 		},
+		"VersionConstraint": func(pkg ctrlpkg.Package) string {
+			if pkg != nil && !pkg.IsNil() {
+				return pkg.VersionConstraint()
+			}
+			return ""
+		},
+		"ImageRegistryMirrors": func(pkg ctrlpkg.Package) string {
+			if pkg != nil && !pkg.IsNil() {
+				return registrymirror.Mirrors(pkg.GetSpec().ImageRegistryMirrors).String()
+			}
+			return ""
+		},
+		"SchedulingOverrides": func(pkg ctrlpkg.Package) string {
+			if pkg != nil && !pkg.IsNil() {
+				return scheduling.String(pkg.GetSpec().Scheduling)
+			}
+			return ""
+		},
+		"OptionalDependencyEnabled": func(pkg ctrlpkg.Package, name string) bool {
+			if pkg != nil && !pkg.IsNil() {
+				return slices.Contains(pkg.GetSpec().OptionalDependencies, name)
+			}
+			return false
+		},
+		"CurrentRevision": func(pkg ctrlpkg.Package) *v1alpha1.PackageRevision {
+			if pkg != nil && !pkg.IsNil() {
+				return revisions.Current(pkg.GetStatus())
+			}
+			return nil
+		},
+		"PreviousRevision": func(pkg ctrlpkg.Package) *v1alpha1.PackageRevision {
+			if pkg != nil && !pkg.IsNil() {
+				return revisions.Previous(pkg.GetStatus())
+			}
+			return nil
+		},
+		"PackageMetadata": func(pkg ctrlpkg.Package) editableMetadata {
+			if pkg != nil && !pkg.IsNil() {
+				return newEditableMetadata(pkg)
+			}
+			return editableMetadata{}
+		},
 		"IsSuspended": func(pkg ctrlpkg.Package) bool {
 			if pkg != nil && !pkg.IsNil() {
 				return pkg.GetSpec().Suspend
 			}
 			return false
 		},
+		"IsPaused": func(pkg ctrlpkg.Package) bool {
+			return pkg != nil && !pkg.IsNil() && pkg.IsPaused()
+		},
+		"UnhealthyStatus": func(pkg ctrlpkg.Package) *client.PackageStatus {
+			if pkg != nil && !pkg.IsNil() {
+				return client.GetUnhealthyStatus(pkg.GetStatus())
+			}
+			return nil
+		},
+		"SandboxExpiresAt": func(pkg ctrlpkg.Package) *time.Time {
+			if pkg != nil && !pkg.IsNil() {
+				if expiresAt, ok := sandbox.ExpiresAt(pkg); ok {
+					return &expiresAt
+				}
+			}
+			return nil
+		},
+	}
+
+	messages, err := i18n.Load(webFs, localesDir)
+	if err != nil {
+		return fmt.Errorf("failed to load messages: %w", err)
+	}
+	localized := make(map[string]*parsedTemplates, len(messages.Locales()))
+	for _, locale := range messages.Locales() {
+		if localized[locale], err = t.parseLocalizedTemplates(messages.Catalog(locale)); err != nil {
+			return fmt.Errorf("failed to parse templates for locale %v: %w", locale, err)
+		}
+	}
+	t.messages = messages
+	t.localized = localized
+	t.parsedTemplates = *localized[i18n.DefaultLocale]
+	return nil
+}
+
+// parseLocalizedTemplates parses all templates with the translation funcs T and TN bound to the given catalog
+func (t *templates) parseLocalizedTemplates(catalog *i18n.Catalog) (*parsedTemplates, error) {
+	funcs := maps.Clone(t.templateFuncs)
+	funcs["T"] = catalog.T
+	funcs["TN"] = catalog.TN
+	funcs["Locale"] = catalog.Locale
+
+	var parsed parsedTemplates
+	var errs error
+	must := func(tmpl *template.Template, err error) *template.Template {
+		multierr.AppendInto(&errs, err)
+		return tmpl
+	}
+	var err error
+	parsed.baseTemplate, err = template.New("base.html").
+		Funcs(funcs).
+		ParseFS(webFs, path.Join(templatesDir, "layout", "base.html"))
+	if err != nil {
+		return nil, err
+	}
+	parsed.clusterPkgsPageTemplate = must(t.pageTmpl(parsed.baseTemplate, "clusterpackages.html"))
+	parsed.pkgsPageTmpl = must(t.pageTmpl(parsed.baseTemplate, "packages.html"))
+	parsed.pkgPageTmpl = must(t.pageTmpl(parsed.baseTemplate, "package.html"))
+	parsed.pkgDiscussionPageTmpl = must(t.pageTmpl(parsed.baseTemplate, "discussion.html"))
+	parsed.supportPageTmpl = must(t.pageTmpl(parsed.baseTemplate, "support.html"))
+	parsed.bootstrapPageTmpl = must(t.pageTmpl(parsed.baseTemplate, "bootstrap.html"))
+	parsed.kubeconfigPageTmpl = must(t.pageTmpl(parsed.baseTemplate, "kubeconfig.html"))
+	parsed.settingsPageTmpl = must(t.pageTmpl(parsed.baseTemplate, "settings.html"))
+	parsed.repositoryPageTmpl = must(t.pageTmpl(parsed.baseTemplate, "repository.html"))
+	parsed.auditPageTmpl = must(t.pageTmpl(parsed.baseTemplate, "audit.html"))
+	parsed.categoriesPageTmpl = must(t.pageTmpl(parsed.baseTemplate, "categories.html"))
+	parsed.comparePageTmpl = must(t.pageTmpl(parsed.baseTemplate, "compare.html"))
+	parsed.pkgDetailHeaderTmpl = must(t.componentTmpl(funcs, "pkg-detail-header", "pkg-detail-btns"))
+	parsed.pkgConfigInput = must(t.componentTmpl(funcs, "pkg-config-input", "datalist"))
+	parsed.pkgUninstallModalTmpl = must(t.componentTmpl(funcs, "pkg-uninstall-modal"))
+	parsed.settingsConfirmModalTmpl = must(t.componentTmpl(funcs, "settings-confirm-modal"))
+	parsed.toastTmpl = must(t.componentTmpl(funcs, "toast"))
+	parsed.datalistTmpl = must(t.componentTmpl(funcs, "datalist"))
+	parsed.pkgDiscussionBadgeTmpl = must(t.componentTmpl(funcs, "discussion-badge"))
+	parsed.pkgWorkloadsTmpl = must(t.componentTmpl(funcs, "pkg-workloads"))
+	parsed.pkgWhyTmpl = must(t.componentTmpl(funcs, "why-installed"))
+	parsed.repoImportResultTmpl = must(t.componentTmpl(funcs, "repo-import-result"))
+	parsed.pkgResourcesTmpl = must(t.componentTmpl(funcs, "pkg-resources"))
+	parsed.pkgChangelogTmpl = must(t.componentTmpl(funcs, "pkg-changelog"))
+	parsed.yamlModalTmpl = must(t.componentTmpl(funcs, "yaml-modal"))
+	parsed.yamlEditorModalTmpl = must(t.componentTmpl(funcs, "yaml-editor-modal"))
+	if errs != nil {
+		return nil, errs
 	}
+	return &parsed, nil
+}
+
+// forLocale returns the templates of the given locale or of the default locale, if the locale is not supported
+func (t *templates) forLocale(locale string) *parsedTemplates {
+	if parsed, ok := t.localized[locale]; ok {
+		return parsed
+	}
+	return &t.parsedTemplates
+}
 
-	t.baseTemplate = template.Must(template.New("base.html").
-		Funcs(t.templateFuncs).
-		ParseFS(webFs, path.Join(templatesDir, "layout", "base.html")))
-	t.clusterPkgsPageTemplate = t.pageTmpl("clusterpackages.html")
-	t.pkgsPageTmpl = t.pageTmpl("packages.html")
-	t.pkgPageTmpl = t.pageTmpl("package.html")
-	t.pkgDiscussionPageTmpl = t.pageTmpl("discussion.html")
-	t.supportPageTmpl = t.pageTmpl("support.html")
-	t.bootstrapPageTmpl = t.pageTmpl("bootstrap.html")
-	t.kubeconfigPageTmpl = t.pageTmpl("kubeconfig.html")
-	t.settingsPageTmpl = t.pageTmpl("settings.html")
-	t.repositoryPageTmpl = t.pageTmpl("repository.html")
-	t.pkgDetailHeaderTmpl = t.componentTmpl("pkg-detail-header", "pkg-detail-btns")
-	t.pkgConfigInput = t.componentTmpl("pkg-config-input", "datalist")
-	t.pkgUninstallModalTmpl = t.componentTmpl("pkg-uninstall-modal")
-	t.toastTmpl = t.componentTmpl("toast")
-	t.datalistTmpl = t.componentTmpl("datalist")
-	t.pkgDiscussionBadgeTmpl = t.componentTmpl("discussion-badge")
-	t.yamlModalTmpl = t.componentTmpl("yaml-modal")
-}
-
-func (t *templates) pageTmpl(fileName string) *template.Template {
-	return template.Must(
-		template.Must(t.baseTemplate.Clone()).ParseFS(
+func (t *templates) pageTmpl(base *template.Template, fileName string) (*template.Template, error) {
+	if tmpl, err := base.Clone(); err != nil {
+		return nil, err
+	} else {
+		return tmpl.ParseFS(
 			webFs,
 			path.Join(pagesDir, fileName),
-			path.Join(componentsDir, "*.html")))
+			path.Join(componentsDir, "*.html"))
+	}
 }
 
-func (t *templates) componentTmpl(id string, requiredTemplates ...string) *template.Template {
+func (t *templates) componentTmpl(
+	funcs template.FuncMap, id string, requiredTemplates ...string) (*template.Template, error) {
 	tpls := make([]string, 0)
 	for _, requiredTmpl := range requiredTemplates {
 		tpls = append(tpls, path.Join(componentsDir, requiredTmpl+".html"))
 	}
 	tpls = append(tpls, path.Join(componentsDir, id+".html"))
-	return template.Must(
-		template.New(id).Funcs(t.templateFuncs).ParseFS(
-			webFs,
-			tpls...))
+	return template.New(id).Funcs(funcs).ParseFS(
+		webFs,
+		tpls...)
+}
+
+// preferredTheme returns the theme that the user of the given request has chosen. Without a valid choice, themeAuto
+// is returned, such that the browser follows prefers-color-scheme.
+func (t *templates) preferredTheme(r *http.Request) string {
+	if r == nil {
+		return themeAuto
+	}
+	return getThemeFromCookie(r)
+}
+
+// isUpgradable checks whether desired is newer than the installed version of pkg and satisfies its version constraint
+func isUpgradable(pkg ctrlpkg.Package, desired string) bool {
+	if pkg != nil && !pkg.IsNil() {
+		return semver.IsUpgradableWithConstraint(pkg.GetSpec().PackageInfo.Version, desired, pkg.VersionConstraint())
+	}
+	return false
+}
+
+// isDowngrade checks whether desired is older than the installed version of pkg, regardless of its version constraint
+func isDowngrade(pkg ctrlpkg.Package, desired string) bool {
+	if pkg != nil && !pkg.IsNil() {
+		return semver.IsUpgradable(desired, pkg.GetSpec().PackageInfo.Version)
+	}
+	return false
+}
+
+// isOutsideConstraint checks whether desired is newer than the installed version of pkg, but does not satisfy its
+// version constraint, so that the package can not be updated to it
+func isOutsideConstraint(pkg ctrlpkg.Package, desired string) bool {
+	if pkg != nil && !pkg.IsNil() {
+		return semver.IsUpgradable(pkg.GetSpec().PackageInfo.Version, desired) && !isUpgradable(pkg, desired)
+	}
+	return false
+}
+
+// renderMarkdown converts the given markdown source to HTML. Relative references are resolved against baseUrl, if it
+// is not nil.
+func (t *templates) renderMarkdown(baseUrl *url.URL, source string) template.HTML {
+	key := newMarkdownCacheKey(source, baseUrl)
+	if t.markdownCache != nil {
+		if html, ok := t.markdownCache.get(key); ok {
+			return html
+		}
+	}
+
+	var buf bytes.Buffer
+
+	converter := goldmark.New(
+		goldmark.WithExtensions(
+			extension.Linkify,
+			highlighting.NewHighlighting(
+				highlighting.WithFormatOptions(chromahtml.WithClasses(true)),
+			),
+		),
+		goldmark.WithParserOptions(
+			parser.WithASTTransformers(
+				util.Prioritized(&ASTTransformer{baseUrl: baseUrl}, 1000),
+			),
+		),
+	)
+
+	if err := converter.Convert([]byte(source), &buf); err != nil {
+		return template.HTML("<p>" + source + "</p>")
+	}
+
+	html := template.HTML(buf.String())
+	if t.markdownCache != nil {
+		t.markdownCache.add(key, html)
+	}
+	return html
+}
+
+// markdownBaseUrl returns the URL of the manifest of the given package, which relative references in markdown
+// descriptions are resolved against. If the package or its manifest URL is not available, nil is returned.
+func (t *templates) markdownBaseUrl(pkg ctrlpkg.Package) *url.URL {
+	if pkg == nil || pkg.IsNil() {
+		return nil
+	}
+	return t.manifestBaseUrl(
+		pkg.GetSpec().PackageInfo.RepositoryName, pkg.GetSpec().PackageInfo.Name, pkg.GetSpec().PackageInfo.Version)
+}
+
+// manifestBaseUrl returns the URL of the manifest of the given package version in the given repository, so that
+// packages that are not installed can be resolved as well. If the manifest URL is not available, nil is returned.
+func (t *templates) manifestBaseUrl(repositoryName, manifestName, version string) *url.URL {
+	manifestUrl, err := t.repoClientset.ForRepoWithName(repositoryName).GetPackageManifestURL(manifestName, version)
+	if err != nil {
+		return nil
+	}
+	if baseUrl, err := url.Parse(manifestUrl); err == nil {
+		return baseUrl
+	}
+	return nil
 }
 
-type ASTTransformer struct{}
+type ASTTransformer struct {
+	baseUrl *url.URL
+}
 
 func (g *ASTTransformer) Transform(node *ast.Document, reader text.Reader, pc parser.Context) {
 	_ = ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
@@ -213,8 +525,15 @@ func (g *ASTTransformer) Transform(node *ast.Document, reader text.Reader, pc pa
 
 		switch v := n.(type) {
 		case *ast.Link:
+			v.Destination = resolveRelativeUrl(g.baseUrl, v.Destination)
 			v.SetAttributeString("target", "_blank")
 			v.SetAttributeString("rel", "noopener noreferrer")
+		case *ast.Image:
+			v.Destination = resolveRelativeUrl(g.baseUrl, v.Destination)
+		case *ast.FencedCodeBlock:
+			if language := v.Language(reader.Source()); language != nil && !isHighlightedLanguage(string(language)) {
+				v.SetAttributeString("nohl", true)
+			}
 		case *ast.Blockquote:
 			v.SetAttributeString("class", "border-start border-primary border-3 ps-2")
 		}
@@ -222,3 +541,16 @@ func (g *ASTTransformer) Transform(node *ast.Document, reader text.Reader, pc pa
 		return ast.WalkContinue, nil
 	})
 }
+
+// resolveRelativeUrl resolves destination against baseUrl, if destination is a relative reference.
+// Absolute URLs, protocol-relative URLs and anchor links are returned unchanged.
+func resolveRelativeUrl(baseUrl *url.URL, destination []byte) []byte {
+	if baseUrl == nil || len(destination) == 0 || destination[0] == '#' {
+		return destination
+	}
+	if ref, err := url.Parse(string(destination)); err != nil || ref.IsAbs() || ref.Host != "" {
+		return destination
+	} else {
+		return []byte(baseUrl.ResolveReference(ref).String())
+	}
+}
@@ -0,0 +1,156 @@
+package web
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	repoclient "github.com/glasskube/glasskube/internal/repo/client"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Markdown", func() {
+	baseUrl, _ := url.Parse("https://packages.dl.glasskube.dev/packages/cert-manager/v1.14.2+1/package.yaml")
+
+	DescribeTable("resolveRelativeUrl",
+		func(base *url.URL, destination string, result string) {
+			Expect(string(resolveRelativeUrl(base, []byte(destination)))).To(Equal(result))
+		},
+		Entry("Relative path", baseUrl, "./screenshot.png",
+			"https://packages.dl.glasskube.dev/packages/cert-manager/v1.14.2+1/screenshot.png"),
+		Entry("Relative path without dot", baseUrl, "docs/README.md",
+			"https://packages.dl.glasskube.dev/packages/cert-manager/v1.14.2+1/docs/README.md"),
+		Entry("Parent path", baseUrl, "../logo.svg",
+			"https://packages.dl.glasskube.dev/packages/cert-manager/logo.svg"),
+		Entry("Absolute path", baseUrl, "/index.yaml", "https://packages.dl.glasskube.dev/index.yaml"),
+		Entry("Absolute URL", baseUrl, "https://glasskube.dev/", "https://glasskube.dev/"),
+		Entry("Protocol-relative URL", baseUrl, "//glasskube.dev/", "//glasskube.dev/"),
+		Entry("Mail link", baseUrl, "mailto:hello@glasskube.eu", "mailto:hello@glasskube.eu"),
+		Entry("Anchor link", baseUrl, "#installation", "#installation"),
+		Entry("Empty destination", baseUrl, "", ""),
+		Entry("No base URL", nil, "./screenshot.png", "./screenshot.png"),
+	)
+})
+
+var _ = Describe("parseTemplates", func() {
+	It("should parse all embedded templates", func() {
+		var t templates
+		Expect(t.parseTemplates()).To(Succeed())
+		Expect(t.pkgsPageTmpl).NotTo(BeNil())
+		Expect(t.toastTmpl).NotTo(BeNil())
+	})
+})
+
+var _ = Describe("PreferredTheme", func() {
+	var t templates
+
+	BeforeEach(func() {
+		Expect(t.parseTemplates()).To(Succeed())
+	})
+
+	render := func(data map[string]any) string {
+		var buf bytes.Buffer
+		Expect(t.kubeconfigPageTmpl.Execute(&buf, data)).To(Succeed())
+		return buf.String()
+	}
+
+	It("should render the theme of the cookie", func() {
+		r := httptest.NewRequest(http.MethodGet, "/kubeconfig", nil)
+		r.AddCookie(&http.Cookie{Name: themeKey, Value: themeDark})
+		Expect(render(map[string]any{"Request": r})).To(ContainSubstring(`data-bs-theme="dark"`))
+	})
+
+	It("should fall back to the browser preference without a valid cookie", func() {
+		r := httptest.NewRequest(http.MethodGet, "/kubeconfig", nil)
+		r.AddCookie(&http.Cookie{Name: themeKey, Value: "sepia"})
+		Expect(render(map[string]any{"Request": r})).NotTo(ContainSubstring(`data-bs-theme="`))
+		Expect(render(map[string]any{})).NotTo(ContainSubstring(`data-bs-theme="`))
+	})
+})
+
+var _ = Describe("version changes", func() {
+	pkg := &v1alpha1.ClusterPackage{Spec: v1alpha1.PackageSpec{
+		PackageInfo: v1alpha1.PackageInfoTemplate{Name: "cert-manager", Version: "v1.14.2+1"},
+	}}
+	pkg.SetVersionConstraint("~1.14.0")
+
+	DescribeTable("of the selected version",
+		func(desired string, upgradable, downgrade, outsideConstraint bool) {
+			Expect(isUpgradable(pkg, desired)).To(Equal(upgradable))
+			Expect(isDowngrade(pkg, desired)).To(Equal(downgrade))
+			Expect(isOutsideConstraint(pkg, desired)).To(Equal(outsideConstraint))
+		},
+		Entry("update within the constraint", "v1.14.5+1", true, false, false),
+		Entry("newer version outside the constraint", "v1.15.0+1", false, false, true),
+		Entry("older version", "v1.13.0+1", false, true, false),
+		Entry("same version", "v1.14.2+1", false, false, false),
+	)
+
+	It("should not compare versions without a package", func() {
+		var missing *v1alpha1.ClusterPackage
+		Expect(isUpgradable(missing, "v1.15.0+1")).To(BeFalse())
+		Expect(isDowngrade(missing, "v1.13.0+1")).To(BeFalse())
+		Expect(isOutsideConstraint(missing, "v1.15.0+1")).To(BeFalse())
+	})
+})
+
+// manifestUrlClientset returns clients that only know the URLs of package manifests
+type manifestUrlClientset struct {
+	repoclient.RepoClientset
+}
+
+func (manifestUrlClientset) ForRepoWithName(name string) repoclient.RepoClient {
+	return manifestUrlClient{repositoryName: name}
+}
+
+type manifestUrlClient struct {
+	repoclient.RepoClient
+	repositoryName string
+}
+
+func (c manifestUrlClient) GetPackageManifestURL(name, version string) (string, error) {
+	return fmt.Sprintf("https://%v.example.com/packages/%v/%v/package.yaml", c.repositoryName, name, version), nil
+}
+
+var _ = Describe("ManifestMarkdown", func() {
+	It("should resolve relative references against the manifest of a package that is not installed", func() {
+		t := templates{repoClientset: manifestUrlClientset{}}
+		Expect(t.parseTemplates()).To(Succeed())
+		manifestMarkdown := t.templateFuncs["ManifestMarkdown"].(func(string, string, string, string) template.HTML)
+		result := manifestMarkdown("glasskube", "cert-manager", "v1.14.2+1", "![screenshot](./screenshot.png)")
+		Expect(string(result)).To(ContainSubstring(
+			`src="https://glasskube.example.com/packages/cert-manager/v1.14.2+1/screenshot.png"`))
+	})
+})
+
+var _ = Describe("Markdown syntax highlighting", func() {
+	var markdown func(ctrlpkg.Package, string) template.HTML
+
+	BeforeEach(func() {
+		var t templates
+		Expect(t.parseTemplates()).To(Succeed())
+		markdown = t.templateFuncs["Markdown"].(func(ctrlpkg.Package, string) template.HTML)
+	})
+
+	It("should highlight code blocks of allowed languages", func() {
+		result := markdown(nil, "```yaml\nkey: value\n```\n")
+		Expect(string(result)).To(HavePrefix(`<pre class="chroma">`))
+	})
+
+	It("should render code blocks of other languages as plain text", func() {
+		result := markdown(nil, "```brainfuck\n+[-->-[>>+>-----<<]<--<---]>-.\n```\n")
+		Expect(string(result)).To(HavePrefix(`<pre><code class="language-brainfuck">`))
+	})
+
+	It("should render code blocks without language as plain text", func() {
+		result := markdown(nil, "```\nkey: value\n```\n")
+		Expect(string(result)).To(HavePrefix(`<pre><code>`))
+	})
+})
@@ -0,0 +1,270 @@
+package web
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/multierr"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+var errNoCertificate = errors.New("no certificate has been loaded")
+
+// TLSOptions configure serving the UI over HTTPS. The certificate is either read from files or from a Secret and is
+// reloaded whenever it changes, e.g. when it is renewed by cert-manager.
+type TLSOptions struct {
+	// CertFile and KeyFile are the paths of the PEM encoded certificate and private key
+	CertFile string
+	KeyFile  string
+	// CertSecret is a Secret of type kubernetes.io/tls in the form "namespace/name"
+	CertSecret string
+	// MinVersion is the minimum TLS version, either "1.2" or "1.3"
+	MinVersion string
+	// CipherSuites are the names of the cipher suites that may be used with TLS 1.2. Empty means the Go defaults.
+	CipherSuites []string
+	// RedirectPort is a port on which plain HTTP requests are redirected to HTTPS. Empty disables the redirect.
+	RedirectPort string
+}
+
+func DefaultTLSOptions() TLSOptions {
+	return TLSOptions{MinVersion: "1.2"}
+}
+
+// Enabled returns whether a certificate source is configured
+func (opts TLSOptions) Enabled() bool {
+	return opts.CertFile != "" || opts.KeyFile != "" || opts.CertSecret != ""
+}
+
+func (opts TLSOptions) validate() error {
+	if (opts.CertFile == "") != (opts.KeyFile == "") {
+		return errors.New("TLS certificate file and key file must be given together")
+	} else if opts.CertFile != "" && opts.CertSecret != "" {
+		return errors.New("TLS certificate can either be read from files or from a secret, not both")
+	} else if opts.RedirectPort != "" && !opts.Enabled() {
+		return errors.New("HTTP redirect requires a TLS certificate")
+	} else if opts.CertSecret != "" {
+		if _, _, err := opts.secretName(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (opts TLSOptions) secretName() (namespace, name string, err error) {
+	namespace, name, ok := strings.Cut(opts.CertSecret, "/")
+	if !ok || namespace == "" || name == "" {
+		return "", "", fmt.Errorf("invalid TLS secret %q: expected namespace/name", opts.CertSecret)
+	}
+	return namespace, name, nil
+}
+
+// tlsConfig returns the TLS configuration for the given minimum version and cipher suites, which obtains the
+// certificate from getCertificate for every handshake
+func (opts TLSOptions) tlsConfig(
+	getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error),
+) (*tls.Config, error) {
+	config := tls.Config{GetCertificate: getCertificate}
+	if opts.MinVersion != "" {
+		if version, ok := tlsVersions[opts.MinVersion]; !ok {
+			return nil, fmt.Errorf("invalid TLS version %q: must be one of 1.2, 1.3", opts.MinVersion)
+		} else {
+			config.MinVersion = version
+		}
+	}
+	for _, name := range opts.CipherSuites {
+		index := slices.IndexFunc(tls.CipherSuites(), func(suite *tls.CipherSuite) bool { return suite.Name == name })
+		if index < 0 {
+			return nil, fmt.Errorf("invalid or insecure cipher suite %q", name)
+		}
+		config.CipherSuites = append(config.CipherSuites, tls.CipherSuites()[index].ID)
+	}
+	return &config, nil
+}
+
+// certificateReloader holds the current certificate of the server, which can be replaced while the server is running
+type certificateReloader struct {
+	certificate *tls.Certificate
+	mutex       sync.RWMutex
+}
+
+func (r *certificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if r.certificate == nil {
+		return nil, errNoCertificate
+	}
+	return r.certificate, nil
+}
+
+func (r *certificateReloader) setCertificate(cert *tls.Certificate) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.certificate = cert
+}
+
+func (r *certificateReloader) loadFiles(certFile, keyFile string) error {
+	if cert, err := tls.LoadX509KeyPair(certFile, keyFile); err != nil {
+		return err
+	} else {
+		r.setCertificate(&cert)
+		return nil
+	}
+}
+
+// watchFiles loads the certificate again whenever something changes in the directories of the given files, until
+// stopCh is closed. The directories are watched instead of the files, because mounted Secrets are updated by
+// replacing a symlink. If the new certificate can not be loaded, the previous one is kept.
+func (r *certificateReloader) watchFiles(certFile, keyFile string, stopCh chan struct{}) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	for _, dir := range slices.Compact([]string{filepath.Dir(certFile), filepath.Dir(keyFile)}) {
+		err = multierr.Append(err, watcher.Add(dir))
+	}
+	if err != nil {
+		_ = watcher.Close()
+		return err
+	}
+	go func() {
+		defer func() { _ = watcher.Close() }()
+		for {
+			select {
+			case <-stopCh:
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Has(fsnotify.Chmod) {
+					continue
+				}
+				if err := r.loadFiles(certFile, keyFile); err != nil {
+					fmt.Fprintf(os.Stderr, "TLS certificate was not updated: %v\n", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+func (r *certificateReloader) loadSecret(secret *v1.Secret) error {
+	cert, err := tls.X509KeyPair(secret.Data[v1.TLSCertKey], secret.Data[v1.TLSPrivateKeyKey])
+	if err != nil {
+		return fmt.Errorf("secret %v/%v does not contain a valid certificate: %w", secret.Namespace, secret.Name, err)
+	}
+	r.setCertificate(&cert)
+	return nil
+}
+
+// watchSecret loads the certificate from the given Secret and again whenever the Secret changes, until stopCh is
+// closed. An error is returned if the initial certificate can not be loaded.
+func (r *certificateReloader) watchSecret(
+	clientset kubernetes.Interface, namespace, name string, stopCh chan struct{},
+) error {
+	factory := informers.NewSharedInformerFactoryWithOptions(clientset, 0,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+		}),
+	)
+	informer := factory.Core().V1().Secrets().Informer()
+	load := func(obj any) {
+		if secret, ok := obj.(*v1.Secret); ok {
+			if err := r.loadSecret(secret); err != nil {
+				fmt.Fprintf(os.Stderr, "TLS certificate was not updated: %v\n", err)
+			}
+		}
+	}
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    load,
+		UpdateFunc: func(_, newObj any) { load(newObj) },
+	}); err != nil {
+		return err
+	}
+	factory.Start(stopCh)
+	if !cache.WaitForCacheSync(stopCh, informer.HasSynced) {
+		return errors.New("could not sync TLS secret")
+	}
+	if _, err := r.GetCertificate(nil); err != nil {
+		return fmt.Errorf("could not load TLS certificate from secret %v/%v: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// redirectToHTTPS redirects every request to the same URL on the HTTPS port
+func redirectToHTTPS(httpsPort string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		target := "https://" + net.JoinHostPort(host, httpsPort) + r.URL.RequestURI()
+		http.Redirect(w, r, target, http.StatusPermanentRedirect)
+	})
+}
+
+// initTLS creates the TLS configuration of the server and starts watching the certificate source
+func (s *server) initTLS() (*tls.Config, error) {
+	reloader := &certificateReloader{}
+	if s.CertFile != "" {
+		if err := reloader.loadFiles(s.CertFile, s.KeyFile); err != nil {
+			return nil, fmt.Errorf("could not load TLS certificate: %w", err)
+		}
+		if err := reloader.watchFiles(s.CertFile, s.KeyFile, s.stopCh); err != nil {
+			fmt.Fprintf(os.Stderr, "TLS certificate will not be reloaded after changes: %v\n", err)
+		}
+	} else {
+		namespace, name, _ := s.TLSOptions.secretName()
+		restConfig, _, err := s.LoadConfig()
+		if err != nil {
+			return nil, fmt.Errorf("could not load TLS secret: %w", err)
+		}
+		clientset, err := kubernetes.NewForConfig(restConfig)
+		if err != nil {
+			return nil, fmt.Errorf("could not load TLS secret: %w", err)
+		}
+		if err := reloader.watchSecret(clientset, namespace, name, s.stopCh); err != nil {
+			return nil, err
+		}
+	}
+	return s.TLSOptions.tlsConfig(reloader.GetCertificate)
+}
+
+// serveHTTPRedirect redirects plain HTTP requests on the redirect port to the HTTPS port of the server
+func (s *server) serveHTTPRedirect() error {
+	listener, err := net.Listen("tcp", net.JoinHostPort(s.Host, s.RedirectPort))
+	if err != nil {
+		return err
+	}
+	_, httpsPort, err := net.SplitHostPort(s.listener.Addr().String())
+	if err != nil {
+		return err
+	}
+	s.redirectServer = &http.Server{Handler: redirectToHTTPS(httpsPort)}
+	go func() {
+		if err := s.redirectServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+			s.logger.Error("HTTP redirect server stopped", "error", err)
+		}
+	}()
+	return nil
+}
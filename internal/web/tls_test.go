@@ -0,0 +1,118 @@
+package web
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func generateCertificate(commonName string) (certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	Expect(err).NotTo(HaveOccurred())
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+func certificateCommonName(r *certificateReloader) string {
+	cert, err := r.GetCertificate(nil)
+	if err != nil {
+		return ""
+	}
+	parsed, err := x509.ParseCertificate(cert.Certificate[0])
+	Expect(err).NotTo(HaveOccurred())
+	return parsed.Subject.CommonName
+}
+
+var _ = Describe("TLS", func() {
+	DescribeTable("should reject invalid options",
+		func(opts TLSOptions) {
+			Expect(opts.validate()).To(HaveOccurred())
+		},
+		Entry("cert file without key file", TLSOptions{CertFile: "tls.crt"}),
+		Entry("files and secret", TLSOptions{CertFile: "tls.crt", KeyFile: "tls.key", CertSecret: "ns/name"}),
+		Entry("secret without namespace", TLSOptions{CertSecret: "name"}),
+		Entry("redirect without certificate", TLSOptions{RedirectPort: "8080"}),
+	)
+
+	It("should create the TLS config", func() {
+		opts := TLSOptions{MinVersion: "1.3", CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}}
+		config, err := opts.tlsConfig(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config.MinVersion).To(Equal(uint16(tls.VersionTLS13)))
+		Expect(config.CipherSuites).To(Equal([]uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}))
+	})
+
+	DescribeTable("should reject an invalid TLS config",
+		func(opts TLSOptions) {
+			_, err := opts.tlsConfig(nil)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("unknown version", TLSOptions{MinVersion: "1.0"}),
+		Entry("insecure cipher suite", TLSOptions{CipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"}}),
+	)
+
+	It("should reload the certificate when the files change", func() {
+		dir := GinkgoT().TempDir()
+		certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+		writeCertificate := func(commonName string) {
+			certPEM, keyPEM := generateCertificate(commonName)
+			Expect(os.WriteFile(keyFile, keyPEM, 0600)).To(Succeed())
+			Expect(os.WriteFile(certFile, certPEM, 0600)).To(Succeed())
+		}
+		writeCertificate("first")
+
+		var reloader certificateReloader
+		Expect(reloader.loadFiles(certFile, keyFile)).To(Succeed())
+		Expect(certificateCommonName(&reloader)).To(Equal("first"))
+
+		stopCh := make(chan struct{})
+		DeferCleanup(func() { close(stopCh) })
+		Expect(reloader.watchFiles(certFile, keyFile, stopCh)).To(Succeed())
+		writeCertificate("second")
+		Eventually(func() string { return certificateCommonName(&reloader) }).Should(Equal("second"))
+	})
+
+	It("should keep the certificate if the new files are invalid", func() {
+		dir := GinkgoT().TempDir()
+		certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+		certPEM, keyPEM := generateCertificate("valid")
+		Expect(os.WriteFile(keyFile, keyPEM, 0600)).To(Succeed())
+		Expect(os.WriteFile(certFile, certPEM, 0600)).To(Succeed())
+
+		var reloader certificateReloader
+		Expect(reloader.loadFiles(certFile, keyFile)).To(Succeed())
+		Expect(os.WriteFile(certFile, []byte("invalid"), 0600)).To(Succeed())
+		Expect(reloader.loadFiles(certFile, keyFile)).NotTo(Succeed())
+		Expect(certificateCommonName(&reloader)).To(Equal("valid"))
+	})
+
+	It("should redirect to HTTPS", func() {
+		rec := httptest.NewRecorder()
+		redirectToHTTPS("8443").ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://example.com:8080/a?b=c", nil))
+		Expect(rec.Code).To(Equal(http.StatusPermanentRedirect))
+		Expect(rec.Header().Get("Location")).To(Equal("https://example.com:8443/a?b=c"))
+	})
+})
@@ -0,0 +1,43 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/glasskube/glasskube/internal/tracing"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// tracingMiddleware starts a span for every request, which is the root span of all operations that are triggered by
+// the request, including the reconciliation of packages that are installed or updated. Event streams and static
+// files are not traced.
+func (s *server) tracingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := r.URL.Path
+		if current := mux.CurrentRoute(r); current != nil {
+			if tmpl, err := current.GetPathTemplate(); err == nil {
+				route = tmpl
+			}
+		}
+		if isEventStreamPath(route) || route == "/metrics" || route == "/static/" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracing.Start(ctx, fmt.Sprintf("%v %v", r.Method, route),
+			attribute.String("http.request.method", r.Method),
+			attribute.String("http.route", route),
+		)
+		defer span.End()
+		sw := &statusRecordingResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r.WithContext(ctx))
+		span.SetAttributes(attribute.Int("http.response.status_code", sw.status))
+		if sw.status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(sw.status))
+		}
+	})
+}
@@ -0,0 +1,207 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/audit"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/web/components/toast"
+	"github.com/glasskube/glasskube/internal/web/util"
+	"github.com/glasskube/glasskube/pkg/client"
+	"github.com/glasskube/glasskube/pkg/condition"
+	"github.com/glasskube/glasskube/pkg/update"
+)
+
+const (
+	updateAllScopeCluster    = "cluster"
+	updateAllScopeNamespaced = "namespaced"
+	// updateAllAwaitTimeout is the time to wait for a single package to become ready after it has been updated. A
+	// package that takes longer is reported as failed, so that it does not block the update of all other packages.
+	updateAllAwaitTimeout = 10 * time.Minute
+)
+
+// updateAll starts updating all upgradable packages of the requested scope one after another in the background.
+// The result of every single update is sent to all clients as a toast via SSE. Packages that can not be updated due
+// to dependency conflicts are skipped and reported at the end.
+func (s *server) updateAll(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if s.isGitopsModeEnabled() {
+		s.sendToast(w, toast.WithErr(errors.New("updating packages is not possible in GitOps mode")),
+			toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+
+	// the updates must continue after the request has finished, but need the clients stored in the request context
+	ctx := context.WithoutCancel(r.Context())
+	pkgs, err := s.listInstalledPackages(ctx, r.FormValue("scope"))
+	if err != nil {
+		s.sendToast(w, toast.WithErr(err))
+		return
+	}
+
+	if !s.updateAllMutex.TryLock() {
+		s.sendToast(w, toast.WithErr(errors.New("an update of all packages is already in progress")),
+			toast.WithStatusCode(http.StatusConflict))
+		return
+	}
+	go func() {
+		defer s.updateAllMutex.Unlock()
+		s.updatePackagesSequentially(ctx, pkgs)
+	}()
+
+	s.sendToast(w,
+		toast.WithMessage("Updating all packages. You will be notified about the progress."),
+		toast.WithSeverity(toast.Info),
+		toast.WithStatusCode(http.StatusAccepted))
+}
+
+func (s *server) listInstalledPackages(ctx context.Context, scope string) ([]ctrlpkg.Package, error) {
+	var pkgs []ctrlpkg.Package
+	switch scope {
+	case updateAllScopeCluster:
+		var list v1alpha1.ClusterPackageList
+		if err := s.pkgClient.ClusterPackages().GetAll(ctx, &list); err != nil {
+			return nil, fmt.Errorf("failed to list clusterpackages: %w", err)
+		}
+		for i := range list.Items {
+			pkgs = append(pkgs, &list.Items[i])
+		}
+	case updateAllScopeNamespaced:
+		var list v1alpha1.PackageList
+		if err := s.pkgClient.Packages("").GetAll(ctx, &list); err != nil {
+			return nil, fmt.Errorf("failed to list packages: %w", err)
+		}
+		for i := range list.Items {
+			pkgs = append(pkgs, &list.Items[i])
+		}
+	default:
+		return nil, fmt.Errorf("invalid scope: %v", scope)
+	}
+	return pkgs, nil
+}
+
+// updatePackagesSequentially updates every given package that is upgradable. It does not stop on the first failure,
+// such that a single blocked package does not prevent all other packages from being updated. Every update is awaited
+// and counted as failed unless the package becomes ready within updateAllAwaitTimeout.
+func (s *server) updatePackagesSequentially(ctx context.Context, pkgs []ctrlpkg.Package) {
+	var updated int
+	var skipped, failed []string
+	for _, pkg := range pkgs {
+		if !pkg.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		updater := update.NewUpdater(ctx)
+		tx, err := updater.Prepare(ctx, update.GetExact([]ctrlpkg.Package{pkg}))
+		if err != nil {
+			failed = append(failed, pkg.GetName())
+			s.broadcastToast(toast.WithErr(fmt.Errorf("failed to check for updates of %v: %w", pkg.GetName(), err)))
+			continue
+		} else if len(tx.ConflictItems) > 0 {
+			reason := tx.ConflictItems[0].Conflicts.String()
+			skipped = append(skipped, fmt.Sprintf("%v (%v)", pkg.GetName(), reason))
+			s.broadcastToast(
+				toast.WithMessage(fmt.Sprintf("Skipped %v because of dependency conflicts: %v", pkg.GetName(), reason)),
+				toast.WithSeverity(toast.Warning))
+			continue
+		} else if tx.IsEmpty() {
+			continue
+		}
+
+		versionBefore := pkg.GetSpec().PackageInfo.Version
+		version := tx.Items[0].Version
+		if _, err := updater.Apply(ctx, tx, update.ApplyUpdateOptions{
+			Blocking:     true,
+			AwaitTimeout: updateAllAwaitTimeout,
+		}); err != nil {
+			failed = append(failed, pkg.GetName())
+			s.broadcastToast(toast.WithErr(fmt.Errorf("failed to update %v: %w", pkg.GetName(), err)))
+			continue
+		}
+		s.recordPackageOperation(ctx, audit.OperationUpdate, pkg, versionBefore, version)
+		if status, err := s.getUpdatedPackageStatus(ctx, pkg); err != nil {
+			failed = append(failed, pkg.GetName())
+			s.broadcastToast(toast.WithErr(fmt.Errorf("failed to get status of %v: %w", pkg.GetName(), err)))
+		} else if status == nil || status.Status != string(condition.Ready) {
+			failed = append(failed, pkg.GetName())
+			s.broadcastToast(toast.WithErr(fmt.Errorf("%v was updated to %v but is not ready: %v",
+				pkg.GetName(), version, statusMessage(status))))
+		} else {
+			updated++
+			s.broadcastToast(
+				toast.WithMessage(fmt.Sprintf("%v has been updated to %v and is ready", pkg.GetName(), version)),
+				toast.WithSeverity(toast.Success))
+		}
+	}
+
+	summary := fmt.Sprintf("Update of all packages finished: %v updated", updated)
+	severity := toast.Success
+	if len(skipped) > 0 {
+		summary += fmt.Sprintf(", %v skipped: %v", len(skipped), strings.Join(skipped, ", "))
+		severity = toast.Warning
+	}
+	if len(failed) > 0 {
+		summary += fmt.Sprintf(", %v failed: %v", len(failed), strings.Join(failed, ", "))
+		severity = toast.Danger
+	}
+	s.broadcastToast(toast.WithMessage(summary), toast.WithSeverity(severity))
+}
+
+// getUpdatedPackageStatus fetches the given package again, because the object returned by the updater does not
+// contain the status that has been reached after the update.
+func (s *server) getUpdatedPackageStatus(ctx context.Context, pkg ctrlpkg.Package) (*client.PackageStatus, error) {
+	switch pkg := pkg.(type) {
+	case *v1alpha1.ClusterPackage:
+		var current v1alpha1.ClusterPackage
+		if err := s.pkgClient.ClusterPackages().Get(ctx, pkg.Name, &current); err != nil {
+			return nil, err
+		}
+		return client.GetStatus(&current.Status), nil
+	case *v1alpha1.Package:
+		var current v1alpha1.Package
+		if err := s.pkgClient.Packages(pkg.Namespace).Get(ctx, pkg.Name, &current); err != nil {
+			return nil, err
+		}
+		return client.GetStatus(&current.Status), nil
+	default:
+		return nil, fmt.Errorf("unexpected object kind: %v", pkg.GroupVersionKind().Kind)
+	}
+}
+
+func statusMessage(status *client.PackageStatus) string {
+	if status == nil {
+		return "status unknown"
+	} else if status.Message != "" {
+		return status.Message
+	}
+	return status.Reason
+}
+
+// broadcastToast renders a toast from the given options and sends it to all connected clients via SSE. If the toast
+// contains an error, this is also logged to stderr.
+func (s *server) broadcastToast(options ...toast.ResponseOption) {
+	response := toast.Response{ToastInput: toast.ToastInput{Dismissible: true}}
+	for _, opt := range options {
+		opt(&response)
+	}
+	response.Apply()
+
+	var buf bytes.Buffer
+	err := s.templates.toastTmpl.Execute(&buf, response.ToastInput)
+	util.CheckTmplError(err, "toast")
+	s.broadcaster.Toast(buf.String())
+
+	if response.Err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", response.Err)
+	}
+}
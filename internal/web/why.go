@@ -0,0 +1,30 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/glasskube/glasskube/internal/web/components/toast"
+	"github.com/glasskube/glasskube/internal/web/util"
+)
+
+// packageWhy explains why a package is installed, by showing every chain of dependencies through which it is
+// required by a manually installed package
+func (s *server) packageWhy(w http.ResponseWriter, r *http.Request) {
+	pkg, err := s.getPackageFromRequest(r)
+	if err != nil {
+		s.sendToast(w, toast.WithErr(err))
+		return
+	}
+	g, err := s.dependencyMgr.NewGraph(r.Context())
+	if err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to build dependency graph: %w", err)))
+		return
+	}
+	err = s.templatesFor(r).pkgWhyTmpl.ExecuteTemplate(w, "why-installed", map[string]any{
+		"Chains":      g.Why(pkg.GetName(), pkg.GetNamespace()),
+		"PackageHref": strings.TrimSuffix(r.URL.Path, "/why"),
+	})
+	util.CheckTmplError(err, fmt.Sprintf("why-installed (%v)", pkg.GetName()))
+}
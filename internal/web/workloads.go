@@ -0,0 +1,234 @@
+package web
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/web/components/toast"
+	"github.com/glasskube/glasskube/internal/web/util"
+	"github.com/glasskube/glasskube/internal/workloads"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const workloadLogTailLines = 100
+
+type workloadListers struct {
+	deployments  appsv1listers.DeploymentLister
+	statefulSets appsv1listers.StatefulSetLister
+	daemonSets   appsv1listers.DaemonSetLister
+}
+
+// initWorkloadInformers sets up the listers for Deployments, StatefulSets and DaemonSets. Whenever one of them
+// changes, the workloads section of the detail page of every package it belongs to is refreshed via SSE.
+func (s *server) initWorkloadInformers(ctx context.Context, factory informers.SharedInformerFactory) {
+	deployments := factory.Apps().V1().Deployments()
+	statefulSets := factory.Apps().V1().StatefulSets()
+	daemonSets := factory.Apps().V1().DaemonSets()
+	s.workloadListers = &workloadListers{
+		deployments:  deployments.Lister(),
+		statefulSets: statefulSets.Lister(),
+		daemonSets:   daemonSets.Lister(),
+	}
+	for kind, informer := range map[string]cache.SharedIndexInformer{
+		workloads.KindDeployment:  deployments.Informer(),
+		workloads.KindStatefulSet: statefulSets.Informer(),
+		workloads.KindDaemonSet:   daemonSets.Informer(),
+	} {
+		_, err := informer.AddEventHandler(cache.ResourceEventHandlerDetailedFuncs{
+			AddFunc: func(obj any, isInInitialList bool) {
+				if !isInInitialList {
+					s.workloadChanged(ctx, kind, obj)
+				}
+			},
+			UpdateFunc: func(oldObj, newObj any) { s.workloadChanged(ctx, kind, newObj) },
+			DeleteFunc: func(obj any) {
+				if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+					obj = tombstone.Obj
+				}
+				s.workloadChanged(ctx, kind, obj)
+			},
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to watch %v workloads: %v\n", kind, err)
+		}
+	}
+}
+
+func (s *server) workloadChanged(ctx context.Context, kind string, obj any) {
+	if obj, ok := obj.(metav1.Object); ok {
+		if pkgs, err := s.findPackagesOfWorkload(ctx, kind, obj); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to find packages of %v %v/%v: %v\n",
+				kind, obj.GetNamespace(), obj.GetName(), err)
+		} else if len(pkgs) > 0 {
+			s.broadcaster.WorkloadsChanged(pkgs...)
+		}
+	}
+}
+
+func (s *server) findPackagesOfWorkload(
+	ctx context.Context,
+	kind string,
+	obj metav1.Object,
+) ([]ctrlpkg.Package, error) {
+	var result []ctrlpkg.Package
+	var clpkgs v1alpha1.ClusterPackageList
+	if err := s.pkgClient.ClusterPackages().GetAll(ctx, &clpkgs); err != nil {
+		return nil, err
+	}
+	for i := range clpkgs.Items {
+		if workloads.BelongsTo(&clpkgs.Items[i], kind, obj) {
+			result = append(result, &clpkgs.Items[i])
+		}
+	}
+	var pkgs v1alpha1.PackageList
+	if err := s.pkgClient.Packages("").GetAll(ctx, &pkgs); err != nil {
+		return nil, err
+	}
+	for i := range pkgs.Items {
+		if workloads.BelongsTo(&pkgs.Items[i], kind, obj) {
+			result = append(result, &pkgs.Items[i])
+		}
+	}
+	return result, nil
+}
+
+// getWorkloads returns the status of all workloads that belong to pkg, including the status of their pods
+func (s *server) getWorkloads(ctx context.Context, pkg ctrlpkg.Package) ([]workloads.Status, error) {
+	if s.workloadListers == nil {
+		return nil, nil
+	}
+	var result []workloads.Status
+	deployments, err := s.workloadListers.deployments.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range deployments {
+		if workloads.BelongsTo(pkg, workloads.KindDeployment, obj) {
+			if pods, err := s.listPodsOfWorkload(ctx, obj.Namespace, obj.Spec.Selector); err != nil {
+				return nil, err
+			} else {
+				result = append(result, workloads.ForDeployment(obj, pods))
+			}
+		}
+	}
+	statefulSets, err := s.workloadListers.statefulSets.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range statefulSets {
+		if workloads.BelongsTo(pkg, workloads.KindStatefulSet, obj) {
+			if pods, err := s.listPodsOfWorkload(ctx, obj.Namespace, obj.Spec.Selector); err != nil {
+				return nil, err
+			} else {
+				result = append(result, workloads.ForStatefulSet(obj, pods))
+			}
+		}
+	}
+	daemonSets, err := s.workloadListers.daemonSets.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range daemonSets {
+		if workloads.BelongsTo(pkg, workloads.KindDaemonSet, obj) {
+			if pods, err := s.listPodsOfWorkload(ctx, obj.Namespace, obj.Spec.Selector); err != nil {
+				return nil, err
+			} else {
+				result = append(result, workloads.ForDaemonSet(obj, pods))
+			}
+		}
+	}
+	slices.SortFunc(result, func(a, b workloads.Status) int {
+		if a.Namespace != b.Namespace {
+			return cmp.Compare(a.Namespace, b.Namespace)
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return result, nil
+}
+
+func (s *server) listPodsOfWorkload(
+	ctx context.Context,
+	namespace string,
+	labelSelector *metav1.LabelSelector,
+) ([]corev1.Pod, error) {
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := s.k8sClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
+func (s *server) packageWorkloads(w http.ResponseWriter, r *http.Request) {
+	pkg, err := s.getPackageFromRequest(r)
+	if err != nil {
+		s.sendToast(w, toast.WithErr(err))
+		return
+	}
+	workloadList, err := s.getWorkloads(r.Context(), pkg)
+	if err != nil {
+		err = fmt.Errorf("failed to get workloads of %v: %w", pkg.GetName(), err)
+	}
+	err = s.templatesFor(r).pkgWorkloadsTmpl.ExecuteTemplate(w, "pkg-workloads", map[string]any{
+		"Workloads":   workloadList,
+		"Error":       err,
+		"PackageHref": strings.TrimSuffix(r.URL.Path, "/workloads"),
+	})
+	util.CheckTmplError(err, fmt.Sprintf("pkg-workloads (%v)", pkg.GetName()))
+}
+
+// packageWorkloadLogs shows the most recent log lines of the primary container of a pod. Only pods that belong to a
+// workload of the package can be selected.
+func (s *server) packageWorkloadLogs(w http.ResponseWriter, r *http.Request) {
+	pkg, err := s.getPackageFromRequest(r)
+	if err != nil {
+		s.sendToast(w, toast.WithErr(err))
+		return
+	}
+	workloadList, err := s.getWorkloads(r.Context(), pkg)
+	if err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to get workloads of %v: %w", pkg.GetName(), err)))
+		return
+	}
+
+	namespace, name := r.FormValue("namespace"), r.FormValue("pod")
+	var pod *workloads.PodStatus
+	for _, workload := range workloadList {
+		for i := range workload.Pods {
+			if workload.Pods[i].Namespace == namespace && workload.Pods[i].Name == name {
+				pod = &workload.Pods[i]
+			}
+		}
+	}
+	if pod == nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("pod %v/%v does not belong to %v", namespace, name, pkg.GetName())),
+			toast.WithStatusCode(http.StatusNotFound))
+		return
+	}
+
+	tailLines := int64(workloadLogTailLines)
+	logs, err := s.k8sClient.CoreV1().Pods(pod.Namespace).
+		GetLogs(pod.Name, &corev1.PodLogOptions{Container: pod.Container, TailLines: &tailLines}).
+		DoRaw(r.Context())
+	err = s.templatesFor(r).pkgWorkloadsTmpl.ExecuteTemplate(w, "pkg-workload-logs-modal", map[string]any{
+		"Pod":   pod,
+		"Logs":  string(logs),
+		"Error": err,
+	})
+	util.CheckTmplError(err, fmt.Sprintf("pkg-workload-logs-modal (%v)", pkg.GetName()))
+}
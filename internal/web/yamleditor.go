@@ -0,0 +1,144 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/audit"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/web/components/toast"
+	"github.com/glasskube/glasskube/internal/web/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// editableObject is the part of a package that can be changed in the YAML editor
+type editableObject struct {
+	APIVersion string               `json:"apiVersion"`
+	Kind       string               `json:"kind"`
+	Metadata   editableObjectMeta   `json:"metadata"`
+	Spec       v1alpha1.PackageSpec `json:"spec"`
+}
+
+type editableObjectMeta struct {
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// packageYamlEditor shows the labels, annotations and spec of an installed package as YAML in an editable modal (GET)
+// and applies the edited YAML to the package (POST). The result is validated by the API server against the CRD schema
+// before it is persisted. In gitops mode, the package is only validated and the resulting YAML is shown.
+func (s *server) packageYamlEditor(w http.ResponseWriter, r *http.Request) {
+	pkg, err := s.getPackageFromRequest(r)
+	if err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to fetch package: %w", err)))
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		data, err := yaml.Marshal(toEditableObject(pkg))
+		err = s.templatesFor(r).yamlEditorModalTmpl.Execute(w, map[string]any{
+			"Href":            r.URL.Path,
+			"Object":          string(data),
+			"ResourceVersion": pkg.GetResourceVersion(),
+			"GitopsMode":      s.isGitopsModeEnabled(),
+			"ReadOnly":        s.ReadOnly,
+			"Err":             err,
+		})
+		util.CheckTmplError(err, "yaml-editor-modal")
+		return
+	} else if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var edited editableObject
+	if err := yaml.UnmarshalStrict([]byte(r.FormValue("yaml")), &edited); err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("invalid yaml: %w", err)), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	} else if err := validateEditedObject(pkg, edited); err != nil {
+		s.sendToast(w, toast.WithErr(err), toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+
+	versionBefore := pkg.GetSpec().PackageInfo.Version
+	pkg.SetResourceVersion(r.FormValue("resourceVersion"))
+	pkg.SetLabels(edited.Metadata.Labels)
+	pkg.SetAnnotations(edited.Metadata.Annotations)
+	*pkg.GetSpec() = edited.Spec
+
+	opts := metav1.UpdateOptions{FieldValidation: metav1.FieldValidationStrict}
+	if s.isGitopsModeEnabled() {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	switch p := pkg.(type) {
+	case *v1alpha1.ClusterPackage:
+		err = s.pkgClient.ClusterPackages().Update(r.Context(), p, opts)
+	case *v1alpha1.Package:
+		err = s.pkgClient.Packages(p.GetNamespace()).Update(r.Context(), p, opts)
+	}
+	if err != nil {
+		s.sendToast(w, toast.WithErr(fmt.Errorf("failed to apply yaml: %w", err)),
+			toast.WithStatusCode(http.StatusBadRequest))
+		return
+	}
+
+	if s.isGitopsModeEnabled() {
+		s.sendYamlModal(w, pkg, nil)
+		return
+	}
+
+	operation := audit.OperationConfigure
+	if versionAfter := pkg.GetSpec().PackageInfo.Version; versionAfter != versionBefore {
+		operation = audit.OperationUpdate
+	}
+	s.recordPackageOperation(r.Context(), operation, pkg, versionBefore, pkg.GetSpec().PackageInfo.Version)
+	s.sendToast(w, toast.WithMessage(fmt.Sprintf("%v has been updated", pkg.GetName())))
+}
+
+func toEditableObject(pkg ctrlpkg.Package) editableObject {
+	gvk := pkg.GetObjectKind().GroupVersionKind()
+	if gvk.Empty() {
+		if _, ok := pkg.(*v1alpha1.ClusterPackage); ok {
+			gvk = v1alpha1.GroupVersion.WithKind("ClusterPackage")
+		} else {
+			gvk = v1alpha1.GroupVersion.WithKind("Package")
+		}
+	}
+	return editableObject{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Metadata: editableObjectMeta{
+			Name:        pkg.GetName(),
+			Namespace:   pkg.GetNamespace(),
+			Labels:      pkg.GetLabels(),
+			Annotations: pkg.GetAnnotations(),
+		},
+		Spec: *pkg.GetSpec(),
+	}
+}
+
+// validateEditedObject makes sure that the edited YAML still describes the same package
+func validateEditedObject(pkg ctrlpkg.Package, edited editableObject) error {
+	original := toEditableObject(pkg)
+	var errs []error
+	if edited.APIVersion != original.APIVersion || edited.Kind != original.Kind {
+		errs = append(errs, fmt.Errorf("apiVersion and kind must be %v, %v", original.APIVersion, original.Kind))
+	}
+	if edited.Metadata.Name != original.Metadata.Name {
+		errs = append(errs, fmt.Errorf("name can not be changed (expected %v)", original.Metadata.Name))
+	}
+	if edited.Metadata.Namespace != original.Metadata.Namespace {
+		errs = append(errs, fmt.Errorf("namespace can not be changed (expected %v)", original.Metadata.Namespace))
+	}
+	if strings.TrimSpace(edited.Spec.PackageInfo.Name) != original.Spec.PackageInfo.Name {
+		errs = append(errs, fmt.Errorf("spec.packageInfo.name can not be changed (expected %v)",
+			original.Spec.PackageInfo.Name))
+	}
+	return errors.Join(errs...)
+}
@@ -11,6 +11,7 @@ import (
 var ErrInvalidObject = errors.New("validator called with unexpected object type")
 var ErrDependencyConflict = errors.New("dependency conflict")
 var ErrPackagesInstalled = errors.New("dependent package(s) installed")
+var ErrUnknownOptionalDependency = errors.New("not an optional dependency")
 
 func newConflictError(conflicts dependency.Conflicts) error {
 	return fmt.Errorf("%w: %v", ErrDependencyConflict, conflicts)
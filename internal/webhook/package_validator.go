@@ -3,13 +3,16 @@ package webhook
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
+	"slices"
 
 	"github.com/glasskube/glasskube/api/v1alpha1"
 	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
 	"github.com/glasskube/glasskube/internal/controller/owners"
 	"github.com/glasskube/glasskube/internal/dependency"
 	"github.com/glasskube/glasskube/internal/dependency/graph"
+	deputil "github.com/glasskube/glasskube/internal/dependency/util"
 	"github.com/glasskube/glasskube/internal/manifestvalues"
 	repoclient "github.com/glasskube/glasskube/internal/repo/client"
 	"go.uber.org/multierr"
@@ -130,6 +133,11 @@ func (p *PackageValidatingWebhook) validateCreateOrUpdate(ctx context.Context, p
 		return err
 	}
 
+	if err := validateOptionalDependencies(&manifest, pkg); err != nil {
+		return err
+	}
+
+	manifest = deputil.WithEnabledDependencies(manifest, pkg.GetSpec().OptionalDependencies)
 	if result, err := p.Validate(ctx, pkg.GetName(), pkg.GetNamespace(), &manifest, pkg.GetSpec().PackageInfo.Version); err != nil {
 		return err
 	} else if len(result.Conflicts) > 0 {
@@ -139,3 +147,15 @@ func (p *PackageValidatingWebhook) validateCreateOrUpdate(ctx context.Context, p
 		return nil
 	}
 }
+
+// validateOptionalDependencies checks that all enabled optional dependencies of the package are declared as optional
+// dependencies in its manifest
+func validateOptionalDependencies(manifest *v1alpha1.PackageManifest, pkg ctrlpkg.Package) error {
+	optional := deputil.OptionalDependencies(manifest)
+	for _, name := range pkg.GetSpec().OptionalDependencies {
+		if !slices.Contains(optional, name) {
+			return fmt.Errorf("%w: %v", ErrUnknownOptionalDependency, name)
+		}
+	}
+	return nil
+}
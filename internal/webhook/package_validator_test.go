@@ -173,6 +173,15 @@ var _ = Describe("PackageValidatingWebhook", Ordered, func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
+		When("an unknown optional dependency is enabled", func() {
+			It("should return error", func(ctx context.Context) {
+				webhook := newPackageValidatingWebhook(&barv1pkg, &barv1pi)
+				pkg := foov1pkg.DeepCopy()
+				pkg.Spec.OptionalDependencies = []string{"bar"}
+				_, err := webhook.ValidateCreate(ctx, pkg)
+				Expect(err).To(MatchError(ErrUnknownOptionalDependency))
+			})
+		})
 		When("called with object other than Package", func() {
 			It("should return error", func(ctx context.Context) {
 				webhook := newPackageValidatingWebhook()
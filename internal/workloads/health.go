@@ -0,0 +1,53 @@
+package workloads
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Kind returns the kind of the given workload, or an empty string if obj is not a supported workload
+func Kind(obj any) string {
+	switch obj.(type) {
+	case *appsv1.Deployment:
+		return KindDeployment
+	case *appsv1.StatefulSet:
+		return KindStatefulSet
+	case *appsv1.DaemonSet:
+		return KindDaemonSet
+	default:
+		return ""
+	}
+}
+
+// Problem returns a description of why the given workload is unhealthy, or an empty string if it is healthy.
+// Workloads that are in the middle of a rollout are considered healthy, so that updates do not cause false alarms:
+//   - a Deployment is unhealthy if its Available condition is False,
+//   - a StatefulSet is unhealthy if all replicas have been updated, but not all of them are ready,
+//   - a DaemonSet is unhealthy if all pods have been updated, but some of them are unavailable.
+func Problem(obj any) string {
+	switch w := obj.(type) {
+	case *appsv1.Deployment:
+		for _, cond := range w.Status.Conditions {
+			if cond.Type == appsv1.DeploymentAvailable && cond.Status == corev1.ConditionFalse {
+				return fmt.Sprintf("%v %v/%v is unavailable: %v", KindDeployment, w.Namespace, w.Name, cond.Message)
+			}
+		}
+	case *appsv1.StatefulSet:
+		desired := int32(1)
+		if w.Spec.Replicas != nil {
+			desired = *w.Spec.Replicas
+		}
+		if w.Status.CurrentRevision == w.Status.UpdateRevision && w.Status.ReadyReplicas < desired {
+			return fmt.Sprintf("%v %v/%v has %v of %v ready replicas",
+				KindStatefulSet, w.Namespace, w.Name, w.Status.ReadyReplicas, desired)
+		}
+	case *appsv1.DaemonSet:
+		if w.Status.UpdatedNumberScheduled >= w.Status.DesiredNumberScheduled && w.Status.NumberUnavailable > 0 {
+			return fmt.Sprintf("%v %v/%v has %v of %v pods unavailable",
+				KindDaemonSet, w.Namespace, w.Name, w.Status.NumberUnavailable, w.Status.DesiredNumberScheduled)
+		}
+	}
+	return ""
+}
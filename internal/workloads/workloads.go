@@ -0,0 +1,149 @@
+package workloads
+
+import (
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// LabelHelmReleaseName and LabelHelmReleaseNamespace are added to all resources of a HelmRelease by the flux
+	// helm-controller.
+	LabelHelmReleaseName      = "helm.toolkit.fluxcd.io/name"
+	LabelHelmReleaseNamespace = "helm.toolkit.fluxcd.io/namespace"
+
+	// annotationDefaultContainer is the well-known annotation that kubectl uses to select the container of a pod
+	annotationDefaultContainer = "kubectl.kubernetes.io/default-container"
+)
+
+const (
+	KindDeployment  = "Deployment"
+	KindStatefulSet = "StatefulSet"
+	KindDaemonSet   = "DaemonSet"
+)
+
+// BelongsTo reports whether the workload obj of the given kind belongs to pkg. This is the case if
+//   - obj has the package and instance labels that are added to all resources of a namespaced package,
+//   - obj is an owned resource of pkg, or
+//   - obj was created by a HelmRelease that is an owned resource of pkg.
+func BelongsTo(pkg ctrlpkg.Package, kind string, obj metav1.Object) bool {
+	labels := obj.GetLabels()
+	if pkg.IsNamespaceScoped() && obj.GetNamespace() == pkg.GetNamespace() &&
+		labels[v1alpha1.LabelPackageInstanceName] == pkg.GetName() &&
+		labels[v1alpha1.LabelPackageName] == pkg.GetSpec().PackageInfo.Name {
+		return true
+	}
+	for _, ref := range pkg.GetStatus().OwnedResources {
+		if ref.Group == appsv1.GroupName && ref.Kind == kind &&
+			ref.Namespace == obj.GetNamespace() && ref.Name == obj.GetName() {
+			return true
+		}
+		if ref.Kind == "HelmRelease" && labels[LabelHelmReleaseName] == ref.Name &&
+			labels[LabelHelmReleaseNamespace] == ref.Namespace {
+			return true
+		}
+	}
+	return false
+}
+
+type Status struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Desired   int32
+	Ready     int32
+	Pods      []PodStatus
+}
+
+func (s Status) IsReady() bool {
+	return s.Ready >= s.Desired
+}
+
+type PodStatus struct {
+	Namespace       string
+	Name            string
+	Phase           corev1.PodPhase
+	Containers      int
+	ReadyContainers int
+	Restarts        int32
+	// Container is the primary container of the pod, which is used for showing logs
+	Container string
+}
+
+func (s PodStatus) IsReady() bool {
+	return s.Phase == corev1.PodRunning && s.ReadyContainers == s.Containers
+}
+
+func ForDeployment(obj *appsv1.Deployment, pods []corev1.Pod) Status {
+	desired := int32(1)
+	if obj.Spec.Replicas != nil {
+		desired = *obj.Spec.Replicas
+	}
+	return Status{
+		Kind:      KindDeployment,
+		Namespace: obj.Namespace,
+		Name:      obj.Name,
+		Desired:   desired,
+		Ready:     obj.Status.ReadyReplicas,
+		Pods:      ForPods(pods),
+	}
+}
+
+func ForStatefulSet(obj *appsv1.StatefulSet, pods []corev1.Pod) Status {
+	desired := int32(1)
+	if obj.Spec.Replicas != nil {
+		desired = *obj.Spec.Replicas
+	}
+	return Status{
+		Kind:      KindStatefulSet,
+		Namespace: obj.Namespace,
+		Name:      obj.Name,
+		Desired:   desired,
+		Ready:     obj.Status.ReadyReplicas,
+		Pods:      ForPods(pods),
+	}
+}
+
+func ForDaemonSet(obj *appsv1.DaemonSet, pods []corev1.Pod) Status {
+	return Status{
+		Kind:      KindDaemonSet,
+		Namespace: obj.Namespace,
+		Name:      obj.Name,
+		Desired:   obj.Status.DesiredNumberScheduled,
+		Ready:     obj.Status.NumberReady,
+		Pods:      ForPods(pods),
+	}
+}
+
+func ForPods(pods []corev1.Pod) []PodStatus {
+	result := make([]PodStatus, 0, len(pods))
+	for _, pod := range pods {
+		status := PodStatus{
+			Namespace:  pod.Namespace,
+			Name:       pod.Name,
+			Phase:      pod.Status.Phase,
+			Containers: len(pod.Spec.Containers),
+			Container:  primaryContainer(&pod),
+		}
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Ready {
+				status.ReadyContainers++
+			}
+			status.Restarts += cs.RestartCount
+		}
+		result = append(result, status)
+	}
+	return result
+}
+
+func primaryContainer(pod *corev1.Pod) string {
+	if name, ok := pod.Annotations[annotationDefaultContainer]; ok {
+		return name
+	} else if len(pod.Spec.Containers) > 0 {
+		return pod.Spec.Containers[0].Name
+	} else {
+		return ""
+	}
+}
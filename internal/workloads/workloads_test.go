@@ -0,0 +1,138 @@
+package workloads
+
+import (
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func deployment(namespace, name string, labels map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels}}
+}
+
+var _ = Describe("BelongsTo", func() {
+	pkg := &v1alpha1.Package{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "my-app"},
+		Spec:       v1alpha1.PackageSpec{PackageInfo: v1alpha1.PackageInfoTemplate{Name: "app"}},
+	}
+	clpkg := &v1alpha1.ClusterPackage{
+		ObjectMeta: metav1.ObjectMeta{Name: "cert-manager"},
+		Status: v1alpha1.PackageStatus{OwnedResources: []v1alpha1.OwnedResourceRef{
+			{
+				GroupVersionKind: metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+				Namespace:        "cert-manager",
+				Name:             "cert-manager-webhook",
+			},
+			{
+				GroupVersionKind: metav1.GroupVersionKind{
+					Group: "helm.toolkit.fluxcd.io", Version: "v2", Kind: "HelmRelease"},
+				Namespace: "flux-system",
+				Name:      "cert-manager",
+			},
+		}},
+	}
+
+	DescribeTable("should match workloads",
+		func(pkg ctrlpkg.Package, kind string, obj *appsv1.Deployment, expected bool) {
+			Expect(BelongsTo(pkg, kind, obj)).To(Equal(expected))
+		},
+		Entry("with package labels", pkg, KindDeployment, deployment("ns", "my-app-server", map[string]string{
+			v1alpha1.LabelPackageName: "app", v1alpha1.LabelPackageInstanceName: "my-app"}), true),
+		Entry("with package labels of another instance", pkg, KindDeployment,
+			deployment("ns", "other-server", map[string]string{
+				v1alpha1.LabelPackageName: "app", v1alpha1.LabelPackageInstanceName: "other"}), false),
+		Entry("with package labels in another namespace", pkg, KindDeployment,
+			deployment("other", "my-app-server", map[string]string{
+				v1alpha1.LabelPackageName: "app", v1alpha1.LabelPackageInstanceName: "my-app"}), false),
+		Entry("that are owned resources", clpkg, KindDeployment,
+			deployment("cert-manager", "cert-manager-webhook", nil), true),
+		Entry("that are owned resources of another kind", clpkg, KindStatefulSet,
+			deployment("cert-manager", "cert-manager-webhook", nil), false),
+		Entry("of an owned HelmRelease", clpkg, KindDeployment,
+			deployment("cert-manager", "cert-manager", map[string]string{
+				LabelHelmReleaseName: "cert-manager", LabelHelmReleaseNamespace: "flux-system"}), true),
+		Entry("of another HelmRelease", clpkg, KindDeployment,
+			deployment("cert-manager", "cert-manager", map[string]string{
+				LabelHelmReleaseName: "cert-manager", LabelHelmReleaseNamespace: "default"}), false),
+		Entry("without any relation", clpkg, KindDeployment, deployment("cert-manager", "foo", nil), false),
+	)
+})
+
+var _ = Describe("ForPods", func() {
+	It("should summarize container readiness and restarts", func() {
+		pods := []corev1.Pod{{
+			ObjectMeta: metav1.ObjectMeta{Name: "p", Namespace: "ns"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "main"}, {Name: "sidecar"}}},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "main", Ready: true, RestartCount: 2},
+					{Name: "sidecar", Ready: false, RestartCount: 1},
+				},
+			},
+		}}
+		result := ForPods(pods)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].ReadyContainers).To(Equal(1))
+		Expect(result[0].Containers).To(Equal(2))
+		Expect(result[0].Restarts).To(Equal(int32(3)))
+		Expect(result[0].Container).To(Equal("main"))
+		Expect(result[0].IsReady()).To(BeFalse())
+	})
+
+	It("should use the default container annotation", func() {
+		pods := []corev1.Pod{{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{"kubectl.kubernetes.io/default-container": "sidecar"}},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "main"}, {Name: "sidecar"}}},
+		}}
+		Expect(ForPods(pods)[0].Container).To(Equal("sidecar"))
+	})
+})
+
+var _ = Describe("Problem", func() {
+	replicas := int32(2)
+
+	DescribeTable("should detect unhealthy workloads",
+		func(obj any, expected string) {
+			Expect(Problem(obj)).To(Equal(expected))
+		},
+		Entry("available deployment", &appsv1.Deployment{Status: appsv1.DeploymentStatus{
+			Conditions: []appsv1.DeploymentCondition{{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue}},
+		}}, ""),
+		Entry("unavailable deployment", &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "app"},
+			Status: appsv1.DeploymentStatus{Conditions: []appsv1.DeploymentCondition{{
+				Type: appsv1.DeploymentAvailable, Status: corev1.ConditionFalse,
+				Message: "Deployment does not have minimum availability.",
+			}}},
+		}, "Deployment ns/app is unavailable: Deployment does not have minimum availability."),
+		Entry("ready statefulset", &appsv1.StatefulSet{
+			Spec:   appsv1.StatefulSetSpec{Replicas: &replicas},
+			Status: appsv1.StatefulSetStatus{ReadyReplicas: 2, CurrentRevision: "a", UpdateRevision: "a"},
+		}, ""),
+		Entry("statefulset during rollout", &appsv1.StatefulSet{
+			Spec:   appsv1.StatefulSetSpec{Replicas: &replicas},
+			Status: appsv1.StatefulSetStatus{ReadyReplicas: 1, CurrentRevision: "a", UpdateRevision: "b"},
+		}, ""),
+		Entry("statefulset with unready replicas", &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "db"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+			Status:     appsv1.StatefulSetStatus{ReadyReplicas: 1, CurrentRevision: "a", UpdateRevision: "a"},
+		}, "StatefulSet ns/db has 1 of 2 ready replicas"),
+		Entry("daemonset during rollout", &appsv1.DaemonSet{Status: appsv1.DaemonSetStatus{
+			DesiredNumberScheduled: 3, UpdatedNumberScheduled: 2, NumberUnavailable: 1,
+		}}, ""),
+		Entry("daemonset with unavailable pods", &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "agent"},
+			Status: appsv1.DaemonSetStatus{
+				DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberUnavailable: 1,
+			},
+		}, "DaemonSet ns/agent has 1 of 3 pods unavailable"),
+		Entry("unsupported object", &corev1.Pod{}, ""),
+	)
+})
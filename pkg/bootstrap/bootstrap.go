@@ -103,21 +103,12 @@ func (c *BootstrapClient) Bootstrap(
 	start := time.Now()
 
 	if options.Url == "" {
-		version := config.Version
-		if options.Latest {
-			if releaseInfo, err := releaseinfo.FetchLatestRelease(); err != nil {
-				if httperror.Is(err, http.StatusServiceUnavailable) || httperror.IsTimeoutError(err) {
-					telemetry.BootstrapFailure(time.Since(start))
-					return nil, fmt.Errorf("network connectivity error, check your network: %w", err)
-				}
-				telemetry.BootstrapFailure(time.Since(start))
-				return nil, fmt.Errorf("could not determine latest version: %w", err)
-			} else {
-				version = releaseInfo.Version
-			}
+		if url, err := defaultManifestUrl(options); err != nil {
+			telemetry.BootstrapFailure(time.Since(start))
+			return nil, err
+		} else {
+			options.Url = url
 		}
-		options.Url = fmt.Sprintf("https://github.com/glasskube/glasskube/releases/download/v%v/manifest-%v.yaml",
-			version, options.Type)
 	}
 
 	if !options.NoProgress {
@@ -171,6 +162,24 @@ func (c *BootstrapClient) Bootstrap(
 	return manifests, nil
 }
 
+// defaultManifestUrl returns the URL of the release manifest of the given type for the version of this binary or, if
+// options.Latest is set, for the latest release
+func defaultManifestUrl(options BootstrapOptions) (string, error) {
+	version := config.Version
+	if options.Latest {
+		if releaseInfo, err := releaseinfo.FetchLatestRelease(); err != nil {
+			if httperror.Is(err, http.StatusServiceUnavailable) || httperror.IsTimeoutError(err) {
+				return "", fmt.Errorf("network connectivity error, check your network: %w", err)
+			}
+			return "", fmt.Errorf("could not determine latest version: %w", err)
+		} else {
+			version = releaseInfo.Version
+		}
+	}
+	return fmt.Sprintf("https://github.com/glasskube/glasskube/releases/download/v%v/manifest-%v.yaml",
+		version, options.Type), nil
+}
+
 func (c *BootstrapClient) preprocessManifests(
 	ctx context.Context,
 	objs []unstructured.Unstructured,
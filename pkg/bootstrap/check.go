@@ -0,0 +1,249 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/glasskube/glasskube/internal/clientutils"
+	"github.com/glasskube/glasskube/internal/constants"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+)
+
+// CheckProblem is a difference between the bootstrapped resources in the cluster and the manifests of a release
+type CheckProblem struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Message   string `json:"message"`
+	// Warning is true for problems that do not necessarily break the installation
+	Warning bool `json:"warning,omitempty"`
+	// repairable is true if applying the manifest of the resource again is expected to fix the problem
+	repairable bool
+}
+
+func (p CheckProblem) String() string {
+	if p.Namespace != "" {
+		return fmt.Sprintf("%v %v/%v: %v", p.Kind, p.Namespace, p.Name, p.Message)
+	}
+	return fmt.Sprintf("%v %v: %v", p.Kind, p.Name, p.Message)
+}
+
+// CheckResult contains all problems found by BootstrapClient.Check
+type CheckResult struct {
+	Url      string         `json:"url"`
+	Problems []CheckProblem `json:"problems"`
+	// manifests are the checked manifests, which are applied again by BootstrapClient.Repair
+	manifests []unstructured.Unstructured
+}
+
+// IsHealthy returns true if no problems other than warnings have been found
+func (r *CheckResult) IsHealthy() bool {
+	return !slices.ContainsFunc(r.Problems, func(p CheckProblem) bool { return !p.Warning })
+}
+
+// IsRepairable returns true if any problem can be fixed with BootstrapClient.Repair
+func (r *CheckResult) IsRepairable() bool {
+	return slices.ContainsFunc(r.Problems, func(p CheckProblem) bool { return p.repairable })
+}
+
+func (r *CheckResult) add(obj *unstructured.Unstructured, warning, repairable bool, format string, a ...any) {
+	r.Problems = append(r.Problems, CheckProblem{
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		Message:    fmt.Sprintf(format, a...),
+		Warning:    warning,
+		repairable: repairable,
+	})
+}
+
+// Check compares the resources in the cluster with the manifests that Bootstrap would apply with the same options.
+// It reports missing resources, resources that have been modified since they were applied, CRDs whose versions differ
+// from the expected ones and controllers that are not ready. Nothing is changed in the cluster.
+func (c *BootstrapClient) Check(ctx context.Context, options BootstrapOptions) (*CheckResult, error) {
+	if err := c.InitRestMapper(); err != nil {
+		return nil, err
+	}
+	if client, err := dynamic.NewForConfig(c.clientConfig); err != nil {
+		return nil, err
+	} else {
+		c.Client = client
+	}
+
+	if options.Url == "" {
+		if url, err := defaultManifestUrl(options); err != nil {
+			return nil, err
+		} else {
+			options.Url = url
+		}
+	}
+	manifests, err := clientutils.FetchResourcesFromUrl(options.Url)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch Glasskube manifests: %w", err)
+	}
+	if options.CreateDefaultRepository {
+		manifests = append(manifests, defaultRepository())
+	}
+
+	result := CheckResult{Url: options.Url, manifests: manifests}
+	for i := range manifests {
+		if err := c.checkObject(ctx, &manifests[i], &result); err != nil {
+			return nil, fmt.Errorf("could not check %v %v: %w", manifests[i].GetKind(), manifests[i].GetName(), err)
+		}
+	}
+	return &result, nil
+}
+
+func (c *BootstrapClient) checkObject(
+	ctx context.Context,
+	obj *unstructured.Unstructured,
+	result *CheckResult,
+) error {
+	if obj.GetKind() == constants.Job {
+		// jobs only run once during the bootstrap and may have been cleaned up since
+		return nil
+	}
+	gvk := obj.GroupVersionKind()
+	mapping, err := c.Mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		result.add(obj, false, true, "resource type %v is not installed", gvk.GroupVersion().WithKind(gvk.Kind))
+		return nil
+	} else if err != nil {
+		return err
+	}
+	resource := c.Client.Resource(mapping.Resource).Namespace(obj.GetNamespace())
+	existing, err := resource.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		result.add(obj, false, true, "is missing")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	switch obj.GetKind() {
+	case "PackageRepository":
+		// the default repository may be changed by users, e.g. to add authentication
+		return nil
+	case "CustomResourceDefinition":
+		if mismatch, err := checkCRDVersions(obj, existing); err != nil {
+			return err
+		} else if mismatch != "" {
+			result.add(obj, true, true, "%v", mismatch)
+			return nil
+		}
+	case constants.Deployment:
+		if ready, err := isDeploymentReady(existing); err != nil {
+			return err
+		} else if !ready {
+			result.add(obj, false, false, "is not ready")
+		}
+	}
+
+	// a server-side dry-run shows what applying the manifest would change without changing anything
+	applied, err := resource.Apply(ctx, obj.GetName(), obj, getApplyOptions(BootstrapOptions{DryRun: true}))
+	if err != nil {
+		return err
+	}
+	if !equality.Semantic.DeepEqual(withoutMetadataAndStatus(existing), withoutMetadataAndStatus(applied)) {
+		result.add(obj, false, true, "has been modified and differs from the manifest")
+	}
+	return nil
+}
+
+// checkCRDVersions returns a description of the difference if the served versions or the storage version of the
+// existing CRD differ from the expected ones
+func checkCRDVersions(expectedObj, existingObj *unstructured.Unstructured) (string, error) {
+	var expected, existing apiextensionsv1.CustomResourceDefinition
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(expectedObj.Object, &expected); err != nil {
+		return "", err
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(existingObj.Object, &existing); err != nil {
+		return "", err
+	}
+	expectedServed, expectedStorage := crdVersions(&expected)
+	existingServed, existingStorage := crdVersions(&existing)
+	if !slices.Equal(expectedServed, existingServed) {
+		return fmt.Sprintf("serves versions %v, but this version of glasskube expects %v", existingServed,
+			expectedServed), nil
+	} else if expectedStorage != existingStorage {
+		return fmt.Sprintf("stores version %v, but this version of glasskube expects %v", existingStorage,
+			expectedStorage), nil
+	}
+	return "", nil
+}
+
+func crdVersions(crd *apiextensionsv1.CustomResourceDefinition) (served []string, storage string) {
+	for _, version := range crd.Spec.Versions {
+		if version.Served {
+			served = append(served, version.Name)
+		}
+		if version.Storage {
+			storage = version.Name
+		}
+	}
+	slices.Sort(served)
+	return
+}
+
+func isDeploymentReady(obj *unstructured.Unstructured) (bool, error) {
+	replicas, _, err := unstructured.NestedInt64(obj.Object, "status", "replicas")
+	if err != nil {
+		return false, err
+	}
+	available, _, err := unstructured.NestedInt64(obj.Object, "status", "availableReplicas")
+	if err != nil {
+		return false, err
+	}
+	return replicas > 0 && available == replicas, nil
+}
+
+func withoutMetadataAndStatus(obj *unstructured.Unstructured) map[string]any {
+	result := obj.DeepCopy().Object
+	delete(result, "metadata")
+	delete(result, "status")
+	return result
+}
+
+// Repair applies the manifests of all resources with repairable problems again, in the order of the release
+// manifest. All jobs of the manifest are run again as well, because recreated resources may depend on them, e.g. on
+// the generated webhook certificates.
+func (c *BootstrapClient) Repair(
+	ctx context.Context,
+	result *CheckResult,
+	options BootstrapOptions,
+) ([]unstructured.Unstructured, error) {
+	if !result.IsRepairable() {
+		return nil, errors.New("none of the problems can be repaired")
+	}
+	var manifests []unstructured.Unstructured
+	for _, obj := range result.manifests {
+		if obj.GetKind() == constants.Job || slices.ContainsFunc(result.Problems, func(p CheckProblem) bool {
+			return p.repairable && p.Kind == obj.GetKind() && p.Namespace == obj.GetNamespace() &&
+				p.Name == obj.GetName()
+		}) {
+			manifests = append(manifests, obj)
+		}
+	}
+
+	// the annotations of the existing namespace must be kept, e.g. the telemetry settings. Resources that have been
+	// modified with kubectl are reported, but repaired anyway, because that is what the repair is for.
+	if err := c.preprocessManifests(ctx, manifests, &options); err != nil {
+		statusMessage(fmt.Sprintf("Repairing anyways: %v", err), true, false)
+	}
+	statusMessage(fmt.Sprintf("Applying %v Glasskube manifests", len(manifests)), true, options.NoProgress)
+	if err := c.applyManifests(ctx, manifests, options); err != nil {
+		statusMessage(fmt.Sprintf("Couldn't apply manifests: %v", err), false, false)
+		return nil, err
+	}
+	statusMessage("Glasskube successfully repaired!", true, options.NoProgress)
+	return manifests, nil
+}
@@ -8,9 +8,14 @@ import (
 
 type packageBuilder struct {
 	manifestName, version, repositoryName string
+	digest                                string
 	namespace, name                       string
 	autoUpdate                            bool
+	versionConstraint                     string
 	values                                map[string]v1alpha1.ValueConfiguration
+	imageRegistryMirrors                  []v1alpha1.ImageRegistryMirror
+	scheduling                            *v1alpha1.SchedulingOverrides
+	optionalDependencies                  []string
 }
 
 func PackageBuilder(name string) *packageBuilder {
@@ -25,11 +30,21 @@ func (b *packageBuilder) WithVersion(version string) *packageBuilder {
 	return b
 }
 
+func (b *packageBuilder) WithDigest(digest string) *packageBuilder {
+	b.digest = digest
+	return b
+}
+
 func (b *packageBuilder) WithAutoUpdates(enabled bool) *packageBuilder {
 	b.autoUpdate = enabled
 	return b
 }
 
+func (b *packageBuilder) WithVersionConstraint(constraint string) *packageBuilder {
+	b.versionConstraint = constraint
+	return b
+}
+
 func (b *packageBuilder) WithRepositoryName(repositoryName string) *packageBuilder {
 	b.repositoryName = repositoryName
 	return b
@@ -52,6 +67,21 @@ func (b *packageBuilder) WithValues(values map[string]v1alpha1.ValueConfiguratio
 	return b
 }
 
+func (b *packageBuilder) WithImageRegistryMirrors(mirrors []v1alpha1.ImageRegistryMirror) *packageBuilder {
+	b.imageRegistryMirrors = mirrors
+	return b
+}
+
+func (b *packageBuilder) WithScheduling(scheduling *v1alpha1.SchedulingOverrides) *packageBuilder {
+	b.scheduling = scheduling
+	return b
+}
+
+func (b *packageBuilder) WithOptionalDependencies(names []string) *packageBuilder {
+	b.optionalDependencies = names
+	return b
+}
+
 func (b *packageBuilder) BuildClusterPackage() *v1alpha1.ClusterPackage {
 	pkg := v1alpha1.ClusterPackage{
 		ObjectMeta: metav1.ObjectMeta{
@@ -62,11 +92,16 @@ func (b *packageBuilder) BuildClusterPackage() *v1alpha1.ClusterPackage {
 				Name:           b.manifestName,
 				Version:        b.version,
 				RepositoryName: b.repositoryName,
+				Digest:         b.digest,
 			},
-			Values: b.values,
+			Values:               b.values,
+			ImageRegistryMirrors: b.imageRegistryMirrors,
+			OptionalDependencies: b.optionalDependencies,
+			Scheduling:           b.scheduling,
 		},
 	}
 	pkg.SetAutoUpdatesEnabled(b.autoUpdate)
+	pkg.SetVersionConstraint(b.versionConstraint)
 	return &pkg
 }
 
@@ -81,11 +116,16 @@ func (b *packageBuilder) BuildPackage() *v1alpha1.Package {
 				Name:           b.manifestName,
 				Version:        b.version,
 				RepositoryName: b.repositoryName,
+				Digest:         b.digest,
 			},
-			Values: b.values,
+			Values:               b.values,
+			ImageRegistryMirrors: b.imageRegistryMirrors,
+			OptionalDependencies: b.optionalDependencies,
+			Scheduling:           b.scheduling,
 		},
 	}
 	pkg.SetAutoUpdatesEnabled(b.autoUpdate)
+	pkg.SetVersionConstraint(b.versionConstraint)
 	return &pkg
 }
 
@@ -33,6 +33,10 @@ func (c *baseClientset) PackageRepositories() PackageRepositoryInterface {
 	return &packageRepositoryClient{restClient: c.restClient}
 }
 
+func (c *baseClientset) PackageProfiles() PackageProfileInterface {
+	return &packageProfileClient{restClient: c.restClient}
+}
+
 func (c *baseClientset) WithStores(
 	clusterPackageStore cache.Store,
 	packageStore cache.Store,
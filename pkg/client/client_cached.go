@@ -9,6 +9,7 @@ import (
 	"github.com/glasskube/glasskube/api/v1alpha1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/tools/cache"
 )
@@ -155,6 +156,16 @@ func (c *readWriteCacheClient[T, L]) Update(ctx context.Context, target *T, opts
 	return c.fallback.Update(ctx, target, opts)
 }
 
+func (c *readWriteCacheClient[T, L]) Patch(
+	ctx context.Context,
+	target *T,
+	pt types.PatchType,
+	data []byte,
+	opts metav1.PatchOptions,
+) error {
+	return c.fallback.Patch(ctx, target, pt, data, opts)
+}
+
 func (c *readWriteCacheClient[T, L]) Delete(ctx context.Context, target *T, options metav1.DeleteOptions) error {
 	return c.fallback.Delete(ctx, target, options)
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/glasskube/glasskube/api/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
@@ -40,6 +41,22 @@ func (c *clusterPackageClient) Update(
 		Into(p)
 }
 
+// Patch implements PackageInterface.
+func (c *clusterPackageClient) Patch(
+	ctx context.Context,
+	p *v1alpha1.ClusterPackage,
+	pt types.PatchType,
+	data []byte,
+	opts metav1.PatchOptions) error {
+	return c.restClient.Patch(pt).
+		Resource(clusterPackageGVR.Resource).
+		Name(p.GetName()).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(p)
+}
+
 func (c *clusterPackageClient) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
 	var timeout time.Duration
 	if opts.TimeoutSeconds != nil {
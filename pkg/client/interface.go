@@ -5,6 +5,7 @@ import (
 
 	"github.com/glasskube/glasskube/api/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/tools/cache"
 )
@@ -14,6 +15,7 @@ type PackageV1Alpha1Client interface {
 	Packages(namespace string) PackageInterface
 	PackageInfos() PackageInfoInterface
 	PackageRepositories() PackageRepositoryInterface
+	PackageProfiles() PackageProfileInterface
 	WithStores(
 		clusterPackageStore cache.Store,
 		packageStore cache.Store,
@@ -38,6 +40,10 @@ type PackageRepositoryInterface interface {
 	readWriteClientInterface[v1alpha1.PackageRepository, v1alpha1.PackageRepositoryList]
 }
 
+type PackageProfileInterface interface {
+	readWriteClientInterface[v1alpha1.PackageProfile, v1alpha1.PackageProfileList]
+}
+
 type readOnlyClientInterface[T any, L any] interface {
 	Get(ctx context.Context, name string, target *T) error
 	GetAll(ctx context.Context, target *L) error
@@ -48,5 +54,8 @@ type readWriteClientInterface[T any, L any] interface {
 	readOnlyClientInterface[T, L]
 	Create(ctx context.Context, target *T, opts metav1.CreateOptions) error
 	Update(ctx context.Context, target *T, opts metav1.UpdateOptions) error
+	// Patch applies the patch data of the given type to the object with the name of target and stores the result in
+	// target
+	Patch(ctx context.Context, target *T, pt types.PatchType, data []byte, opts metav1.PatchOptions) error
 	Delete(ctx context.Context, target *T, opts metav1.DeleteOptions) error
 }
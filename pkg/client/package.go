@@ -7,6 +7,7 @@ import (
 	"github.com/glasskube/glasskube/api/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
@@ -67,6 +68,24 @@ func (p *packageClient) Update(ctx context.Context, target *v1alpha1.Package, op
 		Into(target)
 }
 
+// Patch implements PackageInterface.
+func (p *packageClient) Patch(
+	ctx context.Context,
+	target *v1alpha1.Package,
+	pt types.PatchType,
+	data []byte,
+	opts v1.PatchOptions,
+) error {
+	return p.restClient.Patch(pt).
+		Namespace(p.ns).
+		Resource(packagesResource).
+		Name(target.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(target)
+}
+
 // Watch implements PackageInterface.
 func (p *packageClient) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
 	var timeout time.Duration
@@ -57,3 +57,13 @@ func NewPendingStatus() *PackageStatus {
 func NewUninstallingStatus() *PackageStatus {
 	return &PackageStatus{Status: "Uninstalling"}
 }
+
+// GetUnhealthyStatus returns the status of the Healthy condition if the workloads of the package are unhealthy.
+// Otherwise, nil is returned.
+func GetUnhealthyStatus(status *v1alpha1.PackageStatus) *PackageStatus {
+	if cnd := meta.FindStatusCondition(status.Conditions, string(condition.Healthy)); cnd != nil &&
+		cnd.Status == metav1.ConditionFalse {
+		return newPackageStatus(cnd)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+//nolint:dupl // It might be possible to refactor this using generics but for now we accept the dupliate code.
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+)
+
+var packageProfileGVR = v1alpha1.GroupVersion.WithResource("packageprofiles")
+
+type packageProfileClient struct {
+	restClient rest.Interface
+}
+
+// Create implements PackageProfileInterface.
+func (c *packageProfileClient) Create(
+	ctx context.Context,
+	obj *v1alpha1.PackageProfile,
+	opts metav1.CreateOptions,
+) error {
+	return c.restClient.Post().
+		Resource(packageProfileGVR.Resource).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(obj).Do(ctx).Into(obj)
+}
+
+// Update implements PackageProfileInterface.
+func (c *packageProfileClient) Update(
+	ctx context.Context,
+	obj *v1alpha1.PackageProfile,
+	opts metav1.UpdateOptions) error {
+	return c.restClient.Put().
+		Resource(packageProfileGVR.Resource).
+		Name(obj.GetName()).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(obj).
+		Do(ctx).
+		Into(obj)
+}
+
+// Patch implements PackageProfileInterface.
+func (c *packageProfileClient) Patch(
+	ctx context.Context,
+	obj *v1alpha1.PackageProfile,
+	pt types.PatchType,
+	data []byte,
+	opts metav1.PatchOptions) error {
+	return c.restClient.Patch(pt).
+		Resource(packageProfileGVR.Resource).
+		Name(obj.GetName()).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(obj)
+}
+
+// Watch implements PackageProfileInterface.
+func (c *packageProfileClient) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.restClient.Get().
+		Resource(packageProfileGVR.Resource).
+		Timeout(timeout).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch(ctx)
+}
+
+// Get implements PackageProfileInterface.
+func (c *packageProfileClient) Get(ctx context.Context, name string, obj *v1alpha1.PackageProfile) error {
+	return c.restClient.Get().
+		Resource(packageProfileGVR.Resource).
+		Name(name).
+		Do(ctx).Into(obj)
+}
+
+// GetAll implements PackageProfileInterface.
+func (c *packageProfileClient) GetAll(ctx context.Context, result *v1alpha1.PackageProfileList) error {
+	return c.restClient.Get().
+		Resource(packageProfileGVR.Resource).
+		Do(ctx).Into(result)
+}
+
+// Delete implements PackageProfileInterface.
+func (c *packageProfileClient) Delete(
+	ctx context.Context, obj *v1alpha1.PackageProfile, options metav1.DeleteOptions) error {
+	return c.restClient.Delete().
+		Resource(packageProfileGVR.Resource).
+		Name(obj.Name).
+		Body(&options).
+		Do(ctx).Into(nil)
+}
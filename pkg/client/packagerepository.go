@@ -7,6 +7,7 @@ import (
 
 	"github.com/glasskube/glasskube/api/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
@@ -44,6 +45,22 @@ func (c *packageRepositoryClient) Update(
 		Into(obj)
 }
 
+// Patch implements PackageRepositoryInterface.
+func (c *packageRepositoryClient) Patch(
+	ctx context.Context,
+	obj *v1alpha1.PackageRepository,
+	pt types.PatchType,
+	data []byte,
+	opts metav1.PatchOptions) error {
+	return c.restClient.Patch(pt).
+		Resource(packageRepositoryGVR.Resource).
+		Name(obj.GetName()).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(obj)
+}
+
 // Watch implements PackageRepositoryInterface.
 func (c *packageRepositoryClient) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
 	var timeout time.Duration
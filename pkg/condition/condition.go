@@ -8,16 +8,25 @@ type (
 const (
 	Ready  Type = "Ready"
 	Failed Type = "Failed"
+	// Healthy reports whether the workloads of an installed package are healthy. In contrast to Ready, which reports
+	// the result of the last reconciliation, it is also updated when a workload fails after the installation.
+	Healthy Type = "Healthy"
 )
 
 const (
 	SyncCompleted             Reason = "SyncCompleted"
 	SyncFailed                Reason = "SyncFailed"
+	SyncRetrying              Reason = "SyncRetrying"
+	TLSVerificationFailed     Reason = "TLSVerificationFailed"
+	ProxyConnectionFailed     Reason = "ProxyConnectionFailed"
 	Reconciling               Reason = "Reconciling"
 	UpToDate                  Reason = "UpToDate"
 	UnsupportedFormat         Reason = "UnsupportedFormat"
 	ValueConfigurationInvalid Reason = "ValueConfigurationInvalid"
 	InstallationSucceeded     Reason = "InstallationSucceeded"
 	InstallationFailed        Reason = "InstallationFailed"
+	HookFailed                Reason = "HookFailed"
 	Pending                   Reason = "Pending"
+	WorkloadsHealthy          Reason = "WorkloadsHealthy"
+	WorkloadsUnhealthy        Reason = "WorkloadsUnhealthy"
 )
@@ -0,0 +1,200 @@
+package describe
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/cliutils"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/workloads"
+	"github.com/glasskube/glasskube/pkg/client"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// HealthHealthy is the health of workloads that are available
+	HealthHealthy = "Healthy"
+	// HealthUnhealthy is the health of workloads that are not available, see workloads.Problem
+	HealthUnhealthy = "Unhealthy"
+	// HealthFailed is the health of resources that could not be applied in the last reconciliation
+	HealthFailed = "Failed"
+	// HealthMissing is the health of owned resources that do not exist (anymore)
+	HealthMissing = "Missing"
+	// HealthApplied is the health of all other resources, whose health can not be determined
+	HealthApplied = "Applied"
+)
+
+// ResourceStatus is the health of a resource that is owned by a package
+type ResourceStatus struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	Health     string `json:"health"`
+	Message    string `json:"message,omitempty"`
+	// uid is only set for workloads, whose events are included by DescribeEvents
+	uid types.UID
+}
+
+// DependencyStatus is a dependency of a package manifest together with the package that satisfies it
+type DependencyStatus struct {
+	Name     string `json:"name"`
+	Version  string `json:"version,omitempty"`
+	Optional bool   `json:"optional,omitempty"`
+	// Enabled is false for optional dependencies that are not enabled for the package
+	Enabled          bool   `json:"enabled"`
+	InstalledVersion string `json:"installedVersion,omitempty"`
+	// Status is the status of the installed dependency, or empty if it is not installed
+	Status string `json:"status,omitempty"`
+}
+
+// Event is a Kubernetes event that concerns a package or one of its workloads
+type Event struct {
+	Type     string    `json:"type"`
+	Reason   string    `json:"reason"`
+	Object   string    `json:"object"`
+	Message  string    `json:"message"`
+	Count    int32     `json:"count"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+// DescribeDependencies returns the status of all dependencies of the given manifest. Dependencies are installed as
+// ClusterPackages, so the first ClusterPackage of a dependency is used. If the ClusterPackages can not be listed, the
+// dependencies are returned without their status together with the error.
+func DescribeDependencies(ctx context.Context, pkg ctrlpkg.Package, manifest *v1alpha1.PackageManifest) (
+	[]DependencyStatus, error) {
+	var clpkgs v1alpha1.ClusterPackageList
+	err := cliutils.PackageClient(ctx).ClusterPackages().GetAll(ctx, &clpkgs)
+	result := make([]DependencyStatus, 0, len(manifest.Dependencies))
+	for _, dep := range manifest.Dependencies {
+		status := DependencyStatus{
+			Name:     dep.Name,
+			Version:  dep.Version,
+			Optional: dep.Optional,
+			Enabled:  !dep.Optional || (!pkg.IsNil() && slices.Contains(pkg.GetSpec().OptionalDependencies, dep.Name)),
+		}
+		for i := range clpkgs.Items {
+			if clpkgs.Items[i].Spec.PackageInfo.Name == dep.Name {
+				status.InstalledVersion = clpkgs.Items[i].Status.Version
+				if pkgStatus := client.GetStatusOrPending(&clpkgs.Items[i]); pkgStatus != nil {
+					status.Status = pkgStatus.Status
+				}
+				break
+			}
+		}
+		result = append(result, status)
+	}
+	return result, err
+}
+
+// DescribeResources returns the health of all resources owned by pkg. Resources that could not be applied are
+// included as well, even if they are not owned by the package yet. Only the health of Deployments, StatefulSets and
+// DaemonSets is checked, all other resources are reported as applied.
+func DescribeResources(ctx context.Context, pkg ctrlpkg.Package) ([]ResourceStatus, error) {
+	var result []ResourceStatus
+	for _, ref := range pkg.GetStatus().FailedResources {
+		result = append(result, ResourceStatus{
+			APIVersion: schema.GroupVersion{Group: ref.Group, Version: ref.Version}.String(),
+			Kind:       ref.Kind,
+			Namespace:  ref.Namespace,
+			Name:       ref.Name,
+			Health:     HealthFailed,
+			Message:    ref.Message,
+		})
+	}
+	for _, ref := range pkg.GetStatus().OwnedResources {
+		if slices.ContainsFunc(result, func(s ResourceStatus) bool {
+			return s.Kind == ref.Kind && s.Namespace == ref.Namespace && s.Name == ref.Name
+		}) {
+			continue
+		}
+		status := ResourceStatus{
+			APIVersion: schema.GroupVersion{Group: ref.Group, Version: ref.Version}.String(),
+			Kind:       ref.Kind,
+			Namespace:  ref.Namespace,
+			Name:       ref.Name,
+			Health:     HealthApplied,
+		}
+		if ref.Group == appsv1.GroupName {
+			if obj, err := getWorkload(ctx, ref); errors.IsNotFound(err) {
+				status.Health = HealthMissing
+			} else if err != nil {
+				return nil, err
+			} else if obj != nil {
+				status.uid = obj.GetUID()
+				if problem := workloads.Problem(obj); problem != "" {
+					status.Health = HealthUnhealthy
+					status.Message = problem
+				} else {
+					status.Health = HealthHealthy
+				}
+			}
+		}
+		result = append(result, status)
+	}
+	return result, nil
+}
+
+// getWorkload returns the workload with the given reference, or nil if the reference is not a workload
+func getWorkload(ctx context.Context, ref v1alpha1.OwnedResourceRef) (metav1.Object, error) {
+	apps := cliutils.KubernetesClient(ctx).AppsV1()
+	switch ref.Kind {
+	case workloads.KindDeployment:
+		return apps.Deployments(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	case workloads.KindStatefulSet:
+		return apps.StatefulSets(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	case workloads.KindDaemonSet:
+		return apps.DaemonSets(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	default:
+		return nil, nil
+	}
+}
+
+// DescribeEvents returns the most recent events of pkg and the given workloads of pkg (see DescribeResources),
+// newest first. At most limit events are returned.
+func DescribeEvents(ctx context.Context, pkg ctrlpkg.Package, resources []ResourceStatus, limit int) (
+	[]Event, error) {
+	uids := []types.UID{pkg.GetUID()}
+	for _, resource := range resources {
+		if resource.uid != "" {
+			uids = append(uids, resource.uid)
+		}
+	}
+	var result []Event
+	events := cliutils.KubernetesClient(ctx).CoreV1().Events("")
+	for _, uid := range uids {
+		list, err := events.List(ctx, metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("involvedObject.uid", string(uid)).String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range list.Items {
+			lastSeen := event.LastTimestamp.Time
+			if lastSeen.IsZero() {
+				lastSeen = event.EventTime.Time
+			}
+			result = append(result, Event{
+				Type:     event.Type,
+				Reason:   event.Reason,
+				Object:   event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name,
+				Message:  event.Message,
+				Count:    max(event.Count, 1),
+				LastSeen: lastSeen,
+			})
+		}
+	}
+	slices.SortStableFunc(result, func(a, b Event) int { return -cmp.Compare(a.LastSeen.Unix(), b.LastSeen.Unix()) })
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
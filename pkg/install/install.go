@@ -7,6 +7,8 @@ import (
 
 	"github.com/glasskube/glasskube/api/v1alpha1"
 	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	deputil "github.com/glasskube/glasskube/internal/dependency/util"
+	"github.com/glasskube/glasskube/internal/tracing"
 	"github.com/glasskube/glasskube/pkg/client"
 	"github.com/glasskube/glasskube/pkg/condition"
 	"github.com/glasskube/glasskube/pkg/statuswriter"
@@ -15,8 +17,9 @@ import (
 )
 
 type installer struct {
-	client client.PackageV1Alpha1Client
-	status statuswriter.StatusWriter
+	client   client.PackageV1Alpha1Client
+	status   statuswriter.StatusWriter
+	manifest *v1alpha1.PackageManifest
 }
 
 func NewInstaller(pkgClient client.PackageV1Alpha1Client) *installer {
@@ -28,6 +31,13 @@ func (obj *installer) WithStatusWriter(sw statuswriter.StatusWriter) *installer
 	return obj
 }
 
+// WithComponents makes InstallBlocking report the status of the dependencies and components that are defined in the
+// given manifest, in addition to the status of the package itself, if the StatusWriter supports it.
+func (obj *installer) WithComponents(manifest *v1alpha1.PackageManifest) *installer {
+	obj.manifest = manifest
+	return obj
+}
+
 // InstallBlocking creates a new v1alpha1.Package custom resource in the cluster and waits until
 // the package has either status Ready or Failed.
 func (obj *installer) InstallBlocking(
@@ -35,6 +45,9 @@ func (obj *installer) InstallBlocking(
 ) (*client.PackageStatus, error) {
 	obj.status.Start()
 	defer obj.status.Stop()
+	ctx, span := tracing.Start(ctx, "install", tracing.PackageAttributes(pkg,
+		pkg.GetSpec().PackageInfo.Name, pkg.GetSpec().PackageInfo.Version)...)
+	defer span.End()
 	pkg, err := obj.install(ctx, pkg, opts)
 	if err != nil {
 		return nil, err
@@ -55,7 +68,10 @@ func (obj *installer) Install(
 ) error {
 	obj.status.Start()
 	defer obj.status.Stop()
+	ctx, span := tracing.Start(ctx, "install", tracing.PackageAttributes(pkg,
+		pkg.GetSpec().PackageInfo.Name, pkg.GetSpec().PackageInfo.Version)...)
 	_, err := obj.install(ctx, pkg, opts)
+	tracing.End(span, err)
 	return err
 }
 
@@ -65,6 +81,7 @@ func (obj *installer) install(
 	opts metav1.CreateOptions,
 ) (ctrlpkg.Package, error) {
 	obj.status.SetStatus(fmt.Sprintf("Installing %v...", pkg.GetName()))
+	tracing.Inject(ctx, pkg)
 	switch pkg := pkg.(type) {
 	case *v1alpha1.ClusterPackage:
 		return pkg, obj.client.ClusterPackages().Create(ctx, pkg, opts)
@@ -75,16 +92,39 @@ func (obj *installer) install(
 	}
 }
 
-func (obj *installer) awaitInstall(ctx context.Context, pkg ctrlpkg.Package) (*client.PackageStatus, error) {
-	watcher, err := obj.watch(ctx, pkg)
+func (obj *installer) awaitInstall(ctx context.Context, pkg ctrlpkg.Package) (_ *client.PackageStatus, err error) {
+	ctx, span := tracing.Start(ctx, "readiness wait")
+	defer func() { tracing.End(span, err) }()
+	cmpWriter, _ := obj.status.(statuswriter.ComponentStatusWriter)
+	var components []ctrlpkg.Package
+	if cmpWriter != nil {
+		components = append(obj.getComponents(pkg), pkg)
+		for _, cmp := range components {
+			cmpWriter.SetComponentStatus(componentName(cmp), statuswriter.ComponentPending, "")
+		}
+	}
+
+	watcher, err := obj.watchAll(ctx, append(components, pkg))
 	if err != nil {
 		return nil, err
 	}
 	defer watcher.Stop()
 	for event := range watcher.ResultChan() {
-		if obj, ok := event.Object.(ctrlpkg.Package); ok && ctrlpkg.IsSameResource(obj, pkg) {
+		eventPkg, ok := event.Object.(ctrlpkg.Package)
+		if !ok {
+			continue
+		}
+		if cmpWriter != nil && (event.Type == watch.Added || event.Type == watch.Modified) {
+			for _, cmp := range components {
+				if ctrlpkg.IsSameResource(eventPkg, cmp) {
+					state, message := componentState(eventPkg)
+					cmpWriter.SetComponentStatus(componentName(cmp), state, message)
+				}
+			}
+		}
+		if ctrlpkg.IsSameResource(eventPkg, pkg) {
 			if event.Type == watch.Added || event.Type == watch.Modified {
-				if status := client.GetStatus(obj.GetStatus()); status != nil {
+				if status := client.GetStatus(eventPkg.GetStatus()); status != nil {
 					return status, nil
 				}
 			} else if event.Type == watch.Deleted {
@@ -95,6 +135,48 @@ func (obj *installer) awaitInstall(ctx context.Context, pkg ctrlpkg.Package) (*c
 	return nil, errors.New("failed to confirm package installation status")
 }
 
+// getComponents returns the dependencies and components of the given package, as they are created by the operator
+func (obj *installer) getComponents(pkg ctrlpkg.Package) []ctrlpkg.Package {
+	if obj.manifest == nil {
+		return nil
+	}
+	var result []ctrlpkg.Package
+	for _, dep := range deputil.EnabledDependencies(obj.manifest, pkg.GetSpec().OptionalDependencies) {
+		result = append(result, &v1alpha1.ClusterPackage{ObjectMeta: metav1.ObjectMeta{Name: dep.Name}})
+	}
+	for _, cmp := range obj.manifest.Components {
+		namespace := pkg.GetNamespace()
+		if namespace == "" {
+			namespace = obj.manifest.DefaultNamespace
+		}
+		result = append(result, &v1alpha1.Package{
+			ObjectMeta: metav1.ObjectMeta{Name: deputil.ComponentName(pkg.GetName(), cmp), Namespace: namespace},
+		})
+	}
+	for _, p := range result {
+		// the GVK is needed to compare the objects with the objects received from the API server
+		p.GetObjectKind().SetGroupVersionKind(v1alpha1.GroupVersion.WithKind(packageKind(p)))
+	}
+	return result
+}
+
+func componentName(pkg ctrlpkg.Package) string {
+	if pkg.IsNamespaceScoped() {
+		return fmt.Sprintf("%v/%v", pkg.GetNamespace(), pkg.GetName())
+	}
+	return pkg.GetName()
+}
+
+func componentState(pkg ctrlpkg.Package) (statuswriter.ComponentState, string) {
+	if status := client.GetStatus(pkg.GetStatus()); status == nil {
+		return statuswriter.ComponentPending, ""
+	} else if status.Status == string(condition.Ready) {
+		return statuswriter.ComponentReady, ""
+	} else {
+		return statuswriter.ComponentFailed, status.Message
+	}
+}
+
 func isDryRun(opts metav1.CreateOptions) bool {
 	for _, option := range opts.DryRun {
 		if option == metav1.DryRunAll {
@@ -104,6 +186,31 @@ func isDryRun(opts metav1.CreateOptions) bool {
 	return false
 }
 
+// watchAll watches all kinds and namespaces of the given packages with a single watch.Interface
+func (obj *installer) watchAll(ctx context.Context, pkgs []ctrlpkg.Package) (watch.Interface, error) {
+	var watchers []watch.Interface
+	seen := make(map[string]struct{})
+	for _, pkg := range pkgs {
+		key := packageKind(pkg) + "/" + pkg.GetNamespace()
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		if w, err := obj.watch(ctx, pkg); err != nil {
+			for _, w := range watchers {
+				w.Stop()
+			}
+			return nil, err
+		} else {
+			watchers = append(watchers, w)
+		}
+	}
+	if len(watchers) == 1 {
+		return watchers[0], nil
+	}
+	return newMultiWatcher(watchers), nil
+}
+
 func (i *installer) watch(ctx context.Context, pkg ctrlpkg.Package) (watch.Interface, error) {
 	switch pkg := pkg.(type) {
 	case *v1alpha1.ClusterPackage:
@@ -114,3 +221,10 @@ func (i *installer) watch(ctx context.Context, pkg ctrlpkg.Package) (watch.Inter
 		return nil, fmt.Errorf("unexpected package type: %T", pkg)
 	}
 }
+
+func packageKind(pkg ctrlpkg.Package) string {
+	if pkg.IsNamespaceScoped() {
+		return "Package"
+	}
+	return "ClusterPackage"
+}
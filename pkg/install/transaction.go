@@ -0,0 +1,170 @@
+package install
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/tracing"
+	"github.com/glasskube/glasskube/internal/util"
+	"github.com/glasskube/glasskube/pkg/client"
+	"github.com/glasskube/glasskube/pkg/condition"
+	"github.com/glasskube/glasskube/pkg/statuswriter"
+	"go.opentelemetry.io/otel/attribute"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// PackageResult is the outcome of a single package of a transaction
+type PackageResult struct {
+	Package ctrlpkg.Package
+	// Status is the last observed status of the package. It is nil, if the package has not been created or the
+	// transaction was aborted before the package reported a status.
+	Status *client.PackageStatus
+	Err    error
+}
+
+// TransactionResult is the outcome of InstallAllBlocking
+type TransactionResult struct {
+	// Packages contains one result for every package, in the order in which they have been given
+	Packages []PackageResult
+	// RolledBack is true if at least one package was created and deleted again, because the transaction failed
+	RolledBack bool
+	// RollbackErr contains the errors that occurred while deleting the created packages
+	RollbackErr error
+}
+
+// Succeeded returns true if all packages of the transaction are ready
+func (r *TransactionResult) Succeeded() bool {
+	for _, p := range r.Packages {
+		if p.Err != nil || p.Status == nil || p.Status.Status != string(condition.Ready) {
+			return false
+		}
+	}
+	return true
+}
+
+// InstallAllBlocking installs all given packages as a transaction: The packages are created in the given order and
+// afterwards, InstallAllBlocking waits until every package has a status. If any package can not be created or does
+// not become ready, all packages created by the transaction are deleted again. Dependencies that the operator created
+// for these packages are removed by the operator as well.
+//
+// An error is only returned if the transaction can not be carried out at all. Failures of individual packages are
+// reported in the result.
+func (obj *installer) InstallAllBlocking(
+	ctx context.Context, pkgs []ctrlpkg.Package, opts metav1.CreateOptions,
+) (_ *TransactionResult, err error) {
+	obj.status.Start()
+	defer obj.status.Stop()
+	ctx, span := tracing.Start(ctx, "install transaction", attribute.Int("glasskube.package.count", len(pkgs)))
+	defer func() { tracing.End(span, err) }()
+
+	result := TransactionResult{Packages: make([]PackageResult, len(pkgs))}
+	cmpWriter, _ := obj.status.(statuswriter.ComponentStatusWriter)
+	setStatus := func(i int, state statuswriter.ComponentState, message string) {
+		if cmpWriter != nil {
+			cmpWriter.SetComponentStatus(componentName(pkgs[i]), state, message)
+		}
+	}
+	for i, pkg := range pkgs {
+		result.Packages[i].Package = pkg
+		setStatus(i, statuswriter.ComponentPending, "")
+	}
+
+	// the watch is started before any package is created, so that no status change can be missed
+	var watcher watch.Interface
+	if !isDryRun(opts) {
+		if watcher, err = obj.watchAll(ctx, pkgs); err != nil {
+			return nil, err
+		}
+		defer watcher.Stop()
+	}
+
+	var created []ctrlpkg.Package
+	for i, pkg := range pkgs {
+		if _, err := obj.install(ctx, pkg, opts); err != nil {
+			result.Packages[i].Err = err
+			setStatus(i, statuswriter.ComponentFailed, err.Error())
+			obj.rollback(ctx, &result, created, opts)
+			return &result, nil
+		}
+		created = append(created, pkg)
+	}
+
+	if isDryRun(opts) {
+		for i := range result.Packages {
+			result.Packages[i].Status = &client.PackageStatus{
+				Status:  string(condition.Ready),
+				Reason:  "DryRun",
+				Message: "Dry run - package simulated as installed and ready.",
+			}
+			setStatus(i, statuswriter.ComponentReady, "")
+		}
+		return &result, nil
+	}
+
+	pending := len(pkgs)
+	for event := range watcher.ResultChan() {
+		eventPkg, ok := event.Object.(ctrlpkg.Package)
+		if !ok {
+			continue
+		}
+		for i, pkg := range pkgs {
+			if !ctrlpkg.IsSameResource(eventPkg, pkg) || result.Packages[i].Status != nil {
+				continue
+			}
+			if event.Type == watch.Deleted {
+				result.Packages[i].Err = errors.New("created package has been deleted unexpectedly")
+				setStatus(i, statuswriter.ComponentFailed, result.Packages[i].Err.Error())
+				obj.rollback(ctx, &result, created, opts)
+				return &result, nil
+			} else if status := client.GetStatus(eventPkg.GetStatus()); status != nil {
+				result.Packages[i].Status = status
+				state, message := componentState(eventPkg)
+				setStatus(i, state, message)
+				if status.Status != string(condition.Ready) {
+					obj.rollback(ctx, &result, created, opts)
+					return &result, nil
+				}
+				if pending--; pending == 0 {
+					return &result, nil
+				}
+			}
+		}
+	}
+	return nil, errors.New("failed to confirm installation status of all packages")
+}
+
+// rollback deletes the given packages in reverse order of their creation
+func (obj *installer) rollback(
+	ctx context.Context, result *TransactionResult, created []ctrlpkg.Package, opts metav1.CreateOptions,
+) {
+	if len(created) == 0 {
+		return
+	}
+	result.RolledBack = true
+	deleteOptions := metav1.DeleteOptions{
+		PropagationPolicy: util.Pointer(metav1.DeletePropagationForeground),
+		DryRun:            opts.DryRun,
+	}
+	for i := len(created) - 1; i >= 0; i-- {
+		pkg := created[i]
+		obj.status.SetStatus(fmt.Sprintf("Rolling back %v...", pkg.GetName()))
+		var err error
+		switch pkg := pkg.(type) {
+		case *v1alpha1.ClusterPackage:
+			err = obj.client.ClusterPackages().Delete(ctx, pkg, deleteOptions)
+		case *v1alpha1.Package:
+			err = obj.client.Packages(pkg.GetNamespace()).Delete(ctx, pkg, deleteOptions)
+		default:
+			err = fmt.Errorf("unexpected package type: %T", pkg)
+		}
+		if err != nil && !apierrors.IsNotFound(err) {
+			result.RollbackErr = errors.Join(result.RollbackErr, fmt.Errorf("failed to delete %v: %w",
+				componentName(pkg), err))
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package install
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// multiWatcher merges the events of multiple watchers into one result channel
+type multiWatcher struct {
+	watchers []watch.Interface
+	result   chan watch.Event
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func newMultiWatcher(watchers []watch.Interface) *multiWatcher {
+	w := multiWatcher{watchers: watchers, result: make(chan watch.Event), done: make(chan struct{})}
+	var wg sync.WaitGroup
+	for _, watcher := range watchers {
+		wg.Add(1)
+		go func(watcher watch.Interface) {
+			defer wg.Done()
+			for event := range watcher.ResultChan() {
+				select {
+				case w.result <- event:
+				case <-w.done:
+					return
+				}
+			}
+		}(watcher)
+	}
+	go func() {
+		wg.Wait()
+		close(w.result)
+	}()
+	return &w
+}
+
+// ResultChan implements watch.Interface.
+func (w *multiWatcher) ResultChan() <-chan watch.Event {
+	return w.result
+}
+
+// Stop implements watch.Interface.
+func (w *multiWatcher) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.done)
+		for _, watcher := range w.watchers {
+			watcher.Stop()
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package rollback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/cliutils"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/controller/revisions"
+	"github.com/glasskube/glasskube/internal/dependency"
+	deputil "github.com/glasskube/glasskube/internal/dependency/util"
+	repoclient "github.com/glasskube/glasskube/internal/repo/client"
+	"github.com/glasskube/glasskube/internal/semver"
+	"github.com/glasskube/glasskube/pkg/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var ErrNoPreviousRevision = errors.New("no previous revision to roll back to")
+
+type RollbackTransaction struct {
+	Package ctrlpkg.Package
+	// Revision is the revision that the package is rolled back to
+	Revision     v1alpha1.PackageRevision
+	Requirements []dependency.Requirement
+	Conflicts    dependency.Conflicts
+}
+
+type ApplyRollbackOptions struct {
+	DryRun bool
+}
+
+type rollbacker struct {
+	client     client.PackageV1Alpha1Client
+	repoClient repoclient.RepoClientset
+	dm         *dependency.DependendcyManager
+}
+
+func NewRollbacker(ctx context.Context) *rollbacker {
+	return &rollbacker{
+		client:     cliutils.PackageClient(ctx),
+		repoClient: cliutils.RepositoryClientset(ctx),
+		dm:         cliutils.DependencyManager(ctx),
+	}
+}
+
+// Prepare looks up the given revision in the history of pkg and validates the dependencies of its version against
+// the packages that are currently installed. If revision is zero, the revision before the current one is used.
+func (r *rollbacker) Prepare(ctx context.Context, pkg ctrlpkg.Package, revision int64) (*RollbackTransaction, error) {
+	var target *v1alpha1.PackageRevision
+	if revision == 0 {
+		if target = revisions.Previous(pkg.GetStatus()); target == nil {
+			return nil, ErrNoPreviousRevision
+		}
+	} else if target = revisions.Find(pkg.GetStatus(), revision); target == nil {
+		return nil, fmt.Errorf("revision %v not found in the history of %v", revision, pkg.GetName())
+	} else if current := revisions.Current(pkg.GetStatus()); current.Revision == target.Revision {
+		return nil, fmt.Errorf("revision %v is the current revision of %v", revision, pkg.GetName())
+	}
+
+	if constraint := pkg.VersionConstraint(); constraint != "" {
+		if err := semver.ValidateConstraint(target.Version, constraint); err != nil {
+			return nil, fmt.Errorf("version %v does not satisfy the version constraint %v of %v: %w",
+				target.Version, constraint, pkg.GetName(), err)
+		}
+	}
+
+	var manifest v1alpha1.PackageManifest
+	if err := r.repoClient.ForPackage(pkg).
+		FetchPackageManifest(pkg.GetSpec().PackageInfo.Name, target.Version, &manifest); err != nil {
+		return nil, fmt.Errorf("could not fetch manifest for version %v: %w", target.Version, err)
+	}
+	manifest = deputil.WithEnabledDependencies(manifest, pkg.GetSpec().OptionalDependencies)
+	result, err := r.dm.Validate(ctx, pkg.GetName(), pkg.GetNamespace(), &manifest, target.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RollbackTransaction{
+		Package:      pkg,
+		Revision:     *target.DeepCopy(),
+		Requirements: result.Requirements,
+		Conflicts:    result.Conflicts,
+	}, nil
+}
+
+// Apply sets the version and values of the package to those of the target revision. The operator then records the
+// rollback as a new revision once it was installed successfully.
+func (r *rollbacker) Apply(ctx context.Context, tx *RollbackTransaction, opts ApplyRollbackOptions) error {
+	if len(tx.Conflicts) > 0 {
+		return fmt.Errorf("rollback of %v to revision %v has conflicts: %v",
+			tx.Package.GetName(), tx.Revision.Revision, tx.Conflicts)
+	}
+
+	updateOpts := metav1.UpdateOptions{}
+	if opts.DryRun {
+		updateOpts.DryRun = []string{metav1.DryRunAll}
+	}
+	tx.Package.GetSpec().PackageInfo.SetVersion(tx.Revision.Version)
+	tx.Package.GetSpec().Values = tx.Revision.DeepCopy().Values
+	switch pkg := tx.Package.(type) {
+	case *v1alpha1.ClusterPackage:
+		return r.client.ClusterPackages().Update(ctx, pkg, updateOpts)
+	case *v1alpha1.Package:
+		return r.client.Packages(pkg.GetNamespace()).Update(ctx, pkg, updateOpts)
+	default:
+		return fmt.Errorf("unexpected object kind: %v", pkg.GroupVersionKind().Kind)
+	}
+}
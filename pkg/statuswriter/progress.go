@@ -0,0 +1,188 @@
+package statuswriter
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"golang.org/x/term"
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+type componentStatus struct {
+	name    string
+	state   ComponentState
+	message string
+}
+
+// progressStatusWriter renders the current status and the status of all components in place on a terminal. The
+// output is redrawn periodically, so that the spinners of pending components keep moving.
+type progressStatusWriter struct {
+	out        *os.File
+	mutex      sync.Mutex
+	desc       string
+	components []*componentStatus
+	frame      int
+	lines      int
+	stop       chan struct{}
+	stopped    sync.WaitGroup
+}
+
+// SetStatus implements StatusWriter.
+func (obj *progressStatusWriter) SetStatus(desc string) {
+	obj.mutex.Lock()
+	defer obj.mutex.Unlock()
+	obj.desc = desc
+}
+
+// SetComponentStatus implements ComponentStatusWriter.
+func (obj *progressStatusWriter) SetComponentStatus(name string, state ComponentState, message string) {
+	obj.mutex.Lock()
+	defer obj.mutex.Unlock()
+	for _, cmp := range obj.components {
+		if cmp.name == name {
+			cmp.state = state
+			cmp.message = message
+			return
+		}
+	}
+	obj.components = append(obj.components, &componentStatus{name: name, state: state, message: message})
+}
+
+// Start implements StatusWriter.
+func (obj *progressStatusWriter) Start() {
+	obj.stop = make(chan struct{})
+	obj.stopped.Add(1)
+	go func() {
+		defer obj.stopped.Done()
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-obj.stop:
+				return
+			case <-ticker.C:
+				obj.render(false)
+			}
+		}
+	}()
+}
+
+// Stop implements StatusWriter. If components were reported, their final status is kept on the terminal, followed by
+// a summary. Otherwise, the status line is removed.
+func (obj *progressStatusWriter) Stop() {
+	if obj.stop == nil {
+		return
+	}
+	close(obj.stop)
+	obj.stopped.Wait()
+	obj.stop = nil
+	obj.render(true)
+	// the final output is kept, so that the next Start does not overwrite it
+	obj.mutex.Lock()
+	defer obj.mutex.Unlock()
+	obj.lines = 0
+	obj.components = nil
+}
+
+func (obj *progressStatusWriter) render(final bool) {
+	obj.mutex.Lock()
+	defer obj.mutex.Unlock()
+
+	var lines []string
+	if !final {
+		lines = append(lines, fmt.Sprintf("%v %v", color.CyanString(spinnerFrames[obj.frame]), obj.desc))
+	}
+	var ready, failed int
+	for _, cmp := range obj.components {
+		var symbol string
+		switch cmp.state {
+		case ComponentReady:
+			ready++
+			symbol = color.GreenString("✔")
+		case ComponentFailed:
+			failed++
+			symbol = color.RedString("✘")
+		default:
+			symbol = color.YellowString(spinnerFrames[obj.frame])
+		}
+		line := fmt.Sprintf("  %v %v: %v", symbol, cmp.name, componentStateText(cmp.state))
+		if cmp.message != "" && cmp.state != ComponentReady {
+			line += " " + color.New(color.Faint).Sprint(cmp.message)
+		}
+		lines = append(lines, line)
+	}
+	if final && len(obj.components) > 0 {
+		lines = append(lines, fmt.Sprintf("%v of %v ready, %v failed", ready, len(obj.components), failed))
+	}
+	obj.frame = (obj.frame + 1) % len(spinnerFrames)
+
+	width, _, err := term.GetSize(int(obj.out.Fd()))
+	if err != nil {
+		width = 0
+	}
+	var sb strings.Builder
+	if obj.lines > 0 {
+		// move the cursor to the first line of the previous output
+		fmt.Fprintf(&sb, "\033[%dA", obj.lines)
+	}
+	for _, line := range lines {
+		sb.WriteString("\r\033[2K")
+		sb.WriteString(truncateLine(line, width))
+		sb.WriteString("\n")
+	}
+	// clear lines of the previous output that are not needed anymore
+	for i := len(lines); i < obj.lines; i++ {
+		sb.WriteString("\r\033[2K\n")
+	}
+	if extra := obj.lines - len(lines); extra > 0 {
+		fmt.Fprintf(&sb, "\033[%dA", extra)
+	}
+	obj.lines = len(lines)
+	_, _ = obj.out.WriteString(sb.String())
+}
+
+// truncateLine shortens the line to the given terminal width, because wrapped lines would break moving the cursor
+// back to the first line. Color escape sequences are not counted, but only kept if they are before the cut.
+func truncateLine(line string, width int) string {
+	if width <= 0 {
+		return line
+	}
+	var sb strings.Builder
+	visible := 0
+	inEscape := false
+	for _, r := range line {
+		if r == '\033' {
+			inEscape = true
+		}
+		if inEscape {
+			sb.WriteRune(r)
+			if r == 'm' {
+				inEscape = false
+			}
+			continue
+		}
+		if visible >= width-1 {
+			break
+		}
+		sb.WriteRune(r)
+		visible++
+	}
+	if !color.NoColor {
+		sb.WriteString("\033[0m")
+	}
+	return sb.String()
+}
+
+// Progress returns a StatusWriter that shows the status of all components in place, if stderr is a terminal.
+// Otherwise, every status change is written to stderr as a separate line.
+func Progress() StatusWriter {
+	if term.IsTerminal(int(os.Stderr.Fd())) {
+		return &progressStatusWriter{out: os.Stderr}
+	}
+	return Stderr()
+}
@@ -5,3 +5,18 @@ type StatusWriter interface {
 	Start()
 	Stop()
 }
+
+type ComponentState int
+
+const (
+	ComponentPending ComponentState = iota
+	ComponentReady
+	ComponentFailed
+)
+
+// ComponentStatusWriter is a StatusWriter that also shows the status of the individual components of an operation,
+// e.g. the dependencies of a package that is being installed.
+type ComponentStatusWriter interface {
+	StatusWriter
+	SetComponentStatus(name string, state ComponentState, message string)
+}
@@ -7,8 +7,9 @@ import (
 )
 
 type writerStatusWriter struct {
-	writer    io.Writer
-	autoclose bool
+	writer          io.Writer
+	autoclose       bool
+	componentStates map[string]string
 }
 
 // SetStatus implements StatusWriter.
@@ -17,6 +18,22 @@ func (obj *writerStatusWriter) SetStatus(desc string) {
 	_, _ = fmt.Fprintln(obj.writer, desc)
 }
 
+// SetComponentStatus implements ComponentStatusWriter. A line is only written if the status of the component has
+// changed.
+func (obj *writerStatusWriter) SetComponentStatus(name string, state ComponentState, message string) {
+	line := fmt.Sprintf("%v: %v", name, componentStateText(state))
+	if message != "" {
+		line += fmt.Sprintf(" (%v)", message)
+	}
+	if obj.componentStates == nil {
+		obj.componentStates = make(map[string]string)
+	} else if obj.componentStates[name] == line {
+		return
+	}
+	obj.componentStates[name] = line
+	_, _ = fmt.Fprintln(obj.writer, line)
+}
+
 // Start implements StatusWriter.
 func (*writerStatusWriter) Start() {}
 
@@ -40,3 +57,14 @@ func Stdout() *writerStatusWriter {
 func Stderr() *writerStatusWriter {
 	return Writer(os.Stderr, false)
 }
+
+func componentStateText(state ComponentState) string {
+	switch state {
+	case ComponentReady:
+		return "Ready"
+	case ComponentFailed:
+		return "Failed"
+	default:
+		return "Pending"
+	}
+}
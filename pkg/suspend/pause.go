@@ -0,0 +1,34 @@
+package suspend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+)
+
+// Pause sets the pause annotation of the package, so that the operator stops reconciling it. In contrast to Suspend,
+// the spec of the package is not changed. Pause returns false if the package was already paused.
+func Pause(ctx context.Context, pkg ctrlpkg.Package, opts ...Option) (bool, error) {
+	return setPausedAndUpdate(ctx, pkg, true, opts)
+}
+
+// Unpause removes the pause annotation of the package. It returns false if the package was not paused.
+func Unpause(ctx context.Context, pkg ctrlpkg.Package, opts ...Option) (bool, error) {
+	return setPausedAndUpdate(ctx, pkg, false, opts)
+}
+
+func setPausedAndUpdate(ctx context.Context, pkg ctrlpkg.Package, value bool, opts Options) (bool, error) {
+	if pkg.IsPaused() == value {
+		return false, nil
+	}
+	pkg.SetPaused(value)
+	if err := doUpdate(ctx, pkg, opts.Get().UpdateOptions()); err != nil {
+		action := "pause"
+		if !value {
+			action = "unpause"
+		}
+		return false, fmt.Errorf("%v failed for %v %v: %w", action, pkg.GroupVersionKind().Kind, pkg.GetName(), err)
+	}
+	return true, nil
+}
@@ -0,0 +1,43 @@
+package suspend
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+)
+
+// RetryFailed requests that the operator applies the resources of the package that failed in the last reconciliation
+// again, or runs the hook that failed again. The resources that were applied successfully are left untouched.
+// RetryFailed returns false if the package has neither failed resources nor a failed hook.
+func RetryFailed(ctx context.Context, pkg ctrlpkg.Package, opts ...Option) (bool, error) {
+	if len(pkg.GetStatus().FailedResources) == 0 && pkg.GetStatus().FailedHook() == nil {
+		return false, nil
+	}
+	pkg.RequestRetry(time.Now())
+	if err := doUpdate(ctx, pkg, Options(opts).Get().UpdateOptions()); err != nil {
+		return false, fmt.Errorf("retry failed for %v %v: %w", pkg.GroupVersionKind().Kind, pkg.GetName(), err)
+	}
+	return true, nil
+}
+
+// RecreateFailed requests that the operator deletes the failed resources of the package that can not be applied,
+// because an immutable field changed, and applies them again. Like RetryFailed, all other resources are left
+// untouched. RecreateFailed returns false if no failed resource has immutable changes.
+func RecreateFailed(ctx context.Context, pkg ctrlpkg.Package, opts ...Option) (bool, error) {
+	if !slices.ContainsFunc(pkg.GetStatus().FailedResources, func(ref v1alpha1.FailedResourceRef) bool {
+		return ref.Immutable
+	}) {
+		return false, nil
+	}
+	now := time.Now()
+	pkg.RequestRetry(now)
+	pkg.RequestRecreate(now)
+	if err := doUpdate(ctx, pkg, Options(opts).Get().UpdateOptions()); err != nil {
+		return false, fmt.Errorf("recreate failed for %v %v: %w", pkg.GroupVersionKind().Kind, pkg.GetName(), err)
+	}
+	return true, nil
+}
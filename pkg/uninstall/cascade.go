@@ -0,0 +1,118 @@
+package uninstall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/dependency/graph"
+	"github.com/glasskube/glasskube/pkg/client"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// CascadeMode determines what happens to the packages that depend on a package that is uninstalled
+type CascadeMode string
+
+const (
+	// CascadeNone refuses to uninstall a package that other packages depend on
+	CascadeNone CascadeMode = ""
+	// CascadeDependents uninstalls all packages that depend on the package as well
+	CascadeDependents CascadeMode = "dependents"
+	// CascadeOrphan keeps all packages that depend on the package, although their dependency is missing afterwards
+	CascadeOrphan CascadeMode = "orphan"
+)
+
+var ErrHasDependents = errors.New("other packages depend on it")
+
+func ParseCascadeMode(s string) (CascadeMode, error) {
+	switch mode := CascadeMode(s); mode {
+	case CascadeNone, CascadeDependents, CascadeOrphan:
+		return mode, nil
+	default:
+		return CascadeNone, fmt.Errorf("invalid cascade mode %q: must be %v or %v", s, CascadeDependents, CascadeOrphan)
+	}
+}
+
+// Impact is the effect of uninstalling a package on the other packages in the cluster
+type Impact struct {
+	// Dependents are all installed packages that depend on the package, directly or via other packages
+	Dependents []graph.PackageRef
+	// Cascaded are the dependents that are uninstalled together with the package, in the order in which they must be
+	// uninstalled
+	Cascaded []graph.PackageRef
+	// Orphaned are the dependents that stay installed, although a package they depend on is uninstalled
+	Orphaned []graph.PackageRef
+	// Pruned are dependencies that are no longer needed and are removed as well
+	Pruned []graph.PackageRef
+}
+
+// AnalyzeImpact simulates uninstalling the package with the given name in g, which is modified in the process.
+//
+// With CascadeNone, an error wrapping ErrHasDependents is returned if any installed package depends on it. With
+// CascadeOrphan, dependency errors of the orphaned packages are accepted. The impact is returned in all cases, so that
+// it can be shown to the user.
+func AnalyzeImpact(g *graph.DependencyGraph, name, namespace string, mode CascadeMode) (*Impact, error) {
+	impact := Impact{Dependents: g.DependantsTransitive(name, namespace)}
+	switch mode {
+	case CascadeDependents:
+		impact.Cascaded = g.DeleteCascading(name, namespace)
+	case CascadeOrphan:
+		impact.Orphaned = g.Dependants(name, namespace)
+		slices.SortFunc(impact.Orphaned, func(a, b graph.PackageRef) int {
+			return strings.Compare(a.String(), b.String())
+		})
+		g.Delete(name, namespace)
+	default:
+		if len(impact.Dependents) > 0 {
+			return &impact, fmt.Errorf("%v can not be uninstalled, because %w: %v",
+				graph.PackageRef{Name: name, Namespace: namespace}, ErrHasDependents, joinRefs(impact.Dependents))
+		}
+		g.Delete(name, namespace)
+	}
+	impact.Pruned = g.Prune()
+	if mode != CascadeOrphan {
+		if err := g.Validate(); err != nil {
+			return &impact, fmt.Errorf("%v can not be uninstalled: %w",
+				graph.PackageRef{Name: name, Namespace: namespace}, err)
+		}
+	}
+	return &impact, nil
+}
+
+// GetPackages fetches the given packages from the cluster. Packages that do not exist anymore are skipped.
+func GetPackages(ctx context.Context, pkgClient client.PackageV1Alpha1Client, refs []graph.PackageRef) (
+	[]ctrlpkg.Package, error) {
+	var result []ctrlpkg.Package
+	for _, ref := range refs {
+		var err error
+		var pkg ctrlpkg.Package
+		if ref.Namespace == "" {
+			var cp v1alpha1.ClusterPackage
+			err = pkgClient.ClusterPackages().Get(ctx, ref.Name, &cp)
+			pkg = &cp
+		} else {
+			var p v1alpha1.Package
+			err = pkgClient.Packages(ref.Namespace).Get(ctx, ref.Name, &p)
+			pkg = &p
+		}
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("cannot get %v: %w", ref, err)
+		}
+		result = append(result, pkg)
+	}
+	return result, nil
+}
+
+func joinRefs(refs []graph.PackageRef) string {
+	names := make([]string, len(refs))
+	for i, ref := range refs {
+		names[i] = ref.String()
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,222 @@
+package uninstall
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/glasskube/glasskube/api/v1alpha1"
+	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
+	"github.com/glasskube/glasskube/internal/workloads"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+)
+
+// RetainOptions configures which resources of a package are kept in the cluster when it is uninstalled
+type RetainOptions struct {
+	PersistentVolumeClaims bool
+	Secrets                bool
+}
+
+func (o RetainOptions) IsEmpty() bool {
+	return !o.PersistentVolumeClaims && !o.Secrets
+}
+
+// retainResources prevents the garbage collection of the PersistentVolumeClaims and Secrets of pkg, as configured in
+// the retain options. Owner references to pkg and its StatefulSets are removed and the resources are annotated with
+// v1alpha1.AnnotationRetainedFrom, so that a future installation of the package can adopt them again.
+//
+// Resources that are part of a Helm chart are deleted by Helm itself and can not be retained this way. Only the
+// volume claims of StatefulSets are kept in this case.
+func (obj *uninstaller) retainResources(ctx context.Context, pkg ctrlpkg.Package, isDryRun bool) error {
+	if obj.retain.IsEmpty() {
+		return nil
+	}
+	if obj.k8sClient == nil {
+		return fmt.Errorf("cannot retain resources of %v: no kubernetes client configured", pkg.GetName())
+	}
+	obj.status.SetStatus(fmt.Sprintf("Retaining resources of %v...", pkg.GetName()))
+
+	statefulSets, err := obj.listStatefulSets(ctx, pkg)
+	if err != nil {
+		return err
+	}
+	if obj.retain.PersistentVolumeClaims {
+		for _, sts := range statefulSets {
+			if err := obj.retainStatefulSetClaims(ctx, &sts, isDryRun); err != nil {
+				return err
+			}
+		}
+		if err := obj.retainVolumeClaims(ctx, pkg, statefulSets, isDryRun); err != nil {
+			return err
+		}
+	}
+	if obj.retain.Secrets {
+		if err := obj.retainSecrets(ctx, pkg, isDryRun); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (obj *uninstaller) listStatefulSets(ctx context.Context, pkg ctrlpkg.Package) ([]appsv1.StatefulSet, error) {
+	list, err := obj.k8sClient.AppsV1().StatefulSets(pkg.GetNamespace()).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("cannot list statefulsets: %w", err)
+	}
+	var result []appsv1.StatefulSet
+	for _, sts := range list.Items {
+		if workloads.BelongsTo(pkg, workloads.KindStatefulSet, &sts) {
+			result = append(result, sts)
+		}
+	}
+	return result, nil
+}
+
+// retainStatefulSetClaims changes the retention policy of sts, so that its controller does not delete the volume
+// claims together with the StatefulSet
+func (obj *uninstaller) retainStatefulSetClaims(ctx context.Context, sts *appsv1.StatefulSet, isDryRun bool) error {
+	policy := sts.Spec.PersistentVolumeClaimRetentionPolicy
+	if policy == nil || policy.WhenDeleted != appsv1.DeletePersistentVolumeClaimRetentionPolicyType {
+		return nil
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		client := obj.k8sClient.AppsV1().StatefulSets(sts.Namespace)
+		current, err := client.Get(ctx, sts.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		current.Spec.PersistentVolumeClaimRetentionPolicy.WhenDeleted =
+			appsv1.RetainPersistentVolumeClaimRetentionPolicyType
+		_, err = client.Update(ctx, current, updateOptions(isDryRun))
+		return err
+	})
+}
+
+func (obj *uninstaller) retainVolumeClaims(
+	ctx context.Context,
+	pkg ctrlpkg.Package,
+	statefulSets []appsv1.StatefulSet,
+	isDryRun bool,
+) error {
+	client := obj.k8sClient.CoreV1().PersistentVolumeClaims(pkg.GetNamespace())
+	list, err := client.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("cannot list persistent volume claims: %w", err)
+	}
+	for _, item := range list.Items {
+		if !belongsTo(pkg, "PersistentVolumeClaim", &item) && !isStatefulSetClaim(statefulSets, &item) {
+			continue
+		}
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			current, err := client.Get(ctx, item.Name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			markRetained(pkg, statefulSets, current)
+			_, err = client.Update(ctx, current, updateOptions(isDryRun))
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("cannot retain persistent volume claim %v: %w", item.Name, err)
+		}
+	}
+	return nil
+}
+
+func (obj *uninstaller) retainSecrets(ctx context.Context, pkg ctrlpkg.Package, isDryRun bool) error {
+	client := obj.k8sClient.CoreV1().Secrets(pkg.GetNamespace())
+	list, err := client.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("cannot list secrets: %w", err)
+	}
+	for _, item := range list.Items {
+		if !belongsTo(pkg, "Secret", &item) {
+			continue
+		}
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			current, err := client.Get(ctx, item.Name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			markRetained(pkg, nil, current)
+			_, err = client.Update(ctx, current, updateOptions(isDryRun))
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("cannot retain secret %v: %w", item.Name, err)
+		}
+	}
+	return nil
+}
+
+// belongsTo reports whether the core resource obj of the given kind is owned by pkg, or was created by it
+func belongsTo(pkg ctrlpkg.Package, kind string, obj metav1.Object) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == pkg.GetUID() {
+			return true
+		}
+	}
+	for _, ref := range pkg.GetStatus().OwnedResources {
+		if ref.Group == corev1.GroupName && ref.Kind == kind &&
+			ref.Namespace == obj.GetNamespace() && ref.Name == obj.GetName() {
+			return true
+		}
+	}
+	return workloads.BelongsTo(pkg, kind, obj)
+}
+
+// isStatefulSetClaim reports whether obj was created from the volume claim template of one of the given StatefulSets.
+// These claims are named "<template>-<statefulset>-<ordinal>".
+func isStatefulSetClaim(statefulSets []appsv1.StatefulSet, obj metav1.Object) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if isStatefulSetRef(statefulSets, ref) {
+			return true
+		}
+	}
+	for _, sts := range statefulSets {
+		for _, tmpl := range sts.Spec.VolumeClaimTemplates {
+			if strings.HasPrefix(obj.GetName(), tmpl.Name+"-"+sts.Name+"-") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isStatefulSetRef(statefulSets []appsv1.StatefulSet, ref metav1.OwnerReference) bool {
+	for _, sts := range statefulSets {
+		if ref.UID == sts.UID {
+			return true
+		}
+	}
+	return false
+}
+
+// markRetained removes all owner references of obj that would cause its deletion together with pkg and annotates it
+// with the package it was retained from
+func markRetained(pkg ctrlpkg.Package, statefulSets []appsv1.StatefulSet, obj metav1.Object) {
+	var refs []metav1.OwnerReference
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID != pkg.GetUID() && !isStatefulSetRef(statefulSets, ref) {
+			refs = append(refs, ref)
+		}
+	}
+	obj.SetOwnerReferences(refs)
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[v1alpha1.AnnotationRetainedFrom] = cache.MetaObjectToName(pkg).String()
+	obj.SetAnnotations(annotations)
+}
+
+func updateOptions(isDryRun bool) metav1.UpdateOptions {
+	if isDryRun {
+		return metav1.UpdateOptions{DryRun: []string{metav1.DryRunAll}}
+	}
+	return metav1.UpdateOptions{}
+}
@@ -12,11 +12,14 @@ import (
 	"github.com/glasskube/glasskube/pkg/statuswriter"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
 )
 
 type uninstaller struct {
-	client client.PackageV1Alpha1Client
-	status statuswriter.StatusWriter
+	client    client.PackageV1Alpha1Client
+	k8sClient kubernetes.Interface
+	retain    RetainOptions
+	status    statuswriter.StatusWriter
 }
 
 func NewUninstaller(pkgClient client.PackageV1Alpha1Client) *uninstaller {
@@ -28,6 +31,13 @@ func (obj *uninstaller) WithStatusWriter(sw statuswriter.StatusWriter) *uninstal
 	return obj
 }
 
+// WithRetention configures the uninstaller to keep the given resources of the package in the cluster
+func (obj *uninstaller) WithRetention(k8sClient kubernetes.Interface, options RetainOptions) *uninstaller {
+	obj.k8sClient = k8sClient
+	obj.retain = options
+	return obj
+}
+
 // UninstallBlocking deletes the v1alpha1.Package custom resource from the
 // cluster and waits until the package is fully deleted.
 func (obj *uninstaller) UninstallBlocking(ctx context.Context, pkg ctrlpkg.Package, isDryRun bool) error {
@@ -59,6 +69,9 @@ func (uninstaller *uninstaller) delete(ctx context.Context, pkg ctrlpkg.Package,
 	if isDryRun {
 		deleteOptions.DryRun = []string{metav1.DryRunAll}
 	}
+	if err := uninstaller.retainResources(ctx, pkg, isDryRun); err != nil {
+		return err
+	}
 	uninstaller.status.SetStatus(fmt.Sprintf("Uninstalling %v...", pkg.GetName()))
 
 	switch pkg := pkg.(type) {
@@ -4,14 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/glasskube/glasskube/api/v1alpha1"
 	"github.com/glasskube/glasskube/internal/cliutils"
 	"github.com/glasskube/glasskube/internal/controller/ctrlpkg"
 	"github.com/glasskube/glasskube/internal/dependency"
+	deputil "github.com/glasskube/glasskube/internal/dependency/util"
 	"github.com/glasskube/glasskube/internal/repo"
 	repoclient "github.com/glasskube/glasskube/internal/repo/client"
 	"github.com/glasskube/glasskube/internal/semver"
+	"github.com/glasskube/glasskube/internal/tracing"
 	"github.com/glasskube/glasskube/pkg/client"
 	"github.com/glasskube/glasskube/pkg/condition"
 	"github.com/glasskube/glasskube/pkg/statuswriter"
@@ -82,6 +85,12 @@ func (c *updater) PrepareForVersion(
 	if !semver.IsUpgradable(pkg.GetSpec().PackageInfo.Version, pkgVersion) {
 		return nil, fmt.Errorf("can't update to downgraded version or equal version")
 	}
+	if constraint := pkg.VersionConstraint(); constraint != "" {
+		if err := semver.ValidateConstraint(pkgVersion, constraint); err != nil {
+			return nil, fmt.Errorf("version %v does not satisfy the version constraint %v of %v: %w",
+				pkgVersion, constraint, pkg.GetName(), err)
+		}
+	}
 
 	c.status.SetStatus("Updating package index")
 
@@ -91,7 +100,9 @@ func (c *updater) PrepareForVersion(
 	if err := c.repoClient.ForPackage(pkg).
 		FetchPackageManifest(pkg.GetSpec().PackageInfo.Name, pkgVersion, &manifest); err != nil {
 		return nil, err
-	} else if result, err := c.dm.Validate(ctx, pkg.GetName(), pkg.GetNamespace(), &manifest, pkgVersion); err != nil {
+	}
+	manifest = deputil.WithEnabledDependencies(manifest, pkg.GetSpec().OptionalDependencies)
+	if result, err := c.dm.Validate(ctx, pkg.GetName(), pkg.GetNamespace(), &manifest, pkgVersion); err != nil {
 		return nil, err
 	} else if len(result.Conflicts) > 0 {
 		tx.ConflictItems = append(tx.ConflictItems, updateTransactionItemConflict{item, result.Conflicts})
@@ -137,15 +148,26 @@ outer:
 
 		for _, indexItem := range index.Packages {
 			if indexItem.Name == pkg.GetSpec().PackageInfo.Name {
-				if semver.IsUpgradable(pkg.GetSpec().PackageInfo.Version, indexItem.LatestVersion) {
-					item := updateTransactionItem{Package: pkg, Version: indexItem.LatestVersion}
+				latestVersion := indexItem.LatestVersion
+				if constraint := pkg.VersionConstraint(); constraint != "" {
+					// packages with a version constraint are updated to the highest version within the constraint
+					v, err := latestVersionWithConstraint(repoClient, pkg.GetSpec().PackageInfo.Name, constraint)
+					if err != nil {
+						return nil, err
+					}
+					latestVersion = v
+				}
+				if latestVersion != "" && semver.IsUpgradableWithConstraint(
+					pkg.GetSpec().PackageInfo.Version, latestVersion, pkg.VersionConstraint()) {
+					item := updateTransactionItem{Package: pkg, Version: latestVersion}
 					var manifest v1alpha1.PackageManifest
 					if err := repoClient.FetchPackageManifest(
-						pkg.GetSpec().PackageInfo.Name, indexItem.LatestVersion, &manifest); err != nil {
+						pkg.GetSpec().PackageInfo.Name, latestVersion, &manifest); err != nil {
 						return nil, err
 					}
+					manifest = deputil.WithEnabledDependencies(manifest, pkg.GetSpec().OptionalDependencies)
 					if result, err := c.dm.Validate(ctx, pkg.GetName(), pkg.GetNamespace(),
-						&manifest, indexItem.LatestVersion); err != nil {
+						&manifest, latestVersion); err != nil {
 						return nil, err
 					} else if len(result.Conflicts) > 0 {
 						// This package can't be updated due to conflicts
@@ -177,9 +199,28 @@ outer:
 	return &tx, nil
 }
 
+// latestVersionWithConstraint returns the highest version of a package that satisfies the given constraint or an
+// empty string, if there is no such version.
+func latestVersionWithConstraint(repoClient repoclient.RepoClient, name string, constraint string) (string, error) {
+	var idx repo.PackageIndex
+	if err := repoClient.FetchPackageIndex(name, &idx); err != nil {
+		return "", fmt.Errorf("failed to fetch package index of %v: %w", name, err)
+	}
+	versions := make([]string, len(idx.Versions))
+	for i, item := range idx.Versions {
+		versions[i] = item.Version
+	}
+	return semver.LatestVersionWithConstraint(versions, constraint)
+}
+
+// ErrAwaitTimeout is returned by Apply if a package has not become ready within ApplyUpdateOptions.AwaitTimeout
+var ErrAwaitTimeout = errors.New("timed out waiting for the package to become ready")
+
 type ApplyUpdateOptions struct {
 	Blocking bool
 	DryRun   bool
+	// AwaitTimeout limits the time to wait for every updated package, if Blocking is set. Zero means no limit.
+	AwaitTimeout time.Duration
 }
 
 func (c *updater) Apply(
@@ -202,7 +243,7 @@ func (c *updater) Apply(
 			}
 			if opts.Blocking {
 				c.status.SetStatus(fmt.Sprintf("Checking %v", item.Package.GetName()))
-				if err := c.awaitUpdate(ctx, item.Package); err != nil {
+				if err := c.awaitUpdate(ctx, item.Package, opts.AwaitTimeout); err != nil {
 					return nil, fmt.Errorf("package update for %v failed: %w", item.Package.GetName(), err)
 				}
 			}
@@ -217,7 +258,8 @@ func (c *updater) UpdatePackage(ctx context.Context, pkg ctrlpkg.Package, versio
 	if DryRun {
 		opts.DryRun = []string{metav1.DryRunAll}
 	}
-	pkg.GetSpec().PackageInfo.Version = version
+	pkg.GetSpec().PackageInfo.SetVersion(version)
+	tracing.Inject(ctx, pkg)
 	switch pkg := pkg.(type) {
 	case *v1alpha1.ClusterPackage:
 		return c.client.ClusterPackages().Update(ctx, pkg, opts)
@@ -228,28 +270,46 @@ func (c *updater) UpdatePackage(ctx context.Context, pkg ctrlpkg.Package, versio
 	}
 }
 
-func (c *updater) awaitUpdate(ctx context.Context, pkg ctrlpkg.Package) error {
+func (c *updater) awaitUpdate(ctx context.Context, pkg ctrlpkg.Package, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
 	switch pkg := pkg.(type) {
 	case *v1alpha1.ClusterPackage:
 		watcher, err := c.client.ClusterPackages().Watch(ctx, metav1.ListOptions{})
 		if err != nil {
 			return err
 		}
-		return c.await(watcher, pkg)
+		return c.await(ctx, watcher, pkg)
 	case *v1alpha1.Package:
 		watcher, err := c.client.Packages(pkg.Namespace).Watch(ctx, metav1.ListOptions{})
 		if err != nil {
 			return err
 		}
-		return c.await(watcher, pkg)
+		return c.await(ctx, watcher, pkg)
 	default:
 		return fmt.Errorf("unexpected object kind: %v", pkg.GroupVersionKind().Kind)
 	}
 }
 
-func (c *updater) await(watcher watch.Interface, pkg ctrlpkg.Package) error {
+func (c *updater) await(ctx context.Context, watcher watch.Interface, pkg ctrlpkg.Package) error {
 	defer watcher.Stop()
-	for event := range watcher.ResultChan() {
+	for {
+		var event watch.Event
+		var ok bool
+		select {
+		case <-ctx.Done():
+			return awaitError(ctx)
+		case event, ok = <-watcher.ResultChan():
+		}
+		if !ok {
+			if ctx.Err() != nil {
+				return awaitError(ctx)
+			}
+			return errors.New("watch closed unexpectedly")
+		}
 		if eventPkg, ok := event.Object.(ctrlpkg.Package); ok && eventPkg.GetUID() == pkg.GetUID() {
 			if eventPkg.GetStatus().Version == eventPkg.GetSpec().PackageInfo.Version {
 				return nil
@@ -263,5 +323,11 @@ func (c *updater) await(watcher watch.Interface, pkg ctrlpkg.Package) error {
 			}
 		}
 	}
-	return errors.New("watch closed unexpectedly")
+}
+
+func awaitError(ctx context.Context) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return ErrAwaitTimeout
+	}
+	return ctx.Err()
 }
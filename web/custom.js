@@ -1,12 +1,37 @@
 (() => {
   const getColorSchemeQuery = () =>
     window.matchMedia('(prefers-color-scheme: dark)');
+  // an explicit choice is rendered by the server, otherwise fall back to prefers-color-scheme
   const getPreferredTheme = () =>
-    getColorSchemeQuery().matches ? 'dark' : 'light';
+    document.body.dataset.preferredTheme ||
+    (getColorSchemeQuery().matches ? 'dark' : 'light');
   const setPreferredTheme = () =>
     document.body.setAttribute('data-bs-theme', getPreferredTheme());
+  const updateThemeSelectors = () => {
+    const theme = document.body.dataset.preferredTheme || '';
+    document.querySelectorAll('[data-theme-value]').forEach((elem) => {
+      const selected = elem.dataset.themeValue === theme;
+      elem.classList.toggle('active', selected && !elem.matches('input'));
+      if (elem.matches('input')) {
+        elem.checked = selected;
+      }
+    });
+  };
   setPreferredTheme();
   getColorSchemeQuery().addEventListener('change', () => setPreferredTheme());
+  document.body.addEventListener('click', (evt) => {
+    const elem = evt.target.closest('[data-theme-value]');
+    if (elem) {
+      document.body.dataset.preferredTheme = elem.dataset.themeValue;
+      setPreferredTheme();
+      updateThemeSelectors();
+    }
+  });
+  // make sure partial refreshes (e.g. triggered via SSE) never reset the theme
+  document.body.addEventListener('htmx:afterSettle', () => {
+    setPreferredTheme();
+    updateThemeSelectors();
+  });
 })();
 
 (() => {
@@ -26,10 +51,309 @@
 (() => {
   const modal = document.getElementById('modal-container');
   modal.addEventListener('show.bs.modal', (evt) => {
+    if (modal.dataset.keepContent) {
+      delete modal.dataset.keepContent;
+      return;
+    }
     // https://getbootstrap.com/docs/5.3/components/modal/#events
     // "hidden.bs.modal" is too early to clear innerHTML – the form submission from inside the modal would be cancelled
     modal.innerHTML = '';
   });
+  // the server triggers show-modal after it has swapped content into the modal, e.g. to confirm a settings change
+  document.body.addEventListener('show-modal', () => {
+    modal.dataset.keepContent = 'true';
+    bootstrap.Modal.getOrCreateInstance(modal).show();
+  });
+})();
+
+(() => {
+  // forms in a modal with the data-close-modal-on-success attribute close the modal after a successful request
+  document.body.addEventListener('htmx:afterRequest', (evt) => {
+    if (
+      evt.detail.successful &&
+      evt.detail.elt.closest('[data-close-modal-on-success]')
+    ) {
+      document
+        .querySelector('#modal-container [data-bs-dismiss="modal"]')
+        ?.click();
+    }
+  });
+})();
+
+(() => {
+  // keyboard shortcuts: "/" focuses the search, "j"/"k" move between package cards, "Enter" opens the focused card
+  const isTyping = (elem) =>
+    elem instanceof HTMLInputElement ||
+    elem instanceof HTMLTextAreaElement ||
+    elem instanceof HTMLSelectElement ||
+    elem?.isContentEditable;
+  const focusCard = (offset) => {
+    const cards = [...document.querySelectorAll('[data-package-card]')];
+    if (cards.length === 0) {
+      return;
+    }
+    const current = cards.indexOf(document.activeElement);
+    const next =
+      current < 0
+        ? offset > 0
+          ? 0
+          : cards.length - 1
+        : Math.min(Math.max(current + offset, 0), cards.length - 1);
+    cards[next].focus();
+    cards[next].scrollIntoView({ block: 'nearest' });
+  };
+  document.addEventListener('keydown', (evt) => {
+    if (
+      evt.defaultPrevented ||
+      evt.ctrlKey ||
+      evt.metaKey ||
+      evt.altKey ||
+      document.body.classList.contains('modal-open')
+    ) {
+      return;
+    }
+    if (isTyping(evt.target)) {
+      if (evt.key === 'Escape' && evt.target.id === 'package-search') {
+        evt.target.blur();
+      }
+      return;
+    }
+    switch (evt.key) {
+      case '/': {
+        const search = document.getElementById('package-search');
+        if (search) {
+          evt.preventDefault();
+          search.focus();
+          search.select();
+        }
+        break;
+      }
+      case 'j':
+        evt.preventDefault();
+        focusCard(1);
+        break;
+      case 'k':
+        evt.preventDefault();
+        focusCard(-1);
+        break;
+      // "Enter" needs no handling: the focused card is a link and is opened by the browser
+    }
+  });
+})();
+
+(() => {
+  // command palette: Ctrl-K / Cmd-K opens a search over pages, packages and actions, which is ranked by the server
+  const palette = document.getElementById('command-palette');
+  const input = document.getElementById('command-palette-input');
+  const results = document.getElementById('command-palette-results');
+  let items = [];
+  let active = 0;
+  let request;
+  let debounce;
+
+  const setActive = (index) => {
+    const elems = results.querySelectorAll('[role="option"]');
+    if (elems.length === 0) {
+      return;
+    }
+    active = (index + elems.length) % elems.length;
+    elems.forEach((elem, i) => {
+      elem.classList.toggle('active', i === active);
+      elem.setAttribute('aria-selected', i === active);
+    });
+    elems[active].scrollIntoView({ block: 'nearest' });
+    input.setAttribute('aria-activedescendant', elems[active].id);
+  };
+  const renderMessage = (message) => {
+    const elem = document.createElement('div');
+    elem.className = 'list-group-item text-body-secondary';
+    elem.textContent = message;
+    results.replaceChildren(elem);
+  };
+  const render = () => {
+    if (items.length === 0) {
+      renderMessage(palette.dataset.noMatch);
+      return;
+    }
+    results.replaceChildren(
+      ...items.map((item, i) => {
+        const elem = document.createElement('a');
+        elem.id = `command-palette-item-${i}`;
+        elem.className =
+          'list-group-item list-group-item-action d-flex align-items-center gap-2';
+        elem.href = item.href;
+        elem.setAttribute('role', 'option');
+        elem.addEventListener('click', (evt) => {
+          evt.preventDefault();
+          select(item);
+        });
+        elem.addEventListener('mousemove', () => active !== i && setActive(i));
+        const icon = document.createElement('span');
+        icon.className = `bi ${item.icon || 'bi-dot'}`;
+        const text = document.createElement('span');
+        text.className = 'flex-grow-1 text-truncate';
+        text.textContent = item.title;
+        if (item.subtitle) {
+          const subtitle = document.createElement('small');
+          subtitle.className = 'd-block text-body-secondary text-truncate';
+          subtitle.textContent = item.subtitle;
+          text.append(subtitle);
+        }
+        elem.append(icon, text);
+        if (item.installed && item.kind === 'package') {
+          const badge = document.createElement('span');
+          badge.className = 'bi bi-check-circle-fill text-success';
+          elem.append(badge);
+        }
+        return elem;
+      }),
+    );
+    setActive(0);
+  };
+  const search = () => {
+    request?.abort();
+    request = new AbortController();
+    fetch(`/api/v1/palette?q=${encodeURIComponent(input.value)}`, {
+      signal: request.signal,
+      headers: { Accept: 'application/json' },
+    })
+      .then((response) => {
+        if (!response.ok) {
+          throw new Error(response.statusText);
+        }
+        return response.json();
+      })
+      .then((data) => {
+        items = data.items;
+        render();
+      })
+      .catch((err) => {
+        if (err.name !== 'AbortError') {
+          items = [];
+          renderMessage(palette.dataset.failed);
+        }
+      });
+  };
+  const select = (item) => {
+    bootstrap.Modal.getOrCreateInstance(palette).hide();
+    if (item.modal) {
+      bootstrap.Modal.getOrCreateInstance(
+        document.getElementById('modal-container'),
+      ).show();
+      htmx.ajax('GET', item.modal, {
+        target: '#modal-container',
+        select: item.modalSelect,
+        swap: 'innerHTML',
+      });
+    } else {
+      htmx.ajax('GET', item.href, {
+        target: 'main',
+        select: 'main',
+        swap: 'outerHTML',
+      }).then(() => history.pushState({}, '', item.href));
+    }
+  };
+  const open = () => {
+    input.value = '';
+    bootstrap.Modal.getOrCreateInstance(palette).show();
+    search();
+  };
+
+  palette.addEventListener('shown.bs.modal', () => input.focus());
+  input.addEventListener('input', () => {
+    clearTimeout(debounce);
+    debounce = setTimeout(search, 150);
+  });
+  input.addEventListener('keydown', (evt) => {
+    switch (evt.key) {
+      case 'ArrowDown':
+        evt.preventDefault();
+        setActive(active + 1);
+        break;
+      case 'ArrowUp':
+        evt.preventDefault();
+        setActive(active - 1);
+        break;
+      case 'Enter':
+        evt.preventDefault();
+        if (items[active]) {
+          select(items[active]);
+        }
+        break;
+    }
+  });
+  document.addEventListener('keydown', (evt) => {
+    if ((evt.ctrlKey || evt.metaKey) && evt.key.toLowerCase() === 'k') {
+      evt.preventDefault();
+      if (!palette.classList.contains('show')) {
+        open();
+      }
+    }
+  });
+  document.body.addEventListener('click', (evt) => {
+    if (evt.target.closest('[data-command-palette-open]')) {
+      open();
+    }
+  });
+})();
+
+(() => {
+  // content is loaded into the modal after it has been opened, so the initial focus has to be moved into the new
+  // content. Focus is kept inside the modal by bootstrap's focus trap.
+  const modal = document.getElementById('modal-container');
+  const focusModalContent = () => {
+    const title = modal.querySelector('.modal-title');
+    if (title) {
+      modal.setAttribute('aria-label', title.textContent.trim());
+    }
+    const elem =
+      modal.querySelector('[autofocus]') ||
+      modal.querySelector(
+        '.modal-body :is(input, select, textarea, button, a[href]):not([disabled])',
+      ) ||
+      modal.querySelector('.modal-footer button:not([disabled])');
+    elem?.focus();
+  };
+  modal.addEventListener('shown.bs.modal', focusModalContent);
+  document.body.addEventListener('htmx:afterSettle', (evt) => {
+    if (evt.detail.target === modal) {
+      focusModalContent();
+    }
+  });
+})();
+
+(() => {
+  // toasts with a duration are removed after it has elapsed. The timer restarts while the pointer or the focus is on
+  // the toast, so that it does not disappear while it is being read.
+  const scheduleDismiss = (elem) => {
+    const duration = Number(elem.dataset.toastDuration);
+    let timeout;
+    const start = () => {
+      clearTimeout(timeout);
+      timeout = setTimeout(() => {
+        elem.classList.remove('show');
+        elem.remove();
+      }, duration);
+    };
+    const stop = () => clearTimeout(timeout);
+    elem.addEventListener('mouseenter', stop);
+    elem.addEventListener('focusin', stop);
+    elem.addEventListener('mouseleave', start);
+    elem.addEventListener('focusout', start);
+    start();
+  };
+  document.body.addEventListener('htmx:load', (evt) => {
+    const elt = evt.detail.elt;
+    if (!(elt instanceof Element)) {
+      return;
+    }
+    if (elt.matches('.toast[data-toast-duration]')) {
+      scheduleDismiss(elt);
+    }
+    elt
+      .querySelectorAll('.toast[data-toast-duration]')
+      .forEach(scheduleDismiss);
+  });
 })();
 
 function setSSEDisconnected() {
@@ -47,6 +371,79 @@ document.addEventListener('htmx:sseClose', function (evt) {
   setSSEDisconnected();
 });
 
+// Refreshes of the package detail page are received via websocket, so that the page only receives the refresh ids
+// it shows. Elements subscribe with hx-trigger="ws:<id>", like they do with "sse:<id>" for server sent events.
+(function () {
+  const maxRetryDelay = 30000;
+  let socket;
+  let retryDelay = 1000;
+  let subscribed = new Set();
+
+  const currentIds = () => {
+    const ids = new Set();
+    document.querySelectorAll('[hx-trigger*="ws:"]').forEach((elem) => {
+      for (const match of elem.getAttribute('hx-trigger').matchAll(/ws:([^\s,[]+)/g)) {
+        ids.add(match[1]);
+      }
+    });
+    return ids;
+  };
+  const trigger = (id) =>
+    document
+      .querySelectorAll(`[hx-trigger*="ws:${CSS.escape(id)}"]`)
+      .forEach((elem) => htmx.trigger(elem, `ws:${id}`));
+  const sync = () => {
+    if (socket?.readyState !== WebSocket.OPEN) {
+      return;
+    }
+    const ids = currentIds();
+    const added = [...ids].filter((id) => !subscribed.has(id));
+    const removed = [...subscribed].filter((id) => !ids.has(id));
+    if (added.length > 0) {
+      socket.send(JSON.stringify({ type: 'subscribe', ids: added }));
+    }
+    if (removed.length > 0) {
+      socket.send(JSON.stringify({ type: 'unsubscribe', ids: removed }));
+    }
+    subscribed = ids;
+  };
+  const connect = (isReconnect) => {
+    const protocol = location.protocol === 'https:' ? 'wss:' : 'ws:';
+    socket = new WebSocket(`${protocol}//${location.host}/ws`);
+    socket.addEventListener('open', () => {
+      retryDelay = 1000;
+      subscribed = new Set();
+      sync();
+      if (isReconnect) {
+        // refreshes might have been missed while disconnected
+        subscribed.forEach(trigger);
+      }
+    });
+    socket.addEventListener('message', (evt) => {
+      const msg = JSON.parse(evt.data);
+      if (msg.type === 'refresh') {
+        trigger(msg.id);
+      } else if (msg.type === 'reconnect' && msg.retry) {
+        retryDelay = msg.retry;
+      }
+    });
+    socket.addEventListener('close', () => {
+      const delay = retryDelay + Math.random() * 1000;
+      retryDelay = Math.min(retryDelay * 2, maxRetryDelay);
+      setTimeout(() => connect(true), delay);
+    });
+  };
+  const init = () => {
+    if (!socket && currentIds().size > 0) {
+      connect(false);
+    } else {
+      sync();
+    }
+  };
+  document.addEventListener('DOMContentLoaded', init);
+  document.addEventListener('htmx:afterSettle', init);
+})();
+
 window.giscusReported = false;
 function handleGiscusMessage(ev) {
   if (window.giscusReported) return;
@@ -64,6 +461,9 @@ function handleGiscusMessage(ev) {
     formData.append('githubUrl', githubUrl);
     fetch('', {
       method: 'POST',
+      headers: {
+        'X-CSRF-Token': document.querySelector('meta[name="csrf-token"]').content,
+      },
       body: formData,
     });
     window.giscusReported = true;
@@ -2,5 +2,5 @@ import 'htmx.org/dist/htmx.esm';
 import './windowHtmx';
 import 'htmx-ext-sse';
 import 'htmx-ext-response-targets';
-import 'bootstrap';
+import './windowBootstrap';
 import 'giscus';
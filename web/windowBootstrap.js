@@ -0,0 +1,3 @@
+// This file is required to provide the global bootstrap variable, e.g. to open modals from custom scripts.
+import * as bootstrap from 'bootstrap';
+window.bootstrap = bootstrap;